@@ -4,4 +4,5 @@ go-bindata -pkg="generator" -o="generator/bindata.go" templates
 go fmt generator/*.go
 go build
 #pushd form2curl/form2curl; go build; popd
+pushd go2curl/go2curl; go build; popd
 pushd webui; gopherjs build; popd
@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/shell"
+	"net/url"
+	"strings"
+)
+
+/*
+	Generators of command line tools build a command line instead of a program.
+	Command is passed to httpgen as a template context and it returns the source code by itself.
+*/
+type Command struct {
+	Options *common.CurlOptions
+	Args    []string
+}
+
+func (self Command) SourceCode() string {
+	return strings.Join(self.Args, " ")
+}
+
+func (self *Command) add(args ...string) {
+	self.Args = append(self.Args, args...)
+}
+
+/*
+	Shell words. Adjacent quoted strings are concatenated by shell.
+*/
+func literal(src string) string {
+	return shell.Escape(src)
+}
+
+func substitution(command string) string {
+	return fmt.Sprintf("\"$(%s)\"", command)
+}
+
+/*
+	Same rule of curl_easy_escape(): only unreserved characters are kept.
+*/
+func escape(src string) string {
+	var buffer bytes.Buffer
+	for _, c := range []byte(src) {
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || c == '-' || c == '.' || c == '_' || c == '~' {
+			buffer.WriteByte(c)
+		} else {
+			fmt.Fprintf(&buffer, "%%%02X", c)
+		}
+	}
+	return buffer.String()
+}
+
+/*
+	URL encoded "content", "=content" and "name@filename" of --data-urlencode can't be a pair of form.
+*/
+func canUseFormPairs(options *common.CurlOptions) bool {
+	if !options.CanUseSimpleForm() {
+		return false
+	}
+	for _, data := range options.ProcessedData {
+		if data.Type == common.DataUrlEncodeType {
+			index := strings.IndexAny(data.Value, "=@")
+			if index < 1 || data.Value[index] == '@' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func formPairs(options *common.CurlOptions) [][]string {
+	var result [][]string
+	for _, data := range options.ProcessedData {
+		for _, pair := range strings.Split(data.Value, "&") {
+			if pair == "" {
+				continue
+			}
+			fragments := strings.SplitN(pair, "=", 2)
+			key, value := fragments[0], fragments[1]
+			if data.Type != common.DataUrlEncodeType {
+				key, _ = url.QueryUnescape(key)
+				value, _ = url.QueryUnescape(value)
+			}
+			result = append(result, []string{key, value})
+		}
+	}
+	return result
+}
+
+/*
+	Shell word of the request body. Files are read by command substitution.
+	It returns false when the body can't be expressed in shell.
+*/
+func shellBody(options *common.CurlOptions, tool string) (string, bool, error) {
+	var words []string
+	for i, data := range options.ProcessedData {
+		var word string
+		switch data.Type {
+		case common.DataAsciiType:
+			if data.UseExternalFile() {
+				word = substitution(fmt.Sprintf("tr -d '\\r\\n' < %s", literal(data.Value[1:])))
+			} else {
+				word = literal(data.Value)
+			}
+		case common.DataBinaryType:
+			if data.UseExternalFile() {
+				word = substitution(fmt.Sprintf("cat %s", literal(data.Value[1:])))
+			} else {
+				word = literal(data.Value)
+			}
+		case common.DataUrlEncodeType:
+			// curl's rule: "content", "=content", "name=content", "@filename" and "name@filename"
+			index := strings.IndexAny(data.Value, "=@")
+			if index != -1 && data.Value[index] == '@' {
+				options.AddWarning("%s can't URL encode file content. --data-urlencode %s is ignored.", tool, data.Value)
+				return "", false, nil
+			} else if index == -1 {
+				word = literal(escape(data.Value))
+			} else if index == 0 {
+				word = literal(escape(data.Value[1:]))
+			} else {
+				word = literal(data.Value[:index+1] + escape(data.Value[index+1:]))
+			}
+		default:
+			return "", false, common.UnexpectedDataError(strings.ToLower(tool), &data)
+		}
+		if i > 0 {
+			words = append(words, "'&'")
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, ""), true, nil
+}
+
+/*
+	Returns file name when the body is a single file sent as is.
+*/
+func singleFile(options *common.CurlOptions) string {
+	if len(options.ProcessedData) != 1 {
+		return ""
+	}
+	data := &options.ProcessedData[0]
+	if data.Type == common.DataBinaryType && data.UseExternalFile() {
+		return data.Value[1:]
+	}
+	return ""
+}
+
+/*
+	Parse -F value into field name, file name to read, file name to send, content type and whether it is a file or not.
+*/
+func formField(data *common.DataOption) (name, fileName, sentFileName, contentType string, isFile bool, isText bool) {
+	field := strings.SplitN(data.Value, "=", 2)
+	name = field[0]
+	if data.Type != common.FormType || !(strings.HasPrefix(field[1], "@") || strings.HasPrefix(field[1], "<")) {
+		return name, "", "", "", false, false
+	}
+	fragments := strings.Split(field[1][1:], ";")
+	fileName = fragments[0]
+	sentFileName = fragments[0]
+	for _, fragment := range fragments[1:] {
+		if strings.HasPrefix(fragment, "filename=") {
+			sentFileName = fragment[9:]
+		} else if strings.HasPrefix(fragment, "type=") {
+			contentType = fragment[5:]
+		}
+	}
+	return name, fileName, sentFileName, contentType, field[1][0] == '@', field[1][0] == '<'
+}
+
+/*
+	Accept-Encoding headers added by --compressed. Tools decode response by themselves.
+*/
+func isCompressionHeader(header []string) bool {
+	if strings.ToLower(header[0]) != "accept-encoding" {
+		return false
+	}
+	for _, encoding := range strings.Split(header[1], ",") {
+		encoding = strings.TrimSpace(encoding)
+		if encoding != "gzip" && encoding != "deflate" {
+			return false
+		}
+	}
+	return true
+}
+
+func proxyUrl(options *common.CurlOptions) *url.URL {
+	proxy := options.Proxy
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	u, _ := url.Parse(proxy)
+	if u.Port() == "" {
+		// curl uses 1080 as default proxy port
+		u.Host = u.Host + ":1080"
+	}
+	return u
+}
@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"github.com/shibukawa/curl_as_dsl/common"
+)
+
+/*
+	curl itself. It is useful to normalize commands copied from browsers.
+*/
+type CurlGenerator struct {
+	Options *common.CurlOptions
+	Style   common.CurlStyle
+}
+
+func (self CurlGenerator) SourceCode() string {
+	return self.Options.ToCurl(self.Style)
+}
+
+func ProcessCurlCommandForCurl(options *common.CurlOptions, style common.CurlStyle) (string, interface{}, error) {
+	return "", CurlGenerator{Options: options, Style: style}, nil
+}
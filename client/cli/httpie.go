@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"math"
+	"strings"
+)
+
+/*
+	Separators in request item keys are escaped by backslash.
+*/
+func httpieKey(src string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", ":", "\\:", "=", "\\=", "@", "\\@")
+	return replacer.Replace(src)
+}
+
+/*
+	HTTPie: http [flags] [METHOD] URL [REQUEST_ITEM ...]
+	Request items are "Header:value", "field=value", "field=@file" (file content as text),
+	"field@file" (file upload) and "param==value".
+*/
+type HttpieGenerator struct {
+	Command
+	flags   []string
+	url     string
+	items   []string
+	stdin   string
+	hasBody bool
+}
+
+func NewHttpieGenerator(options *common.CurlOptions) *HttpieGenerator {
+	result := &HttpieGenerator{}
+	result.Options = options
+	result.url = literal(options.Url)
+	return result
+}
+
+func (self *HttpieGenerator) SetDataForUrl() error {
+	if canUseFormPairs(self.Options) {
+		for _, pair := range formPairs(self.Options) {
+			self.items = append(self.items, literal(httpieKey(pair[0])+"=="+pair[1]))
+		}
+		return nil
+	}
+	body, ok, err := shellBody(self.Options, "HTTPie")
+	if err != nil || !ok {
+		return err
+	}
+	separator := "?"
+	if strings.Contains(self.Options.Url, "?") {
+		separator = "&"
+	}
+	self.url = literal(self.Options.Url+separator) + body
+	return nil
+}
+
+/*
+	Simple form is sent by --form. Other data is sent as raw body with explicit Content-Type.
+*/
+func (self *HttpieGenerator) SetDataForBody() error {
+	self.hasBody = true
+	if canUseFormPairs(self.Options) {
+		self.flags = append(self.flags, "--form")
+		for _, pair := range formPairs(self.Options) {
+			self.items = append(self.items, literal(httpieKey(pair[0])+"="+pair[1]))
+		}
+		return nil
+	}
+	if self.canUseFormWithFiles() {
+		// "name@file" of --data-urlencode is a form field which has file content
+		self.flags = append(self.flags, "--form")
+		for _, data := range self.Options.ProcessedData {
+			index := strings.IndexAny(data.Value, "=@")
+			if data.Type == common.DataUrlEncodeType && data.Value[index] == '@' {
+				self.items = append(self.items, literal(httpieKey(data.Value[:index])+"=@"+data.Value[index+1:]))
+			} else {
+				self.items = append(self.items, literal(httpieKey(data.Value[:index])+"="+data.Value[index+1:]))
+			}
+		}
+		return nil
+	}
+	self.Options.InsertDataContentTypeHeader()
+	if self.Options.ProcessedData.UploadFile() != "" {
+		// empty header removes Content-Type: application/json of HTTPie's default
+		self.items = append(self.items, "Content-Type:")
+	}
+	if fileName := singleFile(self.Options); fileName != "" {
+		self.stdin = literal(fileName)
+		return nil
+	}
+	body, ok, err := shellBody(self.Options, "HTTPie")
+	if err != nil {
+		return err
+	}
+	if ok {
+		self.flags = append(self.flags, "--raw", body)
+	}
+	return nil
+}
+
+/*
+	--data-urlencode "name=content" and "name@file" are form fields.
+*/
+func (self *HttpieGenerator) canUseFormWithFiles() bool {
+	for _, data := range self.Options.ProcessedData {
+		if data.Type != common.DataUrlEncodeType {
+			return false
+		}
+		if strings.IndexAny(data.Value, "=@") < 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func (self *HttpieGenerator) SetFormForBody() {
+	self.hasBody = true
+	self.flags = append(self.flags, "--multipart")
+	for _, data := range self.Options.ProcessedData {
+		name, fileName, sentFileName, contentType, isFile, isText := formField(&data)
+		if isFile {
+			if sentFileName != fileName {
+				self.Options.AddWarning("HTTPie sends the file name of %s. filename=%s is ignored.", fileName, sentFileName)
+			}
+			if contentType != "" {
+				self.items = append(self.items, literal(httpieKey(name)+"@"+fileName+";type="+contentType))
+			} else {
+				self.items = append(self.items, literal(httpieKey(name)+"@"+fileName))
+			}
+		} else if isText {
+			if contentType != "" {
+				self.Options.AddWarning("HTTPie doesn't send Content-Type of text field. type=%s is ignored.", contentType)
+			}
+			self.items = append(self.items, literal(httpieKey(name)+"=@"+fileName))
+		} else {
+			self.items = append(self.items, literal(httpieKey(name)+"="+strings.SplitN(data.Value, "=", 2)[1]))
+		}
+	}
+}
+
+func (self *HttpieGenerator) SetHeader() {
+	for _, header := range self.Options.Headers() {
+		if isCompressionHeader(header) {
+			// HTTPie sends "Accept-Encoding: gzip, deflate" by default
+			continue
+		}
+		self.items = append(self.items, literal(httpieKey(header[0])+":"+header[1]))
+	}
+}
+
+func (self *HttpieGenerator) SetCookie() {
+	if self.Options.UseCookieJar() {
+		self.Options.AddWarning("HTTPie can't read or write Netscape cookie files. Use --session instead.")
+	}
+	if len(self.Options.Cookies()) != 0 {
+		self.items = append(self.items, literal("Cookie:"+self.Options.CookieString()))
+	}
+}
+
+/*
+	HTTPie guesses the method from request items. It is written only when it is different from curl.
+*/
+func (self *HttpieGenerator) method() string {
+	method := self.Options.Method()
+	if (method == "GET" && !self.hasBody) || (method == "POST" && self.hasBody) {
+		return ""
+	}
+	return method
+}
+
+func ProcessCurlCommandForHttpie(options *common.CurlOptions) (string, interface{}, error) {
+	generator := NewHttpieGenerator(options)
+
+	if options.ProcessedData.HasData() {
+		if options.Get {
+			if err := generator.SetDataForUrl(); err != nil {
+				return "", nil, err
+			}
+		} else {
+			if err := generator.SetDataForBody(); err != nil {
+				return "", nil, err
+			}
+		}
+	} else if options.ProcessedData.HasForm() {
+		generator.SetFormForBody()
+	}
+	generator.SetHeader()
+	if options.User != "" {
+		if options.UseDigestAuth() {
+			generator.flags = append(generator.flags, "--auth-type=digest")
+		}
+		generator.flags = append(generator.flags, "--auth="+literal(options.User))
+	}
+	if options.UseCookieJar() || len(options.Cookies()) != 0 {
+		generator.SetCookie()
+	}
+	if options.Proxy != "" {
+		proxy := proxyUrl(options).String()
+		generator.flags = append(generator.flags, literal("--proxy=http:"+proxy), literal("--proxy=https:"+proxy))
+	}
+	if options.Insecure {
+		generator.flags = append(generator.flags, "--verify=no")
+	}
+	if options.MaxTime != 0 {
+		generator.flags = append(generator.flags, fmt.Sprintf("--timeout=%d", int(math.Ceil(options.MaxTime))))
+		options.AddWarning("HTTPie's --timeout is a time limit of each network operation, not whole transfer.")
+	}
+	if options.ConnectTimeout != 0 {
+		options.AddWarning("HTTPie doesn't have connection timeout. --connect-timeout is ignored.")
+	}
+	if options.Http2Flag {
+		options.AddWarning("HTTPie doesn't support HTTP/2. --http2 is ignored.")
+	}
+	if options.AWSV2 != "" {
+		options.AddWarning("HTTPie doesn't support AWS V2 signature. --awsv2 is ignored.")
+	}
+
+	generator.add("http")
+	generator.add(generator.flags...)
+	if method := generator.method(); method != "" {
+		generator.add(method)
+	}
+	generator.add(generator.url)
+	generator.add(generator.items...)
+	if generator.stdin != "" {
+		generator.add("<", generator.stdin)
+	}
+	return "", generator.Command, nil
+}
@@ -0,0 +1,300 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"math"
+	"strings"
+)
+
+func psQuote(src string) string {
+	return "'" + strings.Replace(src, "'", "''", -1) + "'"
+}
+
+var psMethods = map[string]string{
+	"GET":     "Get",
+	"POST":    "Post",
+	"PUT":     "Put",
+	"DELETE":  "Delete",
+	"HEAD":    "Head",
+	"PATCH":   "Patch",
+	"OPTIONS": "Options",
+	"TRACE":   "Trace",
+	"MERGE":   "Merge",
+}
+
+/*
+	PowerShell 7: Invoke-RestMethod with splatted parameters.
+	Parameters are written in a hashtable because the command line becomes long.
+*/
+type PowerShellGenerator struct {
+	Options *common.CurlOptions
+
+	prepare    []string
+	parameters [][]string
+}
+
+func NewPowerShellGenerator(options *common.CurlOptions) *PowerShellGenerator {
+	result := &PowerShellGenerator{Options: options}
+	result.addParameter("Uri", psQuote(options.Url))
+	return result
+}
+
+func (self PowerShellGenerator) SourceCode() string {
+	var buffer bytes.Buffer
+	for _, line := range self.prepare {
+		fmt.Fprintf(&buffer, "%s\n", line)
+	}
+	buffer.WriteString("$params = @{\n")
+	for _, parameter := range self.parameters {
+		fmt.Fprintf(&buffer, "    %s = %s\n", parameter[0], parameter[1])
+	}
+	buffer.WriteString("}\n")
+	buffer.WriteString("Invoke-RestMethod @params")
+	return buffer.String()
+}
+
+func (self *PowerShellGenerator) addParameter(name, value string) {
+	self.parameters = append(self.parameters, []string{name, value})
+}
+
+func hashtable(pairs [][]string, indent string) string {
+	var buffer bytes.Buffer
+	buffer.WriteString("@{\n")
+	for _, pair := range pairs {
+		fmt.Fprintf(&buffer, "%s    %s = %s\n", indent, psQuote(pair[0]), pair[1])
+	}
+	fmt.Fprintf(&buffer, "%s}", indent)
+	return buffer.String()
+}
+
+func (self *PowerShellGenerator) dataExpression(data *common.DataOption) (string, error) {
+	switch data.Type {
+	case common.DataAsciiType:
+		if data.UseExternalFile() {
+			return fmt.Sprintf("((Get-Content -Raw %s) -replace '[\\r\\n]', '')", psQuote(data.Value[1:])), nil
+		}
+		return psQuote(data.Value), nil
+	case common.DataBinaryType:
+		if data.UseExternalFile() {
+			return fmt.Sprintf("(Get-Content -Raw %s)", psQuote(data.Value[1:])), nil
+		}
+		return psQuote(data.Value), nil
+	case common.DataUrlEncodeType:
+		// curl's rule: "content", "=content", "name=content", "@filename" and "name@filename"
+		index := strings.IndexAny(data.Value, "=@")
+		var content string
+		if index == -1 {
+			content = psQuote(data.Value)
+		} else if data.Value[index] == '@' {
+			content = fmt.Sprintf("(Get-Content -Raw %s)", psQuote(data.Value[index+1:]))
+		} else {
+			content = psQuote(data.Value[index+1:])
+		}
+		if index > 0 {
+			return fmt.Sprintf("(%s + [uri]::EscapeDataString(%s))", psQuote(data.Value[:index]+"="), content), nil
+		}
+		return fmt.Sprintf("[uri]::EscapeDataString(%s)", content), nil
+	default:
+		return "", common.UnexpectedDataError("powershell", data)
+	}
+}
+
+func (self *PowerShellGenerator) stringBody() (string, error) {
+	if len(self.Options.ProcessedData) == 1 {
+		return self.dataExpression(&self.Options.ProcessedData[0])
+	}
+	var values []string
+	for _, data := range self.Options.ProcessedData {
+		value, err := self.dataExpression(&data)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, value)
+	}
+	return fmt.Sprintf("(@(%s) -join '&')", strings.Join(values, ", ")), nil
+}
+
+/*
+	Hashtable body is encoded as query string of GET request and form of other requests.
+	Hashtable can't have duplicated keys.
+*/
+func (self *PowerShellGenerator) formBody() (string, bool) {
+	if !canUseFormPairs(self.Options) {
+		return "", false
+	}
+	pairs := formPairs(self.Options)
+	keys := make(map[string]bool)
+	var entries [][]string
+	for _, pair := range pairs {
+		if keys[pair[0]] {
+			return "", false
+		}
+		keys[pair[0]] = true
+		entries = append(entries, []string{pair[0], psQuote(pair[1])})
+	}
+	self.prepare = append(self.prepare, "$body = "+hashtable(entries, ""))
+	return "$body", true
+}
+
+func (self *PowerShellGenerator) SetDataForUrl() error {
+	if body, ok := self.formBody(); ok {
+		self.addParameter("Body", body)
+		return nil
+	}
+	separator := "?"
+	if strings.Contains(self.Options.Url, "?") {
+		separator = "&"
+	}
+	query, err := self.stringBody()
+	if err != nil {
+		return err
+	}
+	self.parameters[0][1] = fmt.Sprintf("(%s + %s)", psQuote(self.Options.Url+separator), query)
+	return nil
+}
+
+func (self *PowerShellGenerator) SetDataForBody() error {
+	if body, ok := self.formBody(); ok {
+		self.addParameter("Body", body)
+		return nil
+	}
+	self.Options.InsertDataContentTypeHeader()
+	if fileName := singleFile(self.Options); fileName != "" {
+		self.addParameter("InFile", psQuote(fileName))
+		return nil
+	}
+	body, err := self.stringBody()
+	if err != nil {
+		return err
+	}
+	self.addParameter("Body", body)
+	return nil
+}
+
+/*
+	-Form (PowerShell 6.1+) sends multipart/form-data. File name and content type can't be specified.
+*/
+func (self *PowerShellGenerator) SetFormForBody() {
+	var entries [][]string
+	for _, data := range self.Options.ProcessedData {
+		name, fileName, sentFileName, contentType, isFile, isText := formField(&data)
+		if contentType != "" {
+			self.Options.AddWarning("Invoke-RestMethod -Form can't set Content-Type of each field. type=%s is ignored.", contentType)
+		}
+		if isFile {
+			if sentFileName != fileName {
+				self.Options.AddWarning("Invoke-RestMethod sends the file name of %s. filename=%s is ignored.", fileName, sentFileName)
+			}
+			entries = append(entries, []string{name, fmt.Sprintf("(Get-Item %s)", psQuote(fileName))})
+		} else if isText {
+			entries = append(entries, []string{name, fmt.Sprintf("(Get-Content -Raw %s)", psQuote(fileName))})
+		} else {
+			entries = append(entries, []string{name, psQuote(strings.SplitN(data.Value, "=", 2)[1])})
+		}
+	}
+	self.prepare = append(self.prepare, "$form = "+hashtable(entries, ""))
+	self.addParameter("Form", "$form")
+}
+
+/*
+	Content-Type is passed by -ContentType parameter.
+*/
+func (self *PowerShellGenerator) SetHeader() {
+	var entries [][]string
+	for _, header := range self.Options.GroupedHeaders() {
+		value := strings.Join(header.Values, ", ")
+		if header.Key == "content-type" {
+			self.addParameter("ContentType", psQuote(value))
+			continue
+		}
+		entries = append(entries, []string{header.Key, psQuote(value)})
+	}
+	if len(self.Options.Cookies()) != 0 {
+		entries = append(entries, []string{"Cookie", psQuote(self.Options.CookieString())})
+	}
+	if len(entries) > 0 {
+		self.prepare = append(self.prepare, "$headers = "+hashtable(entries, ""))
+		self.addParameter("Headers", "$headers")
+	}
+}
+
+func (self *PowerShellGenerator) SetMethod() {
+	method := self.Options.Method()
+	if method == "GET" {
+		return
+	}
+	if name, ok := psMethods[method]; ok {
+		self.addParameter("Method", psQuote(name))
+	} else {
+		self.addParameter("CustomMethod", psQuote(method))
+	}
+}
+
+/*
+	Invoke-RestMethod answers Basic authentication only when -Authentication is specified.
+	Other challenges (Digest) are answered with -Credential.
+*/
+func (self *PowerShellGenerator) SetAuth() {
+	user, password := self.Options.UserAndPassword()
+	self.prepare = append(self.prepare, fmt.Sprintf("$credential = New-Object System.Management.Automation.PSCredential(%s, (ConvertTo-SecureString %s -AsPlainText -Force))", psQuote(user), psQuote(password)))
+	self.addParameter("Credential", "$credential")
+	if self.Options.UseBasicAuth() {
+		self.addParameter("Authentication", "'Basic'")
+		if self.Options.ParsedUrl().Scheme != "https" {
+			self.addParameter("AllowUnencryptedAuthentication", "$true")
+		}
+	}
+}
+
+func ProcessCurlCommandForPowerShell(options *common.CurlOptions) (string, interface{}, error) {
+	generator := NewPowerShellGenerator(options)
+
+	generator.SetMethod()
+	if options.ProcessedData.HasData() {
+		if options.Get {
+			if err := generator.SetDataForUrl(); err != nil {
+				return "", nil, err
+			}
+		} else {
+			if err := generator.SetDataForBody(); err != nil {
+				return "", nil, err
+			}
+		}
+	} else if options.ProcessedData.HasForm() {
+		generator.SetFormForBody()
+	}
+	generator.SetHeader()
+	if options.User != "" {
+		generator.SetAuth()
+	}
+	if options.UseCookieJar() {
+		options.AddWarning("Invoke-RestMethod can't read or write Netscape cookie files. Use -SessionVariable instead.")
+	}
+	if options.Proxy != "" {
+		u := proxyUrl(options)
+		if u.User != nil {
+			options.AddWarning("Proxy user is ignored. Use -ProxyCredential instead.")
+			u.User = nil
+		}
+		generator.addParameter("Proxy", psQuote(u.String()))
+	}
+	if options.Insecure {
+		generator.addParameter("SkipCertificateCheck", "$true")
+	}
+	if options.ConnectTimeout != 0 {
+		options.AddWarning("Invoke-RestMethod doesn't have connection timeout. --connect-timeout is ignored.")
+	}
+	if options.MaxTime != 0 {
+		generator.addParameter("TimeoutSec", fmt.Sprintf("%d", int(math.Ceil(options.MaxTime))))
+	}
+	if options.Http2Flag {
+		generator.addParameter("HttpVersion", "'2.0'")
+	}
+	if options.AWSV2 != "" {
+		options.AddWarning("Invoke-RestMethod doesn't support AWS V2 signature. --awsv2 is ignored.")
+	}
+
+	return "", *generator, nil
+}
@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"github.com/shibukawa/curl_as_dsl/common"
+	"strconv"
+	"strings"
+)
+
+/*
+	GNU Wget. Response body is written to stdout like curl.
+*/
+type WgetGenerator struct {
+	Command
+	url      string
+	body     string
+	bodyFile string
+}
+
+func NewWgetGenerator(options *common.CurlOptions) *WgetGenerator {
+	result := &WgetGenerator{}
+	result.Options = options
+	result.url = literal(options.Url)
+	result.add("wget", "-q", "-O", "-", "--content-on-error")
+	return result
+}
+
+func (self *WgetGenerator) SetDataForUrl() error {
+	body, ok, err := shellBody(self.Options, "wget")
+	if err != nil || !ok {
+		return err
+	}
+	separator := "?"
+	if strings.Contains(self.Options.Url, "?") {
+		separator = "&"
+	}
+	self.url = literal(self.Options.Url+separator) + body
+	return nil
+}
+
+func (self *WgetGenerator) SetDataForBody() error {
+	if fileName := singleFile(self.Options); fileName != "" {
+		self.bodyFile = literal(fileName)
+		return nil
+	}
+	body, ok, err := shellBody(self.Options, "wget")
+	if err != nil {
+		return err
+	}
+	if ok {
+		self.body = body
+	}
+	return nil
+}
+
+/*
+	--post-data and --post-file send POST request. --body-data and --body-file are used with --method.
+*/
+func (self *WgetGenerator) SetMethod() {
+	method := self.Options.Method()
+	if method == "POST" && (self.body != "" || self.bodyFile != "") {
+		if self.bodyFile != "" {
+			self.add("--post-file=" + self.bodyFile)
+		} else {
+			self.add("--post-data=" + self.body)
+		}
+		return
+	}
+	if method != "GET" {
+		self.add("--method=" + literal(method))
+	}
+	if self.bodyFile != "" {
+		self.add("--body-file=" + self.bodyFile)
+	} else if self.body != "" {
+		self.add("--body-data=" + self.body)
+	}
+}
+
+func (self *WgetGenerator) SetHeader() {
+	compression := false
+	for _, header := range self.Options.Headers() {
+		if isCompressionHeader(header) {
+			compression = true
+			continue
+		}
+		self.add(literal("--header=" + header[0] + ": " + header[1]))
+	}
+	if compression {
+		self.add("--compression=auto")
+	}
+}
+
+/*
+	wget reads and writes Netscape format cookie files like curl.
+*/
+func (self *WgetGenerator) SetCookie() {
+	if len(self.Options.Cookies()) != 0 {
+		self.add(literal("--header=Cookie: " + self.Options.CookieString()))
+	}
+	files := self.Options.CookieFiles()
+	if len(files) > 0 {
+		self.add("--load-cookies=" + literal(files[0]))
+		for _, fileName := range files[1:] {
+			self.Options.AddWarning("wget reads only one cookie file. %s is ignored.", fileName)
+		}
+	}
+	if self.Options.CookieJar != "" {
+		self.add("--save-cookies="+literal(self.Options.CookieJar), "--keep-session-cookies")
+	}
+}
+
+/*
+	wget doesn't have proxy option. Proxy settings are passed as .wgetrc commands.
+*/
+func (self *WgetGenerator) SetProxy() {
+	u := proxyUrl(self.Options)
+	if strings.HasPrefix(u.Scheme, "socks") {
+		self.Options.AddWarning("wget doesn't support SOCKS proxy. %s is ignored.", self.Options.Proxy)
+		return
+	}
+	self.add("-e", "use_proxy=yes", "-e", literal("http_proxy="+u.String()), "-e", literal("https_proxy="+u.String()))
+}
+
+func ProcessCurlCommandForWget(options *common.CurlOptions) (string, interface{}, error) {
+	generator := NewWgetGenerator(options)
+
+	if options.ProcessedData.HasData() {
+		if options.Get {
+			if err := generator.SetDataForUrl(); err != nil {
+				return "", nil, err
+			}
+		} else {
+			if err := generator.SetDataForBody(); err != nil {
+				return "", nil, err
+			}
+		}
+	} else if options.ProcessedData.HasForm() {
+		options.AddWarning("wget doesn't support multipart/form-data. -F options are ignored.")
+	}
+	generator.SetMethod()
+	generator.SetHeader()
+	if options.User != "" {
+		user, password := options.UserAndPassword()
+		generator.add("--user="+literal(user), "--password="+literal(password))
+		if options.UseBasicAuth() {
+			// curl sends Basic authorization header without challenge
+			generator.add("--auth-no-challenge")
+		}
+		if options.UseDigestAuth() {
+			options.AddWarning("wget can't select Digest authentication. It uses the scheme of the server's challenge.")
+		}
+	}
+	if options.UseCookieJar() || len(options.Cookies()) != 0 {
+		generator.SetCookie()
+	}
+	if options.Proxy != "" {
+		generator.SetProxy()
+	}
+	if options.Insecure {
+		generator.add("--no-check-certificate")
+	}
+	if options.ConnectTimeout != 0 {
+		generator.add("--connect-timeout=" + strconv.FormatFloat(options.ConnectTimeout, 'f', -1, 64))
+	}
+	if options.MaxTime != 0 {
+		generator.add("--read-timeout=" + strconv.FormatFloat(options.MaxTime, 'f', -1, 64))
+		options.AddWarning("wget doesn't have time limit of whole transfer. -m is used as --read-timeout.")
+	}
+	if options.ProcessedData.UploadFile() != "" {
+		options.AddWarning("wget sends Content-Type: application/x-www-form-urlencoded with the file of -T.")
+	}
+	if options.Http2Flag {
+		options.AddWarning("wget doesn't support HTTP/2. --http2 is ignored.")
+	}
+	if options.AWSV2 != "" {
+		options.AddWarning("wget doesn't support AWS V2 signature. --awsv2 is ignored.")
+	}
+
+	generator.add(generator.url)
+	return "", generator.Command, nil
+}
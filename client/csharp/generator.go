@@ -0,0 +1,532 @@
+package csharp
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/literal"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+	HttpClient rejects these headers in HttpRequestMessage.Headers. They should be set to HttpContent.Headers.
+*/
+var contentHeaders = map[string]bool{
+	"allow":               true,
+	"content-disposition": true,
+	"content-encoding":    true,
+	"content-language":    true,
+	"content-length":      true,
+	"content-location":    true,
+	"content-md5":         true,
+	"content-range":       true,
+	"content-type":        true,
+	"expires":             true,
+	"last-modified":       true,
+}
+
+var methods = map[string]string{
+	"GET":     "HttpMethod.Get",
+	"POST":    "HttpMethod.Post",
+	"PUT":     "HttpMethod.Put",
+	"DELETE":  "HttpMethod.Delete",
+	"HEAD":    "HttpMethod.Head",
+	"PATCH":   "HttpMethod.Patch",
+	"OPTIONS": "HttpMethod.Options",
+	"TRACE":   "HttpMethod.Trace",
+}
+
+type CSharpGenerator struct {
+	Options *common.CurlOptions
+
+	Url                   string
+	AdditionalDeclaration string
+	usings                map[string]bool
+	prepare               []string
+	handler               []string
+	client                []string
+	modifyRequest         []string
+	decompression         []string
+	hasContent            bool
+	multipart             bool
+}
+
+func NewCSharpGenerator(options *common.CurlOptions) *CSharpGenerator {
+	result := &CSharpGenerator{Options: options}
+	result.Url = literal.CSharp(options.Url)
+	result.usings = map[string]bool{
+		"System":          true,
+		"System.Net.Http": true,
+	}
+	return result
+}
+
+//--- Getter methods called from template
+
+func (self CSharpGenerator) Usings() string {
+	var usings []string
+	for using := range self.usings {
+		usings = append(usings, using)
+	}
+	sort.Strings(usings)
+	var buffer bytes.Buffer
+	for _, using := range usings {
+		fmt.Fprintf(&buffer, "using %s;\n", using)
+	}
+	return buffer.String()
+}
+
+func (self CSharpGenerator) Prepare() string {
+	var buffer bytes.Buffer
+	for _, line := range self.prepare {
+		fmt.Fprintf(&buffer, "%s\n", line)
+	}
+	return buffer.String()
+}
+
+/*
+	HttpClientHandler keeps connection settings like proxy, certificate verification and cookies.
+*/
+func (self CSharpGenerator) Handler() string {
+	handler := self.handler
+	if len(self.decompression) > 0 {
+		handler = append(append([]string(nil), handler...), fmt.Sprintf("AutomaticDecompression = %s,", strings.Join(self.decompression, " | ")))
+	}
+	if len(handler) == 0 {
+		return ""
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("var handler = new HttpClientHandler\n{\n")
+	for _, line := range handler {
+		fmt.Fprintf(&buffer, "    %s\n", line)
+	}
+	buffer.WriteString("};\n")
+	return buffer.String()
+}
+
+func (self CSharpGenerator) Client() string {
+	var buffer bytes.Buffer
+	if len(self.handler) > 0 || len(self.decompression) > 0 {
+		buffer.WriteString("using var client = new HttpClient(handler);\n")
+	} else {
+		buffer.WriteString("using var client = new HttpClient();\n")
+	}
+	for _, line := range self.client {
+		fmt.Fprintf(&buffer, "%s\n", line)
+	}
+	return buffer.String()
+}
+
+func (self CSharpGenerator) Method() string {
+	method := self.Options.Method()
+	if expression, ok := methods[method]; ok {
+		return expression
+	}
+	return fmt.Sprintf("new HttpMethod(%s)", literal.CSharp(method))
+}
+
+func (self CSharpGenerator) ModifyRequest() string {
+	var buffer bytes.Buffer
+	for _, line := range self.modifyRequest {
+		fmt.Fprintf(&buffer, "%s\n", line)
+	}
+	return buffer.String()
+}
+
+func (self CSharpGenerator) SaveCookies() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	return fmt.Sprintf("SaveCookies(cookies, %s);\n", literal.CSharp(self.Options.CookieJar))
+}
+
+//--- Preparing C# source code methods
+
+func (self *CSharpGenerator) addUsings(names ...string) {
+	for _, name := range names {
+		self.usings[name] = true
+	}
+}
+
+/*
+	URL encoded "content", "=content" and "name@filename" of --data-urlencode can't be a pair of FormUrlEncodedContent.
+*/
+func (self *CSharpGenerator) canUseFormUrlEncodedContent() bool {
+	if !self.Options.CanUseSimpleForm() {
+		return false
+	}
+	for _, data := range self.Options.ProcessedData {
+		if data.Type == common.DataUrlEncodeType {
+			index := strings.IndexAny(data.Value, "=@")
+			if index < 1 || data.Value[index] == '@' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (self *CSharpGenerator) SetDataForUrl() error {
+	separator := "?"
+	if strings.Contains(self.Options.Url, "?") {
+		separator = "&"
+	}
+	query, err := self.stringBody()
+	if err != nil {
+		return err
+	}
+	self.prepare = append(self.prepare, fmt.Sprintf("var url = %s + %s;", literal.CSharp(self.Options.Url+separator), query))
+	self.Url = "url"
+	return nil
+}
+
+func (self *CSharpGenerator) SetDataForBody() error {
+	self.hasContent = true
+	if self.canUseFormUrlEncodedContent() {
+		self.addUsings("System.Collections.Generic")
+		var buffer bytes.Buffer
+		buffer.WriteString("request.Content = new FormUrlEncodedContent(new List<KeyValuePair<string, string>>\n{\n")
+		for _, data := range self.Options.ProcessedData {
+			for _, pair := range strings.Split(data.Value, "&") {
+				if pair == "" {
+					continue
+				}
+				fragments := strings.SplitN(pair, "=", 2)
+				key, value := fragments[0], fragments[1]
+				if data.Type != common.DataUrlEncodeType {
+					key, _ = url.QueryUnescape(key)
+					value, _ = url.QueryUnescape(value)
+				}
+				fmt.Fprintf(&buffer, "    new(%s, %s),\n", literal.CSharp(key), literal.CSharp(value))
+			}
+		}
+		buffer.WriteString("});")
+		self.modifyRequest = append(self.modifyRequest, buffer.String())
+		return nil
+	}
+	if len(self.Options.ProcessedData) == 1 {
+		data := &self.Options.ProcessedData[0]
+		if data.Type == common.DataBinaryType && data.UseExternalFile() {
+			self.addUsings("System.IO")
+			self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("request.Content = new StreamContent(File.OpenRead(%s));", literal.CSharp(data.Value[1:])))
+			return nil
+		}
+	}
+	body, err := self.stringBody()
+	if err != nil {
+		return err
+	}
+	self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("request.Content = new StringContent(%s);", body))
+	return nil
+}
+
+func (self *CSharpGenerator) stringBody() (string, error) {
+	if len(self.Options.ProcessedData) == 1 {
+		return self.dataExpression(&self.Options.ProcessedData[0])
+	}
+	var values []string
+	for _, data := range self.Options.ProcessedData {
+		value, err := self.dataExpression(&data)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, value)
+	}
+	return fmt.Sprintf("string.Join(\"&\", %s)", strings.Join(values, ", ")), nil
+}
+
+func (self *CSharpGenerator) dataExpression(data *common.DataOption) (string, error) {
+	switch data.Type {
+	case common.DataAsciiType:
+		if data.UseExternalFile() {
+			self.addUsings("System.IO")
+			return fmt.Sprintf("File.ReadAllText(%s).Replace(\"\\r\", \"\").Replace(\"\\n\", \"\")", literal.CSharp(data.Value[1:])), nil
+		}
+		return literal.CSharp(data.Value), nil
+	case common.DataBinaryType:
+		if data.UseExternalFile() {
+			self.addUsings("System.IO")
+			return fmt.Sprintf("File.ReadAllText(%s)", literal.CSharp(data.Value[1:])), nil
+		}
+		return literal.CSharp(data.Value), nil
+	case common.DataUrlEncodeType:
+		// curl's rule: "content", "=content", "name=content", "@filename" and "name@filename"
+		index := strings.IndexAny(data.Value, "=@")
+		var content string
+		if index == -1 {
+			content = literal.CSharp(data.Value)
+		} else if data.Value[index] == '@' {
+			self.addUsings("System.IO")
+			content = fmt.Sprintf("File.ReadAllText(%s)", literal.CSharp(data.Value[index+1:]))
+		} else {
+			content = literal.CSharp(data.Value[index+1:])
+		}
+		if index > 0 {
+			return fmt.Sprintf("%s + Uri.EscapeDataString(%s)", literal.CSharp(data.Value[:index]+"="), content), nil
+		}
+		return fmt.Sprintf("Uri.EscapeDataString(%s)", content), nil
+	default:
+		return "", common.UnexpectedDataError("csharp", data)
+	}
+}
+
+/*
+	MultipartFormDataContent sets Content-Type header with boundary.
+*/
+func (self *CSharpGenerator) SetFormForBody() {
+	self.hasContent = true
+	self.multipart = true
+	self.prepare = append(self.prepare, "var form = new MultipartFormDataContent();")
+	fileCount := 0
+	for _, data := range self.Options.ProcessedData {
+		field := strings.SplitN(data.Value, "=", 2)
+		if data.Type == common.FormType && (strings.HasPrefix(field[1], "@") || strings.HasPrefix(field[1], "<")) {
+			self.addUsings("System.IO")
+			fragments := strings.Split(field[1][1:], ";")
+			contentType := ""
+			sentFileName := fragments[0]
+			for _, fragment := range fragments[1:] {
+				if strings.HasPrefix(fragment, "filename=") {
+					sentFileName = fragment[9:]
+				} else if strings.HasPrefix(fragment, "type=") {
+					contentType = fragment[5:]
+				}
+			}
+			if field[1][0] == '@' {
+				fileCount++
+				variable := fmt.Sprintf("file%d", fileCount)
+				self.prepare = append(self.prepare, fmt.Sprintf("var %s = new StreamContent(File.OpenRead(%s));", variable, literal.CSharp(fragments[0])))
+				if contentType != "" {
+					self.addUsings("System.Net.Http.Headers")
+					self.prepare = append(self.prepare, fmt.Sprintf("%s.Headers.ContentType = new MediaTypeHeaderValue(%s);", variable, literal.CSharp(contentType)))
+				}
+				self.prepare = append(self.prepare, fmt.Sprintf("form.Add(%s, %s, %s);", variable, literal.CSharp(field[0]), literal.CSharp(sentFileName)))
+			} else if contentType != "" {
+				self.addUsings("System.Text")
+				self.prepare = append(self.prepare, fmt.Sprintf("form.Add(new StringContent(File.ReadAllText(%s), Encoding.UTF8, %s), %s);", literal.CSharp(fragments[0]), literal.CSharp(contentType), literal.CSharp(field[0])))
+			} else {
+				self.prepare = append(self.prepare, fmt.Sprintf("form.Add(new StringContent(File.ReadAllText(%s)), %s);", literal.CSharp(fragments[0]), literal.CSharp(field[0])))
+			}
+		} else {
+			self.prepare = append(self.prepare, fmt.Sprintf("form.Add(new StringContent(%s), %s);", literal.CSharp(field[1]), literal.CSharp(field[0])))
+		}
+	}
+	self.modifyRequest = append(self.modifyRequest, "request.Content = form;")
+}
+
+func (self *CSharpGenerator) SetHeader() {
+	for _, header := range self.Options.Headers() {
+		name := strings.ToLower(header[0])
+		if name == "accept-encoding" && self.setDecompression(header[1]) {
+			continue
+		}
+		if !contentHeaders[name] {
+			self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("request.Headers.TryAddWithoutValidation(%s, %s);", literal.CSharp(header[0]), literal.CSharp(header[1])))
+		} else if !self.hasContent {
+			self.Options.AddWarning("%s header is ignored because HttpClient sends it only with request body.", header[0])
+		} else if name == "content-type" {
+			if self.multipart {
+				self.Options.AddWarning("MultipartFormDataContent sets Content-Type header with boundary. %s is ignored.", header[1])
+				continue
+			}
+			self.addUsings("System.Net.Http.Headers")
+			self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("request.Content.Headers.ContentType = MediaTypeHeaderValue.Parse(%s);", literal.CSharp(header[1])))
+		} else {
+			self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("request.Content.Headers.TryAddWithoutValidation(%s, %s);", literal.CSharp(header[0]), literal.CSharp(header[1])))
+		}
+	}
+}
+
+/*
+	HttpClientHandler sends Accept-Encoding and decodes response by itself when decompression is enabled.
+	It returns false when header contains unsupported encoding.
+*/
+func (self *CSharpGenerator) setDecompression(value string) bool {
+	var encodings []string
+	for _, encoding := range strings.Split(value, ",") {
+		encoding = strings.TrimSpace(encoding)
+		switch encoding {
+		case "gzip":
+			encodings = append(encodings, "DecompressionMethods.GZip")
+		case "deflate":
+			encodings = append(encodings, "DecompressionMethods.Deflate")
+		case "br":
+			encodings = append(encodings, "DecompressionMethods.Brotli")
+		default:
+			return false
+		}
+	}
+	self.addUsings("System.Net")
+	for _, encoding := range encodings {
+		found := false
+		for _, existing := range self.decompression {
+			if existing == encoding {
+				found = true
+			}
+		}
+		if !found {
+			self.decompression = append(self.decompression, encoding)
+		}
+	}
+	return true
+}
+
+func (self *CSharpGenerator) SetBasicAuth() {
+	self.addUsings("System.Net.Http.Headers", "System.Text")
+	user, password := self.Options.UserAndPassword()
+	self.modifyRequest = append(self.modifyRequest,
+		fmt.Sprintf("request.Headers.Authorization = new AuthenticationHeaderValue(\"Basic\", Convert.ToBase64String(Encoding.UTF8.GetBytes(%s)));", literal.CSharp(user+":"+password)))
+}
+
+/*
+	HttpClientHandler answers Digest challenge with credentials registered in CredentialCache.
+*/
+func (self *CSharpGenerator) SetDigestAuth() {
+	self.addUsings("System.Net")
+	user, password := self.Options.UserAndPassword()
+	self.handler = append(self.handler, fmt.Sprintf("Credentials = new CredentialCache { { new Uri(%s), \"Digest\", new NetworkCredential(%s, %s) } },",
+		literal.CSharp(self.Options.Url), literal.CSharp(user), literal.CSharp(password)))
+}
+
+func (self *CSharpGenerator) AddCookieCode() {
+	self.addUsings("System.Net")
+	self.prepare = append(self.prepare, "var cookies = new CookieContainer();")
+	for _, cookie := range self.Options.Cookies() {
+		self.prepare = append(self.prepare, fmt.Sprintf("cookies.Add(new Uri(%s), new Cookie(%s, %s));", literal.CSharp(self.Options.Url), literal.CSharp(cookie[0]), literal.CSharp(cookie[1])))
+	}
+	if self.Options.UseCookieJar() {
+		self.addUsings("System.IO")
+	}
+	if len(self.Options.CookieFiles()) > 0 {
+		for _, fileName := range self.Options.CookieFiles() {
+			self.prepare = append(self.prepare, fmt.Sprintf("LoadCookies(cookies, %s);", literal.CSharp(fileName)))
+		}
+		self.AdditionalDeclaration += `
+// fields of Netscape cookie file: domain, include subdomains, path, secure, expires, name, value
+static void LoadCookies(CookieContainer cookies, string fileName)
+{
+    // curl ignores missing cookie file
+    if (!File.Exists(fileName))
+    {
+        return;
+    }
+    foreach (var line in File.ReadAllLines(fileName))
+    {
+        var fields = line.Split('\t');
+        var httpOnly = line.StartsWith("#HttpOnly_");
+        if (fields.Length != 7 || (line.StartsWith("#") && !httpOnly))
+        {
+            continue;
+        }
+        var cookie = new Cookie(fields[5], fields[6], fields[2], httpOnly ? fields[0].Substring(10) : fields[0])
+        {
+            Secure = fields[3] == "TRUE",
+            HttpOnly = httpOnly,
+        };
+        if (fields[4] != "0")
+        {
+            cookie.Expires = DateTimeOffset.FromUnixTimeSeconds(long.Parse(fields[4])).UtcDateTime;
+        }
+        cookies.Add(cookie);
+    }
+}
+`
+	}
+	if self.Options.CookieJar != "" {
+		self.addUsings("System.Collections.Generic")
+		self.AdditionalDeclaration += `
+static void SaveCookies(CookieContainer cookies, string fileName)
+{
+    var lines = new List<string> { "# Netscape HTTP Cookie File" };
+    foreach (Cookie cookie in cookies.GetAllCookies())
+    {
+        var expires = cookie.Expires == DateTime.MinValue ? 0 : new DateTimeOffset(cookie.Expires).ToUnixTimeSeconds();
+        lines.Add(string.Join("\t", (cookie.HttpOnly ? "#HttpOnly_" : "") + cookie.Domain, cookie.Domain.StartsWith(".") ? "TRUE" : "FALSE",
+            cookie.Path, cookie.Secure ? "TRUE" : "FALSE", expires, cookie.Name, cookie.Value));
+    }
+    File.WriteAllLines(fileName, lines);
+}
+`
+	}
+	self.handler = append(self.handler, "CookieContainer = cookies,")
+}
+
+/*
+	WebProxy accepts http, https, socks4, socks4a and socks5 proxies.
+*/
+func (self *CSharpGenerator) SetProxy() {
+	self.addUsings("System.Net")
+	proxy := self.Options.Proxy
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	u, _ := url.Parse(proxy)
+	if u.Port() == "" {
+		// curl uses 1080 as default proxy port
+		u.Host = u.Host + ":1080"
+	}
+	self.handler = append(self.handler, fmt.Sprintf("Proxy = new WebProxy(%s),", literal.CSharp(u.String())))
+}
+
+func (self *CSharpGenerator) SetInsecure() {
+	self.handler = append(self.handler, "ServerCertificateCustomValidationCallback = HttpClientHandler.DangerousAcceptAnyServerCertificateValidator,")
+}
+
+func (self *CSharpGenerator) SetTimeout() {
+	self.client = append(self.client, fmt.Sprintf("client.Timeout = TimeSpan.FromSeconds(%s);", strconv.FormatFloat(self.Options.MaxTime, 'f', -1, 64)))
+}
+
+func (self *CSharpGenerator) SetHttp2() {
+	self.addUsings("System.Net")
+	self.modifyRequest = append(self.modifyRequest, "request.Version = HttpVersion.Version20;")
+}
+
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
+	generator := NewCSharpGenerator(options)
+
+	if options.ProcessedData.HasData() {
+		if options.Get {
+			if err := generator.SetDataForUrl(); err != nil {
+				return "", nil, err
+			}
+		} else {
+			if !generator.canUseFormUrlEncodedContent() {
+				options.InsertDataContentTypeHeader()
+			}
+			if err := generator.SetDataForBody(); err != nil {
+				return "", nil, err
+			}
+		}
+	} else if options.ProcessedData.HasForm() {
+		generator.SetFormForBody()
+	}
+	generator.SetHeader()
+	if options.UseBasicAuth() {
+		generator.SetBasicAuth()
+	} else if options.UseDigestAuth() {
+		generator.SetDigestAuth()
+	}
+	if options.UseCookieJar() || len(options.Cookies()) != 0 {
+		generator.AddCookieCode()
+	}
+	if options.Proxy != "" {
+		generator.SetProxy()
+	}
+	if options.Insecure {
+		generator.SetInsecure()
+	}
+	if options.ConnectTimeout != 0 {
+		options.AddWarning("HttpClientHandler doesn't have connection timeout. --connect-timeout is ignored.")
+	}
+	if options.MaxTime != 0 {
+		generator.SetTimeout()
+	}
+	if options.Http2Flag {
+		generator.SetHttp2()
+	}
+
+	return "full", *generator, nil
+}
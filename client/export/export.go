@@ -0,0 +1,226 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"net/url"
+	"strings"
+)
+
+/*
+	Exporters write API tool documents instead of source code.
+	Files referred by the options are not read. Their names are written instead.
+*/
+type Document struct {
+	Options *common.CurlOptions
+	Content interface{}
+}
+
+func (self Document) SourceCode() string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(self.Content); err != nil {
+		panic(err)
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+/*
+	Query parameter, form field or header.
+	FileName is the file of -F "name=@file" (IsFile) or -F "name=<file".
+*/
+type field struct {
+	Name         string
+	Value        string
+	FileName     string
+	SentFileName string
+	ContentType  string
+	IsFile       bool
+}
+
+/*
+	Same rule of curl_easy_escape(): only unreserved characters are kept.
+*/
+func escape(src string) string {
+	var buffer bytes.Buffer
+	for _, c := range []byte(src) {
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || c == '-' || c == '.' || c == '_' || c == '~' {
+			buffer.WriteByte(c)
+		} else {
+			fmt.Fprintf(&buffer, "%%%02X", c)
+		}
+	}
+	return buffer.String()
+}
+
+func parseQuery(query string) []field {
+	var result []field
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		fragments := strings.SplitN(pair, "=", 2)
+		name, _ := url.QueryUnescape(fragments[0])
+		var value string
+		if len(fragments) == 2 {
+			value, _ = url.QueryUnescape(fragments[1])
+		}
+		result = append(result, field{Name: name, Value: value})
+	}
+	return result
+}
+
+/*
+	URL encoded "content", "=content" and "name@filename" of --data-urlencode can't be a pair of form.
+*/
+func canUseFormPairs(options *common.CurlOptions) bool {
+	if !options.CanUseSimpleForm() {
+		return false
+	}
+	for _, data := range options.ProcessedData {
+		if data.Type == common.DataUrlEncodeType {
+			index := strings.IndexAny(data.Value, "=@")
+			if index < 1 || data.Value[index] == '@' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func formFields(options *common.CurlOptions) []field {
+	var result []field
+	for _, data := range options.ProcessedData {
+		if data.Type == common.DataUrlEncodeType {
+			fragments := strings.SplitN(data.Value, "=", 2)
+			result = append(result, field{Name: fragments[0], Value: fragments[1]})
+		} else {
+			result = append(result, parseQuery(data.Value)...)
+		}
+	}
+	return result
+}
+
+/*
+	Request body of -d, --data-binary and --data-urlencode.
+	Contents of files are not included. It adds warnings for them.
+*/
+func bodyText(options *common.CurlOptions) string {
+	var fragments []string
+	for _, data := range options.ProcessedData {
+		switch data.Type {
+		case common.DataAsciiType, common.DataBinaryType:
+			if data.UseExternalFile() {
+				options.AddWarning("Content of %s is not included.", data.FileName())
+				continue
+			}
+			fragments = append(fragments, data.Value)
+		case common.DataUrlEncodeType:
+			// curl's rule: "content", "=content", "name=content", "@filename" and "name@filename"
+			index := strings.IndexAny(data.Value, "=@")
+			if index == -1 {
+				fragments = append(fragments, escape(data.Value))
+			} else if data.Value[index] == '@' {
+				options.AddWarning("Content of %s is not included.", data.Value[index+1:])
+			} else if index == 0 {
+				fragments = append(fragments, escape(data.Value[1:]))
+			} else {
+				fragments = append(fragments, data.Value[:index+1]+escape(data.Value[index+1:]))
+			}
+		}
+	}
+	return strings.Join(fragments, "&")
+}
+
+/*
+	Returns file name when the body is a single file sent as is.
+*/
+func singleFile(options *common.CurlOptions) string {
+	if len(options.ProcessedData) != 1 {
+		return ""
+	}
+	data := &options.ProcessedData[0]
+	if data.Type == common.DataBinaryType && data.UseExternalFile() {
+		return data.FileName()
+	}
+	return ""
+}
+
+/*
+	Fields of -F and --form-string.
+*/
+func multipartFields(options *common.CurlOptions) []field {
+	var result []field
+	for _, data := range options.ProcessedData {
+		fragments := strings.SplitN(data.Value, "=", 2)
+		item := field{Name: fragments[0], Value: fragments[1]}
+		if data.UseExternalFile() {
+			item.Value = ""
+			item.IsFile = data.SendAsFormFile()
+			parameters := strings.Split(fragments[1][1:], ";")
+			item.FileName = parameters[0]
+			item.SentFileName = parameters[0]
+			for _, parameter := range parameters[1:] {
+				if strings.HasPrefix(parameter, "filename=") {
+					item.SentFileName = parameter[9:]
+				} else if strings.HasPrefix(parameter, "type=") {
+					item.ContentType = parameter[5:]
+				}
+			}
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+/*
+	URL that curl requests. -G appends data to the query string.
+*/
+func requestUrl(options *common.CurlOptions) string {
+	if !options.Get || !options.ProcessedData.HasData() {
+		return options.Url
+	}
+	separator := "?"
+	if strings.Contains(options.Url, "?") {
+		separator = "&"
+	}
+	return options.Url + separator + bodyText(options)
+}
+
+/*
+	Content-Type header, or the value curl sends by default.
+*/
+func contentType(options *common.CurlOptions) string {
+	if value := options.FindContentTypeHeader(); value != "" {
+		return value
+	}
+	if options.Get {
+		return ""
+	}
+	if options.ProcessedData.HasData() {
+		return "application/x-www-form-urlencoded"
+	}
+	if options.ProcessedData.HasForm() {
+		return "multipart/form-data"
+	}
+	return ""
+}
+
+func unsupported(options *common.CurlOptions, format string) {
+	if options.Proxy != "" {
+		options.AddWarning("%s doesn't have proxy setting. -x is ignored.", format)
+	}
+	if options.CookieJar != "" || len(options.CookieFiles()) > 0 {
+		options.AddWarning("%s can't refer cookie files. -b FILE and -c are ignored.", format)
+	}
+	if options.ConnectTimeout != 0 || options.MaxTime != 0 {
+		options.AddWarning("%s doesn't have timeout setting. --connect-timeout and -m are ignored.", format)
+	}
+	if options.AWSV2 != "" {
+		options.AddWarning("%s doesn't support AWS V2 signature. --awsv2 is ignored.", format)
+	}
+}
@@ -0,0 +1,180 @@
+package export
+
+import (
+	"encoding/base64"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"net/url"
+)
+
+/*
+	HTTP Archive 1.2 (http://www.softwareishard.com/blog/har-12-spec/)
+	It has one entry. The request is not sent, so the response is empty.
+*/
+type harLog struct {
+	Log struct {
+		Version string     `json:"version"`
+		Creator harCreator `json:"creator"`
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            int         `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	Url         string         `json:"url"`
+	HttpVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harPostData struct {
+	MimeType string     `json:"mimeType"`
+	Params   []harParam `json:"params"`
+	Text     string     `json:"text"`
+}
+
+type harParam struct {
+	Name        string `json:"name"`
+	Value       string `json:"value,omitempty"`
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HttpVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     struct {
+		Size     int    `json:"size"`
+		MimeType string `json:"mimeType"`
+	} `json:"content"`
+	RedirectURL string `json:"redirectURL"`
+	HeadersSize int    `json:"headersSize"`
+	BodySize    int    `json:"bodySize"`
+}
+
+type harTimings struct {
+	Send    int `json:"send"`
+	Wait    int `json:"wait"`
+	Receive int `json:"receive"`
+}
+
+func (self *harRequest) SetPostData(options *common.CurlOptions) {
+	postData := &harPostData{MimeType: contentType(options), Params: []harParam{}}
+	if options.ProcessedData.HasForm() {
+		for _, item := range multipartFields(options) {
+			param := harParam{Name: item.Name, Value: item.Value}
+			if item.IsFile {
+				param.FileName = item.SentFileName
+				param.ContentType = item.ContentType
+				if param.ContentType == "" {
+					param.ContentType = "application/octet-stream"
+				}
+			} else if item.FileName != "" {
+				options.AddWarning("Content of %s is not included.", item.FileName)
+			}
+			postData.Params = append(postData.Params, param)
+		}
+	} else {
+		if canUseFormPairs(options) {
+			for _, item := range formFields(options) {
+				postData.Params = append(postData.Params, harParam{Name: item.Name, Value: item.Value})
+			}
+		}
+		postData.Text = bodyText(options)
+	}
+	self.PostData = postData
+}
+
+/*
+	Headers that curl sends. Authorization header is added for Basic authentication.
+*/
+func (self *harRequest) SetHeaders(options *common.CurlOptions) {
+	for _, header := range options.Headers() {
+		self.Headers = append(self.Headers, harNameValue{Name: header[0], Value: header[1]})
+	}
+	if options.ProcessedData.HasAnyData() && !options.Get && options.FindContentTypeHeader() == "" {
+		self.Headers = append(self.Headers, harNameValue{Name: "Content-Type", Value: contentType(options)})
+	}
+	for _, cookie := range options.Cookies() {
+		self.Cookies = append(self.Cookies, harNameValue{Name: cookie[0], Value: cookie[1]})
+	}
+	if len(self.Cookies) > 0 {
+		self.Headers = append(self.Headers, harNameValue{Name: "Cookie", Value: options.CookieString()})
+	}
+	if options.UseBasicAuth() {
+		self.Headers = append(self.Headers, harNameValue{Name: "Authorization", Value: "Basic " + base64.StdEncoding.EncodeToString([]byte(options.User))})
+	} else if options.UseDigestAuth() {
+		options.AddWarning("HAR can't have Digest authentication. It needs a challenge from the server.")
+	}
+}
+
+func ProcessCurlCommandForHar(options *common.CurlOptions) (string, interface{}, error) {
+	httpVersion := "HTTP/1.1"
+	if options.Http2Flag {
+		httpVersion = "HTTP/2"
+	}
+	request := harRequest{
+		Method:      options.Method(),
+		Url:         requestUrl(options),
+		HttpVersion: httpVersion,
+		Cookies:     []harNameValue{},
+		Headers:     []harNameValue{},
+		QueryString: []harNameValue{},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+	if u, err := url.Parse(request.Url); err == nil {
+		for _, item := range parseQuery(u.RawQuery) {
+			request.QueryString = append(request.QueryString, harNameValue{Name: item.Name, Value: item.Value})
+		}
+	}
+	request.SetHeaders(options)
+	if options.ProcessedData.HasAnyData() && !options.Get {
+		request.SetPostData(options)
+	}
+	if options.Insecure {
+		options.AddWarning("HAR doesn't have TLS setting. -k is ignored.")
+	}
+	unsupported(options, "HAR")
+
+	var log harLog
+	log.Log.Version = "1.2"
+	log.Log.Creator = harCreator{Name: "curl_as_dsl"}
+	entry := harEntry{
+		// The request is not sent. Fixed time keeps the output reproducible.
+		StartedDateTime: "1970-01-01T00:00:00.000Z",
+		Request:         request,
+	}
+	entry.Response.HttpVersion = httpVersion
+	entry.Response.Cookies = []harNameValue{}
+	entry.Response.Headers = []harNameValue{}
+	entry.Response.HeadersSize = -1
+	entry.Response.BodySize = -1
+	log.Log.Entries = []harEntry{entry}
+	return "", Document{Options: options, Content: log}, nil
+}
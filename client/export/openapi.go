@@ -0,0 +1,311 @@
+package export
+
+import (
+	"encoding/json"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+/*
+	OpenAPI 3.0 document that has a single path item.
+	Schemas are inferred from values in the command. Examples keep the values.
+*/
+type openApiDocument struct {
+	OpenApi string `json:"openapi"`
+	Info    struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Servers    []openApiServer                         `json:"servers,omitempty"`
+	Paths      map[string]map[string]*openApiOperation `json:"paths"`
+	Components *openApiComponents                      `json:"components,omitempty"`
+}
+
+type openApiServer struct {
+	Url string `json:"url"`
+}
+
+type openApiOperation struct {
+	Parameters  []openApiParameter         `json:"parameters,omitempty"`
+	RequestBody *openApiRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openApiResponse `json:"responses"`
+	Security    []map[string][]string      `json:"security,omitempty"`
+}
+
+type openApiParameter struct {
+	Name    string         `json:"name"`
+	In      string         `json:"in"`
+	Schema  *openApiSchema `json:"schema"`
+	Example interface{}    `json:"example,omitempty"`
+}
+
+type openApiRequestBody struct {
+	Content map[string]openApiMediaType `json:"content"`
+}
+
+type openApiMediaType struct {
+	Schema   *openApiSchema             `json:"schema"`
+	Encoding map[string]openApiEncoding `json:"encoding,omitempty"`
+}
+
+type openApiEncoding struct {
+	ContentType string `json:"contentType"`
+}
+
+type openApiResponse struct {
+	Description string `json:"description"`
+}
+
+type openApiSchema struct {
+	Type       string                    `json:"type,omitempty"`
+	Format     string                    `json:"format,omitempty"`
+	Nullable   bool                      `json:"nullable,omitempty"`
+	Properties map[string]*openApiSchema `json:"properties,omitempty"`
+	Items      *openApiSchema            `json:"items,omitempty"`
+	Example    interface{}               `json:"example,omitempty"`
+}
+
+type openApiComponents struct {
+	SecuritySchemes map[string]openApiSecurityScheme `json:"securitySchemes"`
+}
+
+type openApiSecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+/*
+	Value in query string, form or header as the inferred type.
+*/
+func typedValue(value string) interface{} {
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	if b, err := strconv.ParseBool(value); err == nil && (value == "true" || value == "false") {
+		return b
+	}
+	return value
+}
+
+func inferSchema(value string) *openApiSchema {
+	switch typedValue(value).(type) {
+	case int64:
+		return &openApiSchema{Type: "integer"}
+	case float64:
+		return &openApiSchema{Type: "number"}
+	case bool:
+		return &openApiSchema{Type: "boolean"}
+	default:
+		return &openApiSchema{Type: "string"}
+	}
+}
+
+/*
+	Schema of decoded JSON. Numbers are decoded as json.Number to distinguish integers.
+*/
+func inferJsonSchema(value interface{}) *openApiSchema {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		schema := &openApiSchema{Type: "object", Properties: make(map[string]*openApiSchema)}
+		for key, child := range v {
+			schema.Properties[key] = inferJsonSchema(child)
+		}
+		return schema
+	case []interface{}:
+		schema := &openApiSchema{Type: "array", Items: &openApiSchema{}}
+		if len(v) > 0 {
+			schema.Items = inferJsonSchema(v[0])
+		}
+		return schema
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return &openApiSchema{Type: "integer"}
+		}
+		return &openApiSchema{Type: "number"}
+	case bool:
+		return &openApiSchema{Type: "boolean"}
+	case nil:
+		return &openApiSchema{Nullable: true}
+	default:
+		return &openApiSchema{Type: "string"}
+	}
+}
+
+/*
+	Object schema of fields. Field that appears more than once is an array.
+*/
+func fieldsSchema(fields []field) *openApiSchema {
+	schema := &openApiSchema{Type: "object", Properties: make(map[string]*openApiSchema)}
+	for _, item := range fields {
+		var property *openApiSchema
+		if item.IsFile {
+			property = &openApiSchema{Type: "string", Format: "binary"}
+		} else {
+			property = inferSchema(item.Value)
+		}
+		if existing, ok := schema.Properties[item.Name]; ok {
+			if existing.Type != "array" {
+				existing = &openApiSchema{Type: "array", Items: existing}
+				schema.Properties[item.Name] = existing
+			}
+			continue
+		}
+		schema.Properties[item.Name] = property
+	}
+	return schema
+}
+
+func formExample(fields []field) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, item := range fields {
+		if item.IsFile || item.FileName != "" {
+			continue
+		}
+		if existing, ok := result[item.Name]; ok {
+			if values, ok := existing.([]interface{}); ok {
+				result[item.Name] = append(values, typedValue(item.Value))
+			} else {
+				result[item.Name] = []interface{}{existing, typedValue(item.Value)}
+			}
+		} else {
+			result[item.Name] = typedValue(item.Value)
+		}
+	}
+	return result
+}
+
+func requestBody(options *common.CurlOptions) *openApiRequestBody {
+	mediaType := contentType(options)
+	if index := strings.IndexByte(mediaType, ';'); index != -1 {
+		mediaType = strings.TrimSpace(mediaType[:index])
+	}
+	var content openApiMediaType
+	if options.ProcessedData.HasForm() {
+		fields := multipartFields(options)
+		content.Schema = fieldsSchema(fields)
+		content.Schema.Example = formExample(fields)
+		for _, item := range fields {
+			if item.ContentType != "" {
+				if content.Encoding == nil {
+					content.Encoding = make(map[string]openApiEncoding)
+				}
+				content.Encoding[item.Name] = openApiEncoding{ContentType: item.ContentType}
+			} else if item.FileName != "" && !item.IsFile {
+				options.AddWarning("Content of %s is not included.", item.FileName)
+			}
+		}
+	} else if fileName := singleFile(options); fileName != "" {
+		content.Schema = &openApiSchema{Type: "string", Format: "binary"}
+		if options.FindContentTypeHeader() == "" {
+			mediaType = "application/octet-stream"
+		}
+	} else if text := bodyText(options); strings.Contains(mediaType, "json") {
+		var value interface{}
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			options.AddWarning("Request body is not a valid JSON: %s", err.Error())
+			content.Schema = &openApiSchema{Type: "string", Example: text}
+		} else {
+			content.Schema = inferJsonSchema(value)
+			content.Schema.Example = value
+		}
+	} else if canUseFormPairs(options) {
+		fields := formFields(options)
+		content.Schema = fieldsSchema(fields)
+		content.Schema.Example = formExample(fields)
+	} else {
+		content.Schema = &openApiSchema{Type: "string", Example: text}
+	}
+	return &openApiRequestBody{Content: map[string]openApiMediaType{mediaType: content}}
+}
+
+/*
+	Query, header and cookie parameters.
+	OpenAPI ignores Accept, Content-Type and Authorization header parameters.
+*/
+func parameters(options *common.CurlOptions, query string) []openApiParameter {
+	var result []openApiParameter
+	indexes := make(map[string]int)
+	add := func(name, in, value string) {
+		// parameter is unique by name and location. Repeated query parameter is an array
+		if i, ok := indexes[in+":"+name]; ok {
+			parameter := &result[i]
+			if parameter.Schema.Type != "array" {
+				parameter.Schema = &openApiSchema{Type: "array", Items: parameter.Schema}
+				parameter.Example = []interface{}{parameter.Example}
+			}
+			parameter.Example = append(parameter.Example.([]interface{}), typedValue(value))
+			return
+		}
+		indexes[in+":"+name] = len(result)
+		result = append(result, openApiParameter{Name: name, In: in, Schema: inferSchema(value), Example: typedValue(value)})
+	}
+	for _, item := range parseQuery(query) {
+		add(item.Name, "query", item.Value)
+	}
+	for _, header := range options.GroupedHeaders() {
+		switch header.Key {
+		case "accept", "content-type", "authorization", "cookie":
+			continue
+		}
+		add(header.Key, "header", strings.Join(header.Values, ", "))
+	}
+	for _, cookie := range options.Cookies() {
+		add(cookie[0], "cookie", cookie[1])
+	}
+	return result
+}
+
+func ProcessCurlCommandForOpenApi(options *common.CurlOptions) (string, interface{}, error) {
+	method := strings.ToLower(options.Method())
+	switch method {
+	case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+	default:
+		return "", nil, &common.UnsupportedOptionError{Target: "openapi", Option: "-X " + options.Method(), Reason: "OpenAPI doesn't have operations of other methods"}
+	}
+	u, _ := url.Parse(requestUrl(options))
+
+	operation := &openApiOperation{
+		Parameters: parameters(options, u.RawQuery),
+		Responses:  map[string]openApiResponse{"default": {Description: "Response"}},
+	}
+	if options.ProcessedData.HasAnyData() && !options.Get {
+		operation.RequestBody = requestBody(options)
+	}
+
+	var document openApiDocument
+	document.OpenApi = "3.0.3"
+	document.Info.Title = u.Host
+	document.Info.Version = "1.0.0"
+	if u.Host != "" {
+		document.Servers = []openApiServer{{Url: u.Scheme + "://" + u.Host}}
+	}
+	if options.User != "" {
+		name, scheme := "basicAuth", "basic"
+		if options.UseDigestAuth() {
+			name, scheme = "digestAuth", "digest"
+		}
+		document.Components = &openApiComponents{SecuritySchemes: map[string]openApiSecurityScheme{name: {Type: "http", Scheme: scheme}}}
+		operation.Security = []map[string][]string{{name: {}}}
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	document.Paths = map[string]map[string]*openApiOperation{path: {method: operation}}
+
+	if options.Insecure {
+		options.AddWarning("OpenAPI doesn't have TLS setting. -k is ignored.")
+	}
+	if options.Http2Flag {
+		options.AddWarning("OpenAPI doesn't have HTTP version setting. --http2 is ignored.")
+	}
+	unsupported(options, "OpenAPI")
+	return "", Document{Options: options, Content: document}, nil
+}
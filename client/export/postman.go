@@ -0,0 +1,182 @@
+package export
+
+import (
+	"github.com/shibukawa/curl_as_dsl/common"
+	"net/url"
+	"strings"
+)
+
+/*
+	Postman Collection v2.1 (https://schema.postman.com/collection/json/v2.1.0/draft-07/docs/index.html)
+	It has one item.
+*/
+type postmanCollection struct {
+	Info struct {
+		Name   string `json:"name"`
+		Schema string `json:"schema"`
+	} `json:"info"`
+	Item []postmanItem `json:"item"`
+}
+
+type postmanItem struct {
+	Name                    string          `json:"name"`
+	Request                 postmanRequest  `json:"request"`
+	ProtocolProfileBehavior map[string]bool `json:"protocolProfileBehavior,omitempty"`
+}
+
+type postmanRequest struct {
+	Method string            `json:"method"`
+	Header []postmanKeyValue `json:"header"`
+	Url    postmanUrl        `json:"url"`
+	Body   *postmanBody      `json:"body,omitempty"`
+	Auth   *postmanAuth      `json:"auth,omitempty"`
+}
+
+type postmanKeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+type postmanUrl struct {
+	Raw      string            `json:"raw"`
+	Protocol string            `json:"protocol,omitempty"`
+	Host     []string          `json:"host,omitempty"`
+	Port     string            `json:"port,omitempty"`
+	Path     []string          `json:"path,omitempty"`
+	Query    []postmanKeyValue `json:"query,omitempty"`
+}
+
+type postmanFormData struct {
+	Key         string `json:"key"`
+	Value       string `json:"value,omitempty"`
+	Src         string `json:"src,omitempty"`
+	Type        string `json:"type"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+type postmanBody struct {
+	Mode       string            `json:"mode"`
+	Raw        string            `json:"raw,omitempty"`
+	UrlEncoded []postmanKeyValue `json:"urlencoded,omitempty"`
+	FormData   []postmanFormData `json:"formdata,omitempty"`
+	File       *struct {
+		Src string `json:"src"`
+	} `json:"file,omitempty"`
+}
+
+type postmanAuth struct {
+	Type   string            `json:"type"`
+	Basic  []postmanKeyValue `json:"basic,omitempty"`
+	Digest []postmanKeyValue `json:"digest,omitempty"`
+}
+
+func newPostmanUrl(rawUrl string) postmanUrl {
+	result := postmanUrl{Raw: rawUrl}
+	u, err := url.Parse(rawUrl)
+	if err != nil || u.Host == "" {
+		return result
+	}
+	result.Protocol = u.Scheme
+	result.Host = strings.Split(u.Hostname(), ".")
+	result.Port = u.Port()
+	if path := strings.TrimPrefix(u.EscapedPath(), "/"); path != "" {
+		result.Path = strings.Split(path, "/")
+	}
+	for _, item := range parseQuery(u.RawQuery) {
+		result.Query = append(result.Query, postmanKeyValue{Key: item.Name, Value: item.Value})
+	}
+	return result
+}
+
+/*
+	Postman sends Content-Type of urlencoded and formdata bodies by itself.
+*/
+func (self *postmanRequest) SetBody(options *common.CurlOptions) {
+	if options.ProcessedData.HasForm() {
+		body := &postmanBody{Mode: "formdata"}
+		for _, item := range multipartFields(options) {
+			if item.IsFile {
+				if item.SentFileName != item.FileName {
+					options.AddWarning("Postman sends the file name of %s. filename=%s is ignored.", item.FileName, item.SentFileName)
+				}
+				body.FormData = append(body.FormData, postmanFormData{Key: item.Name, Src: item.FileName, Type: "file", ContentType: item.ContentType})
+			} else {
+				if item.FileName != "" {
+					options.AddWarning("Content of %s is not included.", item.FileName)
+				}
+				body.FormData = append(body.FormData, postmanFormData{Key: item.Name, Value: item.Value, Type: "text", ContentType: item.ContentType})
+			}
+		}
+		self.Body = body
+	} else if fileName := singleFile(options); fileName != "" {
+		self.Body = &postmanBody{Mode: "file", File: &struct {
+			Src string `json:"src"`
+		}{Src: fileName}}
+	} else if canUseFormPairs(options) && options.FindContentTypeHeader() == "" {
+		body := &postmanBody{Mode: "urlencoded"}
+		for _, item := range formFields(options) {
+			body.UrlEncoded = append(body.UrlEncoded, postmanKeyValue{Key: item.Name, Value: item.Value, Type: "text"})
+		}
+		self.Body = body
+	} else {
+		self.Body = &postmanBody{Mode: "raw", Raw: bodyText(options)}
+	}
+	if options.FindContentTypeHeader() == "" && self.Body.Mode != "urlencoded" && self.Body.Mode != "formdata" {
+		self.Header = append(self.Header, postmanKeyValue{Key: "Content-Type", Value: contentType(options)})
+	}
+}
+
+func (self *postmanRequest) SetAuth(options *common.CurlOptions) {
+	user, password := options.UserAndPassword()
+	parameters := []postmanKeyValue{
+		{Key: "username", Value: user, Type: "string"},
+		{Key: "password", Value: password, Type: "string"},
+	}
+	if options.UseDigestAuth() {
+		self.Auth = &postmanAuth{Type: "digest", Digest: parameters}
+	} else {
+		self.Auth = &postmanAuth{Type: "basic", Basic: parameters}
+	}
+}
+
+func ProcessCurlCommandForPostman(options *common.CurlOptions) (string, interface{}, error) {
+	requestUrl := requestUrl(options)
+	item := postmanItem{
+		Request: postmanRequest{
+			Method: options.Method(),
+			Header: []postmanKeyValue{},
+			Url:    newPostmanUrl(requestUrl),
+		},
+	}
+	for _, header := range options.Headers() {
+		item.Request.Header = append(item.Request.Header, postmanKeyValue{Key: header[0], Value: header[1]})
+	}
+	if len(options.Cookies()) > 0 {
+		item.Request.Header = append(item.Request.Header, postmanKeyValue{Key: "Cookie", Value: options.CookieString()})
+	}
+	if options.ProcessedData.HasAnyData() && !options.Get {
+		item.Request.SetBody(options)
+	}
+	if options.User != "" {
+		item.Request.SetAuth(options)
+	}
+	if options.Insecure {
+		item.ProtocolProfileBehavior = map[string]bool{"strictSSL": false}
+	}
+	if options.Http2Flag {
+		options.AddWarning("Postman collection doesn't have HTTP version setting. --http2 is ignored.")
+	}
+	unsupported(options, "Postman collection")
+
+	u := options.ParsedUrl()
+	item.Name = options.Method() + " " + u.EscapedPath()
+	if u.EscapedPath() == "" {
+		item.Name += "/"
+	}
+	var collection postmanCollection
+	collection.Info.Name = u.Host
+	collection.Info.Schema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+	collection.Item = []postmanItem{item}
+	return "", Document{Options: options, Content: collection}, nil
+}
@@ -0,0 +1,717 @@
+package fetch
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/literal"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+	Type annotations of helper functions are written in block comments starting with "/*:".
+	They are enabled in TypeScript and removed in JavaScript.
+*/
+var typeAnnotation = regexp.MustCompile(`/\*(: [^*]+)\*/`)
+
+type FileInput struct {
+	Id       string
+	FileName string
+}
+
+type FetchGenerator struct {
+	Options    *common.CurlOptions
+	Node       bool
+	TypeScript bool
+	// Function is true in modules. The code is written in functions even for Node.js.
+	Function bool
+
+	Url                string
+	FileInputs         []FileInput
+	declarations       []string
+	imports            map[string]map[string]bool
+	prepare            []string
+	headers            [][]string
+	init               []string
+	dispatcher         []string
+	body               string
+	useHeadersVariable bool
+	useUrlEncode       bool
+	timer              bool
+}
+
+func NewFetchGenerator(options *common.CurlOptions, node, typeScript bool) *FetchGenerator {
+	result := &FetchGenerator{Options: options, Node: node, TypeScript: typeScript}
+	result.Url = literal.JavaScript(options.Url)
+	result.imports = make(map[string]map[string]bool)
+	return result
+}
+
+//--- Getter methods called from template
+
+func (self FetchGenerator) Imports() string {
+	var modules []string
+	for module := range self.imports {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	var buffer bytes.Buffer
+	for _, module := range modules {
+		var names []string
+		for name := range self.imports[module] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(&buffer, "import { %s } from \"%s\";\n", strings.Join(names, ", "), module)
+	}
+	if buffer.Len() > 0 {
+		buffer.WriteString("\n")
+	}
+	return buffer.String()
+}
+
+/*
+	undici is needed only when the request uses custom dispatcher.
+*/
+func (self FetchGenerator) Dependencies() string {
+	if _, ok := self.imports["undici"]; ok {
+		return "// npm install undici\n"
+	}
+	return ""
+}
+
+func (self FetchGenerator) Declaration() string {
+	if len(self.declarations) == 0 {
+		return ""
+	}
+	declaration := strings.TrimPrefix(strings.Join(self.declarations, ""), "\n") + "\n"
+	if self.TypeScript {
+		return typeAnnotation.ReplaceAllString(declaration, "$1")
+	}
+	return typeAnnotation.ReplaceAllString(declaration, "")
+}
+
+func (self FetchGenerator) indent() string {
+	if self.Node && !self.Function {
+		return ""
+	}
+	return "    "
+}
+
+func (self FetchGenerator) Prepare() string {
+	var buffer bytes.Buffer
+	for _, line := range self.prepare {
+		fmt.Fprintf(&buffer, "%s\n%s", strings.Replace(line, "\n", "\n"+self.indent(), -1), self.indent())
+	}
+	if self.useHeadersVariable && len(self.headers) == 0 {
+		fmt.Fprintf(&buffer, "const headers%s = {};\n%s", self.typed(": Record<string, string>"), self.indent())
+	} else if self.useHeadersVariable {
+		fmt.Fprintf(&buffer, "const headers%s = {\n", self.typed(": Record<string, string>"))
+		for _, header := range self.headers {
+			fmt.Fprintf(&buffer, "%s    %s: %s,\n", self.indent(), literal.JavaScript(header[0]), header[1])
+		}
+		fmt.Fprintf(&buffer, "%s};\n%s", self.indent(), self.indent())
+	}
+	return buffer.String()
+}
+
+/*
+	The second parameter of fetch(). It is omitted when the request is simple GET.
+*/
+func (self FetchGenerator) Init() string {
+	indent := self.indent()
+	var lines []string
+	method := self.Options.Method()
+	if method != "GET" {
+		lines = append(lines, fmt.Sprintf("method: %s,", literal.JavaScript(method)))
+	}
+	if self.useHeadersVariable {
+		lines = append(lines, "headers,")
+	} else if len(self.headers) > 0 {
+		lines = append(lines, "headers: {")
+		for _, header := range self.headers {
+			lines = append(lines, fmt.Sprintf("    %s: %s,", literal.JavaScript(header[0]), header[1]))
+		}
+		lines = append(lines, "},")
+	}
+	if self.body != "" {
+		lines = append(lines, fmt.Sprintf("body: %s,", self.body))
+	}
+	lines = append(lines, self.init...)
+	if len(self.dispatcher) > 0 {
+		lines = append(lines, "dispatcher,")
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString(", {\n")
+	for _, line := range lines {
+		fmt.Fprintf(&buffer, "%s    %s\n", indent, line)
+	}
+	fmt.Fprintf(&buffer, "%s}", indent)
+	return buffer.String()
+}
+
+func (self FetchGenerator) Let() string {
+	if self.Options.UseDigestAuth() && self.Node {
+		return "let"
+	}
+	return "const"
+}
+
+/*
+	fetch() doesn't answer Digest challenge. Send the request again with Digest authorization header.
+*/
+func (self FetchGenerator) Authenticate() string {
+	if !self.Options.UseDigestAuth() || !self.Node {
+		return ""
+	}
+	user, password := self.Options.UserAndPassword()
+	indent := self.indent()
+	var buffer bytes.Buffer
+	buffer.WriteString("if (response.status === 401) {\n")
+	fmt.Fprintf(&buffer, "%s    const uri = new URL(response.url);\n", indent)
+	fmt.Fprintf(&buffer, "%s    headers[\"Authorization\"] = digestAuthorization(response.headers.get(\"WWW-Authenticate\") ?? \"\", %s, uri.pathname + uri.search, %s, %s);\n",
+		indent, literal.JavaScript(self.Options.Method()), literal.JavaScript(user), literal.JavaScript(password))
+	fmt.Fprintf(&buffer, "%s    response = await fetch(%s%s);\n", indent, self.Url, strings.Replace(self.Init(), "\n", "\n    ", -1))
+	fmt.Fprintf(&buffer, "%s}\n%s", indent, indent)
+	return buffer.String()
+}
+
+func (self FetchGenerator) SaveCookies() string {
+	if self.Options.CookieJar == "" || !self.Node {
+		return ""
+	}
+	return fmt.Sprintf("await saveCookies(%s, cookies, response);\n%s", literal.JavaScript(self.Options.CookieJar), self.indent())
+}
+
+func (self FetchGenerator) TearDown() string {
+	if !self.timer {
+		return ""
+	}
+	return fmt.Sprintf("\n%sclearTimeout(timer);", self.indent())
+}
+
+//--- Preparing JavaScript source code methods
+
+func (self *FetchGenerator) typed(annotation string) string {
+	if self.TypeScript {
+		return annotation
+	}
+	return ""
+}
+
+func (self *FetchGenerator) addImport(module string, names ...string) {
+	if _, ok := self.imports[module]; !ok {
+		self.imports[module] = make(map[string]bool)
+	}
+	for _, name := range names {
+		self.imports[module][name] = true
+	}
+}
+
+/*
+	Browser reads files from <input type="file">. Node.js reads them from file system.
+*/
+func (self *FetchGenerator) fileInputId(fileName string) string {
+	for _, input := range self.FileInputs {
+		if input.FileName == fileName {
+			return input.Id
+		}
+	}
+	if len(self.FileInputs) == 0 {
+		self.declarations = append(self.declarations, `
+function selectedFile(id) {
+    const file = document.getElementById(id).files[0];
+    if (!file) {
+        throw new Error("select file of #" + id);
+    }
+    return file;
+}
+`)
+	}
+	id := fmt.Sprintf("file%d", len(self.FileInputs)+1)
+	self.FileInputs = append(self.FileInputs, FileInput{Id: id, FileName: fileName})
+	return id
+}
+
+func (self *FetchGenerator) fileAsText(fileName string) string {
+	if self.Node {
+		self.addImport("node:fs/promises", "readFile")
+		return fmt.Sprintf("await readFile(%s, \"utf8\")", literal.JavaScript(fileName))
+	}
+	return fmt.Sprintf("await selectedFile(%s).text()", literal.JavaScript(self.fileInputId(fileName)))
+}
+
+func (self *FetchGenerator) fileAsBlob(fileName, contentType string) string {
+	if self.Node {
+		self.addImport("node:fs", "openAsBlob")
+		if contentType != "" {
+			return fmt.Sprintf("await openAsBlob(%s, { type: %s })", literal.JavaScript(fileName), literal.JavaScript(contentType))
+		}
+		return fmt.Sprintf("await openAsBlob(%s)", literal.JavaScript(fileName))
+	}
+	return fmt.Sprintf("selectedFile(%s)", literal.JavaScript(self.fileInputId(fileName)))
+}
+
+/*
+	URLSearchParams can't express "content", "=content" and "name@filename" of --data-urlencode.
+*/
+func (self *FetchGenerator) canUseSearchParams() bool {
+	if !self.Options.CanUseSimpleForm() {
+		return false
+	}
+	for _, data := range self.Options.ProcessedData {
+		if data.Type == common.DataUrlEncodeType {
+			if name, _, isFile := data.UrlEncodeParts(); name == "" || isFile {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (self *FetchGenerator) searchParams() [][]string {
+	var result [][]string
+	for _, data := range self.Options.ProcessedData {
+		if data.Type == common.DataUrlEncodeType {
+			result = append(result, strings.SplitN(data.Value, "=", 2))
+			continue
+		}
+		for _, pair := range strings.Split(data.Value, "&") {
+			if pair == "" {
+				continue
+			}
+			fragments := strings.SplitN(pair, "=", 2)
+			key, _ := url.QueryUnescape(fragments[0])
+			value, _ := url.QueryUnescape(fragments[1])
+			result = append(result, []string{key, value})
+		}
+	}
+	return result
+}
+
+func (self *FetchGenerator) SetDataForUrl() error {
+	if self.canUseSearchParams() {
+		self.prepare = append(self.prepare, fmt.Sprintf("const url = new URL(%s);", literal.JavaScript(self.Options.Url)))
+		for _, pair := range self.searchParams() {
+			self.prepare = append(self.prepare, fmt.Sprintf("url.searchParams.append(%s, %s);", literal.JavaScript(pair[0]), literal.JavaScript(pair[1])))
+		}
+	} else {
+		separator := "?"
+		if strings.Contains(self.Options.Url, "?") {
+			separator = "&"
+		}
+		query, err := self.stringBody()
+		if err != nil {
+			return err
+		}
+		self.prepare = append(self.prepare, fmt.Sprintf("const url = %s + %s;", literal.JavaScript(self.Options.Url+separator), query))
+	}
+	self.Url = "url"
+	return nil
+}
+
+func (self *FetchGenerator) SetDataForBody() error {
+	if self.canUseSearchParams() {
+		var buffer bytes.Buffer
+		buffer.WriteString("new URLSearchParams([\n")
+		for _, pair := range self.searchParams() {
+			fmt.Fprintf(&buffer, "%s        [%s, %s],\n", self.indent(), literal.JavaScript(pair[0]), literal.JavaScript(pair[1]))
+		}
+		fmt.Fprintf(&buffer, "%s    ])", self.indent())
+		self.body = buffer.String()
+		return nil
+	}
+	if len(self.Options.ProcessedData) == 1 {
+		data := &self.Options.ProcessedData[0]
+		if data.Type == common.DataBinaryType && data.UseExternalFile() {
+			self.body = self.fileAsBlob(data.Value[1:], "")
+			if data.Upload && !self.Node {
+				// File of the input has the type of the extension. Blob without type doesn't send Content-Type like -T
+				self.body = "new Blob([" + self.body + "])"
+			}
+			return nil
+		}
+	}
+	body, err := self.stringBody()
+	if err != nil {
+		return err
+	}
+	self.body = body
+	return nil
+}
+
+func (self *FetchGenerator) stringBody() (string, error) {
+	if len(self.Options.ProcessedData) == 1 {
+		return self.dataExpression(&self.Options.ProcessedData[0])
+	}
+	var values []string
+	for _, data := range self.Options.ProcessedData {
+		value, err := self.dataExpression(&data)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, value)
+	}
+	return fmt.Sprintf("[%s].join(\"&\")", strings.Join(values, ", ")), nil
+}
+
+func (self *FetchGenerator) dataExpression(data *common.DataOption) (string, error) {
+	switch data.Type {
+	case common.DataAsciiType:
+		if data.UseExternalFile() {
+			return fmt.Sprintf("(%s).replace(/[\\r\\n]/g, \"\")", self.fileAsText(data.Value[1:])), nil
+		}
+		return literal.JavaScript(data.Value), nil
+	case common.DataBinaryType:
+		if data.UseExternalFile() {
+			return self.fileAsText(data.Value[1:]), nil
+		}
+		return literal.JavaScript(data.Value), nil
+	case common.DataUrlEncodeType:
+		name, content, isFile := data.UrlEncodeParts()
+		if isFile {
+			content = self.fileAsText(content)
+		} else {
+			content = literal.JavaScript(content)
+		}
+		if name != "" {
+			return fmt.Sprintf("%s + %s(%s)", literal.JavaScript(name+"="), self.urlEncode(), content), nil
+		}
+		return fmt.Sprintf("%s(%s)", self.urlEncode(), content), nil
+	default:
+		return "", common.UnexpectedDataError("fetch", data)
+	}
+}
+
+/*
+	encodeURIComponent() doesn't encode !'()* and encodes spaces as %20. curl encodes them and sends spaces as "+".
+*/
+func (self *FetchGenerator) urlEncode() string {
+	if !self.useUrlEncode {
+		self.useUrlEncode = true
+		self.declarations = append(self.declarations, `
+function urlEncode(text/*: string*/)/*: string*/ {
+    return encodeURIComponent(text)
+        .replace(/[!'()*]/g, (char) => "%" + char.charCodeAt(0).toString(16).toUpperCase())
+        .replace(/%20/g, "+");
+}
+`)
+	}
+	return "urlEncode"
+}
+
+/*
+	FormData sets Content-Type header with boundary. Text fields can't have their own Content-Type.
+*/
+func (self *FetchGenerator) SetFormForBody() {
+	self.prepare = append(self.prepare, "const form = new FormData();")
+	for _, data := range self.Options.ProcessedData {
+		field := strings.SplitN(data.Value, "=", 2)
+		if data.Type == common.FormType && (strings.HasPrefix(field[1], "@") || strings.HasPrefix(field[1], "<")) {
+			fragments := strings.Split(field[1][1:], ";")
+			contentType := ""
+			sentFileName := fragments[0]
+			for _, fragment := range fragments[1:] {
+				if strings.HasPrefix(fragment, "filename=") {
+					sentFileName = fragment[9:]
+				} else if strings.HasPrefix(fragment, "type=") {
+					contentType = fragment[5:]
+				}
+			}
+			if field[1][0] == '@' {
+				if !self.Node && contentType != "" {
+					self.Options.AddWarning("Browser sends the file type of %s. type=%s is ignored.", fragments[0], contentType)
+				} else if contentType == "" && common.FormFileContentType(fragments[0]) != "application/octet-stream" {
+					// openAsBlob() without type is sent as application/octet-stream
+					contentType = common.FormFileContentType(fragments[0])
+				}
+				self.prepare = append(self.prepare, fmt.Sprintf("form.append(%s, %s, %s);", literal.JavaScript(field[0]), self.fileAsBlob(fragments[0], contentType), literal.JavaScript(sentFileName)))
+			} else {
+				if contentType != "" {
+					self.Options.AddWarning("FormData can't set Content-Type of text field. type=%s is ignored.", contentType)
+				}
+				self.Options.AddWarning("FormData converts newlines of text field to CRLF. Newlines of %s are sent as CRLF.", fragments[0])
+				self.prepare = append(self.prepare, fmt.Sprintf("form.append(%s, %s);", literal.JavaScript(field[0]), self.fileAsText(fragments[0])))
+			}
+		} else {
+			self.prepare = append(self.prepare, fmt.Sprintf("form.append(%s, %s);", literal.JavaScript(field[0]), literal.JavaScript(field[1])))
+		}
+	}
+	self.body = "form"
+}
+
+func (self *FetchGenerator) AddDigestCode() {
+	self.addImport("node:crypto", "createHash", "randomBytes")
+	self.useHeadersVariable = true
+	self.declarations = append(self.declarations, `
+function digestAuthorization(challenge/*: string*/, method/*: string*/, uri/*: string*/, username/*: string*/, password/*: string*/)/*: string*/ {
+    const params/*: Record<string, string>*/ = {};
+    for (const [, key, quoted, plain] of challenge.matchAll(/(\w+)=(?:"([^"]*)"|([^,\s]*))/g)) {
+        params[key] = quoted ?? plain;
+    }
+    const md5Hex = (text/*: string*/) => createHash("md5").update(text).digest("hex");
+    const ha1 = md5Hex([username, params.realm, password].join(":"));
+    const ha2 = md5Hex([method, uri].join(":"));
+    let authorization = `+"`"+`Digest username="${username}", realm="${params.realm}", nonce="${params.nonce}", uri="${uri}"`+"`"+`;
+    if (params.qop) {
+        const cnonce = randomBytes(8).toString("hex");
+        const response = md5Hex([ha1, params.nonce, "00000001", cnonce, "auth", ha2].join(":"));
+        authorization += `+"`"+`, qop=auth, nc=00000001, cnonce="${cnonce}", response="${response}"`+"`"+`;
+    } else {
+        authorization += `+"`"+`, response="${md5Hex([ha1, params.nonce, ha2].join(":"))}"`+"`"+`;
+    }
+    if (params.opaque) {
+        authorization += `+"`"+`, opaque="${params.opaque}"`+"`"+`;
+    }
+    return authorization + ", algorithm=MD5";
+}
+`)
+}
+
+/*
+	fetch() of Node.js doesn't have cookie jar. Cookie files are read and written in Netscape format.
+*/
+func (self *FetchGenerator) AddCookieCode() {
+	self.addImport("node:fs/promises", "readFile")
+	self.declarations = append(self.declarations, `
+// fields of Netscape cookie file: domain, include subdomains, path, secure, expires, name, value
+async function loadCookies(fileName/*: string*/)/*: Promise<string[][]>*/ {
+    // curl ignores missing cookie file
+    const text = await readFile(fileName, "utf8").catch(() => "");
+    return text.split(/\r?\n/)
+        .map((line) => line.split("\t"))
+        .filter((fields) => fields.length === 7 && (!fields[0].startsWith("#") || fields[0].startsWith("#HttpOnly_")));
+}
+
+function cookieHeader(cookies/*: string[][]*/, url/*: string*/)/*: string*/ {
+    const { hostname, pathname, protocol } = new URL(url);
+    const now = Date.now() / 1000;
+    return cookies.filter(([domain, includeSubdomains, path, secure, expires]) => {
+        const host = domain.replace(/^#HttpOnly_/, "").replace(/^\./, "");
+        const domainMatched = hostname === host || (includeSubdomains === "TRUE" && hostname.endsWith("." + host));
+        return domainMatched && pathname.startsWith(path) && (protocol === "https:" || secure !== "TRUE") && (expires === "0" || Number(expires) > now);
+    }).map((fields) => fields[5] + "=" + fields[6]).join("; ");
+}
+`)
+	if self.Options.CookieJar != "" {
+		self.addImport("node:fs/promises", "writeFile")
+		self.declarations = append(self.declarations, `
+async function saveCookies(fileName/*: string*/, cookies/*: string[][]*/, response/*: Response*/) {
+    const host = new URL(response.url).hostname;
+    for (const setCookie of response.headers.getSetCookie()) {
+        const [pair, ...attributes] = setCookie.split(";").map((fragment) => fragment.trim());
+        const index = pair.indexOf("=");
+        const [name, value] = [pair.slice(0, index), pair.slice(index + 1)];
+        const options/*: Record<string, string>*/ = {};
+        for (const attribute of attributes) {
+            const [key, ...rest] = attribute.split("=");
+            options[key.toLowerCase()] = rest.join("=");
+        }
+        const domain = options.domain ? "." + options.domain.replace(/^\./, "") : host;
+        let expires = "0";
+        if (options["max-age"]) {
+            expires = String(Math.floor(Date.now() / 1000) + Number(options["max-age"]));
+        } else if (options.expires) {
+            expires = String(Math.floor(Date.parse(options.expires) / 1000));
+        }
+        const fields = [("httponly" in options ? "#HttpOnly_" : "") + domain, options.domain ? "TRUE" : "FALSE", options.path || "/",
+            "secure" in options ? "TRUE" : "FALSE", expires, name, value];
+        const old = cookies.findIndex((cookie) => cookie[0] === fields[0] && cookie[2] === fields[2] && cookie[5] === name);
+        cookies.splice(old === -1 ? cookies.length : old, 1, fields);
+    }
+    const lines = cookies.map((fields) => fields.join("\t") + "\n");
+    await writeFile(fileName, "# Netscape HTTP Cookie File\n" + lines.join(""));
+}
+`)
+	}
+	var files []string
+	for _, fileName := range self.Options.CookieFiles() {
+		files = append(files, fmt.Sprintf("...await loadCookies(%s)", literal.JavaScript(fileName)))
+	}
+	self.prepare = append(self.prepare, fmt.Sprintf("const cookies = [%s];", strings.Join(files, ", ")))
+	if len(self.Options.Cookies()) == 0 {
+		self.headers = append(self.headers, []string{"Cookie", fmt.Sprintf("cookieHeader(cookies, %s)", self.urlString())})
+	} else {
+		self.headers = append(self.headers, []string{"Cookie", fmt.Sprintf("[cookieHeader(cookies, %s), %s].filter(Boolean).join(\"; \")", self.urlString(), literal.JavaScript(self.Options.CookieString()))})
+	}
+}
+
+func (self *FetchGenerator) urlString() string {
+	if self.Url == "url" && self.canUseSearchParams() {
+		return "url.href"
+	}
+	return self.Url
+}
+
+/*
+	Browsers don't allow scripts to set Cookie header and to access cookie files.
+	Cookies are passed via document.cookie and browser's cookie storage is used as a cookie jar.
+*/
+func (self *FetchGenerator) SetBrowserCookie() {
+	if self.Options.UseCookieJar() {
+		self.Options.AddWarning("Browser can't read or write cookie files. Browser's cookie storage is used instead.")
+	}
+	for _, cookie := range self.Options.Cookies() {
+		self.prepare = append(self.prepare, fmt.Sprintf("document.cookie = %s;", literal.JavaScript(cookie[0]+"="+cookie[1])))
+	}
+	self.init = append(self.init, "credentials: \"include\",")
+}
+
+/*
+	fetch() of Node.js is implemented by undici. Proxy, -k and HTTP/2 are options of its dispatcher.
+*/
+func (self *FetchGenerator) SetDispatcher() {
+	tls := "connect"
+	className := "Agent"
+	if self.Options.Proxy != "" {
+		proxy := self.Options.Proxy
+		if !strings.Contains(proxy, "://") {
+			proxy = "http://" + proxy
+		}
+		u, _ := url.Parse(proxy)
+		if strings.HasPrefix(u.Scheme, "socks") {
+			self.Options.AddWarning("undici doesn't support SOCKS proxy. %s is ignored.", self.Options.Proxy)
+		} else {
+			if u.Port() == "" {
+				// curl uses 1080 as default proxy port
+				u.Host = u.Host + ":1080"
+			}
+			className = "ProxyAgent"
+			tls = "requestTls"
+			self.dispatcher = append(self.dispatcher, fmt.Sprintf("uri: %s,", literal.JavaScript(u.String())))
+		}
+	}
+	if self.Options.ConnectTimeout != 0 {
+		self.dispatcher = append(self.dispatcher, fmt.Sprintf("connect: { timeout: %s },", milliseconds(self.Options.ConnectTimeout)))
+	}
+	if self.Options.Insecure {
+		if tls == "connect" && self.Options.ConnectTimeout != 0 {
+			self.dispatcher[len(self.dispatcher)-1] = fmt.Sprintf("connect: { timeout: %s, rejectUnauthorized: false },", milliseconds(self.Options.ConnectTimeout))
+		} else {
+			self.dispatcher = append(self.dispatcher, fmt.Sprintf("%s: { rejectUnauthorized: false },", tls))
+		}
+	}
+	if self.Options.Http2Flag {
+		self.dispatcher = append(self.dispatcher, "allowH2: true,")
+	}
+	if len(self.dispatcher) == 0 {
+		return
+	}
+	self.addImport("undici", className)
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "const dispatcher = new %s({\n", className)
+	for _, line := range self.dispatcher {
+		fmt.Fprintf(&buffer, "    %s\n", line)
+	}
+	buffer.WriteString("});")
+	self.prepare = append(self.prepare, buffer.String())
+}
+
+/*
+	AbortController stops the request and reading response body when -m seconds passed.
+	Functions can't clear the timer because the caller reads the body. They use AbortSignal.timeout() instead.
+*/
+func (self *FetchGenerator) SetTimeout() {
+	if self.Function {
+		self.init = append(self.init, fmt.Sprintf("signal: AbortSignal.timeout(%s),", milliseconds(self.Options.MaxTime)))
+		return
+	}
+	self.prepare = append(self.prepare, "const controller = new AbortController();")
+	self.prepare = append(self.prepare, fmt.Sprintf("const timer = setTimeout(() => controller.abort(), %s);", milliseconds(self.Options.MaxTime)))
+	self.init = append(self.init, "signal: controller.signal,")
+	self.timer = true
+}
+
+func milliseconds(seconds float64) string {
+	return strconv.FormatInt(int64(seconds*1000), 10)
+}
+
+func processFetchCommand(options *common.CurlOptions, node, typeScript bool) (string, interface{}, error) {
+	generator := NewFetchGenerator(options, node, typeScript)
+	if err := processFetchRequest(generator); err != nil {
+		return "", nil, err
+	}
+	return "full", *generator, nil
+}
+
+func processFetchRequest(generator *FetchGenerator) error {
+	options := generator.Options
+	node := generator.Node
+	if options.ProcessedData.HasData() {
+		if options.Get {
+			if err := generator.SetDataForUrl(); err != nil {
+				return err
+			}
+		} else {
+			if !generator.canUseSearchParams() {
+				options.InsertDataContentTypeHeader()
+			}
+			if err := generator.SetDataForBody(); err != nil {
+				return err
+			}
+		}
+	} else if options.ProcessedData.HasForm() {
+		generator.SetFormForBody()
+	}
+	for _, header := range options.GroupedHeaders() {
+		generator.headers = append(generator.headers, []string{header.Key, literal.JavaScript(strings.Join(header.Values, ", "))})
+	}
+	if options.UseBasicAuth() {
+		generator.headers = append(generator.headers, []string{"Authorization", fmt.Sprintf("\"Basic \" + btoa(%s)", literal.JavaScript(options.User))})
+	} else if options.UseDigestAuth() {
+		if node {
+			generator.AddDigestCode()
+		} else {
+			options.AddWarning("fetch() can't answer Digest authentication. Browser asks user name and password instead.")
+		}
+	}
+	if node {
+		if options.UseCookieJar() {
+			generator.AddCookieCode()
+		} else if len(options.Cookies()) != 0 {
+			generator.headers = append(generator.headers, []string{"Cookie", literal.JavaScript(options.CookieString())})
+		}
+		generator.SetDispatcher()
+	} else {
+		if options.UseCookieJar() || len(options.Cookies()) != 0 {
+			generator.SetBrowserCookie()
+		}
+		if options.Proxy != "" {
+			options.AddWarning("Browser uses its own proxy settings. %s is ignored.", options.Proxy)
+		}
+		if options.Insecure {
+			options.AddWarning("Browser doesn't allow scripts to skip certificate verification. -k is ignored.")
+		}
+		if options.ConnectTimeout != 0 {
+			options.AddWarning("fetch() doesn't have connection timeout. --connect-timeout is ignored.")
+		}
+	}
+	if options.MaxTime != 0 {
+		generator.SetTimeout()
+	}
+
+	return nil
+}
+
+/*
+	Dispatcher functions of curl command
+	These are exported functions and called from httpgen.
+*/
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
+	return processFetchCommand(options, false, false)
+}
+
+func ProcessCurlCommandForNode(options *common.CurlOptions) (string, interface{}, error) {
+	return processFetchCommand(options, true, false)
+}
+
+func ProcessCurlCommandForTypeScript(options *common.CurlOptions) (string, interface{}, error) {
+	return processFetchCommand(options, true, true)
+}
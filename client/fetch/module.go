@@ -0,0 +1,163 @@
+package fetch
+
+import (
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"regexp"
+	"strings"
+)
+
+/*
+	Request in a module of Node.js. It is written as an async function that returns the response.
+*/
+type FetchFunction struct {
+	FetchGenerator
+	Name  string
+	Title string
+}
+
+/*
+	Module that has functions of requests. Imports and helper functions like digestAuthorization() are shared.
+*/
+type FetchModule struct {
+	Functions  []FetchFunction
+	TypeScript bool
+	HasMain    bool
+
+	header FetchGenerator
+}
+
+var reservedNames = map[string]bool{
+	// variables of generated functions and main
+	"response": true, "url": true, "headers": true, "form": true, "cookies": true, "dispatcher": true,
+	"uri": true, "send": true, "main": true,
+	// globals
+	"fetch": true, "process": true, "console": true, "btoa": true, "encodeuricomponent": true, "urlsearchparams": true,
+	"formdata": true, "abortsignal": true,
+	// keywords
+	"await": true, "break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"debugger": true, "default": true, "delete": true, "do": true, "else": true, "enum": true, "export": true,
+	"extends": true, "false": true, "finally": true, "for": true, "function": true, "if": true, "import": true,
+	"in": true, "instanceof": true, "let": true, "new": true, "null": true, "return": true, "static": true,
+	"super": true, "switch": true, "this": true, "throw": true, "true": true, "try": true, "typeof": true,
+	"var": true, "void": true, "while": true, "with": true, "yield": true,
+}
+
+/*
+	Name of the function parameter like "userId" for the parameter "user_id".
+*/
+func ParameterName(name string) string {
+	result := common.CamelCase(common.IdentifierWords(name))
+	if reservedNames[strings.ToLower(result)] {
+		result += "Value"
+	}
+	return result
+}
+
+/*
+	Parameters of the function like "userId, token". TypeScript has their types.
+*/
+func (self FetchFunction) Arguments() string {
+	var arguments []string
+	for _, parameter := range self.Options.FunctionParameters() {
+		arguments = append(arguments, ParameterName(parameter.Name)+self.typed(": string"))
+	}
+	return strings.Join(arguments, ", ")
+}
+
+func (self FetchFunction) ReturnType() string {
+	return self.typed(": Promise<Response>")
+}
+
+/*
+	Function that main calls. Parameters are read from environment variables.
+*/
+func (self FetchFunction) Sender() string {
+	if len(self.Options.FunctionParameters()) == 0 {
+		return self.Name
+	}
+	var arguments []string
+	for _, parameter := range self.Options.FunctionParameters() {
+		if self.TypeScript {
+			arguments = append(arguments, fmt.Sprintf("(process.env.%s ?? \"\")", parameter.EnvironmentVariable()))
+		} else {
+			arguments = append(arguments, fmt.Sprintf("process.env.%s", parameter.EnvironmentVariable()))
+		}
+	}
+	return fmt.Sprintf("() => %s(%s)", self.Name, strings.Join(arguments, ", "))
+}
+
+func (self FetchModule) Dependencies() string {
+	return self.header.Dependencies()
+}
+
+func (self FetchModule) Imports() string {
+	return self.header.Imports()
+}
+
+func (self FetchModule) Declaration() string {
+	return self.header.Declaration()
+}
+
+var declaredFunction = regexp.MustCompile(`function (\w+)\(`)
+
+/*
+	Create a module of fetch() of Node.js from requests. Each function returns the response and the caller reads the body.
+	main code runs only when the file is executed directly. packageName is ignored because the module is the file.
+*/
+func processFetchCommandsForModule(requests []common.NamedRequest, typeScript, hasMain bool) (string, interface{}, error) {
+	module := FetchModule{TypeScript: typeScript, HasMain: hasMain}
+	module.header = *NewFetchGenerator(&common.CurlOptions{}, true, typeScript)
+	if hasMain {
+		module.header.addImport("node:url", "pathToFileURL")
+	}
+	used := map[string]bool{"pathtofileurl": true}
+	for name := range reservedNames {
+		used[name] = true
+	}
+	helpers := make(map[string]string)
+	for i := range requests {
+		generator := NewFetchGenerator(requests[i].Options, true, typeScript)
+		generator.Function = true
+		if err := processFetchRequest(generator); err != nil {
+			return "", nil, err
+		}
+		for name, names := range generator.imports {
+			for importedName := range names {
+				module.header.addImport(name, importedName)
+				used[strings.ToLower(importedName)] = true
+			}
+		}
+		for _, declaration := range generator.declarations {
+			name := declaredFunction.FindStringSubmatch(declaration)[1]
+			if existing, ok := helpers[name]; !ok {
+				helpers[name] = declaration
+				for _, match := range declaredFunction.FindAllStringSubmatch(declaration, -1) {
+					used[strings.ToLower(match[1])] = true
+				}
+				module.header.declarations = append(module.header.declarations, declaration)
+			} else if existing != declaration {
+				generator.Options.AddWarning("%s() is shared by all requests. The one of the first request is used.", name)
+			}
+		}
+		module.Functions = append(module.Functions, FetchFunction{FetchGenerator: *generator})
+	}
+	// names are decided after all helpers are known
+	for i := range module.Functions {
+		names := append(append([]string(nil), requests[i].Groups...), requests[i].Name)
+		module.Functions[i].Name = common.UniqueName(common.CamelCase(common.IdentifierWords(names...)), used)
+		module.Functions[i].Title = strings.Join(names, " / ")
+	}
+	return "module", module, nil
+}
+
+/*
+	Module dispatcher functions. These are called from generator.GenerateModule().
+*/
+func ProcessCurlCommandsForNodeModule(requests []common.NamedRequest, packageName string, hasMain bool) (string, interface{}, error) {
+	return processFetchCommandsForModule(requests, false, hasMain)
+}
+
+func ProcessCurlCommandsForTypeScriptModule(requests []common.NamedRequest, packageName string, hasMain bool) (string, interface{}, error) {
+	return processFetchCommandsForModule(requests, true, hasMain)
+}
@@ -1,23 +1,21 @@
 package golang
 
 import (
-	"fmt"
 	"github.com/shibukawa/curl_as_dsl/common"
-	"mime"
-	"strings"
+	"github.com/shibukawa/curl_as_dsl/literal"
 )
 
-func escapeDQ(src string) string {
-	return strings.Replace(strings.Replace(src, "\"", "\\\"", -1), "\\", "\\\\", -1)
-}
-
 func ClientNeeded(options *common.CurlOptions) bool {
-	if options.Insecure || options.Proxy != "" || options.User != "" || len(options.Cookie) > 0 {
+	if options.Insecure || options.Proxy != "" || options.User != "" || len(options.Cookie) > 0 || options.CookieJar != "" {
 		return true
 	}
 	if len(options.AWSV2) > 0 {
 		return true
 	}
+	// http.Post() always sends Content-Type, but curl doesn't send it for -T
+	if options.ProcessedData.UploadFile() != "" {
+		return true
+	}
 	if options.OnlyHasContentTypeHeader() {
 		method := options.Method()
 		if method != "GET" && method != "POST" {
@@ -32,8 +30,18 @@ func ClientNeeded(options *common.CurlOptions) bool {
 	Dispatcher function of curl command
 	This is an exported function and called from httpgen.
 */
-func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}) {
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
+	templateName, generator, err := processCurlCommand(options)
+	if err != nil {
+		return "", nil, err
+	}
+	if templateName == "" {
+		return "", nil, &common.UnsupportedOptionError{Target: "go", Option: "-X " + options.Method(), Reason: "the method can't send data with these options"}
+	}
+	return templateName, generator, nil
+}
 
+func processCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
 	generator := NewGoGenerator(options)
 
 	if ClientNeeded(options) {
@@ -47,7 +55,7 @@ func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}) {
 		if options.ProcessedData.HasData() {
 			if options.Get {
 				return processCurlPostDataWithUrl(generator)
-			} else if len(options.ProcessedData) == 1 && options.ProcessedData[0].UseExternalFile() {
+			} else if len(options.ProcessedData) == 1 && options.ProcessedData[0].Type == common.DataBinaryType && options.ProcessedData[0].UseExternalFile() {
 				return processCurlPostSingleFile(generator)
 			} else {
 				return processCurlPostData(generator)
@@ -71,20 +79,24 @@ func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}) {
 		return processCurlSimple(generator)
 	}
 
-	return "", nil
+	return "", nil, nil
 }
 
-func processCurlFullFeatureRequest(generator *GoGenerator) (string, interface{}) {
+func processCurlFullFeatureRequest(generator *GoGenerator) (string, interface{}, error) {
 	options := generator.Options
 
 	if options.ProcessedData.HasData() {
 		if options.Get {
-			generator.SetDataForUrl()
+			if err := generator.SetDataForUrl(); err != nil {
+				return "", nil, err
+			}
 			generator.DataVariable = "nil"
 		} else {
 			generator.DataVariable = "&buffer"
-			generator.Options.InsertContentTypeHeader("application/x-www-form-urlencoded")
-			generator.SetDataForBody()
+			generator.Options.InsertDataContentTypeHeader()
+			if err := generator.SetDataForBody(); err != nil {
+				return "", nil, err
+			}
 		}
 	} else if options.ProcessedData.HasForm() {
 		generator.DataVariable = "&buffer"
@@ -96,12 +108,32 @@ func processCurlFullFeatureRequest(generator *GoGenerator) (string, interface{})
 	if options.Proxy != "" {
 		generator.Modules["net/url"] = true
 	}
-	if options.User != "" {
+	if options.UseBasicAuth() {
 		generator.Modules["encoding/base64"] = true
 	}
+	if options.UseDigestAuth() {
+		generator.Modules["crypto/md5"] = true
+		generator.Modules["crypto/rand"] = true
+		generator.Modules["encoding/hex"] = true
+		generator.Modules["fmt"] = true
+		generator.Modules["regexp"] = true
+		generator.Modules["strings"] = true
+	}
 	if options.Insecure {
 		generator.Modules["crypto/tls"] = true
 	}
+	if options.UseCookieJar() {
+		generator.Modules["net/http/cookiejar"] = true
+		generator.Modules["net/url"] = true
+		generator.Modules["strings"] = true
+		generator.Modules["strconv"] = true
+		generator.Modules["time"] = true
+		generator.Modules["io/ioutil"] = true
+		if options.CookieJar != "" {
+			generator.Modules["bytes"] = true
+			generator.Modules["fmt"] = true
+		}
+	}
 	if generator.Options.AWSV2 != "" {
 		generator.Modules["encoding/base64"] = true
 		generator.Modules["crypto/hmac"] = true
@@ -109,30 +141,30 @@ func processCurlFullFeatureRequest(generator *GoGenerator) (string, interface{})
 		generator.Modules["time"] = true
 		generator.Modules["fmt"] = true
 	}
-	return "full", *generator
+	return "full", *generator, nil
 }
 
-func processCurlPostSingleFile(generator *GoGenerator) (string, interface{}) {
+func processCurlPostSingleFile(generator *GoGenerator) (string, interface{}, error) {
 	fileName := generator.Options.ProcessedData[0].Value[1:]
 	contentType := ""
 	headers := generator.Options.Headers()
 	if len(headers) > 0 {
 		contentType = headers[0][1]
 	} else {
-		contentType = mime.TypeByExtension(fileName)
+		contentType = "application/x-www-form-urlencoded"
 	}
 	var value struct {
 		Url         string
 		FilePath    string
 		ContentType string
 	}
-	value.Url = fmt.Sprintf("\"%s\"", generator.Options.Url)
-	value.FilePath = fileName
-	value.ContentType = contentType
-	return "post_single_file", value
+	value.Url = literal.Go(generator.Options.Url)
+	value.FilePath = literal.Go(fileName)
+	value.ContentType = literal.Go(contentType)
+	return "post_single_file", value, nil
 }
 
-func processCurlPostData(generator *GoGenerator) (string, interface{}) {
+func processCurlPostData(generator *GoGenerator) (string, interface{}, error) {
 	var contentType string
 	headers := generator.Options.Headers()
 	if len(headers) > 0 {
@@ -141,7 +173,7 @@ func processCurlPostData(generator *GoGenerator) (string, interface{}) {
 	if !generator.Options.ProcessedData.HasForm() && generator.Options.CanUseSimpleForm() && contentType == "" {
 		generator.Modules["net/url"] = true
 		generator.SetDataForPostForm()
-		return "post_form", generator
+		return "post_form", generator, nil
 	}
 	if generator.Options.ProcessedData.HasForm() {
 		generator.DataVariable = "&buffer"
@@ -150,29 +182,37 @@ func processCurlPostData(generator *GoGenerator) (string, interface{}) {
 		generator.HasBoundary = true
 	} else {
 		generator.DataVariable = "&buffer"
-		contentType = "application/x-www-form-urlencoded"
-		generator.SetDataForBody()
+		if contentType == "" {
+			contentType = "application/x-www-form-urlencoded"
+		}
+		if err := generator.SetDataForBody(); err != nil {
+			return "", nil, err
+		}
 	}
 
 	generator.ContentType = contentType
-	return "post_text", *generator
+	return "post_text", *generator, nil
 }
 
-func processCurlPostDataWithUrl(generator *GoGenerator) (string, interface{}) {
-	generator.SetDataForUrl()
-	return "post_with_data_url", *generator
+func processCurlPostDataWithUrl(generator *GoGenerator) (string, interface{}, error) {
+	if err := generator.SetDataForUrl(); err != nil {
+		return "", nil, err
+	}
+	return "post_with_data_url", *generator, nil
 }
 
-func processCurlGetDataWithUrl(generator *GoGenerator) (string, interface{}) {
-	generator.SetDataForUrl()
-	return "get_with_data_url", *generator
+func processCurlGetDataWithUrl(generator *GoGenerator) (string, interface{}, error) {
+	if err := generator.SetDataForUrl(); err != nil {
+		return "", nil, err
+	}
+	return "get_with_data_url", *generator, nil
 }
 
-func processCurlSimple(generator *GoGenerator) (string, interface{}) {
+func processCurlSimple(generator *GoGenerator) (string, interface{}, error) {
 	method := generator.Options.Method()
 	if method == "GET" {
-		return "simple_get", *generator
+		return "simple_get", *generator, nil
 	} else { // "POST"
-		return "simple_post", *generator
+		return "simple_post", *generator, nil
 	}
 }
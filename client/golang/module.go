@@ -0,0 +1,183 @@
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"regexp"
+	"strings"
+)
+
+/*
+	Request in a module. It is written as a function that takes a context and a shared client.
+*/
+type GoFunction struct {
+	GoGenerator
+	Name  string
+	Title string
+}
+
+func (self GoFunction) Comment() string {
+	return fmt.Sprintf("// %s sends %q.", self.Name, self.Title)
+}
+
+/*
+	Parameters of the request after the context and the client like ", userId string".
+*/
+func (self GoFunction) Arguments() string {
+	var buffer bytes.Buffer
+	for _, parameter := range self.Options.FunctionParameters() {
+		fmt.Fprintf(&buffer, ", %s string", ParameterName(parameter.Name))
+	}
+	return buffer.String()
+}
+
+/*
+	Function that main() calls. Parameters are read from environment variables.
+*/
+func (self GoFunction) Sender() string {
+	if len(self.Options.FunctionParameters()) == 0 {
+		return self.Name
+	}
+	var arguments []string
+	for _, parameter := range self.Options.FunctionParameters() {
+		arguments = append(arguments, fmt.Sprintf("os.Getenv(%q)", parameter.EnvironmentVariable()))
+	}
+	return fmt.Sprintf("func(ctx context.Context, client *http.Client) (*http.Response, error) {\nreturn %s(ctx, client, %s)\n}", self.Name, strings.Join(arguments, ", "))
+}
+
+var reservedNames = map[string]bool{
+	// variables of generated functions
+	"ctx": true, "client": true, "request": true, "resp": true, "err": true, "buffer": true, "writer": true,
+	"part": true, "file": true, "content": true, "values": true, "jar": true, "body": true,
+	// packages
+	"bytes": true, "context": true, "http": true, "io": true, "ioutil": true, "log": true, "multipart": true,
+	"os": true, "url": true, "strings": true, "fmt": true,
+	// keywords
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true, "defer": true,
+	"else": true, "fallthrough": true, "for": true, "func": true, "go": true, "goto": true, "if": true,
+	"import": true, "interface": true, "map": true, "package": true, "range": true, "return": true,
+	"select": true, "struct": true, "switch": true, "type": true, "var": true,
+}
+
+/*
+	Name of the function parameter like "userId" for the parameter "user_id".
+*/
+func ParameterName(name string) string {
+	result := common.CamelCase(common.IdentifierWords(name))
+	if reservedNames[result] {
+		result += "Value"
+	}
+	return result
+}
+
+/*
+	Module that has functions of requests. NewClient() creates the client shared by all functions.
+*/
+type GoModule struct {
+	Package   string
+	Modules   map[string]bool
+	Client    GoGenerator
+	Functions []GoFunction
+	HasMain   bool
+
+	declarations []string
+}
+
+func (self GoModule) AdditionalDeclaration() string {
+	return strings.Join(self.declarations, "")
+}
+
+var declaredFunction = regexp.MustCompile(`func (\w+)\(`)
+
+/*
+	Create a module from requests. Options of requests should be checked by CheckError() already.
+	Clients of all requests are merged into one. When they conflict, the first request wins with a warning.
+	When hasMain is true, main() calls all functions in order.
+*/
+func ProcessCurlCommandsForModule(requests []common.NamedRequest, packageName string, hasMain bool) (string, interface{}, error) {
+	module := GoModule{
+		Package: packageName,
+		Modules: map[string]bool{"context": true, "net/http": true},
+		HasMain: hasMain,
+	}
+	if module.HasMain {
+		module.Modules["log"] = true
+		module.Modules["io/ioutil"] = true
+	}
+	clientOptions := &common.CurlOptions{}
+	clientOptions.Init()
+	used := map[string]bool{"main": true, "newclient": true}
+	helpers := make(map[string]string)
+
+	for i := range requests {
+		options := requests[i].Options
+		generator := NewGoGenerator(options)
+		generator.Function = true
+		generator.Modules = map[string]bool{"net/http": true}
+		if _, _, err := processCurlFullFeatureRequest(generator); err != nil {
+			return "", nil, err
+		}
+		for key := range generator.Modules {
+			module.Modules[key] = true
+		}
+		for _, declaration := range generator.Declarations() {
+			name := declaredFunction.FindStringSubmatch(declaration)[1]
+			if existing, ok := helpers[name]; !ok {
+				helpers[name] = declaration
+				used[strings.ToLower(name)] = true
+				module.declarations = append(module.declarations, declaration)
+			} else if existing != declaration {
+				options.AddWarning("%s() is shared by all requests. The one of the first request is used.", name)
+			}
+		}
+
+		if options.Insecure && !clientOptions.Insecure {
+			clientOptions.Insecure = true
+			if i > 0 {
+				options.AddWarning("The module shares one client. -k is applied to all requests.")
+			}
+		}
+		if options.Proxy != "" {
+			if clientOptions.Proxy == "" {
+				clientOptions.Proxy = options.Proxy
+			} else if clientOptions.Proxy != options.Proxy {
+				options.AddWarning("The module shares one client. Proxy %s of the first request is used.", clientOptions.Proxy)
+			}
+		}
+		for _, fileName := range options.CookieFiles() {
+			if !contains(clientOptions.Cookie, fileName) {
+				clientOptions.Cookie = append(clientOptions.Cookie, fileName)
+			}
+		}
+		if options.CookieJar != "" && clientOptions.CookieJar == "" {
+			clientOptions.CookieJar = options.CookieJar
+		}
+
+		if hasMain && len(options.FunctionParameters()) > 0 {
+			module.Modules["os"] = true
+		}
+		names := append(append([]string(nil), requests[i].Groups...), requests[i].Name)
+		module.Functions = append(module.Functions, GoFunction{
+			GoGenerator: *generator,
+			Title:       strings.Join(names, " / "),
+		})
+	}
+	// names are decided after all helpers are known
+	for i := range module.Functions {
+		names := append(append([]string(nil), requests[i].Groups...), requests[i].Name)
+		module.Functions[i].Name = common.UniqueName(common.PascalCase(common.IdentifierWords(names...)), used)
+	}
+	module.Client = *NewGoGenerator(clientOptions)
+	module.Client.Function = true
+	return "module", module, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -2,11 +2,10 @@ package golang
 
 import (
 	"fmt"
-	"os"
 	//"log"
 	"bytes"
 	"github.com/shibukawa/curl_as_dsl/common"
-	"net/url"
+	"github.com/shibukawa/curl_as_dsl/literal"
 	"strings"
 )
 
@@ -19,6 +18,9 @@ type GoGenerator struct {
 	ContentType  string
 	HasBoundary  bool
 
+	// Function is true when the request is written in a function that returns errors instead of main()
+	Function bool
+
 	extraUrl string
 }
 
@@ -32,14 +34,41 @@ func NewGoGenerator(options *common.CurlOptions) *GoGenerator {
 	return result
 }
 
+/*
+	Statement for errors. Functions return them to callers.
+*/
+func (self GoGenerator) fatal() string {
+	if self.Function {
+		return "return nil, err"
+	}
+	return "log.Fatal(err)"
+}
+
+func (self GoGenerator) jar() string {
+	if self.Function {
+		return "client.Jar"
+	}
+	return "jar"
+}
+
 //--- Getter methods called from template
 
 func (self GoGenerator) Url() string {
-	return fmt.Sprintf("\"%s\"%s", self.Options.Url, self.extraUrl)
+	return literal.Go(self.Options.Url) + self.extraUrl
+}
+
+/*
+	Content type argument of http.Post(). Multipart forms have the boundary of the writer.
+*/
+func (self GoGenerator) PostContentType() string {
+	if self.HasBoundary {
+		return literal.Go(self.ContentType+"; boundary=") + " + writer.Boundary()"
+	}
+	return literal.Go(self.ContentType)
 }
 
 func (self GoGenerator) Method() string {
-	return self.Options.Method()
+	return literal.Go(self.Options.Method())
 }
 
 func (self GoGenerator) FilePath() string {
@@ -47,26 +76,60 @@ func (self GoGenerator) FilePath() string {
 }
 
 func (self GoGenerator) PrepareClient() string {
+	var buffer bytes.Buffer
 	if self.Options.Proxy != "" {
-		return fmt.Sprintf("proxyUrl, err := url.Parse(\"%s\")", self.Options.Proxy)
+		if self.Function {
+			// NewClient() doesn't return errors. The URL is checked by CheckError()
+			fmt.Fprintf(&buffer, "proxyUrl, _ := url.Parse(%s)", literal.Go(self.Options.Proxy))
+		} else {
+			fmt.Fprintf(&buffer, "proxyUrl, err := url.Parse(%s)", literal.Go(self.Options.Proxy))
+		}
 	}
-	return ""
+	if self.Options.UseCookieJar() {
+		if buffer.Len() > 0 {
+			buffer.WriteString("\n")
+		}
+		if self.Function {
+			// cookiejar.New() never fails without options
+			buffer.WriteString("jar, _ := cookiejar.New(nil)\n")
+		} else {
+			buffer.WriteString("jar, err := cookiejar.New(nil)\n")
+			buffer.WriteString("if err != nil {\n")
+			buffer.WriteString("    log.Fatal(err)\n")
+			buffer.WriteString("}\n")
+		}
+		for _, fileName := range self.Options.CookieFiles() {
+			fmt.Fprintf(&buffer, "LoadCookies(jar, %s)\n", literal.Go(fileName))
+		}
+	}
+	return buffer.String()
 }
 
 func (self GoGenerator) ClientBody() string {
-	if !self.Options.Insecure && self.Options.Proxy == "" {
-		return "{}"
+	var fields []string
+	if self.Options.Insecure || self.Options.Proxy != "" {
+		var buffer bytes.Buffer
+		buffer.WriteString(`Transport: &http.Transport{`)
+		if self.Options.Insecure {
+			buffer.WriteString("TLSClientConfig: &tls.Config{InsecureSkipVerify: true},\n")
+		}
+		if self.Options.Proxy != "" {
+			buffer.WriteString("Proxy: http.ProxyURL(proxyUrl),\n")
+		}
+		buffer.WriteString("}")
+		fields = append(fields, buffer.String())
 	}
-	var buffer bytes.Buffer
-	buffer.WriteString(`{Transport: &http.Transport{`)
-	if self.Options.Insecure {
-		buffer.WriteString("TLSClientConfig: &tls.Config{InsecureSkipVerify: true},\n")
+	if self.Options.UseCookieJar() {
+		fields = append(fields, "Jar: jar")
 	}
-	if self.Options.Proxy != "" {
-		buffer.WriteString("Proxy: http.ProxyURL(proxyUrl),\n")
+	return "{" + strings.Join(fields, ",\n") + "}"
+}
+
+func (self GoGenerator) SaveCookies() string {
+	if self.Options.CookieJar == "" {
+		return ""
 	}
-	buffer.WriteString("}}")
-	return buffer.String()
+	return fmt.Sprintf("\nif err := SaveCookies(%s, request.URL, %s); err != nil {\n    %s\n}", self.jar(), literal.Go(self.Options.CookieJar), self.fatal())
 }
 
 func (self GoGenerator) ModifyRequest() string {
@@ -83,40 +146,147 @@ func (self GoGenerator) ModifyRequest() string {
 			key := strings.TrimSpace(headers[0])
 			value := strings.TrimSpace(headers[1])
 			if strings.ToLower(key) == "content-type" && self.HasBoundary {
-				fmt.Fprintf(&buffer, "request.Header.Add(\"%s\", \"%s; boundary=\" + writer.Boundary())\n", key, value)
+				fmt.Fprintf(&buffer, "request.Header.Add(%s, %s + writer.Boundary())\n", literal.Go(key), literal.Go(value+"; boundary="))
 			} else {
-				fmt.Fprintf(&buffer, "request.Header.Add(\"%s\", \"%s\")\n", key, value)
+				fmt.Fprintf(&buffer, "request.Header.Add(%s, %s)\n", literal.Go(key), literal.Go(value))
 			}
 		}
 	}
 
-	if self.Options.User != "" {
-		fmt.Fprintf(&buffer, "request.Header.Add(\"Authorization\", \"Basic \" + base64.StdEncoding.EncodeToString([]byte(\"%s\")))\n", self.Options.User)
+	if self.Options.UseBasicAuth() {
+		fmt.Fprintf(&buffer, "request.Header.Add(\"Authorization\", \"Basic \" + base64.StdEncoding.EncodeToString([]byte(%s)))\n", literal.Go(self.Options.User))
 	}
 
-	for _, cookie := range self.Options.Cookie {
-		fragments := strings.SplitN(cookie, "=", 2)
-		if len(fragments) == 2 {
-			name := strings.TrimSpace(fragments[0])
-			value := strings.TrimSpace(fragments[1])
-			fmt.Fprintf(&buffer, "request.AddCookie(&http.Cookie{Name: \"%s\", Value: \"%s\"})\n", name, value)
-		}
+	for _, cookie := range self.Options.Cookies() {
+		fmt.Fprintf(&buffer, "request.AddCookie(&http.Cookie{Name: %s, Value: %s})\n", literal.Go(cookie[0]), literal.Go(cookie[1]))
 	}
 
 	if self.Options.AWSV2 != "" {
-		buffer.WriteString(fmt.Sprintf("SignAWSV2(request, \"\", \"%s\")\n", self.ContentType))
+		fmt.Fprintf(&buffer, "SignAWSV2(request, \"\", %s)\n", literal.Go(self.ContentType))
 	}
 
 	return buffer.String()
 }
 
-func (self GoGenerator) AdditionalDeclaration() string {
+/*
+	Retry the request with Digest authorization header when server returns a challenge.
+*/
+func (self GoGenerator) Authenticate() string {
+	if !self.Options.UseDigestAuth() {
+		return ""
+	}
+	user, password := self.Options.UserAndPassword()
 	var buffer bytes.Buffer
+	buffer.WriteString("if resp.StatusCode == http.StatusUnauthorized {\n")
+	buffer.WriteString("    resp.Body.Close()\n")
+	fmt.Fprintf(&buffer, "    request.Header.Set(\"Authorization\", DigestAuthorization(resp.Header.Get(\"WWW-Authenticate\"), request.Method, request.URL.RequestURI(), %s, %s))\n", literal.Go(user), literal.Go(password))
+	if self.DataVariable == "file" {
+		// *os.File body is closed by client.Do(). Open it again.
+		fmt.Fprintf(&buffer, "    request.Body, err = os.Open(%s)\n", literal.Go(self.Options.ProcessedData[0].Value[1:]))
+		buffer.WriteString("    if err != nil {\n")
+		fmt.Fprintf(&buffer, "        %s\n", self.fatal())
+		buffer.WriteString("    }\n")
+	} else if self.DataVariable != "nil" {
+		buffer.WriteString("    request.Body, _ = request.GetBody()\n")
+	}
+	buffer.WriteString("    resp, err = client.Do(request)\n")
+	buffer.WriteString("    if err != nil {\n")
+	fmt.Fprintf(&buffer, "        %s\n", self.fatal())
+	buffer.WriteString("    }\n")
+	buffer.WriteString("}\n")
+	return buffer.String()
+}
+
+func (self GoGenerator) AdditionalDeclaration() string {
+	return strings.Join(self.Declarations(), "")
+}
+
+/*
+	Helper functions used by the request. Each item has one function.
+	Modules share them between requests.
+*/
+func (self GoGenerator) Declarations() []string {
+	var result []string
+
+	if self.Options.UseDigestAuth() {
+		result = append(result, `
+			func DigestAuthorization(challenge, method, uri, username, password string) string {
+				params := make(map[string]string)
+				for _, match := range regexp.MustCompile("(\\w+)=(?:\"([^\"]*)\"|([^,\\s]*))").FindAllStringSubmatch(challenge, -1) {
+					params[match[1]] = match[2] + match[3]
+				}
+				md5Hex := func(text string) string {
+					return fmt.Sprintf("%x", md5.Sum([]byte(text)))
+				}
+				ha1 := md5Hex(strings.Join([]string{username, params["realm"], password}, ":"))
+				ha2 := md5Hex(method + ":" + uri)
+				authorization := fmt.Sprintf("Digest username=\"%s\", realm=\"%s\", nonce=\"%s\", uri=\"%s\"", username, params["realm"], params["nonce"], uri)
+				if params["qop"] != "" {
+					cnonce := make([]byte, 8)
+					rand.Read(cnonce)
+					response := md5Hex(strings.Join([]string{ha1, params["nonce"], "00000001", hex.EncodeToString(cnonce), "auth", ha2}, ":"))
+					authorization += fmt.Sprintf(", qop=auth, nc=00000001, cnonce=\"%x\", response=\"%s\"", cnonce, response)
+				} else {
+					authorization += fmt.Sprintf(", response=\"%s\"", md5Hex(strings.Join([]string{ha1, params["nonce"], ha2}, ":")))
+				}
+				if params["opaque"] != "" {
+					authorization += fmt.Sprintf(", opaque=\"%s\"", params["opaque"])
+				}
+				return authorization + ", algorithm=MD5"
+			}
+		`)
+	}
+
+	if self.Options.UseCookieJar() {
+		result = append(result, `
+			func LoadCookies(jar http.CookieJar, fileName string) {
+				content, err := ioutil.ReadFile(fileName)
+				if err != nil {
+					// curl ignores missing cookie file
+					return
+				}
+				for _, line := range strings.Split(string(content), "\n") {
+					line = strings.TrimPrefix(strings.TrimSpace(line), "#HttpOnly_")
+					fields := strings.Split(line, "\t")
+					if strings.HasPrefix(line, "#") || len(fields) != 7 {
+						continue
+					}
+					cookie := &http.Cookie{Name: fields[5], Value: fields[6], Path: fields[2], Secure: fields[3] == "TRUE"}
+					if fields[1] == "TRUE" {
+						cookie.Domain = fields[0]
+					}
+					if expires, _ := strconv.ParseInt(fields[4], 10, 64); expires > 0 {
+						cookie.Expires = time.Unix(expires, 0)
+					}
+					scheme := "http"
+					if cookie.Secure {
+						scheme = "https"
+					}
+					jar.SetCookies(&url.URL{Scheme: scheme, Host: strings.TrimPrefix(fields[0], "."), Path: fields[2]}, []*http.Cookie{cookie})
+				}
+			}
+		`)
+	}
+
+	if self.Options.CookieJar != "" {
+		result = append(result, `
+			func SaveCookies(jar http.CookieJar, u *url.URL, fileName string) error {
+				var buffer bytes.Buffer
+				buffer.WriteString("# Netscape HTTP Cookie File\n")
+				// http.CookieJar only returns names and values of cookies for the URL
+				secure := strings.ToUpper(strconv.FormatBool(u.Scheme == "https"))
+				for _, cookie := range jar.Cookies(u) {
+					fmt.Fprintf(&buffer, "%s\tFALSE\t/\t%s\t0\t%s\t%s\n", u.Hostname(), secure, cookie.Name, cookie.Value)
+				}
+				return ioutil.WriteFile(fileName, buffer.Bytes(), 0644)
+			}
+		`)
+	}
 
 	if self.Options.AWSV2 != "" {
 		fragments := strings.SplitN(self.Options.AWSV2, ":", 2)
 		if len(fragments) == 2 {
-			fmt.Fprintf(&buffer, `
+			result = append(result, fmt.Sprintf(`
 				func SignAWSV2(req *http.Request, md5, contentType string) {
 					dateStr := time.Now().UTC().Format(time.RFC1123Z)
 					req.Header.Set("Date", dateStr)
@@ -124,27 +294,30 @@ func (self GoGenerator) AdditionalDeclaration() string {
 						req.Header.Set("Content-MD5", md5)
 					}
 					strToSign := fmt.Sprintf("%%s\n%%s\n%%s\n%%s\n%%s", req.Method, md5, contentType, dateStr, req.URL.Path)
-					hash := hmac.New(sha1.New, []byte("%s"))
+					hash := hmac.New(sha1.New, []byte(%s))
 					hash.Write([]byte(strToSign))
 					signature := make([]byte, base64.StdEncoding.EncodedLen(hash.Size()))
 					base64.StdEncoding.Encode(signature, hash.Sum(nil))
-					req.Header.Set("Authorization", fmt.Sprintf("AWS %%s:%%s", "%s", string(signature)))
+					req.Header.Set("Authorization", fmt.Sprintf("AWS %%s:%%s", %s, string(signature)))
 				}
-			`, fragments[1], fragments[0])
+			`, literal.Go(fragments[1]), literal.Go(fragments[0])))
 		}
 	}
 
-	return buffer.String()
+	return result
 }
 
 //--- Setter/Getter methods
 
-func (self *GoGenerator) SetDataForBody() {
+func (self *GoGenerator) SetDataForBody() error {
 	var buffer bytes.Buffer
 	if len(self.Options.ProcessedData) == 1 {
-		var body string
-		body, self.DataVariable = NewStringForData(self, &self.Options.ProcessedData[0])
+		body, name, err := NewStringForData(self, &self.Options.ProcessedData[0])
+		if err != nil {
+			return err
+		}
 		buffer.WriteString(body)
+		self.DataVariable = name
 	} else {
 		for i, data := range self.Options.ProcessedData {
 			if i > 0 {
@@ -152,23 +325,28 @@ func (self *GoGenerator) SetDataForBody() {
 			} else {
 				buffer.WriteString("var buffer bytes.Buffer\n")
 			}
-			buffer.WriteString(StringForData(self, &data))
+			code, err := StringForData(self, &data)
+			if err != nil {
+				return err
+			}
+			buffer.WriteString(code)
 		}
 		self.DataVariable = "&buffer"
 	}
 	self.Data = buffer.String()
+	return nil
 }
 
-func (self *GoGenerator) SetDataForUrl() {
+func (self *GoGenerator) SetDataForUrl() error {
 	if self.Options.CanUseSimpleForm() {
 		// Use url.Values to create URL option string
 		self.SetDataForPostForm()
 		self.extraUrl = " + \"?\" + values.Encode()"
-	} else {
-		// Use bytes.Buffer to create URL option string
-		self.SetDataForBody()
-		self.extraUrl = " + \"?\" + buffer.String()"
+		return nil
 	}
+	// Use bytes.Buffer to create URL option string
+	self.extraUrl = " + \"?\" + buffer.String()"
+	return self.SetDataForBody()
 }
 
 func (self *GoGenerator) SetFormForBody() {
@@ -183,30 +361,22 @@ func (self *GoGenerator) SetFormForBody() {
 }
 
 func (self *GoGenerator) SetDataForPostForm() {
-	entries := make(map[string][]string)
-	for _, data := range self.Options.ProcessedData {
-		singleData, _ := url.ParseQuery(data.Value)
-		for key, values := range singleData {
-			entries[key] = append(entries[key], values[0])
-		}
-	}
+	keys, entries := self.Options.ProcessedData.FormValues()
 
 	var buffer bytes.Buffer
 	count := 0
-	for key, values := range entries {
+	for _, key := range keys {
+		values := entries[key]
 		if count == 0 {
 			buffer.WriteString("values := url.Values{\n")
 		}
-		buffer.WriteString(`"` + key)
-		buffer.WriteString(`": {`)
+		buffer.WriteString(literal.Go(key))
+		buffer.WriteString(`: {`)
 		for j, value := range values {
-			if j == 0 {
-				buffer.WriteString(`"`)
-			} else {
-				buffer.WriteString(`, "`)
+			if j > 0 {
+				buffer.WriteString(`, `)
 			}
-			buffer.WriteString(value)
-			buffer.WriteString(`"`)
+			buffer.WriteString(literal.Go(value))
 		}
 		count++
 		buffer.WriteString("},\n")
@@ -218,90 +388,94 @@ func (self *GoGenerator) SetDataForPostForm() {
 	self.Modules["net/url"] = true
 }
 
-func NewStringForData(generator *GoGenerator, data *common.DataOption) (string, string) {
+func NewStringForData(generator *GoGenerator, data *common.DataOption) (string, string, error) {
 	var result string
 	var name string
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			var buffer bytes.Buffer
 			buffer.WriteString("var buffer bytes.Buffer\n")
-			fmt.Fprintf(&buffer, "content, err := ioutil.ReadFile(\"%s\")\n", data.Value[1:])
+			fmt.Fprintf(&buffer, "content, err := ioutil.ReadFile(%s)\n", literal.Go(data.Value[1:]))
 			buffer.WriteString("if err != nil {\n")
-			buffer.WriteString("    log.Fatal(err)\n")
+			fmt.Fprintf(&buffer, "    %s\n", generator.fatal())
 			buffer.WriteString("}\n")
 			buffer.WriteString("buffer.WriteString(strings.Replace(string(content), \"\\n\", \"\", -1))")
 			result = buffer.String()
 			name = "&buffer"
 			generator.Modules["strings"] = true
+			generator.Modules["io/ioutil"] = true
 		} else {
-			result = fmt.Sprintf("buffer := bytes.NewBufferString(\"%s\")\n", escapeDQ(strings.Replace(data.Value, "\n", "", -1)))
+			result = fmt.Sprintf("buffer := bytes.NewBufferString(%s)\n", literal.GoRaw(strings.Replace(data.Value, "\n", "", -1)))
 			name = "buffer"
 		}
 		generator.Modules["bytes"] = true
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			var buffer bytes.Buffer
-			fmt.Fprintf(&buffer, "file, err := os.Open(\"%s\")\n", data.Value[1:])
+			fmt.Fprintf(&buffer, "file, err := os.Open(%s)\n", literal.Go(data.Value[1:]))
 			buffer.WriteString("if err != nil {\n")
-			buffer.WriteString("    log.Fatal(err)\n")
+			fmt.Fprintf(&buffer, "    %s\n", generator.fatal())
 			buffer.WriteString("}\n")
 			result = buffer.String()
 			name = "file"
 			generator.Modules["os"] = true
 		} else {
-			result = fmt.Sprintf("buffer := bytes.NewBufferString(\"%s\")\n", escapeDQ(data.Value))
+			result = fmt.Sprintf("buffer := bytes.NewBufferString(%s)\n", literal.GoRaw(data.Value))
 			name = "buffer"
 			generator.Modules["bytes"] = true
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
+		fieldName, content, isFile := data.UrlEncodeParts()
+		if isFile {
 			var buffer bytes.Buffer
 			buffer.WriteString("var buffer bytes.Buffer\n")
-			fmt.Fprintf(&buffer, "content, err := ioutil.ReadFile(\"%s\")\n", data.Value[1:])
+			fmt.Fprintf(&buffer, "content, err := ioutil.ReadFile(%s)\n", literal.Go(content))
 			buffer.WriteString("if err != nil {\n")
-			buffer.WriteString("    log.Fatal(err)\n")
+			fmt.Fprintf(&buffer, "    %s\n", generator.fatal())
 			buffer.WriteString("}\n")
-			buffer.WriteString("buffer.WriteString(url.QueryEscape(string(content)))")
+			fmt.Fprintf(&buffer, "buffer.WriteString(%surl.QueryEscape(string(content)))", urlEncodeName(fieldName))
 			result = buffer.String()
 			name = "&buffer"
+			generator.Modules["io/ioutil"] = true
 		} else {
-			result = fmt.Sprintf("buffer := bytes.NewBufferString(url.QueryEscape(\"%s\"))\n", escapeDQ(data.Value))
+			result = fmt.Sprintf("buffer := bytes.NewBufferString(%surl.QueryEscape(%s))\n", urlEncodeName(fieldName), literal.GoRaw(content))
 			name = "buffer"
 		}
 		generator.Modules["bytes"] = true
 		generator.Modules["net/url"] = true
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return "", "", common.UnexpectedDataError("go", data)
 	}
-	return result, name
+	return result, name, nil
 }
 
-func StringForData(generator *GoGenerator, data *common.DataOption) string {
+func StringForData(generator *GoGenerator, data *common.DataOption) (string, error) {
 	var result string
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			var buffer bytes.Buffer
 			buffer.WriteString("{\n")
-			fmt.Fprintf(&buffer, "content, err := ioutil.ReadFile(\"%s\")\n", data.Value[1:])
+			fmt.Fprintf(&buffer, "content, err := ioutil.ReadFile(%s)\n", literal.Go(data.Value[1:]))
 			buffer.WriteString("if err != nil {\n")
-			buffer.WriteString("    log.Fatal(err)\n")
+			fmt.Fprintf(&buffer, "    %s\n", generator.fatal())
 			buffer.WriteString("}\n")
 			buffer.WriteString("buffer.WriteString(strings.Replace(string(content), \"\\n\", \"\", -1))\n")
 			buffer.WriteString("}\n")
 			result = buffer.String()
 			generator.Modules["strings"] = true
+			generator.Modules["io/ioutil"] = true
 		} else {
-			result = fmt.Sprintf("    buffer.WriteString(\"%s\")\n", escapeDQ(strings.Replace(data.Value, "\n", "", -1)))
+			result = fmt.Sprintf("    buffer.WriteString(%s)\n", literal.GoRaw(strings.Replace(data.Value, "\n", "", -1)))
 		}
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			var buffer bytes.Buffer
 			buffer.WriteString("{\n")
-			fmt.Fprintf(&buffer, "file, err := os.Open(\"%s\")\n", data.Value[1:])
+			fmt.Fprintf(&buffer, "file, err := os.Open(%s)\n", literal.Go(data.Value[1:]))
 			buffer.WriteString("if err != nil {\n")
-			buffer.WriteString("    log.Fatal(err)\n")
+			fmt.Fprintf(&buffer, "    %s\n", generator.fatal())
 			buffer.WriteString("}\n")
 			buffer.WriteString("io.Copy(&buffer, file)\n")
 			buffer.WriteString("}\n")
@@ -309,28 +483,40 @@ func StringForData(generator *GoGenerator, data *common.DataOption) string {
 			generator.Modules["os"] = true
 			generator.Modules["io"] = true
 		} else {
-			result = fmt.Sprintf("buffer.WriteString(\"%s\")\n", escapeDQ(data.Value))
+			result = fmt.Sprintf("buffer.WriteString(%s)\n", literal.GoRaw(data.Value))
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
+		name, content, isFile := data.UrlEncodeParts()
+		if isFile {
 			var buffer bytes.Buffer
 			buffer.WriteString("{\n")
-			fmt.Fprintf(&buffer, "content, err := ioutil.ReadFile(\"%s\")\n", data.Value[1:])
+			fmt.Fprintf(&buffer, "content, err := ioutil.ReadFile(%s)\n", literal.Go(content))
 			buffer.WriteString("if err != nil {\n")
-			buffer.WriteString("    log.Fatal(err)\n")
+			fmt.Fprintf(&buffer, "    %s\n", generator.fatal())
 			buffer.WriteString("}\n")
-			buffer.WriteString("buffer.WriteString(url.QueryEscape(string(content)))\n")
+			fmt.Fprintf(&buffer, "buffer.WriteString(%surl.QueryEscape(string(content)))\n", urlEncodeName(name))
 			buffer.WriteString("}\n")
 			result = buffer.String()
+			generator.Modules["io/ioutil"] = true
 		} else {
-			result = fmt.Sprintf("buffer.WriteString(url.QueryEscape(\"%s\"))\n", escapeDQ(data.Value))
+			result = fmt.Sprintf("buffer.WriteString(%surl.QueryEscape(%s))\n", urlEncodeName(name), literal.GoRaw(content))
 		}
 		generator.Modules["net/url"] = true
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return "", common.UnexpectedDataError("go", data)
 	}
 	generator.Modules["bytes"] = true
-	return result
+	return result, nil
+}
+
+/*
+	curl doesn't encode the name of --data-urlencode.
+*/
+func urlEncodeName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return literal.GoRaw(name+"=") + " + "
 }
 
 func FormString(generator *GoGenerator, data *common.DataOption) string {
@@ -338,10 +524,6 @@ func FormString(generator *GoGenerator, data *common.DataOption) string {
 	switch data.Type {
 	case common.FormType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
 		if strings.HasPrefix(field[1], "@") {
 			var buffer bytes.Buffer
 			var contentType string
@@ -355,25 +537,29 @@ func FormString(generator *GoGenerator, data *common.DataOption) string {
 					contentType = fragment[5:]
 				}
 			}
+			if contentType == "" {
+				contentType = common.FormFileContentType(sourceFile)
+			}
 			buffer.WriteString("{\n")
-			if contentType != "" {
+			// CreateFormFile() sends application/octet-stream
+			if contentType != "application/octet-stream" {
 				buffer.WriteString("header := make(textproto.MIMEHeader)\n")
-				fmt.Fprintf(&buffer, "header.Add(\"Content-Disposition\", \"form-data; name=\\\"%s\\\"; filename=\\\"%s\\\"\")\n", field[0], sentFileName)
-				fmt.Fprintf(&buffer, "header.Add(\"Content-Type\", \"%s\")\n", contentType)
+				fmt.Fprintf(&buffer, "header.Add(\"Content-Disposition\", %s)\n", literal.Go(fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field[0], sentFileName)))
+				fmt.Fprintf(&buffer, "header.Add(\"Content-Type\", %s)\n", literal.Go(contentType))
 				buffer.WriteString("fileWriter, err := writer.CreatePart(header)\n")
 				buffer.WriteString("if err != nil {\n")
-				buffer.WriteString("    log.Fatal(err)\n")
+				fmt.Fprintf(&buffer, "    %s\n", generator.fatal())
 				buffer.WriteString("}\n")
 				generator.Modules["net/textproto"] = true
 			} else {
-				fmt.Fprintf(&buffer, "fileWriter, err := writer.CreateFormFile(\"%s\", \"%s\")\n", field[0], sentFileName)
+				fmt.Fprintf(&buffer, "fileWriter, err := writer.CreateFormFile(%s, %s)\n", literal.Go(field[0]), literal.Go(sentFileName))
 				buffer.WriteString("if err != nil {\n")
-				buffer.WriteString("    log.Fatal(err)\n")
+				fmt.Fprintf(&buffer, "    %s\n", generator.fatal())
 				buffer.WriteString("}\n")
 			}
-			fmt.Fprintf(&buffer, "file, err := os.Open(\"%s\")\n", sourceFile)
+			fmt.Fprintf(&buffer, "file, err := os.Open(%s)\n", literal.Go(sourceFile))
 			buffer.WriteString("if err != nil {\n")
-			buffer.WriteString("    log.Fatal(err)\n")
+			fmt.Fprintf(&buffer, "    %s\n", generator.fatal())
 			buffer.WriteString("}\n")
 			buffer.WriteString("io.Copy(fileWriter, file)\n")
 			buffer.WriteString("}\n")
@@ -392,17 +578,17 @@ func FormString(generator *GoGenerator, data *common.DataOption) string {
 			}
 			buffer.WriteString("{\n")
 			buffer.WriteString("header := make(textproto.MIMEHeader)\n")
-			fmt.Fprintf(&buffer, "header.Add(\"Content-Disposition\", \"form-data; name=\\\"%s\\\"\")\n", field[0])
+			fmt.Fprintf(&buffer, "header.Add(\"Content-Disposition\", %s)\n", literal.Go(fmt.Sprintf(`form-data; name="%s"`, field[0])))
 			if contentType != "" {
-				fmt.Fprintf(&buffer, "header.Add(\"Content-Type\", \"%s\")\n", contentType)
+				fmt.Fprintf(&buffer, "header.Add(\"Content-Type\", %s)\n", literal.Go(contentType))
 			}
 			buffer.WriteString("fileWriter, err := writer.CreatePart(header)\n")
 			buffer.WriteString("if err != nil {\n")
-			buffer.WriteString("    log.Fatal(err)\n")
+			fmt.Fprintf(&buffer, "    %s\n", generator.fatal())
 			buffer.WriteString("}\n")
-			fmt.Fprintf(&buffer, "file, err := os.Open(\"%s\")\n", sourceFile)
+			fmt.Fprintf(&buffer, "file, err := os.Open(%s)\n", literal.Go(sourceFile))
 			buffer.WriteString("if err != nil {\n")
-			buffer.WriteString("    log.Fatal(err)\n")
+			fmt.Fprintf(&buffer, "    %s\n", generator.fatal())
 			buffer.WriteString("}\n")
 			buffer.WriteString("io.Copy(fileWriter, file)\n")
 			buffer.WriteString("}\n")
@@ -411,15 +597,11 @@ func FormString(generator *GoGenerator, data *common.DataOption) string {
 			generator.Modules["os"] = true
 			generator.Modules["io"] = true
 		} else {
-			result = fmt.Sprintf("writer.WriteField(\"%s\", \"%s\")\n", field[0], field[1])
+			result = fmt.Sprintf("writer.WriteField(%s, %s)\n", literal.Go(field[0]), literal.Go(field[1]))
 		}
 	case common.FormStringType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
-		result = fmt.Sprintf("writer.WriteField(\"%s\", \"%s\")\n", field[0], field[1])
+		result = fmt.Sprintf("writer.WriteField(%s, %s)\n", literal.Go(field[0]), literal.Go(field[1]))
 	}
 	generator.Modules["bytes"] = true
 	generator.Modules["mime/multipart"] = true
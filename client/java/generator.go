@@ -4,9 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/shibukawa/curl_as_dsl/common"
-	"log"
-	"net/url"
-	"os"
+	"github.com/shibukawa/curl_as_dsl/literal"
 	"strings"
 )
 
@@ -19,16 +17,18 @@ type JavaGenerator struct {
 	HasBody                bool
 	Body                   string
 	PrepareBody            string
-	AdditionalDeclaration  string
+	declarations           []string
 	specialHeaders         [][]string
 	commonInitialize       []string
 	mimeCounter            int
 	formFileContentCounter int
+	// indent of statements. Functions of modules are shallower than main()
+	indent string
 }
 
 func NewJavaGenerator(options *common.CurlOptions) *JavaGenerator {
-	result := &JavaGenerator{Options: options}
-	result.Url = fmt.Sprintf("\"%s\"", options.Url)
+	result := &JavaGenerator{Options: options, indent: "            "}
+	result.Url = literal.Java(options.Url)
 	result.Modules = make(map[string]bool)
 	result.Modules["java.net.URL"] = true
 	result.Modules[fmt.Sprintf("java.net.%s", result.ConnectionClass())] = true
@@ -44,6 +44,10 @@ func NewJavaGenerator(options *common.CurlOptions) *JavaGenerator {
 
 //--- Getter methods called from template
 
+func (self JavaGenerator) AdditionalDeclaration() string {
+	return strings.Join(self.declarations, "")
+}
+
 func (self JavaGenerator) ConnectionClass() string {
 	var targetUrl string
 	if self.Options.Proxy != "" {
@@ -61,10 +65,7 @@ func (self JavaGenerator) Proxy() string {
 	if self.Options.Proxy == "" {
 		return ""
 	}
-	u, err := url.Parse(self.Options.Proxy)
-	if err != nil {
-		log.Fatal(err)
-	}
+	u := self.Options.ParsedProxy()
 	hostFragments := strings.Split(u.Host, ":")
 	host := hostFragments[0]
 	var port string
@@ -73,13 +74,13 @@ func (self JavaGenerator) Proxy() string {
 	} else {
 		port = hostFragments[1]
 	}
-	return fmt.Sprintf(`new Proxy(Proxy.Type.%s, new InetSocketAddress("%s", %s))`, strings.ToUpper(u.Scheme), host, port)
+	return fmt.Sprintf(`new Proxy(Proxy.Type.%s, new InetSocketAddress(%s, %s))`, strings.ToUpper(u.Scheme), literal.Java(host), port)
 }
 
 func (self JavaGenerator) CommonInitialize() string {
 	var buffer bytes.Buffer
 	indent := func() {
-		buffer.WriteString("            ")
+		buffer.WriteString(self.indent)
 	}
 
 	for _, line := range self.commonInitialize {
@@ -92,23 +93,27 @@ func (self JavaGenerator) CommonInitialize() string {
 }
 
 func (self JavaGenerator) PrepareConnection() string {
+	return self.prepareConnection(self.indent, self.specialHeaders, "wr")
+}
+
+func (self JavaGenerator) prepareConnection(indentString string, specialHeaders [][]string, writer string) string {
 	var buffer bytes.Buffer
 	indent := func() {
-		buffer.WriteString("            ")
+		buffer.WriteString(indentString)
 	}
 	method := self.Options.Method()
 	if method != "GET" {
 		indent()
-		buffer.WriteString(fmt.Sprintf("conn.setRequestMethod(\"%s\");\n", method))
+		buffer.WriteString(fmt.Sprintf("conn.setRequestMethod(%s);\n", literal.Java(method)))
 	}
 	for _, header := range self.Options.Header {
 		indent()
-		headers := strings.Split(header, ":")
-		buffer.WriteString(fmt.Sprintf("conn.setRequestProperty(\"%s\", \"%s\");\n", strings.TrimSpace(headers[0]), strings.TrimSpace(headers[1])))
+		headers := strings.SplitN(header, ":", 2)
+		buffer.WriteString(fmt.Sprintf("conn.setRequestProperty(%s, %s);\n", literal.Java(strings.TrimSpace(headers[0])), literal.Java(strings.TrimSpace(headers[1]))))
 	}
-	for _, header := range self.specialHeaders {
+	for _, header := range specialHeaders {
 		indent()
-		buffer.WriteString(fmt.Sprintf("conn.setRequestProperty(\"%s\", %s);\n", strings.TrimSpace(header[0]), header[1]))
+		buffer.WriteString(fmt.Sprintf("conn.setRequestProperty(%s, %s);\n", literal.Java(strings.TrimSpace(header[0])), header[1]))
 	}
 	if self.HasBody {
 		indent()
@@ -116,18 +121,43 @@ func (self JavaGenerator) PrepareConnection() string {
 		indent()
 		buffer.WriteString("conn.setDoOutput(true);\n")
 		indent()
-		buffer.WriteString("DataOutputStream wr = new DataOutputStream(conn.getOutputStream());\n")
+		fmt.Fprintf(&buffer, "DataOutputStream %s = new DataOutputStream(conn.getOutputStream());\n", writer)
 		indent()
-		buffer.WriteString("wr.writeBytes(content);\n")
+		fmt.Fprintf(&buffer, "%s.writeBytes(content);\n", writer)
 		indent()
-		buffer.WriteString("wr.flush();\n")
+		fmt.Fprintf(&buffer, "%s.flush();\n", writer)
 		indent()
-		buffer.WriteString("wr.close();\n")
+		fmt.Fprintf(&buffer, "%s.close();\n", writer)
 	}
 
 	return buffer.String()
 }
 
+/*
+	Send the request again with Digest authorization header when server returns a challenge.
+*/
+func (self JavaGenerator) Authenticate() string {
+	if !self.Options.UseDigestAuth() {
+		return ""
+	}
+	user, password := self.Options.UserAndPassword()
+	specialHeaders := append(self.specialHeaders[:len(self.specialHeaders):len(self.specialHeaders)], []string{"Authorization", "authorization"})
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "%sif (conn.getResponseCode() == 401) {\n", self.indent)
+	fmt.Fprintf(&buffer, "%s    String authorization = digestAuthorization(conn.getHeaderField(\"WWW-Authenticate\"), %s, url.getFile(), %s, %s);\n", self.indent, literal.Java(self.Options.Method()), literal.Java(user), literal.Java(password))
+	fmt.Fprintf(&buffer, "%s    conn = (%s)url.openConnection(%s);\n", self.indent, self.ConnectionClass(), self.Proxy())
+	buffer.WriteString(self.prepareConnection(self.indent+"    ", specialHeaders, "retryWr"))
+	fmt.Fprintf(&buffer, "%s}\n", self.indent)
+	return buffer.String()
+}
+
+func (self JavaGenerator) SaveCookies() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n%ssaveCookies(cookieManager.getCookieStore(), %s);", self.indent, literal.Java(self.Options.CookieJar))
+}
+
 //--- Preparing Java source code methods
 
 func (self *JavaGenerator) AppendCommonInitialize(newLine string, check bool) {
@@ -162,8 +192,32 @@ func (self *JavaGenerator) FormFileContentVariable() string {
 	return fmt.Sprintf("fileContent%d", self.formFileContentCounter)
 }
 
+func (self *JavaGenerator) AddDigestCode() {
+	self.declarations = append(self.declarations, digestAuthorizationCode)
+	addModules(self.Modules, digestAuthorizationModules...)
+}
+
+/*
+	CookieManager is registered as a default handler. It sends stored cookies and keeps
+	cookies from responses. Cookie files are read and written in Netscape format.
+*/
+func (self *JavaGenerator) AddCookieCode() {
+	self.AppendCommonInitialize("CookieManager cookieManager = new CookieManager(null, CookiePolicy.ACCEPT_ALL);", true)
+	self.AppendCommonInitialize("CookieHandler.setDefault(cookieManager);", true)
+	for _, fileName := range self.Options.CookieFiles() {
+		self.AppendCommonInitialize(fmt.Sprintf("loadCookies(cookieManager.getCookieStore(), %s);", literal.Java(fileName)), true)
+	}
+	self.declarations = append(self.declarations, loadCookiesCode)
+	addModules(self.Modules, loadCookiesModules...)
+	if self.Options.CookieJar == "" {
+		return
+	}
+	self.declarations = append(self.declarations, saveCookiesCode)
+	addModules(self.Modules, saveCookiesModules...)
+}
+
 func (self *JavaGenerator) AddMultiPartCode() {
-	self.AdditionalDeclaration = `
+	self.declarations = append(self.declarations, `
     static String BOUNDARY = "----------ThIs_Is_tHe_bouNdaRY_$";
     static String encodeMultiPartFormData(String[][] fields, String[][] files) {
         try {
@@ -213,21 +267,21 @@ func (self *JavaGenerator) AddMultiPartCode() {
             return "";
         }
     }
-`
+`)
 	boundary := "----------ThIs_Is_tHe_bouNdaRY_$"
 	self.Options.InsertContentTypeHeader(fmt.Sprintf("multipart/form-data; boundary=%s", boundary))
 	self.Modules["java.io.StringWriter"] = true
 	self.Modules["java.io.FileReader"] = true
 }
 
-func (self *JavaGenerator) SetDataForUrl() {
+func (self *JavaGenerator) SetDataForUrl() error {
 	var buffer bytes.Buffer
 	buffer.WriteString("StringWriter writer = new StringWriter();\n")
 	indent := func() {
-		buffer.WriteString("            ")
+		buffer.WriteString(self.indent)
 	}
 	indent()
-	fmt.Fprintf(&buffer, "writer.write(\"%s\");\n", self.Options.Url)
+	fmt.Fprintf(&buffer, "writer.write(%s);\n", literal.Java(self.Options.Url))
 	indent()
 	buffer.WriteString("writer.write('?');\n")
 	indent()
@@ -237,20 +291,24 @@ func (self *JavaGenerator) SetDataForUrl() {
 				indent()
 				buffer.WriteString("writer.write('&');\n")
 			}
-			singleData, _ := url.ParseQuery(data.Value)
-			for key, values := range singleData {
-				fmt.Fprintf(&buffer, "writer.write(\"%s\");\n", key)
+			keys, singleData := data.FormValues()
+			for _, key := range keys {
+				values := singleData[key]
+				fmt.Fprintf(&buffer, "writer.write(%s);\n", literal.Java(key))
 				indent()
 				buffer.WriteString("writer.write('=');\n")
 				indent()
-				fmt.Fprintf(&buffer, "writer.write(\"%s\");\n", values[0])
+				fmt.Fprintf(&buffer, "writer.write(%s);\n", literal.Java(values[0]))
 				indent()
 			}
 		}
 		self.PrepareBody = buffer.String()
 	} else {
 		if len(self.Options.ProcessedData) == 1 {
-			prepareLines, forWriter := NewStringForData(self, &self.Options.ProcessedData[0])
+			prepareLines, forWriter, err := NewStringForData(self, &self.Options.ProcessedData[0])
+			if err != nil {
+				return err
+			}
 			for _, line := range prepareLines {
 				buffer.WriteString(line)
 				buffer.WriteByte('\n')
@@ -265,14 +323,17 @@ func (self *JavaGenerator) SetDataForUrl() {
 					buffer.WriteString("writer.write('&');\n")
 				}
 				indent()
-				prepareLines, forWriter := StringForData(self, &data)
+				prepareLines, forWriter, err := StringForData(self, &data)
+				if err != nil {
+					return err
+				}
 				for _, line := range prepareLines {
 					buffer.WriteString(line)
 					buffer.WriteByte('\n')
 					indent()
 				}
 				if forWriter != "" {
-					fmt.Fprintf(&buffer, "writer.write(%s);\n")
+					fmt.Fprintf(&buffer, "writer.write(%s);\n", forWriter)
 				}
 			}
 			indent()
@@ -281,15 +342,19 @@ func (self *JavaGenerator) SetDataForUrl() {
 	self.Url = "writer.toString()"
 	self.PrepareBody = buffer.String()
 	self.Modules["java.io.StringWriter"] = true
+	return nil
 }
 
-func (self *JavaGenerator) SetDataForBody() {
+func (self *JavaGenerator) SetDataForBody() error {
 	var buffer bytes.Buffer
 	indent := func() {
-		buffer.WriteString("            ")
+		buffer.WriteString(self.indent)
 	}
 	if len(self.Options.ProcessedData) == 1 {
-		prepareLines, forWriter := NewStringForData(self, &self.Options.ProcessedData[0])
+		prepareLines, forWriter, err := NewStringForData(self, &self.Options.ProcessedData[0])
+		if err != nil {
+			return err
+		}
 		for _, line := range prepareLines {
 			buffer.WriteString(line)
 			buffer.WriteByte('\n')
@@ -305,7 +370,10 @@ func (self *JavaGenerator) SetDataForBody() {
 				indent()
 				buffer.WriteString("writer.write('&');\n")
 			}
-			prepareLines, forWriter := StringForData(self, &data)
+			prepareLines, forWriter, err := StringForData(self, &data)
+			if err != nil {
+				return err
+			}
 			for _, line := range prepareLines {
 				indent()
 				buffer.WriteString(line)
@@ -323,12 +391,13 @@ func (self *JavaGenerator) SetDataForBody() {
 	}
 	self.PrepareBody = buffer.String()
 	self.HasBody = true
+	return nil
 }
 
 func (self *JavaGenerator) SetDataForForm() {
 	var buffer bytes.Buffer
 	indent := func() {
-		buffer.WriteString("            ")
+		buffer.WriteString(self.indent)
 	}
 
 	buffer.WriteString("StringWriter writer = new StringWriter();\n")
@@ -337,11 +406,12 @@ func (self *JavaGenerator) SetDataForForm() {
 			indent()
 			buffer.WriteString("writer.write('&');\n")
 		}
-		singleData, _ := url.ParseQuery(data.Value)
-		for key, values := range singleData {
-			fmt.Fprintf(&buffer, "writer.write(\"%s\");\n", key)
+		keys, singleData := data.FormValues()
+		for _, key := range keys {
+			values := singleData[key]
+			fmt.Fprintf(&buffer, "writer.write(%s);\n", literal.Java(key))
 			buffer.WriteString("writer.write('=');\n")
-			fmt.Fprintf(&buffer, "writer.write(\"%s\");\n", values[0])
+			fmt.Fprintf(&buffer, "writer.write(%s);\n", literal.Java(values[0]))
 		}
 	}
 
@@ -366,7 +436,7 @@ func (self *JavaGenerator) SetFormForBody() {
 
 	var buffer bytes.Buffer
 	indent := func() {
-		buffer.WriteString("            ")
+		buffer.WriteString(self.indent)
 	}
 
 	if len(fields) > 0 {
@@ -403,41 +473,49 @@ func (self *JavaGenerator) SetFormForBody() {
 	Dispatcher function of curl command
 	This is an exported function and called from httpgen.
 */
-func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}) {
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
 	generator := NewJavaGenerator(options)
+	if err := processJavaRequest(generator); err != nil {
+		return "", nil, err
+	}
+	return "full", *generator, nil
+}
 
-	if options.ProcessedData.HasData() {
-		if options.Get {
-			generator.SetDataForUrl()
-		} else {
-			generator.Options.InsertContentTypeHeader("application/x-www-form-urlencoded")
-			generator.SetDataForBody()
-		}
-	} else if options.ProcessedData.HasForm() {
-		generator.SetFormForBody()
+func processJavaRequest(generator *JavaGenerator) error {
+	options := generator.Options
+	if err := ProcessData(options, generator); err != nil {
+		return err
 	}
-	if generator.Options.User != "" {
-		generator.specialHeaders = append(generator.specialHeaders, []string{"Authorization", fmt.Sprintf("\"Basic \" + Base64.getEncoder().encodeToString(\"%s\".getBytes(StandardCharsets.UTF_8))", generator.Options.User)})
+	if generator.Options.UseBasicAuth() {
+		generator.specialHeaders = append(generator.specialHeaders, []string{"Authorization", fmt.Sprintf("\"Basic \" + Base64.getEncoder().encodeToString(%s.getBytes(StandardCharsets.UTF_8))", literal.Java(generator.Options.User))})
 		generator.Modules["java.util.Base64"] = true
 		generator.Modules["java.nio.charset.StandardCharsets"] = true
+	} else if generator.Options.UseDigestAuth() {
+		generator.AddDigestCode()
+	}
+	if len(options.Cookies()) != 0 {
+		generator.specialHeaders = append(generator.specialHeaders, []string{"Cookie", literal.Java(options.CookieString())})
+	}
+	if options.UseCookieJar() {
+		generator.AddCookieCode()
 	}
 	if generator.HasBody {
 		generator.Modules["java.io.DataOutputStream"] = true
 	}
 
-	return "full", *generator
+	return nil
 }
 
 // helper functions
 
-func NewStringForData(generator *JavaGenerator, data *common.DataOption) ([]string, string) {
+func NewStringForData(generator *JavaGenerator, data *common.DataOption) ([]string, string, error) {
 	var result []string
 	var resultForWriter string
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = append(result, "StringWriter writer = new StringWriter();")
-			result = append(result, fmt.Sprintf(`FileReader fileReader = new FileReader("%s");`, data.Value[1:]))
+			result = append(result, fmt.Sprintf(`FileReader fileReader = new FileReader(%s);`, literal.Java(data.Value[1:])))
 			result = append(result, "BufferedReader bufferedReader = new BufferedReader(fileReader);")
 			result = append(result, "String str = bufferedReader.readLine();")
 			result = append(result, "while (str != null) {")
@@ -449,12 +527,12 @@ func NewStringForData(generator *JavaGenerator, data *common.DataOption) ([]stri
 			generator.Modules["java.io.StringWriter"] = true
 			generator.Modules["java.io.FileReader"] = true
 		} else {
-			resultForWriter = fmt.Sprintf(`"%s"`, strings.Replace(data.Value, "\n", "", -1))
+			resultForWriter = literal.Java(strings.Replace(data.Value, "\n", "", -1))
 		}
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = append(result, "StringWriter writer = new StringWriter();")
-			result = append(result, fmt.Sprintf(`FileReader fileReader = new FileReader("%s");`, data.Value[1:]))
+			result = append(result, fmt.Sprintf(`FileReader fileReader = new FileReader(%s);`, literal.Java(data.Value[1:])))
 			result = append(result, "for (int n = 0; -1 != (n = fileReader.read(buffer));) {")
 			result = append(result, "    writer.write(buffer, 0, n);")
 			result = append(result, "}")
@@ -464,12 +542,16 @@ func NewStringForData(generator *JavaGenerator, data *common.DataOption) ([]stri
 			generator.Modules["java.io.StringWriter"] = true
 			generator.Modules["java.io.FileReader"] = true
 		} else {
-			resultForWriter = fmt.Sprintf(`"%s"`, data.Value)
+			resultForWriter = literal.Java(data.Value)
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
+		name, content, isFile := data.UrlEncodeParts()
+		if isFile {
 			result = append(result, "StringWriter writer = new StringWriter();")
-			result = append(result, fmt.Sprintf(`FileReader fileReader = new FileReader("%s");`, data.Value[1:]))
+			if name != "" {
+				result = append(result, fmt.Sprintf("writer.write(%s);", literal.Java(name+"=")))
+			}
+			result = append(result, fmt.Sprintf(`FileReader fileReader = new FileReader(%s);`, literal.Java(content)))
 			result = append(result, "BufferedReader bufferedReader = new BufferedReader(fileReader);")
 			result = append(result, "String str = bufferedReader.readLine();")
 			result = append(result, "while (str != null) {")
@@ -481,23 +563,33 @@ func NewStringForData(generator *JavaGenerator, data *common.DataOption) ([]stri
 			generator.Modules["java.io.StringWriter"] = true
 			generator.Modules["java.io.FileReader"] = true
 		} else {
-			resultForWriter = fmt.Sprintf("URLEncoder.encode(\"%s\", \"UTF-8\")", data.Value)
+			resultForWriter = fmt.Sprintf("%sURLEncoder.encode(%s, \"UTF-8\")", urlEncodeName(name), literal.Java(content))
 		}
 		generator.Modules["java.net.URLEncoder"] = true
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return nil, "", common.UnexpectedDataError("java", data)
 	}
-	return result, resultForWriter
+	return result, resultForWriter, nil
 }
 
-func StringForData(generator *JavaGenerator, data *common.DataOption) ([]string, string) {
+/*
+	curl doesn't encode the name of --data-urlencode.
+*/
+func urlEncodeName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return literal.Java(name+"=") + " + "
+}
+
+func StringForData(generator *JavaGenerator, data *common.DataOption) ([]string, string, error) {
 	var result []string
 	var resultForWriter string
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = append(result, "{")
-			result = append(result, fmt.Sprintf(`    FileReader fileReader = new FileReader("%s");`, data.Value[1:]))
+			result = append(result, fmt.Sprintf(`    FileReader fileReader = new FileReader(%s);`, literal.Java(data.Value[1:])))
 			result = append(result, "    BufferedReader bufferedReader = new BufferedReader(fileReader);")
 			result = append(result, "    String str = bufferedReader.readLine();")
 			result = append(result, "    while (str != null) {")
@@ -508,12 +600,12 @@ func StringForData(generator *JavaGenerator, data *common.DataOption) ([]string,
 			result = append(result, "}")
 			generator.Modules["java.io.FileReader"] = true
 		} else {
-			resultForWriter = fmt.Sprintf("\"%s\"", strings.Replace(data.Value, "\n", "", -1))
+			resultForWriter = literal.Java(strings.Replace(data.Value, "\n", "", -1))
 		}
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = append(result, "{")
-			result = append(result, fmt.Sprintf(`    FileReader fileReader = new FileReader("%s");`, data.Value[1:]))
+			result = append(result, fmt.Sprintf(`    FileReader fileReader = new FileReader(%s);`, literal.Java(data.Value[1:])))
 			result = append(result, "    for (int n = 0; -1 != (n = fileReader.read(buffer));) {")
 			result = append(result, "        writer.write(buffer, 0, n);")
 			result = append(result, "    }")
@@ -523,12 +615,16 @@ func StringForData(generator *JavaGenerator, data *common.DataOption) ([]string,
 			generator.Modules["java.io.FileReader"] = true
 			generator.Modules["java.io.BufferedReader"] = true
 		} else {
-			resultForWriter = fmt.Sprintf("\"%s\"", data.Value)
+			resultForWriter = literal.Java(data.Value)
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
+		name, content, isFile := data.UrlEncodeParts()
+		if isFile {
 			result = append(result, "{")
-			result = append(result, fmt.Sprintf(`    FileReader fileReader = new FileReader("%s");`, data.Value[1:]))
+			if name != "" {
+				result = append(result, fmt.Sprintf("    writer.write(%s);", literal.Java(name+"=")))
+			}
+			result = append(result, fmt.Sprintf(`    FileReader fileReader = new FileReader(%s);`, literal.Java(content)))
 			result = append(result, "    BufferedReader bufferedReader = new BufferedReader(fileReader);")
 			result = append(result, "    String str = bufferedReader.readLine();")
 			result = append(result, "    while (str != null) {")
@@ -539,13 +635,13 @@ func StringForData(generator *JavaGenerator, data *common.DataOption) ([]string,
 			result = append(result, "}")
 			generator.Modules["java.io.FileReader"] = true
 		} else {
-			resultForWriter = fmt.Sprintf("URLEncoder.encode(\"%s\", \"UTF-8\")", data.Value)
+			resultForWriter = fmt.Sprintf("%sURLEncoder.encode(%s, \"UTF-8\")", urlEncodeName(name), literal.Java(content))
 		}
 		generator.Modules["java.net.URLEncoder"] = true
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return nil, "", common.UnexpectedDataError("java", data)
 	}
-	return result, resultForWriter
+	return result, resultForWriter, nil
 }
 
 func FormString(generator *JavaGenerator, data *common.DataOption) string {
@@ -553,16 +649,12 @@ func FormString(generator *JavaGenerator, data *common.DataOption) string {
 	switch data.Type {
 	case common.FormType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
 		if strings.HasPrefix(field[1], "@") {
 			var buffer bytes.Buffer
 			fragments := strings.Split(field[1][1:], ";")
 
 			// field name, source file name
-			buffer.WriteString(fmt.Sprintf("    {\"%s\", \"%s\", ", field[0], fragments[0]))
+			buffer.WriteString(fmt.Sprintf("    {%s, %s, ", literal.Java(field[0]), literal.Java(fragments[0])))
 
 			var contentType string
 			sentFileName := fragments[0]
@@ -574,12 +666,12 @@ func FormString(generator *JavaGenerator, data *common.DataOption) string {
 				}
 			}
 			// sent file name
-			buffer.WriteString(fmt.Sprintf("\"%s\", ", sentFileName))
+			buffer.WriteString(fmt.Sprintf("%s, ", literal.Java(sentFileName)))
 
 			// sent file name
 			var mimeTypeVariable string
 			if contentType != "" {
-				buffer.WriteString(fmt.Sprintf("\"%s\"", contentType))
+				buffer.WriteString(literal.Java(contentType))
 			} else {
 				mimeTypeVariable = generator.MimeTypeVariable()
 				buffer.WriteString(fmt.Sprintf("%s != null ? %s : \"application/octet-stream\"", mimeTypeVariable, mimeTypeVariable))
@@ -588,7 +680,7 @@ func FormString(generator *JavaGenerator, data *common.DataOption) string {
 			result = buffer.String()
 			generator.AppendCommonInitialize("FileNameMap fileNameMap = URLConnection.getFileNameMap();", true)
 			if mimeTypeVariable != "" {
-				generator.AppendCommonInitialize(fmt.Sprintf("String %s = fileNameMap.getContentTypeFor(\"%s\");", mimeTypeVariable, fragments[0]), false)
+				generator.AppendCommonInitialize(fmt.Sprintf("String %s = fileNameMap.getContentTypeFor(%s);", mimeTypeVariable, literal.Java(fragments[0])), false)
 			}
 			generator.Modules["java.net.URLConnection"] = true
 			generator.Modules["java.net.FileNameMap"] = true
@@ -603,7 +695,7 @@ func FormString(generator *JavaGenerator, data *common.DataOption) string {
 			generator.AppendCommonInitialize(fmt.Sprintf("String %s;", contentVariable), false)
 			generator.AppendCommonInitialize("{", false)
 			generator.AppendCommonInitialize("    StringWriter writer = new StringWriter();", false)
-			generator.AppendCommonInitialize(fmt.Sprintf(`    FileReader fileReader = new FileReader("%s");`, fragments[0]), false)
+			generator.AppendCommonInitialize(fmt.Sprintf(`    FileReader fileReader = new FileReader(%s);`, literal.Java(fragments[0])), false)
 			generator.AppendCommonInitialize("    for (int n = 0; -1 != (n = fileReader.read(buffer));) {", false)
 			generator.AppendCommonInitialize("        writer.write(buffer, 0, n);", false)
 			generator.AppendCommonInitialize("    }", false)
@@ -611,7 +703,7 @@ func FormString(generator *JavaGenerator, data *common.DataOption) string {
 			generator.AppendCommonInitialize(fmt.Sprintf("    %s = writer.toString();", contentVariable), false)
 			generator.AppendCommonInitialize("}", false)
 
-			buffer.WriteString(fmt.Sprintf(`    {"%s", %s, `, field[0], contentVariable))
+			buffer.WriteString(fmt.Sprintf(`    {%s, %s, `, literal.Java(field[0]), contentVariable))
 
 			var contentType string
 			for _, fragment := range fragments[1:] {
@@ -622,20 +714,16 @@ func FormString(generator *JavaGenerator, data *common.DataOption) string {
 			if contentType == "" {
 				buffer.WriteString(`""`)
 			} else {
-				buffer.WriteString(fmt.Sprintf(`"%s"`, contentType))
+				buffer.WriteString(literal.Java(contentType))
 			}
 			buffer.WriteString("},\n")
 			result = buffer.String()
 		} else {
-			result = fmt.Sprintf("    {\"%s\", \"%s\", \"\"},\n", field[0], field[1])
+			result = fmt.Sprintf("    {%s, %s, \"\"},\n", literal.Java(field[0]), literal.Java(field[1]))
 		}
 	case common.FormStringType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
-		result = fmt.Sprintf("        (\"%s\", \"%s\", None),\n", field[0], field[1])
+		result = fmt.Sprintf("    {%s, %s, \"\"},\n", literal.Java(field[0]), literal.Java(field[1]))
 	}
 	return result
 }
@@ -0,0 +1,420 @@
+package java
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/literal"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+/*
+	HttpClient rejects these headers. It throws IllegalArgumentException.
+*/
+var restrictedHeaders = map[string]bool{
+	"connection":     true,
+	"content-length": true,
+	"expect":         true,
+	"host":           true,
+	"upgrade":        true,
+}
+
+type HttpClientGenerator struct {
+	Options *common.CurlOptions
+	Modules map[string]bool
+
+	Url                   string
+	AdditionalDeclaration string
+	body                  string
+	multipart             bool
+	prepare               []string
+	clientBuilder         []string
+	requestBuilder        []string
+}
+
+func NewHttpClientGenerator(options *common.CurlOptions) *HttpClientGenerator {
+	result := &HttpClientGenerator{Options: options}
+	result.Url = literal.Java(options.Url)
+	result.Modules = make(map[string]bool)
+	addModules(result.Modules, "java.net.URI", "java.net.http.HttpClient", "java.net.http.HttpRequest", "java.net.http.HttpResponse")
+	return result
+}
+
+//--- Getter methods called from template
+
+func (self HttpClientGenerator) Prepare() string {
+	var buffer bytes.Buffer
+	for _, line := range self.prepare {
+		fmt.Fprintf(&buffer, "%s\n        ", line)
+	}
+	return buffer.String()
+}
+
+func (self HttpClientGenerator) ClientBuilder() string {
+	var buffer bytes.Buffer
+	for _, line := range self.clientBuilder {
+		fmt.Fprintf(&buffer, "\n                %s", line)
+	}
+	return buffer.String()
+}
+
+func (self HttpClientGenerator) RequestBuilder() string {
+	var buffer bytes.Buffer
+	for _, line := range self.requestBuilder {
+		fmt.Fprintf(&buffer, "\n                %s", line)
+	}
+	return buffer.String()
+}
+
+/*
+	HttpClient doesn't support Digest authentication.
+	Send the request again with Digest authorization header when server returns a challenge.
+*/
+func (self HttpClientGenerator) Authenticate() string {
+	if !self.Options.UseDigestAuth() {
+		return ""
+	}
+	user, password := self.Options.UserAndPassword()
+	var buffer bytes.Buffer
+	buffer.WriteString("        if (response.statusCode() == 401) {\n")
+	buffer.WriteString("            URI uri = response.request().uri();\n")
+	buffer.WriteString("            String path = uri.getRawQuery() != null ? uri.getRawPath() + \"?\" + uri.getRawQuery() : uri.getRawPath();\n")
+	fmt.Fprintf(&buffer, "            String authorization = digestAuthorization(response.headers().firstValue(\"WWW-Authenticate\").orElse(\"\"), %s, path, %s, %s);\n", literal.Java(self.Options.Method()), literal.Java(user), literal.Java(password))
+	buffer.WriteString("            response = client.send(request.header(\"Authorization\", authorization).build(), HttpResponse.BodyHandlers.ofString());\n")
+	buffer.WriteString("        }\n")
+	return buffer.String()
+}
+
+func (self HttpClientGenerator) SaveCookies() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n        saveCookies(cookieManager.getCookieStore(), %s);", literal.Java(self.Options.CookieJar))
+}
+
+//--- Preparing Java source code methods
+
+func (self *HttpClientGenerator) setHeader(name, value string) {
+	if restrictedHeaders[strings.ToLower(name)] {
+		self.Options.AddWarning("java.net.http.HttpClient doesn't allow to set %s header. It is ignored.", name)
+		return
+	}
+	self.requestBuilder = append(self.requestBuilder, fmt.Sprintf(".header(%s, %s)", literal.Java(name), value))
+}
+
+func (self *HttpClientGenerator) SetDataForUrl() error {
+	separator := "?"
+	if strings.Contains(self.Options.Url, "?") {
+		separator = "&"
+	}
+	query, err := self.stringBody()
+	if err != nil {
+		return err
+	}
+	self.prepare = append(self.prepare, fmt.Sprintf("String query = %s;", query))
+	self.Url = fmt.Sprintf("%s + query", literal.Java(self.Options.Url+separator))
+	return nil
+}
+
+func (self *HttpClientGenerator) SetDataForBody() error {
+	if len(self.Options.ProcessedData) == 1 {
+		data := &self.Options.ProcessedData[0]
+		if data.Type == common.DataBinaryType && data.UseExternalFile() {
+			self.Modules["java.nio.file.Path"] = true
+			self.body = fmt.Sprintf("HttpRequest.BodyPublishers.ofFile(Path.of(%s))", literal.Java(data.Value[1:]))
+			return nil
+		}
+	}
+	body, err := self.stringBody()
+	if err != nil {
+		return err
+	}
+	self.body = fmt.Sprintf("HttpRequest.BodyPublishers.ofString(%s)", body)
+	return nil
+}
+
+func (self *HttpClientGenerator) stringBody() (string, error) {
+	if len(self.Options.ProcessedData) == 1 {
+		return self.dataExpression(&self.Options.ProcessedData[0])
+	}
+	var values []string
+	for _, data := range self.Options.ProcessedData {
+		value, err := self.dataExpression(&data)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, value)
+	}
+	return fmt.Sprintf("String.join(\"&\", %s)", strings.Join(values, ", ")), nil
+}
+
+func (self *HttpClientGenerator) dataExpression(data *common.DataOption) (string, error) {
+	readFile := func(fileName string) string {
+		self.Modules["java.nio.file.Files"] = true
+		self.Modules["java.nio.file.Path"] = true
+		return fmt.Sprintf("Files.readString(Path.of(%s))", literal.Java(fileName))
+	}
+	switch data.Type {
+	case common.DataAsciiType:
+		if data.UseExternalFile() {
+			self.Modules["java.nio.file.Files"] = true
+			self.Modules["java.nio.file.Path"] = true
+			return fmt.Sprintf("String.join(\"\", Files.readAllLines(Path.of(%s)))", literal.Java(data.Value[1:])), nil
+		}
+		return literal.Java(data.Value), nil
+	case common.DataBinaryType:
+		if data.UseExternalFile() {
+			return readFile(data.Value[1:]), nil
+		}
+		return literal.Java(data.Value), nil
+	case common.DataUrlEncodeType:
+		self.AddUrlEncodeCode()
+		// curl's rule: "content", "=content", "name=content", "@filename" and "name@filename"
+		index := strings.IndexAny(data.Value, "=@")
+		var content string
+		if index == -1 {
+			content = literal.Java(data.Value)
+		} else if data.Value[index] == '@' {
+			content = readFile(data.Value[index+1:])
+		} else {
+			content = literal.Java(data.Value[index+1:])
+		}
+		if index > 0 {
+			return fmt.Sprintf("%s + urlEncode(%s)", literal.Java(data.Value[:index]+"="), content), nil
+		}
+		return fmt.Sprintf("urlEncode(%s)", content), nil
+	default:
+		return "", common.UnexpectedDataError("java.httpclient", data)
+	}
+}
+
+func (self *HttpClientGenerator) AddUrlEncodeCode() {
+	if strings.Contains(self.AdditionalDeclaration, "static String urlEncode") {
+		return
+	}
+	self.AdditionalDeclaration += `
+    // Same as curl's --data-urlencode. It uses "+" for space like URLEncoder, but encodes "*" and keeps "~".
+    static String urlEncode(String source) {
+        return URLEncoder.encode(source, StandardCharsets.UTF_8).replace("*", "%2A").replace("%7E", "~");
+    }
+`
+	addModules(self.Modules, "java.net.URLEncoder", "java.nio.charset.StandardCharsets")
+}
+
+func (self *HttpClientGenerator) AddMultiPartCode() {
+	self.AdditionalDeclaration += `
+    static byte[] encodeMultiPartFormData(String boundary, String[][] fields, String[][] files) throws IOException {
+        ByteArrayOutputStream body = new ByteArrayOutputStream();
+        for (String[] field : fields) {
+            body.write(("--" + boundary + "\r\nContent-Disposition: form-data; name=\"" + field[0] + "\"\r\n").getBytes(StandardCharsets.UTF_8));
+            if (!field[2].isEmpty()) {
+                body.write(("Content-Type: " + field[2] + "\r\n").getBytes(StandardCharsets.UTF_8));
+            }
+            body.write(("\r\n" + field[1] + "\r\n").getBytes(StandardCharsets.UTF_8));
+        }
+        for (String[] file : files) {
+            body.write(("--" + boundary + "\r\nContent-Disposition: form-data; name=\"" + file[0] + "\"; filename=\"" + file[2] + "\"\r\n").getBytes(StandardCharsets.UTF_8));
+            body.write(("Content-Type: " + file[3] + "\r\n\r\n").getBytes(StandardCharsets.UTF_8));
+            body.write(Files.readAllBytes(Path.of(file[1])));
+            body.write("\r\n".getBytes(StandardCharsets.UTF_8));
+        }
+        body.write(("--" + boundary + "--\r\n").getBytes(StandardCharsets.UTF_8));
+        return body.toByteArray();
+    }
+`
+	addModules(self.Modules, "java.io.ByteArrayOutputStream", "java.io.IOException", "java.nio.charset.StandardCharsets", "java.nio.file.Files", "java.nio.file.Path", "java.util.UUID")
+}
+
+func (self *HttpClientGenerator) SetFormForBody() {
+	self.AddMultiPartCode()
+	var fields []string
+	var files []string
+
+	for _, data := range self.Options.ProcessedData {
+		field := strings.SplitN(data.Value, "=", 2)
+		if data.Type == common.FormType && (strings.HasPrefix(field[1], "@") || strings.HasPrefix(field[1], "<")) {
+			fragments := strings.Split(field[1][1:], ";")
+			contentType := ""
+			sentFileName := fragments[0]
+			for _, fragment := range fragments[1:] {
+				if strings.HasPrefix(fragment, "filename=") {
+					sentFileName = fragment[9:]
+				} else if strings.HasPrefix(fragment, "type=") {
+					contentType = fragment[5:]
+				}
+			}
+			if field[1][0] == '@' {
+				contentTypeExpression := literal.Java(contentType)
+				if contentType == "" {
+					addModules(self.Modules, "java.net.URLConnection", "java.util.Objects")
+					contentTypeExpression = fmt.Sprintf("Objects.requireNonNullElse(URLConnection.guessContentTypeFromName(%s), \"application/octet-stream\")", literal.Java(fragments[0]))
+				}
+				files = append(files, fmt.Sprintf("    {%s, %s, %s, %s},", literal.Java(field[0]), literal.Java(fragments[0]), literal.Java(sentFileName), contentTypeExpression))
+			} else {
+				fields = append(fields, fmt.Sprintf("    {%s, Files.readString(Path.of(%s)), %s},", literal.Java(field[0]), literal.Java(fragments[0]), literal.Java(contentType)))
+			}
+		} else {
+			fields = append(fields, fmt.Sprintf("    {%s, %s, \"\"},", literal.Java(field[0]), literal.Java(field[1])))
+		}
+	}
+
+	if len(fields) > 0 {
+		self.prepare = append(self.prepare, "String[][] fields = {")
+		self.prepare = append(self.prepare, fields...)
+		self.prepare = append(self.prepare, "};")
+	} else {
+		self.prepare = append(self.prepare, "String[][] fields = {};")
+	}
+	if len(files) > 0 {
+		self.prepare = append(self.prepare, "String[][] files = {")
+		self.prepare = append(self.prepare, files...)
+		self.prepare = append(self.prepare, "};")
+	} else {
+		self.prepare = append(self.prepare, "String[][] files = {};")
+	}
+	self.prepare = append(self.prepare, "String boundary = \"----------\" + UUID.randomUUID();")
+	self.multipart = true
+	self.body = "HttpRequest.BodyPublishers.ofByteArray(encodeMultiPartFormData(boundary, fields, files))"
+}
+
+/*
+	Received cookies are kept by CookieManager. Cookie files are read and written in Netscape format.
+*/
+func (self *HttpClientGenerator) SetCookie() {
+	self.prepare = append(self.prepare, "CookieManager cookieManager = new CookieManager(null, CookiePolicy.ACCEPT_ALL);")
+	for _, fileName := range self.Options.CookieFiles() {
+		self.prepare = append(self.prepare, fmt.Sprintf("loadCookies(cookieManager.getCookieStore(), %s);", literal.Java(fileName)))
+	}
+	for _, cookie := range self.Options.Cookies() {
+		self.prepare = append(self.prepare, "{")
+		self.prepare = append(self.prepare, fmt.Sprintf("    HttpCookie cookie = new HttpCookie(%s, %s);", literal.Java(cookie[0]), literal.Java(cookie[1])))
+		self.prepare = append(self.prepare, "    cookie.setPath(\"/\");")
+		self.prepare = append(self.prepare, "    cookie.setVersion(0);")
+		self.prepare = append(self.prepare, fmt.Sprintf("    cookieManager.getCookieStore().add(URI.create(%s), cookie);", literal.Java(self.Options.Url)))
+		self.prepare = append(self.prepare, "}")
+	}
+	self.clientBuilder = append(self.clientBuilder, ".cookieHandler(cookieManager)")
+	self.AdditionalDeclaration += loadCookiesCode
+	addModules(self.Modules, loadCookiesModules...)
+	if self.Options.CookieJar != "" {
+		self.AdditionalDeclaration += saveCookiesCode
+		addModules(self.Modules, saveCookiesModules...)
+	}
+}
+
+/*
+	HttpClient supports only HTTP proxy. curl uses 1080 as default proxy port.
+*/
+func (self *HttpClientGenerator) SetProxy() {
+	proxy := self.Options.Proxy
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	u, _ := url.Parse(proxy)
+	if strings.HasPrefix(u.Scheme, "socks") {
+		self.Options.AddWarning("java.net.http.HttpClient doesn't support SOCKS proxy. %s is ignored.", self.Options.Proxy)
+		return
+	}
+	port := u.Port()
+	if port == "" {
+		port = "1080"
+	}
+	addModules(self.Modules, "java.net.InetSocketAddress", "java.net.ProxySelector")
+	self.clientBuilder = append(self.clientBuilder, fmt.Sprintf(".proxy(ProxySelector.of(new InetSocketAddress(%s, %s)))", literal.Java(u.Hostname()), port))
+}
+
+/*
+	Trust all certificates like curl's -k option. Hostname verification is disabled via system property.
+*/
+func (self *HttpClientGenerator) SetInsecure() {
+	self.AdditionalDeclaration += `
+    static SSLContext insecureSSLContext() throws GeneralSecurityException {
+        TrustManager[] trustAllManagers = {
+            new X509TrustManager() {
+                public void checkClientTrusted(X509Certificate[] chain, String authType) {}
+                public void checkServerTrusted(X509Certificate[] chain, String authType) {}
+                public X509Certificate[] getAcceptedIssuers() {
+                    return new X509Certificate[0];
+                }
+            }
+        };
+        SSLContext context = SSLContext.getInstance("TLS");
+        context.init(null, trustAllManagers, new SecureRandom());
+        return context;
+    }
+`
+	addModules(self.Modules, "java.security.GeneralSecurityException", "java.security.SecureRandom", "java.security.cert.X509Certificate",
+		"javax.net.ssl.SSLContext", "javax.net.ssl.TrustManager", "javax.net.ssl.X509TrustManager")
+	self.prepare = append([]string{"System.setProperty(\"jdk.internal.httpclient.disableHostnameVerification\", \"true\");"}, self.prepare...)
+	self.clientBuilder = append(self.clientBuilder, ".sslContext(insecureSSLContext())")
+}
+
+func (self *HttpClientGenerator) SetTimeout() {
+	milliseconds := func(seconds float64) string {
+		return strconv.FormatInt(int64(seconds*1000), 10)
+	}
+	self.Modules["java.time.Duration"] = true
+	if self.Options.ConnectTimeout != 0 {
+		self.clientBuilder = append(self.clientBuilder, fmt.Sprintf(".connectTimeout(Duration.ofMillis(%s))", milliseconds(self.Options.ConnectTimeout)))
+	}
+	if self.Options.MaxTime != 0 {
+		self.requestBuilder = append(self.requestBuilder, fmt.Sprintf(".timeout(Duration.ofMillis(%s))", milliseconds(self.Options.MaxTime)))
+	}
+}
+
+/*
+	Dispatcher function of curl command
+	This is an exported function and called from httpgen.
+*/
+func ProcessCurlCommandForHttpClient(options *common.CurlOptions) (string, interface{}, error) {
+	generator := NewHttpClientGenerator(options)
+
+	if options.Http2Flag {
+		generator.clientBuilder = append(generator.clientBuilder, ".version(HttpClient.Version.HTTP_2)")
+	} else {
+		generator.clientBuilder = append(generator.clientBuilder, ".version(HttpClient.Version.HTTP_1_1)")
+	}
+	if err := ProcessData(options, generator); err != nil {
+		return "", nil, err
+	}
+	for _, header := range options.Headers() {
+		if generator.multipart && strings.ToLower(header[0]) == "content-type" {
+			continue
+		}
+		generator.setHeader(header[0], literal.Java(header[1]))
+	}
+	if generator.multipart {
+		generator.setHeader("Content-Type", "\"multipart/form-data; boundary=\" + boundary")
+	}
+	method := options.Method()
+	if generator.body != "" {
+		generator.requestBuilder = append(generator.requestBuilder, fmt.Sprintf(".method(%s, %s)", literal.Java(method), generator.body))
+	} else if method != "GET" {
+		generator.requestBuilder = append(generator.requestBuilder, fmt.Sprintf(".method(%s, HttpRequest.BodyPublishers.noBody())", literal.Java(method)))
+	}
+	if options.UseBasicAuth() {
+		addModules(generator.Modules, "java.nio.charset.StandardCharsets", "java.util.Base64")
+		generator.setHeader("Authorization", fmt.Sprintf("\"Basic \" + Base64.getEncoder().encodeToString(%s.getBytes(StandardCharsets.UTF_8))", literal.Java(options.User)))
+	} else if options.UseDigestAuth() {
+		generator.AdditionalDeclaration += digestAuthorizationCode
+		addModules(generator.Modules, digestAuthorizationModules...)
+	}
+	if options.UseCookieJar() {
+		generator.SetCookie()
+	} else if len(options.Cookies()) != 0 {
+		generator.setHeader("Cookie", literal.Java(options.CookieString()))
+	}
+	if options.Proxy != "" {
+		generator.SetProxy()
+	}
+	if options.Insecure {
+		generator.SetInsecure()
+	}
+	if options.ConnectTimeout != 0 || options.MaxTime != 0 {
+		generator.SetTimeout()
+	}
+
+	return "full", *generator, nil
+}
@@ -0,0 +1,142 @@
+package java
+
+import (
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"strings"
+)
+
+/*
+	Request in a module of HttpURLConnection. It is written as a static method that returns the connection.
+*/
+type JavaFunction struct {
+	JavaGenerator
+	Name  string
+	Title string
+}
+
+/*
+	Module that has methods of requests in one class. Imports and helper methods are shared.
+*/
+type JavaModule struct {
+	Package   string
+	Modules   map[string]bool
+	Functions []JavaFunction
+	HasMain   bool
+
+	declarations []string
+}
+
+var reservedNames = map[string]bool{
+	// variables of generated methods and helpers
+	"url": true, "conn": true, "writer": true, "content": true, "fields": true, "files": true, "cookiemanager": true,
+	"buffer": true, "filereader": true, "bufferedreader": true, "str": true, "authorization": true, "wr": true,
+	"retrywr": true, "mimetype": true, "filecontent": true, "args": true, "input": true, "br": true,
+	"digestauthorization": true, "md5hex": true, "loadcookies": true, "savecookies": true,
+	"encodemultipartformdata": true, "boundary": true, "print": true, "main": true,
+	// keywords
+	"abstract": true, "assert": true, "boolean": true, "break": true, "byte": true, "case": true, "catch": true,
+	"char": true, "class": true, "const": true, "continue": true, "default": true, "do": true, "double": true,
+	"else": true, "enum": true, "extends": true, "false": true, "final": true, "finally": true, "float": true,
+	"for": true, "goto": true, "if": true, "implements": true, "import": true, "instanceof": true, "int": true,
+	"interface": true, "long": true, "native": true, "new": true, "null": true, "package": true, "private": true,
+	"protected": true, "public": true, "return": true, "short": true, "static": true, "strictfp": true,
+	"super": true, "switch": true, "synchronized": true, "this": true, "throw": true, "throws": true,
+	"transient": true, "true": true, "try": true, "void": true, "volatile": true, "while": true,
+}
+
+/*
+	Name of the method parameter like "userId" for the parameter "user_id".
+*/
+func ParameterName(name string) string {
+	result := common.CamelCase(common.IdentifierWords(name))
+	if reservedNames[strings.ToLower(result)] {
+		result += "Value"
+	}
+	return result
+}
+
+/*
+	Parameters of the method like "String userId, String token".
+*/
+func (self JavaFunction) Arguments() string {
+	var arguments []string
+	for _, parameter := range self.Options.FunctionParameters() {
+		arguments = append(arguments, "String "+ParameterName(parameter.Name))
+	}
+	return strings.Join(arguments, ", ")
+}
+
+/*
+	Call of the method in main(). Parameters are read from environment variables.
+*/
+func (self JavaFunction) Sender() string {
+	var arguments []string
+	for _, parameter := range self.Options.FunctionParameters() {
+		arguments = append(arguments, fmt.Sprintf("System.getenv(%q)", parameter.EnvironmentVariable()))
+	}
+	return fmt.Sprintf("%s(%s)", self.Name, strings.Join(arguments, ", "))
+}
+
+/*
+	CookieManager keeps cookies when the response is received. The method receives it before saving cookies
+	because the caller reads the response.
+*/
+func (self JavaFunction) SaveCookies() string {
+	saveCookies := self.JavaGenerator.SaveCookies()
+	if saveCookies == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n%sconn.getResponseCode();%s", self.indent, saveCookies)
+}
+
+func (self JavaModule) AdditionalDeclaration() string {
+	return strings.Join(self.declarations, "")
+}
+
+/*
+	Create a class that has a static method for each request. Methods return the connection and the caller reads the response.
+	The class is Main. It is in the default package like programs of this generator if packageName is empty or "main".
+*/
+func ProcessCurlCommandsForModule(requests []common.NamedRequest, packageName string, hasMain bool) (string, interface{}, error) {
+	module := JavaModule{Modules: map[string]bool{"java.net.HttpURLConnection": true}, HasMain: hasMain}
+	if packageName != "main" {
+		module.Package = packageName
+	}
+	used := make(map[string]bool)
+	for name := range reservedNames {
+		used[name] = true
+	}
+	for i := range requests {
+		generator := NewJavaGenerator(requests[i].Options)
+		generator.indent = "        "
+		if err := processJavaRequest(generator); err != nil {
+			return "", nil, err
+		}
+		for key := range generator.Modules {
+			module.Modules[key] = true
+		}
+		// helper methods are constants of this package
+		for _, declaration := range generator.declarations {
+			if !contains(module.declarations, declaration) {
+				module.declarations = append(module.declarations, declaration)
+			}
+		}
+		names := append(append([]string(nil), requests[i].Groups...), requests[i].Name)
+		module.Functions = append(module.Functions, JavaFunction{
+			JavaGenerator: *generator,
+			Name:          common.UniqueName(common.CamelCase(common.IdentifierWords(names...)), used),
+			Title:         strings.Join(names, " / "),
+		})
+	}
+	return "module", module, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package java
+
+import (
+	"github.com/shibukawa/curl_as_dsl/common"
+)
+
+/*
+	DataProcessor is implemented by the generators for JVM languages.
+	ProcessData classifies -d, --data-* and -F options and calls one of them.
+*/
+type DataProcessor interface {
+	SetDataForUrl() error
+	SetDataForBody() error
+	SetFormForBody()
+}
+
+func ProcessData(options *common.CurlOptions, generator DataProcessor) error {
+	if options.ProcessedData.HasData() {
+		if options.Get {
+			return generator.SetDataForUrl()
+		}
+		options.InsertDataContentTypeHeader()
+		return generator.SetDataForBody()
+	} else if options.ProcessedData.HasForm() {
+		generator.SetFormForBody()
+	}
+	return nil
+}
+
+func addModules(modules map[string]bool, names ...string) {
+	for _, name := range names {
+		modules[name] = true
+	}
+}
+
+/*
+	Java source code shared by HttpURLConnection and HttpClient generators.
+*/
+const digestAuthorizationCode = `
+    static String digestAuthorization(String challenge, String method, String uri, String username, String password) throws IOException {
+        Map<String, String> params = new HashMap<String, String>();
+        Matcher matcher = Pattern.compile("(\\w+)=(?:\"([^\"]*)\"|([^,\\s]*))").matcher(challenge);
+        while (matcher.find()) {
+            params.put(matcher.group(1), matcher.group(2) != null ? matcher.group(2) : matcher.group(3));
+        }
+        String ha1 = md5Hex(username + ":" + params.get("realm") + ":" + password);
+        String ha2 = md5Hex(method + ":" + uri);
+        String authorization = String.format("Digest username=\"%s\", realm=\"%s\", nonce=\"%s\", uri=\"%s\"", username, params.get("realm"), params.get("nonce"), uri);
+        if (params.containsKey("qop")) {
+            byte[] cnonceBytes = new byte[8];
+            new SecureRandom().nextBytes(cnonceBytes);
+            String cnonce = String.format("%016x", new BigInteger(1, cnonceBytes));
+            String response = md5Hex(ha1 + ":" + params.get("nonce") + ":00000001:" + cnonce + ":auth:" + ha2);
+            authorization += String.format(", qop=auth, nc=00000001, cnonce=\"%s\", response=\"%s\"", cnonce, response);
+        } else {
+            authorization += String.format(", response=\"%s\"", md5Hex(ha1 + ":" + params.get("nonce") + ":" + ha2));
+        }
+        if (params.containsKey("opaque")) {
+            authorization += String.format(", opaque=\"%s\"", params.get("opaque"));
+        }
+        return authorization + ", algorithm=MD5";
+    }
+
+    static String md5Hex(String text) throws IOException {
+        try {
+            byte[] digest = MessageDigest.getInstance("MD5").digest(text.getBytes("UTF-8"));
+            return String.format("%032x", new BigInteger(1, digest));
+        } catch (NoSuchAlgorithmException e) {
+            throw new IOException(e);
+        }
+    }
+`
+
+var digestAuthorizationModules = []string{
+	"java.io.IOException",
+	"java.math.BigInteger",
+	"java.security.MessageDigest",
+	"java.security.NoSuchAlgorithmException",
+	"java.security.SecureRandom",
+	"java.util.HashMap",
+	"java.util.Map",
+	"java.util.regex.Matcher",
+	"java.util.regex.Pattern",
+}
+
+const loadCookiesCode = `
+    static void loadCookies(CookieStore cookieStore, String fileName) throws IOException {
+        File file = new File(fileName);
+        if (!file.exists()) {
+            return;
+        }
+        BufferedReader reader = new BufferedReader(new FileReader(file));
+        String line;
+        while ((line = reader.readLine()) != null) {
+            boolean httpOnly = line.startsWith("#HttpOnly_");
+            if (httpOnly) {
+                line = line.substring("#HttpOnly_".length());
+            }
+            String[] fields = line.trim().split("\t", -1);
+            if (line.startsWith("#") || fields.length != 7) {
+                continue;
+            }
+            HttpCookie cookie = new HttpCookie(fields[5], fields[6]);
+            cookie.setVersion(0);
+            String host = fields[0].startsWith(".") ? fields[0].substring(1) : fields[0];
+            if (fields[1].equals("TRUE")) {
+                cookie.setDomain(fields[0]);
+            }
+            cookie.setPath(fields[2]);
+            cookie.setSecure(fields[3].equals("TRUE"));
+            cookie.setHttpOnly(httpOnly);
+            long expires = Long.parseLong(fields[4]);
+            if (expires != 0) {
+                cookie.setMaxAge(expires - System.currentTimeMillis() / 1000);
+            }
+            cookieStore.add(URI.create("http://" + host + "/"), cookie);
+        }
+        reader.close();
+    }
+`
+
+var loadCookiesModules = []string{
+	"java.io.BufferedReader",
+	"java.io.IOException",
+	"java.io.File",
+	"java.io.FileReader",
+	"java.net.CookieHandler",
+	"java.net.CookieManager",
+	"java.net.CookiePolicy",
+	"java.net.CookieStore",
+	"java.net.HttpCookie",
+	"java.net.URI",
+}
+
+const saveCookiesCode = `
+    static void saveCookies(CookieStore cookieStore, String fileName) throws IOException {
+        PrintWriter writer = new PrintWriter(new FileWriter(fileName));
+        Set<HttpCookie> saved = new HashSet<HttpCookie>();
+        long now = System.currentTimeMillis() / 1000;
+        writer.println("# Netscape HTTP Cookie File");
+        for (URI uri : cookieStore.getURIs()) {
+            for (HttpCookie cookie : cookieStore.get(uri)) {
+                if (!saved.add(cookie)) {
+                    continue;
+                }
+                String domain = cookie.getDomain();
+                if (domain == null || domain.equalsIgnoreCase(uri.getHost() + ".local")) {
+                    domain = uri.getHost();
+                }
+                writer.printf("%s%s\t%s\t%s\t%s\t%d\t%s\t%s\n", cookie.isHttpOnly() ? "#HttpOnly_" : "", domain,
+                    domain.startsWith(".") ? "TRUE" : "FALSE", cookie.getPath() != null ? cookie.getPath() : "/",
+                    cookie.getSecure() ? "TRUE" : "FALSE", cookie.getMaxAge() < 0 ? 0 : now + cookie.getMaxAge(),
+                    cookie.getName(), cookie.getValue());
+            }
+        }
+        writer.close();
+    }
+`
+
+var saveCookiesModules = []string{
+	"java.io.FileWriter",
+	"java.io.PrintWriter",
+	"java.util.HashSet",
+	"java.util.Set",
+}
@@ -0,0 +1,488 @@
+package kotlin
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/client/java"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/literal"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const okHttpVersion = "4.12.0"
+
+type OkHttpGenerator struct {
+	Options *common.CurlOptions
+	Modules map[string]bool
+
+	Url                   string
+	AdditionalDeclaration string
+	body                  string
+	contentType           string
+	prepare               []string
+	clientBuilder         []string
+	requestBuilder        []string
+	dependencies          []string
+}
+
+func NewOkHttpGenerator(options *common.CurlOptions) *OkHttpGenerator {
+	result := &OkHttpGenerator{Options: options}
+	result.Url = literal.Kotlin(options.Url)
+	result.Modules = make(map[string]bool)
+	result.Modules["okhttp3.OkHttpClient"] = true
+	result.Modules["okhttp3.Request"] = true
+	result.dependencies = []string{"okhttp"}
+	return result
+}
+
+//--- Getter methods called from template
+
+func (self OkHttpGenerator) Dependencies() string {
+	var buffer bytes.Buffer
+	for _, artifact := range self.dependencies {
+		fmt.Fprintf(&buffer, "//     implementation(\"com.squareup.okhttp3:%s:%s\")\n", artifact, okHttpVersion)
+	}
+	return buffer.String()
+}
+
+func (self OkHttpGenerator) Prepare() string {
+	var buffer bytes.Buffer
+	for _, line := range self.prepare {
+		fmt.Fprintf(&buffer, "%s\n    ", line)
+	}
+	return buffer.String()
+}
+
+/*
+	OkHttpClient() is used when there is no special settings.
+*/
+func (self OkHttpGenerator) Client() string {
+	if len(self.clientBuilder) == 0 {
+		return "OkHttpClient()"
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("OkHttpClient.Builder()")
+	for _, line := range self.clientBuilder {
+		fmt.Fprintf(&buffer, "\n        %s", line)
+	}
+	buffer.WriteString("\n        .build()")
+	return buffer.String()
+}
+
+func (self OkHttpGenerator) RequestBuilder() string {
+	var buffer bytes.Buffer
+	for _, line := range self.requestBuilder {
+		fmt.Fprintf(&buffer, "\n        %s", line)
+	}
+	return buffer.String()
+}
+
+func (self OkHttpGenerator) SaveCookies() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n    saveCookies(cookieManager.cookieStore, %s)", literal.Kotlin(self.Options.CookieJar))
+}
+
+//--- Preparing Kotlin source code methods
+
+func (self *OkHttpGenerator) SetDataForUrl() error {
+	separator := "?"
+	if strings.Contains(self.Options.Url, "?") {
+		separator = "&"
+	}
+	query, err := self.stringBody()
+	if err != nil {
+		return err
+	}
+	self.prepare = append(self.prepare, fmt.Sprintf("val query = %s", query))
+	self.Url = fmt.Sprintf("%s + query", literal.Kotlin(self.Options.Url+separator))
+	return nil
+}
+
+/*
+	OkHttp overwrites Content-Type header by the media type of request body.
+*/
+func (self *OkHttpGenerator) SetDataForBody() error {
+	self.contentType = self.Options.FindContentTypeHeader()
+	if len(self.Options.ProcessedData) == 1 {
+		data := &self.Options.ProcessedData[0]
+		if data.Type == common.DataBinaryType && data.UseExternalFile() {
+			self.Modules["java.io.File"] = true
+			self.Modules["okhttp3.RequestBody.Companion.asRequestBody"] = true
+			self.body = fmt.Sprintf("File(%s).asRequestBody(%s)", literal.Kotlin(data.Value[1:]), self.mediaType())
+			return nil
+		}
+	}
+	body, err := self.stringBody()
+	if err != nil {
+		return err
+	}
+	if strings.Contains(body, " + ") {
+		body = "(" + body + ")"
+	}
+	// String.toRequestBody() appends charset to the media type
+	self.Modules["okhttp3.RequestBody.Companion.toRequestBody"] = true
+	self.body = fmt.Sprintf("%s.toByteArray().toRequestBody(%s)", body, self.mediaType())
+	return nil
+}
+
+/*
+	Media type of the request body. The body of -T doesn't have it and OkHttp doesn't send Content-Type.
+*/
+func (self *OkHttpGenerator) mediaType() string {
+	if self.contentType == "" {
+		return "null"
+	}
+	self.Modules["okhttp3.MediaType.Companion.toMediaType"] = true
+	return literal.Kotlin(self.contentType) + ".toMediaType()"
+}
+
+func (self *OkHttpGenerator) stringBody() (string, error) {
+	if len(self.Options.ProcessedData) == 1 {
+		return self.dataExpression(&self.Options.ProcessedData[0])
+	}
+	var values []string
+	for _, data := range self.Options.ProcessedData {
+		value, err := self.dataExpression(&data)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, value)
+	}
+	return fmt.Sprintf("listOf(%s).joinToString(\"&\")", strings.Join(values, ", ")), nil
+}
+
+func (self *OkHttpGenerator) dataExpression(data *common.DataOption) (string, error) {
+	readFile := func(fileName string) string {
+		self.Modules["java.io.File"] = true
+		return fmt.Sprintf("File(%s).readText()", literal.Kotlin(fileName))
+	}
+	switch data.Type {
+	case common.DataAsciiType:
+		if data.UseExternalFile() {
+			self.Modules["java.io.File"] = true
+			return fmt.Sprintf("File(%s).readLines().joinToString(\"\")", literal.Kotlin(data.Value[1:])), nil
+		}
+		return literal.Kotlin(data.Value), nil
+	case common.DataBinaryType:
+		if data.UseExternalFile() {
+			return readFile(data.Value[1:]), nil
+		}
+		return literal.Kotlin(data.Value), nil
+	case common.DataUrlEncodeType:
+		self.AddUrlEncodeCode()
+		// curl's rule: "content", "=content", "name=content", "@filename" and "name@filename"
+		index := strings.IndexAny(data.Value, "=@")
+		var content string
+		if index == -1 {
+			content = literal.Kotlin(data.Value)
+		} else if data.Value[index] == '@' {
+			content = readFile(data.Value[index+1:])
+		} else {
+			content = literal.Kotlin(data.Value[index+1:])
+		}
+		if index > 0 {
+			return fmt.Sprintf("%s + urlEncode(%s)", literal.Kotlin(data.Value[:index]+"="), content), nil
+		}
+		return fmt.Sprintf("urlEncode(%s)", content), nil
+	default:
+		return "", common.UnexpectedDataError("kotlin", data)
+	}
+}
+
+func (self *OkHttpGenerator) AddUrlEncodeCode() {
+	if strings.Contains(self.AdditionalDeclaration, "fun urlEncode") {
+		return
+	}
+	self.AdditionalDeclaration += `
+// Same as curl's --data-urlencode. It uses "+" for space like URLEncoder, but encodes "*" and keeps "~".
+fun urlEncode(source: String): String =
+    URLEncoder.encode(source, "UTF-8").replace("*", "%2A").replace("%7E", "~")
+`
+	self.Modules["java.net.URLEncoder"] = true
+}
+
+/*
+	OkHttp has multipart encoder. MultipartBody sets Content-Type header with boundary.
+*/
+func (self *OkHttpGenerator) SetFormForBody() {
+	self.Modules["okhttp3.MultipartBody"] = true
+	self.prepare = append(self.prepare, "val body = MultipartBody.Builder()")
+	self.prepare = append(self.prepare, "    .setType(MultipartBody.FORM)")
+
+	for _, data := range self.Options.ProcessedData {
+		field := strings.SplitN(data.Value, "=", 2)
+		if data.Type == common.FormType && (strings.HasPrefix(field[1], "@") || strings.HasPrefix(field[1], "<")) {
+			self.Modules["java.io.File"] = true
+			self.Modules["okhttp3.MediaType.Companion.toMediaType"] = true
+			fragments := strings.Split(field[1][1:], ";")
+			contentType := ""
+			sentFileName := fragments[0]
+			for _, fragment := range fragments[1:] {
+				if strings.HasPrefix(fragment, "filename=") {
+					sentFileName = fragment[9:]
+				} else if strings.HasPrefix(fragment, "type=") {
+					contentType = fragment[5:]
+				}
+			}
+			if field[1][0] == '@' {
+				self.Modules["okhttp3.RequestBody.Companion.asRequestBody"] = true
+				mediaType := literal.Kotlin(contentType)
+				if contentType == "" {
+					self.Modules["java.net.URLConnection"] = true
+					mediaType = fmt.Sprintf("(URLConnection.guessContentTypeFromName(%s) ?: \"application/octet-stream\")", literal.Kotlin(fragments[0]))
+				}
+				self.prepare = append(self.prepare, fmt.Sprintf("    .addFormDataPart(%s, %s, File(%s).asRequestBody(%s.toMediaType()))", literal.Kotlin(field[0]), literal.Kotlin(sentFileName), literal.Kotlin(fragments[0]), mediaType))
+			} else if contentType == "" {
+				self.prepare = append(self.prepare, fmt.Sprintf("    .addFormDataPart(%s, File(%s).readText())", literal.Kotlin(field[0]), literal.Kotlin(fragments[0])))
+			} else {
+				self.Modules["okhttp3.RequestBody.Companion.toRequestBody"] = true
+				self.prepare = append(self.prepare, fmt.Sprintf("    .addPart(MultipartBody.Part.createFormData(%s, null, File(%s).readBytes().toRequestBody(%s.toMediaType())))", literal.Kotlin(field[0]), literal.Kotlin(fragments[0]), literal.Kotlin(contentType)))
+			}
+		} else {
+			self.prepare = append(self.prepare, fmt.Sprintf("    .addFormDataPart(%s, %s)", literal.Kotlin(field[0]), literal.Kotlin(field[1])))
+		}
+	}
+	self.prepare = append(self.prepare, "    .build()")
+	self.body = "body"
+	self.contentType = "multipart/form-data"
+}
+
+/*
+	OkHttp doesn't support Digest authentication. Authenticator answers the challenge.
+*/
+func (self *OkHttpGenerator) SetDigestAuth() {
+	user, password := self.Options.UserAndPassword()
+	self.AdditionalDeclaration += `
+fun digestAuthorization(challenge: String, method: String, uri: String, username: String, password: String): String {
+    val params = Regex("(\\w+)=(?:\"([^\"]*)\"|([^,\\s]*))").findAll(challenge)
+        .associate { it.groupValues[1] to it.groupValues[2].ifEmpty { it.groupValues[3] } }
+    val ha1 = md5Hex("$username:${params["realm"]}:$password")
+    val ha2 = md5Hex("$method:$uri")
+    var authorization = "Digest username=\"$username\", realm=\"${params["realm"]}\", nonce=\"${params["nonce"]}\", uri=\"$uri\""
+    if ("qop" in params) {
+        val cnonce = "%016x".format(SecureRandom().nextLong())
+        val response = md5Hex("$ha1:${params["nonce"]}:00000001:$cnonce:auth:$ha2")
+        authorization += ", qop=auth, nc=00000001, cnonce=\"$cnonce\", response=\"$response\""
+    } else {
+        authorization += ", response=\"${md5Hex("$ha1:${params["nonce"]}:$ha2")}\""
+    }
+    params["opaque"]?.let { authorization += ", opaque=\"$it\"" }
+    return "$authorization, algorithm=MD5"
+}
+
+fun md5Hex(text: String): String =
+    MessageDigest.getInstance("MD5").digest(text.toByteArray()).joinToString("") { "%02x".format(it) }
+`
+	self.Modules["java.security.MessageDigest"] = true
+	self.Modules["java.security.SecureRandom"] = true
+	self.Modules["okhttp3.Authenticator"] = true
+	self.Modules["okhttp3.Response"] = true
+	self.Modules["okhttp3.Route"] = true
+	self.prepare = append(self.prepare, "val authenticator = object : Authenticator {")
+	self.prepare = append(self.prepare, "    override fun authenticate(route: Route?, response: Response): Request? {")
+	self.prepare = append(self.prepare, "        val challenge = response.header(\"WWW-Authenticate\")")
+	self.prepare = append(self.prepare, "        if (challenge == null || response.request.header(\"Authorization\") != null) {")
+	self.prepare = append(self.prepare, "            return null")
+	self.prepare = append(self.prepare, "        }")
+	self.prepare = append(self.prepare, "        val url = response.request.url")
+	self.prepare = append(self.prepare, "        val uri = url.encodedPath + (url.encodedQuery?.let { \"?$it\" } ?: \"\")")
+	self.prepare = append(self.prepare, fmt.Sprintf("        val authorization = digestAuthorization(challenge, response.request.method, uri, %s, %s)", literal.Kotlin(user), literal.Kotlin(password)))
+	self.prepare = append(self.prepare, "        return response.request.newBuilder().header(\"Authorization\", authorization).build()")
+	self.prepare = append(self.prepare, "    }")
+	self.prepare = append(self.prepare, "}")
+	self.clientBuilder = append(self.clientBuilder, ".authenticator(authenticator)")
+}
+
+/*
+	JavaNetCookieJar bridges java.net.CookieManager. Cookie files are read and written in Netscape format.
+*/
+func (self *OkHttpGenerator) SetCookie() {
+	self.dependencies = append(self.dependencies, "okhttp-urlconnection")
+	self.Modules["java.net.CookieManager"] = true
+	self.Modules["java.net.CookiePolicy"] = true
+	self.Modules["java.net.CookieStore"] = true
+	self.Modules["java.net.HttpCookie"] = true
+	self.Modules["java.net.URI"] = true
+	self.Modules["okhttp3.JavaNetCookieJar"] = true
+	self.prepare = append(self.prepare, "val cookieManager = CookieManager(null, CookiePolicy.ACCEPT_ALL)")
+	for _, fileName := range self.Options.CookieFiles() {
+		self.prepare = append(self.prepare, fmt.Sprintf("loadCookies(cookieManager.cookieStore, %s)", literal.Kotlin(fileName)))
+	}
+	for _, cookie := range self.Options.Cookies() {
+		self.prepare = append(self.prepare, fmt.Sprintf("cookieManager.cookieStore.add(URI.create(%s), HttpCookie(%s, %s).apply {", literal.Kotlin(self.Options.Url), literal.Kotlin(cookie[0]), literal.Kotlin(cookie[1])))
+		self.prepare = append(self.prepare, "    path = \"/\"")
+		self.prepare = append(self.prepare, "    version = 0")
+		self.prepare = append(self.prepare, "})")
+	}
+	self.clientBuilder = append(self.clientBuilder, ".cookieJar(JavaNetCookieJar(cookieManager))")
+
+	self.Modules["java.io.File"] = true
+	self.AdditionalDeclaration += `
+fun loadCookies(cookieStore: CookieStore, fileName: String) {
+    val file = File(fileName)
+    if (!file.exists()) {
+        return
+    }
+    file.forEachLine { rawLine ->
+        val httpOnly = rawLine.startsWith("#HttpOnly_")
+        val line = rawLine.removePrefix("#HttpOnly_")
+        val fields = line.split("\t")
+        if (line.startsWith("#") || fields.size != 7) {
+            return@forEachLine
+        }
+        val cookie = HttpCookie(fields[5], fields[6])
+        cookie.version = 0
+        if (fields[1] == "TRUE") {
+            cookie.domain = fields[0]
+        }
+        cookie.path = fields[2]
+        cookie.secure = fields[3] == "TRUE"
+        cookie.isHttpOnly = httpOnly
+        val expires = fields[4].toLong()
+        if (expires != 0L) {
+            cookie.maxAge = expires - System.currentTimeMillis() / 1000
+        }
+        cookieStore.add(URI.create("http://" + fields[0].removePrefix(".") + "/"), cookie)
+    }
+}
+`
+	if self.Options.CookieJar == "" {
+		return
+	}
+	self.AdditionalDeclaration += `
+fun saveCookies(cookieStore: CookieStore, fileName: String) {
+    val now = System.currentTimeMillis() / 1000
+    val saved = mutableSetOf<HttpCookie>()
+    File(fileName).printWriter().use { writer ->
+        writer.println("# Netscape HTTP Cookie File")
+        for (uri in cookieStore.getURIs()) {
+            for (cookie in cookieStore.get(uri)) {
+                if (!saved.add(cookie)) {
+                    continue
+                }
+                val domain = cookie.domain ?: uri.host
+                val fields = listOf((if (cookie.isHttpOnly) "#HttpOnly_" else "") + domain,
+                    if (domain.startsWith(".")) "TRUE" else "FALSE", cookie.path ?: "/",
+                    if (cookie.secure) "TRUE" else "FALSE", if (cookie.maxAge < 0) "0" else (now + cookie.maxAge).toString(),
+                    cookie.name, cookie.value)
+                writer.println(fields.joinToString("\t"))
+            }
+        }
+    }
+}
+`
+}
+
+/*
+	curl uses 1080 as default proxy port.
+*/
+func (self *OkHttpGenerator) SetProxy() {
+	proxy := self.Options.Proxy
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	u, _ := url.Parse(proxy)
+	port := u.Port()
+	if port == "" {
+		port = "1080"
+	}
+	proxyType := "HTTP"
+	if strings.HasPrefix(u.Scheme, "socks") {
+		proxyType = "SOCKS"
+	}
+	self.Modules["java.net.InetSocketAddress"] = true
+	self.Modules["java.net.Proxy"] = true
+	self.clientBuilder = append(self.clientBuilder, fmt.Sprintf(".proxy(Proxy(Proxy.Type.%s, InetSocketAddress(%s, %s)))", proxyType, literal.Kotlin(u.Hostname()), port))
+}
+
+/*
+	Trust all certificates and host names like curl's -k option.
+*/
+func (self *OkHttpGenerator) SetInsecure() {
+	self.Modules["java.security.SecureRandom"] = true
+	self.Modules["java.security.cert.X509Certificate"] = true
+	self.Modules["javax.net.ssl.SSLContext"] = true
+	self.Modules["javax.net.ssl.TrustManager"] = true
+	self.Modules["javax.net.ssl.X509TrustManager"] = true
+	self.prepare = append(self.prepare, "val trustAllManager = object : X509TrustManager {")
+	self.prepare = append(self.prepare, "    override fun checkClientTrusted(chain: Array<X509Certificate>, authType: String) {}")
+	self.prepare = append(self.prepare, "    override fun checkServerTrusted(chain: Array<X509Certificate>, authType: String) {}")
+	self.prepare = append(self.prepare, "    override fun getAcceptedIssuers(): Array<X509Certificate> = arrayOf()")
+	self.prepare = append(self.prepare, "}")
+	self.prepare = append(self.prepare, "val sslContext = SSLContext.getInstance(\"TLS\")")
+	self.prepare = append(self.prepare, "sslContext.init(null, arrayOf<TrustManager>(trustAllManager), SecureRandom())")
+	self.clientBuilder = append(self.clientBuilder, ".sslSocketFactory(sslContext.socketFactory, trustAllManager)")
+	self.clientBuilder = append(self.clientBuilder, ".hostnameVerifier { _, _ -> true }")
+}
+
+func (self *OkHttpGenerator) SetTimeout() {
+	milliseconds := func(seconds float64) string {
+		return strconv.FormatInt(int64(seconds*1000), 10)
+	}
+	self.Modules["java.time.Duration"] = true
+	if self.Options.ConnectTimeout != 0 {
+		self.clientBuilder = append(self.clientBuilder, fmt.Sprintf(".connectTimeout(Duration.ofMillis(%s))", milliseconds(self.Options.ConnectTimeout)))
+	}
+	if self.Options.MaxTime != 0 {
+		self.clientBuilder = append(self.clientBuilder, fmt.Sprintf(".callTimeout(Duration.ofMillis(%s))", milliseconds(self.Options.MaxTime)))
+	}
+}
+
+/*
+	Dispatcher function of curl command
+	This is an exported function and called from httpgen.
+*/
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
+	generator := NewOkHttpGenerator(options)
+
+	if err := java.ProcessData(options, generator); err != nil {
+		return "", nil, err
+	}
+	for _, header := range options.Headers() {
+		if generator.contentType != "" && strings.ToLower(header[0]) == "content-type" {
+			continue
+		}
+		generator.requestBuilder = append(generator.requestBuilder, fmt.Sprintf(".addHeader(%s, %s)", literal.Kotlin(header[0]), literal.Kotlin(header[1])))
+	}
+	method := options.Method()
+	if generator.body != "" {
+		generator.requestBuilder = append(generator.requestBuilder, fmt.Sprintf(".method(%s, %s)", literal.Kotlin(method), generator.body))
+	} else if method == "POST" || method == "PUT" || method == "PATCH" {
+		// OkHttp requires request body for these methods
+		generator.Modules["okhttp3.RequestBody.Companion.toRequestBody"] = true
+		generator.requestBuilder = append(generator.requestBuilder, fmt.Sprintf(".method(%s, ByteArray(0).toRequestBody())", literal.Kotlin(method)))
+	} else if method != "GET" {
+		generator.requestBuilder = append(generator.requestBuilder, fmt.Sprintf(".method(%s, null)", literal.Kotlin(method)))
+	}
+	if options.UseBasicAuth() {
+		user, password := options.UserAndPassword()
+		generator.Modules["okhttp3.Credentials"] = true
+		generator.requestBuilder = append(generator.requestBuilder, fmt.Sprintf(".addHeader(\"Authorization\", Credentials.basic(%s, %s))", literal.Kotlin(user), literal.Kotlin(password)))
+	} else if options.UseDigestAuth() {
+		generator.SetDigestAuth()
+	}
+	if options.UseCookieJar() {
+		generator.SetCookie()
+	} else if len(options.Cookies()) != 0 {
+		generator.requestBuilder = append(generator.requestBuilder, fmt.Sprintf(".addHeader(\"Cookie\", %s)", literal.Kotlin(options.CookieString())))
+	}
+	if options.Proxy != "" {
+		generator.SetProxy()
+	}
+	if options.Insecure {
+		generator.SetInsecure()
+	}
+	if options.ConnectTimeout != 0 || options.MaxTime != 0 {
+		generator.SetTimeout()
+	}
+	// OkHttp negotiates HTTP/2 via ALPN for https URL by default
+	if options.Http2Flag && options.ParsedUrl().Scheme == "http" {
+		options.AddWarning("OkHttp doesn't support HTTP/2 upgrade from HTTP/1.1 (h2c). --http2 works only for https URL.")
+	}
+
+	return "full", *generator, nil
+}
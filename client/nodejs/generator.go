@@ -4,17 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/shibukawa/curl_as_dsl/common"
-	"log"
-	"net/url"
-	"os"
+	"github.com/shibukawa/curl_as_dsl/literal"
 	"strconv"
 	"strings"
 )
 
-func escapeDQ(src string) string {
-	return strings.Replace(strings.Replace(src, "\"", "\\\"", -1), "\\", "\\\\", -1)
-}
-
 type ExternalFile struct {
 	FileName string
 	TextType bool
@@ -30,6 +24,7 @@ type NodeJsGenerator struct {
 	BodyLines             []string
 	ExternalFiles         []ExternalFile
 	usedFile              int
+	useUrlEncode          bool
 	extraUrl              string
 	AdditionalDeclaration string
 	processedHeaders      []common.HeaderGroup
@@ -48,29 +43,26 @@ func NewNodeJsGenerator(options *common.CurlOptions) *NodeJsGenerator {
 //--- Getter methods called from template
 
 func (self NodeJsGenerator) Url() string {
-	return fmt.Sprintf("\"%s\"", self.Options.Url)
+	return literal.JavaScript(self.Options.Url)
 }
 
+/*
+	String literal of the host name without the port.
+*/
 func (self NodeJsGenerator) Host() string {
-	u, err := url.Parse(self.Options.Url)
-	if err != nil {
-		log.Fatal(err)
-	}
+	u := self.Options.ParsedUrl()
 	fragments := strings.SplitN(u.Host, ":", 2)
 	if len(fragments) > 1 {
-		_, err = strconv.Atoi(fragments[1])
+		_, err := strconv.Atoi(fragments[1])
 		if err != nil {
-			return u.Host
+			return literal.JavaScript(u.Host)
 		}
 	}
-	return fragments[0]
+	return literal.JavaScript(fragments[0])
 }
 
 func (self NodeJsGenerator) Port() int {
-	u, err := url.Parse(self.Options.Url)
-	if err != nil {
-		log.Fatal(err)
-	}
+	u := self.Options.ParsedUrl()
 	fragments := strings.SplitN(u.Host, ":", 2)
 	if len(fragments) > 1 {
 		port, err := strconv.Atoi(fragments[1])
@@ -86,28 +78,24 @@ func (self NodeJsGenerator) Port() int {
 }
 
 func (self NodeJsGenerator) Method() string {
-	return self.Options.Method()
+	return literal.JavaScript(self.Options.Method())
 }
 
 func (self NodeJsGenerator) Path() string {
-	u, err := url.Parse(self.Options.Url)
-	if err != nil {
-		log.Fatal(err)
-	}
+	u := self.Options.ParsedUrl()
 	path := u.Path
 	if u.Path == "" {
 		path = "/"
 	}
 	if self.extraUrl != "" {
-		return fmt.Sprintf("\"%s?\" + %s", path, self.extraUrl)
+		return literal.JavaScript(path+"?") + " + " + self.extraUrl
 	} else {
-		return fmt.Sprintf("\"%s\"", path)
+		return literal.JavaScript(path)
 	}
 }
 
 func (self NodeJsGenerator) indent() string {
-	hasIndent := self.Options.ProcessedData.ExternalFileCount() > 0
-	if hasIndent {
+	if len(self.ExternalFiles) > 0 {
 		return "    "
 	} else {
 		return ""
@@ -115,25 +103,28 @@ func (self NodeJsGenerator) indent() string {
 }
 
 func (self NodeJsGenerator) PrepareOptions() string {
+	return self.prepareOptions(self.indent(), self.specialHeaders)
+}
+
+func (self NodeJsGenerator) prepareOptions(indent string, specialHeaders []string) string {
 	var buffer bytes.Buffer
-	indent := self.indent()
-	if len(self.processedHeaders) != 0 || len(self.specialHeaders) != 0 {
+	if len(self.processedHeaders) != 0 || len(specialHeaders) != 0 {
 		fmt.Fprintf(&buffer, "\n%s    headers: {\n", indent)
 		for _, header := range self.processedHeaders {
 			if len(header.Values) == 1 {
-				fmt.Fprintf(&buffer, "%s        \"%s\": \"%s\",\n", indent, header.Key, header.Values[0])
+				fmt.Fprintf(&buffer, "%s        %s: %s,\n", indent, literal.JavaScript(header.Key), literal.JavaScript(header.Values[0]))
 			} else {
-				fmt.Fprintf(&buffer, "%s        \"%s\": [", indent, header.Key)
+				fmt.Fprintf(&buffer, "%s        %s: [", indent, literal.JavaScript(header.Key))
 				for i, value := range header.Values {
 					if i != 0 {
 						buffer.WriteString(", ")
 					}
-					fmt.Fprintf(&buffer, "\"%s\"", value)
+					buffer.WriteString(literal.JavaScript(value))
 				}
 				buffer.WriteString("],\n")
 			}
 		}
-		for _, header := range self.specialHeaders {
+		for _, header := range specialHeaders {
 			fmt.Fprintf(&buffer, "%s        %s,\n", indent, header)
 		}
 		fmt.Fprintf(&buffer, "%s    },", indent)
@@ -145,8 +136,185 @@ func (self NodeJsGenerator) PrepareOptions() string {
 	return buffer.String()
 }
 
+func (self NodeJsGenerator) ResponseHandlerName() string {
+	if self.Options.UseDigestAuth() {
+		return " onResponse"
+	}
+	return ""
+}
+
+/*
+	Send the request again with Digest authorization header when server returns a challenge.
+	Response callback is named as onResponse to receive the second response.
+*/
+func (self NodeJsGenerator) Authenticate() string {
+	if !self.Options.UseDigestAuth() {
+		return ""
+	}
+	indent := self.indent() + "    "
+	user, password := self.Options.UserAndPassword()
+	authorization := fmt.Sprintf("\"Authorization\": digestAuthorization(res.headers[\"www-authenticate\"], %s, %s, %s, %s)",
+		self.Method(), self.Path(), literal.JavaScript(user), literal.JavaScript(password))
+
+	var buffer bytes.Buffer
+	buffer.WriteString("if (res.statusCode == 401 && !digestRetried) {\n")
+	fmt.Fprintf(&buffer, "%s    digestRetried = true;\n", indent)
+	fmt.Fprintf(&buffer, "%s    res.resume();\n", indent)
+	fmt.Fprintf(&buffer, "%s    var retry = %s.request({\n", indent, self.ClientModule)
+	fmt.Fprintf(&buffer, "%s        host: %s,\n", indent, self.Host())
+	fmt.Fprintf(&buffer, "%s        path: %s,\n", indent, self.Path())
+	if self.Port() != 0 {
+		fmt.Fprintf(&buffer, "%s        port: %d,\n", indent, self.Port())
+	}
+	fmt.Fprintf(&buffer, "%s        method: %s,", indent, self.Method())
+	buffer.WriteString(self.prepareOptions(indent+"    ", append(self.specialHeaders[:len(self.specialHeaders):len(self.specialHeaders)], authorization)))
+	fmt.Fprintf(&buffer, "\n%s    }, onResponse);\n", indent)
+	for _, line := range self.BodyLines {
+		fmt.Fprintf(&buffer, "%s    retry.write(%s);\n", indent, line)
+	}
+	fmt.Fprintf(&buffer, "%s    retry.end();\n", indent)
+	fmt.Fprintf(&buffer, "%s    retry.on('error', function(e) {\n", indent)
+	fmt.Fprintf(&buffer, "%s        console.log(\"Got error: \" + e.message);\n", indent)
+	fmt.Fprintf(&buffer, "%s    });\n", indent)
+	fmt.Fprintf(&buffer, "%s    return;\n", indent)
+	fmt.Fprintf(&buffer, "%s}\n%s", indent, indent)
+	return buffer.String()
+}
+
 //--- Setter/Getter methods
 
+func (self *NodeJsGenerator) AddDigestCode() {
+	self.AdditionalDeclaration += `
+var digestRetried = false;
+
+function digestAuthorization(challenge, method, uri, username, password) {
+    var params = {};
+    challenge.replace(/(\w+)=(?:"([^"]*)"|([^,\s]*))/g, function (match, key, quoted, plain) {
+        params[key] = quoted !== undefined ? quoted : plain;
+    });
+    var md5Hex = function (text) {
+        return crypto.createHash("md5").update(text).digest("hex");
+    };
+    var ha1 = md5Hex([username, params.realm, password].join(":"));
+    var ha2 = md5Hex([method, uri].join(":"));
+    var authorization = 'Digest username="' + username + '", realm="' + params.realm + '", nonce="' + params.nonce + '", uri="' + uri + '"';
+    if (params.qop) {
+        var cnonce = crypto.randomBytes(8).toString("hex");
+        var response = md5Hex([ha1, params.nonce, "00000001", cnonce, "auth", ha2].join(":"));
+        authorization += ', qop=auth, nc=00000001, cnonce="' + cnonce + '", response="' + response + '"';
+    } else {
+        authorization += ', response="' + md5Hex([ha1, params.nonce, ha2].join(":")) + '"';
+    }
+    if (params.opaque) {
+        authorization += ', opaque="' + params.opaque + '"';
+    }
+    return authorization + ", algorithm=MD5";
+}
+`
+	self.Modules["crypto"] = true
+}
+
+func (self *NodeJsGenerator) AddCookieCode() {
+	var buffer bytes.Buffer
+	buffer.WriteString(`
+function loadCookies(fileName) {
+    var cookies = [];
+    if (!fs.existsSync(fileName)) {
+        return cookies;
+    }
+    fs.readFileSync(fileName, "utf8").split("\n").forEach(function (line) {
+        var httpOnly = line.indexOf("#HttpOnly_") == 0;
+        line = line.trim().replace(/^#HttpOnly_/, "");
+        var fields = line.split("\t");
+        if (line.charAt(0) == "#" || fields.length != 7) {
+            return;
+        }
+        cookies.push({domain: fields[0], includeSubdomains: fields[1] == "TRUE", path: fields[2], secure: fields[3] == "TRUE",
+            expires: parseInt(fields[4], 10) || 0, name: fields[5], value: fields[6], httpOnly: httpOnly});
+    });
+    return cookies;
+}
+
+function cookieHeader(cookies, secure, host, path) {
+    var now = Date.now() / 1000;
+    return cookies.filter(function (cookie) {
+        var domain = cookie.domain.replace(/^\./, "");
+        var domainMatched = host == domain || (cookie.includeSubdomains && host.slice(-domain.length - 1) == "." + domain);
+        return domainMatched && path.indexOf(cookie.path) == 0 && (secure || !cookie.secure) && (cookie.expires == 0 || cookie.expires > now);
+    }).map(function (cookie) {
+        return cookie.name + "=" + cookie.value;
+    }).join("; ");
+}
+`)
+	if self.Options.CookieJar != "" {
+		buffer.WriteString(`
+function storeCookies(cookies, setCookieHeaders, host) {
+    (setCookieHeaders || []).forEach(function (header) {
+        var attributes = header.split(";");
+        var pair = attributes.shift().split("=");
+        var cookie = {domain: host, includeSubdomains: false, path: "/", secure: false, expires: 0,
+            name: pair.shift().trim(), value: pair.join("=").trim(), httpOnly: false};
+        var maxAge;
+        attributes.forEach(function (attribute) {
+            var keyValue = attribute.split("=");
+            var key = keyValue.shift().trim().toLowerCase();
+            var value = keyValue.join("=").trim();
+            if (key == "domain") {
+                cookie.domain = "." + value.replace(/^\./, "");
+                cookie.includeSubdomains = true;
+            } else if (key == "path") {
+                cookie.path = value;
+            } else if (key == "secure") {
+                cookie.secure = true;
+            } else if (key == "httponly") {
+                cookie.httpOnly = true;
+            } else if (key == "expires") {
+                cookie.expires = Math.floor(Date.parse(value) / 1000);
+            } else if (key == "max-age") {
+                maxAge = parseInt(value, 10);
+            }
+        });
+        if (maxAge !== undefined) {
+            cookie.expires = Math.floor(Date.now() / 1000) + maxAge;
+        }
+        for (var i = cookies.length - 1; i >= 0; i--) {
+            if (cookies[i].name == cookie.name && cookies[i].domain == cookie.domain && cookies[i].path == cookie.path) {
+                cookies.splice(i, 1);
+            }
+        }
+        cookies.push(cookie);
+    });
+}
+
+function saveCookies(fileName, cookies) {
+    var now = Date.now() / 1000;
+    var lines = ["# Netscape HTTP Cookie File"];
+    cookies.forEach(function (cookie) {
+        if (cookie.expires != 0 && cookie.expires < now) {
+            return;
+        }
+        lines.push([(cookie.httpOnly ? "#HttpOnly_" : "") + cookie.domain, cookie.includeSubdomains ? "TRUE" : "FALSE", cookie.path,
+            cookie.secure ? "TRUE" : "FALSE", cookie.expires, cookie.name, cookie.value].join("\t"));
+    });
+    fs.writeFileSync(fileName, lines.join("\n") + "\n");
+}
+`)
+	}
+	var files []string
+	for _, fileName := range self.Options.CookieFiles() {
+		files = append(files, fmt.Sprintf("loadCookies(%s)", literal.JavaScript(fileName)))
+	}
+	switch len(files) {
+	case 0:
+		buffer.WriteString("\nvar cookies = [];\n")
+	case 1:
+		fmt.Fprintf(&buffer, "\nvar cookies = %s;\n", files[0])
+	default:
+		fmt.Fprintf(&buffer, "\nvar cookies = [].concat(%s);\n", strings.Join(files, ", "))
+	}
+	self.AdditionalDeclaration += buffer.String()
+}
+
 func (self *NodeJsGenerator) AddMultiPartCode() {
 	self.AdditionalDeclaration = `
 BOUNDARY = '----------ThIs_Is_tHe_bouNdaRY_$';
@@ -179,6 +347,23 @@ function encodeMultiPartFormData(fields, files) {
 	self.Options.InsertContentTypeHeader(fmt.Sprintf("multipart/form-data; boundary=%s", boundary))
 }
 
+/*
+	encodeURIComponent() doesn't encode !'()* and encodes spaces as %20. curl encodes them and sends spaces as "+".
+*/
+func (self *NodeJsGenerator) urlEncodeFunction() string {
+	if !self.useUrlEncode {
+		self.useUrlEncode = true
+		self.AdditionalDeclaration += `
+function urlEncode(text) {
+    return encodeURIComponent(text).replace(/[!'()*]/g, function (char) {
+        return '%' + char.charCodeAt(0).toString(16).toUpperCase();
+    }).replace(/%20/g, '+');
+}
+`
+	}
+	return "urlEncode"
+}
+
 func (self *NodeJsGenerator) FileContent() string {
 	if len(self.ExternalFiles) == 1 {
 		return "fileContent"
@@ -188,37 +373,43 @@ func (self *NodeJsGenerator) FileContent() string {
 	return fmt.Sprintf("fileContents[%d]", index)
 }
 
-func (self *NodeJsGenerator) SetDataForUrl() {
+func (self *NodeJsGenerator) SetDataForUrl() error {
 	if self.Options.CanUseSimpleForm() {
 		self.SetDataForForm(false)
-		self.extraUrl = strings.Join(self.BodyLines, "")
-		self.BodyLines = nil
-		self.HasBody = false
-	} else {
-		// Use bytes.Buffer to create URL option string
-		self.SetDataForBody()
-		self.extraUrl = strings.Join(self.BodyLines, "")
-		self.BodyLines = nil
-		self.HasBody = false
+	} else if err := self.SetDataForBody(); err != nil {
+		return err
 	}
+	self.extraUrl = strings.Join(self.BodyLines, " + ")
+	self.BodyLines = nil
+	self.HasBody = false
+	return nil
 }
 
-func (self *NodeJsGenerator) SetDataForBody() {
+func (self *NodeJsGenerator) SetDataForBody() error {
 	if len(self.Options.ProcessedData) == 1 {
-		self.BodyLines = append(self.BodyLines, NewStringForData(self, &self.Options.ProcessedData[0]))
+		line, err := NewStringForData(self, &self.Options.ProcessedData[0])
+		if err != nil {
+			return err
+		}
+		self.BodyLines = append(self.BodyLines, line)
 	} else {
 		for i, data := range self.Options.ProcessedData {
 			if i != 0 {
 				self.BodyLines = append(self.BodyLines, "\"&\"")
 			}
-			self.BodyLines = append(self.BodyLines, StringForData(self, &data))
+			line, err := StringForData(self, &data)
+			if err != nil {
+				return err
+			}
+			self.BodyLines = append(self.BodyLines, line)
 		}
 	}
 	self.HasBody = true
+	return nil
 }
 
 func (self *NodeJsGenerator) SetDataForForm(hasIndent bool) {
-	entries := make(map[string][]string)
+	keys, entries := self.Options.ProcessedData.FormValues()
 	var indent string
 	if hasIndent {
 		indent = "    "
@@ -226,30 +417,22 @@ func (self *NodeJsGenerator) SetDataForForm(hasIndent bool) {
 		indent = ""
 	}
 
-	for _, data := range self.Options.ProcessedData {
-		singleData, _ := url.ParseQuery(data.Value)
-		for key, values := range singleData {
-			entries[key] = append(entries[key], values[0])
-		}
-	}
-
 	var buffer bytes.Buffer
 	count := 0
-	for key, values := range entries {
+	for _, key := range keys {
+		values := entries[key]
 		if count == 0 {
 			buffer.WriteString("var query = querystring.stringify({\n")
-		} else {
-			fmt.Fprintf(&buffer, "%s, \"", indent)
 		}
 		if len(values) == 1 {
-			fmt.Fprintf(&buffer, "%s    \"%s\": \"%s\",\n", indent, escapeDQ(key), escapeDQ(values[0]))
+			fmt.Fprintf(&buffer, "%s    %s: %s,\n", indent, literal.JavaScript(key), literal.JavaScript(values[0]))
 		} else {
-			fmt.Fprintf(&buffer, "%s    \"%s\": [\n%s         ", indent, key, indent)
+			fmt.Fprintf(&buffer, "%s    %s: [\n%s         ", indent, literal.JavaScript(key), indent)
 			for i, value := range values {
 				if i != 0 {
 					buffer.WriteString(", ")
 				}
-				fmt.Fprintf(&buffer, "\"%s\"", escapeDQ(value))
+				buffer.WriteString(literal.JavaScript(value))
 			}
 			fmt.Fprintf(&buffer, "],\n%s    ", indent)
 		}
@@ -309,10 +492,20 @@ func (self *NodeJsGenerator) SetFormForBody() {
 	self.HasBody = true
 }
 
+func (self NodeJsGenerator) StoreCookies() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	return fmt.Sprintf("storeCookies(cookies, res.headers[\"set-cookie\"], %s);\n%s    ", self.Host(), self.indent())
+}
+
 func (self NodeJsGenerator) TearDown() string {
 	indent := self.indent()
 	var buffer bytes.Buffer
 	fmt.Fprintf(&buffer, "\n    %sres.on('end', function() {", indent)
+	if self.Options.CookieJar != "" {
+		fmt.Fprintf(&buffer, "\n        %ssaveCookies(%s, cookies);", indent, literal.JavaScript(self.Options.CookieJar))
+	}
 	fmt.Fprintf(&buffer, "\n        %sprocess.exit(0);", indent)
 	fmt.Fprintf(&buffer, "\n    %s});", indent)
 	return buffer.String()
@@ -322,7 +515,7 @@ func (self NodeJsGenerator) TearDown() string {
 	Dispatcher function of curl command
 	This is an exported function and called from httpgen.
 */
-func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}) {
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
 	generator := NewNodeJsGenerator(options)
 	if options.Http2Flag {
 		generator.Modules["http2"] = true
@@ -338,14 +531,18 @@ func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}) {
 
 	for _, data := range options.ProcessedData {
 		fileName := data.FileName()
+		if data.Type == common.DataUrlEncodeType {
+			// FileName() doesn't have the file of name@file
+			if _, content, isFile := data.UrlEncodeParts(); isFile {
+				fileName = content
+			}
+		}
 		if fileName != "" {
 			isText := data.Type == common.DataAsciiType
-			generator.ExternalFiles = append(generator.ExternalFiles, ExternalFile{FileName: fileName, TextType: isText})
+			generator.ExternalFiles = append(generator.ExternalFiles, ExternalFile{FileName: literal.JavaScript(fileName), TextType: isText})
 		}
 	}
 
-	generator.processedHeaders = options.GroupedHeaders()
-
 	var templateName string
 	switch len(generator.ExternalFiles) {
 	case 0:
@@ -356,82 +553,118 @@ func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}) {
 		templateName = "external_files"
 	}
 
-	if generator.Options.User != "" {
-		generator.specialHeaders = append(generator.specialHeaders, fmt.Sprintf("\"Authorization\": \"Basic \" + new Buffer(\"%s\").toString(\"base64\")", generator.Options.User))
+	if generator.Options.UseBasicAuth() {
+		generator.specialHeaders = append(generator.specialHeaders, fmt.Sprintf("\"Authorization\": \"Basic \" + new Buffer(%s).toString(\"base64\")", literal.JavaScript(generator.Options.User)))
+	}
+	if options.UseCookieJar() {
+		cookieHeader := fmt.Sprintf("cookieHeader(cookies, %t, %s, %s)", strings.HasPrefix(options.Url, "https"), generator.Host(), generator.Path())
+		if len(options.Cookies()) != 0 {
+			cookieHeader = fmt.Sprintf("[%s, %s].filter(Boolean).join(\"; \")", literal.JavaScript(options.CookieString()), cookieHeader)
+		}
+		generator.specialHeaders = append(generator.specialHeaders, "\"Cookie\": "+cookieHeader)
+	} else if len(options.Cookies()) != 0 {
+		generator.specialHeaders = append(generator.specialHeaders, "\"Cookie\": "+literal.JavaScript(options.CookieString()))
 	}
 
 	if options.ProcessedData.HasData() {
 		if options.Get {
-			generator.SetDataForUrl()
+			if err := generator.SetDataForUrl(); err != nil {
+				return "", nil, err
+			}
 		} else {
-			generator.Options.InsertContentTypeHeader("application/x-www-form-urlencoded")
-			generator.SetDataForBody()
+			generator.Options.InsertDataContentTypeHeader()
+			if err := generator.SetDataForBody(); err != nil {
+				return "", nil, err
+			}
 		}
 	} else if options.ProcessedData.HasForm() {
 		generator.SetFormForBody()
-	} else if options.Method() == "GET" && len(generator.processedHeaders) == 0 && len(generator.specialHeaders) == 0 {
-		if templateName == "full" && !options.Insecure {
+	}
+
+	// after Content-Type of the data is inserted
+	generator.processedHeaders = options.GroupedHeaders()
+	if !options.ProcessedData.HasAnyData() && options.Method() == "GET" && len(generator.processedHeaders) == 0 && len(generator.specialHeaders) == 0 {
+		if templateName == "full" && !options.Insecure && !options.UseDigestAuth() && !options.UseCookieJar() {
 			templateName = "simple_get"
 		}
 	}
 
-	return templateName, *generator
+	if options.UseDigestAuth() {
+		generator.AddDigestCode()
+	}
+	if options.UseCookieJar() {
+		if templateName == "full" {
+			// external file templates always load fs module
+			generator.Modules["fs"] = true
+		}
+		generator.AddCookieCode()
+	}
+
+	return templateName, *generator, nil
 }
 
 // helper functions
 
-func NewStringForData(generator *NodeJsGenerator, data *common.DataOption) string {
+func NewStringForData(generator *NodeJsGenerator, data *common.DataOption) (string, error) {
 	var result string
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = fmt.Sprintf("%s.replace('\\n', '')", generator.FileContent())
 		} else {
-			result = fmt.Sprintf("\"%s\"", escapeDQ(strings.Replace(data.Value, "\n", "", -1)))
+			result = literal.JavaScript(strings.Replace(data.Value, "\n", "", -1))
 		}
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = generator.FileContent()
 		} else {
-			result = fmt.Sprintf("\"%s\"", escapeDQ(data.Value))
+			result = literal.JavaScript(data.Value)
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf("encodeURIComponent(%s)", generator.FileContent())
-		} else {
-			result = fmt.Sprintf("encodeURIComponent(\"%s\")", escapeDQ(data.Value))
-		}
+		result = urlEncode(generator, data)
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return "", common.UnexpectedDataError("node", data)
 	}
-	return result
+	return result, nil
 }
 
-func StringForData(generator *NodeJsGenerator, data *common.DataOption) string {
+func StringForData(generator *NodeJsGenerator, data *common.DataOption) (string, error) {
 	var result string
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = fmt.Sprintf("%s.replace('\\n', '')", generator.FileContent())
 		} else {
-			result = fmt.Sprintf("\"%s\"", escapeDQ(strings.Replace(data.Value, "\n", "", -1)))
+			result = literal.JavaScript(strings.Replace(data.Value, "\n", "", -1))
 		}
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = fmt.Sprintf("%s", generator.FileContent())
 		} else {
-			result = fmt.Sprintf("\"%s\"", escapeDQ(data.Value))
+			result = literal.JavaScript(data.Value)
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf("encodeURIComponent(%s)", generator.FileContent())
-		} else {
-			result = fmt.Sprintf("encodeURIComponent(\"%s\")", escapeDQ(data.Value))
-		}
+		result = urlEncode(generator, data)
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return "", common.UnexpectedDataError("node", data)
 	}
-	return result
+	return result, nil
+}
+
+/*
+	Value of --data-urlencode. curl doesn't encode the name.
+*/
+func urlEncode(generator *NodeJsGenerator, data *common.DataOption) string {
+	name, content, isFile := data.UrlEncodeParts()
+	if isFile {
+		content = generator.FileContent()
+	} else {
+		content = literal.JavaScript(content)
+	}
+	if name != "" {
+		return fmt.Sprintf("%s + %s(%s)", literal.JavaScript(name+"="), generator.urlEncodeFunction(), content)
+	}
+	return fmt.Sprintf("%s(%s)", generator.urlEncodeFunction(), content)
 }
 
 func FormString(generator *NodeJsGenerator, data *common.DataOption) string {
@@ -439,15 +672,11 @@ func FormString(generator *NodeJsGenerator, data *common.DataOption) string {
 	switch data.Type {
 	case common.FormType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
 		if strings.HasPrefix(field[1], "@") {
 			var buffer bytes.Buffer
 			fragments := strings.Split(field[1][1:], ";")
 
-			contentType := "application/octet-stream"
+			contentType := common.FormFileContentType(fragments[0])
 			sentFileName := fragments[0]
 			for _, fragment := range fragments[1:] {
 				if strings.HasPrefix(fragment, "filename=") {
@@ -458,8 +687,8 @@ func FormString(generator *NodeJsGenerator, data *common.DataOption) string {
 			}
 			// sent file name
 			// field name, source file name
-			fmt.Fprintf(&buffer, "    {key: \"%s\", filename: \"%s\", content: %s, contentType: \"%s\"},\n", field[0],
-				sentFileName, generator.FileContent(), contentType)
+			fmt.Fprintf(&buffer, "    {key: %s, filename: %s, content: %s, contentType: %s},\n", literal.JavaScript(field[0]),
+				literal.JavaScript(sentFileName), generator.FileContent(), literal.JavaScript(contentType))
 			result = buffer.String()
 		} else if strings.HasPrefix(field[1], "<") {
 			var buffer bytes.Buffer
@@ -467,7 +696,7 @@ func FormString(generator *NodeJsGenerator, data *common.DataOption) string {
 			fragments := strings.Split(field[1][1:], ";")
 
 			// field name, content
-			fmt.Fprintf(&buffer, "    {key: \"%s\", value: %s", field[0], generator.FileContent())
+			fmt.Fprintf(&buffer, "    {key: %s, value: %s", literal.JavaScript(field[0]), generator.FileContent())
 
 			var contentType string
 			for _, fragment := range fragments[1:] {
@@ -477,21 +706,17 @@ func FormString(generator *NodeJsGenerator, data *common.DataOption) string {
 				}
 			}
 			if contentType != "" {
-				fmt.Fprintf(&buffer, ", contentType: \"%s\"},\n", contentType)
+				fmt.Fprintf(&buffer, ", contentType: %s},\n", literal.JavaScript(contentType))
 			} else {
 				buffer.WriteString("},\n")
 			}
 			result = buffer.String()
 		} else {
-			result = fmt.Sprintf("    {key: \"%s\", value: \"%s\"},\n", field[0], field[1])
+			result = fmt.Sprintf("    {key: %s, value: %s},\n", literal.JavaScript(field[0]), literal.JavaScript(field[1]))
 		}
 	case common.FormStringType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
-		result = fmt.Sprintf("    {key: \"%s\", value: \"%s\"},\n", field[0], field[1])
+		result = fmt.Sprintf("    {key: %s, value: %s},\n", literal.JavaScript(field[0]), literal.JavaScript(field[1]))
 	}
 	return result
 }
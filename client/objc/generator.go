@@ -4,9 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/shibukawa/curl_as_dsl/common"
-	"log"
-	"net/url"
-	"os"
+	"github.com/shibukawa/curl_as_dsl/literal"
 	"strings"
 )
 
@@ -16,7 +14,6 @@ type ObjCGenerator struct {
 	Url                   string
 	IsHttps               bool
 	HasBody               bool
-	Body                  string
 	PrepareBody           string
 	AdditionalDeclaration string
 	specialHeaders        [][]string
@@ -26,7 +23,7 @@ type ObjCGenerator struct {
 
 func NewObjCGenerator(options *common.CurlOptions) *ObjCGenerator {
 	result := &ObjCGenerator{Options: options}
-	result.Url = fmt.Sprintf(`@"%s"`, options.Url)
+	result.Url = literal.ObjectiveC(options.Url)
 	result.Modules = make(map[string]bool)
 	result.Modules["Foundation/Foundation.h"] = true
 
@@ -35,23 +32,13 @@ func NewObjCGenerator(options *common.CurlOptions) *ObjCGenerator {
 
 //--- Getter methods called from template
 
-func (self ObjCGenerator) Proxy() string {
-	if self.Options.Proxy == "" {
-		return ""
-	}
-	u, err := url.Parse(self.Options.Proxy)
-	if err != nil {
-		log.Fatal(err)
-	}
-	hostFragments := strings.Split(u.Host, ":")
-	host := hostFragments[0]
-	var port string
-	if len(hostFragments) == 1 {
-		port = "80"
-	} else {
-		port = hostFragments[1]
-	}
-	return fmt.Sprintf(`new Proxy(Proxy.Type.%s, new InetSocketAddress("%s", %s))`, strings.ToUpper(u.Scheme), host, port)
+/*
+	Foundation URL loading system handles Digest challenge-response by itself.
+	Generated code passes credential from delegate method.
+*/
+func (self ObjCGenerator) Credential() string {
+	user, password := self.Options.UserAndPassword()
+	return fmt.Sprintf(`[NSURLCredential credentialWithUser:%s password:%s persistence:NSURLCredentialPersistenceForSession]`, literal.ObjectiveC(user), literal.ObjectiveC(password))
 }
 
 func (self ObjCGenerator) CommonInitialize() string {
@@ -77,16 +64,16 @@ func (self ObjCGenerator) ModifyRequest() string {
 	method := self.Options.Method()
 	if method != "GET" {
 		indent()
-		buffer.WriteString(fmt.Sprintf("[request setHTTPMethod:@\"%s\"];\n", method))
+		buffer.WriteString(fmt.Sprintf("[request setHTTPMethod:%s];\n", literal.ObjectiveC(method)))
 	}
 	for _, headerStr := range self.Options.Header {
 		indent()
 		header := strings.Split(headerStr, ":")
-		buffer.WriteString(fmt.Sprintf("[request setValue:@\"%s\" forHTTPHeaderField:@\"%s\"];\n", strings.TrimSpace(header[1]), strings.TrimSpace(header[0])))
+		buffer.WriteString(fmt.Sprintf("[request setValue:%s forHTTPHeaderField:%s];\n", literal.ObjectiveC(strings.TrimSpace(header[1])), literal.ObjectiveC(strings.TrimSpace(header[0]))))
 	}
 	for _, header := range self.specialHeaders {
 		indent()
-		buffer.WriteString(fmt.Sprintf("[request setValue:%s forHTTPHeaderField:@\"%s\"];\n", header[1], header[0]))
+		buffer.WriteString(fmt.Sprintf("[request setValue:%s forHTTPHeaderField:%s];\n", header[1], literal.ObjectiveC(header[0])))
 	}
 	if self.HasBody {
 		indent()
@@ -98,6 +85,13 @@ func (self ObjCGenerator) ModifyRequest() string {
 	return buffer.String()
 }
 
+func (self ObjCGenerator) SaveCookies() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n        saveCookies(cookieStorage, %s);", literal.ObjectiveC(self.Options.CookieJar))
+}
+
 //--- Preparing ObjC source code methods
 
 func (self *ObjCGenerator) AppendCommonInitialize(newLine string, check bool) {
@@ -116,6 +110,71 @@ func (self *ObjCGenerator) AppendCommonInitialize(newLine string, check bool) {
 	self.commonInitialize = append(self.commonInitialize, newLine)
 }
 
+/*
+	URL loading system sends and stores cookies via shared cookie storage.
+	Generated code fills it from Netscape format files and dumps it into cookie jar file.
+*/
+func (self *ObjCGenerator) AddCookieCode() {
+	self.AppendCommonInitialize("NSHTTPCookieStorage *cookieStorage = [NSHTTPCookieStorage sharedHTTPCookieStorage];", true)
+	self.AppendCommonInitialize("[cookieStorage setCookieAcceptPolicy:NSHTTPCookieAcceptPolicyAlways];", true)
+	for _, fileName := range self.Options.CookieFiles() {
+		self.AppendCommonInitialize(fmt.Sprintf("loadCookies(cookieStorage, %s);", literal.ObjectiveC(fileName)), true)
+	}
+	for _, cookie := range self.Options.Cookies() {
+		self.AppendCommonInitialize(fmt.Sprintf("[cookieStorage setCookie:[NSHTTPCookie cookieWithProperties:@{NSHTTPCookieOriginURL: [NSURL URLWithString:%s], NSHTTPCookiePath: @\"/\", NSHTTPCookieName: %s, NSHTTPCookieValue: %s}]];", literal.ObjectiveC(self.Options.Url), literal.ObjectiveC(cookie[0]), literal.ObjectiveC(cookie[1])), false)
+	}
+	self.AdditionalDeclaration += `
+void loadCookies(NSHTTPCookieStorage* storage, NSString* fileName)
+{
+    NSString* contents = [NSString stringWithContentsOfFile:fileName encoding:NSUTF8StringEncoding error:nil];
+    for (NSString* rawLine in [contents componentsSeparatedByCharactersInSet:[NSCharacterSet newlineCharacterSet]]) {
+        NSString* line = rawLine;
+        BOOL httpOnly = [line hasPrefix:@"#HttpOnly_"];
+        if (httpOnly) {
+            line = [line substringFromIndex:[@"#HttpOnly_" length]];
+        }
+        NSArray* fields = [line componentsSeparatedByString:@"\t"];
+        if ([line hasPrefix:@"#"] || [fields count] != 7) {
+            continue;
+        }
+        NSMutableDictionary* properties = [NSMutableDictionary dictionary];
+        properties[NSHTTPCookieDomain] = fields[0];
+        properties[NSHTTPCookiePath] = fields[2];
+        properties[NSHTTPCookieName] = fields[5];
+        properties[NSHTTPCookieValue] = fields[6];
+        if ([fields[3] isEqualToString:@"TRUE"]) {
+            properties[NSHTTPCookieSecure] = @"TRUE";
+        }
+        if ([fields[4] longLongValue] != 0) {
+            properties[NSHTTPCookieExpires] = [NSDate dateWithTimeIntervalSince1970:[fields[4] doubleValue]];
+        }
+        if (httpOnly) {
+            properties[@"HttpOnly"] = @"TRUE";
+        }
+        NSHTTPCookie* cookie = [NSHTTPCookie cookieWithProperties:properties];
+        if (cookie) {
+            [storage setCookie:cookie];
+        }
+    }
+}
+`
+	if self.Options.CookieJar == "" {
+		return
+	}
+	self.AdditionalDeclaration += `
+void saveCookies(NSHTTPCookieStorage* storage, NSString* fileName)
+{
+    NSMutableString* contents = [@"# Netscape HTTP Cookie File\n" mutableCopy];
+    for (NSHTTPCookie* cookie in [storage cookies]) {
+        [contents appendFormat:@"%@%@\t%@\t%@\t%@\t%.0f\t%@\t%@\n", cookie.isHTTPOnly ? @"#HttpOnly_" : @"", cookie.domain,
+            [cookie.domain hasPrefix:@"."] ? @"TRUE" : @"FALSE", cookie.path, cookie.isSecure ? @"TRUE" : @"FALSE",
+            cookie.expiresDate ? [cookie.expiresDate timeIntervalSince1970] : 0.0, cookie.name, cookie.value];
+    }
+    [contents writeToFile:fileName atomically:YES encoding:NSUTF8StringEncoding error:nil];
+}
+`
+}
+
 func (self *ObjCGenerator) AddMultiPartCode() {
 	/*
 		Thank you for following questions and answers!
@@ -183,13 +242,13 @@ NSString* getMimeTypeFromPath(NSString* path)
 	self.specialHeaders = append(self.specialHeaders, []string{"Content-type", `[NSString stringWithFormat: @"multipart/form-data; boundary=%@", boundary]`})
 }
 
-func (self *ObjCGenerator) SetDataForUrl() {
+func (self *ObjCGenerator) SetDataForUrl() error {
 	var buffer bytes.Buffer
 	indent := func() {
 		buffer.WriteString("        ")
 	}
 	if self.Options.CanUseSimpleForm() {
-		fmt.Fprintf(&buffer, "NSMutableString* url = [@\"%s\" mutableCopy];\n", self.Options.Url)
+		fmt.Fprintf(&buffer, "NSMutableString* url = [%s mutableCopy];\n", literal.ObjectiveC(self.Options.Url))
 		indent()
 		buffer.WriteString("[url appendString:@\"?\"];\n")
 		indent()
@@ -198,25 +257,29 @@ func (self *ObjCGenerator) SetDataForUrl() {
 				indent()
 				buffer.WriteString("[url appendString:@\"&\"];\n")
 			}
-			singleData, _ := url.ParseQuery(data.Value)
-			for key, values := range singleData {
-				fmt.Fprintf(&buffer, "[url appendFormat:@\"%%@=%%@\", @\"%s\", @\"%s\"];\n", key, values[0])
+			keys, singleData := data.FormValues()
+			for _, key := range keys {
+				values := singleData[key]
+				fmt.Fprintf(&buffer, "[url appendFormat:@\"%%@=%%@\", %s, %s];\n", literal.ObjectiveC(key), literal.ObjectiveC(values[0]))
 				indent()
 			}
 		}
 		self.PrepareBody = buffer.String()
 	} else {
 		if len(self.Options.ProcessedData) == 1 {
-			prepareLines, forWriter := NewStringForData(self, &self.Options.ProcessedData[0])
+			prepareLines, forWriter, err := NewStringForData(self, &self.Options.ProcessedData[0])
+			if err != nil {
+				return err
+			}
 			for _, line := range prepareLines {
 				buffer.WriteString(line)
 				buffer.WriteByte('\n')
 				indent()
 			}
-			fmt.Fprintf(&buffer, "NSString* url = [[NSString alloc] initWithFormat:@\"%%@?%%@\", @\"%s\", %s];\n", self.Options.Url, forWriter)
+			fmt.Fprintf(&buffer, "NSString* url = [[NSString alloc] initWithFormat:@\"%%@?%%@\", %s, %s];\n", literal.ObjectiveC(self.Options.Url), forWriter)
 			indent()
 		} else {
-			fmt.Fprintf(&buffer, "NSMutableString* query = [@\"%s\" mutableCopy];\n", self.Options.Url)
+			fmt.Fprintf(&buffer, "NSMutableString* query = [%s mutableCopy];\n", literal.ObjectiveC(self.Options.Url))
 			indent()
 			buffer.WriteString("[url appendString:@\"?\"];\n")
 			indent()
@@ -226,7 +289,10 @@ func (self *ObjCGenerator) SetDataForUrl() {
 					buffer.WriteString("[url appendString:@\"&\"];\n")
 				}
 				indent()
-				prepareLines, forWriter := StringForData(self, &data)
+				prepareLines, forWriter, err := StringForData(self, &data)
+				if err != nil {
+					return err
+				}
 				for _, line := range prepareLines {
 					buffer.WriteString(line)
 					buffer.WriteByte('\n')
@@ -241,15 +307,19 @@ func (self *ObjCGenerator) SetDataForUrl() {
 	}
 	self.Url = "url"
 	self.PrepareBody = buffer.String()
+	return nil
 }
 
-func (self *ObjCGenerator) SetDataForBody() {
+func (self *ObjCGenerator) SetDataForBody() error {
 	var buffer bytes.Buffer
 	indent := func() {
 		buffer.WriteString("        ")
 	}
 	if len(self.Options.ProcessedData) == 1 {
-		prepareLines, forWriter := NewBinaryForData(self, &self.Options.ProcessedData[0])
+		prepareLines, forWriter, err := NewBinaryForData(self, &self.Options.ProcessedData[0])
+		if err != nil {
+			return err
+		}
 		for _, line := range prepareLines {
 			buffer.WriteString(line)
 			buffer.WriteByte('\n')
@@ -266,7 +336,10 @@ func (self *ObjCGenerator) SetDataForBody() {
 				indent()
 				buffer.WriteString("[content appendBytes:\"&\" length:1];\n")
 			}
-			prepareLines, forWriter := BinaryForData(self, &data)
+			prepareLines, forWriter, err := BinaryForData(self, &data)
+			if err != nil {
+				return err
+			}
 			for _, line := range prepareLines {
 				indent()
 				buffer.WriteString(line)
@@ -281,31 +354,7 @@ func (self *ObjCGenerator) SetDataForBody() {
 	}
 	self.PrepareBody = buffer.String()
 	self.HasBody = true
-}
-
-func (self *ObjCGenerator) SetDataForForm() {
-	var buffer bytes.Buffer
-	indent := func() {
-		buffer.WriteString("            ")
-	}
-
-	buffer.WriteString("StringWriter writer = new StringWriter();\n")
-	for i, data := range self.Options.ProcessedData {
-		if i != 0 {
-			indent()
-			buffer.WriteString("writer.write('&');\n")
-		}
-		singleData, _ := url.ParseQuery(data.Value)
-		for key, values := range singleData {
-			fmt.Fprintf(&buffer, "writer.write(\"%s\");\n", key)
-			buffer.WriteString("writer.write('=');\n")
-			fmt.Fprintf(&buffer, "writer.write(\"%s\");\n", values[0])
-		}
-	}
-
-	self.PrepareBody = buffer.String()
-	self.HasBody = true
-	self.Body = "values"
+	return nil
 }
 
 func (self *ObjCGenerator) SetFormForBody() {
@@ -367,141 +416,164 @@ func (self *ObjCGenerator) SetFormForBody() {
 	Dispatcher function of curl command
 	This is an exported function and called from common.
 */
-func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}) {
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
 	generator := NewObjCGenerator(options)
 
 	if options.ProcessedData.HasData() {
 		if options.Get {
-			generator.SetDataForUrl()
+			if err := generator.SetDataForUrl(); err != nil {
+				return "", nil, err
+			}
 		} else {
-			generator.Options.InsertContentTypeHeader("application/x-www-form-urlencoded")
-			generator.SetDataForBody()
+			generator.Options.InsertDataContentTypeHeader()
+			if err := generator.SetDataForBody(); err != nil {
+				return "", nil, err
+			}
 		}
 	} else if options.ProcessedData.HasForm() {
 		generator.SetFormForBody()
 	}
-	if generator.Options.User != "" {
+	if generator.Options.UseBasicAuth() {
 		generator.specialHeaders = append(generator.specialHeaders,
 			[]string{
 				"Authorization",
-				fmt.Sprintf(`[NSString stringWithFormat:@"Basic %%@", [[@"%s"dataUsingEncoding:NSUTF8StringEncoding] base64EncodedStringWithOptions:NSDataBase64EncodingEndLineWithLineFeed]]`, generator.Options.User)})
+				fmt.Sprintf(`[NSString stringWithFormat:@"Basic %%@", [[%s dataUsingEncoding:NSUTF8StringEncoding] base64EncodedStringWithOptions:NSDataBase64EncodingEndLineWithLineFeed]]`, literal.ObjectiveC(generator.Options.User))})
+	}
+	if options.UseCookieJar() {
+		generator.AddCookieCode()
+	} else if len(options.Cookies()) != 0 {
+		generator.specialHeaders = append(generator.specialHeaders, []string{"Cookie", literal.ObjectiveC(options.CookieString())})
 	}
 	if generator.HasBody {
 	}
 
-	return "full", *generator
+	return "full", *generator, nil
 }
 
 // helper functions
 
-func NewBinaryForData(generator *ObjCGenerator, data *common.DataOption) ([]string, string) {
+func NewBinaryForData(generator *ObjCGenerator, data *common.DataOption) ([]string, string, error) {
 	var result []string
 	var resultForWriter string
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = append(result,
 				`NSMutableData* content = [NSMutableData data];`,
-				fmt.Sprintf(`NSString *fileContents = [NSString stringWithContentsOfFile:@"%s" encoding:NSUTF8StringEncoding error:NULL];`, data.Value[1:]),
+				fmt.Sprintf(`NSString *fileContents = [NSString stringWithContentsOfFile:%s encoding:NSUTF8StringEncoding error:NULL];`, literal.ObjectiveC(data.Value[1:])),
 				`for (NSString *line in [fileContents componentsSeparatedByCharactersInSet:[NSCharacterSet newlineCharacterSet]]) {`,
 				`    [content appendData:[line dataUsingEncoding:NSUTF8StringEncoding]];`,
 				`}`)
 		} else {
-			resultForWriter = fmt.Sprintf(`[@"%s" dataUsingEncoding:NSUTF8StringEncoding]`, strings.Replace(data.Value, "\n", "", -1))
+			resultForWriter = fmt.Sprintf(`[%s dataUsingEncoding:NSUTF8StringEncoding]`, literal.ObjectiveC(strings.Replace(data.Value, "\n", "", -1)))
 		}
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
-			resultForWriter = fmt.Sprintf(`[NSData dataWithContentsOfFile:@"%s"]`, data.Value[1:])
+		if data.UseExternalFile() {
+			resultForWriter = fmt.Sprintf(`[NSData dataWithContentsOfFile:%s]`, literal.ObjectiveC(data.Value[1:]))
 		} else {
-			resultForWriter = fmt.Sprintf(`@"%s"`, data.Value)
+			resultForWriter = literal.ObjectiveC(data.Value)
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = append(result,
 				`NSError *error = nil;`,
-				fmt.Sprintf(`NSString *source = [NSString stringWithContentsOfFile:@"%s" encoding: NSUTF8StringEncoding error:&error];`, data.Value[1:]),
+				fmt.Sprintf(`NSString *source = [NSString stringWithContentsOfFile:%s encoding: NSUTF8StringEncoding error:&error];`, literal.ObjectiveC(data.Value[1:])),
 				`NSString *encodedSource = [source stringByAddingPercentEncodingWithAllowedCharacters:[NSCharacterSet URLQueryAllowedCharacterSet]];`)
 			resultForWriter = "[encodedSource dataUsingEncoding:NSUTF8StringEncoding]"
 		} else {
-			resultForWriter = fmt.Sprintf(`[[@"%s" stringByAddingPercentEncodingWithAllowedCharacters:[NSCharacterSet URLQueryAllowedCharacterSet]] dataUsingEncoding:NSUTF8StringEncoding]`, data.Value)
+			resultForWriter = fmt.Sprintf(`[%s dataUsingEncoding:NSUTF8StringEncoding]`, UrlEncodedString(data))
 		}
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return nil, "", common.UnexpectedDataError("objc", data)
 	}
-	return result, resultForWriter
+	return result, resultForWriter, nil
 }
 
-func BinaryForData(generator *ObjCGenerator, data *common.DataOption) ([]string, string) {
+func BinaryForData(generator *ObjCGenerator, data *common.DataOption) ([]string, string, error) {
 	var result []string
 	var resultForWriter string
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = append(result,
 				"{",
-				fmt.Sprintf(`    NSString *fileContents = [NSString stringWithContentsOfFile:@"%s" encoding:NSUTF8StringEncoding error:NULL];`, data.Value[1:]),
+				fmt.Sprintf(`    NSString *fileContents = [NSString stringWithContentsOfFile:%s encoding:NSUTF8StringEncoding error:NULL];`, literal.ObjectiveC(data.Value[1:])),
 				`    for (NSString *line in [fileContents componentsSeparatedByCharactersInSet:[NSCharacterSet newlineCharacterSet]]) {`,
 				`        [content appendData:[line dataUsingEncoding:NSUTF8StringEncoding]];`,
 				`    }`,
 				`}`)
 		} else {
-			resultForWriter = fmt.Sprintf(`[@"%s" dataUsingEncoding:NSUTF8StringEncoding]`, strings.Replace(data.Value, "\n", "", -1))
+			resultForWriter = fmt.Sprintf(`[%s dataUsingEncoding:NSUTF8StringEncoding]`, literal.ObjectiveC(strings.Replace(data.Value, "\n", "", -1)))
 		}
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = append(result, fmt.Sprintf(`[content appendData:[NSData dataWithContentsOfFile:@"%s"]];`, data.Value[1:]))
+		if data.UseExternalFile() {
+			result = append(result, fmt.Sprintf(`[content appendData:[NSData dataWithContentsOfFile:%s]];`, literal.ObjectiveC(data.Value[1:])))
 		} else {
-			resultForWriter = fmt.Sprintf(`[@"%s" dataUsingEncoding:NSUTF8StringEncoding]`, data.Value)
+			resultForWriter = fmt.Sprintf(`[%s dataUsingEncoding:NSUTF8StringEncoding]`, literal.ObjectiveC(data.Value))
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = append(result,
 				"{",
 				`    NSError *error = nil;`,
-				fmt.Sprintf(`    NSString *source = [NSString stringWithContentsOfFile:@"%s" encoding: NSUTF8StringEncoding error:&error];`, data.Value[1:]),
+				fmt.Sprintf(`    NSString *source = [NSString stringWithContentsOfFile:%s encoding: NSUTF8StringEncoding error:&error];`, literal.ObjectiveC(data.Value[1:])),
 				`    NSString *encodedSource = [source stringByAddingPercentEncodingWithAllowedCharacters:[NSCharacterSet URLQueryAllowedCharacterSet]];`,
 				`    [content appendData:[encodedSource dataUsingEncoding:NSUTF8StringEncoding]];`,
 				"}")
 		} else {
-			resultForWriter = fmt.Sprintf("URLEncoder.encode(\"%s\", \"UTF-8\")", data.Value)
+			resultForWriter = fmt.Sprintf(`[%s dataUsingEncoding:NSUTF8StringEncoding]`, UrlEncodedString(data))
 		}
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return nil, "", common.UnexpectedDataError("objc", data)
 	}
-	return result, resultForWriter
+	return result, resultForWriter, nil
 }
 
-func NewStringForData(generator *ObjCGenerator, data *common.DataOption) ([]string, string) {
+func NewStringForData(generator *ObjCGenerator, data *common.DataOption) ([]string, string, error) {
 	var result []string
 	var resultForWriter string
 	switch data.Type {
 	case common.DataAsciiType:
-		resultForWriter = fmt.Sprintf(`@"%s"`, strings.Replace(data.Value, "\n", "", -1))
+		resultForWriter = literal.ObjectiveC(strings.Replace(data.Value, "\n", "", -1))
 	case common.DataBinaryType:
-		resultForWriter = fmt.Sprintf(`@"%s"`, data.Value)
+		resultForWriter = literal.ObjectiveC(data.Value)
 	case common.DataUrlEncodeType:
-		resultForWriter = fmt.Sprintf(`[[@"%s"
-		stringByAddingPercentEncodingWithAllowedCharacters:[NSCharacterSet URLQueryAllowedCharacterSet]] dataUsingEncoding:NSUTF8StringEncoding]`, data.Value)
+		resultForWriter = UrlEncodedString(data)
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return nil, "", common.UnexpectedDataError("objc", data)
 	}
-	return result, resultForWriter
+	return result, resultForWriter, nil
 }
 
-func StringForData(generator *ObjCGenerator, data *common.DataOption) ([]string, string) {
+func StringForData(generator *ObjCGenerator, data *common.DataOption) ([]string, string, error) {
 	var result []string
 	var resultForWriter string
 	switch data.Type {
 	case common.DataAsciiType:
-		resultForWriter = fmt.Sprintf(`[@"%s" dataUsingEncoding:NSUTF8StringEncoding]`, strings.Replace(data.Value, "\n", "", -1))
+		resultForWriter = fmt.Sprintf(`[%s dataUsingEncoding:NSUTF8StringEncoding]`, literal.ObjectiveC(strings.Replace(data.Value, "\n", "", -1)))
 	case common.DataBinaryType:
-		resultForWriter = fmt.Sprintf(`[@"%s" dataUsingEncoding:NSUTF8StringEncoding]`, data.Value)
+		resultForWriter = fmt.Sprintf(`[%s dataUsingEncoding:NSUTF8StringEncoding]`, literal.ObjectiveC(data.Value))
 	case common.DataUrlEncodeType:
-		resultForWriter = fmt.Sprintf("URLEncoder.encode(\"%s\", \"UTF-8\")", data.Value)
+		resultForWriter = UrlEncodedString(data)
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return nil, "", common.UnexpectedDataError("objc", data)
 	}
-	return result, resultForWriter
+	return result, resultForWriter, nil
+}
+
+/*
+	NSString expression of --data-urlencode value. Only content is encoded like curl.
+*/
+func UrlEncodedString(data *common.DataOption) string {
+	name, content, _ := data.UrlEncodeParts()
+	if content == "" {
+		return literal.ObjectiveC(data.Value)
+	}
+	encoded := fmt.Sprintf(`[%s stringByAddingPercentEncodingWithAllowedCharacters:[NSCharacterSet URLQueryAllowedCharacterSet]]`, literal.ObjectiveC(content))
+	if name == "" {
+		return encoded
+	}
+	return fmt.Sprintf(`[%s stringByAppendingString:%s]`, literal.ObjectiveC(name+"="), encoded)
 }
 
 func FormString(generator *ObjCGenerator, data *common.DataOption) string {
@@ -509,16 +581,12 @@ func FormString(generator *ObjCGenerator, data *common.DataOption) string {
 	switch data.Type {
 	case common.FormType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
 		if strings.HasPrefix(field[1], "@") {
 			var buffer bytes.Buffer
 			fragments := strings.Split(field[1][1:], ";")
 
 			// field name, source file name
-			fmt.Fprintf(&buffer, "    [NSArray arrayWithObjects:@\"%s\", @\"%s\", ", field[0], fragments[0])
+			fmt.Fprintf(&buffer, "    [NSArray arrayWithObjects:%s, %s, ", literal.ObjectiveC(field[0]), literal.ObjectiveC(fragments[0]))
 
 			var contentType string
 			sentFileName := fragments[0]
@@ -530,13 +598,13 @@ func FormString(generator *ObjCGenerator, data *common.DataOption) string {
 				}
 			}
 			// sent file name
-			fmt.Fprintf(&buffer, "@\"%s\", ", sentFileName)
+			fmt.Fprintf(&buffer, "%s, ", literal.ObjectiveC(sentFileName))
 
 			// sent file name
 			if contentType != "" {
-				fmt.Fprintf(&buffer, "@\"%s\"", contentType)
+				buffer.WriteString(literal.ObjectiveC(contentType))
 			} else {
-				fmt.Fprintf(&buffer, "getMimeTypeFromPath(@\"%s\")", fragments[0])
+				fmt.Fprintf(&buffer, "getMimeTypeFromPath(%s)", literal.ObjectiveC(fragments[0]))
 			}
 			buffer.WriteString(", nil],\n")
 			result = buffer.String()
@@ -546,7 +614,7 @@ func FormString(generator *ObjCGenerator, data *common.DataOption) string {
 			fragments := strings.Split(field[1][1:], ";")
 
 			// field name, content
-			fmt.Fprintf(&buffer, "    [NSArray arrayWithObjects:@\"%s\", [NSString stringWithContentsOfFile:@\"%s\" encoding:NSUTF8StringEncoding error: nil], ", field[0], fragments[0])
+			fmt.Fprintf(&buffer, "    [NSArray arrayWithObjects:%s, [NSString stringWithContentsOfFile:%s encoding:NSUTF8StringEncoding error: nil], ", literal.ObjectiveC(field[0]), literal.ObjectiveC(fragments[0]))
 
 			var contentType string
 			for _, fragment := range fragments[1:] {
@@ -555,20 +623,16 @@ func FormString(generator *ObjCGenerator, data *common.DataOption) string {
 				}
 			}
 			if contentType != "" {
-				fmt.Fprintf(&buffer, `@"%s", `, contentType)
+				fmt.Fprintf(&buffer, `%s, `, literal.ObjectiveC(contentType))
 			}
 			buffer.WriteString("nil],\n")
 			result = buffer.String()
 		} else {
-			result = fmt.Sprintf("    [NSArray arrayWithObjects:@\"%s\", @\"%s\", nil],\n", field[0], field[1])
+			result = fmt.Sprintf("    [NSArray arrayWithObjects:%s, %s, nil],\n", literal.ObjectiveC(field[0]), literal.ObjectiveC(field[1]))
 		}
 	case common.FormStringType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
-		result = fmt.Sprintf("    [NSArray arrayWithObjects:@\"%s\", @\"%s\", nil],\n", field[0], field[1])
+		result = fmt.Sprintf("    [NSArray arrayWithObjects:%s, %s, nil],\n", literal.ObjectiveC(field[0]), literal.ObjectiveC(field[1]))
 	}
 	return result
 }
@@ -4,21 +4,20 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/shibukawa/curl_as_dsl/common"
-	"net/url"
-	"os"
+	"github.com/shibukawa/curl_as_dsl/literal"
 	"strings"
 )
 
 type PHPGenerator struct {
 	Options *common.CurlOptions
 
-	HasBody               bool
-	Body                  string
-	PrepareBody           string
-	queries               [][]string
-	extraUrl              string
-	AdditionalDeclaration string
-	specialHeaders        []string
+	HasBody        bool
+	Body           string
+	PrepareBody    string
+	queries        [][]string
+	extraUrl       string
+	declarations   []string
+	specialHeaders []string
 }
 
 func NewPHPGenerator(options *common.CurlOptions) *PHPGenerator {
@@ -29,8 +28,15 @@ func NewPHPGenerator(options *common.CurlOptions) *PHPGenerator {
 
 //--- Getter methods called from template
 
+/*
+	Helper functions and variables that they use like $cookies.
+*/
+func (self PHPGenerator) AdditionalDeclaration() string {
+	return strings.Join(self.declarations, "")
+}
+
 func (self PHPGenerator) Url() string {
-	return fmt.Sprintf(`"%s"%s`, self.Options.Url, self.extraUrl)
+	return literal.PHP(self.Options.Url) + self.extraUrl
 }
 
 func (self PHPGenerator) HasHeader() bool {
@@ -52,7 +58,7 @@ func (self PHPGenerator) PrepareHeader() string {
 	buffer.WriteString("\n$headers = ")
 	if len(self.Options.Header)+len(self.specialHeaders) == 1 {
 		for _, header := range self.Options.Header {
-			fmt.Fprintf(&buffer, "\"%s\";\n", header)
+			fmt.Fprintf(&buffer, "%s;\n", headerLiteral(header))
 		}
 		for _, header := range self.specialHeaders {
 			buffer.WriteString(header)
@@ -61,7 +67,7 @@ func (self PHPGenerator) PrepareHeader() string {
 	} else {
 		buffer.WriteString("\n")
 		for i, header := range self.Options.Header {
-			fmt.Fprintf(&buffer, `  "%s\n"`, header)
+			fmt.Fprintf(&buffer, "  %s", headerLiteral(header+"\n"))
 			if i != len(self.Options.Header)-1 || len(self.specialHeaders) > 0 {
 				buffer.WriteString(" .\n")
 			} else {
@@ -81,7 +87,7 @@ func (self PHPGenerator) PrepareHeader() string {
 }
 
 func (self PHPGenerator) Method() string {
-	return self.Options.Method()
+	return literal.PHP(self.Options.Method())
 }
 
 func (self PHPGenerator) Content() string {
@@ -94,12 +100,200 @@ func (self PHPGenerator) Content() string {
 	return ""
 }
 
+func (self PHPGenerator) IgnoreErrors() string {
+	// Digest authentication needs to read headers of 401 response
+	if self.Options.UseDigestAuth() {
+		return ",\n    \"ignore_errors\" => true"
+	}
+	return ""
+}
+
+/*
+	Send the request again with Digest authorization header when server returns a challenge.
+*/
+func (self PHPGenerator) Authenticate() string {
+	if !self.Options.UseDigestAuth() {
+		return ""
+	}
+	user, password := self.Options.UserAndPassword()
+	var buffer bytes.Buffer
+	buffer.WriteString("if ($fp !== false && preg_match('#^HTTP/\\S+ 401#', $http_response_header[0])) {\n")
+	fmt.Fprintf(&buffer, "  $authorization = digest_authorization($http_response_header, %s, %s, %s, %s);\n", self.Method(), self.Url(), literal.PHP(user), literal.PHP(password))
+	if self.HasHeader() {
+		buffer.WriteString("  stream_context_set_option($ctx, \"http\", \"header\", rtrim($headers) . \"\\nAuthorization: \" . $authorization);\n")
+	} else {
+		buffer.WriteString("  stream_context_set_option($ctx, \"http\", \"header\", \"Authorization: \" . $authorization);\n")
+	}
+	fmt.Fprintf(&buffer, "  $fp = fopen(%s, \"r\", false, $ctx);\n", self.Url())
+	buffer.WriteString("}\n")
+	return buffer.String()
+}
+
+func (self PHPGenerator) SaveCookies() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("if (isset($http_response_header)) {\n")
+	fmt.Fprintf(&buffer, "  store_cookies($cookies, $http_response_header, %s);\n", self.Url())
+	fmt.Fprintf(&buffer, "  save_cookies(%s, $cookies);\n", literal.PHP(self.Options.CookieJar))
+	buffer.WriteString("}\n")
+	return buffer.String()
+}
+
 //--- Setter/Getter methods
 
+/*
+	PHP streams don't have cookie jar. Cookies are read from and written to Netscape format files
+	and matched by the helper functions.
+*/
+func (self *PHPGenerator) AddCookieCode() {
+	self.declarations = append(self.declarations, `
+function load_cookies($file_name) {
+  $cookies = array();
+  if (!file_exists($file_name)) {
+    return $cookies;
+  }
+  foreach (file($file_name, FILE_IGNORE_NEW_LINES) as $line) {
+    $http_only = strpos($line, "#HttpOnly_") === 0;
+    if ($http_only) {
+      $line = substr($line, strlen("#HttpOnly_"));
+    }
+    $fields = explode("\t", rtrim($line, "\r\n"));
+    if (substr($line, 0, 1) == "#" || count($fields) != 7) {
+      continue;
+    }
+    $cookies[] = array("domain" => $fields[0], "subdomains" => $fields[1] == "TRUE", "path" => $fields[2], "secure" => $fields[3] == "TRUE",
+      "expires" => intval($fields[4]), "name" => $fields[5], "value" => $fields[6], "http_only" => $http_only);
+  }
+  return $cookies;
+}
+
+function cookie_header($cookies, $url, $extra) {
+  $parts = parse_url($url);
+  $path = isset($parts["path"]) ? $parts["path"] : "/";
+  $pairs = $extra === "" ? array() : array($extra);
+  foreach ($cookies as $cookie) {
+    $domain = ltrim($cookie["domain"], ".");
+    $domain_matched = $parts["host"] == $domain || ($cookie["subdomains"] && substr($parts["host"], -strlen($domain) - 1) == "." . $domain);
+    if ($domain_matched && strpos($path, $cookie["path"]) === 0 && ($parts["scheme"] == "https" || !$cookie["secure"])
+        && ($cookie["expires"] == 0 || $cookie["expires"] > time())) {
+      $pairs[] = $cookie["name"] . "=" . $cookie["value"];
+    }
+  }
+  return count($pairs) == 0 ? "" : "Cookie: " . implode("; ", $pairs) . "\n";
+}
+`)
+	if self.Options.CookieJar != "" {
+		self.declarations = append(self.declarations, `
+function store_cookies(&$cookies, $response_headers, $url) {
+  $host = parse_url($url, PHP_URL_HOST);
+  foreach ($response_headers as $header) {
+    if (!preg_match('/^Set-Cookie:\s*(.*)$/i', $header, $match)) {
+      continue;
+    }
+    $attributes = explode(";", $match[1]);
+    $pair = explode("=", array_shift($attributes), 2);
+    $cookie = array("domain" => $host, "subdomains" => false, "path" => "/", "secure" => false, "expires" => 0,
+      "name" => trim($pair[0]), "value" => isset($pair[1]) ? trim($pair[1]) : "", "http_only" => false);
+    $max_age = null;
+    foreach ($attributes as $attribute) {
+      $key_value = explode("=", $attribute, 2);
+      $key = strtolower(trim($key_value[0]));
+      $value = isset($key_value[1]) ? trim($key_value[1]) : "";
+      if ($key == "domain") {
+        $cookie["domain"] = "." . ltrim($value, ".");
+        $cookie["subdomains"] = true;
+      } elseif ($key == "path") {
+        $cookie["path"] = $value;
+      } elseif ($key == "secure") {
+        $cookie["secure"] = true;
+      } elseif ($key == "httponly") {
+        $cookie["http_only"] = true;
+      } elseif ($key == "expires") {
+        $cookie["expires"] = strtotime($value);
+      } elseif ($key == "max-age") {
+        $max_age = intval($value);
+      }
+    }
+    if ($max_age !== null) {
+      $cookie["expires"] = time() + $max_age;
+    }
+    foreach ($cookies as $i => $old) {
+      if ($old["name"] == $cookie["name"] && $old["domain"] == $cookie["domain"] && $old["path"] == $cookie["path"]) {
+        unset($cookies[$i]);
+      }
+    }
+    $cookies[] = $cookie;
+  }
+}
+
+function save_cookies($file_name, $cookies) {
+  $lines = array("# Netscape HTTP Cookie File");
+  foreach ($cookies as $cookie) {
+    if ($cookie["expires"] != 0 && $cookie["expires"] < time()) {
+      continue;
+    }
+    $lines[] = implode("\t", array(($cookie["http_only"] ? "#HttpOnly_" : "") . $cookie["domain"], $cookie["subdomains"] ? "TRUE" : "FALSE",
+      $cookie["path"], $cookie["secure"] ? "TRUE" : "FALSE", $cookie["expires"], $cookie["name"], $cookie["value"]));
+  }
+  file_put_contents($file_name, implode("\n", $lines) . "\n");
+}
+`)
+	}
+	var files []string
+	for _, fileName := range self.Options.CookieFiles() {
+		files = append(files, fmt.Sprintf("load_cookies(%s)", literal.PHP(fileName)))
+	}
+	switch len(files) {
+	case 0:
+		self.declarations = append(self.declarations, "\n$cookies = array();\n")
+	case 1:
+		self.declarations = append(self.declarations, fmt.Sprintf("\n$cookies = %s;\n", files[0]))
+	default:
+		self.declarations = append(self.declarations, fmt.Sprintf("\n$cookies = array_merge(%s);\n", strings.Join(files, ", ")))
+	}
+	self.specialHeaders = append(self.specialHeaders, fmt.Sprintf("cookie_header($cookies, %s, %s)", self.Url(), literal.PHP(self.Options.CookieString())))
+}
+
+func (self *PHPGenerator) AddDigestCode() {
+	self.declarations = append(self.declarations, `
+function digest_authorization($response_headers, $method, $url, $username, $password) {
+  $challenge = "";
+  foreach ($response_headers as $header) {
+    if (preg_match('/^WWW-Authenticate:\s*Digest\s+(.*)$/i', $header, $match)) {
+      $challenge = $match[1];
+    }
+  }
+  preg_match_all('/(\w+)=(?:"([^"]*)"|([^,\s]*))/', $challenge, $matches, PREG_SET_ORDER);
+  $params = array();
+  foreach ($matches as $match) {
+    $params[$match[1]] = isset($match[3]) ? $match[3] : $match[2];
+  }
+  $parts = parse_url($url);
+  $uri = (isset($parts["path"]) ? $parts["path"] : "/") . (isset($parts["query"]) ? "?" . $parts["query"] : "");
+  $ha1 = md5(implode(":", array($username, $params["realm"], $password)));
+  $ha2 = md5(implode(":", array($method, $uri)));
+  $authorization = sprintf('Digest username="%s", realm="%s", nonce="%s", uri="%s"', $username, $params["realm"], $params["nonce"], $uri);
+  if (isset($params["qop"])) {
+    $cnonce = bin2hex(openssl_random_pseudo_bytes(8));
+    $response = md5(implode(":", array($ha1, $params["nonce"], "00000001", $cnonce, "auth", $ha2)));
+    $authorization .= sprintf(', qop=auth, nc=00000001, cnonce="%s", response="%s"', $cnonce, $response);
+  } else {
+    $authorization .= sprintf(', response="%s"', md5(implode(":", array($ha1, $params["nonce"], $ha2))));
+  }
+  if (isset($params["opaque"])) {
+    $authorization .= sprintf(', opaque="%s"', $params["opaque"]);
+  }
+  return $authorization . ", algorithm=MD5";
+}
+`)
+}
+
 func (self *PHPGenerator) AddMultiPartCode() {
-	self.AdditionalDeclaration = `
+	self.declarations = append(self.declarations, `
 $BOUNDARY = "---------------------".substr(md5(rand(0,32000)), 0, 10);
-
+`, `
 function encode_multipart_formdata($fields, $files, $boundary) {
   $result = "";
   $finfo = finfo_open(FILEINFO_MIME_TYPE);
@@ -124,43 +318,43 @@ function encode_multipart_formdata($fields, $files, $boundary) {
   $result .= $boundary . "--\r\n";
   return $result;
 }
-`
+`)
 	self.Options.InsertContentTypeHeader("multipart/form-data; boundary={$BOUNDARY}")
 }
 
-func (self *PHPGenerator) SetDataForUrl() {
+func (self *PHPGenerator) SetDataForUrl() error {
 	var buffer bytes.Buffer
 	if self.Options.CanUseSimpleForm() {
-		entries := make(map[string][]string)
-		for _, data := range self.Options.ProcessedData {
-			singleData, _ := url.ParseQuery(data.Value)
-			for key, values := range singleData {
-				entries[key] = append(entries[key], values[0])
-			}
-		}
+		keys, entries := self.Options.ProcessedData.FormValues()
 		fmt.Fprintf(&buffer, "\n%s = http_build_query([", "$query")
-		count := 0
-		for key, values := range entries {
-			if count != 0 {
+		for i, key := range keys {
+			if i != 0 {
 				buffer.WriteString(",")
 			}
-			buffer.WriteString(fmt.Sprintf("\n  \"%s\" => \"%s\"", key, values[0]))
-			count++
+			buffer.WriteString(fmt.Sprintf("\n  %s => %s", literal.PHP(key), literal.PHP(entries[key][0])))
 		}
 		buffer.WriteString("\n], PHP_QUERY_RFC1738);")
 		self.extraUrl = ` . "?" . $query`
 	} else {
 		// Use bytes.Buffer to create URL option string
 		if len(self.Options.ProcessedData) == 1 {
-			self.extraUrl = fmt.Sprintf(` . "?" . %s`, NewStringForData(self, &self.Options.ProcessedData[0]))
+			query, err := NewStringForData(self, &self.Options.ProcessedData[0])
+			if err != nil {
+				return err
+			}
+			self.extraUrl = fmt.Sprintf(` . "?" . %s`, query)
 		} else {
 			for i, data := range self.Options.ProcessedData {
 				if i == 0 {
 					buffer.WriteString("\n$query = \n  ")
 				}
-				buffer.WriteString(StringForData(self, &data))
+				value, err := StringForData(self, &data)
+				if err != nil {
+					return err
+				}
+				buffer.WriteString(value)
 				if i != len(self.Options.ProcessedData)-1 {
-					buffer.WriteString(".\n  ")
+					buffer.WriteString(" . \"&\" .\n  ")
 				}
 			}
 			buffer.WriteString(";\n")
@@ -168,12 +362,17 @@ func (self *PHPGenerator) SetDataForUrl() {
 		}
 	}
 	self.PrepareBody = buffer.String()
+	return nil
 }
 
-func (self *PHPGenerator) SetDataForBody(varName string) {
+func (self *PHPGenerator) SetDataForBody(varName string) error {
 	var buffer bytes.Buffer
 	if len(self.Options.ProcessedData) == 1 {
-		self.Body = NewStringForData(self, &self.Options.ProcessedData[0])
+		body, err := NewStringForData(self, &self.Options.ProcessedData[0])
+		if err != nil {
+			return err
+		}
+		self.Body = body
 	} else {
 		for i, data := range self.Options.ProcessedData {
 			if i == 0 {
@@ -181,32 +380,28 @@ func (self *PHPGenerator) SetDataForBody(varName string) {
 			} else {
 				buffer.WriteString(" . \"&\" .\n  ")
 			}
-			buffer.WriteString(StringForData(self, &data))
+			value, err := StringForData(self, &data)
+			if err != nil {
+				return err
+			}
+			buffer.WriteString(value)
 		}
 		buffer.WriteString(";\n")
 		self.Body = varName
 	}
 	self.PrepareBody = buffer.String()
+	return nil
 }
 
 func (self *PHPGenerator) SetDataForForm(varName string) {
-	entries := make(map[string][]string)
-	for _, data := range self.Options.ProcessedData {
-		singleData, _ := url.ParseQuery(data.Value)
-		for key, values := range singleData {
-			entries[key] = append(entries[key], values[0])
-		}
-	}
-
+	keys, entries := self.Options.ProcessedData.FormValues()
 	var buffer bytes.Buffer
 	fmt.Fprintf(&buffer, "\n%s = http_build_query([", varName)
-	count := 0
-	for key, values := range entries {
-		if count != 0 {
+	for i, key := range keys {
+		if i != 0 {
 			buffer.WriteString(",")
 		}
-		buffer.WriteString(fmt.Sprintf("\n  \"%s\" => \"%s\"", key, values[0]))
-		count++
+		buffer.WriteString(fmt.Sprintf("\n  %s => %s", literal.PHP(key), literal.PHP(entries[key][0])))
 	}
 	buffer.WriteString("\n], PHP_QUERY_RFC1738);")
 
@@ -258,78 +453,124 @@ func (self *PHPGenerator) SetFormForBody() {
 	Dispatcher function of curl command
 	This is an exported function and called from httpgen.
 */
-func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}) {
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
 	generator := NewPHPGenerator(options)
+	if err := processPHPRequest(generator); err != nil {
+		return "", nil, err
+	}
+	return "full", *generator, nil
+}
 
+func processPHPRequest(generator *PHPGenerator) error {
+	options := generator.Options
 	if options.ProcessedData.HasData() {
 		if options.Get {
-			generator.SetDataForUrl()
+			if err := generator.SetDataForUrl(); err != nil {
+				return err
+			}
 		} else {
-			generator.Options.InsertContentTypeHeader("application/x-www-form-urlencoded")
-			generator.SetDataForBody("$content")
+			generator.Options.InsertDataContentTypeHeader()
+			if err := generator.SetDataForBody("$content"); err != nil {
+				return err
+			}
 		}
 	} else if options.ProcessedData.HasForm() {
 		generator.SetFormForBody()
 	}
-	if generator.Options.User != "" {
-		generator.specialHeaders = append(generator.specialHeaders, fmt.Sprintf(`"Authorization: Basic " . base64_encode('%s') . "\n"`, generator.Options.User))
+	if generator.Options.UseBasicAuth() {
+		generator.specialHeaders = append(generator.specialHeaders, fmt.Sprintf(`"Authorization: Basic " . base64_encode(%s) . "\n"`, literal.PHP(generator.Options.User)))
+	} else if generator.Options.UseDigestAuth() {
+		generator.AddDigestCode()
+	}
+	if options.UseCookieJar() {
+		generator.AddCookieCode()
+	} else if len(options.Cookies()) != 0 {
+		generator.specialHeaders = append(generator.specialHeaders, literal.PHP("Cookie: "+options.CookieString()+"\n"))
+	}
+	if options.ProcessedData.UploadFile() != "" {
+		options.AddWarning("PHP sends Content-Type: application/x-www-form-urlencoded with the file of -T.")
 	}
 
-	return "full", *generator
+	return nil
 }
 
 // helper functions
 
-func NewStringForData(generator *PHPGenerator, data *common.DataOption) string {
+/*
+	String literal of the header. {$BOUNDARY} in the multipart header is kept as the variable.
+*/
+func headerLiteral(header string) string {
+	var literals []string
+	for i, fragment := range strings.Split(header, "{$BOUNDARY}") {
+		if i != 0 {
+			literals = append(literals, "$BOUNDARY")
+		}
+		if fragment != "" {
+			literals = append(literals, literal.PHP(fragment))
+		}
+	}
+	return strings.Join(literals, " . ")
+}
+
+func NewStringForData(generator *PHPGenerator, data *common.DataOption) (string, error) {
 	var result string
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf(`str_replace(array("\r\n", "\n", "\r"), "", file_get_contents("%s"))`, data.Value[1:])
+		if data.UseExternalFile() {
+			result = fmt.Sprintf(`str_replace(array("\r\n", "\n", "\r"), "", file_get_contents(%s))`, literal.PHP(data.Value[1:]))
 		} else {
-			result = fmt.Sprintf(`"%s"`, strings.Replace(data.Value, "\n", "", -1))
+			result = literal.PHP(strings.Replace(data.Value, "\n", "", -1))
 		}
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf(`file_get_contents("%s")`, data.Value[1:])
+		if data.UseExternalFile() {
+			result = fmt.Sprintf(`file_get_contents(%s)`, literal.PHP(data.Value[1:]))
 		} else {
-			result = fmt.Sprintf(`"%s"`, data.Value)
+			result = literal.PHP(data.Value)
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf("urlencode(file_get_contents('%s'))", data.Value[1:])
-		} else {
-			result = fmt.Sprintf("urlencode('%s')", data.Value)
-		}
+		result = urlEncode(data)
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return "", common.UnexpectedDataError("php", data)
 	}
-	return result
+	return result, nil
 }
 
-func StringForData(generator *PHPGenerator, data *common.DataOption) string {
+func StringForData(generator *PHPGenerator, data *common.DataOption) (string, error) {
 	var result string
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf("str_replace(array(\"\\r\\n\", \"\\n\", \"\\r\"), '', file_get_contents('%s'))", data.Value[1:])
+		if data.UseExternalFile() {
+			result = fmt.Sprintf("str_replace(array(\"\\r\\n\", \"\\n\", \"\\r\"), '', file_get_contents(%s))", literal.PHP(data.Value[1:]))
 		} else {
-			result = fmt.Sprintf(`"%s"`, strings.Replace(data.Value, "\n", "", -1))
+			result = literal.PHP(strings.Replace(data.Value, "\n", "", -1))
 		}
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf("file_get_contents(\"%s\")", data.Value[1:])
+		if data.UseExternalFile() {
+			result = fmt.Sprintf("file_get_contents(%s)", literal.PHP(data.Value[1:]))
 		} else {
-			result = fmt.Sprintf(`"%s"`, data.Value)
+			result = literal.PHP(data.Value)
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf("urlencode(file_get_contents(\"%s\"))", data.Value[1:])
-		} else {
-			result = fmt.Sprintf("urlencode(\"%s\")", data.Value)
-		}
+		result = urlEncode(data)
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return "", common.UnexpectedDataError("php", data)
+	}
+	return result, nil
+}
+
+/*
+	Value of --data-urlencode. curl doesn't encode the name.
+*/
+func urlEncode(data *common.DataOption) string {
+	name, content, isFile := data.UrlEncodeParts()
+	var result string
+	if isFile {
+		result = fmt.Sprintf("urlencode(file_get_contents(%s))", literal.PHP(content))
+	} else {
+		result = fmt.Sprintf("urlencode(%s)", literal.PHP(content))
+	}
+	if name != "" {
+		return literal.PHP(name+"=") + " . " + result
 	}
 	return result
 }
@@ -339,16 +580,12 @@ func FormString(generator *PHPGenerator, data *common.DataOption) string {
 	switch data.Type {
 	case common.FormType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
 		if strings.HasPrefix(field[1], "@") {
 			var buffer bytes.Buffer
 			fragments := strings.Split(field[1][1:], ";")
 
 			// field name, source file name
-			fmt.Fprintf(&buffer, "  array(\"key\"=>\"%s\", \"source_file\"=>\"%s\", ", field[0], fragments[0])
+			fmt.Fprintf(&buffer, "  array(\"key\"=>%s, \"source_file\"=>%s, ", literal.PHP(field[0]), literal.PHP(fragments[0]))
 
 			var contentType string
 			sentFileName := fragments[0]
@@ -360,11 +597,11 @@ func FormString(generator *PHPGenerator, data *common.DataOption) string {
 				}
 			}
 			// sent file name
-			fmt.Fprintf(&buffer, "\"filename\"=>\"%s\", ", sentFileName)
+			fmt.Fprintf(&buffer, "\"filename\"=>%s, ", literal.PHP(sentFileName))
 
 			// sent file name
 			if contentType != "" {
-				fmt.Fprintf(&buffer, "\"content_type\"=>\"%s\"),\n", contentType)
+				fmt.Fprintf(&buffer, "\"content_type\"=>%s),\n", literal.PHP(contentType))
 			} else {
 				buffer.WriteString("\"content_type\"=>\"\"),\n")
 			}
@@ -375,7 +612,7 @@ func FormString(generator *PHPGenerator, data *common.DataOption) string {
 			fragments := strings.Split(field[1][1:], ";")
 
 			// field name, content
-			fmt.Fprintf(&buffer, `  array("key"=>"%s", "value"=>file_get_contents("%s"), "content_type"=>`, field[0], fragments[0])
+			fmt.Fprintf(&buffer, `  array("key"=>%s, "value"=>file_get_contents(%s), "content_type"=>`, literal.PHP(field[0]), literal.PHP(fragments[0]))
 
 			var contentType string
 			for _, fragment := range fragments[1:] {
@@ -386,19 +623,15 @@ func FormString(generator *PHPGenerator, data *common.DataOption) string {
 			if contentType == "" {
 				buffer.WriteString("\"\"),\n")
 			} else {
-				fmt.Fprintf(&buffer, "\"%s\"),\n", contentType)
+				fmt.Fprintf(&buffer, "%s),\n", literal.PHP(contentType))
 			}
 			result = buffer.String()
 		} else {
-			result = fmt.Sprintf("  array(\"key\"=>\"%s\", \"value\"=>\"%s\", \"content_type\"=>\"\"),\n", field[0], field[1])
+			result = fmt.Sprintf("  array(\"key\"=>%s, \"value\"=>%s, \"content_type\"=>\"\"),\n", literal.PHP(field[0]), literal.PHP(field[1]))
 		}
 	case common.FormStringType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
-		result = fmt.Sprintf("  array(\"key\"=>\"%s\", \"value\"=>\"%s\", \"content_type\"=>\"\"),\n", field[0], field[1])
+		result = fmt.Sprintf("  array(\"key\"=>%s, \"value\"=>%s, \"content_type\"=>\"\"),\n", literal.PHP(field[0]), literal.PHP(field[1]))
 	}
 	return result
 }
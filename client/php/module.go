@@ -0,0 +1,183 @@
+package php
+
+import (
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"strings"
+)
+
+/*
+	Request in a module. It is written as a function that returns the stream of the response.
+*/
+type PHPFunction struct {
+	PHPGenerator
+	Name  string
+	Title string
+}
+
+/*
+	Module that has functions of requests. Helper functions are shared. Variables that helpers use
+	like $cookies and $BOUNDARY are local variables of the functions.
+*/
+type PHPModule struct {
+	Functions []PHPFunction
+	HasMain   bool
+
+	declarations []string
+}
+
+var reservedNames = map[string]bool{
+	// variables of generated functions
+	"ctx": true, "fp": true, "headers": true, "content": true, "query": true, "fields": true, "files": true,
+	"cookies": true, "boundary": true, "authorization": true, "http_response_header": true, "this": true,
+	"globals": true, "send": true,
+}
+
+var reservedFunctions = map[string]bool{
+	// helper functions
+	"encode_multipart_formdata": true, "digest_authorization": true, "load_cookies": true, "cookie_header": true,
+	"store_cookies": true, "save_cookies": true,
+	// built-in functions that are likely names of requests
+	"header": true, "file": true, "copy": true, "count": true, "date": true, "time": true, "mail": true,
+	"link": true, "sleep": true, "exec": true, "flush": true, "stat": true, "touch": true, "unlink": true,
+	"rename": true, "key": true, "next": true, "reset": true, "current": true, "each": true, "sort": true,
+	"end": true, "system": true, "phpinfo": true,
+	// keywords
+	"abstract": true, "and": true, "array": true, "as": true, "break": true, "callable": true, "case": true,
+	"catch": true, "class": true, "clone": true, "const": true, "continue": true, "declare": true,
+	"default": true, "die": true, "do": true, "echo": true, "else": true, "elseif": true, "empty": true,
+	"enddeclare": true, "endfor": true, "endforeach": true, "endif": true, "endswitch": true, "endwhile": true,
+	"enum": true, "eval": true, "exit": true, "extends": true, "final": true, "finally": true, "fn": true,
+	"for": true, "foreach": true, "function": true, "global": true, "goto": true, "if": true,
+	"implements": true, "include": true, "include_once": true, "instanceof": true, "insteadof": true,
+	"interface": true, "isset": true, "list": true, "match": true, "namespace": true, "new": true, "or": true,
+	"print": true, "private": true, "protected": true, "public": true, "readonly": true, "require": true,
+	"require_once": true, "return": true, "static": true, "switch": true, "throw": true, "trait": true,
+	"try": true, "unset": true, "use": true, "var": true, "while": true, "xor": true, "yield": true,
+}
+
+/*
+	Variable of the function parameter like "$user_id".
+*/
+func ParameterName(name string) string {
+	result := common.SnakeCase(common.IdentifierWords(name))
+	if reservedNames[result] {
+		result += "_value"
+	}
+	return "$" + result
+}
+
+/*
+	Parameters of the function like "$user_id, $token".
+*/
+func (self PHPFunction) Arguments() string {
+	var arguments []string
+	for _, parameter := range self.Options.FunctionParameters() {
+		arguments = append(arguments, ParameterName(parameter.Name))
+	}
+	return strings.Join(arguments, ", ")
+}
+
+/*
+	Closure that main code calls. Parameters are read from environment variables.
+*/
+func (self PHPFunction) Sender() string {
+	var arguments []string
+	for _, parameter := range self.Options.FunctionParameters() {
+		arguments = append(arguments, fmt.Sprintf("getenv('%s')", parameter.EnvironmentVariable()))
+	}
+	return fmt.Sprintf("function () { return %s(%s); }", self.Name, strings.Join(arguments, ", "))
+}
+
+/*
+	Variables of helpers, body and headers. Code of the program is indented in the function.
+*/
+func (self PHPFunction) Prepare() string {
+	var code string
+	for _, declaration := range self.declarations {
+		if !isFunction(declaration) {
+			code += declaration
+		}
+	}
+	code = strings.Trim(code+self.PrepareBody+self.PrepareHeader(), "\n")
+	if code == "" {
+		return ""
+	}
+	return indent(code + "\n")
+}
+
+/*
+	Options of the stream context after "method".
+*/
+func (self PHPFunction) ContextOptions() string {
+	return indent(self.Header() + self.Content() + self.IgnoreErrors())
+}
+
+func (self PHPFunction) Authenticate() string {
+	return indent(self.PHPGenerator.Authenticate())
+}
+
+func (self PHPFunction) SaveCookies() string {
+	return indent(self.PHPGenerator.SaveCookies())
+}
+
+/*
+	Indent lines after the first line. Empty lines are kept empty.
+*/
+func indent(code string) string {
+	lines := strings.Split(code, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" || i == len(lines)-1 {
+			lines[i] = "  " + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isFunction(declaration string) bool {
+	return strings.HasPrefix(declaration, "\nfunction ")
+}
+
+func (self PHPModule) AdditionalDeclaration() string {
+	return strings.Join(self.declarations, "")
+}
+
+/*
+	Create a module from requests. Each function returns the stream of fopen() and the caller reads the response.
+	Main code runs only when the file is executed directly. packageName is ignored.
+*/
+func ProcessCurlCommandsForModule(requests []common.NamedRequest, packageName string, hasMain bool) (string, interface{}, error) {
+	module := PHPModule{HasMain: hasMain}
+	used := make(map[string]bool)
+	for name := range reservedFunctions {
+		used[name] = true
+	}
+	for i := range requests {
+		generator := NewPHPGenerator(requests[i].Options)
+		if err := processPHPRequest(generator); err != nil {
+			return "", nil, err
+		}
+		// helper functions are constants of this package
+		for _, declaration := range generator.declarations {
+			if isFunction(declaration) && !contains(module.declarations, declaration) {
+				module.declarations = append(module.declarations, declaration)
+			}
+		}
+		names := append(append([]string(nil), requests[i].Groups...), requests[i].Name)
+		module.Functions = append(module.Functions, PHPFunction{
+			PHPGenerator: *generator,
+			Name:         common.UniqueName(common.SnakeCase(common.IdentifierWords(names...)), used),
+			Title:        strings.Join(names, " / "),
+		})
+	}
+	return "module", module, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
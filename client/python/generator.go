@@ -4,9 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/shibukawa/curl_as_dsl/common"
-	"log"
-	"net/url"
-	"os"
+	"github.com/shibukawa/curl_as_dsl/literal"
 	"strings"
 )
 
@@ -14,12 +12,12 @@ type PythonGenerator struct {
 	Options *common.CurlOptions
 	Modules map[string]bool
 
-	HasBody               bool
-	Body                  string
-	PrepareBody           string
-	extraUrl              string
-	AdditionalDeclaration string
-	specialHeaders        []string
+	HasBody        bool
+	Body           string
+	PrepareBody    string
+	extraUrl       string
+	declarations   []string
+	specialHeaders []string
 }
 
 func NewPythonGenerator(options *common.CurlOptions) *PythonGenerator {
@@ -32,6 +30,10 @@ func NewPythonGenerator(options *common.CurlOptions) *PythonGenerator {
 
 //--- Getter methods called from template
 
+func (self PythonGenerator) AdditionalDeclaration() string {
+	return strings.Join(self.declarations, "")
+}
+
 func (self PythonGenerator) ConnectionClass() string {
 	var targetUrl string
 	if self.Options.Proxy != "" {
@@ -46,50 +48,41 @@ func (self PythonGenerator) ConnectionClass() string {
 }
 
 func (self PythonGenerator) Host() string {
-	var targetUrl string
 	if self.Options.Proxy != "" {
-		targetUrl = self.Options.Proxy
-	} else {
-		targetUrl = self.Options.Url
-	}
-	u, err := url.Parse(targetUrl)
-	if err != nil {
-		log.Fatal(err)
+		return literal.Python(self.Options.ParsedProxy().Host)
 	}
-	return u.Host
+	return literal.Python(self.Options.ParsedUrl().Host)
 }
 
 func (self PythonGenerator) Proxy() string {
 	if self.Options.Proxy != "" {
-		u, err := url.Parse(self.Options.Url)
-		if err != nil {
-			log.Fatal(err)
-		}
-		return fmt.Sprintf("conn.set_tunnel(\"%s\")\n    ", u.Host)
+		u := self.Options.ParsedUrl()
+		return fmt.Sprintf("conn.set_tunnel(%s)\n    ", literal.Python(u.Host))
 	}
 	return ""
 }
 
 func (self PythonGenerator) HasHeader() bool {
-	return len(self.Options.Header) != 0 || len(self.specialHeaders) != 0
+	// Digest authentication and cookie jar modify headers dict
+	return len(self.Options.Header) != 0 || len(self.specialHeaders) != 0 || self.Options.UseDigestAuth() || self.Options.UseCookieJar()
 }
 
 func (self PythonGenerator) Header() string {
-	if len(self.Options.Header) == 0 && len(self.specialHeaders) == 0 {
+	if !self.HasHeader() {
 		return ""
 	}
 	return "headers"
 }
 
 func (self PythonGenerator) PrepareHeader() string {
-	if len(self.Options.Header) == 0 && len(self.specialHeaders) == 0 {
+	if !self.HasHeader() {
 		return ""
 	}
 	var buffer bytes.Buffer
 	buffer.WriteString("headers = {\n")
 	for _, header := range self.Options.Header {
-		headers := strings.Split(header, ":")
-		fmt.Fprintf(&buffer, "        \"%s\": \"%s\",\n", strings.TrimSpace(headers[0]), strings.TrimSpace(headers[1]))
+		headers := strings.SplitN(header, ":", 2)
+		fmt.Fprintf(&buffer, "        %s: %s,\n", literal.Python(strings.TrimSpace(headers[0])), literal.Python(strings.TrimSpace(headers[1])))
 	}
 	for _, header := range self.specialHeaders {
 		buffer.WriteString(header)
@@ -103,26 +96,114 @@ func (self PythonGenerator) Method() string {
 }
 
 func (self PythonGenerator) Path() string {
-	u, err := url.Parse(self.Options.Url)
-	if err != nil {
-		log.Fatal(err)
-	}
+	u := self.Options.ParsedUrl()
 	path := u.Path
 	if u.Path == "" {
 		path = "/"
 	}
 	if self.extraUrl != "" {
-		return fmt.Sprintf("\"%s?\" + %s", path, self.extraUrl)
+		return literal.Python(path+"?") + " + " + self.extraUrl
 
 	} else {
-		return fmt.Sprintf("\"%s\"", path)
+		return literal.Python(path)
+	}
+}
+
+/*
+	http.cookiejar works with urllib.request.Request. Dummy request is used to calculate Cookie header.
+*/
+func (self PythonGenerator) PrepareCookie() string {
+	if !self.Options.UseCookieJar() {
+		return ""
+	}
+	u := self.Options.ParsedUrl()
+	var buffer bytes.Buffer
+	buffer.WriteString("jar = http.cookiejar.MozillaCookieJar()\n")
+	for _, fileName := range self.Options.CookieFiles() {
+		fmt.Fprintf(&buffer, "    if os.path.exists(%s):\n", literal.Python(fileName))
+		fmt.Fprintf(&buffer, "        jar.load(%s, ignore_discard=True, ignore_expires=True)\n", literal.Python(fileName))
+	}
+	if len(self.Options.CookieFiles()) != 0 {
+		buffer.WriteString("    for cookie in jar:\n")
+		buffer.WriteString("        if cookie.expires == 0:\n")
+		buffer.WriteString("            # curl writes session cookie with 0 expiration time\n")
+		buffer.WriteString("            cookie.expires, cookie.discard = None, True\n")
+	}
+	fmt.Fprintf(&buffer, "    cookie_request = urllib.request.Request(%s + %s)\n", literal.Python(u.Scheme+"://"+u.Host), self.Path())
+	buffer.WriteString("    jar.add_cookie_header(cookie_request)\n")
+	buffer.WriteString("    if cookie_request.has_header('Cookie'):\n")
+	buffer.WriteString("        headers['Cookie'] = '; '.join(filter(None, [headers.get('Cookie'), cookie_request.get_header('Cookie')]))\n    ")
+	return buffer.String()
+}
+
+func (self PythonGenerator) SaveCookie() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("jar.extract_cookies(res, cookie_request)\n")
+	fmt.Fprintf(&buffer, "    jar.save(%s, ignore_discard=True, ignore_expires=True)\n    ", literal.Python(self.Options.CookieJar))
+	return buffer.String()
+}
+
+func (self PythonGenerator) Request() string {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "conn.request(%s, %s", literal.Python(self.Method()), self.Path())
+	if self.HasBody {
+		fmt.Fprintf(&buffer, ", body=%s", self.Body)
+	}
+	if self.HasHeader() {
+		fmt.Fprintf(&buffer, ", headers=%s", self.Header())
+	}
+	buffer.WriteString(")")
+	return buffer.String()
+}
+
+/*
+	Retry the request with Digest authorization header when server returns a challenge.
+*/
+func (self PythonGenerator) Authenticate() string {
+	if !self.Options.UseDigestAuth() {
+		return ""
 	}
+	user, password := self.Options.UserAndPassword()
+	var buffer bytes.Buffer
+	buffer.WriteString("if res.status == 401:\n")
+	buffer.WriteString("        res.read()\n")
+	fmt.Fprintf(&buffer, "        headers['Authorization'] = digest_authorization(res.getheader('WWW-Authenticate'), %s, %s, %s, %s)\n", literal.Python(self.Method()), self.Path(), literal.Python(user), literal.Python(password))
+	fmt.Fprintf(&buffer, "        %s\n", self.Request())
+	buffer.WriteString("        res = conn.getresponse()\n    ")
+	return buffer.String()
 }
 
 //--- Setter/Getter methods
 
+func (self *PythonGenerator) AddDigestCode() {
+	self.declarations = append(self.declarations, `
+def digest_authorization(challenge, method, uri, username, password):
+    params = dict((key, quoted or plain) for key, quoted, plain in re.findall(r'(\w+)=(?:"([^"]*)"|([^,\s]*))', challenge))
+    md5_hex = lambda text: hashlib.md5(text.encode('utf-8')).hexdigest()
+    ha1 = md5_hex(':'.join([username, params['realm'], password]))
+    ha2 = md5_hex(':'.join([method, uri]))
+    authorization = 'Digest username="%s", realm="%s", nonce="%s", uri="%s"' % (username, params['realm'], params['nonce'], uri)
+    if 'qop' in params:
+        cnonce = binascii.hexlify(os.urandom(8)).decode('ascii')
+        response = md5_hex(':'.join([ha1, params['nonce'], '00000001', cnonce, 'auth', ha2]))
+        authorization += ', qop=auth, nc=00000001, cnonce="%s", response="%s"' % (cnonce, response)
+    else:
+        authorization += ', response="%s"' % md5_hex(':'.join([ha1, params['nonce'], ha2]))
+    if 'opaque' in params:
+        authorization += ', opaque="%s"' % params['opaque']
+    return authorization + ', algorithm=MD5'
+`)
+	self.Modules["binascii"] = true
+	self.Modules["hashlib"] = true
+	self.Modules["os"] = true
+	self.Modules["re"] = true
+}
+
 func (self *PythonGenerator) AddMultiPartCode() {
-	self.AdditionalDeclaration = `
+	self.declarations = append(self.declarations, `
 BOUNDARY = '----------ThIs_Is_tHe_bouNdaRY_$'
 
 def encode_multipart_formdata(fields, files):
@@ -140,67 +221,64 @@ def encode_multipart_formdata(fields, files):
         L.append('Content-Type: %s' % contenttype)
         L.append('')
         L.append(open(sourcefile).read())
-        L.append('')
     L.append('--' + BOUNDARY + '--')
     return '\r\n'.join(L)
-`
+`)
 	boundary := "----------ThIs_Is_tHe_bouNdaRY_$"
 	self.Options.InsertContentTypeHeader(fmt.Sprintf("multipart/form-data; boundary=%s", boundary))
 }
 
-func (self *PythonGenerator) SetDataForUrl() {
+func (self *PythonGenerator) SetDataForUrl() error {
 	if self.Options.CanUseSimpleForm() {
 		self.SetDataForForm()
-		self.extraUrl = self.Body
-		self.Body = ""
-		self.HasBody = false
-	} else {
-		// Use bytes.Buffer to create URL option string
-		self.SetDataForBody()
-		self.extraUrl = self.Body
-		self.Body = ""
-		self.HasBody = false
+	} else if err := self.SetDataForBody(); err != nil {
+		return err
 	}
+	self.extraUrl = self.Body
+	self.Body = ""
+	self.HasBody = false
+	return nil
 }
 
-func (self *PythonGenerator) SetDataForBody() {
+func (self *PythonGenerator) SetDataForBody() error {
 	var buffer bytes.Buffer
 	if len(self.Options.ProcessedData) == 1 {
-		var body string
-		body, self.Body = NewStringForData(self, &self.Options.ProcessedData[0])
+		body, name, err := NewStringForData(self, &self.Options.ProcessedData[0])
+		if err != nil {
+			return err
+		}
 		buffer.WriteString(body)
+		self.Body = name
 	} else {
 		for i, data := range self.Options.ProcessedData {
 			if i == 0 {
 				buffer.WriteString("body = [\n")
 			}
-			buffer.WriteString(StringForData(self, &data))
+			line, err := StringForData(self, &data)
+			if err != nil {
+				return err
+			}
+			buffer.WriteString(line)
 		}
 		buffer.WriteString("    ]\n    ")
 		self.Body = "'&'.join(body)"
 	}
 	self.PrepareBody = buffer.String()
 	self.HasBody = true
+	return nil
 }
 
 func (self *PythonGenerator) SetDataForForm() {
-	entries := make(map[string][]string)
-	for _, data := range self.Options.ProcessedData {
-		singleData, _ := url.ParseQuery(data.Value)
-		for key, values := range singleData {
-			entries[key] = append(entries[key], values[0])
-		}
-	}
+	keys, entries := self.Options.ProcessedData.FormValues()
 
 	var buffer bytes.Buffer
 	count := 0
-	for key, values := range entries {
+	for _, key := range keys {
+		values := entries[key]
 		if count == 0 {
 			buffer.WriteString("values = urllib.parse.urlencode({\n")
-		} else {
-			buffer.WriteString(", \"")
 		}
-		fmt.Fprintf(&buffer, "        \"%s\": \"%s\",\n", key, values[0])
+		fmt.Fprintf(&buffer, "        %s: %s,\n", literal.Python(key), literal.Python(values[0]))
 		count++
 	}
 	buffer.WriteString("    })\n    ")
@@ -234,7 +312,6 @@ func (self *PythonGenerator) SetFormForBody() {
 	}
 	if len(files) > 0 {
 		if len(fields) > 0 {
-			buffer.WriteString("    ")
 			self.Body = "encode_multipart_formdata(fields, files)"
 		} else {
 			self.Body = "encode_multipart_formdata([], files)"
@@ -255,91 +332,116 @@ func (self *PythonGenerator) SetFormForBody() {
 	Dispatcher function of curl command
 	This is an exported function and called from httpgen.
 */
-func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}) {
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
 	generator := NewPythonGenerator(options)
 
 	if options.ProcessedData.HasData() {
 		if options.Get {
-			generator.SetDataForUrl()
+			if err := generator.SetDataForUrl(); err != nil {
+				return "", nil, err
+			}
 		} else {
-			generator.Options.InsertContentTypeHeader("application/x-www-form-urlencoded")
-			generator.SetDataForBody()
+			generator.Options.InsertDataContentTypeHeader()
+			if err := generator.SetDataForBody(); err != nil {
+				return "", nil, err
+			}
 		}
 	} else if options.ProcessedData.HasForm() {
 		generator.SetFormForBody()
 	}
-	if generator.Options.User != "" {
-		generator.specialHeaders = append(generator.specialHeaders, fmt.Sprintf("        'Authorization': 'Basic %%s' %% base64.b64encode(b'%s').decode('ascii'),\n", generator.Options.User))
+	if generator.Options.UseBasicAuth() {
+		generator.specialHeaders = append(generator.specialHeaders, fmt.Sprintf("        'Authorization': 'Basic %%s' %% base64.b64encode(%s).decode('ascii'),\n", literal.PythonBytes(generator.Options.User)))
 		generator.Modules["base64"] = true
 
+	} else if generator.Options.UseDigestAuth() {
+		generator.AddDigestCode()
+	}
+	if len(generator.Options.Cookies()) != 0 {
+		generator.specialHeaders = append(generator.specialHeaders, fmt.Sprintf("        'Cookie': %s,\n", literal.Python(generator.Options.CookieString())))
+	}
+	if generator.Options.UseCookieJar() {
+		generator.Modules["http.cookiejar"] = true
+		generator.Modules["os"] = true
+		generator.Modules["urllib.request"] = true
 	}
 
-	return "full", *generator
+	return "full", *generator, nil
 }
 
 // helper functions
 
-func NewStringForData(generator *PythonGenerator, data *common.DataOption) (string, string) {
+func NewStringForData(generator *PythonGenerator, data *common.DataOption) (string, string, error) {
 	var result string
 	var name string
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = ""
-			name = fmt.Sprintf("open(r'%s').read().replace('\\n', '')", data.Value[1:])
+			name = fmt.Sprintf("open(%s).read().replace('\\n', '')", literal.Python(data.Value[1:]))
 		} else {
 			result = ""
-			name = fmt.Sprintf("r'%s'", strings.Replace(data.Value, "\n", "", -1))
+			name = literal.Python(strings.Replace(data.Value, "\n", "", -1))
 		}
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = ""
-			name = fmt.Sprintf("open(r'%s').read()", data.Value[1:])
+			name = fmt.Sprintf("open(%s).read()", literal.Python(data.Value[1:]))
 		} else {
 			result = ""
-			name = fmt.Sprintf("'%s'", data.Value)
+			name = literal.Python(data.Value)
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = ""
-			name = fmt.Sprintf("urllib.parse.quote_plus(open(r'%s').read())", data.Value[1:])
+		fieldName, content, isFile := data.UrlEncodeParts()
+		result = ""
+		if isFile {
+			name = fmt.Sprintf("%surllib.parse.quote_plus(open(%s).read())", urlEncodeName(fieldName), literal.Python(content))
 		} else {
-			result = ""
-			name = fmt.Sprintf("urllib.parse.quote_plus(r'%s')", data.Value)
+			name = fmt.Sprintf("%surllib.parse.quote_plus(%s)", urlEncodeName(fieldName), literal.Python(content))
 		}
 		generator.Modules["urllib.parse"] = true
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return "", "", common.UnexpectedDataError("python", data)
 	}
-	return result, name
+	return result, name, nil
 }
 
-func StringForData(generator *PythonGenerator, data *common.DataOption) string {
+func StringForData(generator *PythonGenerator, data *common.DataOption) (string, error) {
 	var result string
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf("        open('%s').read().replace('\\n', ''),\n", data.Value[1:])
+		if data.UseExternalFile() {
+			result = fmt.Sprintf("        open(%s).read().replace('\\n', ''),\n", literal.Python(data.Value[1:]))
 		} else {
-			result = fmt.Sprintf("        r'%s',\n", strings.Replace(data.Value, "\n", "", -1))
+			result = fmt.Sprintf("        %s,\n", literal.Python(strings.Replace(data.Value, "\n", "", -1)))
 		}
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf("        open('%s').read(),\n", data.Value[1:])
+		if data.UseExternalFile() {
+			result = fmt.Sprintf("        open(%s).read(),\n", literal.Python(data.Value[1:]))
 		} else {
-			result = fmt.Sprintf("        r'%s',\n", data.Value)
+			result = fmt.Sprintf("        %s,\n", literal.Python(data.Value))
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf("        urllib.parse.quote_plus(open(r'%s').read()),\n", data.Value[1:])
+		name, content, isFile := data.UrlEncodeParts()
+		if isFile {
+			result = fmt.Sprintf("        %surllib.parse.quote_plus(open(%s).read()),\n", urlEncodeName(name), literal.Python(content))
 		} else {
-			result = fmt.Sprintf("        urllib.parse.quote_plus(r'%s'),\n", data.Value)
+			result = fmt.Sprintf("        %surllib.parse.quote_plus(%s),\n", urlEncodeName(name), literal.Python(content))
 		}
 		generator.Modules["urllib.parse"] = true
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return "", common.UnexpectedDataError("python", data)
 	}
-	return result
+	return result, nil
+}
+
+/*
+	curl doesn't encode the name of --data-urlencode.
+*/
+func urlEncodeName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return literal.Python(name+"=") + " + "
 }
 
 func FormString(generator *PythonGenerator, data *common.DataOption) string {
@@ -347,16 +449,12 @@ func FormString(generator *PythonGenerator, data *common.DataOption) string {
 	switch data.Type {
 	case common.FormType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
 		if strings.HasPrefix(field[1], "@") {
 			var buffer bytes.Buffer
 			fragments := strings.Split(field[1][1:], ";")
 
 			// field name, source file name
-			fmt.Fprintf(&buffer, "        (r'%s', r'%s', ", field[0], fragments[0])
+			fmt.Fprintf(&buffer, "        (%s, %s, ", literal.Python(field[0]), literal.Python(fragments[0]))
 
 			var contentType string
 			sentFileName := fragments[0]
@@ -368,13 +466,13 @@ func FormString(generator *PythonGenerator, data *common.DataOption) string {
 				}
 			}
 			// sent file name
-			fmt.Fprintf(&buffer, "r'%s', ", sentFileName)
+			fmt.Fprintf(&buffer, "%s, ", literal.Python(sentFileName))
 
 			// sent file name
 			if contentType != "" {
-				fmt.Fprintf(&buffer, "r'%s'", contentType)
+				buffer.WriteString(literal.Python(contentType))
 			} else {
-				fmt.Fprintf(&buffer, "mimetypes.guess_type(r'%s')[0] or 'application/octet-stream'", fragments[0])
+				fmt.Fprintf(&buffer, "mimetypes.guess_type(%s)[0] or 'application/octet-stream'", literal.Python(fragments[0]))
 				generator.Modules["mimetypes"] = true
 			}
 			buffer.WriteString("),\n")
@@ -385,7 +483,7 @@ func FormString(generator *PythonGenerator, data *common.DataOption) string {
 			fragments := strings.Split(field[1][1:], ";")
 
 			// field name, content
-			fmt.Fprintf(&buffer, "        (r'%s', open(r'%s').read(), ", field[0], fragments[0])
+			fmt.Fprintf(&buffer, "        (%s, open(%s).read(), ", literal.Python(field[0]), literal.Python(fragments[0]))
 
 			var contentType string
 			for _, fragment := range fragments[1:] {
@@ -396,20 +494,16 @@ func FormString(generator *PythonGenerator, data *common.DataOption) string {
 			if contentType == "" {
 				buffer.WriteString("None")
 			} else {
-				fmt.Fprintf(&buffer, "r'%s'", contentType)
+				buffer.WriteString(literal.Python(contentType))
 			}
 			buffer.WriteString("),\n")
 			result = buffer.String()
 		} else {
-			result = fmt.Sprintf("        (\"%s\", \"%s\", None),\n", field[0], field[1])
+			result = fmt.Sprintf("        (%s, %s, None),\n", literal.Python(field[0]), literal.Python(field[1]))
 		}
 	case common.FormStringType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
-		result = fmt.Sprintf("        (\"%s\", \"%s\", None),\n", field[0], field[1])
+		result = fmt.Sprintf("        (%s, %s, None),\n", literal.Python(field[0]), literal.Python(field[1]))
 	}
 	return result
 }
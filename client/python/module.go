@@ -0,0 +1,230 @@
+package python
+
+import (
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/literal"
+	"regexp"
+	"strings"
+)
+
+/*
+	Request in a module of http.client.
+*/
+type PythonFunction struct {
+	PythonGenerator
+	Name       string
+	Title      string
+	Connection string
+}
+
+/*
+	Connection that main() opens and passes to functions. Requests to the same host share it.
+*/
+type PythonConnection struct {
+	Name   string
+	Class  string
+	Host   string
+	Tunnel string
+}
+
+/*
+	Request in a module of requests. It takes the shared session.
+*/
+type RequestsFunction struct {
+	RequestsGenerator
+	Name  string
+	Title string
+}
+
+/*
+	Module that has functions of requests. Imports and helper functions like encode_multipart_formdata() are shared.
+	Functions is []PythonFunction or []RequestsFunction.
+*/
+type PythonModule struct {
+	Modules     map[string]bool
+	Functions   interface{}
+	Connections []PythonConnection
+	HasMain     bool
+
+	declarations []string
+}
+
+var reservedNames = map[string]bool{
+	// variables of generated functions
+	"session": true, "conn": true, "res": true, "headers": true, "body": true, "files": true, "fields": true,
+	"jar": true, "values": true, "request": true, "main": true,
+	// modules
+	"base64": true, "http": true, "json": true, "os": true, "requests": true, "urllib": true,
+	// keywords
+	"and": true, "as": true, "assert": true, "async": true, "await": true, "break": true, "class": true,
+	"continue": true, "def": true, "del": true, "elif": true, "else": true, "except": true, "finally": true,
+	"for": true, "from": true, "global": true, "if": true, "import": true, "in": true, "is": true,
+	"lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true, "raise": true, "return": true,
+	"try": true, "while": true, "with": true, "yield": true,
+}
+
+/*
+	Name of the function parameter like "user_id".
+*/
+func ParameterName(name string) string {
+	result := common.SnakeCase(common.IdentifierWords(name))
+	if reservedNames[result] {
+		result += "_value"
+	}
+	return result
+}
+
+func parameterNames(options *common.CurlOptions) []string {
+	var names []string
+	for _, parameter := range options.FunctionParameters() {
+		names = append(names, ParameterName(parameter.Name))
+	}
+	return names
+}
+
+func environmentVariables(options *common.CurlOptions) []string {
+	var variables []string
+	for _, parameter := range options.FunctionParameters() {
+		variables = append(variables, fmt.Sprintf("os.environ['%s']", parameter.EnvironmentVariable()))
+	}
+	return variables
+}
+
+/*
+	Parameters of the function like "conn, user_id, token".
+*/
+func (self PythonFunction) Arguments() string {
+	return strings.Join(append([]string{"conn"}, parameterNames(self.Options)...), ", ")
+}
+
+/*
+	Function that main() calls with the connection to the host. Parameters are read from environment variables.
+*/
+func (self PythonFunction) Sender() string {
+	return fmt.Sprintf("lambda: %s(%s)", self.Name, strings.Join(append([]string{self.Connection}, environmentVariables(self.Options)...), ", "))
+}
+
+/*
+	Parameters of the function like "session, user_id, token".
+*/
+func (self RequestsFunction) Arguments() string {
+	return strings.Join(append([]string{"session"}, parameterNames(self.Options)...), ", ")
+}
+
+func (self RequestsFunction) Sender() string {
+	if len(self.Options.FunctionParameters()) == 0 {
+		return self.Name
+	}
+	return fmt.Sprintf("lambda session: %s(%s)", self.Name, strings.Join(append([]string{"session"}, environmentVariables(self.Options)...), ", "))
+}
+
+func (self PythonModule) AdditionalDeclaration() string {
+	return strings.Join(self.declarations, "")
+}
+
+var declaredFunction = regexp.MustCompile(`def (\w+)\(`)
+
+/*
+	Add helper functions that are not declared yet. Helpers that have the same name and different code are warned.
+*/
+func (self *PythonModule) declare(options *common.CurlOptions, declarations []string, helpers map[string]string, used map[string]bool) {
+	for _, declaration := range declarations {
+		name := declaredFunction.FindStringSubmatch(declaration)[1]
+		if existing, ok := helpers[name]; !ok {
+			helpers[name] = declaration
+			used[name] = true
+			self.declarations = append(self.declarations, declaration)
+		} else if existing != declaration {
+			options.AddWarning("%s() is shared by all requests. The one of the first request is used.", name)
+		}
+	}
+}
+
+/*
+	Name of the connection variable of main() like "conn" or "conn2". Connections are added for new hosts.
+*/
+func (self *PythonModule) connection(generator PythonGenerator) string {
+	connection := PythonConnection{Class: generator.ConnectionClass(), Host: generator.Host()}
+	if generator.Options.Proxy != "" {
+		connection.Tunnel = literal.Python(generator.Options.ParsedUrl().Host)
+	}
+	for _, existing := range self.Connections {
+		if existing.Class == connection.Class && existing.Host == connection.Host && existing.Tunnel == connection.Tunnel {
+			return existing.Name
+		}
+	}
+	connection.Name = "conn"
+	if len(self.Connections) > 0 {
+		connection.Name = fmt.Sprintf("conn%d", len(self.Connections)+1)
+	}
+	self.Connections = append(self.Connections, connection)
+	return connection.Name
+}
+
+func functionName(request *common.NamedRequest, used map[string]bool) (string, string) {
+	names := append(append([]string(nil), request.Groups...), request.Name)
+	return common.UniqueName(common.SnakeCase(common.IdentifierWords(names...)), used), strings.Join(names, " / ")
+}
+
+/*
+	Create a module of http.client from requests. Functions take a connection and return the response.
+	main() opens one connection for each host (and proxy) and passes it to the functions of the host.
+	packageName is ignored because the module name of Python is the file name.
+*/
+func ProcessCurlCommandsForModule(requests []common.NamedRequest, packageName string, hasMain bool) (string, interface{}, error) {
+	module := PythonModule{Modules: make(map[string]bool), HasMain: hasMain}
+	used := map[string]bool{"main": true}
+	helpers := make(map[string]string)
+	var functions []PythonFunction
+	for i := range requests {
+		_, context, err := ProcessCurlCommand(requests[i].Options)
+		if err != nil {
+			return "", nil, err
+		}
+		generator := context.(PythonGenerator)
+		for key := range generator.Modules {
+			module.Modules[key] = true
+		}
+		if hasMain && len(requests[i].Options.FunctionParameters()) > 0 {
+			module.Modules["os"] = true
+		}
+		module.declare(generator.Options, generator.declarations, helpers, used)
+		functions = append(functions, PythonFunction{PythonGenerator: generator, Connection: module.connection(generator)})
+	}
+	// names are decided after all helpers and connections are known
+	for _, connection := range module.Connections {
+		used[connection.Name] = true
+	}
+	for i := range functions {
+		functions[i].Name, functions[i].Title = functionName(&requests[i], used)
+	}
+	module.Functions = functions
+	return "module", module, nil
+}
+
+/*
+	Create a module of requests. Functions take requests.Session. main() creates the session shared by all functions.
+*/
+func ProcessCurlCommandsForRequestsModule(requests []common.NamedRequest, packageName string, hasMain bool) (string, interface{}, error) {
+	module := PythonModule{Modules: make(map[string]bool), HasMain: hasMain}
+	used := map[string]bool{"main": true}
+	var functions []RequestsFunction
+	for i := range requests {
+		generator, err := newRequestsCommand(requests[i].Options, "requests", true)
+		if err != nil {
+			return "", nil, err
+		}
+		for key := range generator.Modules {
+			module.Modules[key] = true
+		}
+		if hasMain && len(requests[i].Options.FunctionParameters()) > 0 {
+			module.Modules["os"] = true
+		}
+		function := RequestsFunction{RequestsGenerator: *generator}
+		function.Name, function.Title = functionName(&requests[i], used)
+		functions = append(functions, function)
+	}
+	module.Functions = functions
+	return "module", module, nil
+}
@@ -0,0 +1,471 @@
+package python
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/literal"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+/*
+	Generator for third party HTTP libraries: requests and httpx.
+	They have almost same API. Differences are handled by Library field.
+*/
+type RequestsGenerator struct {
+	Options *common.CurlOptions
+	Modules map[string]bool
+	Library string
+
+	// Function is true when the request is written in a function that takes a shared session
+	Function bool
+
+	Prepare               string
+	AdditionalDeclaration string
+	url                   string
+	hasBody               bool
+	arguments             []string
+	clientArguments       []string
+	clientCookies         []string
+}
+
+func NewRequestsGenerator(options *common.CurlOptions, library string) *RequestsGenerator {
+	result := &RequestsGenerator{Options: options, Library: library}
+	result.Modules = make(map[string]bool)
+	result.Modules[library] = true
+	result.url = literal.Python(options.Url)
+
+	return result
+}
+
+//--- Getter methods called from template
+
+func (self RequestsGenerator) IsHttpx() bool {
+	return self.Library == "httpx"
+}
+
+func (self RequestsGenerator) Request() string {
+	var receiver string
+	if self.IsHttpx() {
+		receiver = "client"
+	} else if self.Options.UseCookieJar() || self.Function {
+		receiver = "session"
+	} else {
+		receiver = "requests"
+	}
+	arguments := append([]string{self.url}, self.arguments...)
+
+	method := self.Options.Method()
+	var shortcut bool
+	switch method {
+	case "POST", "PUT", "PATCH":
+		shortcut = true
+	case "GET", "HEAD", "OPTIONS", "DELETE":
+		// httpx doesn't accept request body in these shortcut methods
+		shortcut = !self.IsHttpx() || !self.hasBody
+	}
+	if shortcut {
+		return fmt.Sprintf("%s.%s(%s)", receiver, strings.ToLower(method), strings.Join(arguments, ", "))
+	}
+	return fmt.Sprintf("%s.request(%s, %s)", receiver, literal.Python(method), strings.Join(arguments, ", "))
+}
+
+func (self RequestsGenerator) ClientArguments() string {
+	return strings.Join(self.clientArguments, ", ")
+}
+
+/*
+	Cookies passed by -b option are added to the client after it reads cookie files.
+*/
+func (self RequestsGenerator) PrepareClient() string {
+	var buffer bytes.Buffer
+	for _, cookie := range self.clientCookies {
+		fmt.Fprintf(&buffer, "%s\n        ", cookie)
+	}
+	return buffer.String()
+}
+
+func (self RequestsGenerator) SaveCookie() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	if self.Function && !self.IsHttpx() {
+		// the shared session has received cookies
+		return fmt.Sprintf("for cookie in session.cookies:\n        jar.set_cookie(cookie)\n    jar.save(%s, ignore_discard=True, ignore_expires=True)\n    ", literal.Python(self.Options.CookieJar))
+	}
+	return fmt.Sprintf("jar.save(%s, ignore_discard=True, ignore_expires=True)\n    ", literal.Python(self.Options.CookieJar))
+}
+
+//--- Setter/Getter methods
+
+func (self *RequestsGenerator) addArgument(format string, args ...interface{}) {
+	self.arguments = append(self.arguments, fmt.Sprintf(format, args...))
+}
+
+func (self *RequestsGenerator) addClientArgument(format string, args ...interface{}) {
+	// requests doesn't have client object. All arguments are passed to request method.
+	if self.IsHttpx() {
+		self.clientArguments = append(self.clientArguments, fmt.Sprintf(format, args...))
+	} else {
+		self.addArgument(format, args...)
+	}
+}
+
+func (self *RequestsGenerator) addPrepare(format string, args ...interface{}) {
+	self.Prepare += fmt.Sprintf(format, args...) + "\n    "
+}
+
+func (self *RequestsGenerator) SetHeader() {
+	var keys []string
+	values := make(map[string][]string)
+	for _, header := range self.Options.Headers() {
+		key := strings.ToLower(header[0])
+		if _, ok := values[key]; !ok {
+			keys = append(keys, header[0])
+		}
+		values[key] = append(values[key], header[1])
+	}
+	if len(keys) == 0 {
+		return
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("headers = {\n")
+	for _, key := range keys {
+		fmt.Fprintf(&buffer, "        %s: %s,\n", literal.Python(key), literal.Python(strings.Join(values[strings.ToLower(key)], ", ")))
+	}
+	buffer.WriteString("    }")
+	self.addPrepare("%s", buffer.String())
+	self.addArgument("headers=headers")
+}
+
+/*
+	Simple "key=value" style data is passed as dict. Libraries encode it.
+*/
+func (self *RequestsGenerator) SetDataForForm(argumentName string) {
+	var keys []string
+	entries := make(map[string][]string)
+	for _, data := range self.Options.ProcessedData {
+		for _, pair := range strings.Split(data.Value, "&") {
+			if pair == "" {
+				continue
+			}
+			fragments := strings.SplitN(pair, "=", 2)
+			key, value := fragments[0], fragments[1]
+			if data.Type != common.DataUrlEncodeType {
+				key, _ = url.QueryUnescape(key)
+				value, _ = url.QueryUnescape(value)
+			}
+			if _, ok := entries[key]; !ok {
+				keys = append(keys, key)
+			}
+			entries[key] = append(entries[key], value)
+		}
+	}
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "%s = {\n", argumentName)
+	for _, key := range keys {
+		values := entries[key]
+		if len(values) == 1 {
+			fmt.Fprintf(&buffer, "        %s: %s,\n", literal.Python(key), literal.Python(values[0]))
+		} else {
+			var items []string
+			for _, value := range values {
+				items = append(items, literal.Python(value))
+			}
+			fmt.Fprintf(&buffer, "        %s: [%s],\n", literal.Python(key), strings.Join(items, ", "))
+		}
+	}
+	buffer.WriteString("    }")
+	self.addPrepare("%s", buffer.String())
+	self.addArgument("%s=%s", argumentName, argumentName)
+	self.hasBody = argumentName == "data"
+}
+
+func (self *RequestsGenerator) SetDataForUrl() error {
+	if self.Options.CanUseSimpleForm() {
+		self.SetDataForForm("params")
+		return nil
+	}
+	if err := self.prepareBody(); err != nil {
+		return err
+	}
+	separator := "?"
+	if strings.Contains(self.Options.Url, "?") {
+		separator = "&"
+	}
+	self.url = fmt.Sprintf("%s + %s + body", self.url, literal.Python(separator))
+	return nil
+}
+
+func (self *RequestsGenerator) SetDataForBody() error {
+	if err := self.prepareBody(); err != nil {
+		return err
+	}
+	if self.IsHttpx() {
+		// httpx uses data= only for dict
+		self.addArgument("content=body")
+	} else {
+		self.addArgument("data=body")
+	}
+	self.hasBody = true
+	return nil
+}
+
+func (self *RequestsGenerator) prepareBody() error {
+	if len(self.Options.ProcessedData) == 1 {
+		expression, err := self.dataExpression(&self.Options.ProcessedData[0], true)
+		if err != nil {
+			return err
+		}
+		self.addPrepare("body = %s", expression)
+		return nil
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("body = '&'.join([\n")
+	for _, data := range self.Options.ProcessedData {
+		expression, err := self.dataExpression(&data, false)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&buffer, "        %s,\n", expression)
+	}
+	buffer.WriteString("    ])")
+	self.addPrepare("%s", buffer.String())
+	return nil
+}
+
+/*
+	All fields are passed by files= to send multipart/form-data even if there are no files.
+	File name None means regular field.
+*/
+func (self *RequestsGenerator) SetFormForBody() {
+	var buffer bytes.Buffer
+	buffer.WriteString("files = [\n")
+	for _, data := range self.Options.ProcessedData {
+		field := strings.SplitN(data.Value, "=", 2)
+		var fileName, content string
+		var contentType string
+		if data.Type == common.FormType && (strings.HasPrefix(field[1], "@") || strings.HasPrefix(field[1], "<")) {
+			fragments := strings.Split(field[1][1:], ";")
+			sentFileName := fragments[0]
+			for _, fragment := range fragments[1:] {
+				if strings.HasPrefix(fragment, "filename=") {
+					sentFileName = fragment[9:]
+				} else if strings.HasPrefix(fragment, "type=") {
+					contentType = fragment[5:]
+				}
+			}
+			if field[1][0] == '@' {
+				fileName = literal.Python(sentFileName)
+				content = fmt.Sprintf("open(%s, 'rb')", literal.Python(fragments[0]))
+			} else {
+				fileName = "None"
+				content = fmt.Sprintf("open(%s).read()", literal.Python(fragments[0]))
+			}
+		} else {
+			fileName = "None"
+			content = literal.Python(field[1])
+		}
+		if contentType != "" {
+			fmt.Fprintf(&buffer, "        (%s, (%s, %s, %s)),\n", literal.Python(field[0]), fileName, content, literal.Python(contentType))
+		} else {
+			fmt.Fprintf(&buffer, "        (%s, (%s, %s)),\n", literal.Python(field[0]), fileName, content)
+		}
+	}
+	buffer.WriteString("    ]")
+	self.addPrepare("%s", buffer.String())
+	self.addArgument("files=files")
+	self.hasBody = true
+}
+
+func (self *RequestsGenerator) SetAuth() {
+	user, password := self.Options.UserAndPassword()
+	user, password = literal.Python(user), literal.Python(password)
+	if self.Options.UseBasicAuth() {
+		self.addArgument("auth=(%s, %s)", user, password)
+	} else if self.IsHttpx() {
+		self.addArgument("auth=httpx.DigestAuth(%s, %s)", user, password)
+	} else {
+		self.addArgument("auth=requests.auth.HTTPDigestAuth(%s, %s)", user, password)
+		self.Modules["requests.auth"] = true
+	}
+}
+
+/*
+	Cookie files and cookie jar file are handled by http.cookiejar.MozillaCookieJar.
+	requests needs Session to store received cookies to the jar.
+*/
+func (self *RequestsGenerator) SetCookie() {
+	cookies := self.Options.Cookies()
+	if !self.Options.UseCookieJar() {
+		if len(cookies) == 0 {
+			return
+		}
+		var fragments []string
+		for _, cookie := range cookies {
+			fragments = append(fragments, literal.Python(cookie[0])+": "+literal.Python(cookie[1]))
+		}
+		self.addClientArgument("cookies={%s}", strings.Join(fragments, ", "))
+		return
+	}
+	self.Modules["http.cookiejar"] = true
+	self.Modules["os"] = true
+	self.addPrepare("jar = http.cookiejar.MozillaCookieJar()")
+	for _, fileName := range self.Options.CookieFiles() {
+		self.addPrepare("if os.path.exists(%s):", literal.Python(fileName))
+		self.addPrepare("    jar.load(%s, ignore_discard=True, ignore_expires=True)", literal.Python(fileName))
+	}
+	if len(self.Options.CookieFiles()) != 0 {
+		self.addPrepare("for cookie in jar:")
+		self.addPrepare("    if cookie.expires == 0:")
+		self.addPrepare("        # curl writes session cookie with 0 expiration time")
+		self.addPrepare("        cookie.expires, cookie.discard = None, True")
+	}
+	if self.IsHttpx() {
+		self.clientArguments = append(self.clientArguments, "cookies=jar")
+		for _, cookie := range cookies {
+			self.clientCookies = append(self.clientCookies, fmt.Sprintf("client.cookies.set(%s, %s)", literal.Python(cookie[0]), literal.Python(cookie[1])))
+		}
+	} else if self.Function {
+		self.addPrepare("session.cookies.update(jar)")
+	} else {
+		self.addPrepare("session = requests.Session()")
+		self.addPrepare("session.cookies = jar")
+		if len(cookies) != 0 {
+			var fragments []string
+			for _, cookie := range cookies {
+				fragments = append(fragments, literal.Python(cookie[0])+": "+literal.Python(cookie[1]))
+			}
+			self.addArgument("cookies={%s}", strings.Join(fragments, ", "))
+		}
+	}
+}
+
+func (self *RequestsGenerator) SetProxy() {
+	proxy := self.Options.Proxy
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	if self.IsHttpx() {
+		self.clientArguments = append(self.clientArguments, fmt.Sprintf("proxy=%s", literal.Python(proxy)))
+	} else {
+		self.addArgument("proxies={'http': %s, 'https': %s}", literal.Python(proxy), literal.Python(proxy))
+	}
+}
+
+/*
+	requests' timeout is a pair of connect and read timeouts. httpx has Timeout class.
+*/
+func (self *RequestsGenerator) SetTimeout() {
+	connectTimeout := strconv.FormatFloat(self.Options.ConnectTimeout, 'f', -1, 64)
+	maxTime := strconv.FormatFloat(self.Options.MaxTime, 'f', -1, 64)
+	if self.Options.MaxTime == 0 {
+		maxTime = "None"
+	}
+	if self.Options.ConnectTimeout == 0 {
+		self.addClientArgument("timeout=%s", maxTime)
+	} else if self.IsHttpx() {
+		self.addClientArgument("timeout=httpx.Timeout(%s, connect=%s)", maxTime, connectTimeout)
+	} else {
+		self.addClientArgument("timeout=(%s, %s)", connectTimeout, maxTime)
+	}
+}
+
+/*
+	Python expression of -d, --data-binary and --data-urlencode value.
+	single is true when the expression is used as request body directly.
+*/
+func (self *RequestsGenerator) dataExpression(data *common.DataOption, single bool) (string, error) {
+	switch data.Type {
+	case common.DataAsciiType:
+		if data.UseExternalFile() {
+			return fmt.Sprintf("open(%s).read().replace('\\r', '').replace('\\n', '')", literal.Python(data.Value[1:])), nil
+		}
+		return literal.Python(data.Value), nil
+	case common.DataBinaryType:
+		if data.UseExternalFile() {
+			if !single {
+				return fmt.Sprintf("open(%s).read()", literal.Python(data.Value[1:])), nil
+			} else if self.IsHttpx() {
+				return fmt.Sprintf("open(%s, 'rb').read()", literal.Python(data.Value[1:])), nil
+			}
+			return fmt.Sprintf("open(%s, 'rb')", literal.Python(data.Value[1:])), nil
+		}
+		return literal.Python(data.Value), nil
+	case common.DataUrlEncodeType:
+		self.Modules["urllib.parse"] = true
+		name, content, isFile := data.UrlEncodeParts()
+		if isFile {
+			return fmt.Sprintf("%surllib.parse.quote_plus(open(%s).read())", urlEncodeName(name), literal.Python(content)), nil
+		}
+		return fmt.Sprintf("%surllib.parse.quote_plus(%s)", urlEncodeName(name), literal.Python(content)), nil
+	}
+	return "", common.UnexpectedDataError("python."+self.Library, data)
+}
+
+func processRequestsCommand(options *common.CurlOptions, library string) (string, interface{}, error) {
+	generator, err := newRequestsCommand(options, library, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return "full", *generator, nil
+}
+
+func newRequestsCommand(options *common.CurlOptions, library string, function bool) (*RequestsGenerator, error) {
+	generator := NewRequestsGenerator(options, library)
+	generator.Function = function
+
+	if options.ProcessedData.HasData() {
+		if options.Get {
+			if err := generator.SetDataForUrl(); err != nil {
+				return nil, err
+			}
+		} else if options.CanUseSimpleForm() {
+			generator.SetDataForForm("data")
+		} else {
+			generator.Options.InsertDataContentTypeHeader()
+			if err := generator.SetDataForBody(); err != nil {
+				return nil, err
+			}
+		}
+	} else if options.ProcessedData.HasForm() {
+		generator.SetFormForBody()
+	}
+	generator.SetHeader()
+	if options.User != "" {
+		generator.SetAuth()
+	}
+	generator.SetCookie()
+	if options.Proxy != "" {
+		generator.SetProxy()
+	}
+	if options.Insecure {
+		generator.addClientArgument("verify=False")
+	}
+	if options.ConnectTimeout != 0 || options.MaxTime != 0 {
+		generator.SetTimeout()
+	}
+	if options.Http2Flag {
+		if generator.IsHttpx() {
+			generator.clientArguments = append(generator.clientArguments, "http2=True")
+		} else {
+			options.AddWarning("requests doesn't support HTTP/2. --http2 is ignored.")
+		}
+	}
+	return generator, nil
+}
+
+/*
+	Dispatcher functions of curl command
+	These are exported functions and called from httpgen.
+*/
+func ProcessCurlCommandForRequests(options *common.CurlOptions) (string, interface{}, error) {
+	return processRequestsCommand(options, "requests")
+}
+
+func ProcessCurlCommandForHttpx(options *common.CurlOptions) (string, interface{}, error) {
+	return processRequestsCommand(options, "httpx")
+}
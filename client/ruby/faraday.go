@@ -0,0 +1,308 @@
+package ruby
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/literal"
+	"net/url"
+	"strings"
+)
+
+/*
+	Faraday raises an error when run_request receives other methods.
+*/
+var faradayMethods = map[string]bool{
+	"GET":     true,
+	"POST":    true,
+	"PUT":     true,
+	"DELETE":  true,
+	"HEAD":    true,
+	"PATCH":   true,
+	"OPTIONS": true,
+	"TRACE":   true,
+	"CONNECT": true,
+}
+
+type FaradayGenerator struct {
+	rubyCode
+	Options *common.CurlOptions
+
+	AdditionalDeclaration string
+	connection            []string
+	requestOptions        []string
+	middleware            []string
+	prepareRequest        []string
+	headers               [][]string
+	useHeadersVariable    bool
+	body                  string
+}
+
+func NewFaradayGenerator(options *common.CurlOptions) *FaradayGenerator {
+	result := &FaradayGenerator{Options: options}
+	result.requires = map[string]bool{"faraday": true}
+	result.gems = map[string]bool{"faraday": true}
+	result.connection = []string{fmt.Sprintf("url: %s", literal.Ruby(options.Url))}
+	result.body = "nil"
+	return result
+}
+
+//--- Getter methods called from template
+
+func (self FaradayGenerator) ConnectionArguments() string {
+	arguments := self.connection
+	if len(self.requestOptions) > 0 {
+		arguments = append(append([]string(nil), arguments...), fmt.Sprintf("request: { %s }", strings.Join(self.requestOptions, ", ")))
+	}
+	return strings.Join(arguments, ", ")
+}
+
+func (self FaradayGenerator) Middleware() string {
+	if len(self.middleware) == 0 {
+		return ""
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString(" do |f|\n")
+	for _, line := range self.middleware {
+		fmt.Fprintf(&buffer, "  %s\n", line)
+	}
+	buffer.WriteString("end")
+	return buffer.String()
+}
+
+func (self FaradayGenerator) PrepareRequest() string {
+	var buffer bytes.Buffer
+	if self.useHeadersVariable {
+		if len(self.headers) == 0 {
+			buffer.WriteString("headers = {}\n")
+		} else {
+			buffer.WriteString("headers = {\n")
+			for _, header := range self.headers {
+				fmt.Fprintf(&buffer, "  %s => %s,\n", literal.Ruby(header[0]), header[1])
+			}
+			buffer.WriteString("}\n")
+		}
+	}
+	for _, line := range self.prepareRequest {
+		fmt.Fprintf(&buffer, "%s\n", line)
+	}
+	return buffer.String()
+}
+
+func (self FaradayGenerator) Request() string {
+	headers := "nil"
+	if self.useHeadersVariable {
+		headers = "headers"
+	} else if len(self.headers) > 0 {
+		var pairs []string
+		for _, header := range self.headers {
+			pairs = append(pairs, fmt.Sprintf("%s => %s", literal.Ruby(header[0]), header[1]))
+		}
+		headers = fmt.Sprintf("{ %s }", strings.Join(pairs, ", "))
+	}
+	return fmt.Sprintf("conn.run_request(:%s, nil, %s, %s)", strings.ToLower(self.Options.Method()), self.body, headers)
+}
+
+func (self FaradayGenerator) Authenticate() string {
+	if !self.Options.UseDigestAuth() {
+		return ""
+	}
+	user, password := self.Options.UserAndPassword()
+	var buffer bytes.Buffer
+	buffer.WriteString("if response.status == 401\n")
+	fmt.Fprintf(&buffer, "  headers[\"Authorization\"] = digest_authorization(response.headers[\"WWW-Authenticate\"], %s, response.env.url.request_uri, %s, %s)\n",
+		literal.Ruby(self.Options.Method()), literal.Ruby(user), literal.Ruby(password))
+	fmt.Fprintf(&buffer, "  response = %s\n", self.Request())
+	buffer.WriteString("end\n")
+	return buffer.String()
+}
+
+func (self FaradayGenerator) SaveCookies() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("jar.parse(response.headers[\"Set-Cookie\"], conn.url_prefix) if response.headers[\"Set-Cookie\"]\n")
+	fmt.Fprintf(&buffer, "jar.save(%s, format: :cookiestxt, session: true)\n", literal.Ruby(self.Options.CookieJar))
+	return buffer.String()
+}
+
+//--- Preparing Ruby source code methods
+
+/*
+	Connection parameters are added to the URL. Array values are sent as duplicated keys by FlatParamsEncoder.
+*/
+func (self *FaradayGenerator) SetDataForUrl() error {
+	if !canUseFormPairs(self.Options) {
+		separator := "?"
+		if strings.Contains(self.Options.Url, "?") {
+			separator = "&"
+		}
+		query, err := self.stringBody(self.Options)
+		if err != nil {
+			return err
+		}
+		self.connection[0] = fmt.Sprintf("url: %s + %s", literal.Ruby(self.Options.Url+separator), query)
+		return nil
+	}
+	var keys []string
+	values := make(map[string][]string)
+	for _, pair := range formPairs(self.Options) {
+		if _, ok := values[pair[0]]; !ok {
+			keys = append(keys, pair[0])
+		}
+		values[pair[0]] = append(values[pair[0]], literal.Ruby(pair[1]))
+	}
+	var entries []string
+	duplicated := false
+	for _, key := range keys {
+		if len(values[key]) == 1 {
+			entries = append(entries, fmt.Sprintf("%s => %s", literal.Ruby(key), values[key][0]))
+		} else {
+			duplicated = true
+			entries = append(entries, fmt.Sprintf("%s => [%s]", literal.Ruby(key), strings.Join(values[key], ", ")))
+		}
+	}
+	self.connection = append(self.connection, fmt.Sprintf("params: { %s }", strings.Join(entries, ", ")))
+	if duplicated {
+		self.requestOptions = append(self.requestOptions, "params_encoder: Faraday::FlatParamsEncoder")
+	}
+	return nil
+}
+
+func (self *FaradayGenerator) SetDataForBody() error {
+	if canUseFormPairs(self.Options) {
+		self.require("uri")
+		self.body = fmt.Sprintf("URI.encode_www_form(%s)", formArray(self.Options))
+	} else {
+		body, err := self.stringBody(self.Options)
+		if err != nil {
+			return err
+		}
+		self.body = body
+	}
+	return nil
+}
+
+/*
+	faraday-multipart encodes Hash body which contains FilePart and ParamPart.
+*/
+func (self *FaradayGenerator) SetFormForBody() {
+	self.gems["faraday-multipart"] = true
+	self.require("faraday/multipart")
+	self.middleware = append(self.middleware, "f.request :multipart")
+	var buffer bytes.Buffer
+	buffer.WriteString("body = {\n")
+	for _, data := range self.Options.ProcessedData {
+		name, fileName, sentFileName, contentType, isFile, isText := formField(&data)
+		if isFile {
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+			fmt.Fprintf(&buffer, "  %s => Faraday::Multipart::FilePart.new(%s, %s, %s),\n", literal.Ruby(name), literal.Ruby(fileName), literal.Ruby(contentType), literal.Ruby(sentFileName))
+		} else if isText && contentType != "" {
+			fmt.Fprintf(&buffer, "  %s => Faraday::Multipart::ParamPart.new(File.read(%s), %s),\n", literal.Ruby(name), literal.Ruby(fileName), literal.Ruby(contentType))
+		} else if isText {
+			fmt.Fprintf(&buffer, "  %s => File.read(%s),\n", literal.Ruby(name), literal.Ruby(fileName))
+		} else {
+			fmt.Fprintf(&buffer, "  %s => %s,\n", literal.Ruby(name), literal.Ruby(strings.SplitN(data.Value, "=", 2)[1]))
+		}
+	}
+	buffer.WriteString("}")
+	self.prepare = append(self.prepare, buffer.String())
+	self.body = "body"
+}
+
+func (self *FaradayGenerator) SetHeader() {
+	for _, header := range self.Options.GroupedHeaders() {
+		self.headers = append(self.headers, []string{header.Key, literal.Ruby(strings.Join(header.Values, ", "))})
+	}
+}
+
+func (self *FaradayGenerator) SetCookie() {
+	if !self.Options.UseCookieJar() {
+		self.headers = append(self.headers, []string{"Cookie", cookieValue(self.Options, "conn.url_prefix")})
+		return
+	}
+	self.addCookieJarCode(self.Options)
+	self.useHeadersVariable = true
+	self.prepareRequest = append(self.prepareRequest, fmt.Sprintf("cookie = %s", cookieValue(self.Options, "conn.url_prefix")))
+	self.prepareRequest = append(self.prepareRequest, "headers[\"Cookie\"] = cookie unless cookie.empty?")
+}
+
+func (self *FaradayGenerator) SetProxy() {
+	proxy := self.Options.Proxy
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	u, _ := url.Parse(proxy)
+	if strings.HasPrefix(u.Scheme, "socks") {
+		self.Options.AddWarning("Faraday's default adapter doesn't support SOCKS proxy. %s is ignored.", self.Options.Proxy)
+		return
+	}
+	if u.Port() == "" {
+		// curl uses 1080 as default proxy port
+		u.Host = u.Host + ":1080"
+	}
+	self.connection = append(self.connection, fmt.Sprintf("proxy: %s", literal.Ruby(u.String())))
+}
+
+func ProcessCurlCommandForFaraday(options *common.CurlOptions) (string, interface{}, error) {
+	method := options.Method()
+	if !faradayMethods[method] {
+		return "", nil, &common.UnsupportedOptionError{Target: "ruby.faraday", Option: "-X " + method, Reason: "Faraday supports only standard HTTP methods"}
+	}
+	generator := NewFaradayGenerator(options)
+
+	if options.ProcessedData.HasData() {
+		if options.Get {
+			if err := generator.SetDataForUrl(); err != nil {
+				return "", nil, err
+			}
+		} else {
+			options.InsertDataContentTypeHeader()
+			if err := generator.SetDataForBody(); err != nil {
+				return "", nil, err
+			}
+		}
+	} else if options.ProcessedData.HasForm() {
+		generator.SetFormForBody()
+	}
+	generator.SetHeader()
+	if options.UseBasicAuth() {
+		user, password := options.UserAndPassword()
+		generator.middleware = append(generator.middleware, fmt.Sprintf("f.request :authorization, :basic, %s, %s", literal.Ruby(user), literal.Ruby(password)))
+	} else if options.UseDigestAuth() {
+		generator.require("digest", "securerandom")
+		generator.AdditionalDeclaration += digestAuthorizationCode
+		generator.useHeadersVariable = true
+		if generator.body != "nil" && generator.body != "body" {
+			// request body is sent twice
+			generator.prepare = append(generator.prepare, fmt.Sprintf("body = %s", generator.body))
+			generator.body = "body"
+		}
+	}
+	if options.UseCookieJar() || len(options.Cookies()) != 0 {
+		generator.SetCookie()
+	}
+	if options.Proxy != "" {
+		generator.SetProxy()
+	}
+	if options.Insecure {
+		generator.connection = append(generator.connection, "ssl: { verify: false }")
+	}
+	if options.ConnectTimeout != 0 {
+		generator.requestOptions = append(generator.requestOptions, fmt.Sprintf("open_timeout: %s", seconds(options.ConnectTimeout)))
+	}
+	if options.MaxTime != 0 {
+		generator.requestOptions = append(generator.requestOptions, fmt.Sprintf("timeout: %s", seconds(options.MaxTime)))
+	}
+	if options.ProcessedData.UploadFile() != "" {
+		options.AddWarning("Faraday sends Content-Type: application/x-www-form-urlencoded with the file of -T.")
+	}
+	if options.Http2Flag {
+		options.AddWarning("Faraday's default adapter doesn't support HTTP/2. --http2 is ignored.")
+	}
+
+	return "full", *generator, nil
+}
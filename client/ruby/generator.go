@@ -0,0 +1,479 @@
+package ruby
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/literal"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var requestClasses = map[string]string{
+	"GET":     "Get",
+	"POST":    "Post",
+	"PUT":     "Put",
+	"DELETE":  "Delete",
+	"HEAD":    "Head",
+	"PATCH":   "Patch",
+	"OPTIONS": "Options",
+	"TRACE":   "Trace",
+}
+
+/*
+	Net::HTTP and Faraday don't answer Digest challenge. Both generators send the request again with this header.
+*/
+const digestAuthorizationCode = `
+def digest_authorization(challenge, method, uri, username, password)
+  params = challenge.scan(/(\w+)=(?:"([^"]*)"|([^,\s]*))/).to_h { |key, quoted, plain| [key, quoted || plain] }
+  ha1 = Digest::MD5.hexdigest([username, params["realm"], password].join(":"))
+  ha2 = Digest::MD5.hexdigest([method, uri].join(":"))
+  authorization = %(Digest username="#{username}", realm="#{params["realm"]}", nonce="#{params["nonce"]}", uri="#{uri}")
+  if params["qop"]
+    cnonce = SecureRandom.hex(8)
+    response = Digest::MD5.hexdigest([ha1, params["nonce"], "00000001", cnonce, "auth", ha2].join(":"))
+    authorization += %(, qop=auth, nc=00000001, cnonce="#{cnonce}", response="#{response}")
+  else
+    authorization += %(, response="#{Digest::MD5.hexdigest([ha1, params["nonce"], ha2].join(":"))}")
+  end
+  authorization += %(, opaque="#{params["opaque"]}") if params["opaque"]
+  authorization + ", algorithm=MD5"
+end
+`
+
+/*
+	Common parts of Net::HTTP and Faraday generators: required libraries and data expressions.
+*/
+type rubyCode struct {
+	requires map[string]bool
+	gems     map[string]bool
+	prepare  []string
+}
+
+func (self rubyCode) Requires() string {
+	var buffer bytes.Buffer
+	if len(self.gems) > 0 {
+		var gems []string
+		for gem := range self.gems {
+			gems = append(gems, gem)
+		}
+		sort.Strings(gems)
+		fmt.Fprintf(&buffer, "# gem install %s\n", strings.Join(gems, " "))
+	}
+	var requires []string
+	for require := range self.requires {
+		requires = append(requires, require)
+	}
+	sort.Strings(requires)
+	for _, require := range requires {
+		fmt.Fprintf(&buffer, "require %s\n", literal.Ruby(require))
+	}
+	return buffer.String()
+}
+
+func (self rubyCode) Prepare() string {
+	var buffer bytes.Buffer
+	for _, line := range self.prepare {
+		fmt.Fprintf(&buffer, "%s\n", line)
+	}
+	return buffer.String()
+}
+
+func (self *rubyCode) require(names ...string) {
+	for _, name := range names {
+		self.requires[name] = true
+	}
+}
+
+/*
+	URL encoded "content", "=content" and "name@filename" of --data-urlencode can't be a pair of form.
+*/
+func canUseFormPairs(options *common.CurlOptions) bool {
+	if !options.CanUseSimpleForm() {
+		return false
+	}
+	for _, data := range options.ProcessedData {
+		if data.Type == common.DataUrlEncodeType {
+			index := strings.IndexAny(data.Value, "=@")
+			if index < 1 || data.Value[index] == '@' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func formPairs(options *common.CurlOptions) [][]string {
+	var result [][]string
+	for _, data := range options.ProcessedData {
+		for _, pair := range strings.Split(data.Value, "&") {
+			if pair == "" {
+				continue
+			}
+			fragments := strings.SplitN(pair, "=", 2)
+			key, value := fragments[0], fragments[1]
+			if data.Type != common.DataUrlEncodeType {
+				key, _ = url.QueryUnescape(key)
+				value, _ = url.QueryUnescape(value)
+			}
+			result = append(result, []string{key, value})
+		}
+	}
+	return result
+}
+
+/*
+	Array literal for URI.encode_www_form. Array keeps order and duplicated keys.
+*/
+func formArray(options *common.CurlOptions) string {
+	var pairs []string
+	for _, pair := range formPairs(options) {
+		pairs = append(pairs, fmt.Sprintf("[%s, %s]", literal.Ruby(pair[0]), literal.Ruby(pair[1])))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(pairs, ", "))
+}
+
+func (self *rubyCode) stringBody(options *common.CurlOptions) (string, error) {
+	if len(options.ProcessedData) == 1 {
+		return self.dataExpression(&options.ProcessedData[0])
+	}
+	var values []string
+	for _, data := range options.ProcessedData {
+		value, err := self.dataExpression(&data)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, value)
+	}
+	return fmt.Sprintf("[%s].join(\"&\")", strings.Join(values, ", ")), nil
+}
+
+func (self *rubyCode) dataExpression(data *common.DataOption) (string, error) {
+	switch data.Type {
+	case common.DataAsciiType:
+		if data.UseExternalFile() {
+			return fmt.Sprintf("File.read(%s).delete(\"\\r\\n\")", literal.Ruby(data.Value[1:])), nil
+		}
+		return literal.Ruby(data.Value), nil
+	case common.DataBinaryType:
+		if data.UseExternalFile() {
+			return fmt.Sprintf("File.binread(%s)", literal.Ruby(data.Value[1:])), nil
+		}
+		return literal.Ruby(data.Value), nil
+	case common.DataUrlEncodeType:
+		self.require("uri")
+		// curl's rule: "content", "=content", "name=content", "@filename" and "name@filename"
+		index := strings.IndexAny(data.Value, "=@")
+		var content string
+		if index == -1 {
+			content = literal.Ruby(data.Value)
+		} else if data.Value[index] == '@' {
+			content = fmt.Sprintf("File.read(%s)", literal.Ruby(data.Value[index+1:]))
+		} else {
+			content = literal.Ruby(data.Value[index+1:])
+		}
+		if index > 0 {
+			return fmt.Sprintf("%s + URI.encode_www_form_component(%s)", literal.Ruby(data.Value[:index]+"="), content), nil
+		}
+		return fmt.Sprintf("URI.encode_www_form_component(%s)", content), nil
+	default:
+		return "", common.UnexpectedDataError("ruby", data)
+	}
+}
+
+/*
+	Parse -F value into field name, file name to read, file name to send, content type and whether it is a file or not.
+*/
+func formField(data *common.DataOption) (name, fileName, sentFileName, contentType string, isFile bool, isText bool) {
+	field := strings.SplitN(data.Value, "=", 2)
+	name = field[0]
+	if data.Type != common.FormType || !(strings.HasPrefix(field[1], "@") || strings.HasPrefix(field[1], "<")) {
+		return name, "", "", "", false, false
+	}
+	fragments := strings.Split(field[1][1:], ";")
+	fileName = fragments[0]
+	sentFileName = fragments[0]
+	for _, fragment := range fragments[1:] {
+		if strings.HasPrefix(fragment, "filename=") {
+			sentFileName = fragment[9:]
+		} else if strings.HasPrefix(fragment, "type=") {
+			contentType = fragment[5:]
+		}
+	}
+	return name, fileName, sentFileName, contentType, field[1][0] == '@', field[1][0] == '<'
+}
+
+/*
+	Netscape format cookie files are read and written by http-cookie gem.
+*/
+func (self *rubyCode) addCookieJarCode(options *common.CurlOptions) {
+	self.gems["http-cookie"] = true
+	self.require("http-cookie")
+	self.prepare = append(self.prepare, "jar = HTTP::CookieJar.new")
+	for _, fileName := range options.CookieFiles() {
+		self.prepare = append(self.prepare, fmt.Sprintf("jar.load(%s, :cookiestxt) if File.exist?(%s)", literal.Ruby(fileName), literal.Ruby(fileName)))
+	}
+}
+
+/*
+	Cookie header value. Cookies passed by -b option are added after cookies in the jar.
+*/
+func cookieValue(options *common.CurlOptions, uri string) string {
+	if !options.UseCookieJar() {
+		return literal.Ruby(options.CookieString())
+	}
+	if len(options.Cookies()) == 0 {
+		return fmt.Sprintf("HTTP::Cookie.cookie_value(jar.cookies(%s))", uri)
+	}
+	return fmt.Sprintf("[HTTP::Cookie.cookie_value(jar.cookies(%s)), %s].reject(&:empty?).join(\"; \")", uri, literal.Ruby(options.CookieString()))
+}
+
+func seconds(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+//--- Net::HTTP
+
+type NetHttpGenerator struct {
+	rubyCode
+	Options *common.CurlOptions
+
+	AdditionalDeclaration string
+	modifyRequest         []string
+	startArguments        []string
+	hasBody               bool
+}
+
+func NewNetHttpGenerator(options *common.CurlOptions) *NetHttpGenerator {
+	result := &NetHttpGenerator{Options: options}
+	result.requires = map[string]bool{"net/http": true}
+	result.gems = make(map[string]bool)
+	return result
+}
+
+//--- Getter methods called from template
+
+func (self NetHttpGenerator) Url() string {
+	return literal.Ruby(self.Options.Url)
+}
+
+func (self NetHttpGenerator) NewRequest() string {
+	method := self.Options.Method()
+	if class, ok := requestClasses[method]; ok {
+		return fmt.Sprintf("Net::HTTP::%s.new(uri)", class)
+	}
+	return fmt.Sprintf("Net::HTTPGenericRequest.new(%s, %t, true, uri)", literal.Ruby(method), self.hasBody)
+}
+
+func (self NetHttpGenerator) ModifyRequest() string {
+	var buffer bytes.Buffer
+	for _, line := range self.modifyRequest {
+		fmt.Fprintf(&buffer, "%s\n", line)
+	}
+	return buffer.String()
+}
+
+func (self NetHttpGenerator) StartArguments() string {
+	arguments := append([]string{"uri.hostname", "uri.port"}, self.startArguments...)
+	return strings.Join(arguments, ", ")
+}
+
+func (self NetHttpGenerator) Authenticate() string {
+	if !self.Options.UseDigestAuth() {
+		return ""
+	}
+	user, password := self.Options.UserAndPassword()
+	var buffer bytes.Buffer
+	buffer.WriteString("if response.code == \"401\"\n")
+	fmt.Fprintf(&buffer, "    request[\"Authorization\"] = digest_authorization(response[\"WWW-Authenticate\"], %s, uri.request_uri, %s, %s)\n",
+		literal.Ruby(self.Options.Method()), literal.Ruby(user), literal.Ruby(password))
+	buffer.WriteString("    response = http.request(request)\n")
+	buffer.WriteString("  end\n  ")
+	return buffer.String()
+}
+
+func (self NetHttpGenerator) SaveCookies() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("response.get_fields(\"Set-Cookie\")&.each { |value| jar.parse(value, uri) }\n  ")
+	fmt.Fprintf(&buffer, "jar.save(%s, format: :cookiestxt, session: true)\n  ", literal.Ruby(self.Options.CookieJar))
+	return buffer.String()
+}
+
+//--- Preparing Ruby source code methods
+
+func (self *NetHttpGenerator) SetDataForUrl() error {
+	self.require("uri")
+	var query string
+	if canUseFormPairs(self.Options) {
+		query = fmt.Sprintf("URI.encode_www_form(%s)", formArray(self.Options))
+	} else {
+		body, err := self.stringBody(self.Options)
+		if err != nil {
+			return err
+		}
+		query = body
+	}
+	if strings.Contains(self.Options.Url, "?") {
+		self.prepare = append(self.prepare, fmt.Sprintf("uri.query = [uri.query, %s].join(\"&\")", query))
+	} else {
+		self.prepare = append(self.prepare, fmt.Sprintf("uri.query = %s", query))
+	}
+	return nil
+}
+
+func (self *NetHttpGenerator) SetDataForBody() error {
+	self.hasBody = true
+	if canUseFormPairs(self.Options) {
+		self.require("uri")
+		self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("request.body = URI.encode_www_form(%s)", formArray(self.Options)))
+	} else {
+		body, err := self.stringBody(self.Options)
+		if err != nil {
+			return err
+		}
+		self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("request.body = %s", body))
+	}
+	return nil
+}
+
+/*
+	Net::HTTP encodes multipart/form-data by itself. Files are read before sending to be able to resend them.
+*/
+func (self *NetHttpGenerator) SetFormForBody() {
+	self.hasBody = true
+	var buffer bytes.Buffer
+	buffer.WriteString("request.set_form([\n")
+	for _, data := range self.Options.ProcessedData {
+		name, fileName, sentFileName, contentType, isFile, isText := formField(&data)
+		if isFile {
+			if contentType != "" {
+				fmt.Fprintf(&buffer, "  [%s, File.binread(%s), { filename: %s, content_type: %s }],\n", literal.Ruby(name), literal.Ruby(fileName), literal.Ruby(sentFileName), literal.Ruby(contentType))
+			} else {
+				fmt.Fprintf(&buffer, "  [%s, File.binread(%s), { filename: %s }],\n", literal.Ruby(name), literal.Ruby(fileName), literal.Ruby(sentFileName))
+			}
+		} else if isText {
+			if contentType != "" {
+				self.Options.AddWarning("Net::HTTP doesn't send Content-Type of text field. type=%s is ignored.", contentType)
+			}
+			fmt.Fprintf(&buffer, "  [%s, File.read(%s)],\n", literal.Ruby(name), literal.Ruby(fileName))
+		} else {
+			fmt.Fprintf(&buffer, "  [%s, %s],\n", literal.Ruby(name), literal.Ruby(strings.SplitN(data.Value, "=", 2)[1]))
+		}
+	}
+	buffer.WriteString("], \"multipart/form-data\")")
+	self.modifyRequest = append(self.modifyRequest, buffer.String())
+}
+
+func (self *NetHttpGenerator) SetHeader() {
+	for _, header := range self.Options.GroupedHeaders() {
+		self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("request[%s] = %s", literal.Ruby(header.Key), literal.Ruby(strings.Join(header.Values, ", "))))
+	}
+}
+
+func (self *NetHttpGenerator) SetCookie() {
+	if self.Options.UseCookieJar() {
+		self.addCookieJarCode(self.Options)
+	}
+	if self.Options.UseCookieJar() {
+		// Net::HTTP sends empty header as is
+		self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("cookie = %s", cookieValue(self.Options, "uri")))
+		self.modifyRequest = append(self.modifyRequest, "request[\"Cookie\"] = cookie unless cookie.empty?")
+	} else {
+		self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("request[\"Cookie\"] = %s", cookieValue(self.Options, "uri")))
+	}
+}
+
+/*
+	Net::HTTP supports only HTTP proxy. User name and password in proxy URL are passed separately.
+*/
+func (self *NetHttpGenerator) SetProxy() {
+	proxy := self.Options.Proxy
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	u, _ := url.Parse(proxy)
+	if strings.HasPrefix(u.Scheme, "socks") {
+		self.Options.AddWarning("Net::HTTP doesn't support SOCKS proxy. %s is ignored.", self.Options.Proxy)
+		return
+	}
+	port := u.Port()
+	if port == "" {
+		// curl uses 1080 as default proxy port
+		port = "1080"
+	}
+	self.startArguments = append(self.startArguments, literal.Ruby(u.Hostname()), port)
+	if u.User != nil {
+		password, _ := u.User.Password()
+		self.startArguments = append(self.startArguments, literal.Ruby(u.User.Username()), literal.Ruby(password))
+	}
+}
+
+func (self *NetHttpGenerator) SetTimeout() {
+	if self.Options.ConnectTimeout != 0 {
+		self.startArguments = append(self.startArguments, fmt.Sprintf("open_timeout: %s", seconds(self.Options.ConnectTimeout)))
+	}
+	if self.Options.MaxTime != 0 {
+		self.startArguments = append(self.startArguments, fmt.Sprintf("read_timeout: %s", seconds(self.Options.MaxTime)))
+	}
+}
+
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
+	generator := NewNetHttpGenerator(options)
+	if err := processNetHttpRequest(generator); err != nil {
+		return "", nil, err
+	}
+	return "full", *generator, nil
+}
+
+func processNetHttpRequest(generator *NetHttpGenerator) error {
+	options := generator.Options
+	if options.ProcessedData.HasData() {
+		if options.Get {
+			if err := generator.SetDataForUrl(); err != nil {
+				return err
+			}
+		} else {
+			options.InsertDataContentTypeHeader()
+			if err := generator.SetDataForBody(); err != nil {
+				return err
+			}
+		}
+	} else if options.ProcessedData.HasForm() {
+		generator.SetFormForBody()
+	}
+	generator.SetHeader()
+	if options.UseBasicAuth() {
+		user, password := options.UserAndPassword()
+		generator.modifyRequest = append(generator.modifyRequest, fmt.Sprintf("request.basic_auth(%s, %s)", literal.Ruby(user), literal.Ruby(password)))
+	} else if options.UseDigestAuth() {
+		generator.require("digest", "securerandom")
+		generator.AdditionalDeclaration += digestAuthorizationCode
+	}
+	if options.UseCookieJar() || len(options.Cookies()) != 0 {
+		generator.SetCookie()
+	}
+	if options.Proxy != "" {
+		generator.SetProxy()
+	}
+	generator.startArguments = append(generator.startArguments, "use_ssl: uri.scheme == \"https\"")
+	if options.Insecure {
+		generator.require("openssl")
+		generator.startArguments = append(generator.startArguments, "verify_mode: OpenSSL::SSL::VERIFY_NONE")
+	}
+	if options.ConnectTimeout != 0 || options.MaxTime != 0 {
+		generator.SetTimeout()
+	}
+	if options.ProcessedData.UploadFile() != "" {
+		options.AddWarning("Net::HTTP sends Content-Type: application/x-www-form-urlencoded with the file of -T.")
+	}
+	if options.Http2Flag {
+		options.AddWarning("Net::HTTP doesn't support HTTP/2. --http2 is ignored.")
+	}
+
+	return nil
+}
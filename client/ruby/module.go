@@ -0,0 +1,165 @@
+package ruby
+
+import (
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"strings"
+)
+
+/*
+	Request in a module of Net::HTTP. It is written as a method that returns the response.
+*/
+type NetHttpFunction struct {
+	NetHttpGenerator
+	Name  string
+	Title string
+}
+
+/*
+	Module that has methods of requests. Required libraries and helper methods are shared.
+*/
+type NetHttpModule struct {
+	rubyCode
+	Functions []NetHttpFunction
+	HasMain   bool
+
+	declarations []string
+}
+
+var keywords = map[string]bool{
+	"alias": true, "and": true, "begin": true, "break": true, "case": true, "class": true, "def": true,
+	"defined": true, "do": true, "else": true, "elsif": true, "end": true, "ensure": true, "false": true,
+	"for": true, "if": true, "in": true, "module": true, "next": true, "nil": true, "not": true, "or": true,
+	"redo": true, "rescue": true, "retry": true, "return": true, "self": true, "super": true, "then": true,
+	"true": true, "undef": true, "unless": true, "until": true, "when": true, "while": true, "yield": true,
+}
+
+/*
+	Local variables of generated methods. Parameters must not have these names.
+*/
+var localVariables = map[string]bool{
+	"uri": true, "request": true, "response": true, "http": true, "jar": true, "cookie": true,
+}
+
+/*
+	Methods that generated code calls. Methods of requests must not override them.
+*/
+var calledMethods = map[string]bool{
+	"digest_authorization": true, "send": true, "puts": true, "require": true, "format": true, "raise": true,
+	"p": true, "print": true, "open": true, "exit": true, "method": true,
+}
+
+/*
+	Name of the method parameter like "user_id".
+*/
+func ParameterName(name string) string {
+	result := common.SnakeCase(common.IdentifierWords(name))
+	if keywords[result] || localVariables[result] {
+		result += "_value"
+	}
+	return result
+}
+
+/*
+	Parameters of the method like "(user_id, token)". It is empty when the method doesn't have parameters.
+*/
+func (self NetHttpFunction) Arguments() string {
+	var arguments []string
+	for _, parameter := range self.Options.FunctionParameters() {
+		arguments = append(arguments, ParameterName(parameter.Name))
+	}
+	if len(arguments) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("(%s)", strings.Join(arguments, ", "))
+}
+
+/*
+	Call of the method in main code. Parameters are read from environment variables.
+*/
+func (self NetHttpFunction) Sender() string {
+	var arguments []string
+	for _, parameter := range self.Options.FunctionParameters() {
+		arguments = append(arguments, fmt.Sprintf("ENV.fetch('%s')", parameter.EnvironmentVariable()))
+	}
+	if len(arguments) == 0 {
+		return fmt.Sprintf("-> { %s }", self.Name)
+	}
+	return fmt.Sprintf("-> { %s(%s) }", self.Name, strings.Join(arguments, ", "))
+}
+
+/*
+	Code of the program is indented one more level in the method.
+*/
+func (self NetHttpFunction) Prepare() string {
+	return indent(self.NetHttpGenerator.Prepare())
+}
+
+func (self NetHttpFunction) ModifyRequest() string {
+	return indent(self.NetHttpGenerator.ModifyRequest())
+}
+
+func (self NetHttpFunction) Authenticate() string {
+	return indent(self.NetHttpGenerator.Authenticate())
+}
+
+func (self NetHttpFunction) SaveCookies() string {
+	return indent(self.NetHttpGenerator.SaveCookies())
+}
+
+func indent(code string) string {
+	return strings.Replace(code, "\n", "\n  ", -1)
+}
+
+func (self NetHttpModule) AdditionalDeclaration() string {
+	return strings.Join(self.declarations, "")
+}
+
+/*
+	Create a module of Net::HTTP from requests. Each method starts its own session and returns the response.
+	Main code runs only when the file is executed directly. packageName is ignored.
+*/
+func ProcessCurlCommandsForModule(requests []common.NamedRequest, packageName string, hasMain bool) (string, interface{}, error) {
+	module := NetHttpModule{HasMain: hasMain}
+	module.requires = make(map[string]bool)
+	module.gems = make(map[string]bool)
+	used := make(map[string]bool)
+	for name := range keywords {
+		used[name] = true
+	}
+	for name := range calledMethods {
+		used[name] = true
+	}
+	for i := range requests {
+		generator := NewNetHttpGenerator(requests[i].Options)
+		if err := processNetHttpRequest(generator); err != nil {
+			return "", nil, err
+		}
+		for key := range generator.requires {
+			module.requires[key] = true
+		}
+		for key := range generator.gems {
+			module.gems[key] = true
+		}
+		// the helper method is a constant of this package
+		if generator.AdditionalDeclaration != "" && !contains(module.declarations, generator.AdditionalDeclaration) {
+			module.declarations = append(module.declarations, generator.AdditionalDeclaration)
+		}
+		names := append(append([]string(nil), requests[i].Groups...), requests[i].Name)
+		module.Functions = append(module.Functions, NetHttpFunction{
+			NetHttpGenerator: *generator,
+			Name:             common.UniqueName(common.SnakeCase(common.IdentifierWords(names...)), used),
+			Title:            strings.Join(names, " / "),
+		})
+	}
+	return "module", module, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,527 @@
+package rust
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/literal"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type RustGenerator struct {
+	Options *common.CurlOptions
+	Async   bool
+
+	AdditionalDeclaration string
+	url                   string
+	prepare               []string
+	form                  []string
+	clientBuilder         []string
+	requestBuilder        []string
+	features              map[string]bool
+	crates                map[string]string
+}
+
+func NewRustGenerator(options *common.CurlOptions, async bool) *RustGenerator {
+	result := &RustGenerator{Options: options, Async: async}
+	result.url = literal.Rust(options.Url)
+	result.features = make(map[string]bool)
+	result.crates = make(map[string]string)
+	if async {
+		result.crates["tokio"] = "{ version = \"1\", features = [\"full\"] }"
+	} else {
+		result.features["blocking"] = true
+	}
+	return result
+}
+
+//--- Getter methods called from template
+
+/*
+	Dependencies for Cargo.toml.
+*/
+func (self RustGenerator) Dependencies() string {
+	var features []string
+	for feature := range self.features {
+		features = append(features, literal.Rust(feature))
+	}
+	sort.Strings(features)
+	var buffer bytes.Buffer
+	if len(features) > 0 {
+		fmt.Fprintf(&buffer, "// reqwest = { version = \"0.12\", features = [%s] }\n", strings.Join(features, ", "))
+	} else {
+		buffer.WriteString("// reqwest = \"0.12\"\n")
+	}
+	var crates []string
+	for crate := range self.crates {
+		crates = append(crates, crate)
+	}
+	sort.Strings(crates)
+	for _, crate := range crates {
+		fmt.Fprintf(&buffer, "// %s = %s\n", crate, self.crates[crate])
+	}
+	return buffer.String()
+}
+
+/*
+	Module prefix of reqwest's blocking/async API.
+*/
+func (self RustGenerator) Reqwest() string {
+	if self.Async {
+		return "reqwest"
+	}
+	return "reqwest::blocking"
+}
+
+func (self RustGenerator) Await() string {
+	if self.Async {
+		return ".await"
+	}
+	return ""
+}
+
+func (self RustGenerator) Prepare() string {
+	var buffer bytes.Buffer
+	for _, line := range self.prepare {
+		fmt.Fprintf(&buffer, "%s\n    ", line)
+	}
+	for _, line := range self.form {
+		fmt.Fprintf(&buffer, "%s\n    ", line)
+	}
+	return buffer.String()
+}
+
+func (self RustGenerator) ClientBuilder() string {
+	var buffer bytes.Buffer
+	for _, call := range self.clientBuilder {
+		fmt.Fprintf(&buffer, "\n        %s", call)
+	}
+	return buffer.String()
+}
+
+func (self RustGenerator) Mut() string {
+	if self.Options.UseDigestAuth() {
+		return "mut "
+	}
+	return ""
+}
+
+func (self RustGenerator) Request() string {
+	return self.request("    ", "")
+}
+
+/*
+	Retry the request with Digest authorization header when server returns a challenge.
+	Request body is built again because it is consumed by the first request.
+*/
+func (self RustGenerator) Authenticate() string {
+	if !self.Options.UseDigestAuth() {
+		return ""
+	}
+	user, password := self.Options.UserAndPassword()
+	var buffer bytes.Buffer
+	buffer.WriteString("if res.status() == reqwest::StatusCode::UNAUTHORIZED {\n")
+	buffer.WriteString("        let challenge = res.headers().get(\"WWW-Authenticate\").and_then(|value| value.to_str().ok()).unwrap_or(\"\").to_string();\n")
+	fmt.Fprintf(&buffer, "        let authorization = digest_authorization(&challenge, %s, res.url(), %s, %s);\n", literal.Rust(self.Options.Method()), literal.Rust(user), literal.Rust(password))
+	for _, line := range self.form {
+		fmt.Fprintf(&buffer, "        %s\n", strings.Replace(line, "\n", "\n    ", -1))
+	}
+	fmt.Fprintf(&buffer, "        res = %s\n", self.request("        ", ".header(\"Authorization\", authorization)"))
+	buffer.WriteString("    }\n    ")
+	return buffer.String()
+}
+
+func (self RustGenerator) SaveCookie() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	return fmt.Sprintf("save_cookies(&jar, %s, &url)?;\n    ", literal.Rust(self.Options.CookieJar))
+}
+
+//--- Setter/Getter methods
+
+func (self RustGenerator) request(indent, extraCall string) string {
+	var buffer bytes.Buffer
+	method := self.Options.Method()
+	switch method {
+	case "GET", "POST", "PUT", "PATCH", "DELETE", "HEAD":
+		fmt.Fprintf(&buffer, "client\n%s    .%s(%s)", indent, strings.ToLower(method), self.url)
+	default:
+		fmt.Fprintf(&buffer, "client\n%s    .request(reqwest::Method::from_bytes(b%s)?, %s)", indent, literal.Rust(method), self.url)
+	}
+	calls := self.requestBuilder
+	if extraCall != "" {
+		calls = append(append([]string(nil), calls...), extraCall)
+	}
+	for _, call := range calls {
+		fmt.Fprintf(&buffer, "\n%s    %s", indent, call)
+	}
+	fmt.Fprintf(&buffer, "\n%s    .send()%s?;", indent, self.Await())
+	return buffer.String()
+}
+
+func (self *RustGenerator) SetHeader() {
+	for _, header := range self.Options.Headers() {
+		if strings.ToLower(header[0]) == "accept-encoding" && self.setCompression(header[1]) {
+			continue
+		}
+		self.requestBuilder = append(self.requestBuilder, fmt.Sprintf(".header(%s, %s)", literal.Rust(header[0]), literal.Rust(header[1])))
+	}
+}
+
+/*
+	reqwest sends Accept-Encoding and decodes response by itself when compression is enabled.
+	It returns false when header contains unsupported encoding.
+*/
+func (self *RustGenerator) setCompression(value string) bool {
+	var encodings []string
+	for _, encoding := range strings.Split(value, ",") {
+		encoding = strings.TrimSpace(encoding)
+		if encoding != "gzip" && encoding != "deflate" {
+			return false
+		}
+		encodings = append(encodings, encoding)
+	}
+	for _, encoding := range encodings {
+		if !self.features[encoding] {
+			self.features[encoding] = true
+			self.clientBuilder = append(self.clientBuilder, fmt.Sprintf(".%s(true)", encoding))
+		}
+	}
+	return true
+}
+
+func (self *RustGenerator) SetDataForForm(method string) {
+	var pairs []string
+	for _, data := range self.Options.ProcessedData {
+		for _, pair := range strings.Split(data.Value, "&") {
+			if pair == "" {
+				continue
+			}
+			fragments := strings.SplitN(pair, "=", 2)
+			key, value := fragments[0], fragments[1]
+			if data.Type != common.DataUrlEncodeType {
+				key, _ = url.QueryUnescape(key)
+				value, _ = url.QueryUnescape(value)
+			}
+			pairs = append(pairs, fmt.Sprintf("(%s, %s)", literal.Rust(key), literal.Rust(value)))
+		}
+	}
+	self.requestBuilder = append(self.requestBuilder, fmt.Sprintf(".%s(&[%s])", method, strings.Join(pairs, ", ")))
+}
+
+func (self *RustGenerator) SetDataForUrl() error {
+	if self.Options.CanUseSimpleForm() {
+		self.SetDataForForm("query")
+		return nil
+	}
+	separator := "?"
+	if strings.Contains(self.Options.Url, "?") {
+		separator = "&"
+	}
+	query, err := self.bodyExpression(false)
+	if err != nil {
+		return err
+	}
+	self.url = fmt.Sprintf("format!(\"{}%s{}\", %s, %s)", separator, self.url, query)
+	return nil
+}
+
+func (self *RustGenerator) SetDataForBody() error {
+	body, err := self.bodyExpression(true)
+	if err != nil {
+		return err
+	}
+	self.requestBuilder = append(self.requestBuilder, fmt.Sprintf(".body(%s)", body))
+	return nil
+}
+
+/*
+	Single value is passed as is when binary is true. Otherwise values are String.
+*/
+func (self *RustGenerator) bodyExpression(binary bool) (string, error) {
+	if len(self.Options.ProcessedData) == 1 {
+		return self.dataExpression(&self.Options.ProcessedData[0], binary)
+	}
+	var values []string
+	for _, data := range self.Options.ProcessedData {
+		value, err := self.dataExpression(&data, false)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, value)
+	}
+	return fmt.Sprintf("[%s].join(\"&\")", strings.Join(values, ", ")), nil
+}
+
+/*
+	Rust expression of -d, --data-binary and --data-urlencode value.
+	Each value is String when they are joined.
+*/
+func (self *RustGenerator) dataExpression(data *common.DataOption, single bool) (string, error) {
+	value := func(src string) string {
+		if single {
+			return literal.Rust(src)
+		}
+		return literal.Rust(src) + ".to_string()"
+	}
+	switch data.Type {
+	case common.DataAsciiType:
+		if data.UseExternalFile() {
+			return fmt.Sprintf("std::fs::read_to_string(%s)?.replace(['\\r', '\\n'], \"\")", literal.Rust(data.Value[1:])), nil
+		}
+		return value(data.Value), nil
+	case common.DataBinaryType:
+		if data.UseExternalFile() {
+			if single {
+				return fmt.Sprintf("std::fs::read(%s)?", literal.Rust(data.Value[1:])), nil
+			}
+			return fmt.Sprintf("std::fs::read_to_string(%s)?", literal.Rust(data.Value[1:])), nil
+		}
+		return value(data.Value), nil
+	case common.DataUrlEncodeType:
+		self.crates["urlencoding"] = "\"2\""
+		// curl's rule: "content", "=content", "name=content", "@filename" and "name@filename"
+		index := strings.IndexAny(data.Value, "=@")
+		var content string
+		if index == -1 {
+			content = literal.Rust(data.Value)
+		} else if data.Value[index] == '@' {
+			content = fmt.Sprintf("&std::fs::read_to_string(%s)?", literal.Rust(data.Value[index+1:]))
+		} else {
+			content = literal.Rust(data.Value[index+1:])
+		}
+		if index > 0 {
+			format := strings.NewReplacer("{", "{{", "}", "}}").Replace(data.Value[:index]) + "={}"
+			return fmt.Sprintf("format!(%s, urlencoding::encode(%s))", literal.Rust(format), content), nil
+		}
+		return fmt.Sprintf("urlencoding::encode(%s).into_owned()", content), nil
+	default:
+		return "", common.UnexpectedDataError("rust", data)
+	}
+}
+
+func (self *RustGenerator) SetFormForBody() {
+	self.features["multipart"] = true
+	if self.Async && self.Options.ProcessedData.ExternalFileCount() > 0 {
+		// async file part needs stream feature
+		self.features["stream"] = true
+	}
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "let form = %s::multipart::Form::new()", self.Reqwest())
+	for _, data := range self.Options.ProcessedData {
+		field := strings.SplitN(data.Value, "=", 2)
+		name := literal.Rust(field[0])
+		if data.Type == common.FormType && (strings.HasPrefix(field[1], "@") || strings.HasPrefix(field[1], "<")) {
+			fragments := strings.Split(field[1][1:], ";")
+			var part string
+			if field[1][0] == '@' {
+				part = fmt.Sprintf("%s::multipart::Part::file(%s)%s?", self.Reqwest(), literal.Rust(fragments[0]), self.Await())
+			} else {
+				part = fmt.Sprintf("%s::multipart::Part::text(std::fs::read_to_string(%s)?)", self.Reqwest(), literal.Rust(fragments[0]))
+			}
+			for _, fragment := range fragments[1:] {
+				if strings.HasPrefix(fragment, "filename=") {
+					part += fmt.Sprintf(".file_name(%s)", literal.Rust(fragment[9:]))
+				} else if strings.HasPrefix(fragment, "type=") {
+					part += fmt.Sprintf(".mime_str(%s)?", literal.Rust(fragment[5:]))
+				}
+			}
+			fmt.Fprintf(&buffer, "\n        .part(%s, %s)", name, part)
+		} else {
+			fmt.Fprintf(&buffer, "\n        .text(%s, %s)", name, literal.Rust(field[1]))
+		}
+	}
+	buffer.WriteString(";")
+	self.form = append(self.form, buffer.String())
+	self.requestBuilder = append(self.requestBuilder, ".multipart(form)")
+}
+
+func (self *RustGenerator) SetAuth() {
+	user, password := self.Options.UserAndPassword()
+	if self.Options.UseBasicAuth() {
+		self.requestBuilder = append(self.requestBuilder, fmt.Sprintf(".basic_auth(%s, Some(%s))", literal.Rust(user), literal.Rust(password)))
+	} else {
+		self.crates["md5"] = "\"0.7\""
+		self.AdditionalDeclaration += `
+fn digest_authorization(challenge: &str, method: &str, url: &reqwest::Url, username: &str, password: &str) -> String {
+    let mut params = std::collections::HashMap::new();
+    let mut rest = challenge.trim_start_matches("Digest").trim();
+    while let Some(index) = rest.find('=') {
+        let key = rest[..index].trim_matches(|c: char| c == ',' || c.is_whitespace()).to_string();
+        rest = &rest[index + 1..];
+        let (value, next) = match rest.strip_prefix('"') {
+            Some(quoted) => quoted.split_once('"').unwrap_or((quoted, "")),
+            None => rest.split_once(',').unwrap_or((rest, "")),
+        };
+        params.insert(key, value.trim().to_string());
+        rest = next;
+    }
+    let param = |key: &str| params.get(key).cloned().unwrap_or_default();
+    let md5_hex = |text: String| format!("{:x}", md5::compute(text));
+    let uri = match url.query() {
+        Some(query) => format!("{}?{}", url.path(), query),
+        None => url.path().to_string(),
+    };
+    let ha1 = md5_hex(format!("{}:{}:{}", username, param("realm"), password));
+    let ha2 = md5_hex(format!("{}:{}", method, uri));
+    let mut authorization = format!("Digest username=\"{}\", realm=\"{}\", nonce=\"{}\", uri=\"{}\"", username, param("realm"), param("nonce"), uri);
+    if params.contains_key("qop") {
+        let seed = std::time::SystemTime::now().duration_since(std::time::UNIX_EPOCH).unwrap_or_default().as_nanos();
+        let cnonce = md5_hex(seed.to_string())[..16].to_string();
+        let response = md5_hex(format!("{}:{}:00000001:{}:auth:{}", ha1, param("nonce"), cnonce, ha2));
+        authorization += &format!(", qop=auth, nc=00000001, cnonce=\"{}\", response=\"{}\"", cnonce, response);
+    } else {
+        authorization += &format!(", response=\"{}\"", md5_hex(format!("{}:{}:{}", ha1, param("nonce"), ha2)));
+    }
+    if params.contains_key("opaque") {
+        authorization += &format!(", opaque=\"{}\"", param("opaque"));
+    }
+    authorization + ", algorithm=MD5"
+}
+`
+	}
+}
+
+/*
+	reqwest::cookie::Jar is used as cookie provider of the client.
+	Netscape format cookie files are converted by helper functions.
+*/
+func (self *RustGenerator) SetCookie() {
+	self.features["cookies"] = true
+	self.prepare = append(self.prepare,
+		fmt.Sprintf("let url = reqwest::Url::parse(%s)?;", literal.Rust(self.Options.Url)),
+		"let jar = std::sync::Arc::new(reqwest::cookie::Jar::default());")
+	for _, fileName := range self.Options.CookieFiles() {
+		self.prepare = append(self.prepare, fmt.Sprintf("load_cookies(&jar, %s);", literal.Rust(fileName)))
+	}
+	for _, cookie := range self.Options.Cookies() {
+		self.prepare = append(self.prepare, fmt.Sprintf("jar.add_cookie_str(%s, &url);", literal.Rust(cookie[0]+"="+cookie[1])))
+	}
+	self.clientBuilder = append(self.clientBuilder, ".cookie_provider(jar.clone())")
+	if len(self.Options.CookieFiles()) > 0 {
+		self.AdditionalDeclaration += `
+fn load_cookies(jar: &reqwest::cookie::Jar, file_name: &str) {
+    // curl ignores missing cookie file
+    let Ok(content) = std::fs::read_to_string(file_name) else {
+        return;
+    };
+    for line in content.lines() {
+        let line = line.strip_prefix("#HttpOnly_").unwrap_or(line);
+        let fields: Vec<&str> = line.split('\t').collect();
+        if line.starts_with('#') || fields.len() != 7 {
+            continue;
+        }
+        let (domain, include_subdomains, path, secure, _expires, name, value) = (fields[0], fields[1], fields[2], fields[3], fields[4], fields[5], fields[6]);
+        let scheme = if secure == "TRUE" { "https" } else { "http" };
+        let Ok(url) = reqwest::Url::parse(&format!("{}://{}{}", scheme, domain.trim_start_matches('.'), path)) else {
+            continue;
+        };
+        let mut cookie = format!("{}={}; Path={}", name, value, path);
+        if include_subdomains == "TRUE" {
+            cookie += &format!("; Domain={}", domain);
+        }
+        if secure == "TRUE" {
+            cookie += "; Secure";
+        }
+        jar.add_cookie_str(&cookie, &url);
+    }
+}
+`
+	}
+	if self.Options.CookieJar != "" {
+		self.AdditionalDeclaration += `
+// reqwest::cookie::Jar can't list stored cookies. Cookies sent to the URL are saved.
+fn save_cookies(jar: &reqwest::cookie::Jar, file_name: &str, url: &reqwest::Url) -> std::io::Result<()> {
+    use reqwest::cookie::CookieStore;
+    let mut content = String::from("# Netscape HTTP Cookie File\n");
+    if let Some(cookies) = jar.cookies(url) {
+        for cookie in cookies.to_str().unwrap_or("").split("; ") {
+            if let Some((name, value)) = cookie.split_once('=') {
+                content += &format!("{}\tFALSE\t/\tFALSE\t0\t{}\t{}\n", url.host_str().unwrap_or(""), name, value);
+            }
+        }
+    }
+    std::fs::write(file_name, content)
+}
+`
+	}
+}
+
+func (self *RustGenerator) SetProxy() {
+	proxy := self.Options.Proxy
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	self.clientBuilder = append(self.clientBuilder, fmt.Sprintf(".proxy(reqwest::Proxy::all(%s)?)", literal.Rust(proxy)))
+}
+
+func (self *RustGenerator) SetTimeout() {
+	seconds := func(value float64) string {
+		result := strconv.FormatFloat(value, 'f', -1, 64)
+		if !strings.Contains(result, ".") {
+			result += ".0"
+		}
+		return fmt.Sprintf("std::time::Duration::from_secs_f64(%s)", result)
+	}
+	if self.Options.ConnectTimeout != 0 {
+		self.clientBuilder = append(self.clientBuilder, fmt.Sprintf(".connect_timeout(%s)", seconds(self.Options.ConnectTimeout)))
+	}
+	if self.Options.MaxTime != 0 {
+		self.clientBuilder = append(self.clientBuilder, fmt.Sprintf(".timeout(%s)", seconds(self.Options.MaxTime)))
+	}
+}
+
+func processCurlCommand(options *common.CurlOptions, async bool) (string, interface{}, error) {
+	generator := NewRustGenerator(options, async)
+
+	if options.ProcessedData.HasData() {
+		if options.Get {
+			if err := generator.SetDataForUrl(); err != nil {
+				return "", nil, err
+			}
+		} else if options.CanUseSimpleForm() {
+			generator.SetDataForForm("form")
+		} else {
+			generator.Options.InsertDataContentTypeHeader()
+			if err := generator.SetDataForBody(); err != nil {
+				return "", nil, err
+			}
+		}
+	} else if options.ProcessedData.HasForm() {
+		generator.SetFormForBody()
+	}
+	generator.SetHeader()
+	if options.User != "" {
+		generator.SetAuth()
+	}
+	if len(options.Cookie) > 0 || options.CookieJar != "" {
+		generator.SetCookie()
+	}
+	if options.Proxy != "" {
+		generator.SetProxy()
+	}
+	if options.Insecure {
+		generator.clientBuilder = append(generator.clientBuilder, ".danger_accept_invalid_certs(true)")
+	}
+	if options.ConnectTimeout != 0 || options.MaxTime != 0 {
+		generator.SetTimeout()
+	}
+
+	return "full", *generator, nil
+}
+
+/*
+	Dispatcher functions of curl command
+	These are exported functions and called from httpgen.
+*/
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
+	return processCurlCommand(options, false)
+}
+
+func ProcessCurlCommandForAsync(options *common.CurlOptions) (string, interface{}, error) {
+	return processCurlCommand(options, true)
+}
@@ -0,0 +1,521 @@
+package swift
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/literal"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type SwiftGenerator struct {
+	Options *common.CurlOptions
+
+	Url                   string
+	AdditionalDeclaration string
+	prepare               []string
+	modifyRequest         []string
+	configuration         []string
+	challenges            []string
+	headers               map[string]bool
+}
+
+func NewSwiftGenerator(options *common.CurlOptions) *SwiftGenerator {
+	result := &SwiftGenerator{Options: options}
+	result.Url = fmt.Sprintf("URL(string: %s)!", literal.Swift(options.Url))
+	result.headers = make(map[string]bool)
+	return result
+}
+
+//--- Getter methods called from template
+
+func (self SwiftGenerator) Prepare() string {
+	var buffer bytes.Buffer
+	for _, line := range self.prepare {
+		fmt.Fprintf(&buffer, "%s\n", line)
+	}
+	return buffer.String()
+}
+
+func (self SwiftGenerator) Var() string {
+	if len(self.modifyRequest) == 0 {
+		return "let"
+	}
+	return "var"
+}
+
+func (self SwiftGenerator) ModifyRequest() string {
+	var buffer bytes.Buffer
+	for _, line := range self.modifyRequest {
+		fmt.Fprintf(&buffer, "%s\n", line)
+	}
+	return buffer.String()
+}
+
+/*
+	URLSession.shared is used when there is no special settings.
+*/
+func (self SwiftGenerator) PrepareSession() string {
+	if len(self.configuration) == 0 && len(self.challenges) == 0 {
+		return "let session = URLSession.shared\n"
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("let configuration = URLSessionConfiguration.default\n")
+	for _, line := range self.configuration {
+		fmt.Fprintf(&buffer, "%s\n", line)
+	}
+	if len(self.challenges) == 0 {
+		buffer.WriteString("let session = URLSession(configuration: configuration)\n")
+	} else {
+		buffer.WriteString("let session = URLSession(configuration: configuration, delegate: SessionDelegate(), delegateQueue: nil)\n")
+	}
+	return buffer.String()
+}
+
+/*
+	-k and Digest authentication are handled in the challenge of delegate.
+*/
+func (self SwiftGenerator) Delegate() string {
+	if len(self.challenges) == 0 {
+		return ""
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString(`
+final class SessionDelegate: NSObject, URLSessionTaskDelegate {
+    func urlSession(_ session: URLSession, task: URLSessionTask, didReceive challenge: URLAuthenticationChallenge,
+                    completionHandler: @escaping (URLSession.AuthChallengeDisposition, URLCredential?) -> Void) {
+        switch challenge.protectionSpace.authenticationMethod {
+`)
+	for _, challenge := range self.challenges {
+		buffer.WriteString(challenge)
+	}
+	buffer.WriteString(`        default:
+            break
+        }
+        completionHandler(.performDefaultHandling, nil)
+    }
+}
+`)
+	return buffer.String()
+}
+
+func (self SwiftGenerator) SaveCookies() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	return fmt.Sprintf("saveCookies(HTTPCookieStorage.shared, %s)\n", literal.Swift(self.Options.CookieJar))
+}
+
+//--- Preparing Swift source code methods
+
+func (self *SwiftGenerator) setHeader(name, value string) {
+	key := strings.ToLower(name)
+	if self.headers[key] {
+		self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("request.addValue(%s, forHTTPHeaderField: %s)", value, literal.Swift(name)))
+	} else {
+		self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("request.setValue(%s, forHTTPHeaderField: %s)", value, literal.Swift(name)))
+	}
+	self.headers[key] = true
+}
+
+/*
+	URLQueryItem can't express "content", "=content" and "name@filename" of --data-urlencode.
+*/
+func (self *SwiftGenerator) canUseQueryItems() bool {
+	if !self.Options.CanUseSimpleForm() {
+		return false
+	}
+	for _, data := range self.Options.ProcessedData {
+		if data.Type == common.DataUrlEncodeType {
+			index := strings.IndexAny(data.Value, "=@")
+			if index < 1 || data.Value[index] == '@' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (self *SwiftGenerator) SetDataForUrl() error {
+	if self.canUseQueryItems() {
+		var items []string
+		for _, data := range self.Options.ProcessedData {
+			if data.Type == common.DataUrlEncodeType {
+				fragments := strings.SplitN(data.Value, "=", 2)
+				items = append(items, fmt.Sprintf("    URLQueryItem(name: %s, value: %s),", literal.Swift(fragments[0]), literal.Swift(fragments[1])))
+				continue
+			}
+			for _, pair := range strings.Split(data.Value, "&") {
+				if pair == "" {
+					continue
+				}
+				fragments := strings.SplitN(pair, "=", 2)
+				key, _ := url.QueryUnescape(fragments[0])
+				value, _ := url.QueryUnescape(fragments[1])
+				items = append(items, fmt.Sprintf("    URLQueryItem(name: %s, value: %s),", literal.Swift(key), literal.Swift(value)))
+			}
+		}
+		self.prepare = append(self.prepare, fmt.Sprintf("var components = URLComponents(string: %s)!", literal.Swift(self.Options.Url)))
+		self.prepare = append(self.prepare, "components.queryItems = (components.queryItems ?? []) + [")
+		self.prepare = append(self.prepare, items...)
+		self.prepare = append(self.prepare, "]")
+		self.Url = "components.url!"
+		return nil
+	}
+	separator := "?"
+	if strings.Contains(self.Options.Url, "?") {
+		separator = "&"
+	}
+	query, err := self.stringBody()
+	if err != nil {
+		return err
+	}
+	self.prepare = append(self.prepare, fmt.Sprintf("let query = %s", query))
+	self.Url = fmt.Sprintf("URL(string: %s + query)!", literal.Swift(self.Options.Url+separator))
+	return nil
+}
+
+func (self *SwiftGenerator) SetDataForBody() error {
+	if len(self.Options.ProcessedData) == 1 {
+		body, err := self.dataExpression(&self.Options.ProcessedData[0], true)
+		if err != nil {
+			return err
+		}
+		self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("request.httpBody = %s", body))
+	} else {
+		body, err := self.stringBody()
+		if err != nil {
+			return err
+		}
+		self.modifyRequest = append(self.modifyRequest, fmt.Sprintf("request.httpBody = Data(%s.utf8)", body))
+	}
+	return nil
+}
+
+func (self *SwiftGenerator) stringBody() (string, error) {
+	if len(self.Options.ProcessedData) == 1 {
+		return self.dataExpression(&self.Options.ProcessedData[0], false)
+	}
+	var values []string
+	for _, data := range self.Options.ProcessedData {
+		value, err := self.dataExpression(&data, false)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, value)
+	}
+	return fmt.Sprintf("[%s].joined(separator: \"&\")", strings.Join(values, ", ")), nil
+}
+
+/*
+	Swift expression of -d, --data-binary and --data-urlencode value.
+	It returns Data when binary is true. Otherwise it returns String.
+*/
+func (self *SwiftGenerator) dataExpression(data *common.DataOption, binary bool) (string, error) {
+	var result string
+	switch data.Type {
+	case common.DataAsciiType:
+		if data.UseExternalFile() {
+			result = fmt.Sprintf("try String(contentsOfFile: %s, encoding: .utf8).components(separatedBy: .newlines).joined()", literal.Swift(data.Value[1:]))
+		} else {
+			result = literal.Swift(data.Value)
+		}
+	case common.DataBinaryType:
+		if data.UseExternalFile() {
+			if binary {
+				return fmt.Sprintf("try Data(contentsOf: URL(fileURLWithPath: %s))", literal.Swift(data.Value[1:])), nil
+			}
+			result = fmt.Sprintf("try String(contentsOfFile: %s, encoding: .utf8)", literal.Swift(data.Value[1:]))
+		} else {
+			result = literal.Swift(data.Value)
+		}
+	case common.DataUrlEncodeType:
+		self.AddUrlEncodeCode()
+		// curl's rule: "content", "=content", "name=content", "@filename" and "name@filename"
+		index := strings.IndexAny(data.Value, "=@")
+		var content string
+		if index == -1 {
+			content = literal.Swift(data.Value)
+		} else if data.Value[index] == '@' {
+			content = fmt.Sprintf("try String(contentsOfFile: %s, encoding: .utf8)", literal.Swift(data.Value[index+1:]))
+		} else {
+			content = literal.Swift(data.Value[index+1:])
+		}
+		if index > 0 {
+			result = fmt.Sprintf("%s + urlEncode(%s)", literal.Swift(data.Value[:index]+"="), content)
+		} else {
+			result = fmt.Sprintf("urlEncode(%s)", content)
+		}
+	default:
+		return "", common.UnexpectedDataError("swift", data)
+	}
+	if binary {
+		return fmt.Sprintf("Data((%s).utf8)", result), nil
+	}
+	return result, nil
+}
+
+func (self *SwiftGenerator) AddUrlEncodeCode() {
+	if strings.Contains(self.AdditionalDeclaration, "func urlEncode") {
+		return
+	}
+	self.AdditionalDeclaration += `
+// Same as curl's --data-urlencode. Only unreserved characters are kept and space is "+".
+func urlEncode(_ source: String) -> String {
+    let unreserved = CharacterSet(charactersIn: "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-._~")
+    return source.addingPercentEncoding(withAllowedCharacters: unreserved)!.replacingOccurrences(of: "%20", with: "+")
+}
+`
+}
+
+func (self *SwiftGenerator) AddMultiPartCode() {
+	self.AdditionalDeclaration += `
+#if canImport(UniformTypeIdentifiers)
+import UniformTypeIdentifiers
+#endif
+
+func mimeType(_ path: String) -> String {
+#if canImport(UniformTypeIdentifiers)
+    if let type = UTType(filenameExtension: (path as NSString).pathExtension), let mimeType = type.preferredMIMEType {
+        return mimeType
+    }
+#endif
+    return "application/octet-stream"
+}
+
+// fields: (name, value, content type), files: (name, source file, sent file name, content type)
+func encodeMultiPartBody(_ boundary: String, _ fields: [(String, String, String?)], _ files: [(String, String, String, String)]) throws -> Data {
+    var body = Data()
+    for (name, value, contentType) in fields {
+        body.append(Data("--\(boundary)\r\n".utf8))
+        body.append(Data("Content-Disposition: form-data; name=\"\(name)\"\r\n".utf8))
+        if let contentType = contentType {
+            body.append(Data("Content-Type: \(contentType)\r\n".utf8))
+        }
+        body.append(Data("\r\n\(value)\r\n".utf8))
+    }
+    for (name, sourceFile, fileName, contentType) in files {
+        body.append(Data("--\(boundary)\r\n".utf8))
+        body.append(Data("Content-Disposition: form-data; name=\"\(name)\"; filename=\"\(fileName)\"\r\n".utf8))
+        body.append(Data("Content-Type: \(contentType)\r\n\r\n".utf8))
+        body.append(try Data(contentsOf: URL(fileURLWithPath: sourceFile)))
+        body.append(Data("\r\n".utf8))
+    }
+    body.append(Data("--\(boundary)--\r\n".utf8))
+    return body
+}
+`
+}
+
+func (self *SwiftGenerator) SetFormForBody() {
+	self.AddMultiPartCode()
+	var fields []string
+	var files []string
+
+	for _, data := range self.Options.ProcessedData {
+		field := strings.SplitN(data.Value, "=", 2)
+		if data.Type == common.FormType && (strings.HasPrefix(field[1], "@") || strings.HasPrefix(field[1], "<")) {
+			fragments := strings.Split(field[1][1:], ";")
+			contentType := "nil"
+			sentFileName := fragments[0]
+			for _, fragment := range fragments[1:] {
+				if strings.HasPrefix(fragment, "filename=") {
+					sentFileName = fragment[9:]
+				} else if strings.HasPrefix(fragment, "type=") {
+					contentType = literal.Swift(fragment[5:])
+				}
+			}
+			if field[1][0] == '@' {
+				if contentType == "nil" {
+					contentType = fmt.Sprintf("mimeType(%s)", literal.Swift(fragments[0]))
+				}
+				files = append(files, fmt.Sprintf("    (%s, %s, %s, %s),", literal.Swift(field[0]), literal.Swift(fragments[0]), literal.Swift(sentFileName), contentType))
+			} else {
+				fields = append(fields, fmt.Sprintf("    (%s, try String(contentsOfFile: %s, encoding: .utf8), %s),", literal.Swift(field[0]), literal.Swift(fragments[0]), contentType))
+			}
+		} else {
+			fields = append(fields, fmt.Sprintf("    (%s, %s, nil),", literal.Swift(field[0]), literal.Swift(field[1])))
+		}
+	}
+
+	if len(fields) > 0 {
+		self.prepare = append(self.prepare, "let fields: [(String, String, String?)] = [")
+		self.prepare = append(self.prepare, fields...)
+		self.prepare = append(self.prepare, "]")
+	} else {
+		self.prepare = append(self.prepare, "let fields: [(String, String, String?)] = []")
+	}
+	if len(files) > 0 {
+		self.prepare = append(self.prepare, "let files: [(String, String, String, String)] = [")
+		self.prepare = append(self.prepare, files...)
+		self.prepare = append(self.prepare, "]")
+	} else {
+		self.prepare = append(self.prepare, "let files: [(String, String, String, String)] = []")
+	}
+	self.prepare = append(self.prepare, "let boundary = \"Boundary-\\(UUID().uuidString)\"")
+	self.setHeader("Content-Type", "\"multipart/form-data; boundary=\\(boundary)\"")
+	self.modifyRequest = append(self.modifyRequest, "request.httpBody = try encodeMultiPartBody(boundary, fields, files)")
+}
+
+/*
+	URL loading system sends and stores cookies via shared cookie storage.
+	Generated code fills it from Netscape format files and dumps it into cookie jar file.
+*/
+func (self *SwiftGenerator) AddCookieCode() {
+	self.prepare = append(self.prepare, "HTTPCookieStorage.shared.cookieAcceptPolicy = .always")
+	for _, fileName := range self.Options.CookieFiles() {
+		self.prepare = append(self.prepare, fmt.Sprintf("loadCookies(HTTPCookieStorage.shared, %s)", literal.Swift(fileName)))
+	}
+	for _, cookie := range self.Options.Cookies() {
+		self.prepare = append(self.prepare, fmt.Sprintf("HTTPCookieStorage.shared.setCookie(HTTPCookie(properties: [.originURL: %s, .path: \"/\", .name: %s, .value: %s])!)", self.Url, literal.Swift(cookie[0]), literal.Swift(cookie[1])))
+	}
+	if len(self.Options.CookieFiles()) > 0 {
+		self.AdditionalDeclaration += `
+func loadCookies(_ storage: HTTPCookieStorage, _ fileName: String) {
+    // curl ignores missing cookie file
+    guard let contents = try? String(contentsOfFile: fileName, encoding: .utf8) else {
+        return
+    }
+    for rawLine in contents.components(separatedBy: .newlines) {
+        let httpOnly = rawLine.hasPrefix("#HttpOnly_")
+        let line = httpOnly ? String(rawLine.dropFirst("#HttpOnly_".count)) : rawLine
+        let fields = line.components(separatedBy: "\t")
+        if line.hasPrefix("#") || fields.count != 7 {
+            continue
+        }
+        var properties: [HTTPCookiePropertyKey: Any] = [.domain: fields[0], .path: fields[2], .name: fields[5], .value: fields[6]]
+        if fields[3] == "TRUE" {
+            properties[.secure] = "TRUE"
+        }
+        if let expires = Double(fields[4]), expires != 0 {
+            properties[.expires] = Date(timeIntervalSince1970: expires)
+        }
+        if httpOnly {
+            properties[HTTPCookiePropertyKey("HttpOnly")] = "TRUE"
+        }
+        if let cookie = HTTPCookie(properties: properties) {
+            storage.setCookie(cookie)
+        }
+    }
+}
+`
+	}
+	if self.Options.CookieJar != "" {
+		self.AdditionalDeclaration += `
+func saveCookies(_ storage: HTTPCookieStorage, _ fileName: String) {
+    var contents = "# Netscape HTTP Cookie File\n"
+    for cookie in storage.cookies ?? [] {
+        let expires = cookie.expiresDate.map { String(Int($0.timeIntervalSince1970)) } ?? "0"
+        let fields = [(cookie.isHTTPOnly ? "#HttpOnly_" : "") + cookie.domain, cookie.domain.hasPrefix(".") ? "TRUE" : "FALSE",
+                      cookie.path, cookie.isSecure ? "TRUE" : "FALSE", expires, cookie.name, cookie.value]
+        contents += fields.joined(separator: "\t") + "\n"
+    }
+    try? contents.write(toFile: fileName, atomically: true, encoding: .utf8)
+}
+`
+	}
+}
+
+/*
+	curl uses 1080 as default proxy port.
+*/
+func (self *SwiftGenerator) SetProxy() {
+	proxy := self.Options.Proxy
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	u, _ := url.Parse(proxy)
+	port := u.Port()
+	if port == "" {
+		port = "1080"
+	}
+	host := literal.Swift(u.Hostname())
+	// kCFNetworkProxiesHTTPS* constants are not available on iOS. Keys are written as string.
+	if strings.HasPrefix(u.Scheme, "socks") {
+		self.configuration = append(self.configuration, fmt.Sprintf("configuration.connectionProxyDictionary = [\"SOCKSEnable\": true, \"SOCKSProxy\": %s, \"SOCKSPort\": %s]", host, port))
+	} else {
+		self.configuration = append(self.configuration, "configuration.connectionProxyDictionary = [")
+		self.configuration = append(self.configuration, fmt.Sprintf("    \"HTTPEnable\": true, \"HTTPProxy\": %s, \"HTTPPort\": %s,", host, port))
+		self.configuration = append(self.configuration, fmt.Sprintf("    \"HTTPSEnable\": true, \"HTTPSProxy\": %s, \"HTTPSPort\": %s,", host, port))
+		self.configuration = append(self.configuration, "]")
+	}
+}
+
+/*
+	URLSession doesn't have connection timeout. Request timeout (idle time) is the closest one.
+*/
+func (self *SwiftGenerator) SetTimeout() {
+	seconds := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	if self.Options.ConnectTimeout != 0 {
+		self.configuration = append(self.configuration, fmt.Sprintf("configuration.timeoutIntervalForRequest = %s", seconds(self.Options.ConnectTimeout)))
+	}
+	if self.Options.MaxTime != 0 {
+		self.configuration = append(self.configuration, fmt.Sprintf("configuration.timeoutIntervalForResource = %s", seconds(self.Options.MaxTime)))
+	}
+}
+
+/*
+	Dispatcher function of curl command
+	This is an exported function and called from httpgen.
+*/
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
+	generator := NewSwiftGenerator(options)
+
+	method := options.Method()
+	if method != "GET" {
+		generator.modifyRequest = append(generator.modifyRequest, fmt.Sprintf("request.httpMethod = %s", literal.Swift(method)))
+	}
+	if options.ProcessedData.HasData() && !options.Get {
+		generator.Options.InsertDataContentTypeHeader()
+	}
+	for _, header := range options.Headers() {
+		generator.setHeader(header[0], literal.Swift(header[1]))
+	}
+	if options.ProcessedData.HasData() {
+		if options.Get {
+			if err := generator.SetDataForUrl(); err != nil {
+				return "", nil, err
+			}
+		} else {
+			if err := generator.SetDataForBody(); err != nil {
+				return "", nil, err
+			}
+		}
+	} else if options.ProcessedData.HasForm() {
+		generator.SetFormForBody()
+	}
+	if options.UseBasicAuth() {
+		generator.setHeader("Authorization", fmt.Sprintf("\"Basic \\(Data(%s.utf8).base64EncodedString())\"", literal.Swift(options.User)))
+	} else if options.UseDigestAuth() {
+		user, password := options.UserAndPassword()
+		generator.challenges = append(generator.challenges, fmt.Sprintf(`        case NSURLAuthenticationMethodHTTPDigest where challenge.previousFailureCount == 0:
+            completionHandler(.useCredential, URLCredential(user: %s, password: %s, persistence: .forSession))
+            return
+`, literal.Swift(user), literal.Swift(password)))
+	}
+	if options.Insecure {
+		generator.challenges = append(generator.challenges, `        case NSURLAuthenticationMethodServerTrust:
+            if let serverTrust = challenge.protectionSpace.serverTrust {
+                completionHandler(.useCredential, URLCredential(trust: serverTrust))
+                return
+            }
+`)
+	}
+	if options.UseCookieJar() {
+		generator.AddCookieCode()
+	} else if len(options.Cookies()) != 0 {
+		generator.setHeader("Cookie", literal.Swift(options.CookieString()))
+	}
+	if options.Proxy != "" {
+		generator.SetProxy()
+	}
+	if options.ConnectTimeout != 0 || options.MaxTime != 0 {
+		generator.SetTimeout()
+	}
+
+	return "full", *generator, nil
+}
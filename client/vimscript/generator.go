@@ -4,16 +4,10 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/shibukawa/curl_as_dsl/common"
-	"log"
-	"net/url"
-	"os"
+	"github.com/shibukawa/curl_as_dsl/literal"
 	"strings"
 )
 
-func escapeDQ(src string) string {
-	return strings.Replace(strings.Replace(src, "\"", "\\\"", -1), "\\", "\\\\", -1)
-}
-
 type VimScriptGenerator struct {
 	Options *common.CurlOptions
 
@@ -32,11 +26,12 @@ func NewVimScriptGenerator(options *common.CurlOptions) *VimScriptGenerator {
 //--- Getter methods called from template
 
 func (self VimScriptGenerator) Url() string {
-	return fmt.Sprintf(`'%s'`, self.Options.Url)
+	return literal.Vim(self.Options.Url)
 }
 
 func (self VimScriptGenerator) HasHeader() bool {
-	return len(self.Options.Header) != 0 || len(self.specialHeaders) != 0
+	// Digest authentication adds Authorization header to s:headers when retrying
+	return len(self.Options.Header) != 0 || len(self.specialHeaders) != 0 || self.Options.UseDigestAuth()
 }
 
 func (self VimScriptGenerator) BodyContent() string {
@@ -47,16 +42,19 @@ func (self VimScriptGenerator) BodyContent() string {
 }
 
 func (self VimScriptGenerator) Header() string {
-	if len(self.Options.Header) == 0 && len(self.specialHeaders) == 0 {
+	if !self.HasHeader() {
 		return ""
 	}
 	return fmt.Sprintf(", s:headers")
 }
 
 func (self VimScriptGenerator) PrepareHeader() string {
-	if len(self.Options.Header) == 0 && len(self.specialHeaders) == 0 {
+	if !self.HasHeader() {
 		return ""
 	}
+	if len(self.Options.Header) == 0 && len(self.specialHeaders) == 0 {
+		return "let s:headers = {}\n"
+	}
 	var buffer bytes.Buffer
 	buffer.WriteString("let s:headers = {\n  ")
 	first := true
@@ -66,7 +64,7 @@ func (self VimScriptGenerator) PrepareHeader() string {
 		} else {
 			buffer.WriteString(",\n  ")
 		}
-		fmt.Fprintf(&buffer, "\\\"%s\": \"%s\"", strings.TrimSpace(header.Key), strings.TrimSpace(header.Values[0]))
+		fmt.Fprintf(&buffer, "\\%s: %s", literal.Vim(strings.TrimSpace(header.Key)), literal.Vim(strings.TrimSpace(header.Values[0])))
 	}
 	for _, header := range self.specialHeaders {
 		if first {
@@ -88,15 +86,230 @@ func (self VimScriptGenerator) FinalizeBody() string {
 }
 
 func (self VimScriptGenerator) Method() string {
-	method := strings.ToLower(self.Options.Method())
-	if method != "get" && method != "post" {
-		log.Fatal("VimScript only supports get, post")
+	return strings.ToLower(self.Options.Method())
+}
+
+/*
+	Send the request again with Digest authorization header when server returns a challenge.
+*/
+func (self VimScriptGenerator) Authenticate() string {
+	if !self.Options.UseDigestAuth() {
+		return ""
 	}
-	return method
+	user, password := self.Options.UserAndPassword()
+	var buffer bytes.Buffer
+	buffer.WriteString("if s:res.status == 401\n")
+	fmt.Fprintf(&buffer, "  let s:headers['Authorization'] = s:digest_authorization(s:res.header, '%s', %s, %s, %s)\n",
+		strings.ToUpper(self.Method()), self.Url(), literal.Vim(user), literal.Vim(password))
+	fmt.Fprintf(&buffer, "  let s:res = webapi#http#%s(%s%s%s)\n", self.Method(), self.Url(), self.BodyContent(), self.Header())
+	buffer.WriteString("endif\n")
+	return buffer.String()
 }
 
 //--- Setter/Getter methods
 
+func (self *VimScriptGenerator) AddDigestCode() {
+	// Vim script has sha256() but no MD5 function
+	self.AdditionalDeclaration += `let s:md5_shifts = [7, 12, 17, 22, 5, 9, 14, 20, 4, 11, 16, 23, 6, 10, 15, 21]
+let s:md5_table = map(range(64), 'float2nr(floor(abs(sin(v:val + 1.0)) * 4294967296.0))')
+
+function! s:md5_rotate(x, c) abort
+    return or(and(a:x * float2nr(pow(2, a:c)), 0xffffffff), a:x / float2nr(pow(2, 32 - a:c)))
+endfunction
+
+function! s:md5_hex(text) abort
+    let bytes = map(range(len(a:text)), 'char2nr(a:text[v:val])')
+    let size = len(bytes) * 8
+    call add(bytes, 0x80)
+    while len(bytes) % 64 != 56
+        call add(bytes, 0)
+    endwhile
+    for i in range(8)
+        call add(bytes, size % 256)
+        let size = size / 256
+    endfor
+    let state = [0x67452301, 0xefcdab89, 0x98badcfe, 0x10325476]
+    for offset in range(0, len(bytes) - 1, 64)
+        let words = []
+        for i in range(16)
+            let j = offset + i * 4
+            call add(words, bytes[j] + bytes[j + 1] * 0x100 + bytes[j + 2] * 0x10000 + bytes[j + 3] * 0x1000000)
+        endfor
+        let [a, b, c, d] = state
+        for i in range(64)
+            if i < 16
+                let [f, g] = [or(and(b, c), and(xor(b, 0xffffffff), d)), i]
+            elseif i < 32
+                let [f, g] = [or(and(d, b), and(xor(d, 0xffffffff), c)), (5 * i + 1) % 16]
+            elseif i < 48
+                let [f, g] = [xor(xor(b, c), d), (3 * i + 5) % 16]
+            else
+                let [f, g] = [xor(c, or(b, xor(d, 0xffffffff))), (7 * i) % 16]
+            endif
+            let f = and(f + a + s:md5_table[i] + words[g], 0xffffffff)
+            let [a, d, c] = [d, c, b]
+            let b = and(b + s:md5_rotate(f, s:md5_shifts[i / 16 * 4 + i % 4]), 0xffffffff)
+        endfor
+        let state = map([a, b, c, d], 'and(v:val + state[v:key], 0xffffffff)')
+    endfor
+    let result = ''
+    for word in state
+        for i in range(4)
+            let result .= printf('%02x', word % 256)
+            let word = word / 256
+        endfor
+    endfor
+    return result
+endfunction
+
+function! s:digest_authorization(response_headers, method, url, username, password) abort
+    let challenge = ''
+    for header in a:response_headers
+        if header =~? '^WWW-Authenticate:\s*Digest\s'
+            let challenge = matchstr(header, '^[^:]*:\s*Digest\s\+\zs.*')
+        endif
+    endfor
+    let params = {}
+    let pattern = '\(\w\+\)=\%("\([^"]*\)"\|\([^, \t]*\)\)'
+    let start = match(challenge, pattern)
+    while start >= 0
+        let matched = matchlist(challenge, pattern, start)
+        let params[matched[1]] = matched[2] . matched[3]
+        let start = match(challenge, pattern, start + len(matched[0]))
+    endwhile
+    let uri = matchstr(a:url, '^\w\+://[^/]*\zs.*')
+    if uri == ''
+        let uri = '/'
+    endif
+    let ha1 = s:md5_hex(join([a:username, params.realm, a:password], ':'))
+    let ha2 = s:md5_hex(join([a:method, uri], ':'))
+    let authorization = printf('Digest username="%s", realm="%s", nonce="%s", uri="%s"', a:username, params.realm, params.nonce, uri)
+    if has_key(params, 'qop')
+        let cnonce = s:md5_hex(reltimestr(reltime()) . getpid())[: 15]
+        let response = s:md5_hex(join([ha1, params.nonce, '00000001', cnonce, 'auth', ha2], ':'))
+        let authorization .= printf(', qop=auth, nc=00000001, cnonce="%s", response="%s"', cnonce, response)
+    else
+        let authorization .= printf(', response="%s"', s:md5_hex(join([ha1, params.nonce, ha2], ':')))
+    endif
+    if has_key(params, 'opaque')
+        let authorization .= printf(', opaque="%s"', params.opaque)
+    endif
+    return authorization . ', algorithm=MD5'
+endfunction
+
+`
+}
+
+func (self VimScriptGenerator) SaveCookies() string {
+	if self.Options.CookieJar == "" {
+		return ""
+	}
+	return fmt.Sprintf("call s:store_cookies(s:cookies, s:res.header, %s)\ncall s:save_cookies(%s, s:cookies)\n", self.Url(), literal.Vim(self.Options.CookieJar))
+}
+
+func (self *VimScriptGenerator) AddCookieCode() {
+	self.AdditionalDeclaration += `function! s:load_cookies(file_name) abort
+    let cookies = []
+    if !filereadable(a:file_name)
+        return cookies
+    endif
+    for line in readfile(a:file_name)
+        let http_only = line =~# '^#HttpOnly_'
+        let line = substitute(line, '^#HttpOnly_', '', '')
+        let fields = split(line, "\t", 1)
+        if line =~# '^#' || len(fields) != 7
+            continue
+        endif
+        call add(cookies, {'domain': fields[0], 'subdomains': fields[1] ==# 'TRUE', 'path': fields[2], 'secure': fields[3] ==# 'TRUE',
+            \ 'expires': str2nr(fields[4]), 'name': fields[5], 'value': fields[6], 'http_only': http_only})
+    endfor
+    return cookies
+endfunction
+
+function! s:cookie_header(cookies, url, extra) abort
+    let [scheme, host, path] = matchlist(a:url, '^\(\w\+\)://\([^/:]*\)[^/]*\(.*\)')[1 : 3]
+    if path == ''
+        let path = '/'
+    endif
+    let pairs = a:extra == '' ? [] : [a:extra]
+    for cookie in a:cookies
+        let domain = substitute(cookie.domain, '^\.', '', '')
+        let domain_matched = host ==? domain || (cookie.subdomains && host =~? '\.' . escape(domain, '.') . '$')
+        if domain_matched && stridx(path, cookie.path) == 0 && (scheme ==? 'https' || !cookie.secure)
+            \ && (cookie.expires == 0 || cookie.expires > localtime())
+            call add(pairs, cookie.name . '=' . cookie.value)
+        endif
+    endfor
+    return join(pairs, '; ')
+endfunction
+
+`
+	if self.Options.CookieJar != "" {
+		self.AdditionalDeclaration += `function! s:store_cookies(cookies, response_headers, url) abort
+    let host = matchstr(a:url, '^\w\+://\zs[^/:]*')
+    for header in a:response_headers
+        if header !~? '^Set-Cookie:'
+            continue
+        endif
+        let attributes = split(matchstr(header, '^[^:]*:\s*\zs.*'), ';')
+        let [name, value] = matchlist(attributes[0], '^\([^=]*\)=\?\(.*\)')[1 : 2]
+        let cookie = {'domain': host, 'subdomains': 0, 'path': '/', 'secure': 0, 'expires': 0,
+            \ 'name': trim(name), 'value': trim(value), 'http_only': 0}
+        let max_age = ''
+        for attribute in attributes[1 :]
+            let [key, value] = matchlist(attribute, '^\([^=]*\)=\?\(.*\)')[1 : 2]
+            let key = tolower(trim(key))
+            let value = trim(value)
+            if key ==# 'domain'
+                let cookie.domain = '.' . substitute(value, '^\.', '', '')
+                let cookie.subdomains = 1
+            elseif key ==# 'path'
+                let cookie.path = value
+            elseif key ==# 'secure'
+                let cookie.secure = 1
+            elseif key ==# 'httponly'
+                let cookie.http_only = 1
+            elseif key ==# 'expires' && exists('*strptime')
+                let cookie.expires = strptime('%a, %d %b %Y %H:%M:%S', value)
+            elseif key ==# 'max-age'
+                let max_age = value
+            endif
+        endfor
+        if max_age != ''
+            let cookie.expires = localtime() + str2nr(max_age)
+        endif
+        call filter(a:cookies, '!(v:val.name ==# cookie.name && v:val.domain ==# cookie.domain && v:val.path ==# cookie.path)')
+        call add(a:cookies, cookie)
+    endfor
+endfunction
+
+function! s:save_cookies(file_name, cookies) abort
+    let lines = ['# Netscape HTTP Cookie File']
+    for cookie in a:cookies
+        if cookie.expires != 0 && cookie.expires < localtime()
+            continue
+        endif
+        call add(lines, join([(cookie.http_only ? '#HttpOnly_' : '') . cookie.domain, cookie.subdomains ? 'TRUE' : 'FALSE', cookie.path,
+            \ cookie.secure ? 'TRUE' : 'FALSE', cookie.expires, cookie.name, cookie.value], "\t"))
+    endfor
+    call writefile(lines, a:file_name)
+endfunction
+
+`
+	}
+	var files []string
+	for _, fileName := range self.Options.CookieFiles() {
+		files = append(files, fmt.Sprintf("s:load_cookies(%s)", literal.Vim(fileName)))
+	}
+	if len(files) == 0 {
+		self.AdditionalDeclaration += "let s:cookies = []\n"
+	} else {
+		self.AdditionalDeclaration += fmt.Sprintf("let s:cookies = %s\n", strings.Join(files, " + "))
+	}
+	self.specialHeaders = append(self.specialHeaders, fmt.Sprintf("\\'Cookie': s:cookie_header(s:cookies, %s, %s)", self.Url(), literal.Vim(self.Options.CookieString())))
+	self.FinalizeBodyBuffer.WriteString("unlet! s:cookies\n")
+}
+
 func (self *VimScriptGenerator) AddMultiPartCode() {
 	self.AdditionalDeclaration = `let s:BOUNDARY = '----------ThIs_Is_tHe_bouNdaRY_$'
 
@@ -126,13 +339,17 @@ endfunction
 	self.Options.InsertContentTypeHeader(fmt.Sprintf("multipart/form-data; boundary=%s", boundary))
 }
 
-func (self *VimScriptGenerator) SetDataForBody() {
+func (self *VimScriptGenerator) SetDataForBody() error {
 	var buffer bytes.Buffer
 	if self.Options.CanUseSimpleForm() {
 		self.SetDataForForm()
 		self.FinalizeBodyBuffer.WriteString("unlet! s:body\n")
 	} else if len(self.Options.ProcessedData) == 1 {
-		self.Body = StringForData(self, &self.Options.ProcessedData[0])
+		body, err := StringForData(self, &self.Options.ProcessedData[0])
+		if err != nil {
+			return err
+		}
+		self.Body = body
 		self.PrepareBody = buffer.String()
 	} else {
 		for i, data := range self.Options.ProcessedData {
@@ -141,7 +358,11 @@ func (self *VimScriptGenerator) SetDataForBody() {
 			} else {
 				buffer.WriteString(",\n  \\")
 			}
-			buffer.WriteString(StringForData(self, &data))
+			value, err := StringForData(self, &data)
+			if err != nil {
+				return err
+			}
+			buffer.WriteString(value)
 		}
 		buffer.WriteString("\n  \\], \"&\")\n")
 		self.Body = "s:body"
@@ -149,26 +370,22 @@ func (self *VimScriptGenerator) SetDataForBody() {
 		self.PrepareBody = buffer.String()
 	}
 	self.HasBody = true
+	return nil
 }
 
 func (self *VimScriptGenerator) SetDataForForm() {
-	entries := make(map[string][]string)
-	for _, data := range self.Options.ProcessedData {
-		singleData, _ := url.ParseQuery(data.Value)
-		for key, values := range singleData {
-			entries[key] = append(entries[key], values[0])
-		}
-	}
+	keys, entries := self.Options.ProcessedData.FormValues()
 
 	var buffer bytes.Buffer
 	count := 1
-	for key, values := range entries {
+	for _, key := range keys {
+		values := entries[key]
 		if count == 1 {
 			buffer.WriteString("let s:body = {")
 		} else {
 			buffer.WriteString(`, `)
 		}
-		fmt.Fprintf(&buffer, `"%s": "%s"`, key, values[0])
+		fmt.Fprintf(&buffer, `%s: %s`, literal.Vim(key), literal.Vim(values[0]))
 		count++
 	}
 	buffer.WriteString("}\n")
@@ -223,49 +440,62 @@ func (self *VimScriptGenerator) SetFormForBody() {
 	Dispatcher function of curl command
 	This is an exported function and called from httpgen.
 */
-func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}) {
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
+	method := options.Method()
+	if method != "GET" && method != "POST" {
+		return "", nil, &common.UnsupportedOptionError{Target: "vim", Option: "-X " + method, Reason: "VimScript only supports get, post"}
+	}
 	generator := NewVimScriptGenerator(options)
 
 	if options.ProcessedData.HasData() {
-		generator.Options.InsertContentTypeHeader("application/x-www-form-urlencoded")
-		generator.SetDataForBody()
+		generator.Options.InsertDataContentTypeHeader()
+		if err := generator.SetDataForBody(); err != nil {
+			return "", nil, err
+		}
 	} else if options.ProcessedData.HasForm() {
 		generator.SetFormForBody()
 	}
-	if generator.Options.User != "" {
-		generator.specialHeaders = append(generator.specialHeaders, fmt.Sprintf("\\'Authorization': 'Basic '. webapi#base64#b64encode('%s')", generator.Options.User))
+	if generator.Options.UseBasicAuth() {
+		generator.specialHeaders = append(generator.specialHeaders, fmt.Sprintf("\\'Authorization': 'Basic '. webapi#base64#b64encode(%s)", literal.Vim(generator.Options.User)))
+	} else if generator.Options.UseDigestAuth() {
+		generator.AddDigestCode()
+	}
+	if options.UseCookieJar() {
+		generator.AddCookieCode()
+	} else if len(options.Cookies()) != 0 {
+		generator.specialHeaders = append(generator.specialHeaders, fmt.Sprintf("\\'Cookie': %s", literal.Vim(options.CookieString())))
 	}
 
-	return "full", *generator
+	return "full", *generator, nil
 }
 
 // helper functions
 
-func StringForData(generator *VimScriptGenerator, data *common.DataOption) string {
+func StringForData(generator *VimScriptGenerator, data *common.DataOption) (string, error) {
 	var result string
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf("join(readfile('%s'), '')", data.Value[1:])
+		if data.UseExternalFile() {
+			result = fmt.Sprintf("join(readfile(%s), '')", literal.Vim(data.Value[1:]))
 		} else {
-			result = fmt.Sprintf(`"%s"`, escapeDQ(strings.Replace(data.Value, "\n", "", -1)))
+			result = literal.Vim(strings.Replace(data.Value, "\n", "", -1))
 		}
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf("join(readfile('%s'), \"\\n\")", data.Value[1:])
+		if data.UseExternalFile() {
+			result = fmt.Sprintf("join(readfile(%s), \"\\n\")", literal.Vim(data.Value[1:]))
 		} else {
-			result = fmt.Sprintf(`"%s"`, escapeDQ(data.Value))
+			result = literal.Vim(data.Value)
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
-			result = fmt.Sprintf("webapi#http#encodeURIComponent(join(readfile('%s'), \"\\n\"))", data.Value[1:])
+		if data.UseExternalFile() {
+			result = fmt.Sprintf("webapi#http#encodeURIComponent(join(readfile(%s), \"\\n\"))", literal.Vim(data.Value[1:]))
 		} else {
-			result = fmt.Sprintf(`webapi#http#encodeURIComponent("%s")`, escapeDQ(data.Value))
+			result = fmt.Sprintf(`webapi#http#encodeURIComponent(%s)`, literal.Vim(data.Value))
 		}
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return "", common.UnexpectedDataError("vim", data)
 	}
-	return result
+	return result, nil
 }
 
 func FormString(generator *VimScriptGenerator, data *common.DataOption) string {
@@ -273,16 +503,12 @@ func FormString(generator *VimScriptGenerator, data *common.DataOption) string {
 	switch data.Type {
 	case common.FormType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
 		if strings.HasPrefix(field[1], "@") {
 			var buffer bytes.Buffer
 			fragments := strings.Split(field[1][1:], ";")
 
 			// field name, source file name
-			fmt.Fprintf(&buffer, "  \\{'key': '%s', 'sourcefile': '%s', ", field[0], fragments[0])
+			fmt.Fprintf(&buffer, "  \\{'key': %s, 'sourcefile': %s, ", literal.Vim(field[0]), literal.Vim(fragments[0]))
 
 			var contentType string
 			sentFileName := fragments[0]
@@ -294,11 +520,11 @@ func FormString(generator *VimScriptGenerator, data *common.DataOption) string {
 				}
 			}
 			// sent file name
-			fmt.Fprintf(&buffer, "'filename': '%s', ", sentFileName)
+			fmt.Fprintf(&buffer, "'filename': %s, ", literal.Vim(sentFileName))
 
 			// sent file name
 			if contentType != "" {
-				fmt.Fprintf(&buffer, "'contenttype': '%s'", contentType)
+				fmt.Fprintf(&buffer, "'contenttype': %s", literal.Vim(contentType))
 			} else {
 				buffer.WriteString("'contenttype': 'application/octet-stream'")
 			}
@@ -310,7 +536,7 @@ func FormString(generator *VimScriptGenerator, data *common.DataOption) string {
 			fragments := strings.Split(field[1][1:], ";")
 
 			// field name, content
-			fmt.Fprintf(&buffer, "  \\{'key':'%s', 'value': join(readfile('%s'), \"\\n\")", field[0], fragments[0])
+			fmt.Fprintf(&buffer, "  \\{'key':%s, 'value': join(readfile(%s), \"\\n\")", literal.Vim(field[0]), literal.Vim(fragments[0]))
 
 			var contentType string
 			for _, fragment := range fragments[1:] {
@@ -319,20 +545,16 @@ func FormString(generator *VimScriptGenerator, data *common.DataOption) string {
 				}
 			}
 			if contentType != "" {
-				fmt.Fprintf(&buffer, ", 'contenttype': '%s'", contentType)
+				fmt.Fprintf(&buffer, ", 'contenttype': %s", literal.Vim(contentType))
 			}
 			buffer.WriteString("},\n")
 			result = buffer.String()
 		} else {
-			result = fmt.Sprintf("  \\{'key': '%s', 'value': \"%s\"},\n", field[0], escapeDQ(field[1]))
+			result = fmt.Sprintf("  \\{'key': %s, 'value': %s},\n", literal.Vim(field[0]), literal.Vim(field[1]))
 		}
 	case common.FormStringType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
-		result = fmt.Sprintf("  \\{'key': '%s', 'value': \"%s\"},\n", field[0], escapeDQ(field[1]))
+		result = fmt.Sprintf("  \\{'key': %s, 'value': %s},\n", literal.Vim(field[0]), literal.Vim(field[1]))
 	}
 	return result
 }
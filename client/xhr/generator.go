@@ -4,15 +4,10 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/shibukawa/curl_as_dsl/common"
-	"net/url"
-	"os"
+	"github.com/shibukawa/curl_as_dsl/literal"
 	"strings"
 )
 
-func escapeDQ(src string) string {
-	return strings.Replace(strings.Replace(src, "\"", "\\\"", -1), "\\", "\\\\", -1)
-}
-
 type ExternalFile struct {
 	Data         *common.DataOption
 	FileName     string
@@ -49,37 +44,70 @@ func NewXHRGenerator(options *common.CurlOptions) *XHRGenerator {
 
 func (self XHRGenerator) Url() string {
 	if self.extraUrl != "" {
-		return fmt.Sprintf(`"%s" + "?" + %s`, self.Options.Url, self.extraUrl)
+		return fmt.Sprintf(`%s + "?" + %s`, literal.JavaScript(self.Options.Url), self.extraUrl)
 	}
-	return fmt.Sprintf(`"%s"`, self.Options.Url)
+	return literal.JavaScript(self.Options.Url)
 }
 
 func (self XHRGenerator) Method() string {
-	return self.Options.Method()
+	return literal.JavaScript(self.Options.Method())
+}
+
+/*
+	Browsers answer Digest challenge by themselves with credentials passed to xhr.open().
+	Scripts can't do it because WebCrypto doesn't provide MD5 and the browser consumes the 401 response.
+*/
+func (self XHRGenerator) Credentials() string {
+	if !self.Options.UseDigestAuth() {
+		return ""
+	}
+	user, password := self.Options.UserAndPassword()
+	return fmt.Sprintf(", %s, %s", literal.JavaScript(user), literal.JavaScript(password))
 }
 
+/*
+	Browsers don't allow scripts to set Cookie header and to access cookie files.
+	Cookies are passed via document.cookie and browser's cookie storage is used as a cookie jar.
+*/
 func (self XHRGenerator) PrepareOptions() string {
 	var buffer bytes.Buffer
-	if len(self.processedHeaders) != 0 || len(self.specialHeaders) != 0 {
-		first := true
-		for _, header := range self.processedHeaders {
-			for _, value := range header.Values {
-				if first {
-					first = false
-				} else {
-					buffer.WriteString("    ")
-				}
-				fmt.Fprintf(&buffer, "xhr.setRequestHeader(\"%s\", \"%s\")\n", header.Key, value)
-			}
+	first := true
+	newLine := func() {
+		if first {
+			first = false
+		} else {
+			buffer.WriteString("    ")
 		}
-		for _, headers := range self.specialHeaders {
-			if first {
-				first = false
-			} else {
-				buffer.WriteString("    ")
-			}
-			fmt.Fprintf(&buffer, "xhr.setRequestHeader(\"%s\", %s)\n", headers[0], headers[1])
+	}
+	for _, header := range self.processedHeaders {
+		for _, value := range header.Values {
+			newLine()
+			fmt.Fprintf(&buffer, "xhr.setRequestHeader(%s, %s)\n", literal.JavaScript(header.Key), literal.JavaScript(value))
+		}
+	}
+	for _, headers := range self.specialHeaders {
+		newLine()
+		fmt.Fprintf(&buffer, "xhr.setRequestHeader(%s, %s)\n", literal.JavaScript(headers[0]), headers[1])
+	}
+	if self.Options.UseCookieJar() {
+		newLine()
+		buffer.WriteString("// cookie files can't be read or written by scripts. browser's cookie storage is used instead\n")
+		for _, fileName := range self.Options.CookieFiles() {
+			newLine()
+			fmt.Fprintf(&buffer, "// cookie file: %s\n", fileName)
 		}
+		if self.Options.CookieJar != "" {
+			newLine()
+			fmt.Fprintf(&buffer, "// cookie jar: %s\n", self.Options.CookieJar)
+		}
+	}
+	for _, cookie := range self.Options.Cookies() {
+		newLine()
+		fmt.Fprintf(&buffer, "document.cookie = %s;\n", literal.JavaScript(cookie[0]+"="+cookie[1]))
+	}
+	if self.Options.UseCookieJar() || len(self.Options.Cookies()) != 0 {
+		newLine()
+		buffer.WriteString("xhr.withCredentials = true;\n")
 	}
 	return buffer.String()
 }
@@ -107,7 +135,7 @@ func (self *XHRGenerator) FileReader() string {
 	return fmt.Sprintf("fileReader%d", index)
 }
 
-func (self *XHRGenerator) SetDataForUrl() {
+func (self *XHRGenerator) SetDataForUrl() error {
 	if self.Options.CanUseSimpleForm() {
 		self.SetDataForForm(false)
 		self.extraUrl = self.Body
@@ -115,11 +143,14 @@ func (self *XHRGenerator) SetDataForUrl() {
 		self.HasBody = false
 	} else {
 		// Use bytes.Buffer to create URL option string
-		self.SetDataForBody()
+		if err := self.SetDataForBody(); err != nil {
+			return err
+		}
 		self.extraUrl = self.Body
 		self.Body = ""
 		self.HasBody = false
 	}
+	return nil
 }
 
 func (self *XHRGenerator) VariableName(data *common.DataOption) string {
@@ -131,16 +162,22 @@ func (self *XHRGenerator) VariableName(data *common.DataOption) string {
 	return ""
 }
 
-func (self *XHRGenerator) SetDataForBody() {
-	var prepareFile string
+func (self *XHRGenerator) SetDataForBody() error {
 	if len(self.Options.ProcessedData) == 1 {
-		self.Body, prepareFile = NewStringForData(self, &self.Options.ProcessedData[0])
+		body, prepareFile, err := NewStringForData(self, &self.Options.ProcessedData[0])
+		if err != nil {
+			return err
+		}
+		self.Body = body
 		self.prepareFile.WriteString(prepareFile)
 	} else {
 		var buffer bytes.Buffer
 		buffer.WriteString("\n    var content = [\n")
 		for _, data := range self.Options.ProcessedData {
-			dataStr, _ := StringForData(self, &data)
+			dataStr, _, err := StringForData(self, &data)
+			if err != nil {
+				return err
+			}
 			fmt.Fprintf(&buffer, "        %s,\n", dataStr)
 		}
 		buffer.WriteString("    ];\n")
@@ -148,16 +185,18 @@ func (self *XHRGenerator) SetDataForBody() {
 		self.Body = `content.join("&")`
 	}
 	self.HasBody = true
+	return nil
 }
 
 func (self *XHRGenerator) SetDataForForm(hasIndent bool) {
 	var buffer bytes.Buffer
 	buffer.WriteString("\n    var query = [\n")
 	for _, data := range self.Options.ProcessedData {
-		singleData, _ := url.ParseQuery(data.Value)
-		for key, values := range singleData {
+		keys, singleData := data.FormValues()
+		for _, key := range keys {
+			values := singleData[key]
 			for _, value := range values {
-				buffer.WriteString(fmt.Sprintf("        encodeURIComponent(\"%s\") + \"=\" + encodeURIComponent(\"%s\"),\n", escapeDQ(key), escapeDQ(value)))
+				fmt.Fprintf(&buffer, "        encodeURIComponent(%s) + \"=\" + encodeURIComponent(%s),\n", literal.JavaScript(key), literal.JavaScript(value))
 			}
 		}
 	}
@@ -187,7 +226,11 @@ func (self *XHRGenerator) SetFormForBody() {
 	Dispatcher function of curl command
 	This is an exported function and called from httpgen.
 */
-func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}) {
+func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}, error) {
+	err := checkOptions(options)
+	if err != nil {
+		return "", nil, err
+	}
 	generator := NewXHRGenerator(options)
 
 	for i, data := range options.ProcessedData {
@@ -225,33 +268,64 @@ func ProcessCurlCommand(options *common.CurlOptions) (string, interface{}) {
 		templateName = "external_files"
 	}
 
-	if generator.Options.User != "" {
-		generator.specialHeaders = append(generator.specialHeaders, []string{"Authorization", fmt.Sprintf(`"Basic " + btoa("%s")`, generator.Options.User)})
+	if generator.Options.UseBasicAuth() {
+		generator.specialHeaders = append(generator.specialHeaders, []string{"Authorization", fmt.Sprintf(`"Basic " + btoa(%s)`, literal.JavaScript(generator.Options.User))})
 	}
 
 	if options.ProcessedData.HasData() {
 		if options.Get {
-			generator.SetDataForUrl()
+			if err := generator.SetDataForUrl(); err != nil {
+				return "", nil, err
+			}
 		} else {
-			generator.Options.InsertContentTypeHeader("application/x-www-form-urlencoded")
-			generator.SetDataForBody()
+			generator.Options.InsertDataContentTypeHeader()
+			if err := generator.SetDataForBody(); err != nil {
+				return "", nil, err
+			}
 		}
 	} else if options.ProcessedData.HasForm() {
 		generator.SetFormForBody()
 	} else if options.Method() == "GET" && len(generator.processedHeaders) == 0 && len(generator.specialHeaders) == 0 {
 	}
 
-	return templateName, *generator
+	return templateName, *generator, nil
 }
 
 // helper functions
 
-func NewStringForData(generator *XHRGenerator, data *common.DataOption) (string, string) {
+/*
+	Scripts can read only files dropped by user and FormData can't specify content-type.
+*/
+func checkOptions(options *common.CurlOptions) error {
+	if options.ProcessedData.HasData() && len(options.ProcessedData) > 1 && options.ProcessedData.ExternalFileCount() > 0 {
+		return &common.UnsupportedOptionError{Target: "xhr", Option: "-d", Reason: "XHR generator doesn't support sending any file except form(-F)"}
+	}
+	for _, data := range options.ProcessedData {
+		if data.Type != common.FormType {
+			continue
+		}
+		field := strings.SplitN(data.Value, "=", 2)
+		if !strings.HasPrefix(field[1], "@") && !strings.HasPrefix(field[1], "<") {
+			continue
+		}
+		for _, fragment := range strings.Split(field[1][1:], ";")[1:] {
+			if strings.HasPrefix(fragment, "type=") {
+				return &common.UnsupportedOptionError{Target: "xhr", Option: "-F", Reason: "XHR doesn't support sending content-type"}
+			}
+		}
+		if strings.HasPrefix(field[1], "<") && options.ProcessedData.ExternalFileCount() > 1 {
+			return &common.UnsupportedOptionError{Target: "xhr", Option: "-F", Reason: "XHR generator doesn't support sending multiple files except multipart form"}
+		}
+	}
+	return nil
+}
+
+func NewStringForData(generator *XHRGenerator, data *common.DataOption) (string, string, error) {
 	var result string
 	var prepare bytes.Buffer
 	switch data.Type {
 	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = "file"
 			prepare.WriteString(`
     var reader = new FileReader();
@@ -262,10 +336,15 @@ func NewStringForData(generator *XHRGenerator, data *common.DataOption) (string,
     };
     reader.readAsText(file, "UTF-8");`)
 		} else {
-			result = fmt.Sprintf("\"%s\"", escapeDQ(data.Value))
+			result = literal.JavaScript(data.Value)
 		}
 	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.Upload {
+			// Blob without type doesn't send Content-Type like -T. Text is sent as text/plain
+			result = "file"
+			prepare.WriteString(`
+    request(new Blob([file]));`)
+		} else if data.UseExternalFile() {
 			result = "file"
 			prepare.WriteString(`
     var reader = new FileReader();
@@ -276,10 +355,10 @@ func NewStringForData(generator *XHRGenerator, data *common.DataOption) (string,
     };
     reader.readAsText(file, "UTF-8");`)
 		} else {
-			result = fmt.Sprintf("\"%s\"", escapeDQ(data.Value))
+			result = literal.JavaScript(data.Value)
 		}
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
+		if data.UseExternalFile() {
 			result = "file"
 			prepare.WriteString(`
     var reader = new FileReader();
@@ -290,43 +369,27 @@ func NewStringForData(generator *XHRGenerator, data *common.DataOption) (string,
     };
     reader.readAsText(file, "UTF-8");`)
 		} else {
-			result = fmt.Sprintf(`encodeURIComponent("%s")`, escapeDQ(data.Value))
+			result = fmt.Sprintf("encodeURIComponent(%s)", literal.JavaScript(data.Value))
 		}
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return "", "", common.UnexpectedDataError("xhr", data)
 	}
-	return result, prepare.String()
+	return result, prepare.String(), nil
 }
 
-func StringForData(generator *XHRGenerator, data *common.DataOption) (string, string) {
+func StringForData(generator *XHRGenerator, data *common.DataOption) (string, string, error) {
 	var result string
 	var prepare bytes.Buffer
 	switch data.Type {
-	case common.DataAsciiType:
-		if strings.HasPrefix(data.Value, "@") {
-			fmt.Fprintf(os.Stderr, "XHR generator doesn't support sending any file except form(-F).")
-			os.Exit(1)
-		} else {
-			result = fmt.Sprintf("\"%s\"", escapeDQ(data.Value))
-		}
-	case common.DataBinaryType:
-		if strings.HasPrefix(data.Value, "@") {
-			fmt.Fprintf(os.Stderr, "XHR generator doesn't support sending any file except form(-F).")
-			os.Exit(1)
-		} else {
-			result = fmt.Sprintf("\"%s\"", escapeDQ(data.Value))
-		}
+	// files are rejected by checkOptions()
+	case common.DataAsciiType, common.DataBinaryType:
+		result = literal.JavaScript(data.Value)
 	case common.DataUrlEncodeType:
-		if strings.HasPrefix(data.Value, "@") {
-			fmt.Fprintf(os.Stderr, "XHR generator doesn't support sending any file except form(-F).")
-			os.Exit(1)
-		} else {
-			result = fmt.Sprintf("encodeURIComponent(\"%s\")", escapeDQ(data.Value))
-		}
+		result = fmt.Sprintf("encodeURIComponent(%s)", literal.JavaScript(data.Value))
 	default:
-		panic(fmt.Sprintf("unknown type: %d", data.Type))
+		return "", "", common.UnexpectedDataError("xhr", data)
 	}
-	return result, prepare.String()
+	return result, prepare.String(), nil
 }
 
 func FormString(generator *XHRGenerator, data *common.DataOption) (string, string) {
@@ -335,10 +398,6 @@ func FormString(generator *XHRGenerator, data *common.DataOption) (string, strin
 	switch data.Type {
 	case common.FormType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
 		if strings.HasPrefix(field[1], "@") {
 			fragments := strings.Split(field[1][1:], ";")
 
@@ -346,23 +405,20 @@ func FormString(generator *XHRGenerator, data *common.DataOption) (string, strin
 			for _, fragment := range fragments[1:] {
 				if strings.HasPrefix(fragment, "filename=") {
 					sentFileName = fragment[9:]
-				} else if strings.HasPrefix(fragment, "type=") {
-					fmt.Fprintf(os.Stderr, "XHR doesn't support sending content-type.")
-					os.Exit(1)
 				}
 			}
 			if len(generator.ExternalFiles) == 1 {
 				if sentFileName != "" {
-					fmt.Fprintf(&buffer, "    form.append(\"%s\", file, \"%s\");\n", field[0], sentFileName)
+					fmt.Fprintf(&buffer, "    form.append(%s, file, %s);\n", literal.JavaScript(field[0]), literal.JavaScript(sentFileName))
 				} else {
-					fmt.Fprintf(&buffer, "    form.append(\"%s\", file);\n", field[0])
+					fmt.Fprintf(&buffer, "    form.append(%s, file);\n", literal.JavaScript(field[0]))
 				}
 				prepare.WriteString("request(file);")
 			} else {
 				if sentFileName != "" {
-					fmt.Fprintf(&buffer, "    form.append(\"%s\", files.%s, \"%s\");\n", field[0], generator.VariableName(data), sentFileName)
+					fmt.Fprintf(&buffer, "    form.append(%s, files.%s, %s);\n", literal.JavaScript(field[0]), generator.VariableName(data), literal.JavaScript(sentFileName))
 				} else {
-					fmt.Fprintf(&buffer, "    form.append(\"%s\", files.%s);\n", field[0], generator.VariableName(data))
+					fmt.Fprintf(&buffer, "    form.append(%s, files.%s);\n", literal.JavaScript(field[0]), generator.VariableName(data))
 				}
 				fmt.Fprintf(&buffer, `files.%s = file;
     request();`, generator.VariableName(data))
@@ -370,18 +426,7 @@ func FormString(generator *XHRGenerator, data *common.DataOption) (string, strin
 			// sent file name
 			// field name, source file name
 		} else if strings.HasPrefix(field[1], "<") {
-			if len(generator.ExternalFiles) > 1 {
-				fmt.Fprintf(os.Stderr, "XHR generator doesn't support sending multiple files except multipart form.")
-				os.Exit(1)
-			}
-			fragments := strings.Split(field[1][1:], ";")
-			for _, fragment := range fragments[1:] {
-				if strings.HasPrefix(fragment, "type=") {
-					fmt.Fprintf(os.Stderr, "XHR doesn't support sending content-type.")
-					os.Exit(1)
-				}
-			}
-			fmt.Fprintf(&buffer, "    form.append(\"%s\", file);\n", field[0])
+			fmt.Fprintf(&buffer, "    form.append(%s, file);\n", literal.JavaScript(field[0]))
 			prepare.WriteString(`
     var reader = new FileReader();
     reader.onloadend = function(evt) {
@@ -391,14 +436,11 @@ func FormString(generator *XHRGenerator, data *common.DataOption) (string, strin
     };
     reader.readAsText(file, "UTF-8");`)
 		} else {
-			fmt.Fprintf(&buffer, "    form.append(\"%s\", \"%s\");\n", field[0], field[1])
+			fmt.Fprintf(&buffer, "    form.append(%s, %s);\n", literal.JavaScript(field[0]), literal.JavaScript(field[1]))
 		}
 	case common.FormStringType:
 		field := strings.SplitN(data.Value, "=", 2)
-		if len(field) != 2 {
-			fmt.Fprintln(os.Stderr, "Warning: Illegally formatted input field!\ncurl: option -F: is badly used here")
-			os.Exit(1)
-		}
+		fmt.Fprintf(&buffer, "    form.append(%s, %s);\n", literal.JavaScript(field[0]), literal.JavaScript(field[1]))
 	}
 	return buffer.String(), prepare.String()
 }
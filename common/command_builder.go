@@ -0,0 +1,299 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/*
+	Output style of ToCurl().
+	Multiline puts each option on its own line with line continuation.
+	Windows quotes words for cmd.exe like "Copy as cURL (cmd)" of browsers instead of POSIX shell.
+*/
+type CurlStyle struct {
+	Multiline bool
+	Windows   bool
+}
+
+/*
+	Build normalized curl command from options. It is the reverse of ParseCurlCommand().
+	Options are written in fixed order with short names if curl has them. Redundant -X and --basic are removed.
+	Headers added by -A, -e, --compressed and --tr-encoding are written as the original options.
+*/
+func (self *CurlOptions) ToCurl(style CurlStyle) string {
+	quote := posixQuote
+	continuation := " \\\n  "
+	if style.Windows {
+		quote = windowsQuote
+		continuation = " ^\n  "
+	}
+	separator := " "
+	if style.Multiline {
+		separator = continuation
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("curl ")
+	for i, arg := range self.curlArgs() {
+		if i > 0 {
+			buffer.WriteString(separator)
+		}
+		if len(arg) == 2 && strings.HasPrefix(arg[1], "-") {
+			// curl and ParseCurlCommand() can read the value of short option without space
+			if len(arg[0]) == 2 {
+				buffer.WriteString(quote(arg[0] + arg[1]))
+				continue
+			}
+		}
+		for j, word := range arg {
+			if j > 0 {
+				buffer.WriteByte(' ')
+			}
+			buffer.WriteString(quote(word))
+		}
+	}
+	return buffer.String()
+}
+
+/*
+	Each item is an option and its value, or the url.
+*/
+func (self *CurlOptions) curlArgs() [][]string {
+	var args [][]string
+	add := func(words ...string) {
+		args = append(args, words)
+	}
+	formatSeconds := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	if strings.HasPrefix(self.Url, "-") {
+		add("--url", self.Url)
+	} else {
+		add(self.Url)
+	}
+	if method := strings.ToUpper(self.Request); method != "" && method != self.implicitMethod() {
+		add("-X", method)
+	}
+	if self.Get {
+		add("-G")
+	}
+	if self.Head {
+		add("-I")
+	}
+
+	headers := append([]string(nil), self.Header...)
+	headers, userAgent := takeHeader(headers, "User-Agent")
+	headers, referer := takeHeader(headers, "Referer")
+	headers, compressed := takeExactHeaders(headers, "Accept-Encoding: deflate", "Accept-Encoding: gzip")
+	headers, trEncoding := takeExactHeaders(headers, "Te: gzip")
+	if userAgent != "" {
+		add("-A", userAgent)
+	}
+	if referer != "" {
+		add("-e", referer)
+	}
+	for _, header := range headers {
+		add("-H", header)
+	}
+	if compressed {
+		add("--compressed")
+	}
+	if trEncoding {
+		add("--tr-encoding")
+	}
+
+	for _, cookie := range self.Cookie {
+		add("-b", cookie)
+	}
+	if self.CookieJar != "" {
+		add("-c", self.CookieJar)
+	}
+	if self.User != "" {
+		add("-u", self.User)
+	}
+	if self.Digest {
+		add("--digest")
+	}
+	if self.AWSV2 != "" {
+		add("--awsv2", self.AWSV2)
+	}
+
+	for _, data := range self.ProcessedData {
+		if data.Upload {
+			add(data.Option(), data.Value[1:])
+		} else {
+			add(data.Option(), data.Value)
+		}
+	}
+
+	if self.Proxy != "" {
+		add("-x", self.Proxy)
+	}
+	if self.Insecure {
+		add("-k")
+	}
+	if self.ConnectTimeout != 0 {
+		add("--connect-timeout", formatSeconds(self.ConnectTimeout))
+	}
+	if self.MaxTime != 0 {
+		add("-m", formatSeconds(self.MaxTime))
+	}
+	if self.Http2Flag {
+		add("--http2")
+	}
+	return args
+}
+
+/*
+	Take the header out of headers if it is the only one that has the name.
+	It returns empty value when it can't be written as another option.
+*/
+func takeHeader(headers []string, name string) ([]string, string) {
+	found := -1
+	for i, header := range headers {
+		fragments := strings.SplitN(header, ":", 2)
+		if len(fragments) == 2 && strings.EqualFold(strings.TrimSpace(fragments[0]), name) {
+			if found != -1 {
+				return headers, ""
+			}
+			found = i
+		}
+	}
+	if found == -1 {
+		return headers, ""
+	}
+	value := strings.TrimSpace(strings.SplitN(headers[found], ":", 2)[1])
+	if value == "" {
+		// "-H 'Name:'" removes the header. It is not same as -A ''
+		return headers, ""
+	}
+	return append(headers[:found], headers[found+1:]...), value
+}
+
+/*
+	Take all of the headers out of headers only when they all exist.
+*/
+func takeExactHeaders(headers []string, targets ...string) ([]string, bool) {
+	indexes := make(map[int]bool)
+	for _, target := range targets {
+		found := false
+		for i, header := range headers {
+			if header == target && !indexes[i] {
+				indexes[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return headers, false
+		}
+	}
+	var result []string
+	for i, header := range headers {
+		if !indexes[i] {
+			result = append(result, header)
+		}
+	}
+	return result, true
+}
+
+var posixSafeWord = regexp.MustCompile(`^[A-Za-z0-9_\-.,:/@%+=]+$`)
+
+/*
+	Quote the word for POSIX shell. Words that have control characters use $'...' ANSI-C quoting.
+*/
+func posixQuote(word string) string {
+	if posixSafeWord.MatchString(word) {
+		return word
+	}
+	hasControl := false
+	for _, c := range []byte(word) {
+		if c < 0x20 || c == 0x7f {
+			hasControl = true
+			break
+		}
+	}
+	if !hasControl {
+		return "'" + strings.Replace(word, "'", `'\''`, -1) + "'"
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("$'")
+	for _, c := range []byte(word) {
+		switch c {
+		case '\\', '\'':
+			buffer.WriteByte('\\')
+			buffer.WriteByte(c)
+		case '\n':
+			buffer.WriteString(`\n`)
+		case '\r':
+			buffer.WriteString(`\r`)
+		case '\t':
+			buffer.WriteString(`\t`)
+		default:
+			if c < 0x20 || c == 0x7f {
+				fmt.Fprintf(&buffer, `\x%02x`, c)
+			} else {
+				buffer.WriteByte(c)
+			}
+		}
+	}
+	buffer.WriteByte('\'')
+	return buffer.String()
+}
+
+var windowsSafeWord = regexp.MustCompile(`^[A-Za-z0-9_\-.:/@+]+$`)
+
+/*
+	Quote the word for Microsoft C runtime first, then escape it for cmd.exe.
+	All double quotes are escaped by ^ so that cmd.exe never enters quoted string and
+	every special character is escaped by ^. "%" before a name is followed by ^ to stop variable expansion.
+	Line feed is written as "^" + LF + LF. CR is not kept.
+*/
+func windowsQuote(word string) string {
+	if windowsSafeWord.MatchString(word) {
+		return word
+	}
+	var quoted bytes.Buffer
+	quoted.WriteByte('"')
+	backslashes := 0
+	for _, c := range []byte(word) {
+		switch c {
+		case '\\':
+			backslashes++
+			continue
+		case '"':
+			quoted.WriteString(strings.Repeat(`\`, backslashes*2+1))
+		default:
+			quoted.WriteString(strings.Repeat(`\`, backslashes))
+		}
+		quoted.WriteByte(c)
+		backslashes = 0
+	}
+	quoted.WriteString(strings.Repeat(`\`, backslashes*2))
+	quoted.WriteByte('"')
+
+	var buffer bytes.Buffer
+	src := quoted.Bytes()
+	for i, c := range src {
+		switch c {
+		case '^', '&', '|', '<', '>', '(', ')', '"', '!':
+			buffer.WriteByte('^')
+			buffer.WriteByte(c)
+		case '%':
+			buffer.WriteByte(c)
+			if next := src[i+1]; next == '_' || ('a' <= next && next <= 'z') || ('A' <= next && next <= 'Z') || ('0' <= next && next <= '9') {
+				buffer.WriteByte('^')
+			}
+		case '\n':
+			buffer.WriteString("^\n\n")
+		case '\r':
+		default:
+			buffer.WriteByte(c)
+		}
+	}
+	return buffer.String()
+}
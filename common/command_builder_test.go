@@ -0,0 +1,56 @@
+package common
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type CommandBuilderTest struct{}
+
+var _ = Suite(&CommandBuilderTest{})
+
+func (s *CommandBuilderTest) Test_ToCurl_Normalize(c *C) {
+	options, err := ParseCurlCommand("curl --url http://localhost:18888 --request post --data-ascii a=b --header 'Accept: text/html' --user-agent 'Mozilla/5.0 (X11)' --insecure --basic")
+	c.Assert(err, IsNil)
+	c.Check(options.ToCurl(CurlStyle{}), Equals, "curl http://localhost:18888 -A 'Mozilla/5.0 (X11)' -H 'Accept: text/html' -d a=b -k")
+}
+
+func (s *CommandBuilderTest) Test_ToCurl_SynthesizedHeaders(c *C) {
+	options, err := ParseCurlCommand("curl -e http://example.com --compressed -H 'Accept-Encoding: br' --tr-encoding -H 'User-Agent: a' -H 'User-Agent: b' http://localhost:18888")
+	c.Assert(err, IsNil)
+	c.Check(options.ToCurl(CurlStyle{}), Equals, "curl http://localhost:18888 -e http://example.com -H 'Accept-Encoding: br' -H 'User-Agent: a' -H 'User-Agent: b' --compressed --tr-encoding")
+}
+
+func (s *CommandBuilderTest) Test_ToCurl_Multiline(c *C) {
+	options, err := ParseCurlCommand("curl -X PUT -d test -m 1.5 http://localhost:18888")
+	c.Assert(err, IsNil)
+	c.Check(options.ToCurl(CurlStyle{Multiline: true}), Equals, "curl http://localhost:18888 \\\n  -X PUT \\\n  -d test \\\n  -m 1.5")
+	c.Check(options.ToCurl(CurlStyle{Multiline: true, Windows: true}), Equals, "curl http://localhost:18888 ^\n  -X PUT ^\n  -d test ^\n  -m 1.5")
+}
+
+func (s *CommandBuilderTest) Test_ToCurl_PosixQuote(c *C) {
+	c.Check(posixQuote("a=b&c"), Equals, "'a=b&c'")
+	c.Check(posixQuote("it's"), Equals, `'it'\''s'`)
+	c.Check(posixQuote("a\n'b'\x01"), Equals, `$'a\n\'b\'\x01'`)
+	c.Check(posixQuote(""), Equals, "''")
+}
+
+func (s *CommandBuilderTest) Test_ToCurl_WindowsQuote(c *C) {
+	c.Check(windowsQuote("a=b&c"), Equals, `^"a=b^&c^"`)
+	c.Check(windowsQuote(`say "hi" %PATH% 100%`), Equals, `^"say \^"hi\^" %^PATH% 100%^"`)
+	c.Check(windowsQuote(`C:\dir\`), Equals, `^"C:\dir\\^"`)
+	c.Check(windowsQuote(""), Equals, `^"^"`)
+}
+
+func (s *CommandBuilderTest) Test_ToCurl_RoundTrip(c *C) {
+	options, err := ParseCurlCommand(`curl 'http://localhost:18888/?a=1&b=2' -H 'X-Test: "100%" & <tag>' --data-binary $'line1\nline2\\' -F 'file=@C:\tmp\file.txt;type=text/plain' -d-dash -u 'user:pa ss'`)
+	c.Assert(err, IsNil)
+	for _, style := range []CurlStyle{{}, {Multiline: true}, {Windows: true}, {Multiline: true, Windows: true}} {
+		command := options.ToCurl(style)
+		parsed, err := ParseCurlCommand(command)
+		c.Assert(err, IsNil, Commentf("%s", command))
+		c.Check(parsed.Url, Equals, options.Url)
+		c.Check(parsed.Header, DeepEquals, options.Header)
+		c.Check(parsed.ProcessedData, DeepEquals, options.ProcessedData)
+		c.Check(parsed.User, Equals, options.User)
+	}
+}
@@ -0,0 +1,394 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/jessevdk/go-flags"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type CommandParseError struct {
+	Reason string
+}
+
+func (self *CommandParseError) Error() string {
+	return fmt.Sprintf("can't parse curl command: %s", self.Reason)
+}
+
+/*
+	Parse whole curl command line like "curl -H 'Accept: text/html' http://example.com".
+	It accepts bash style (Copy as cURL (bash) of browsers) and Windows cmd.exe style
+	(Copy as cURL (cmd)) commands. Leading "curl" is optional.
+	curl can take multiple URLs but generators use only the first one.
+	Others are stored in RemainingUrls.
+*/
+func ParseCurlCommand(command string) (*CurlOptions, error) {
+	var args []string
+	var err error
+	if isWindowsCommand(command) {
+		args, err = splitWindowsCommand(command)
+	} else {
+		args, err = splitPosixCommand(command)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 0 && isCurl(args[0]) {
+		args = args[1:]
+	}
+
+	options := &CurlOptions{}
+	options.Init()
+	parser := flags.NewParser(options, flags.PassDoubleDash)
+	urls, err := parser.ParseArgs(args)
+	if err != nil {
+		return nil, &CommandParseError{Reason: err.Error()}
+	}
+	if options.Url == "" && len(urls) > 0 {
+		options.Url = urls[0]
+		urls = urls[1:]
+	}
+	options.AppendUploadFileName()
+	if len(urls) > 0 {
+		options.RemainingUrls = urls
+		options.AddWarning("It accept only one url. Remained urls are ignored: %s", strings.Join(urls, ", "))
+	}
+	return options, nil
+}
+
+func isCurl(command string) bool {
+	name := strings.ToLower(path.Base(strings.Replace(command, "\\", "/", -1)))
+	return name == "curl" || name == "curl.exe"
+}
+
+var windowsCommandPattern = regexp.MustCompile(`\^\r?\n|\^"`)
+
+/*
+	Windows style command has "^" escapes. Texts in single quotes are skipped because they are
+	literal in POSIX shell (e.g. curl -H 'a: ^"' URL) and cmd.exe doesn't use single quotes.
+*/
+func isWindowsCommand(command string) bool {
+	for {
+		start := strings.IndexByte(command, '\'')
+		if start == -1 {
+			return windowsCommandPattern.MatchString(command)
+		}
+		if windowsCommandPattern.MatchString(command[:start]) {
+			return true
+		}
+		end := strings.IndexByte(command[start+1:], '\'')
+		if end == -1 {
+			return windowsCommandPattern.MatchString(command[start+1:])
+		}
+		command = command[start+1+end+1:]
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+/*
+	Split command with the rule of POSIX shell.
+	It supports quotes, backslash escape, line continuation and $'...' ANSI-C quoting.
+	Parameter expansions are not supported.
+*/
+func splitPosixCommand(command string) ([]string, error) {
+	var args []string
+	var buffer bytes.Buffer
+	inArg := false
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case c == '\\':
+			if i+1 == len(command) {
+				continue
+			}
+			i++
+			if command[i] == '\n' {
+				continue
+			}
+			if command[i] == '\r' && i+1 < len(command) && command[i+1] == '\n' {
+				i++
+				continue
+			}
+			buffer.WriteByte(command[i])
+			inArg = true
+		case c == '\'':
+			end := strings.IndexByte(command[i+1:], '\'')
+			if end == -1 {
+				return nil, &CommandParseError{Reason: "unterminated single quote"}
+			}
+			buffer.WriteString(command[i+1 : i+1+end])
+			i = i + 1 + end
+			inArg = true
+		case c == '$' && i+1 < len(command) && command[i+1] == '\'':
+			next, err := parseAnsiCString(command, i+2, &buffer)
+			if err != nil {
+				return nil, err
+			}
+			i = next
+			inArg = true
+		case c == '"':
+			i++
+			for ; i < len(command) && command[i] != '"'; i++ {
+				if command[i] == '\\' && i+1 < len(command) && strings.IndexByte("$`\"\\\n", command[i+1]) != -1 {
+					i++
+					if command[i] == '\n' {
+						continue
+					}
+				}
+				buffer.WriteByte(command[i])
+			}
+			if i == len(command) {
+				return nil, &CommandParseError{Reason: "unterminated double quote"}
+			}
+			inArg = true
+		case c == '#' && !inArg:
+			for i < len(command) && command[i] != '\n' {
+				i++
+			}
+		case isSpace(c):
+			if inArg {
+				args = append(args, buffer.String())
+				buffer.Reset()
+				inArg = false
+			}
+		default:
+			buffer.WriteByte(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, buffer.String())
+	}
+	return args, nil
+}
+
+var ansiCEscapes = map[byte]byte{
+	'a': '\a', 'b': '\b', 'e': 0x1b, 'E': 0x1b, 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t', 'v': '\v',
+	'\\': '\\', '\'': '\'', '"': '"', '?': '?',
+}
+
+/*
+	Read $'...' string from start (next of $') and return the index of closing quote.
+*/
+func parseAnsiCString(command string, start int, buffer *bytes.Buffer) (int, error) {
+	readNumber := func(i, maxDigits, base int) (uint64, int) {
+		end := i
+		for end < len(command) && end-i < maxDigits {
+			if _, err := strconv.ParseUint(command[end:end+1], base, 8); err != nil {
+				break
+			}
+			end++
+		}
+		if end == i {
+			return 0, i
+		}
+		value, _ := strconv.ParseUint(command[i:end], base, 32)
+		return value, end
+	}
+	for i := start; i < len(command); i++ {
+		c := command[i]
+		if c == '\'' {
+			return i, nil
+		}
+		if c != '\\' || i+1 == len(command) {
+			buffer.WriteByte(c)
+			continue
+		}
+		i++
+		c = command[i]
+		if escaped, ok := ansiCEscapes[c]; ok {
+			buffer.WriteByte(escaped)
+			continue
+		}
+		switch c {
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			value, end := readNumber(i, 3, 8)
+			buffer.WriteByte(byte(value))
+			i = end - 1
+		case 'x', 'u', 'U':
+			digits := map[byte]int{'x': 2, 'u': 4, 'U': 8}[c]
+			value, end := readNumber(i+1, digits, 16)
+			if end == i+1 {
+				buffer.WriteByte('\\')
+				buffer.WriteByte(c)
+				continue
+			}
+			if c == 'x' {
+				buffer.WriteByte(byte(value))
+			} else {
+				var encoded [utf8.UTFMax]byte
+				buffer.Write(encoded[:utf8.EncodeRune(encoded[:], rune(value))])
+			}
+			i = end - 1
+		case 'c':
+			if i+1 < len(command) {
+				i++
+				buffer.WriteByte(command[i] & 0x1f)
+			}
+		default:
+			buffer.WriteByte('\\')
+			buffer.WriteByte(c)
+		}
+	}
+	return 0, &CommandParseError{Reason: "unterminated $'...' string"}
+}
+
+/*
+	Split command with the rule of cmd.exe and Microsoft C runtime.
+	cmd.exe removes ^ escape and line continuation first, then curl.exe splits arguments.
+	Line continuation followed by an empty line is a line feed.
+*/
+func splitWindowsCommand(command string) ([]string, error) {
+	var unescaped bytes.Buffer
+	inQuote := false
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		if c == '"' {
+			inQuote = !inQuote
+		} else if c == '^' && !inQuote {
+			i++
+			if i < len(command) && command[i] == '\r' && i+1 < len(command) && command[i+1] == '\n' {
+				i++
+			}
+			if i == len(command) {
+				continue
+			}
+			if command[i] == '\n' {
+				// "^" + LF + LF is a line feed in the argument
+				if strings.HasPrefix(command[i+1:], "\n") {
+					i++
+					unescaped.WriteByte('\n')
+				} else if strings.HasPrefix(command[i+1:], "\r\n") {
+					i += 2
+					unescaped.WriteByte('\n')
+				}
+				continue
+			}
+			c = command[i]
+		}
+		unescaped.WriteByte(c)
+	}
+
+	src := unescaped.String()
+	var args []string
+	var buffer bytes.Buffer
+	inArg := false
+	inQuote = false
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\\':
+			backslashes := 0
+			for i < len(src) && src[i] == '\\' {
+				backslashes++
+				i++
+			}
+			if i < len(src) && src[i] == '"' {
+				buffer.WriteString(strings.Repeat("\\", backslashes/2))
+				if backslashes%2 == 1 {
+					buffer.WriteByte('"')
+				} else {
+					inQuote = !inQuote
+				}
+			} else {
+				buffer.WriteString(strings.Repeat("\\", backslashes))
+				i--
+			}
+			inArg = true
+		case c == '"':
+			if inQuote && i+1 < len(src) && src[i+1] == '"' {
+				buffer.WriteByte('"')
+				i++
+			} else {
+				inQuote = !inQuote
+			}
+			inArg = true
+		case isSpace(c) && !inQuote:
+			if inArg {
+				args = append(args, buffer.String())
+				buffer.Reset()
+				inArg = false
+			}
+		default:
+			buffer.WriteByte(c)
+			inArg = true
+		}
+	}
+	if inQuote {
+		return nil, &CommandParseError{Reason: "unterminated double quote"}
+	}
+	if inArg {
+		args = append(args, buffer.String())
+	}
+	return args, nil
+}
+
+/*
+	Parse a file of named curl commands like this:
+
+		# Users/Create user
+		curl -X POST -d name=bob http://localhost:18888/users
+
+		# Health check
+		curl http://localhost:18888/health
+
+	A command starts at a line that begins with "curl" and continues until the next command or comment.
+	The first comment before the command is its name. "/" in the name separates groups from the name.
+	Commands without names are named "METHOD /path".
+*/
+func ParseCurlBatch(content []byte) ([]NamedRequest, error) {
+	var result []NamedRequest
+	var name, command string
+	var start int
+	flush := func() error {
+		if command == "" {
+			return nil
+		}
+		options, err := ParseCurlCommand(command)
+		if err != nil {
+			return &ImportError{Format: "batch file", Reason: fmt.Sprintf("line %d: %s", start, err.Error())}
+		}
+		request := NamedRequest{Options: options}
+		if name == "" {
+			request.Name = defaultRequestName(options)
+		} else {
+			fragments := strings.Split(name, "/")
+			for _, group := range fragments[:len(fragments)-1] {
+				request.Groups = append(request.Groups, strings.TrimSpace(group))
+			}
+			request.Name = strings.TrimSpace(fragments[len(fragments)-1])
+		}
+		result = append(result, request)
+		name, command = "", ""
+		return nil
+	}
+	for i, line := range strings.Split(string(content), "\n") {
+		words := strings.Fields(line)
+		if strings.HasPrefix(line, "#") || (len(words) > 0 && isCurl(words[0]) && !isSpace(line[0])) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		if strings.HasPrefix(line, "#") {
+			if name == "" {
+				name = strings.TrimSpace(strings.TrimLeft(line, "#"))
+			}
+		} else if command != "" {
+			command += "\n" + line
+		} else if len(words) > 0 {
+			command = line
+			start = i + 1
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,149 @@
+package common
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type CommandParserTest struct{}
+
+var _ = Suite(&CommandParserTest{})
+
+func (s *CommandParserTest) Test_SplitPosixCommand(c *C) {
+	args, err := splitPosixCommand(`curl -H 'Accept: text/html' -d "a=\"b\" \$c" plain\ text`)
+	c.Assert(err, IsNil)
+	c.Check(args, DeepEquals, []string{"curl", "-H", "Accept: text/html", "-d", `a="b" $c`, "plain text"})
+}
+
+func (s *CommandParserTest) Test_SplitPosixCommand_LineContinuation(c *C) {
+	args, err := splitPosixCommand("curl \\\n  -H 'a: b' \\\r\n  http://localhost")
+	c.Assert(err, IsNil)
+	c.Check(args, DeepEquals, []string{"curl", "-H", "a: b", "http://localhost"})
+}
+
+func (s *CommandParserTest) Test_SplitPosixCommand_AnsiC(c *C) {
+	args, err := splitPosixCommand(`curl --data-binary $'it\'s\t\x41\101\u3042\n'`)
+	c.Assert(err, IsNil)
+	c.Check(args, DeepEquals, []string{"curl", "--data-binary", "it's\tAAあ\n"})
+}
+
+func (s *CommandParserTest) Test_SplitPosixCommand_Unterminated(c *C) {
+	_, err := splitPosixCommand(`curl 'http://localhost`)
+	c.Check(err, FitsTypeOf, &CommandParseError{})
+	_, err = splitPosixCommand(`curl "http://localhost`)
+	c.Check(err, FitsTypeOf, &CommandParseError{})
+	_, err = splitPosixCommand(`curl $'http://localhost`)
+	c.Check(err, FitsTypeOf, &CommandParseError{})
+}
+
+func (s *CommandParserTest) Test_SplitWindowsCommand(c *C) {
+	command := "curl ^\"http://localhost/?a=1^&b=2^\" ^\r\n  -H ^\"Accept: text/html^\" ^\n  --data-raw ^\"^{^\\^\"a^\\^\":1^}^\""
+	c.Check(isWindowsCommand(command), Equals, true)
+	args, err := splitWindowsCommand(command)
+	c.Assert(err, IsNil)
+	c.Check(args, DeepEquals, []string{"curl", "http://localhost/?a=1&b=2", "-H", "Accept: text/html", "--data-raw", `{"a":1}`})
+}
+
+func (s *CommandParserTest) Test_SplitWindowsCommand_PlainQuote(c *C) {
+	args, err := splitWindowsCommand(`curl.exe -H "a: b" "C:\path\file" "say ""hi"""`)
+	c.Assert(err, IsNil)
+	c.Check(args, DeepEquals, []string{"curl.exe", "-H", "a: b", `C:\path\file`, `say "hi"`})
+}
+
+func (s *CommandParserTest) Test_ParseCurlCommand(c *C) {
+	options, err := ParseCurlCommand("curl -X PUT -H 'Accept: text/html' --data-raw '@literal' http://localhost:18888")
+	c.Assert(err, IsNil)
+	c.Check(options.Url, Equals, "http://localhost:18888")
+	c.Check(options.Method(), Equals, "PUT")
+	c.Check(options.Header, DeepEquals, []string{"Accept: text/html"})
+	c.Check(options.ProcessedData, DeepEquals, DataOptions{{Value: "@literal", Type: DataAsciiType, Raw: true}})
+	c.Check(options.ProcessedData[0].UseExternalFile(), Equals, false)
+	c.Check(options.Warnings, IsNil)
+	c.Check(options.ToCurl(CurlStyle{}), Equals, "curl http://localhost:18888 -X PUT -H 'Accept: text/html' --data-raw @literal")
+}
+
+func (s *CommandParserTest) Test_ParseCurlCommand_WithoutCurl(c *C) {
+	options, err := ParseCurlCommand("-G -d a=b http://localhost:18888")
+	c.Assert(err, IsNil)
+	c.Check(options.Url, Equals, "http://localhost:18888")
+	c.Check(options.Method(), Equals, "GET")
+}
+
+func (s *CommandParserTest) Test_ParseCurlCommand_MultipleUrls(c *C) {
+	options, err := ParseCurlCommand("curl http://localhost:18888/a http://localhost:18888/b")
+	c.Assert(err, IsNil)
+	c.Check(options.Url, Equals, "http://localhost:18888/a")
+	c.Check(options.RemainingUrls, DeepEquals, []string{"http://localhost:18888/b"})
+	c.Check(len(options.Warnings), Equals, 1)
+}
+
+func (s *CommandParserTest) Test_ParseCurlCommand_UnknownOption(c *C) {
+	_, err := ParseCurlCommand("curl --no-such-option http://localhost:18888")
+	c.Check(err, FitsTypeOf, &CommandParseError{})
+}
+
+func (s *CommandParserTest) Test_SplitWindowsCommand_LineFeed(c *C) {
+	args, err := splitWindowsCommand("curl -d ^\"a^\n\nb^\" ^\n  http://localhost")
+	c.Assert(err, IsNil)
+	c.Check(args, DeepEquals, []string{"curl", "-d", "a\nb", "http://localhost"})
+}
+
+func (s *CommandParserTest) Test_ParseCurlCommand_HeaderOrder(c *C) {
+	options, err := ParseCurlCommand("curl -A agent --compressed -H 'Accept: text/html' -e http://example.com http://localhost:18888")
+	c.Assert(err, IsNil)
+	c.Check(options.Header, DeepEquals, []string{"User-Agent: agent", "Accept-Encoding: deflate", "Accept-Encoding: gzip", "Accept: text/html", "Referer: http://example.com"})
+}
+
+func (s *CommandParserTest) Test_ParseCurlCommand_DoubleQuotedValue(c *C) {
+	options, err := ParseCurlCommand(`curl -d '"hello"' --data-raw '"a\nb"' -d '"a" "b"' -H '"X": y' http://localhost:18888`)
+	c.Assert(err, IsNil)
+	c.Check(options.ProcessedData, DeepEquals, DataOptions{
+		{Value: `"hello"`, Type: DataAsciiType},
+		{Value: `"a\nb"`, Type: DataAsciiType, Raw: true},
+		{Value: `"a" "b"`, Type: DataAsciiType},
+	})
+	c.Check(options.Header, DeepEquals, []string{`"X": y`})
+	c.Check(options.ToCurl(CurlStyle{}), Equals, `curl http://localhost:18888 -H '"X": y' -d '"hello"' -d '"a\nb"' -d '"a" "b"'`)
+	again, err := ParseCurlCommand(options.ToCurl(CurlStyle{}))
+	c.Assert(err, IsNil)
+	c.Check(again.Header, DeepEquals, options.Header)
+	c.Check(again.ProcessedData[1].Value, Equals, `"a\nb"`)
+}
+
+func (s *CommandParserTest) Test_ParseCurlCommand_CaretInSingleQuote(c *C) {
+	command := `curl -H 'c: ^"' http://localhost:18888`
+	c.Check(isWindowsCommand(command), Equals, false)
+	options, err := ParseCurlCommand(command)
+	c.Assert(err, IsNil)
+	c.Check(options.Header, DeepEquals, []string{`c: ^"`})
+	c.Check(isWindowsCommand(`curl -H 'a: b' -d ^"c^" http://localhost:18888`), Equals, true)
+}
+
+func (s *CommandParserTest) Test_ParseCurlBatch(c *C) {
+	requests, err := ParseCurlBatch([]byte(`# Users/Create user
+# This comment is not a name
+curl -X POST http://localhost:18888/users \
+  -H 'Content-Type: application/json' \
+  -d '{
+  "name": "bob"
+}'
+
+curl http://localhost:18888/health
+# Admin / Reset
+curl.exe -X DELETE http://localhost:18888/
+`))
+	c.Assert(err, IsNil)
+	c.Assert(requests, HasLen, 3)
+	c.Check(requests[0].Name, Equals, "Create user")
+	c.Check(requests[0].Groups, DeepEquals, []string{"Users"})
+	c.Check(requests[0].Options.ProcessedData[0].Value, Equals, "{\n  \"name\": \"bob\"\n}")
+	c.Check(requests[1].Name, Equals, "GET /health")
+	c.Check(requests[1].Groups, IsNil)
+	c.Check(requests[2].Name, Equals, "Reset")
+	c.Check(requests[2].Groups, DeepEquals, []string{"Admin"})
+	c.Check(requests[2].Options.Method(), Equals, "DELETE")
+}
+
+func (s *CommandParserTest) Test_ParseCurlBatch_Error(c *C) {
+	_, err := ParseCurlBatch([]byte("# ok\ncurl http://localhost\n\n# broken\ncurl 'http://localhost\n"))
+	c.Check(err, ErrorMatches, "can't import batch file: line 5: can't parse curl command: .*")
+}
@@ -3,7 +3,7 @@ package common
 import (
 	"fmt"
 	"net/url"
-	"os"
+	"path"
 	"strings"
 )
 
@@ -17,9 +17,46 @@ const (
 	FormStringType
 )
 
+/*
+	Option of curl that makes data of the type.
+*/
+func (self DataType) Option() string {
+	switch self {
+	case DataBinaryType:
+		return "--data-binary"
+	case DataUrlEncodeType:
+		return "--data-urlencode"
+	case FormType:
+		return "-F"
+	case FormStringType:
+		return "--form-string"
+	}
+	return "-d"
+}
+
+/*
+	Raw is true for --data-raw. It sends the value as is even if it starts with "@".
+	Upload is true for -T. Its value is "@" and the file name like --data-binary, but curl sends it
+	with PUT method and without Content-Type.
+*/
 type DataOption struct {
-	Value string
-	Type  DataType
+	Value  string
+	Type   DataType
+	Raw    bool
+	Upload bool
+}
+
+/*
+	Option of curl that makes the data. -d and --data-raw are same unless the value starts with "@".
+*/
+func (self *DataOption) Option() string {
+	if self.Upload {
+		return "-T"
+	}
+	if self.Raw && strings.HasPrefix(self.Value, "@") {
+		return "--data-raw"
+	}
+	return self.Type.Option()
 }
 
 func (self *DataOption) IsFormStyle() bool {
@@ -42,7 +79,7 @@ func (self *DataOption) FileName() string {
 				return strings.Split(self.Value[index+2:], ";")[0]
 			}
 		}
-	} else if self.Type != FormStringType {
+	} else if self.Type != FormStringType && !self.Raw {
 		if strings.HasPrefix(self.Value, "@") {
 			return strings.Split(self.Value[1:], ";")[0]
 		}
@@ -66,12 +103,108 @@ func (self *DataOption) SendAsFormFile() bool {
 	return false
 }
 
+/*
+	Content-Type of the file of -F name=@file. curl guesses it from these extensions.
+*/
+func FormFileContentType(fileName string) string {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".gif":
+		return "image/gif"
+	case ".jpg", ".jpeg":
+		return "image/jpeg"
+	case ".png":
+		return "image/png"
+	case ".svg":
+		return "image/svg+xml"
+	case ".txt":
+		return "text/plain"
+	case ".htm", ".html":
+		return "text/html"
+	case ".pdf":
+		return "application/pdf"
+	case ".xml":
+		return "application/xml"
+	}
+	return "application/octet-stream"
+}
+
+/*
+	Keys and values of "key=value&..." style data. Keys are in the order of the data and
+	they are decoded like url.ParseQuery(). Pairs that can't be decoded are skipped.
+*/
+func (self *DataOption) FormValues() ([]string, url.Values) {
+	var keys []string
+	values := make(url.Values)
+	for _, pair := range strings.Split(self.Value, "&") {
+		if pair == "" {
+			continue
+		}
+		words := strings.SplitN(pair, "=", 2)
+		key, err := url.QueryUnescape(words[0])
+		if err != nil {
+			continue
+		}
+		var value string
+		if len(words) == 2 {
+			if value, err = url.QueryUnescape(words[1]); err != nil {
+				continue
+			}
+		}
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = append(values[key], value)
+	}
+	return keys, values
+}
+
+/*
+	Parts of --data-urlencode value. It has these styles like curl:
+
+		content        content is encoded
+		=content       content is encoded
+		name=content   only content is encoded
+		@file          content of the file is encoded
+		name@file      content of the file is encoded
+
+	isFile is true when content is a file name.
+*/
+func (self *DataOption) UrlEncodeParts() (name, content string, isFile bool) {
+	equal := strings.IndexByte(self.Value, '=')
+	at := strings.IndexByte(self.Value, '@')
+	switch {
+	case equal != -1 && (at == -1 || equal < at):
+		return self.Value[:equal], self.Value[equal+1:], false
+	case at != -1:
+		return self.Value[:at], self.Value[at+1:], true
+	}
+	return "", self.Value, false
+}
+
 type DataOptions []DataOption
 
 func (self *DataOptions) Append(data string, typeEmum DataType) {
 	*self = append(*self, DataOption{Value: data, Type: typeEmum})
 }
 
+/*
+	Keys and values of all data in the order of the command.
+*/
+func (self *DataOptions) FormValues() ([]string, url.Values) {
+	var keys []string
+	result := make(url.Values)
+	for _, data := range *self {
+		dataKeys, values := data.FormValues()
+		for _, key := range dataKeys {
+			if _, ok := result[key]; !ok {
+				keys = append(keys, key)
+			}
+			result[key] = append(result[key], values[key]...)
+		}
+	}
+	return keys, result
+}
+
 func (self *DataOptions) HasAnyData() bool {
 	return len(*self) > 0
 }
@@ -90,6 +223,18 @@ func (self *DataOptions) HasData() bool {
 	return false
 }
 
+/*
+	File name of -T option. It is empty when the command doesn't upload a file.
+*/
+func (self *DataOptions) UploadFile() string {
+	for _, data := range *self {
+		if data.Upload {
+			return data.Value[1:]
+		}
+	}
+	return ""
+}
+
 func (self *DataOptions) HasForm() bool {
 	for _, data := range *self {
 		switch data.Type {
@@ -114,38 +259,47 @@ func (self *DataOptions) ExternalFileCount() int {
 
 type CurlOptions struct {
 	// Example of verbosity with level
-	Basic         bool         `long:"basic" description:"Use HTTP Basic Authentication (H)"`
-	Compressed    func()       `long:"compressed" description:"Request compressed response (using deflate or gzip)"`
-	Cookie        []string     `short:"b" long:"cookie" value-name:"STRING/FILE" description:"Read cookies from STRING/FILE (H)"`
-	CookieJar     string       `short:"c" long:"cookie-jar" value-name:"FILE" description:"Write cookies to FILE after operation (H)"`
-	Data          func(string) `short:"d" long:"data" value-name:"DATA" description:"HTTP POST data (H)"`
-	DataAscii     func(string) `long:"data-ascii" value-name:"DATA" description:"HTTP POST ASCII data (H)"`
-	DataBinary    func(string) `long:"data-binary" value-name:"DATA" description:"HTTP POST binary data (H)"`
-	DataUrlEncode func(string) `long:"data-urlencode" value-name:"DATA" description:"HTTP POST data url encoded (H)"`
-	Get           bool         `short:"G" long:"get" description:"Send the -d data with a HTTP GET (H)"`
-	Form          func(string) `short:"F" long:"form" value-name:"KEY=VALUE" description:"Specify HTTP multipart POST data (H)"`
-	FormString    func(string) `long:"form-string" value-name:"KEY=VALUE" description:"Specify HTTP multipart POST data (H)"`
-	Header        []string     `short:"H" long:"header" value-name:"LINE" description:"Pass custom header LINE to server (H)"`
-	Head          bool         `short:"I" long:"head" description:"Show document info only"`
-	Http11        func()       `long:"http1.1" description:"Use HTTP 1.1 (H)"`
-	Http2         func()       `long:"http2" description:"Use HTTP 2 (H)"`
-	Insecure      bool         `short:"k" long:"insecure" description:"Allow connections to SSL sites without certs (H)"`
-	Proxy         string       `short:"x" long:"proxy" value-name:"[PROTOCOL://]HOST[:PORT]" description:"Use proxy on given port"`
-	Referer       func(string) `short:"e" long:"referer" description:"Referer URL (H)"`
-	Request       string       `short:"X" long:"request" value-name:"COMMAND" description:"Specify request command to use"`
-	TrEncoding    func()       `long:"tr-encoding" description:"Request compressed transfer encoding (H)"`
-	Transfer      func(string) `short:"T" long:"upload-file" value-name:"FILE" description:"Transfer FILE to destination"`
-	Url           string       `long:"url" value-name:"URL" description:"URL to work with"`
-	User          string       `short:"u" long:"user" value-name:"USER[:PASSWORD]" description:"Server user and password"`
-	UserAgent     func(string) `short:"A" long:"user-agent" value-name:"STRING" description:"User-Agent to send to server (H)"`
-	//Digest bool `long:"digest" description:"Use HTTP Digest Authentication (H)"`
+	Basic          bool         `long:"basic" description:"Use HTTP Basic Authentication (H)"`
+	Compressed     func()       `long:"compressed" description:"Request compressed response (using deflate or gzip)"`
+	ConnectTimeout float64      `long:"connect-timeout" value-name:"SECONDS" description:"Maximum time allowed for connection"`
+	Cookie         []string     `unquote:"false" short:"b" long:"cookie" value-name:"STRING/FILE" description:"Read cookies from STRING/FILE (H)"`
+	CookieJar      string       `unquote:"false" short:"c" long:"cookie-jar" value-name:"FILE" description:"Write cookies to FILE after operation (H)"`
+	Data           func(string) `unquote:"false" short:"d" long:"data" value-name:"DATA" description:"HTTP POST data (H)"`
+	DataAscii      func(string) `unquote:"false" long:"data-ascii" value-name:"DATA" description:"HTTP POST ASCII data (H)"`
+	DataBinary     func(string) `unquote:"false" long:"data-binary" value-name:"DATA" description:"HTTP POST binary data (H)"`
+	DataRaw        func(string) `unquote:"false" long:"data-raw" value-name:"DATA" description:"HTTP POST data, '@' allowed (H)"`
+	DataUrlEncode  func(string) `unquote:"false" long:"data-urlencode" value-name:"DATA" description:"HTTP POST data url encoded (H)"`
+	Get            bool         `short:"G" long:"get" description:"Send the -d data with a HTTP GET (H)"`
+	Form           func(string) `unquote:"false" short:"F" long:"form" value-name:"KEY=VALUE" description:"Specify HTTP multipart POST data (H)"`
+	FormString     func(string) `unquote:"false" long:"form-string" value-name:"KEY=VALUE" description:"Specify HTTP multipart POST data (H)"`
+	HeaderLine     func(string) `unquote:"false" short:"H" long:"header" value-name:"LINE" description:"Pass custom header LINE to server (H)"`
+	Head           bool         `short:"I" long:"head" description:"Show document info only"`
+	Http11         func()       `long:"http1.1" description:"Use HTTP 1.1 (H)"`
+	Http2          func()       `long:"http2" description:"Use HTTP 2 (H)"`
+	Insecure       bool         `short:"k" long:"insecure" description:"Allow connections to SSL sites without certs (H)"`
+	MaxTime        float64      `short:"m" long:"max-time" value-name:"SECONDS" description:"Maximum time allowed for the transfer"`
+	Proxy          string       `unquote:"false" short:"x" long:"proxy" value-name:"[PROTOCOL://]HOST[:PORT]" description:"Use proxy on given port"`
+	Referer        func(string) `unquote:"false" short:"e" long:"referer" description:"Referer URL (H)"`
+	Request        string       `unquote:"false" short:"X" long:"request" value-name:"COMMAND" description:"Specify request command to use"`
+	TrEncoding     func()       `long:"tr-encoding" description:"Request compressed transfer encoding (H)"`
+	Transfer       func(string) `unquote:"false" short:"T" long:"upload-file" value-name:"FILE" description:"Transfer FILE to destination"`
+	Url            string       `unquote:"false" long:"url" value-name:"URL" description:"URL to work with"`
+	User           string       `unquote:"false" short:"u" long:"user" value-name:"USER[:PASSWORD]" description:"Server user and password"`
+	UserAgent      func(string) `unquote:"false" short:"A" long:"user-agent" value-name:"STRING" description:"User-Agent to send to server (H)"`
+	Digest         bool         `long:"digest" description:"Use HTTP Digest Authentication (H)"`
 
 	// Original parameter
-	AWSV2 string `long:"awsv2" value-name:"ACCESS-KEY:SECRET-KEY" description:"AWS V2 style authentication (original)"`
+	AWSV2 string `unquote:"false" long:"awsv2" value-name:"ACCESS-KEY:SECRET-KEY" description:"AWS V2 style authentication (original)"`
 
 	// Internal Use
+	// -A, -e, --compressed and --tr-encoding add headers too. go-flags clears slice options when they are
+	// set first, so -H is a function to keep headers of these options.
+	Header        []string
 	Http2Flag     bool
 	ProcessedData DataOptions
+	RemainingUrls []string
+	Warnings      []string
+	Parameters    []Parameter
 }
 
 func (self *CurlOptions) Init() {
@@ -153,11 +307,19 @@ func (self *CurlOptions) Init() {
 		self.Header = append(self.Header, "Accept-Encoding: deflate", "Accept-Encoding: gzip")
 	}
 
+	self.HeaderLine = func(data string) {
+		self.Header = append(self.Header, data)
+	}
+
 	self.Data = func(data string) {
 		self.ProcessedData.Append(data, DataAsciiType)
 	}
 	self.DataAscii = self.Data
 
+	self.DataRaw = func(data string) {
+		self.ProcessedData = append(self.ProcessedData, DataOption{Value: data, Type: DataAsciiType, Raw: true})
+	}
+
 	self.DataBinary = func(data string) {
 		self.ProcessedData.Append(data, DataBinaryType)
 	}
@@ -187,10 +349,7 @@ func (self *CurlOptions) Init() {
 	}
 
 	self.Transfer = func(data string) {
-		self.ProcessedData.Append(fmt.Sprintf("@%s", data), DataBinaryType)
-		if self.Request == "" {
-			self.Request = "PUT"
-		}
+		self.ProcessedData = append(self.ProcessedData, DataOption{Value: "@" + data, Type: DataBinaryType, Upload: true})
 	}
 
 	self.TrEncoding = func() {
@@ -203,37 +362,208 @@ func (self *CurlOptions) Init() {
 
 }
 
+/*
+	Copy options to keep original options untouched while generating code.
+	Generators modify headers and add warnings.
+*/
+func (self *CurlOptions) Clone() *CurlOptions {
+	result := *self
+	result.Cookie = append([]string(nil), self.Cookie...)
+	result.Header = append([]string(nil), self.Header...)
+	result.ProcessedData = append(DataOptions(nil), self.ProcessedData...)
+	result.RemainingUrls = append([]string(nil), self.RemainingUrls...)
+	result.Warnings = append([]string(nil), self.Warnings...)
+	result.Parameters = append([]Parameter(nil), self.Parameters...)
+	return &result
+}
+
 func (self *CurlOptions) CheckError() error {
-	if self.ProcessedData.HasData() && self.ProcessedData.HasData() {
-		return fmt.Errorf("Warning: You can only select one HTTP request!")
+	if self.Url == "" {
+		return &InvalidOptionError{Option: "--url", Reason: "Both --url option and url parameters are missing"}
+	}
+	if _, err := url.Parse(self.Url); err != nil {
+		return &URLParseError{Url: self.Url, Err: err}
+	}
+	if self.Proxy != "" {
+		if _, err := url.Parse(self.Proxy); err != nil {
+			return &URLParseError{Url: self.Proxy, Err: err}
+		}
+	}
+	if self.ProcessedData.HasData() && self.ProcessedData.HasForm() {
+		return &UnsupportedOptionError{Option: "-d/-F", Reason: "You can only select one HTTP request!"}
+	}
+	if self.ProcessedData.UploadFile() != "" && len(self.ProcessedData) > 1 {
+		return &UnsupportedOptionError{Option: "-T", Reason: "You can only select one HTTP request!"}
+	}
+	if (self.Get || self.Head) && self.ProcessedData.HasForm() {
+		option := "-G/-F"
+		if !self.Get {
+			option = "-I/-F"
+		}
+		return &UnsupportedOptionError{Option: option, Reason: "You can only select one HTTP request!"}
+	}
+	for _, data := range self.ProcessedData {
+		if (data.Type == FormType || data.Type == FormStringType) && !data.IsFormStyle() {
+			return &InvalidOptionError{Option: "-F", Value: data.Value, Reason: "Illegally formatted input field"}
+		}
 	}
 	return nil
 }
 
+/*
+	Non-fatal problems are stored to show users. Same message is stored only once.
+*/
+func (self *CurlOptions) AddWarning(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	for _, warning := range self.Warnings {
+		if warning == message {
+			return
+		}
+	}
+	self.Warnings = append(self.Warnings, message)
+}
+
+/*
+	Parsed URL and proxy. CheckError() validates them before generating code.
+*/
+func (self *CurlOptions) ParsedUrl() *url.URL {
+	u, err := url.Parse(self.Url)
+	if err != nil {
+		return &url.URL{}
+	}
+	return u
+}
+
+func (self *CurlOptions) ParsedProxy() *url.URL {
+	u, err := url.Parse(self.Proxy)
+	if err != nil {
+		return &url.URL{}
+	}
+	return u
+}
+
 func (self *CurlOptions) Method() string {
 	method := strings.ToUpper(self.Request)
 	// explicit method is the highest priority
 	if method != "" {
 		return method
 	}
+	return self.implicitMethod()
+}
+
+/*
+	Method without -X option.
+*/
+func (self *CurlOptions) implicitMethod() string {
 	if self.Get {
 		return "GET"
 	}
 	if self.Head {
 		return "HEAD"
 	}
+	if self.ProcessedData.UploadFile() != "" {
+		return "PUT"
+	}
 	if self.ProcessedData.HasAnyData() {
 		return "POST"
 	}
 	return "GET"
 }
 
+/*
+	curl appends the file name of -T option to the URL that doesn't have a file name like "http://host/dir/".
+	Call it after the URL is set. ParseCurlCommand() calls it.
+*/
+func (self *CurlOptions) AppendUploadFileName() {
+	fileName := self.ProcessedData.UploadFile()
+	if fileName == "" {
+		return
+	}
+	prefix, path, suffix := splitUrl(self.Url)
+	if path != "" && !strings.HasSuffix(path, "/") {
+		return
+	}
+	if path == "" {
+		path = "/"
+	}
+	self.Url = prefix + path + url.PathEscape(fileName[strings.LastIndexAny(fileName, "/\\")+1:]) + suffix
+}
+
+func (self *CurlOptions) UseBasicAuth() bool {
+	return self.User != "" && !self.Digest
+}
+
+func (self *CurlOptions) UseDigestAuth() bool {
+	return self.User != "" && self.Digest
+}
+
+/*
+	Split -u option into user name and password.
+	curl asks password interactively if it is omitted. Generated code uses empty password instead.
+*/
+func (self *CurlOptions) UserAndPassword() (string, string) {
+	fragments := strings.SplitN(self.User, ":", 2)
+	if len(fragments) == 1 {
+		return fragments[0], ""
+	}
+	return fragments[0], fragments[1]
+}
+
+/*
+	Cookies passed by -b option as "NAME1=VALUE1; NAME2=VALUE2" style.
+	-b option without "=" is treated as a cookie file name like curl.
+*/
+func (self *CurlOptions) Cookies() [][]string {
+	var result [][]string
+	for _, cookie := range self.Cookie {
+		if !strings.Contains(cookie, "=") {
+			continue
+		}
+		for _, fragment := range strings.Split(cookie, ";") {
+			words := strings.SplitN(fragment, "=", 2)
+			if len(words) != 2 {
+				continue
+			}
+			result = append(result, []string{strings.TrimSpace(words[0]), strings.TrimSpace(words[1])})
+		}
+	}
+	return result
+}
+
+func (self *CurlOptions) CookieString() string {
+	var fragments []string
+	for _, cookie := range self.Cookies() {
+		fragments = append(fragments, cookie[0]+"="+cookie[1])
+	}
+	return strings.Join(fragments, "; ")
+}
+
+/*
+	Netscape format cookie files passed by -b option.
+*/
+func (self *CurlOptions) CookieFiles() []string {
+	var result []string
+	for _, cookie := range self.Cookie {
+		if !strings.Contains(cookie, "=") {
+			result = append(result, cookie)
+		}
+	}
+	return result
+}
+
+/*
+	Generated code needs cookie jar when it reads cookie files or writes received cookies.
+*/
+func (self *CurlOptions) UseCookieJar() bool {
+	return len(self.CookieFiles()) != 0 || self.CookieJar != ""
+}
+
 func (self *CurlOptions) Headers() [][]string {
 	var result [][]string
 	for _, header := range self.Header {
 		words := strings.SplitN(header, ":", 2)
 		if len(words) != 2 {
-			fmt.Fprintf(os.Stderr, "[warning] %s is wrong style header.\n", header)
+			self.AddWarning("%s is wrong style header.", header)
 			continue
 		}
 		words[1] = strings.TrimSpace(words[1])
@@ -293,6 +623,15 @@ func (self *CurlOptions) InsertContentTypeHeader(contentType string) {
 	}
 }
 
+/*
+	Content-Type of -d options. curl doesn't send it for -T.
+*/
+func (self *CurlOptions) InsertDataContentTypeHeader() {
+	if self.ProcessedData.UploadFile() == "" {
+		self.InsertContentTypeHeader("application/x-www-form-urlencoded")
+	}
+}
+
 func (self *CurlOptions) CanUseSimpleForm() bool {
 	for _, data := range self.ProcessedData {
 		if data.UseExternalFile() {
@@ -0,0 +1,78 @@
+package common
+
+import (
+	. "gopkg.in/check.v1"
+	"net/url"
+)
+
+type CurlCommandTest struct{}
+
+var _ = Suite(&CurlCommandTest{})
+
+func (s *CurlCommandTest) Test_FormValues(c *C) {
+	var data DataOptions
+	data.Append("q=a+b&hello=world&q=%26", DataAsciiType)
+	data.Append("flag", DataAsciiType)
+	data.Append("a=1", DataAsciiType)
+	keys, values := data.FormValues()
+	c.Check(keys, DeepEquals, []string{"q", "hello", "flag", "a"})
+	c.Check(values, DeepEquals, url.Values{"q": {"a b", "&"}, "hello": {"world"}, "flag": {""}, "a": {"1"}})
+
+	keys, _ = data[0].FormValues()
+	c.Check(keys, DeepEquals, []string{"q", "hello"})
+}
+
+func (s *CurlCommandTest) Test_CheckError_GetWithForm(c *C) {
+	options, err := ParseCurlCommand("curl -G -F 'a=<f.txt' http://localhost:18888")
+	c.Assert(err, IsNil)
+	c.Check(options.CheckError(), DeepEquals, &UnsupportedOptionError{Option: "-G/-F", Reason: "You can only select one HTTP request!"})
+
+	options, err = ParseCurlCommand("curl -I -F 'name=@file.txt;type=text/plain' http://localhost:18888")
+	c.Assert(err, IsNil)
+	c.Check(options.CheckError(), DeepEquals, &UnsupportedOptionError{Option: "-I/-F", Reason: "You can only select one HTTP request!"})
+}
+
+func (s *CurlCommandTest) Test_Upload(c *C) {
+	options, err := ParseCurlCommand("curl -T 'dir/a b.txt' 'http://localhost:18888/files/?x=1'")
+	c.Assert(err, IsNil)
+	c.Check(options.Url, Equals, "http://localhost:18888/files/a%20b.txt?x=1")
+	c.Check(options.Method(), Equals, "PUT")
+	c.Check(options.ProcessedData.UploadFile(), Equals, "dir/a b.txt")
+	c.Check(options.ToCurl(CurlStyle{}), Equals, "curl 'http://localhost:18888/files/a%20b.txt?x=1' -T 'dir/a b.txt'")
+
+	options, err = ParseCurlCommand("curl -X POST -T a.txt http://localhost:18888")
+	c.Assert(err, IsNil)
+	c.Check(options.Url, Equals, "http://localhost:18888/a.txt")
+	c.Check(options.Method(), Equals, "POST")
+
+	options, err = ParseCurlCommand("curl -T a.txt http://localhost:18888/upload")
+	c.Assert(err, IsNil)
+	c.Check(options.Url, Equals, "http://localhost:18888/upload")
+
+	options, err = ParseCurlCommand("curl -T a.txt -d a=1 http://localhost:18888/upload")
+	c.Assert(err, IsNil)
+	c.Check(options.CheckError(), DeepEquals, &UnsupportedOptionError{Option: "-T", Reason: "You can only select one HTTP request!"})
+}
+
+func (s *CurlCommandTest) Test_UrlEncodeParts(c *C) {
+	for _, test := range []struct {
+		value, name, content string
+		isFile               bool
+	}{
+		{"a b", "", "a b", false},
+		{"=a=b@c", "", "a=b@c", false},
+		{"test% =", "test% ", "", false},
+		{"@test.txt", "", "test.txt", true},
+		{"name@a=b.txt", "name", "a=b.txt", true},
+	} {
+		data := DataOption{Value: test.value, Type: DataUrlEncodeType}
+		name, content, isFile := data.UrlEncodeParts()
+		c.Check([]interface{}{name, content, isFile}, DeepEquals, []interface{}{test.name, test.content, test.isFile}, Commentf(test.value))
+	}
+}
+
+func (s *CurlCommandTest) Test_FormFileContentType(c *C) {
+	c.Check(FormFileContentType("dir/test.TXT"), Equals, "text/plain")
+	c.Check(FormFileContentType("image.jpeg"), Equals, "image/jpeg")
+	c.Check(FormFileContentType("data.json"), Equals, "application/octet-stream")
+}
@@ -0,0 +1,59 @@
+package common
+
+import (
+	"fmt"
+)
+
+/*
+	Errors returned by code generators. Library users can check the reason by type switch.
+*/
+
+type URLParseError struct {
+	Url string
+	Err error
+}
+
+func (self *URLParseError) Error() string {
+	return fmt.Sprintf("can't parse URL '%s': %s", self.Url, self.Err)
+}
+
+/*
+	The option is wrongly formatted or required option is missing.
+*/
+type InvalidOptionError struct {
+	Option string
+	Value  string
+	Reason string
+}
+
+func (self *InvalidOptionError) Error() string {
+	if self.Value == "" {
+		return fmt.Sprintf("option %s: %s", self.Option, self.Reason)
+	}
+	return fmt.Sprintf("option %s: %s: '%s'", self.Option, self.Reason, self.Value)
+}
+
+/*
+	The option or the combination of options can't be converted into the target language.
+	Target is empty when curl itself doesn't accept the combination.
+*/
+type UnsupportedOptionError struct {
+	Target string
+	Option string
+	Reason string
+}
+
+func (self *UnsupportedOptionError) Error() string {
+	if self.Target == "" {
+		return fmt.Sprintf("option %s: %s", self.Option, self.Reason)
+	}
+	return fmt.Sprintf("%s generator doesn't support option %s: %s", self.Target, self.Option, self.Reason)
+}
+
+/*
+	Error of data that the generator can't write there. Generators return it instead of panicking
+	when they get data of an unexpected type.
+*/
+func UnexpectedDataError(target string, data *DataOption) error {
+	return &UnsupportedOptionError{Target: target, Option: data.Option() + " " + data.Value, Reason: "the data can't be sent with other options"}
+}
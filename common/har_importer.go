@@ -0,0 +1,216 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"strings"
+)
+
+/*
+	HAR 1.2 (http://www.softwareishard.com/blog/har-12-spec/)
+	Only requests are read. Responses and timings are ignored.
+*/
+type harFile struct {
+	Log *struct {
+		Entries []struct {
+			Request *harRequest `json:"request"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+type harRequest struct {
+	Method   string         `json:"method"`
+	Url      string         `json:"url"`
+	Headers  []harNameValue `json:"headers"`
+	Cookies  []harNameValue `json:"cookies"`
+	PostData *harPostData   `json:"postData"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string     `json:"mimeType"`
+	Text     string     `json:"text"`
+	Params   []harParam `json:"params"`
+}
+
+type harParam struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+}
+
+/*
+	Headers that HTTP clients add by themselves. HTTP/2 pseudo headers (":authority" etc.) are skipped too.
+	Accept-Encoding is removed because generated code doesn't decode compressed responses.
+*/
+var harSkippedHeaders = map[string]bool{
+	"host":            true,
+	"connection":      true,
+	"content-length":  true,
+	"accept-encoding": true,
+}
+
+/*
+	Read requests of all entries in HAR file. Names of requests are "METHOD /path".
+	HAR doesn't have contents of uploaded files. Generated code reads files of the same names.
+*/
+func ParseHar(content []byte) ([]NamedRequest, error) {
+	var file harFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, &ImportError{Format: "HAR", Reason: err.Error()}
+	}
+	if file.Log == nil {
+		return nil, &ImportError{Format: "HAR", Reason: "log is missing"}
+	}
+	var result []NamedRequest
+	for i, entry := range file.Log.Entries {
+		if entry.Request == nil || entry.Request.Url == "" {
+			return nil, &ImportError{Format: "HAR", Reason: fmt.Sprintf("request of entry %d doesn't have url", i)}
+		}
+		options, err := entry.Request.curlOptions()
+		if err != nil {
+			return nil, &ImportError{Format: "HAR", Reason: fmt.Sprintf("entry %d: %s", i, err.Error())}
+		}
+		result = append(result, NamedRequest{Name: defaultRequestName(options), Options: options})
+	}
+	return result, nil
+}
+
+func (self *harRequest) curlOptions() (*CurlOptions, error) {
+	options := newImportedOptions()
+	options.Url = self.Url
+	options.Request = strings.ToUpper(self.Method)
+
+	var contentType, cookieHeader string
+	for _, header := range self.Headers {
+		name := strings.ToLower(header.Name)
+		switch {
+		case strings.HasPrefix(name, ":") || harSkippedHeaders[name]:
+		case name == "content-type":
+			contentType = header.Value
+		case name == "cookie":
+			cookieHeader = header.Value
+		default:
+			options.Header = append(options.Header, header.Name+": "+header.Value)
+		}
+	}
+	var cookies []string
+	for _, cookie := range self.Cookies {
+		cookies = append(cookies, cookie.Name+"="+cookie.Value)
+	}
+	if len(cookies) > 0 {
+		options.Cookie = []string{strings.Join(cookies, "; ")}
+	} else if strings.Contains(cookieHeader, "=") {
+		options.Cookie = []string{cookieHeader}
+	}
+
+	if postData := self.PostData; postData != nil && (postData.Text != "" || len(postData.Params) > 0) {
+		if contentType == "" {
+			contentType = postData.MimeType
+		}
+		mediaType, parameters, _ := mime.ParseMediaType(contentType)
+		switch mediaType {
+		case "multipart/form-data":
+			params := postData.Params
+			if len(params) == 0 {
+				var err error
+				if params, err = parseMultipartText(postData.Text, parameters["boundary"]); err != nil {
+					return nil, err
+				}
+			}
+			for _, param := range params {
+				value, dataType := formValue(param)
+				options.ProcessedData.Append(value, dataType)
+				if param.FileName != "" {
+					options.AddWarning("HAR doesn't have the content of %s. Generated code reads the file.", param.FileName)
+				}
+			}
+			// generators write Content-Type header with their own boundary
+			contentType = ""
+		case "application/x-www-form-urlencoded":
+			if postData.Text != "" {
+				options.DataRaw(postData.Text)
+			} else {
+				for _, param := range postData.Params {
+					options.ProcessedData.Append(param.Name+"="+param.Value, DataUrlEncodeType)
+				}
+			}
+			if strings.TrimSpace(contentType) == mediaType {
+				// curl sends it by default
+				contentType = ""
+			}
+		default:
+			appendBinaryData(options, postData.Text)
+		}
+	}
+	if contentType != "" {
+		options.Header = append(options.Header, "Content-Type: "+contentType)
+	}
+	return options, nil
+}
+
+/*
+	Chrome writes multipart body as text without params.
+*/
+func parseMultipartText(text, boundary string) ([]harParam, error) {
+	if boundary == "" {
+		return nil, fmt.Errorf("boundary of multipart/form-data is missing")
+	}
+	var result []harParam
+	reader := multipart.NewReader(strings.NewReader(text), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, harParam{
+			Name:        part.FormName(),
+			Value:       string(content),
+			FileName:    part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+		})
+	}
+	return result, nil
+}
+
+/*
+	-F reads a file when the value starts with "@" or "<". --form-string sends it as is.
+*/
+func formValue(param harParam) (string, DataType) {
+	if param.FileName != "" {
+		value := param.Name + "=@" + param.FileName
+		if param.ContentType != "" {
+			value += ";type=" + param.ContentType
+		}
+		return value, FormType
+	}
+	if strings.HasPrefix(param.Value, "@") || strings.HasPrefix(param.Value, "<") {
+		return param.Name + "=" + param.Value, FormStringType
+	}
+	return param.Name + "=" + param.Value, FormType
+}
+
+/*
+	--data-binary keeps line feeds. It can't send a body that starts with "@".
+*/
+func appendBinaryData(options *CurlOptions, text string) {
+	if strings.HasPrefix(text, "@") {
+		options.AddWarning("Request body starts with '@'. Generated code reads %s as a file.", strings.Split(text[1:], ";")[0])
+	}
+	options.ProcessedData.Append(text, DataBinaryType)
+}
@@ -0,0 +1,62 @@
+package common
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type HarImporterTest struct{}
+
+var _ = Suite(&HarImporterTest{})
+
+func (s *HarImporterTest) Test_ParseHar(c *C) {
+	requests, err := ParseHar([]byte(`{"log": {"entries": [
+		{"request": {"method": "GET", "url": "http://localhost:18888/users?limit=10", "headers": [
+			{"name": ":authority", "value": "localhost:18888"}, {"name": "Host", "value": "localhost:18888"},
+			{"name": "Accept", "value": "application/json"}, {"name": "Accept-Encoding", "value": "gzip, br"},
+			{"name": "Cookie", "value": "session=abc"}], "cookies": [{"name": "session", "value": "abc"}]}},
+		{"request": {"method": "post", "url": "http://localhost:18888/users", "headers": [{"name": "Content-Type", "value": "application/json"}],
+			"postData": {"mimeType": "application/json", "text": "{\n\"name\": \"bob\"\n}"}}}
+	]}}`))
+	c.Assert(err, IsNil)
+	c.Assert(requests, HasLen, 2)
+	c.Check(requests[0].Name, Equals, "GET /users")
+	c.Check(requests[0].Options.ToCurl(CurlStyle{}), Equals, "curl 'http://localhost:18888/users?limit=10' -H 'Accept: application/json' -b session=abc")
+	c.Check(requests[1].Name, Equals, "POST /users")
+	c.Check(requests[1].Options.ToCurl(CurlStyle{}), Equals, "curl http://localhost:18888/users -H 'Content-Type: application/json' --data-binary $'{\\n\"name\": \"bob\"\\n}'")
+}
+
+func (s *HarImporterTest) Test_ParseHar_Form(c *C) {
+	requests, err := ParseHar([]byte(`{"log": {"entries": [
+		{"request": {"method": "POST", "url": "http://localhost:18888/login", "headers": [{"name": "Content-Type", "value": "application/x-www-form-urlencoded"}],
+			"postData": {"mimeType": "application/x-www-form-urlencoded", "text": "user=a&pass=b"}}},
+		{"request": {"method": "POST", "url": "http://localhost:18888/login", "headers": [],
+			"postData": {"mimeType": "application/x-www-form-urlencoded", "params": [{"name": "user", "value": "a b"}]}}}
+	]}}`))
+	c.Assert(err, IsNil)
+	c.Check(requests[0].Options.ToCurl(CurlStyle{}), Equals, "curl http://localhost:18888/login -d 'user=a&pass=b'")
+	c.Check(requests[1].Options.ToCurl(CurlStyle{}), Equals, "curl http://localhost:18888/login --data-urlencode 'user=a b'")
+}
+
+func (s *HarImporterTest) Test_ParseHar_Multipart(c *C) {
+	requests, err := ParseHar([]byte(`{"log": {"entries": [
+		{"request": {"method": "POST", "url": "http://localhost:18888/upload", "headers": [],
+			"postData": {"mimeType": "multipart/form-data; boundary=X", "params": [
+				{"name": "title", "value": "@home"}, {"name": "file", "fileName": "a.txt", "contentType": "text/plain"}]}}},
+		{"request": {"method": "POST", "url": "http://localhost:18888/upload", "headers": [{"name": "Content-Type", "value": "multipart/form-data; boundary=X"}],
+			"postData": {"mimeType": "multipart/form-data; boundary=X",
+				"text": "--X\r\nContent-Disposition: form-data; name=\"title\"\r\n\r\nhello\r\n--X\r\nContent-Disposition: form-data; name=\"file\"; filename=\"b.png\"\r\n\r\n\r\n--X--\r\n"}}}
+	]}}`))
+	c.Assert(err, IsNil)
+	c.Check(requests[0].Options.ToCurl(CurlStyle{}), Equals, "curl http://localhost:18888/upload --form-string title=@home -F 'file=@a.txt;type=text/plain'")
+	c.Check(requests[0].Options.Warnings, DeepEquals, []string{"HAR doesn't have the content of a.txt. Generated code reads the file."})
+	c.Check(requests[1].Options.ToCurl(CurlStyle{}), Equals, "curl http://localhost:18888/upload -F title=hello -F file=@b.png")
+}
+
+func (s *HarImporterTest) Test_ParseHar_Error(c *C) {
+	_, err := ParseHar([]byte(`{"entries": []}`))
+	c.Check(err, ErrorMatches, "can't import HAR: log is missing")
+	_, err = ParseHar([]byte(`{"log": {"entries": [{"request": {"method": "GET"}}]}}`))
+	c.Check(err, ErrorMatches, "can't import HAR: request of entry 0 doesn't have url")
+	_, err = ParseHar([]byte(`{"log": {"entries": [{"request": {"method": "POST", "url": "http://localhost", "postData": {"mimeType": "multipart/form-data", "text": "--X--"}}}]}}`))
+	c.Check(err, ErrorMatches, "can't import HAR: entry 0: boundary of multipart/form-data is missing")
+}
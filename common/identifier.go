@@ -0,0 +1,76 @@
+package common
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var identifierWord = regexp.MustCompile(`[A-Za-z0-9]+`)
+
+/*
+	Words of names for identifiers of generated code. "GET /users/{id}" becomes "GET", "users" and "id".
+	camelCase words are split too. "request" is added when the first word starts with a digit.
+*/
+func IdentifierWords(names ...string) []string {
+	var result []string
+	for _, name := range names {
+		for _, word := range identifierWord.FindAllString(name, -1) {
+			start := 0
+			for i := 1; i < len(word); i++ {
+				if unicode.IsUpper(rune(word[i])) && !unicode.IsUpper(rune(word[i-1])) {
+					result = append(result, word[start:i])
+					start = i
+				}
+			}
+			result = append(result, word[start:])
+		}
+	}
+	if len(result) == 0 || unicode.IsDigit(rune(result[0][0])) {
+		result = append([]string{"request"}, result...)
+	}
+	return result
+}
+
+/*
+	"CreateUser" style. Words written in capital letters like "GET" become "Get".
+*/
+func PascalCase(words []string) string {
+	var buffer bytes.Buffer
+	for _, word := range words {
+		if strings.ToUpper(word) == word {
+			word = strings.ToLower(word)
+		}
+		buffer.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return buffer.String()
+}
+
+/*
+	"createUser" style.
+*/
+func CamelCase(words []string) string {
+	result := PascalCase(words)
+	return strings.ToLower(result[:1]) + result[1:]
+}
+
+/*
+	"create_user" style.
+*/
+func SnakeCase(words []string) string {
+	return strings.ToLower(strings.Join(words, "_"))
+}
+
+/*
+	Add "2", "3"... when the name is already used. Names are compared without case.
+*/
+func UniqueName(name string, used map[string]bool) string {
+	result := name
+	for i := 2; used[strings.ToLower(result)]; i++ {
+		result = name + strconv.Itoa(i)
+	}
+	used[strings.ToLower(result)] = true
+	return result
+}
@@ -0,0 +1,31 @@
+package common
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type IdentifierTest struct{}
+
+var _ = Suite(&IdentifierTest{})
+
+func (s *IdentifierTest) Test_IdentifierWords(c *C) {
+	c.Check(IdentifierWords("GET /users/{id}"), DeepEquals, []string{"GET", "users", "id"})
+	c.Check(IdentifierWords("Users", "Create user"), DeepEquals, []string{"Users", "Create", "user"})
+	c.Check(IdentifierWords("2fa"), DeepEquals, []string{"request", "2fa"})
+	c.Check(IdentifierWords("/"), DeepEquals, []string{"request"})
+}
+
+func (s *IdentifierTest) Test_Cases(c *C) {
+	words := IdentifierWords("GET users", "byId")
+	c.Check(words, DeepEquals, []string{"GET", "users", "by", "Id"})
+	c.Check(PascalCase(words), Equals, "GetUsersById")
+	c.Check(CamelCase(words), Equals, "getUsersById")
+	c.Check(SnakeCase(words), Equals, "get_users_by_id")
+}
+
+func (s *IdentifierTest) Test_UniqueName(c *C) {
+	used := map[string]bool{"main": true}
+	c.Check(UniqueName("Main", used), Equals, "Main2")
+	c.Check(UniqueName("GetUsers", used), Equals, "GetUsers")
+	c.Check(UniqueName("GetUsers", used), Equals, "GetUsers2")
+}
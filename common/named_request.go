@@ -0,0 +1,45 @@
+package common
+
+import (
+	"fmt"
+)
+
+/*
+	Request read from HAR files or Postman collections.
+	Name is a human readable name like "GET /users" or the item name of Postman.
+	Groups are folder names from the top folder. Generators use them as prefixes of function names or directories.
+*/
+type NamedRequest struct {
+	Name    string
+	Groups  []string
+	Options *CurlOptions
+}
+
+/*
+	The file can't be read as the format.
+*/
+type ImportError struct {
+	Format string
+	Reason string
+}
+
+func (self *ImportError) Error() string {
+	return fmt.Sprintf("can't import %s: %s", self.Format, self.Reason)
+}
+
+func newImportedOptions() *CurlOptions {
+	options := &CurlOptions{}
+	options.Init()
+	return options
+}
+
+/*
+	"METHOD /path" for requests that don't have names.
+*/
+func defaultRequestName(options *CurlOptions) string {
+	path := options.ParsedUrl().EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	return options.Method() + " " + path
+}
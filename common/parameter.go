@@ -0,0 +1,273 @@
+package common
+
+import (
+	"regexp"
+	"strings"
+)
+
+/*
+	Value of the command that becomes a function parameter or an environment variable in generated code.
+	Name is snake_case like "user_id". Original is the text in the command like "{{token}}", ":id" or "42".
+	Secret is read from the environment variable even in functions, and its value is never written.
+*/
+type Parameter struct {
+	Name     string
+	Original string
+	Secret   bool
+}
+
+const parameterMarker = "CURLASDSLPARAM"
+
+/*
+	Parameterize() replaces values with markers. Generators write markers as they are, and
+	generator package replaces them with expressions of the target language.
+*/
+func (self Parameter) Marker() string {
+	return parameterMarker + self.Name + parameterMarker
+}
+
+/*
+	Name of the environment variable like "USER_ID".
+*/
+func (self Parameter) EnvironmentVariable() string {
+	return strings.ToUpper(self.Name)
+}
+
+/*
+	Text written when the parameter can't be an expression. Secrets become references like "${PASSWORD}".
+*/
+func (self Parameter) Fallback() string {
+	if self.Secret {
+		return "${" + self.EnvironmentVariable() + "}"
+	}
+	return self.Original
+}
+
+var ParameterMarkerPattern = regexp.MustCompile(parameterMarker + `([a-z0-9_]+)` + parameterMarker)
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+var pathPlaceholderPattern = regexp.MustCompile(`^:([A-Za-z_][A-Za-z0-9_]*)$`)
+var numberPattern = regexp.MustCompile(`^[0-9]+$`)
+
+/*
+	Replace placeholders in the URL, headers, cookies, data and -u option with markers of parameters:
+
+		{{token}}   Postman style variable in any value
+		:id         path segment like /users/:id
+
+	When auto is true, these values become parameters too:
+
+		token       token of "Authorization: Bearer" header
+		password    password of -u option
+		user_id     numeric path segment after "users"
+*/
+func (self *CurlOptions) Parameterize(auto bool) {
+	add := self.parameterAdder(false)
+	replacePlaceholders := func(value string) string {
+		return placeholderPattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+			return add(placeholderPattern.FindStringSubmatch(placeholder)[1], placeholder)
+		})
+	}
+
+	// path segments. Scheme and host are kept because generators parse them
+	prefix, path, suffix := splitUrl(self.Url)
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if match := pathPlaceholderPattern.FindStringSubmatch(segment); match != nil {
+			segments[i] = add(match[1], segment)
+		} else if auto && numberPattern.MatchString(segment) {
+			name := "id"
+			if i > 0 && len(segments[i-1]) > 1 && !numberPattern.MatchString(segments[i-1]) {
+				name = strings.TrimSuffix(segments[i-1], "s") + "_id"
+			}
+			segments[i] = add(name, segment)
+		}
+	}
+	self.Url = prefix + replacePlaceholders(strings.Join(segments, "/")+suffix)
+
+	for i, header := range self.Header {
+		header = replacePlaceholders(header)
+		words := strings.SplitN(header, ":", 2)
+		if auto && len(words) == 2 && strings.EqualFold(strings.TrimSpace(words[0]), "authorization") {
+			value := strings.TrimSpace(words[1])
+			if len(value) > 7 && strings.EqualFold(value[:7], "bearer ") && !strings.Contains(value, parameterMarker) {
+				header = words[0] + ": " + value[:7] + add("token", value[7:])
+			}
+		}
+		self.Header[i] = header
+	}
+	for i, cookie := range self.Cookie {
+		self.Cookie[i] = replacePlaceholders(cookie)
+	}
+	for i := range self.ProcessedData {
+		self.ProcessedData[i].Value = replacePlaceholders(self.ProcessedData[i].Value)
+	}
+	if self.User != "" {
+		self.User = replacePlaceholders(self.User)
+		if index := strings.IndexByte(self.User, ':'); auto && index != -1 && index < len(self.User)-1 && !strings.Contains(self.User[index:], parameterMarker) {
+			self.User = self.User[:index+1] + add("password", self.User[index+1:])
+		}
+	}
+}
+
+/*
+	Replace credentials with markers of secret parameters. Generated code reads them from environment variables:
+
+		PASSWORD                password of -u option
+		TOKEN                   token of "Authorization: Bearer" header
+		AUTHORIZATION           credentials of other Authorization headers
+		API_KEY                 X-Api-Key header
+		COOKIE_<NAME>           values of Cookie header and -b option
+		AWS_ACCESS_KEY_ID       access key of --awsv2 option
+		AWS_SECRET_ACCESS_KEY   secret key of --awsv2 option
+
+	Values that are parameters already are kept.
+*/
+func (self *CurlOptions) Redact() {
+	add := self.parameterAdder(true)
+	redactCookies := func(cookies string) string {
+		pairs := strings.Split(cookies, ";")
+		for i, pair := range pairs {
+			words := strings.SplitN(pair, "=", 2)
+			if len(words) == 2 && words[1] != "" && !strings.Contains(words[1], parameterMarker) {
+				pairs[i] = words[0] + "=" + add("cookie "+strings.TrimSpace(words[0]), words[1])
+			}
+		}
+		return strings.Join(pairs, ";")
+	}
+
+	for i, header := range self.Header {
+		words := strings.SplitN(header, ":", 2)
+		if len(words) != 2 || strings.Contains(words[1], parameterMarker) {
+			continue
+		}
+		value := strings.TrimSpace(words[1])
+		if value == "" {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(words[0])) {
+		case "authorization":
+			if index := strings.IndexByte(value, ' '); index != -1 {
+				name := "authorization"
+				if strings.EqualFold(value[:index], "bearer") {
+					name = "token"
+				}
+				value = value[:index+1] + add(name, strings.TrimSpace(value[index+1:]))
+			} else {
+				value = add("authorization", value)
+			}
+		case "cookie":
+			value = redactCookies(value)
+		case "x-api-key":
+			value = add("api key", value)
+		default:
+			continue
+		}
+		self.Header[i] = words[0] + ": " + value
+	}
+	for i, cookie := range self.Cookie {
+		// -b FILE is not a secret
+		if strings.Contains(cookie, "=") {
+			self.Cookie[i] = redactCookies(cookie)
+		}
+	}
+	if index := strings.IndexByte(self.User, ':'); index != -1 && index < len(self.User)-1 && !strings.Contains(self.User[index:], parameterMarker) {
+		self.User = self.User[:index+1] + add("password", self.User[index+1:])
+	}
+	if keys := strings.SplitN(self.AWSV2, ":", 2); len(keys) == 2 && !strings.Contains(self.AWSV2, parameterMarker) {
+		self.AWSV2 = add("aws access key id", keys[0]) + ":" + add("aws secret access key", keys[1])
+	}
+}
+
+/*
+	Parameters that generated functions take. Secrets are read from environment variables instead.
+*/
+func (self *CurlOptions) FunctionParameters() []Parameter {
+	var result []Parameter
+	for _, parameter := range self.Parameters {
+		if !parameter.Secret {
+			result = append(result, parameter)
+		}
+	}
+	return result
+}
+
+/*
+	Function that adds a parameter and returns its marker. Same values of the same name are one parameter.
+	Names don't conflict with parameters added before.
+*/
+func (self *CurlOptions) parameterAdder(secret bool) func(name, original string) string {
+	used := make(map[string]bool)
+	names := make(map[string]string)
+	for _, parameter := range self.Parameters {
+		used[strings.ToLower(parameter.Name)] = true
+	}
+	return func(name, original string) string {
+		key := name + "\x00" + original
+		if name, ok := names[key]; ok {
+			return parameterMarker + name + parameterMarker
+		}
+		name = UniqueName(SnakeCase(IdentifierWords(name)), used)
+		names[key] = name
+		self.Parameters = append(self.Parameters, Parameter{Name: name, Original: original, Secret: secret})
+		return parameterMarker + name + parameterMarker
+	}
+}
+
+/*
+	"http://host/path?query" becomes "http://host", "/path" and "?query". Scheme is optional.
+*/
+func splitUrl(rawUrl string) (string, string, string) {
+	start := 0
+	if index := strings.Index(rawUrl, "://"); index != -1 {
+		start = index + 3
+	}
+	slash := strings.IndexByte(rawUrl[start:], '/')
+	if slash == -1 {
+		return rawUrl, "", ""
+	}
+	prefix := rawUrl[:start+slash]
+	rawUrl = rawUrl[start+slash:]
+	if index := strings.IndexAny(rawUrl, "?#"); index != -1 {
+		return prefix, rawUrl[:index], rawUrl[index:]
+	}
+	return prefix, rawUrl, ""
+}
+
+/*
+	Text of the command where the marker was (or reference of the secret). Generators that can't read parameters use it.
+*/
+func RestoreParameters(text string, parameters []Parameter) string {
+	return ParameterMarkerPattern.ReplaceAllStringFunc(text, func(marker string) string {
+		name := ParameterMarkerPattern.FindStringSubmatch(marker)[1]
+		for _, parameter := range parameters {
+			if parameter.Name == name {
+				return parameter.Fallback()
+			}
+		}
+		return marker
+	})
+}
+
+/*
+	Restore values of parameters in the options. Generators that write values at generation time
+	(e.g. base64 of -u option in HAR) use it. Secrets become references like "${PASSWORD}".
+*/
+func (self *CurlOptions) ClearParameters() {
+	if len(self.Parameters) == 0 {
+		return
+	}
+	self.Url = RestoreParameters(self.Url, self.Parameters)
+	for i, header := range self.Header {
+		self.Header[i] = RestoreParameters(header, self.Parameters)
+	}
+	for i, cookie := range self.Cookie {
+		self.Cookie[i] = RestoreParameters(cookie, self.Parameters)
+	}
+	for i := range self.ProcessedData {
+		self.ProcessedData[i].Value = RestoreParameters(self.ProcessedData[i].Value, self.Parameters)
+	}
+	self.User = RestoreParameters(self.User, self.Parameters)
+	self.AWSV2 = RestoreParameters(self.AWSV2, self.Parameters)
+	self.Parameters = nil
+}
@@ -0,0 +1,397 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+/*
+	Postman Collection v2.1 (https://schema.postman.com/collection/json/v2.1.0/draft-07/docs/index.html)
+	Collection variables are expanded. Undefined variables like "{{token}}" are kept as they are.
+*/
+type postmanCollectionFile struct {
+	Info struct {
+		Name   string `json:"name"`
+		Schema string `json:"schema"`
+	} `json:"info"`
+	Item     []postmanImportItem `json:"item"`
+	Auth     *postmanImportAuth  `json:"auth"`
+	Variable []postmanField      `json:"variable"`
+}
+
+type postmanImportItem struct {
+	Name                    string              `json:"name"`
+	Item                    []postmanImportItem `json:"item"`
+	Request                 json.RawMessage     `json:"request"`
+	Auth                    *postmanImportAuth  `json:"auth"`
+	ProtocolProfileBehavior struct {
+		StrictSSL *bool `json:"strictSSL"`
+	} `json:"protocolProfileBehavior"`
+}
+
+type postmanImportRequest struct {
+	Method string             `json:"method"`
+	Header postmanHeaders     `json:"header"`
+	Url    postmanImportUrl   `json:"url"`
+	Body   *postmanImportBody `json:"body"`
+	Auth   *postmanImportAuth `json:"auth"`
+}
+
+/*
+	Key-value pair of headers, queries, url encoded bodies, form data, auth parameters and variables.
+	Src of form data is a string or an array of strings.
+*/
+type postmanField struct {
+	Key         string          `json:"key"`
+	Value       interface{}     `json:"value"`
+	Disabled    bool            `json:"disabled"`
+	Type        string          `json:"type"`
+	Src         json.RawMessage `json:"src"`
+	ContentType string          `json:"contentType"`
+}
+
+func (self *postmanField) String() string {
+	switch value := self.Value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func (self *postmanField) Sources() []string {
+	var single string
+	if err := json.Unmarshal(self.Src, &single); err == nil {
+		if single == "" {
+			return nil
+		}
+		return []string{single}
+	}
+	var multiple []string
+	json.Unmarshal(self.Src, &multiple)
+	return multiple
+}
+
+/*
+	Header is an array of objects or a string of header lines.
+*/
+type postmanHeaders []postmanField
+
+func (self *postmanHeaders) UnmarshalJSON(data []byte) error {
+	var lines string
+	if err := json.Unmarshal(data, &lines); err == nil {
+		for _, line := range strings.Split(lines, "\n") {
+			fragments := strings.SplitN(line, ":", 2)
+			if len(fragments) == 2 {
+				*self = append(*self, postmanField{Key: strings.TrimSpace(fragments[0]), Value: strings.TrimSpace(fragments[1])})
+			}
+		}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]postmanField)(self))
+}
+
+/*
+	Url is a string or an object. Raw is used if the object has it.
+*/
+type postmanImportUrl struct {
+	Raw string
+}
+
+func (self *postmanImportUrl) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &self.Raw); err == nil {
+		return nil
+	}
+	var object struct {
+		Raw      string         `json:"raw"`
+		Protocol string         `json:"protocol"`
+		Host     []string       `json:"host"`
+		Port     string         `json:"port"`
+		Path     []string       `json:"path"`
+		Query    []postmanField `json:"query"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	if object.Raw != "" {
+		self.Raw = object.Raw
+		return nil
+	}
+	raw := strings.Join(object.Host, ".")
+	if object.Protocol != "" {
+		raw = object.Protocol + "://" + raw
+	}
+	if object.Port != "" {
+		raw += ":" + object.Port
+	}
+	if len(object.Path) > 0 {
+		raw += "/" + strings.Join(object.Path, "/")
+	}
+	var queries []string
+	for _, query := range object.Query {
+		if !query.Disabled {
+			queries = append(queries, query.Key+"="+query.String())
+		}
+	}
+	if len(queries) > 0 {
+		raw += "?" + strings.Join(queries, "&")
+	}
+	self.Raw = raw
+	return nil
+}
+
+type postmanImportBody struct {
+	Mode       string         `json:"mode"`
+	Raw        string         `json:"raw"`
+	UrlEncoded []postmanField `json:"urlencoded"`
+	FormData   []postmanField `json:"formdata"`
+	File       *struct {
+		Src string `json:"src"`
+	} `json:"file"`
+	GraphQL *struct {
+		Query     string `json:"query"`
+		Variables string `json:"variables"`
+	} `json:"graphql"`
+	Options struct {
+		Raw struct {
+			Language string `json:"language"`
+		} `json:"raw"`
+	} `json:"options"`
+	Disabled bool `json:"disabled"`
+}
+
+type postmanImportAuth struct {
+	Type   string         `json:"type"`
+	Basic  []postmanField `json:"basic"`
+	Digest []postmanField `json:"digest"`
+	Bearer []postmanField `json:"bearer"`
+	ApiKey []postmanField `json:"apikey"`
+}
+
+func (self *postmanImportAuth) parameter(fields []postmanField, key string) string {
+	for _, field := range fields {
+		if field.Key == key {
+			return field.String()
+		}
+	}
+	return ""
+}
+
+/*
+	Content-Type that Postman sends for languages of raw body.
+*/
+var postmanRawContentTypes = map[string]string{
+	"text":       "text/plain",
+	"javascript": "application/javascript",
+	"json":       "application/json",
+	"html":       "text/html",
+	"xml":        "application/xml",
+}
+
+var postmanVariable = regexp.MustCompile(`\{\{([^{}]+)\}\}`)
+
+type postmanImporter struct {
+	variables map[string]string
+	result    []NamedRequest
+}
+
+func (self *postmanImporter) expand(src string) string {
+	return postmanVariable.ReplaceAllStringFunc(src, func(match string) string {
+		if value, ok := self.variables[match[2:len(match)-2]]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+/*
+	Read all requests in Postman collection. Names of folders from the top are stored in Groups.
+	Auth of collection and folders is inherited by requests that don't have their own auth.
+*/
+func ParsePostmanCollection(content []byte) ([]NamedRequest, error) {
+	var collection postmanCollectionFile
+	if err := json.Unmarshal(content, &collection); err != nil {
+		return nil, &ImportError{Format: "Postman collection", Reason: err.Error()}
+	}
+	if strings.Contains(collection.Info.Schema, "v2.0.0") || strings.Contains(collection.Info.Schema, "v1.0.0") {
+		return nil, &ImportError{Format: "Postman collection", Reason: "only Collection v2.1 is supported"}
+	}
+	importer := &postmanImporter{variables: make(map[string]string)}
+	for _, variable := range collection.Variable {
+		if !variable.Disabled {
+			importer.variables[variable.Key] = variable.String()
+		}
+	}
+	if err := importer.walk(collection.Item, nil, collection.Auth); err != nil {
+		return nil, &ImportError{Format: "Postman collection", Reason: err.Error()}
+	}
+	return importer.result, nil
+}
+
+func (self *postmanImporter) walk(items []postmanImportItem, groups []string, auth *postmanImportAuth) error {
+	for _, item := range items {
+		itemAuth := auth
+		if item.Auth != nil {
+			itemAuth = item.Auth
+		}
+		if item.Request == nil {
+			// folder. groups is copied because sibling folders share the backing array
+			folderGroups := append(append([]string(nil), groups...), item.Name)
+			if err := self.walk(item.Item, folderGroups, itemAuth); err != nil {
+				return err
+			}
+			continue
+		}
+		options, err := self.curlOptions(&item, itemAuth)
+		if err != nil {
+			return fmt.Errorf("%s: %s", item.Name, err.Error())
+		}
+		name := item.Name
+		if name == "" {
+			name = defaultRequestName(options)
+		}
+		self.result = append(self.result, NamedRequest{Name: name, Groups: groups, Options: options})
+	}
+	return nil
+}
+
+func (self *postmanImporter) curlOptions(item *postmanImportItem, auth *postmanImportAuth) (*CurlOptions, error) {
+	var request postmanImportRequest
+	if err := json.Unmarshal(item.Request, &request.Url.Raw); err == nil {
+		// request is just a url
+		request.Method = "GET"
+	} else if err := json.Unmarshal(item.Request, &request); err != nil {
+		return nil, err
+	}
+	if request.Url.Raw == "" {
+		return nil, fmt.Errorf("url is missing")
+	}
+	if request.Auth != nil {
+		auth = request.Auth
+	}
+
+	options := newImportedOptions()
+	options.Url = self.expand(request.Url.Raw)
+	options.Request = strings.ToUpper(request.Method)
+	if options.Request == "" {
+		options.Request = "GET"
+	}
+	for _, header := range request.Header {
+		if header.Disabled {
+			continue
+		}
+		key, value := self.expand(header.Key), self.expand(header.String())
+		if strings.EqualFold(key, "cookie") {
+			options.Cookie = append(options.Cookie, value)
+		} else {
+			options.Header = append(options.Header, key+": "+value)
+		}
+	}
+	if auth != nil {
+		self.setAuth(options, auth)
+	}
+	if request.Body != nil && !request.Body.Disabled {
+		self.setBody(options, request.Body)
+	}
+	if strictSSL := item.ProtocolProfileBehavior.StrictSSL; strictSSL != nil && !*strictSSL {
+		options.Insecure = true
+	}
+	return options, nil
+}
+
+func (self *postmanImporter) setAuth(options *CurlOptions, auth *postmanImportAuth) {
+	switch auth.Type {
+	case "noauth", "":
+	case "basic":
+		options.User = self.expand(auth.parameter(auth.Basic, "username") + ":" + auth.parameter(auth.Basic, "password"))
+	case "digest":
+		options.User = self.expand(auth.parameter(auth.Digest, "username") + ":" + auth.parameter(auth.Digest, "password"))
+		options.Digest = true
+	case "bearer":
+		options.Header = append(options.Header, "Authorization: Bearer "+self.expand(auth.parameter(auth.Bearer, "token")))
+	case "apikey":
+		key := self.expand(auth.parameter(auth.ApiKey, "key"))
+		value := self.expand(auth.parameter(auth.ApiKey, "value"))
+		if auth.parameter(auth.ApiKey, "in") == "query" {
+			separator := "?"
+			if strings.Contains(options.Url, "?") {
+				separator = "&"
+			}
+			options.Url += separator + url.QueryEscape(key) + "=" + url.QueryEscape(value)
+		} else {
+			options.Header = append(options.Header, key+": "+value)
+		}
+	default:
+		options.AddWarning("Postman auth type %s is not supported. It is ignored.", auth.Type)
+	}
+}
+
+/*
+	Postman sends Content-Type of raw and graphql bodies if the request doesn't have it.
+*/
+func (self *postmanImporter) setBody(options *CurlOptions, body *postmanImportBody) {
+	contentType := ""
+	switch body.Mode {
+	case "raw":
+		if body.Raw == "" {
+			return
+		}
+		appendBinaryData(options, self.expand(body.Raw))
+		contentType = postmanRawContentTypes[body.Options.Raw.Language]
+		if contentType == "" {
+			contentType = "text/plain"
+		}
+	case "urlencoded":
+		for _, field := range body.UrlEncoded {
+			if !field.Disabled {
+				options.ProcessedData.Append(self.expand(field.Key)+"="+self.expand(field.String()), DataUrlEncodeType)
+			}
+		}
+	case "formdata":
+		for _, field := range body.FormData {
+			if field.Disabled {
+				continue
+			}
+			if field.Type == "file" {
+				for _, src := range field.Sources() {
+					value, dataType := formValue(harParam{Name: self.expand(field.Key), FileName: src, ContentType: field.ContentType})
+					options.ProcessedData.Append(value, dataType)
+				}
+				continue
+			}
+			value, dataType := formValue(harParam{Name: self.expand(field.Key), Value: self.expand(field.String())})
+			if field.ContentType != "" && dataType == FormType {
+				value += ";type=" + field.ContentType
+			}
+			options.ProcessedData.Append(value, dataType)
+		}
+	case "file":
+		if body.File == nil || body.File.Src == "" {
+			options.AddWarning("File of the body is not selected. It is ignored.")
+			return
+		}
+		options.ProcessedData.Append("@"+body.File.Src, DataBinaryType)
+	case "graphql":
+		if body.GraphQL == nil {
+			return
+		}
+		content := map[string]interface{}{"query": body.GraphQL.Query}
+		var variables interface{}
+		if err := json.Unmarshal([]byte(self.expand(body.GraphQL.Variables)), &variables); err == nil {
+			content["variables"] = variables
+		}
+		text, _ := json.Marshal(content)
+		appendBinaryData(options, string(text))
+		contentType = "application/json"
+	default:
+		options.AddWarning("Postman body mode %s is not supported. It is ignored.", body.Mode)
+	}
+	if contentType != "" {
+		options.InsertContentTypeHeader(contentType)
+	}
+}
@@ -0,0 +1,67 @@
+package common
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type PostmanImporterTest struct{}
+
+var _ = Suite(&PostmanImporterTest{})
+
+func (s *PostmanImporterTest) Test_ParsePostmanCollection(c *C) {
+	requests, err := ParsePostmanCollection([]byte(`{
+		"info": {"name": "API", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}"}]},
+		"variable": [{"key": "baseUrl", "value": "http://localhost:18888"}],
+		"item": [
+			{"name": "Users", "item": [
+				{"name": "List users", "request": {"method": "GET", "url": {"raw": "{{baseUrl}}/users?limit=10"},
+					"header": [{"key": "Accept", "value": "application/json"}, {"key": "X-Debug", "value": "1", "disabled": true}, {"key": "Cookie", "value": "a=b"}]}},
+				{"name": "Admin", "auth": {"type": "basic", "basic": [{"key": "username", "value": "admin"}, {"key": "password", "value": "secret"}]}, "item": [
+					{"name": "Create user", "request": {"method": "POST", "url": "{{baseUrl}}/users",
+						"body": {"mode": "raw", "raw": "{\"name\": \"bob\"}", "options": {"raw": {"language": "json"}}}}}
+				]}
+			]},
+			{"name": "Health", "request": "{{baseUrl}}/health", "auth": {"type": "noauth"}}
+		]
+	}`))
+	c.Assert(err, IsNil)
+	c.Assert(requests, HasLen, 3)
+	c.Check(requests[0].Name, Equals, "List users")
+	c.Check(requests[0].Groups, DeepEquals, []string{"Users"})
+	c.Check(requests[0].Options.ToCurl(CurlStyle{}), Equals, "curl 'http://localhost:18888/users?limit=10' -H 'Accept: application/json' -H 'Authorization: Bearer {{token}}' -b a=b")
+	c.Check(requests[1].Name, Equals, "Create user")
+	c.Check(requests[1].Groups, DeepEquals, []string{"Users", "Admin"})
+	c.Check(requests[1].Options.ToCurl(CurlStyle{}), Equals, `curl http://localhost:18888/users -H 'Content-Type: application/json' -u admin:secret --data-binary '{"name": "bob"}'`)
+	c.Check(requests[2].Groups, IsNil)
+	c.Check(requests[2].Options.ToCurl(CurlStyle{}), Equals, "curl http://localhost:18888/health")
+}
+
+func (s *PostmanImporterTest) Test_ParsePostmanCollection_Body(c *C) {
+	requests, err := ParsePostmanCollection([]byte(`{"item": [
+		{"name": "form", "request": {"method": "POST", "url": "http://localhost:18888/",
+			"body": {"mode": "urlencoded", "urlencoded": [{"key": "a", "value": "b c"}, {"key": "d", "value": "e", "disabled": true}]}}},
+		{"name": "multipart", "request": {"method": "POST", "url": "http://localhost:18888/",
+			"body": {"mode": "formdata", "formdata": [{"key": "a", "value": "<b", "type": "text"}, {"key": "c", "src": ["x.txt", "y.png"], "type": "file"}]}},
+			"protocolProfileBehavior": {"strictSSL": false}},
+		{"name": "file", "request": {"method": "PUT", "url": "http://localhost:18888/", "body": {"mode": "file", "file": {"src": "x.bin"}}}},
+		{"name": "graphql", "request": {"method": "POST", "url": "http://localhost:18888/",
+			"body": {"mode": "graphql", "graphql": {"query": "{ users { id } }", "variables": "{\"limit\": 1}"}}}},
+		{"name": "apikey", "request": {"method": "GET", "url": "http://localhost:18888/?a=b",
+			"auth": {"type": "apikey", "apikey": [{"key": "key", "value": "api_key"}, {"key": "value", "value": "x y"}, {"key": "in", "value": "query"}]}}}
+	]}`))
+	c.Assert(err, IsNil)
+	c.Assert(requests, HasLen, 5)
+	c.Check(requests[0].Options.ToCurl(CurlStyle{}), Equals, "curl http://localhost:18888/ --data-urlencode 'a=b c'")
+	c.Check(requests[1].Options.ToCurl(CurlStyle{}), Equals, "curl http://localhost:18888/ --form-string 'a=<b' -F c=@x.txt -F c=@y.png -k")
+	c.Check(requests[2].Options.ToCurl(CurlStyle{}), Equals, "curl http://localhost:18888/ -X PUT --data-binary @x.bin")
+	c.Check(requests[3].Options.ToCurl(CurlStyle{}), Equals, `curl http://localhost:18888/ -H 'Content-Type: application/json' --data-binary '{"query":"{ users { id } }","variables":{"limit":1}}'`)
+	c.Check(requests[4].Options.ToCurl(CurlStyle{}), Equals, "curl 'http://localhost:18888/?a=b&api_key=x+y'")
+}
+
+func (s *PostmanImporterTest) Test_ParsePostmanCollection_Error(c *C) {
+	_, err := ParsePostmanCollection([]byte(`{"info": {"schema": "https://schema.getpostman.com/json/collection/v2.0.0/collection.json"}, "item": []}`))
+	c.Check(err, ErrorMatches, "can't import Postman collection: only Collection v2.1 is supported")
+	_, err = ParsePostmanCollection([]byte(`{"item": [{"name": "broken", "request": {"method": "GET"}}]}`))
+	c.Check(err, ErrorMatches, "can't import Postman collection: broken: url is missing")
+}
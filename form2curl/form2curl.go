@@ -264,7 +264,7 @@ func (self *Form) selectAttribute(node *html.Node, attributeName string, attribu
 		return names[0]
 	default:
 		if dumpWarning {
-			self.warning("%s has multiple %s attributes. Remove duplication.", getTagName(node), attributeName, attributeName)
+			self.warning("%s has multiple %s attributes. Remove duplication.", getTagName(node), attributeName)
 		}
 		return ""
 	}
@@ -315,6 +315,7 @@ func (self *Form) readFormParameter() {
 			case "multipart/form-data":
 				self.EncType = attribute.Val
 			case "text/plain":
+				self.EncType = attribute.Val
 				self.warning("This tool doesn't support <form> enctype text/plain")
 			default:
 				self.warning("<form> enctype '%s' is invalid. you can use the following values: application/x-www-form-urlencoded, multipart/form-data.", attribute.Val)
@@ -1,6 +1,8 @@
 package form2curl
 
 import (
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 	. "gopkg.in/check.v1"
 )
 
@@ -94,6 +96,7 @@ func (s *Form2CurlTest) Test_FormParameterErrorTest_6(c *C) {
 	form, err := CreateFormFromString(src)
 	c.Check(err, Equals, nil)
 	c.Check(form.EncType, Equals, "text/plain")
+	c.Check(form.Warnings, DeepEquals, []string{"This tool doesn't support <form> enctype text/plain"})
 }
 
 func (s *Form2CurlTest) Test_FormParameterErrorTest_7(c *C) {
@@ -106,6 +109,15 @@ func (s *Form2CurlTest) Test_FormParameterErrorTest_7(c *C) {
 	c.Check(len(form.Warnings), Equals, 1)
 }
 
+func (s *Form2CurlTest) Test_MultipleAttributes(c *C) {
+	// HTML parser drops duplicated attributes, so attributes are passed directly
+	form := &Form{}
+	node := &html.Node{Type: html.ElementNode, DataAtom: atom.Input, Data: "input"}
+	value := form.selectAttribute(node, "name", map[string][]string{"name": {"a", "b"}}, true)
+	c.Check(value, Equals, "")
+	c.Check(form.Warnings, DeepEquals, []string{"<input/> has multiple name attributes. Remove duplication."})
+}
+
 func (s *Form2CurlTest) Test_TextInput(c *C) {
 	src := `
 	<form>
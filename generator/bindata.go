@@ -1,7 +1,14 @@
 // Code generated by go-bindata.
 // sources:
+// templates/csharp_full.tpl
+// templates/fetch_browser_full.tpl
+// templates/fetch_node_full.tpl
+// templates/fetch_node_module.tpl
+// templates/fetch_ts_full.tpl
+// templates/fetch_ts_module.tpl
 // templates/go_full.tpl
 // templates/go_get_with_data_url.tpl
+// templates/go_module.tpl
 // templates/go_post_form.tpl
 // templates/go_post_single_file.tpl
 // templates/go_post_text.tpl
@@ -10,6 +17,9 @@
 // templates/go_simple_method.tpl
 // templates/go_simple_post.tpl
 // templates/java_full.tpl
+// templates/java_httpclient_full.tpl
+// templates/java_module.tpl
+// templates/kotlin_okhttp_full.tpl
 // templates/nodejs_external_file.tpl
 // templates/nodejs_external_files.tpl
 // templates/nodejs_full.tpl
@@ -17,7 +27,20 @@
 // templates/objc_nsurlconnection_full.tpl
 // templates/objc_nsurlsession_full.tpl
 // templates/php_full.tpl
+// templates/php_module.tpl
 // templates/python_full.tpl
+// templates/python_httpx_async_full.tpl
+// templates/python_httpx_full.tpl
+// templates/python_module.tpl
+// templates/python_requests_full.tpl
+// templates/python_requests_module.tpl
+// templates/ruby_faraday_full.tpl
+// templates/ruby_full.tpl
+// templates/ruby_module.tpl
+// templates/rust_async_full.tpl
+// templates/rust_full.tpl
+// templates/swift_async_full.tpl
+// templates/swift_full.tpl
 // templates/vim_script_full.tpl
 // templates/xhr_external_file.tpl
 // templates/xhr_external_files.tpl
@@ -89,7 +112,127 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesGo_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x50\x41\x6b\xf3\x30\x0c\xbd\xfb\x57\xf8\x2b\xe5\xc3\x81\xe0\x1f\x30\xe8\xa1\x5b\xd9\xad\xa3\x14\xb6\xeb\x50\x63\xa5\x35\x75\xed\x4c\x71\x18\xa5\xe4\xbf\x4f\x8a\xdb\xec\x3e\x5d\x92\xa7\xf7\xf4\x9e\xe4\x0e\x9a\x33\x1c\x51\x5f\xc0\x47\xa5\xfc\xa5\x4b\x94\xb5\x51\xb7\x9b\x26\x88\xdc\x5f\x9e\xf1\x5a\xeb\xe5\xa7\x7e\x5a\x69\xbb\x4d\x6e\x08\xd8\xeb\x71\xd4\x5c\x0b\x16\x09\xcd\x70\xc1\x03\x18\xdd\x38\x56\x32\x69\xd7\xce\xf9\xec\x53\x84\xb0\xc1\x26\x00\x81\x00\x96\xa9\x76\x88\xcd\x14\x65\x2a\x7d\x53\x62\x22\xf2\x1d\x61\x07\x84\x2f\xc1\x63\xcc\x22\x13\xa2\x29\x88\x63\xff\x9f\x72\xee\x6c\x61\x45\x5e\xfe\x9e\x93\xbb\x3e\xb4\xd2\xdd\x40\x86\x07\x26\xfc\x1a\xb0\xcf\xb5\x46\x22\x71\x98\x0c\xde\xf0\x7b\x5f\xfa\x46\x36\xb7\x5b\xcc\xa7\xe4\x64\xf9\x7a\x32\x78\xa7\xc0\xa0\x9e\xcd\x3e\x80\x3c\x1c\x02\x6a\xb9\xea\x91\xc2\x2f\xe0\xdb\xeb\xdd\xe7\x37\xae\xef\xe6\xac\xb2\xb7\xdd\x24\x73\xdf\xa2\x0c\xfb\x76\x12\xfc\x5b\xe9\xe8\xc3\xfd\x76\xa9\x90\x8e\xf6\x95\xd3\x82\x61\xba\x48\x8b\xa9\xc3\x16\x69\xb2\xb6\x72\x2a\x5f\x9d\x7a\x34\x45\x71\xe0\xc6\x1c\xe8\xd3\x90\x7d\xb0\x7b\x04\xb7\x0e\xc1\xcc\x13\x7f\xcc\x15\x62\x47\x3e\x66\xd3\x67\xfe\x1c\x8d\x84\x55\x95\x1a\xd5\x4f\x00\x00\x00\xff\xff\xb6\x98\x26\x81\x2b\x02\x00\x00")
+var _templatesCsharp_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x64\x8f\xc1\x6a\xeb\x30\x10\x45\xf7\xf9\x8a\x21\xbc\x85\x0d\x0f\x7f\x40\x43\x16\xc1\x5d\x64\xd1\x40\xb0\x29\x5d\x0f\xd1\xd4\x11\x15\x23\x57\x33\x4e\x08\x66\xfe\xbd\xc8\x56\xb3\xe9\xf2\x0e\xba\xf7\x1c\xcd\x33\x34\xef\xe2\x79\x10\x30\xdb\xe4\x74\x4e\x34\x62\x22\x30\xcb\xe9\x88\xec\x02\xa5\x92\xda\xe0\x89\x35\xbf\xbc\x61\x82\x44\xdf\x13\x89\xc2\x1e\x98\xee\x70\x54\x1d\xbb\xf5\x72\x22\x11\x1c\xa8\xca\x95\x13\xe9\x35\x3a\x30\xfb\x0f\x0b\x2b\x05\x30\xab\x77\x0b\xea\x14\x9d\xff\x7c\x94\x52\x5e\x9d\xb2\x09\xac\xdb\x32\x46\x16\x82\x3d\xe0\x1d\xbd\xc2\x65\x41\x37\x3d\xb1\x3b\xc8\x83\x2f\x55\xa1\x97\xa9\x1e\x6f\xd4\xc6\xf8\xe5\x29\x7f\xa4\x8d\x2c\x31\x50\xf3\x91\xbc\xd2\x9b\x67\xaa\xfe\x6d\xbb\xb2\xf8\x02\x73\xe5\x59\xeb\x5f\x42\xd3\x2b\xea\x24\x6d\x74\x64\x30\x3f\xaf\x1d\xa1\x44\x3e\x5f\x13\x0a\xd9\xb6\xde\x6d\xfe\x6e\xae\x62\xcf\x46\x1b\x59\xb3\x62\x47\xe8\x0e\xd2\x6b\xf2\x3c\xac\xaa\x75\x91\x3c\x38\xe7\xd5\x47\xc6\xf0\x4a\x97\x80\x09\x73\x00\xb3\x9f\x01\x00\xd2\xd0\x77\x6d\x85\x01\x00\x00")
+
+func templatesCsharp_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesCsharp_fullTpl,
+		"templates/csharp_full.tpl",
+	)
+}
+
+func templatesCsharp_fullTpl() (*asset, error) {
+	bytes, err := templatesCsharp_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/csharp_full.tpl", size: 389, mode: os.FileMode(420), modTime: time.Unix(1792305173, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesFetch_browser_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x53\xc1\x8e\xd3\x30\x10\xbd\xe7\x2b\x86\xa8\x87\x44\x5a\x92\x2b\x6a\x1d\x1f\xd8\x16\xa9\x12\x82\x15\x5b\x0e\x48\x48\xac\x6b\x4f\x5b\x0b\xc7\x0e\xf6\x84\xdd\x2a\xf2\xbf\x23\x3b\x6d\x41\x42\x5c\x38\x44\x71\xc6\xef\xbd\xf1\x7b\x19\xb3\x57\xeb\x8f\xf7\xbb\x2f\x0f\x1b\x38\x51\x6f\x78\xc1\xae\x2f\x14\x8a\x17\x00\x00\xac\x47\x12\x20\x4f\xc2\x07\xa4\xae\x1c\xe9\xf0\xfa\x4d\x79\xd9\x22\x4d\x06\xf9\x01\x49\x9e\x60\x87\x81\x58\x3b\x57\x0a\xd6\xce\x02\x6c\xef\xd4\x99\x4f\x13\x78\x61\x8f\x08\x8b\x6f\x77\xb0\xd0\x76\x18\x09\x96\x1d\x34\xef\xb4\xc1\x6d\xfa\x0a\x10\x63\xc1\x06\xce\x8c\xd8\xa3\x49\xf8\x19\x95\x11\x1f\x44\x8f\x10\xe3\x12\xd8\xcc\xa4\xf3\x80\x5d\x79\xd0\x06\x4b\xd0\xaa\x2b\x7f\xa3\xb7\x0a\x62\x2c\x39\x6b\x67\x19\xd6\x0e\x7c\x9a\xd0\xaa\x24\xbe\x1f\x89\x9c\xcd\x84\x80\x56\x95\xfc\x11\xad\x62\xed\x5c\xe6\x05\x1b\x3c\xe6\x4d\x8f\x61\x34\x94\x44\x06\x9f\x8c\x04\xe9\xf5\x70\x6d\xda\x3b\x35\x1a\x2c\x79\x31\x4d\xd0\xac\x51\x1a\xe1\x05\x69\x67\x21\x46\x11\xce\x56\xc2\x61\xb4\x32\x17\x3c\xfe\x18\x31\x50\x55\xc3\x94\xa3\x4a\x84\x07\x8f\x83\xf0\xc9\x8b\x74\x36\x10\x78\x0c\x83\xb3\x01\xa1\x03\xf1\x2c\x34\x41\x0e\xb2\x4a\xd0\xcf\xde\x40\x8c\x69\xb5\xb5\x9a\x20\xc6\x7a\x95\x65\x66\x62\x0a\xf5\x46\xba\xaa\x34\x84\x2f\x54\xd5\xab\x44\xda\xa1\xf0\x6b\xf7\x9c\xce\x95\x69\xca\xc9\xb1\x47\x4b\xcd\x11\x69\x63\x30\x2d\xdf\x9e\xb7\xaa\xba\xba\xad\x33\xf9\xde\x59\x42\x4b\xd0\xc1\xd3\xa7\x8b\xe8\x12\x16\xd3\xad\x41\x20\x41\x63\x88\x7f\x97\x76\xf8\x42\xf1\xab\x5d\x4c\xe9\x60\xf1\x69\x55\xc4\xa2\xf8\x67\xcb\x9c\x7e\xdd\x08\xa5\x36\x3f\xd1\xd2\x7b\x1d\x08\x2d\xfa\xaa\x94\x46\xcb\xef\xe5\x1d\x54\x35\x74\xfc\x12\xdb\x2d\xc6\x46\x8a\x94\x4d\x85\xde\x3b\xff\x07\xe0\x7f\xdc\x3d\x92\xd7\xf6\x78\x91\x9a\x73\x4d\xf9\xa6\x87\xb5\xf3\xff\x4e\x13\x9c\x47\x37\x4d\x72\xba\x11\xbf\x06\x00\x7c\x3c\x71\x52\x28\x03\x00\x00")
+
+func templatesFetch_browser_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesFetch_browser_fullTpl,
+		"templates/fetch_browser_full.tpl",
+	)
+}
+
+func templatesFetch_browser_fullTpl() (*asset, error) {
+	bytes, err := templatesFetch_browser_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/fetch_browser_full.tpl", size: 808, mode: os.FileMode(420), modTime: time.Unix(1792304974, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesFetch_node_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x64\x8f\xc1\x4a\xc5\x30\x10\x45\xf7\xef\x2b\x66\xe1\xa2\xd9\xf4\x03\x2c\x2e\xc4\x6e\x04\x17\xa2\xcf\xfd\x1b\xe2\xd5\x06\x63\x26\x24\x53\x5b\x28\xf9\x77\x09\x6d\x44\x78\xcb\x7b\xb9\x9c\x33\xb3\x6d\xd4\x8f\x88\x08\xef\x08\xd6\x21\x53\x29\xb5\x7a\xfc\x8e\x92\xb4\xa5\x11\xd6\x73\x62\x75\x12\x8e\xe6\x39\x21\x72\xc2\x91\x9e\xa0\x54\x0a\x25\xe4\x28\x21\x83\xee\x88\x17\x76\x4a\x1f\x50\x3b\x75\x75\xf1\x96\x7c\x23\x07\x57\xc7\x66\x38\xd5\x74\x3f\xeb\x84\xa0\xce\xb2\x36\xd8\x2b\xff\xe0\x41\xe4\x6b\x3f\xc6\x4a\xc8\xe2\xd1\x7b\xf9\xec\x2e\x2f\x87\xe0\x96\x6e\xb6\x26\xeb\xb3\xb2\xce\xb9\x5c\x57\x67\xac\x5a\x2e\x66\x38\xfd\x67\xec\x87\xfd\x2d\x15\xab\x76\xc6\x0c\x55\x7c\x06\xa7\x51\x96\xfa\xe2\xef\x00\x10\x9f\x01\x38\x16\x01\x00\x00")
+
+func templatesFetch_node_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesFetch_node_fullTpl,
+		"templates/fetch_node_full.tpl",
+	)
+}
+
+func templatesFetch_node_fullTpl() (*asset, error) {
+	bytes, err := templatesFetch_node_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/fetch_node_full.tpl", size: 278, mode: os.FileMode(420), modTime: time.Unix(1792304974, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesFetch_node_moduleTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x51\x4d\x6b\xdb\x40\x10\xbd\xfb\x57\xbc\x83\x0f\x12\x84\x0d\xbd\xc6\xe8\x10\x1a\x42\x0b\x69\x29\xae\x73\x0a\xa1\x19\x94\x91\xbd\x54\xde\x15\xb3\xa3\xd4\x41\xec\x7f\x2f\xa3\x0f\x37\x21\x74\x4f\xd2\xec\x7b\x6f\xde\x7b\x3b\x0c\x70\x37\xdc\x71\x78\xe6\x50\x7b\x4e\xc8\xd9\x46\x5f\x8f\x5d\x14\x5d\xfe\x6e\xb8\x6e\x49\x48\x7d\x0c\xd3\x44\x28\xec\x19\x6b\x7f\x81\xf5\x2f\x5c\x55\x70\xb7\x7d\xa8\xed\x7a\x66\xf8\x06\x6b\x8f\x9c\x57\xc3\x00\x0e\xcf\xc8\xf9\xf2\x12\xa6\xb4\xf3\xda\xb2\x5d\xf0\xc9\x16\x80\xd2\x6b\xa8\xd1\xcc\xec\x11\xf2\x9d\x8e\x86\x28\xec\xfb\x5a\xf6\xfd\x91\xc3\x68\xa4\xb4\xc1\x96\xb5\x97\xb0\x7b\xed\x0c\x82\x61\x05\x60\x24\xfd\x10\xee\x48\x78\xf6\x7b\xc7\x6a\xd7\xc2\xa9\x8b\x21\x31\x2a\xd0\x1f\xf2\x8a\x86\xb5\x3e\x8c\xc2\xf7\xd2\x2e\x49\x83\x37\x70\xb9\x39\x6b\x5d\xf7\x7a\xe0\xa0\xbe\x26\x5d\x04\x7f\xd2\x0b\x7f\x8e\xf1\xf7\x54\x90\x8c\x26\xce\xf2\x9b\xd5\x9b\x9c\x53\x78\xf7\x85\xd2\x37\xf2\xc1\x92\xfa\x06\x85\x1f\xeb\x74\x47\x56\x72\xbd\xb4\xa8\xaa\x0a\x1d\xe9\x61\x17\x6f\x7d\xcb\xf7\xdb\xbb\xa2\x93\x58\x73\x4a\x8e\x64\xff\xf2\xf0\xe9\xb1\x74\x07\xe1\xa6\x9c\x13\x36\x51\x50\xd4\x31\x24\x45\xb2\x35\xb1\xc1\xc3\xea\xfc\x0c\xef\xca\xc7\x7c\x46\xd7\xf6\xaa\x82\x9c\x2f\xfe\xf9\x03\x80\xc7\x45\xd7\xce\x24\xfb\xa1\x2a\xdb\x53\x94\x9b\x77\xb0\xd8\xb2\x6b\xe3\xbe\x78\xda\xce\xe8\x2b\xac\x87\x85\xe9\x92\x92\xf6\x29\x7f\x1c\xed\xf8\xa4\xf9\xe9\x3f\x5a\xd3\xb6\x33\x43\xf9\xa4\x45\x39\x63\xf3\xdb\x62\xff\x0e\x00\xf4\x37\xc4\x50\xa9\x02\x00\x00")
+
+func templatesFetch_node_moduleTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesFetch_node_moduleTpl,
+		"templates/fetch_node_module.tpl",
+	)
+}
+
+func templatesFetch_node_moduleTpl() (*asset, error) {
+	bytes, err := templatesFetch_node_moduleTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/fetch_node_module.tpl", size: 681, mode: os.FileMode(420), modTime: time.Unix(1792313618, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesFetch_ts_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x64\x8f\xc1\x4a\xc5\x30\x10\x45\xf7\xef\x2b\x66\xe1\xa2\xd9\xf4\x03\x2c\x2e\xc4\x6e\x04\x17\xa2\xcf\xfd\x1b\xe2\xd5\x06\x63\x26\x24\x53\x5b\x28\xf9\x77\x09\x6d\x44\x78\xcb\x7b\xb9\x9c\x33\xb3\x6d\xd4\x8f\x88\x08\xef\x08\xd6\x21\x53\x29\xb5\x7a\xfc\x8e\x92\xb4\xa5\x11\xd6\x73\x62\x75\x12\x8e\xe6\x39\x21\x72\xc2\x91\x9e\xa0\x54\x0a\x25\xe4\x28\x21\x83\xee\x88\x17\x76\x4a\x1f\x50\x3b\x75\x75\xf1\x96\x7c\x23\x07\x57\xc7\x66\x38\xd5\x74\x3f\xeb\x84\xa0\xce\xb2\x36\xd8\x2b\xff\xe0\x41\xe4\x6b\x3f\xc6\x4a\xc8\xe2\xd1\x7b\xf9\xec\x2e\x2f\x87\xe0\x96\x6e\xb6\x26\xeb\xb3\xb2\xce\xb9\x5c\x57\x67\xac\x5a\x2e\x66\x38\xfd\x67\xec\x87\xfd\x2d\x15\xab\x76\xc6\x0c\x55\x7c\x06\xa7\x51\x96\xfa\xe2\xef\x00\x10\x9f\x01\x38\x16\x01\x00\x00")
+
+func templatesFetch_ts_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesFetch_ts_fullTpl,
+		"templates/fetch_ts_full.tpl",
+	)
+}
+
+func templatesFetch_ts_fullTpl() (*asset, error) {
+	bytes, err := templatesFetch_ts_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/fetch_ts_full.tpl", size: 278, mode: os.FileMode(420), modTime: time.Unix(1792304974, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesFetch_ts_moduleTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x51\x4d\x6b\xdb\x40\x10\xbd\xfb\x57\xbc\x83\x0f\x12\x84\x0d\xbd\xc6\xe8\x10\x1a\x42\x0b\x69\x29\xae\x73\x0a\xa1\x19\x94\x91\xbd\x54\xde\x15\xb3\xa3\xd4\x41\xec\x7f\x2f\xa3\x0f\x37\x21\x74\x4f\xd2\xec\x7b\x6f\xde\x7b\x3b\x0c\x70\x37\xdc\x71\x78\xe6\x50\x7b\x4e\xc8\xd9\x46\x5f\x8f\x5d\x14\x5d\xfe\x6e\xb8\x6e\x49\x48\x7d\x0c\xd3\x44\x28\xec\x19\x6b\x7f\x81\xf5\x2f\x5c\x55\x70\xb7\x7d\xa8\xed\x7a\x66\xf8\x06\x6b\x8f\x9c\x57\xc3\x00\x0e\xcf\xc8\xf9\xf2\x12\xa6\xb4\xf3\xda\xb2\x5d\xf0\xc9\x16\x80\xd2\x6b\xa8\xd1\xcc\xec\x11\xf2\x9d\x8e\x86\x28\xec\xfb\x5a\xf6\xfd\x91\xc3\x68\xa4\xb4\xc1\x96\xb5\x97\xb0\x7b\xed\x0c\x82\x61\x05\x60\x24\xfd\x10\xee\x48\x78\xf6\x7b\xc7\x6a\xd7\xc2\xa9\x8b\x21\x31\x2a\xd0\x1f\xf2\x8a\x86\xb5\x3e\x8c\xc2\xf7\xd2\x2e\x49\x83\x37\x70\xb9\x39\x6b\x5d\xf7\x7a\xe0\xa0\xbe\x26\x5d\x04\x7f\xd2\x0b\x7f\x8e\xf1\xf7\x54\x90\x8c\x26\xce\xf2\x9b\xd5\x9b\x9c\x53\x78\xf7\x85\xd2\x37\xf2\xc1\x92\xfa\x06\x85\x1f\xeb\x74\x47\x56\x72\xbd\xb4\xa8\xaa\x0a\x1d\xe9\x61\x17\x6f\x7d\xcb\xf7\xdb\xbb\xa2\x93\x58\x73\x4a\x8e\x64\xff\xf2\xf0\xe9\xb1\x74\x07\xe1\xa6\x9c\x13\x36\x51\x50\xd4\x31\x24\x45\xb2\x35\xb1\xc1\xc3\xea\xfc\x0c\xef\xca\xc7\x7c\x46\xd7\xf6\xaa\x82\x9c\x2f\xfe\xf9\x03\x80\xc7\x45\xd7\xce\x24\xfb\xa1\x2a\xdb\x53\x94\x9b\x77\xb0\xd8\xb2\x6b\xe3\xbe\x78\xda\xce\xe8\x2b\xac\x87\x85\xe9\x92\x92\xf6\x29\x7f\x1c\xed\xf8\xa4\xf9\xe9\x3f\x5a\xd3\xb6\x33\x43\xf9\xa4\x45\x39\x63\xf3\xdb\x62\xff\x0e\x00\xf4\x37\xc4\x50\xa9\x02\x00\x00")
+
+func templatesFetch_ts_moduleTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesFetch_ts_moduleTpl,
+		"templates/fetch_ts_module.tpl",
+	)
+}
+
+func templatesFetch_ts_moduleTpl() (*asset, error) {
+	bytes, err := templatesFetch_ts_moduleTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/fetch_ts_module.tpl", size: 681, mode: os.FileMode(420), modTime: time.Unix(1792313618, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesGo_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x52\xcf\xca\xdb\x30\x0c\xbf\xfb\x29\xb4\x52\x86\x03\xc5\x0f\x50\xe8\xa1\x6b\xd9\xad\xa3\x74\x6c\xd7\xa1\xc6\x4a\x6a\xea\xda\x99\xa3\x6c\x84\xe0\x77\xff\x50\xdc\xa6\xf7\x2f\x27\x4b\xfa\xfd\x85\x74\x58\xdf\xb1\x25\x78\xa0\x0b\x4a\xb9\x47\x17\x13\x83\x56\xd3\x04\x09\x43\x4b\xb0\xbe\xd3\xb8\x81\xf5\x1f\xd8\xee\xc0\x9c\xa2\x1d\x3c\xf5\x90\x33\x00\xc0\x6a\x9a\xe6\x33\xe4\xbc\x52\xd3\x44\xc1\xe6\x5c\x09\xd3\xec\xad\x75\xec\x62\x40\x7f\xa4\xda\x63\x42\x19\x20\x67\xd5\x0c\xa1\x9e\xad\x74\x05\x93\x02\x00\x10\xf8\x39\x51\x87\x89\x0e\xde\x51\x60\x81\x01\x00\xd4\x65\xda\xee\xe0\xeb\x8d\xb9\x33\xe5\x2a\xf0\xf2\xfa\x16\xed\xf8\xc2\xca\xf6\x88\x8c\xaf\x39\xd1\xdf\x81\x7a\xde\x00\xa5\x24\x0a\xb3\xc0\x0f\xfa\x7f\x29\x7b\x2d\xf8\x13\xf1\x2d\x5a\xc8\x79\x33\xd3\x7f\x25\xbf\xbc\x45\xea\x37\x26\x87\x57\x4f\x20\x9d\x5e\x1e\xa7\x68\x5d\x33\x3e\x55\xde\x66\x7d\xb7\x38\x95\xd4\xe6\x18\xf5\x33\x43\x21\xbb\x66\x06\x7c\xd9\x41\x70\xfe\xd9\x5c\x3e\x1f\x5b\xf3\x1d\x19\xbd\xa6\x94\x0a\xf4\xdd\x68\x3f\xf0\x8d\x02\xbb\x1a\x59\x62\x58\x6a\x28\xcd\x6e\x46\xba\x9b\x83\x8f\x3d\xe9\x42\xba\x46\x3b\x2e\x19\x5c\x1c\xd8\x79\x73\x21\xb4\x7b\xef\xf5\xc2\xf8\x64\x14\x39\x9c\x93\x0b\xac\x7b\x4e\x2e\xb4\x5a\xcc\xaa\x4a\x12\xfe\xc4\x7f\x74\x88\xf1\xee\xe6\x7f\x42\x65\xf5\x31\x00\x69\x98\xaf\xbe\x4e\x02\x00\x00")
 
 func templatesGo_fullTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -104,7 +247,7 @@ func templatesGo_fullTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/go_full.tpl", size: 555, mode: os.FileMode(420), modTime: time.Unix(1426433181, 0)}
+	info := bindataFileInfo{name: "templates/go_full.tpl", size: 590, mode: os.FileMode(420), modTime: time.Unix(1792311699, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -129,6 +272,26 @@ func templatesGo_get_with_data_urlTpl() (*asset, error) {
 	return a, nil
 }
 
+var _templatesGo_moduleTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xac\x53\xcd\x6a\x1b\x31\x10\xbe\xef\x53\x4c\x43\x28\xda\x60\x94\x7b\xc0\x07\xc7\x21\xf4\xe2\x10\x52\xda\x1e\x4a\x09\x93\xd5\xd8\x16\x96\x25\x57\xd2\x36\x31\x8b\xde\xbd\x8c\xb4\xde\xdd\xb4\xf8\xd4\xfa\xb2\xd2\x8c\x66\xe6\xfb\x19\x1f\xb0\xd9\xe1\x86\xa0\xeb\x40\x3e\xf6\xe7\x94\xaa\x4a\xef\x0f\xce\x47\x10\x55\xd7\x81\x47\xbb\x21\xb8\xdc\xd1\x71\x06\x97\xcf\x70\x33\x07\xb9\x72\xaa\x35\x14\x20\x25\x00\x80\x8b\xae\xcb\x69\x48\xe9\xa2\xea\x3a\xb2\x2a\xa5\x9a\x2b\xe5\x42\x29\x1d\xb5\xb3\x68\xee\xa8\x31\xe8\x91\x2f\x3c\xe0\xfa\x1a\x1e\xe8\x75\x69\x34\xd9\x08\x8d\x27\x8c\x14\x20\x6e\x09\x9a\x12\x0a\x5b\xf4\xa4\xe0\xe5\x08\x68\x0c\x78\xfa\xd9\x52\x88\x41\x56\xeb\xd6\x36\x63\xa5\xa8\xe1\x6a\x1b\xe3\x41\x96\x2b\x74\x15\x00\x64\x2e\x25\x20\x1f\x3d\x1d\xd0\x53\x9f\x4e\x29\xe7\x3d\xc5\xd6\x5b\xf8\x38\xa9\x9c\x94\x94\xcf\xad\x53\x4c\xa7\x4a\xa3\x00\xf2\xbe\xb5\x0d\xe3\x67\xda\x99\xdd\xd2\xed\xf7\x7d\xdf\x8c\x8b\x63\x0f\xb8\x67\x05\x45\x13\xdf\xa0\x71\x36\xd2\x5b\x94\xcb\xf2\x9d\x9d\xc8\x5d\xfd\x31\x79\xe1\x37\x2d\x37\xe2\xc6\x35\x88\x92\x7e\xa2\x70\x70\x36\xd0\x0c\xc8\x7b\xe7\xeb\x09\xb9\x3b\x8c\x38\x92\xc9\xd2\xe4\x57\x6c\x4d\xae\x7d\xa0\xd7\xa7\x12\xff\xa6\xe3\xb6\x1f\xcf\x90\x66\xb9\x7e\x45\x71\xeb\x14\xa4\x54\xae\x5f\xbc\x19\xce\xdc\xfa\x2b\x7a\x8d\x2f\x86\x69\xd4\x79\x86\x5e\xe7\xee\x1f\xe6\x60\xb5\xe9\x71\x4c\x84\xb4\xda\xe4\xf1\x39\x9e\x06\x94\x2b\xa7\xf4\xfa\xd8\xe3\x18\xe1\x86\xc3\x80\xb5\xc8\x21\xef\x9c\xe8\x59\xfc\xcb\xb8\x45\x1b\xb7\x64\xa3\x6e\x30\x32\x72\x0e\x7d\xc6\x5f\xb4\x74\x6e\xa7\xf3\xa6\x4e\xbd\x2f\x30\xac\x36\xc5\x60\xb2\xaa\x94\xe8\x35\xc8\x4f\x18\x56\xa8\xed\xe0\xea\x1e\xb5\x15\x27\xf9\x7b\x07\x6f\xe6\xd3\x25\xcc\x99\xb5\xf3\xf0\x3c\x83\xc0\xbd\x6e\xe6\xfd\xce\x7c\xff\xc1\x2d\xc4\x5f\x8b\x30\xdd\x80\xb3\x8e\x77\x67\x76\xef\xa4\x47\xa6\x48\x56\x91\x67\xfb\x46\x1e\x00\x00\xe9\x9d\x6e\x13\xcd\x19\xdf\x80\xe7\x16\x9b\xdd\xc6\xbb\xd6\x2a\x51\x9f\xb6\xb3\x1e\xea\xce\xf9\xc0\x3f\xe3\x36\xf2\x1e\x23\x1a\x41\xde\x8f\x25\x69\x38\xbd\x38\x75\x1c\x86\x6a\xd7\x46\x6d\xe4\x13\xa1\x5a\x18\x23\x18\x90\xe4\x7f\x58\xfd\x0e\x63\x0e\xc9\xa5\x71\x81\xc4\x7f\x42\xc1\x0f\x1e\xbd\xb6\x51\x84\xe8\xb5\xdd\x08\x86\x55\xd7\xfd\xea\x4c\xbc\xff\x3d\x00\xee\x7d\x3d\xf5\x08\x05\x00\x00")
+
+func templatesGo_moduleTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesGo_moduleTpl,
+		"templates/go_module.tpl",
+	)
+}
+
+func templatesGo_moduleTpl() (*asset, error) {
+	bytes, err := templatesGo_moduleTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/go_module.tpl", size: 1288, mode: os.FileMode(420), modTime: time.Unix(1792311699, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _templatesGo_post_formTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x8d\x4d\x6a\x03\x31\x0c\x85\xf7\x3e\x85\x1a\xb2\xb0\x21\xf8\x00\x85\x2c\xfa\x43\x76\x85\x50\xe8\xba\xb8\xb1\x66\x6a\xa2\xb1\x07\xd9\x53\x08\x83\xef\x5e\xd9\x53\x72\x80\x6a\x23\xa4\xf7\xbd\xf7\x66\x77\xb9\xba\x11\x61\x72\x21\x2a\x15\xa6\x39\x71\x01\xad\xd6\x15\xd8\x45\xf9\xef\xaf\x78\x3b\xc0\xfe\x13\x1e\x8f\x60\xdf\x92\x5f\x08\x33\xd4\x0a\x32\x3b\x81\x9a\x2c\xe7\x4e\x0c\x18\x7d\xad\x46\xa9\x61\x89\x97\x1e\xa7\x0d\xac\xaa\x81\xc2\xd9\x57\x57\x9c\x80\xfd\x66\xcc\xf3\x01\x90\xb9\x85\x7e\x97\x32\xdb\x73\xca\xe5\x94\x78\xd2\x0d\xfd\x60\x12\xf2\x00\x3f\x8e\x16\xcc\xa6\x5b\xc2\xd0\xf9\x87\x23\xc4\x40\x7f\xb1\x6d\x28\x8d\xf6\x24\xd1\xa4\x45\xde\xd0\xad\xc3\xe3\x80\xdc\x9b\xec\x73\xf2\x37\xfb\x42\x29\xa3\xde\x88\x2f\x79\xdc\xfb\x43\x5a\x4a\x20\xfb\x8e\xce\x3f\x11\xe9\xbb\xe3\x9f\xbd\x4d\x38\x73\x88\x45\xe7\x22\x6b\xd4\xad\xcc\x18\x55\xd5\x6f\x00\x00\x00\xff\xff\x7f\x73\xbc\xec\x6a\x01\x00\x00")
 
 func templatesGo_post_formTplBytes() ([]byte, error) {
@@ -149,7 +312,7 @@ func templatesGo_post_formTpl() (*asset, error) {
 	return a, nil
 }
 
-var _templatesGo_post_single_fileTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x90\xc1\x6e\xc3\x20\x0c\x86\xef\x3c\x85\xc7\x09\xa4\x88\xde\x27\xf5\xb0\x55\xea\x75\xd1\xb4\x3d\x00\x6b\x9c\x14\x8d\x62\x04\xee\xa1\x8a\xf2\xee\x05\x12\xe5\x01\xea\x43\x62\xf8\x7f\xff\x9f\x45\xb4\x97\x7f\x3b\x21\xdc\xac\x0b\x42\xb8\x5b\xa4\xc4\xa0\x04\x94\x92\x94\xe5\xda\x78\x9a\xb6\xce\xd1\xc1\xd1\x9d\x9d\xdf\xce\x01\xf9\x70\x65\x8e\x52\x68\x21\xc6\x7b\xb8\xb4\x20\xa5\x61\x6e\xfa\xe8\x3c\x76\x80\x29\xc1\xfb\x11\x28\x9b\xaf\x88\x41\xc9\x79\x06\x73\x2e\x4a\x6f\xf9\x0a\xcb\x22\x75\xf3\xba\xb1\x19\xdf\x8e\x10\x9c\xdf\xe6\x6b\x15\xb8\x39\x5b\xb6\x5e\x15\x79\xb5\x2e\xed\x9b\x30\xc7\x3d\xbc\x2e\x61\x7a\xca\xac\x6a\xfa\x6f\xf2\x25\xb8\x83\x86\x3a\x51\x60\x0c\xfc\xf3\x88\x58\x69\x5d\xdb\xea\x45\xe6\x80\x23\xa6\x46\x36\x9f\x34\x3c\xcc\xc9\x53\x46\xb5\x3a\xfe\xca\xc5\xbe\xcf\xfa\x4a\xe6\x1b\xed\xf0\xe1\xbd\xda\x27\x5e\xe4\x56\xa1\x4f\x2e\xb0\xca\x5c\x7e\x93\xaa\x30\xad\xc5\x22\x9e\x01\x00\x00\xff\xff\xe0\x5c\xeb\xbb\xc1\x01\x00\x00")
+var _templatesGo_post_single_fileTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x50\x4d\x6a\xeb\x30\x10\xde\xeb\x14\xf3\xb2\x92\x21\x28\xfb\x07\x59\xb4\x81\x6c\x6b\x4a\x7b\x00\x35\x1e\x3b\x43\x27\x1a\x21\x4d\x16\xc6\xe8\xee\x45\xb2\xf1\x01\xa2\x85\xf8\xa4\xf9\xfe\x98\xe8\x6f\xbf\x7e\x42\x78\x78\x0a\xc6\xd0\x23\x4a\x52\xb0\x06\x00\xe0\x20\xf9\xb0\x02\x96\x69\x43\x24\x27\x92\xa7\x12\x6f\xef\x80\x7a\xba\xab\xc6\x83\xe9\x8c\x19\x9f\xe1\xd6\x8c\x6c\x07\x4b\x9b\x8f\xc4\x78\x04\x4c\x09\xfe\x9f\x41\xb2\xfb\x88\x18\xec\xb2\x80\xbb\x12\x63\xef\xf5\x0e\xa5\x74\x8d\x49\x63\xa3\xfd\x3b\x43\x20\xde\xd4\xf5\xb0\x4c\xee\xea\xd5\xb3\xc5\x94\x56\x6a\x69\x77\xc2\x1c\x77\xeb\x5a\xc1\xf5\x92\xb5\x99\x7f\x27\x86\x52\x8e\x50\xf1\x45\x82\x62\xd0\xaf\x39\x62\xfb\xab\x8d\x5e\x4c\x1c\x70\xc4\xd4\x72\xdd\xbb\x0c\xb3\xbb\xb0\x64\xb4\x2b\xe3\x47\x86\x79\x6f\xb3\x6e\xc8\x7d\xa2\x1f\xde\x98\xed\xae\x78\x31\xb7\x0e\xfa\x44\x41\x6d\xd6\x44\x61\xb2\x35\xac\xeb\x4c\x31\x7f\x03\x00\x53\x95\x17\x6b\xbd\x01\x00\x00")
 
 func templatesGo_post_single_fileTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -164,12 +327,12 @@ func templatesGo_post_single_fileTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/go_post_single_file.tpl", size: 449, mode: os.FileMode(420), modTime: time.Unix(1426433181, 0)}
+	info := bindataFileInfo{name: "templates/go_post_single_file.tpl", size: 445, mode: os.FileMode(420), modTime: time.Unix(1792311080, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesGo_post_textTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x8f\xc1\x4a\xc4\x30\x10\x86\xef\x79\x8a\x71\xd9\x43\x82\x25\x0f\xa0\xec\x41\x57\xc4\x8b\xb0\x88\x7a\x95\x74\x33\xad\x61\xb3\x49\x99\xa4\x48\x29\x7d\x77\x27\x6d\xed\x03\x98\x4b\x98\x99\x6f\xfe\xff\x9f\xce\x9c\x2f\xa6\x45\xb8\x1a\x17\x84\x70\xd7\x2e\x52\x06\x29\xc6\x11\xc8\x04\xee\xef\x2f\x38\x54\xb0\xff\x82\xbb\x03\xe8\xd7\x68\x7b\x8f\x09\xa6\x09\xf8\xed\x18\x2a\x63\x2e\x77\xbc\x80\xc1\x4e\x93\x12\xa2\xe9\xc3\x79\x96\x93\x0a\x46\x51\x40\xe6\xf4\x93\xc9\x86\xc1\xb9\x26\x4c\x5d\x05\x48\x54\x44\xbf\x73\xee\xf4\x29\xa6\x2c\x0b\xf6\x41\x9e\xa9\x6a\xd6\xd6\xc7\x18\x32\x86\xfc\x3e\x74\xc8\xcd\x71\x74\x0d\xe8\x17\x93\x1e\x63\x1f\xac\xa1\xe2\x7b\x0f\xf5\x5a\x1c\x76\x70\x0b\x3f\xe4\x32\x92\xfe\x03\xa4\xe2\x54\x3e\x21\xe7\x5b\xe3\x55\x5b\x96\x4f\x43\xce\xd4\xbe\x08\xab\x39\x14\x8b\x97\x44\x37\x07\x08\xce\xaf\xc1\xcb\xf3\xb1\xd5\xcf\xbc\xe0\x25\x8f\x17\x74\xb9\xc2\x62\x83\x34\xdf\xc2\x86\x76\xd0\x47\x1f\x13\xca\x85\xa8\xb9\xb1\x5d\xe8\x62\x9f\x9d\xd7\x6f\x68\xec\x83\xf7\x72\xdb\xf8\xa7\x6f\x19\x9c\xc8\x85\x2c\x53\xe6\xaf\x95\xc5\x4c\x29\x31\x89\xdf\x00\x00\x00\xff\xff\xc7\xb5\xc3\xed\xcc\x01\x00\x00")
+var _templatesGo_post_textTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x8e\x41\x6a\xc3\x30\x14\x44\xf7\x3a\xc5\x34\x64\x21\x43\xd0\x01\x0a\x59\xb4\x29\xdd\x15\x42\x69\xbb\x2d\x4a\xf4\xed\x8a\x28\x92\xf9\x96\x17\x46\xfc\xbb\x17\xc9\xc5\x07\xa8\x76\x9a\x3f\xf3\x66\x46\x7b\xbd\xd9\x81\x70\xb7\x3e\x2a\xe5\xef\x63\xe2\x0c\xad\x4a\x01\xdb\x38\x10\xf6\x37\x5a\x0e\xd8\x7f\xe3\xf1\x08\xf3\x96\xdc\x1c\x68\x82\x08\x00\xec\x4a\x69\x67\x88\xec\x54\x29\x14\x9d\x48\xa7\x54\x3f\xc7\x6b\xc3\xe9\x0e\x45\x01\x40\x29\x30\x2f\x36\x5b\x88\xb4\x3f\xd3\x34\x1e\x40\xcc\x15\xfa\x93\xf3\x68\xce\x69\xca\xba\xda\x3e\x39\x40\xe4\xd0\x22\x55\x3c\xa5\x98\x29\xe6\x8f\x65\xa4\x4d\xaf\xa8\x2f\xcb\xde\x5e\x42\x15\x3b\x05\x00\xbe\x6f\xc0\x87\x23\xa2\x0f\x7f\xbd\xf5\x85\x34\x98\x57\x9b\x6d\xd0\xc4\xbc\x5a\xd7\x11\x8e\x7a\xe2\x36\xc5\x3c\x27\xb7\x98\x53\x48\x13\xe9\xd5\x71\x49\x6e\xd9\x06\xfa\x34\x67\x1f\xcc\x3b\x59\xf7\x14\x82\xde\x12\xff\xec\xad\x87\x33\xfb\x98\xf5\x94\xd9\xc7\x41\xd7\xb2\xae\x53\xa2\x7e\x07\x00\x34\x81\x07\xed\x8b\x01\x00\x00")
 
 func templatesGo_post_textTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -184,7 +347,7 @@ func templatesGo_post_textTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/go_post_text.tpl", size: 460, mode: os.FileMode(420), modTime: time.Unix(1426433181, 0)}
+	info := bindataFileInfo{name: "templates/go_post_text.tpl", size: 395, mode: os.FileMode(420), modTime: time.Unix(1792311080, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -269,7 +432,7 @@ func templatesGo_simple_postTpl() (*asset, error) {
 	return a, nil
 }
 
-var _templatesJava_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x52\xc1\x6e\xdb\x30\x0c\xbd\xfb\x2b\xb8\xa2\x05\x64\xa0\xf0\x07\xb4\xe8\x61\xcd\x76\x08\xd0\x60\x43\xb2\x9e\xb6\x61\x50\x24\xc6\x15\x2a\x53\x86\x24\xaf\xcd\x0c\xfd\xfb\x28\x3b\x41\x12\xcf\xa7\xf1\x10\x40\x8f\x8f\xef\xbd\x90\xee\x7b\xf0\x92\x6a\x84\xeb\x57\xdc\xdf\xc2\xf5\x2f\xb8\x7b\x80\x6a\xe5\x74\x67\x31\x40\x4a\xa6\x69\x9d\x8f\xc0\xb4\x4c\x60\xe0\xbe\xe8\x7b\x24\x9d\x52\x51\xb4\xdd\xd6\x1a\x05\xca\xca\x10\x60\x25\x0d\x41\x9f\x89\xd5\x47\xad\x4d\x34\x8e\xa4\xfd\x84\xdc\xf4\x32\x3f\x78\xb4\x00\xae\xc3\x50\x88\x8c\x2a\xf8\xed\x8c\x86\x86\x47\xc5\x26\x7a\x43\xf5\xf7\x9f\x20\x7d\x1d\x4a\xe8\x07\x72\xae\xe8\xf7\x67\xaf\x5c\xd9\x63\xe1\x9a\xc6\xd1\x92\xd8\x48\x5a\xf3\x07\x59\x3e\xc3\x5f\x3d\xb6\xd2\xe3\xa3\xd3\x39\xeb\xf3\xfa\x09\x3a\x6f\xe1\x01\x08\xdf\x80\x5f\x22\x73\x9e\x19\x49\xa9\xbc\x2f\x66\x44\x89\x50\xe5\xb4\x8b\xe1\x3f\xa5\x04\x8a\x21\x9e\x17\xf3\xed\x92\xd5\x2b\xd7\x22\x9d\x5a\x62\x8c\xe1\xde\xf7\xa3\xc9\x59\xaa\x13\xe9\xb8\x8c\x63\x6d\xf6\x21\x62\x53\xb9\x2e\x56\x2d\xaf\x21\xee\xc4\xd5\x1a\x43\xeb\x28\xe0\x1d\xdc\x68\xb8\x09\x3f\xe8\xea\x76\x08\x53\xd5\x18\x8f\xbd\x85\xd3\x28\xca\x7f\xf1\x15\x86\x20\x6b\x6e\xb1\xff\xb9\xcd\x63\xb7\xdb\xa1\x47\xbd\x46\xa9\xd1\xc3\xd6\x1f\x36\x73\x89\x8b\x0c\x2d\xa9\xed\x22\xdf\x04\x65\x73\x40\x8f\x26\x67\x1d\x36\x98\x38\x8c\x57\x04\x93\x39\x93\x05\xbf\xbd\x18\x8b\x20\xc4\xd0\x63\xe3\xad\xaf\x58\x43\x3f\x19\xca\x41\xe1\x03\x47\xe9\xac\x2d\x27\xb7\x9e\xdb\x8e\xa5\x51\x64\xe2\x7d\xb9\x52\x96\x57\xd6\x05\xd6\x3e\xb1\xf8\x9c\x32\xaa\x17\x10\x2b\x69\x77\xce\x37\xa8\xf9\x9b\xf8\xfc\xae\xb0\x1d\x8e\x82\x53\x73\x1c\xfd\x36\x51\xaa\xd7\x6f\x5e\xaa\x79\xb1\xe5\x97\xff\x96\x28\xc6\xdf\x54\xfc\x0d\x00\x00\xff\xff\xd9\x32\xfe\x9c\x87\x03\x00\x00")
+var _templatesJava_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x52\x41\x6e\xdb\x30\x10\xbc\xeb\x15\xdb\x20\x01\x28\x20\xd0\x03\x62\xf8\x90\xb8\x3d\x04\xb0\xd1\xc2\xae\x4f\x6d\x51\xac\xc9\xb5\x4d\x98\x5a\x0a\x4b\x2a\x89\x2b\xf0\xef\x05\x25\xbb\xb1\x1d\x9f\xca\x83\x00\xee\x8c\x66\x46\xb3\xea\x3a\x10\xe4\x0d\xc1\xed\x8e\xf6\xf7\x70\xfb\x1b\x1e\xc6\x50\xcd\xbc\x69\x1d\x05\x48\xc9\xd6\x8d\x97\x08\x5d\xd7\x13\x20\xa5\x51\xd1\x75\xc4\x26\xa5\xa2\x68\xda\x95\xb3\x1a\xb4\xc3\x10\x60\x86\x96\xa1\xcb\xc4\xea\xd1\x18\x1b\xad\x67\x74\x9f\x49\x3b\x14\xcc\x17\x48\xa9\x00\x00\x38\xbc\x14\x22\x46\xab\xe1\xc5\x5b\x03\x35\x5a\x56\x8b\x28\x96\x37\x3f\x7e\x01\xca\x26\x94\xd0\xf5\xe4\x7c\xa2\xec\x4f\x6e\xf9\x64\x8f\x89\xaf\x6b\xcf\xcf\x6c\xa3\x45\x67\xff\x10\xa4\x94\xc7\xdf\x84\x1a\x14\x7a\xf2\x26\x67\x5d\xce\xa7\xd0\x8a\x83\x31\x30\xbd\xc2\x72\x3e\x55\x99\xb3\x14\x07\x29\x95\xa3\xe2\x8a\x28\x33\xe9\x9c\x76\xd2\x7f\x53\x4a\xa0\x3d\x33\x8c\x41\x5d\x87\xcb\x56\x5c\xe5\x1b\xe2\x77\x48\x0d\x31\xfc\xdb\x7e\x30\x39\x49\xf5\x4e\x3a\xa4\x7d\x6c\xe3\x96\x38\x5a\x8d\x91\x8e\xfd\x1c\xcf\x62\x1f\x22\xd5\x95\x6f\x63\xd5\x88\xe5\xb8\x56\x37\x73\x0a\x8d\xe7\x40\x0f\x70\x67\xe0\x2e\xfc\xe4\x9b\xfb\x3e\x5f\xb5\xa1\x78\xc4\x26\xde\x90\x2a\x3f\xce\x67\x14\x02\x6e\x48\x95\xe5\xe8\xcc\xe6\xa9\x5d\xaf\x49\xc8\xcc\x09\x0d\x09\xac\xe4\x50\xd6\xf9\x5c\xe5\xd1\x33\x37\x6d\x5c\x44\x21\xac\x0f\xd3\xa3\xc9\x09\xa2\xca\x4b\x87\x61\xb1\x60\x33\xe7\xa2\xf3\xd7\xad\x75\x04\x4a\xf5\x18\x8c\x61\x25\x95\x10\x9a\xa9\xe5\x1c\x14\x3e\x8d\x81\x5b\xe7\xca\x8b\xf5\x5f\x6b\xc7\xf1\x20\x72\xe1\x7d\x5e\xe9\x4a\x2a\xed\x7c\x20\x55\x8e\x72\xfb\x0b\x7c\xa1\x89\xf7\x3b\xdb\xff\xe8\xff\x98\x09\x34\x46\xbd\x05\x35\x43\xb7\xf6\x52\x93\x59\xce\xa7\x5f\xde\x34\x35\xfd\xe6\xe8\x32\x0e\x0d\x09\x16\x11\xf5\xee\xbb\xa0\xce\xf2\x1f\xc5\x9e\xbf\xfe\xb7\x44\x31\x3c\x53\xf1\x77\x00\x16\xc3\x17\xc8\xac\x03\x00\x00")
 
 func templatesJava_fullTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -284,12 +447,72 @@ func templatesJava_fullTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/java_full.tpl", size: 903, mode: os.FileMode(420), modTime: time.Unix(1426433181, 0)}
+	info := bindataFileInfo{name: "templates/java_full.tpl", size: 940, mode: os.FileMode(420), modTime: time.Unix(1792302938, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesJava_httpclient_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x92\xdd\xea\x1a\x31\x10\xc5\xef\xf7\x29\x86\x3f\xff\x42\x16\x24\x0f\xa0\xdd\x82\xda\x82\xbd\x10\x8a\xe2\x55\x5b\x4a\x4c\x46\x0d\xc6\x64\x9b\xcc\x6a\x97\x90\x77\x2f\xd9\x0f\x3f\x0a\xcd\xcd\x92\x99\xf9\x9d\x73\x36\x49\x8c\xe0\x85\x3d\x22\xbc\x9f\xb1\x9d\xc0\xfb\x2f\x98\x56\xc0\xd7\x4e\x35\x06\x03\xa4\xa4\x2f\xb5\xf3\x04\x31\x76\x03\x90\xd2\xac\x88\x11\xad\x4a\xa9\x28\xea\x66\x6f\xb4\x04\x69\x44\x08\xb0\x16\xda\x42\xcc\x83\x7c\xae\x94\x26\xed\xac\x30\x9f\x51\x1a\xe1\x45\xde\x40\x4a\x05\x00\xc0\x00\x05\x12\xa4\x25\x5c\x9d\x56\x70\x11\xda\xb2\x2d\x79\x6d\x8f\xdf\x7f\x82\xf0\xc7\x50\x02\x9d\xbc\xbb\x05\xf8\xf2\x47\x62\xdd\xe1\xb1\xa3\xf3\xca\x16\xdf\x3c\xd6\xc2\x23\xa4\xb4\x22\xaa\x97\x46\xa3\x25\x90\xfd\xa7\x82\x47\x8d\x5b\xbc\x2d\x1a\x6d\x14\x7a\x56\x66\xb0\x2f\x0f\xa5\x31\xd3\xf3\xe2\xfb\xdc\x63\xe5\xec\xde\xc9\x6a\x1b\xfc\xdd\x60\x20\x3e\x82\xbe\xdf\x43\xf5\xd2\x7d\x32\xdb\x6d\xbe\x72\xe9\x51\x10\xb2\x6c\xbb\xf3\x06\x52\x2a\xbb\x08\xc3\xf4\x23\xc3\xbf\x56\xa1\x76\x36\xe0\xc7\xfe\x44\x3e\x81\x1f\x0a\x50\x0d\x7f\xc8\x03\x5a\xc5\x86\x08\x63\xde\xc9\x0b\xcc\x17\x4e\xb5\x2b\x61\x95\x41\x1f\xb8\x3b\xf4\x5a\xac\x2c\xf3\xf5\x01\x9f\x37\x74\x42\x4b\x5a\x0a\xca\x67\x38\xda\x6f\xdb\x40\x78\xe1\xae\x21\x5e\x7b\x6d\xe9\xc0\xde\x46\xc1\x29\x7c\x50\x3f\xec\xdb\xe4\x9e\x86\xe7\x1b\x6c\xc2\xd2\x29\xec\x64\xff\xa7\x61\x2c\xbb\x23\x7b\xa7\xda\x3c\x9c\x23\x6c\xc5\x15\x97\xce\x9d\x75\xf7\xcc\x3a\x3c\x15\xa9\xf8\x3b\x00\x65\xe6\x65\x46\x90\x02\x00\x00")
+
+func templatesJava_httpclient_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesJava_httpclient_fullTpl,
+		"templates/java_httpclient_full.tpl",
+	)
+}
+
+func templatesJava_httpclient_fullTpl() (*asset, error) {
+	bytes, err := templatesJava_httpclient_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/java_httpclient_full.tpl", size: 656, mode: os.FileMode(420), modTime: time.Unix(1792304735, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesNodejs_external_fileTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x53\x4d\x8f\xda\x30\x10\xbd\xf3\x2b\x46\xd1\x4a\x18\x15\x59\x7b\xac\x40\x7b\xe8\x42\x3f\x0e\xa5\xdb\x03\x97\x9e\x56\x56\x3c\x01\x6b\x83\x9d\xda\x4e\x17\x14\xf9\xbf\x77\xc6\x49\x49\x16\xb5\x8d\x04\xf2\xcc\x7b\xf6\xf3\x7b\xb6\x7f\x29\x0f\x55\x80\x07\xf0\xf8\xb3\x35\x1e\x45\x51\x85\x62\xb1\x9e\x75\x1d\x78\x65\x0f\x08\x77\x2f\x78\x59\xc2\xdd\x33\xac\x1e\x40\xee\x9c\x6e\x6b\x0c\x90\x12\xcf\x23\x0e\xa3\x54\x4d\xe7\x8f\xdd\x7e\x1d\xb4\x3a\x25\x6a\xca\x0f\x5a\x9b\x68\x9c\x55\xf5\x16\xcb\x5a\x79\xc5\x05\xd1\x66\x55\x90\x1e\x95\xfe\x64\xea\x7e\xba\x30\x56\xe3\x19\xe4\xc7\x73\x44\x4f\x74\x06\x02\xdc\x2f\x24\x0f\xbe\xa9\x13\xf2\xda\x5d\x67\xaa\x7f\x33\xf7\x78\x8e\xfb\x4b\xc3\xcc\x25\xd0\x16\x4a\xa7\x8d\x3d\xac\xa0\x68\x63\xf5\xbe\x48\xc3\xae\x96\x50\xb5\xb6\xcc\xdb\x10\xe8\x3d\x95\x34\x7f\xe3\x6c\x44\x1b\x17\xd0\xcd\x80\x3e\x56\x21\xec\x4f\xc9\x5f\xe9\x6c\x70\x35\x4a\x6a\x3b\x9f\xc1\xf5\x15\xf3\x18\x5b\x6f\xfb\x3a\xe5\x7f\xb6\xfe\xdd\x63\xa3\x3c\x3e\x3a\x7d\x19\xb2\xa3\xb8\x28\x34\xc6\x36\xb5\x21\xb9\x3e\x59\x02\x25\x07\x89\x21\x8a\x51\xef\xe8\x42\xa4\xad\x33\xf9\x0b\x0d\xd9\xfd\xf2\x0a\x36\x2a\x1e\x57\xbd\x08\x8d\xd8\x6f\x4e\xc6\x22\x35\x9c\x8f\x70\x9f\xd2\xc8\xa5\xc6\xc0\x65\x28\x73\x73\x10\x57\xc6\x09\xe3\xd1\xe9\x41\x6c\x97\x8b\x2c\x37\x31\xf1\xd4\x70\x60\x7c\x07\x7a\x93\x63\x88\xc2\x63\xf8\x5b\x4e\xb5\x3b\x88\xe2\xb3\x8b\x64\x3a\x34\xd4\x43\x5a\x1f\xde\x71\x25\x43\x54\xb1\x0d\x1b\xa7\x91\x1a\xc5\x4d\x7b\x87\x21\xa8\x03\xbe\x49\x37\x48\xd2\x99\x6b\x15\xd5\x7c\x7a\x7a\xe5\xb1\xb5\x2f\x53\xed\x5b\xfd\xf9\xe3\xd3\xf6\xc7\x0a\xe6\x24\xd0\x73\xc7\x45\xd3\x62\xcd\xf6\xf6\xa8\xfc\xd6\xbd\xda\xab\xb1\x81\x32\xbe\x84\x67\x7a\x07\xb5\xa1\x64\xf9\x29\xf0\x59\x7e\xa5\x22\xa4\x44\x27\x26\x5f\xbd\x89\x28\xf8\xee\x67\x46\x1a\x67\xe7\x80\x99\x42\x03\x31\x74\xb9\x64\x1f\xf9\x06\x4d\x8c\x08\xfc\x6f\x7e\x99\xde\x87\x87\xf2\xf4\x26\x1d\xd6\xe3\xdf\xef\x00\x00\x00\xff\xff\xea\xb9\x28\x58\xd0\x03\x00\x00")
+var _templatesJava_moduleTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x84\x52\xcb\x6e\xdb\x30\x10\xbc\xeb\x2b\xb6\x41\x02\x50\x40\xa0\xdc\x63\xf8\x90\xb8\x2d\x12\xc0\x69\x03\xbb\x3e\xb5\x45\x41\x8b\x6b\x85\x08\xb5\x14\xf8\xc8\xa3\x04\xff\xbd\x20\x65\xd9\x72\xed\xa2\x3a\xd9\xb3\xbb\x33\xb3\xcb\x09\x01\xe4\x06\xaa\x47\x5e\x3f\xf3\x06\x21\xc6\x6e\xfb\x2b\x84\x31\x3a\x29\x8a\x10\x00\x49\x40\x8c\x21\x80\xe1\xd4\x20\x9c\x3f\xe3\xfb\x25\x9c\xff\x82\xeb\x29\x54\x0f\x5a\x78\x85\x16\x62\x94\x6d\xa7\x8d\x83\x10\x72\x43\x1e\x0e\x01\x49\xc4\x58\x14\x9d\x5f\x2b\x59\x43\xad\xb8\xb5\xf0\xc0\x25\x41\xc8\x4a\x37\x42\x48\x27\x35\x71\xf5\x11\x6b\xc5\x0d\x4f\x7f\xc6\x5a\xd5\x67\x4f\x75\x02\x93\x42\x01\x00\x70\x75\x95\x27\xbf\x49\xa7\x70\xc0\xb6\xf4\xd6\x71\x27\x6b\xb8\x73\xae\x5b\x2d\xe6\x33\x4d\x84\x79\x36\x0f\x7c\xe1\x6d\xea\x67\x59\xd6\x34\xbe\x45\x72\x89\xb4\x04\xf7\x64\xf4\xab\x85\xfb\xaf\x9f\xde\x6a\xec\xfa\x81\x4c\x9b\xbe\xd4\x3e\xd3\x6d\xab\xe9\x9e\xa4\x93\x5c\xc9\xdf\xd8\x1b\xac\x1e\x0d\x76\xdc\xe0\xad\x16\x69\xdb\xd5\x62\x0e\xde\x28\x98\x02\xe1\x2b\xac\x16\xf3\xac\xb4\x32\x2a\x69\x4c\x8a\xbf\x08\x07\x6b\xb3\x7c\x91\x18\xa1\xd6\x44\x30\x05\x76\xba\x5c\x7a\xa3\x2a\xdd\x21\xed\x4b\xac\xb7\xa0\xdf\xde\x7b\x81\x91\xa3\x7d\xd3\xd6\xe9\x8d\x77\x4f\x48\x4e\xd6\xdc\x0d\xe6\x97\xfc\x05\x67\x5a\x3f\x4b\xdc\x5d\x36\x7d\x06\x9d\x37\x94\xdd\x4c\x32\x18\x0f\x02\x90\x32\x73\xc7\x6d\x7e\xc1\x93\xb7\x7f\xd1\x52\x40\xcb\x25\xb1\xa5\x33\x92\x9a\xef\x3f\x81\x9b\xc6\xfe\xe3\xc8\xa7\x9f\x79\xf0\xd2\x19\x49\x2e\xaf\xb9\x44\x12\x68\x76\x7b\xf6\x76\x7a\x77\x05\x00\x1c\x88\xf7\x53\xc7\x19\x48\x2b\xfd\xe7\xad\x97\xef\xd6\x61\x5b\x69\xef\xaa\xcc\xb2\x61\x67\x0b\xb4\x9d\x26\x8b\xd7\x70\x21\xe0\xc2\xfe\xa0\xb3\xcb\xcc\x54\x35\xe8\x86\xda\x4c\x0b\x64\xe5\x31\xfe\x80\xd6\xf2\x06\x59\x59\x4e\x76\x12\xb7\x7e\xb3\x41\x83\x62\x81\x3c\x6d\xb4\x36\xdb\xbc\x1c\xe2\x2c\x41\xf7\xd4\x79\xb7\x74\x06\x79\xbb\x45\x07\x81\x51\x85\x95\x63\xf6\xfe\xe6\x20\x53\x7d\x14\xb9\xd7\x27\xa9\x10\x18\xcb\x38\x4c\x61\x6d\x2a\x83\x5c\xcc\x25\x25\x73\xf0\x61\x0a\xe4\x95\x2a\x47\x97\x38\x75\x0d\x45\x3d\xc1\x48\x6f\x9f\x9b\xb5\xa9\x6a\xa5\x2d\xb2\xf2\x28\x36\xb1\xf8\x33\x00\x34\xab\xd3\x21\x6d\x04\x00\x00")
+
+func templatesJava_moduleTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesJava_moduleTpl,
+		"templates/java_module.tpl",
+	)
+}
+
+func templatesJava_moduleTpl() (*asset, error) {
+	bytes, err := templatesJava_moduleTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/java_module.tpl", size: 1133, mode: os.FileMode(420), modTime: time.Unix(1792316482, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesKotlin_okhttp_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x64\x91\x41\x6b\xc3\x30\x0c\x85\xef\xf9\x15\x6a\xe9\x21\x86\xcd\xb9\x17\x3a\xd8\xda\xeb\x60\x74\xec\x3c\xdc\x58\x0b\xa6\xae\x9d\xc9\x76\xb7\x62\xf4\xdf\x87\x9d\xb4\x14\x96\x93\xa4\xf7\xbd\x27\x22\x77\x1d\x1c\x92\xb1\x5a\x0e\xa4\xb4\x45\x79\x8c\xa1\xe9\x3a\xd0\x38\xa2\xd3\xe8\x7a\x83\x01\x72\x93\x33\xc8\xdd\xfd\x88\xb9\xeb\x80\xcb\x9c\x94\x1b\x10\x56\x47\xbc\x3c\xc0\xea\x13\xd6\x1b\x90\xaf\x5e\x27\x5b\x21\x73\x1a\x3d\x45\xc8\xb9\x02\xc0\xc5\x81\x4e\x33\x97\xc0\x67\xad\x4d\x34\xde\x29\xbb\xc3\xde\x2a\x52\xa5\x29\xcc\x57\x72\x70\x52\xc6\xb5\x02\x72\x03\x00\xc5\x2f\xdf\x08\x47\x45\x08\xcc\x67\x65\xa1\xb7\x06\x5d\x84\x4d\x95\xb6\x53\xc3\x5c\xe1\x22\x13\x7e\x27\x0c\x45\xdf\x4f\x95\x7c\x29\x3f\x89\xd4\x8a\xca\x94\x4f\x26\xb2\x6d\xb1\x7f\x90\x05\x66\x51\xca\x99\x9e\xe1\x6b\x62\xa5\xeb\x95\x66\xfb\xb4\x5d\x3a\xfc\xd9\x2a\x6b\xdb\x79\x9b\x90\xf8\x8b\x7d\x8a\xd8\x0a\x99\x02\x42\x06\xc2\x30\x7a\x17\x10\x1e\x9f\x6e\x41\x23\x19\x17\xad\x6b\x97\xfb\x59\x5c\xc3\x2a\x5f\x41\xd9\x7b\x8d\x7c\x3f\x38\x61\x08\x6a\x40\x5e\x8a\x7f\x09\x37\xe6\xe0\xf5\x65\xb1\x90\x21\x92\x71\x43\x2b\x26\xb2\x9e\xf8\x5d\x9d\x71\xeb\xfd\x71\x7a\xb2\x86\x9b\xbf\x01\x00\xef\xbe\xd2\x50\xef\x01\x00\x00")
+
+func templatesKotlin_okhttp_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesKotlin_okhttp_fullTpl,
+		"templates/kotlin_okhttp_full.tpl",
+	)
+}
+
+func templatesKotlin_okhttp_fullTpl() (*asset, error) {
+	bytes, err := templatesKotlin_okhttp_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/kotlin_okhttp_full.tpl", size: 495, mode: os.FileMode(420), modTime: time.Unix(1792304720, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesNodejs_external_fileTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x53\x4f\x6b\xdb\x4e\x10\xbd\xe7\x53\x0c\x22\x60\x99\x98\x25\xc7\x1f\x0e\x39\xfc\x62\xb7\xcd\xa1\x6e\x4c\xeb\x4b\x4f\x61\xd1\x8e\xac\xc5\xf2\xae\x3d\x3b\x6a\x6c\xc4\x7e\xf7\x32\x2b\x45\x52\x4a\x6b\xb0\xd0\xcc\x7b\xf3\xef\xcd\xe8\x97\x26\x28\x03\x3c\x02\xe1\xb9\xb1\x84\x79\x56\x86\x6c\xfe\x70\xd3\xb6\x40\xda\xed\x11\x6e\x0f\x78\x5d\xc0\xed\x2b\x2c\x1f\x41\x6d\xbc\x69\x6a\x0c\x10\xa3\xc4\xb5\x6d\x42\x21\xc6\x69\xfc\xe8\xed\xf2\xa0\x33\x31\xb6\x2d\xa8\xff\x8d\xb1\x6c\xbd\xd3\xf5\x1a\x8b\x5a\x93\x16\x03\x62\xbc\x29\x83\x22\xd4\xe6\xb3\xad\x31\x6f\x5b\xc8\xad\x33\x78\x01\xf5\xe9\xc2\x48\x4e\xd7\xe2\x0f\x70\x3f\x57\xf2\xf2\x4d\x1f\x11\x24\x9f\x2d\xff\x4d\xdc\xe1\x85\x77\xd7\x93\x10\x17\xd0\xa2\x2b\xbc\xb1\x6e\xbf\x84\xac\xe1\xf2\xbf\x2c\xf6\x3d\x2d\xa0\x6c\x5c\x91\x9a\xc8\x91\x68\x01\xa5\xad\x71\xe5\x1d\xa3\xe3\x39\xb4\x37\x00\x00\x52\x05\x89\xde\x4d\xf9\x15\xde\x05\x5f\xa3\x42\x22\x4f\x09\x7c\x18\x30\x42\x6e\xc8\x75\x76\x4c\x4f\x19\x7c\x4b\x78\xd2\x84\x4f\xde\x5c\x7b\xe5\x08\xcf\xf0\x98\xb0\x55\x6d\xd1\x71\xa7\x2b\xc4\xa8\x44\x46\x0c\x9c\x8f\xf5\x2a\x1f\x78\x99\xb8\xcf\x3e\xb0\x8c\x34\x40\x27\xcd\x55\x07\x6d\x35\x57\x02\x25\x5d\x1c\x82\xda\x7a\x62\xb8\x8f\x71\xe4\x7a\xea\xd3\x24\x28\x71\x93\x0c\x03\xe3\x88\x5c\x79\xd3\x71\x36\xe9\xbd\x63\x0d\x03\xbc\x9c\x44\xac\x00\x7d\xcc\x44\x40\x21\x7d\xc7\x70\xf2\x2e\xe0\xb3\x76\xa6\x46\xea\x17\x95\x13\x86\xa9\x7a\xe9\x10\x1a\xae\xd0\xb1\x2d\x34\x77\xbb\x04\xf5\x83\x3d\xe1\xca\xfb\x83\x4d\xd7\xf5\xae\x71\xed\xf7\x79\xf6\xc5\x33\x50\x9f\x7c\x09\x19\xdc\x89\xa5\x02\x6b\x6e\xc2\xca\x1b\x84\x3b\xc8\xfe\x70\x6f\x30\x04\xbd\xc7\x0f\x9b\x09\xca\xbb\x7c\x66\x34\xeb\xd9\x74\xf3\x45\xd5\xb8\xc3\xb4\xc3\xe9\x8e\xa5\xfe\xec\xe9\x65\xfd\x73\x09\x33\xb8\x83\x8e\x3b\x26\x8d\xf3\x07\x69\x7e\x87\x9a\xd6\xfe\xcd\x0d\xc2\xf4\x94\xf1\x1b\x7a\x5d\xc0\x6d\x6d\x1d\xa6\x8f\x48\xee\xe0\xab\x75\x18\x62\x24\x3c\xab\x37\xb2\x9c\xee\xbe\x63\xc4\x31\x3a\xad\x47\x28\xe8\x4c\xde\x7b\xc5\x94\x39\xd2\xf5\x4d\x06\xc9\xf1\x6f\x37\x3a\xe8\x97\xe8\x9d\x78\xa8\x8e\x1f\xd4\x91\x7a\xf2\xff\x3d\x00\x4b\x27\x14\x67\x0a\x04\x00\x00")
 
 func templatesNodejs_external_fileTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -304,12 +527,12 @@ func templatesNodejs_external_fileTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/nodejs_external_file.tpl", size: 976, mode: os.FileMode(420), modTime: time.Unix(1446807860, 0)}
+	info := bindataFileInfo{name: "templates/nodejs_external_file.tpl", size: 1034, mode: os.FileMode(420), modTime: time.Unix(1792311699, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesNodejs_external_filesTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x53\x4f\x6f\xdb\x3e\x0c\xbd\xe7\x53\x10\x46\x81\x38\xf8\x19\xc2\xef\x38\x24\xe8\x61\x4d\xf6\xe7\xb0\xac\x3d\xe4\x32\x0c\x43\x21\xd8\x74\xa2\xd5\x91\x3c\x49\x5e\x16\x04\xfa\xee\x23\x65\x35\x56\x7a\x98\x80\x36\x22\xf9\x24\xf2\xbd\x67\xfd\x96\x16\x5a\x07\xf7\x60\xf1\xd7\xa0\x2c\x96\x45\xeb\x8a\xc5\x6a\x76\xb9\x80\x95\x7a\x8f\x70\xf7\x82\xe7\x0a\xee\x9e\x61\x79\x0f\x62\x6b\x9a\xa1\x43\x07\x21\xf0\x39\xc2\x70\x95\xa2\xfc\xfc\x94\x1d\xef\x41\xdd\x84\x40\x49\xf1\xbe\x69\x94\x57\x46\xcb\x6e\x83\x75\x27\xad\xe4\x80\x60\xb3\x27\x6b\x8e\xca\xa1\x90\x5d\x57\x7e\xcf\x3a\x2b\xea\x8b\x7f\x3c\x5a\x3a\xf2\x51\x75\x18\x47\xf8\x90\x25\x78\x10\xa0\xa5\xf1\x04\xe9\x92\xb2\x1d\x74\x1d\x2f\x2e\xdd\x50\xd7\xe8\x5c\x45\xa3\xfd\xc4\xda\x2f\xe0\x32\x83\xb4\x5a\x27\x2c\xca\x86\xef\xe0\x81\x6f\xba\x08\xfe\xf7\x55\x1e\x91\x08\x5c\x2e\xaa\xbd\x9d\x41\xec\x28\xda\x9d\x7b\xa4\xd6\x15\x10\xb9\xda\x34\x4a\xef\x97\x50\x0c\xbe\x7d\x57\x84\xc4\xb7\x82\x69\x0e\xb4\xb6\x82\x46\x7a\x99\x4f\xc0\x8b\xee\xe6\x22\xa5\xd3\x88\x31\x5a\x41\x00\xec\x1c\x52\x36\x31\x28\xe3\x61\xca\x5f\x4f\x07\x52\x76\xfc\xad\x5e\x15\xfe\xb1\x10\xfe\x80\x3a\xe3\xdf\xd2\xb8\x6b\xa3\x3d\x6a\xef\x5e\x5b\xb3\x0f\x4f\x16\x7b\x69\xf1\xc1\x34\xe7\x64\x24\x79\x47\x0e\x72\x6d\xdd\x29\x82\x8f\x36\x53\x51\xb0\xab\xe8\x7c\x39\x0d\x7e\x30\xce\x13\x5b\x06\x7f\xa6\x2d\xdb\x5c\x5d\x8b\xbd\xf4\x87\xe5\xd8\x84\x76\x2c\x51\x54\x50\x23\x25\x8c\xf5\xf0\x7f\x98\x38\xf4\x94\x48\x58\x2e\x45\x6c\x64\x72\x45\x1c\xd1\x1f\x4c\x93\x9a\x6d\x63\x10\xdb\x65\x24\x1e\x7b\xe6\xca\xdf\xc1\xa8\xc7\xa4\x7b\x69\xd1\xe5\x82\xd7\x04\x33\xe4\x5f\x67\xf6\x65\xf1\xc9\x78\x22\xed\x7a\xca\x21\xdd\x0f\xff\x71\x24\x9c\x97\x7e\x70\x6b\xd3\x20\x25\x8a\x37\xe9\x2d\x19\x21\xf7\x98\x84\xe7\xc5\x35\xea\x33\x67\x77\xe6\xb9\xe1\xf5\x61\xd0\x2f\x6f\xcd\xce\xfb\xcf\x1f\x1e\x37\xdf\x96\x30\xa7\x06\x23\x76\x95\x3b\xcb\xf4\x76\x28\xed\xc6\x9c\xf4\x95\x58\x82\x4c\x8f\xe3\x99\x1e\x47\xa7\xf4\xf8\x28\xd8\xcb\x2f\x14\xb8\x10\xc8\x31\x71\xb2\xca\x63\xc9\x0f\x31\x22\xc2\x74\x3a\x0a\xcc\x10\xda\x94\x29\xcb\x21\xf3\xa0\x8f\xcf\xd8\x8c\x48\x89\xff\xd4\x2f\xc2\x47\xf1\x50\x1c\x6f\xd4\xe1\x7e\xf4\xf7\x37\x00\x00\xff\xff\xd2\xc2\xdf\xaa\x5c\x04\x00\x00")
+var _templatesNodejs_external_filesTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x64\x53\x4f\x6f\xdb\x3e\x0c\xbd\xe7\x53\x10\x46\x80\xd8\xa8\x21\xfc\x8e\x3f\xc4\xe8\x61\x4d\xb7\xf5\xb0\xae\xc1\xd6\xcb\x30\x0c\x85\x60\xd3\x89\x56\x45\x4a\x29\x7a\x6d\x61\xe8\xbb\x0f\x94\xdd\xd8\xe9\x74\x48\x2c\xf2\xf1\xcf\x7b\xa4\xfe\x68\x82\x36\xc0\x25\x10\x3e\x75\x86\x30\xcf\xda\x90\x15\xd5\xa2\xef\x81\xb4\xdb\x21\x2c\x1f\xf1\xb5\x84\xe5\x03\xac\x2f\x41\xdd\xfa\xa6\xb3\x18\x20\x46\x89\xeb\xfb\xe4\x85\x18\xe7\xf1\x93\x75\xc8\x83\xae\x89\xb1\xef\x41\x7d\x68\x1a\xc3\xc6\x3b\x6d\xaf\xb1\xb6\x9a\xb4\x5c\x20\xc6\xc5\x96\xfc\xc1\x04\x54\xda\xda\xfc\xe7\xac\xb2\x29\x61\x89\x2f\x8c\xe4\xb4\xfd\x64\x2c\xa6\x16\x3e\xce\x0c\xd2\x08\x00\x80\xc3\x67\x18\x93\xe4\x6d\xe7\xea\x94\x38\x0f\x5d\x5d\x63\x08\x25\x10\xfe\xc6\x9a\x0b\xe8\x17\x30\x9e\x36\x28\x42\xdd\x48\x8e\xbc\xef\xcf\x8a\x28\xf9\xf9\xaa\x0f\x28\x4d\x9b\xf6\xbc\x03\x75\x8f\x2f\x7c\xff\x7a\x44\x88\xb1\x84\x1e\x5d\xed\x1b\xe3\x76\x6b\xc8\x3a\x6e\xff\xcf\xe2\xc8\xb6\x84\xa9\x0b\x24\x2a\xa1\xd1\xac\xe7\xf5\xe5\x98\x36\x39\x0b\xe8\xc7\x06\xd3\xad\x82\x08\x68\x03\x42\x0f\x63\xff\x79\x0a\xae\x20\x9e\xa2\x63\x51\x2d\x86\xff\xf2\x4d\xdf\x5f\x85\xe2\x3d\xba\x19\xfb\xd6\x58\xdc\x78\xc7\xe8\x38\xbc\x95\x96\x29\x6c\x09\x8f\x9a\xf0\xca\x37\xaf\xe3\x18\x09\x9f\xe0\x32\xf9\x36\xd6\xa0\xe3\x61\xc8\x10\xa3\x92\x99\x62\xe0\x7c\x6a\x7c\xef\x03\xaf\x13\xf6\xc6\x07\x16\x15\x4e\xae\xa3\xe6\xfd\xe0\xda\x6a\xde\x8b\x2b\xe9\xe7\x10\xd4\xd6\x13\xc3\x7f\x71\x62\x70\xf4\x34\xa6\x49\xae\x84\x4d\x3c\x4e\x88\x03\xf2\xde\x37\x03\xe6\x36\x7d\x0f\xa8\x13\x81\xbb\xa3\xf0\x0c\x30\xc6\xcc\x34\x17\xd0\x37\x0c\x47\xef\x02\xde\x68\xd7\x58\x24\x99\x27\xc4\x98\x13\x86\xf9\x18\xd2\x56\x76\x22\x1c\x9b\x5a\xb3\x40\xc4\xf4\x9d\x3d\xe1\xc6\xfb\x47\x93\x36\xac\xf6\x2e\x78\x8b\xca\xfa\x5d\x9e\x7d\xf6\x0c\x34\x26\x5f\x43\x06\x17\x72\x53\x81\x35\x77\x61\xe3\x1b\x84\x0b\xc8\xde\x99\x6f\x31\x04\xbd\xc3\xa2\x3a\xd5\x15\x9f\x77\xf9\x4a\x26\xbb\x9a\x2f\x4b\xbd\xef\xdc\xe3\xfb\x45\x99\xd7\x5f\x5d\xdd\x5d\xff\x58\xc3\x0a\x2e\x60\xc0\x56\xf3\xad\x90\xe6\xef\x51\xd3\xb5\x7f\x76\x27\x61\x46\xc8\xf4\xac\x1e\x4a\x58\x5a\xe3\x86\xe7\x24\x7b\xf0\xc5\x38\x0c\x31\x12\x3e\xa9\x67\x32\x2c\x4f\x62\x44\xc4\x29\x3a\x8d\x47\x20\xe8\x9a\x7c\xb4\xca\x55\x78\x20\x91\xa7\x19\x91\x1c\xe7\x1c\xfe\xd1\x2f\xc1\x07\xf1\x50\x1d\xce\xd4\x91\x7a\xb1\xa8\xfe\x0e\x00\x01\x2a\x23\xa7\x96\x04\x00\x00")
 
 func templatesNodejs_external_filesTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -324,12 +547,12 @@ func templatesNodejs_external_filesTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/nodejs_external_files.tpl", size: 1116, mode: os.FileMode(420), modTime: time.Unix(1446807870, 0)}
+	info := bindataFileInfo{name: "templates/nodejs_external_files.tpl", size: 1174, mode: os.FileMode(420), modTime: time.Unix(1792311699, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesNodejs_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x52\x41\x6b\xf2\x40\x10\xbd\xe7\x57\x0c\x41\xc8\xca\x17\x96\xef\x1c\xf1\x50\x15\xda\x43\x45\x0f\xbd\xf4\x24\x4b\x76\x34\xc1\x98\x4d\x77\x37\x15\x09\xfb\xdf\x3b\xb3\xd1\x2a\x42\x73\xda\x79\xf3\x78\x6f\x66\x5e\x86\x01\xac\x6a\x0f\x08\x93\x23\x5e\x72\x98\xec\xa0\x98\x83\x5c\x1b\xdd\x37\xe8\x20\x84\x6f\x65\x81\x38\xdc\xa5\x0a\xe6\x60\xf1\xab\xaf\x2d\x8a\xf4\x8e\xa6\xd3\x59\x32\x0c\xd8\xea\x10\x08\x94\x2f\x5a\xd7\xbe\x36\xad\x6a\x56\x58\x36\xca\x2a\x2e\x88\x96\x70\x73\x6b\xb1\x53\x16\x17\x46\x5f\xae\xea\x24\x48\xb2\xdc\x5b\x36\x35\xb6\x7e\xf4\xa6\xa6\x64\x2b\x74\x5e\x0c\x09\xd0\x57\x19\xe7\x0b\x60\x5b\xf9\x46\x4f\xf6\xcd\x63\xa3\x53\xbe\x2a\xa2\xc0\x96\x5e\x84\xe7\xc3\x50\xef\xa1\x45\x02\x8c\xf5\xf0\x9f\xac\x23\x8f\x8a\x2b\x8f\xe1\xc8\x8b\x43\xc7\xee\x09\x7d\x65\xf4\xd5\x60\x1d\x8b\x68\xf1\x30\xf4\xa6\xe3\x4d\xf8\x2a\x49\xc8\x61\xdf\xb7\x25\xd7\xc2\xa2\x9b\xc2\x38\x63\x49\x6d\xd3\xa0\x6c\xcc\x41\xa4\xaf\xc6\xd3\x72\xae\x23\x0c\x49\x17\xfe\x71\x25\x9d\x57\xbe\x77\x4b\xa3\x91\x80\xf4\x09\x5e\xa3\x73\xea\x80\x74\x4f\x56\x63\x9c\xf4\x33\xad\xbc\xca\xee\x86\x20\xca\xaa\x6f\x8f\x37\xcf\x67\xdf\x6c\xb1\x59\x7d\x16\x90\x91\xf0\xc8\x1b\xc5\xc2\x74\xc6\xab\x7c\xa0\xb2\x2b\x73\x8e\x79\x84\x98\xdb\x2d\xff\x1d\xa5\xdf\xd4\x74\x35\xfe\x01\x38\x9f\x77\x2a\x5c\x08\x94\x82\x3c\xdb\xda\xa3\xe0\xc4\x23\x23\x84\x7b\xe2\xdc\xa6\x87\x20\x84\x9f\x3c\x2f\x5a\x6b\xec\xc3\xc0\x02\xff\xbc\x4f\xa4\x8e\xc7\x41\x79\xfa\xdd\x9e\xf4\x7f\x02\x00\x00\xff\xff\x29\x91\x9d\x9c\x99\x02\x00\x00")
+var _templatesNodejs_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x64\x52\xc1\x6e\xdb\x30\x0c\xbd\xfb\x2b\x08\x23\x40\x6c\x24\x30\x76\x8e\x91\xc3\x9a\x00\xeb\x61\x59\x83\x6d\x97\x9d\x0a\xc1\x62\x63\x21\x8e\x98\x50\xf2\x8a\x42\xe0\xbf\x0f\x94\x93\xa6\x58\x7d\x92\xde\x7b\x26\x9f\x1e\x99\x12\xb0\xf1\x07\x84\xd9\x11\xdf\x96\x30\x7b\x86\xd5\x1a\x9a\x1d\xd9\x71\xc0\x00\x22\x7f\x0d\x43\x4a\x99\x05\x11\x58\x03\xe3\x65\x74\x8c\x55\x79\x47\xcb\xba\x2d\x52\x42\x6f\x45\x52\x82\xe6\xab\xb5\x2e\x3a\xf2\x66\xd8\x62\x37\x18\x36\x7a\x01\x91\x42\xc9\x3d\xe3\xd9\x30\x3e\x90\x7d\xbb\x56\x67\xbc\xc0\x5a\x7b\x34\x9b\xc1\xa1\x8f\x53\x6f\x10\x69\xb4\x15\x86\x58\xa5\x02\x00\xa0\xa7\x10\x57\x59\xf7\x48\x21\x82\xc8\x32\xc3\x67\x13\xfb\x09\xde\x9b\xd8\x2b\x9c\x92\x7b\x01\x8f\xd0\xec\x89\x23\x7c\x11\x99\x74\xc4\xd7\xdf\x33\x9c\x75\xd9\x72\x66\x4f\x18\x7b\xb2\x13\xbf\xcb\xe7\x49\xf1\x6e\xf8\xe9\xac\xaf\xd0\x44\x0a\x59\xc2\xcb\xe8\x3b\xbd\xab\xe0\x27\x86\x33\xf9\x80\x8f\xc6\xdb\x01\xf9\x87\x39\xa9\xf9\x8a\x31\xd4\x30\x39\xcf\xa1\x8c\xb1\x47\x1f\x5d\x67\xa2\xd2\x0a\xfd\x8a\xc4\xb8\x21\x3a\xba\x9c\x74\x47\x3e\xd0\x80\xcd\x40\x87\xaa\xfc\x46\x11\xf8\x5a\x78\x05\x25\x2c\xf4\xd6\x84\x68\xe2\x18\x36\x64\x11\x16\x50\xfe\x07\xef\x30\x04\x73\xc0\xba\xcd\x3d\x15\x27\x5f\xcd\xad\x89\x66\x7e\x37\x0c\x55\xd7\x8f\xfe\x78\x73\xa6\xdf\xc7\xbe\xf3\x87\xa7\xed\x9f\x15\xcc\x61\x01\x93\x6e\x2a\x26\x75\xab\x86\x7f\xa3\xe1\x2d\xbd\xe6\x59\x4a\x9e\xf9\x6d\x77\x9e\x97\x30\x1b\x9c\xc7\xbc\x3c\x3a\xdb\xef\xce\x63\x10\x61\xbc\x34\xaf\xec\x22\x56\x29\x5d\x15\x22\xf7\x6d\x51\x1a\xbd\xad\xea\xb6\xd0\xa3\xfa\x45\x66\xe2\x0f\x86\x2b\xbc\x79\xfd\x94\x4f\x96\x4e\xe1\x60\x73\x7a\x7f\xbd\xd4\xed\xbf\x01\x00\xc0\x8c\xd5\x5d\xd5\x02\x00\x00")
 
 func templatesNodejs_fullTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -344,7 +567,7 @@ func templatesNodejs_fullTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/nodejs_full.tpl", size: 665, mode: os.FileMode(420), modTime: time.Unix(1446807850, 0)}
+	info := bindataFileInfo{name: "templates/nodejs_full.tpl", size: 725, mode: os.FileMode(420), modTime: time.Unix(1792311699, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -369,7 +592,7 @@ func templatesNodejs_simple_getTpl() (*asset, error) {
 	return a, nil
 }
 
-var _templatesObjc_nsurlconnection_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xa4\x54\x61\x4f\xe3\x38\x10\xfd\x9e\x5f\x31\xc7\x01\x4a\x2b\x8e\x1f\x90\x52\xd4\x83\x5e\xc5\x89\xd2\xa2\x16\xb4\x5a\x45\xd1\xca\xc4\xd3\xc6\xbb\xae\x9d\x75\x1c\x50\xb7\xea\x7f\xdf\x71\x1a\x87\xd0\x8d\xf6\xcb\x22\xa1\xda\x9e\xf1\x7b\xcf\x33\x6f\xb2\xdb\x81\x61\x6a\x8d\x70\xfa\x0d\xb7\x17\x70\xfa\x05\xa2\x21\x5c\x3e\x68\x5e\x4a\x2c\x60\xbf\xff\x5b\x6c\x72\x6d\x2c\x5c\x51\xa2\x4b\xa1\xa3\xeb\x60\xb7\x43\xc5\xf7\xfb\x20\xb8\x99\xcf\xa7\x50\x64\xba\x94\xfc\x1e\x31\x5f\x94\x4a\x09\xb5\x86\x21\x7c\xfe\x6f\x39\x08\x82\x91\x50\x16\xcd\x8a\xa5\x08\x77\x4f\x4f\x8f\x63\xfd\xa6\xa4\x66\x7c\x8c\x12\xd7\xcc\x22\x44\x30\x5b\xce\x5f\xbe\x62\x6a\xaf\x66\xcb\xe7\xc5\xf4\x56\x2b\x45\x1b\xa1\x95\x4f\xb9\x86\x5d\x00\xf4\x37\x5b\x3e\x94\x96\xbd\x48\x1c\x33\xcb\xa0\x9f\x6a\x02\x56\xb6\x18\x04\x24\x62\x44\x62\x1c\xd7\x26\x97\xb8\xa1\x53\xe6\x00\x3a\x09\x83\xe0\x1f\x08\x5f\xb5\xe0\xbd\xb4\x61\x8a\xc2\x23\x6a\xe8\xb7\xa2\xc0\x05\x5f\x60\x8a\xe2\x15\x17\x58\xe4\x5a\x15\x58\x5f\xf0\x5b\x4a\x37\xf5\x32\xf0\x5a\x1d\xf7\x87\x94\xcc\xda\xbc\xd9\x0d\x21\xec\x48\x69\x50\x06\x35\xc8\x54\xaf\xc3\xd1\xc9\x92\x9e\x53\x16\x11\x9c\x49\x7e\x72\x01\x6d\x9c\xcb\xa2\x0a\xdd\x6a\x8e\x3d\x7f\x67\x2c\x2a\xd5\xcc\x6c\x89\x13\x19\x47\x53\x10\xdd\x87\x5b\x4c\xca\xbb\x2a\x32\x11\x28\x79\x71\xb8\xb9\xd2\x06\x42\xc1\xc1\x35\x58\x28\xa8\xaf\xf6\xea\xe2\xb7\xf5\x9c\x8d\x48\xcb\x88\xa4\x54\x76\x89\x3d\x89\xae\xba\x38\xd1\xe6\x1e\xb7\x11\x85\x92\x5a\xd2\xde\x35\xe8\x0f\x8a\xee\xda\xed\x92\x0f\x6d\xef\x71\xfa\xa9\xab\x1c\x7b\x0f\x00\xcb\x73\x72\x40\x95\xe9\xe2\xc9\xa0\x9b\x73\x2c\xf8\x44\x28\x51\x64\x53\x72\x04\xb9\xf4\xf7\x1a\x9a\x5e\x1e\x9e\x6d\x0e\x72\xf8\x49\xfd\xae\x2e\xcf\xcf\xe6\xef\x76\xa4\x69\xb9\xfc\x97\x73\x51\x75\x43\x8e\x31\x95\xcc\x1c\x7c\x49\x63\x43\x53\x01\x1b\x26\x54\xe8\x16\xcc\xac\xd3\x0b\x48\x33\x66\xa0\x4f\xeb\xd7\x38\xf1\x65\x1f\xb1\xd2\x6a\x43\xd6\x65\x05\xe6\x5a\xcb\x56\x37\x1c\xfc\xad\xde\x6c\xb4\xfa\x5f\x11\x07\x93\xe2\x07\x12\xb2\x3b\x7e\x34\x98\x33\x83\x37\x9a\xbb\x59\x6d\xe6\xa6\xf2\xda\xf7\x12\x0b\x0b\x7d\x53\x2f\x86\x10\x77\xc5\xeb\xf0\x27\x61\x33\x3a\x8d\xe2\xaa\x4c\x40\xff\xee\x64\x69\x8d\xab\x9d\x63\x7a\x36\x92\x18\x12\xaa\xb7\xdb\xd1\x27\x43\xac\xb6\x1e\x83\x5e\xe9\xb5\x76\x4e\x7e\x9f\xfb\x15\x89\x88\x3b\x53\xc8\xa8\x3a\x4d\xc8\x8e\xc2\x26\x83\x06\xad\x65\xc8\xa3\xe6\xb5\xfc\xe3\x30\x8f\xe3\x2d\x38\xf7\x8e\x5a\x68\xe4\x6b\xe1\xf5\x44\x7e\x41\x9c\x0d\x97\x58\x41\xf8\xd7\x3b\x7e\xaf\xb1\xc5\x8a\x09\x89\x1c\xac\x86\xd4\xa0\x13\xfd\x9e\xe4\x9d\x72\x24\x9a\xec\x32\xd5\x3a\x87\xbe\xcd\x90\xaa\x5a\xb5\xc0\x9f\xa5\xa5\x31\xe4\xe8\x7a\xdb\x7a\xf4\x5b\x46\x34\x10\xfe\x6a\xba\xf3\x73\x88\x0f\x40\xa6\x54\xd4\x01\x8c\x68\x54\x70\xc5\x4a\xe9\x51\xdc\x21\xbc\x20\x0d\xb8\x9b\x25\x74\xdd\x74\xbf\x34\x62\xf4\xf9\x50\x76\x52\xda\xd2\x60\xd2\x1e\xd7\x9f\x01\x00\x00\xff\xff\x24\x6b\xcc\x1d\x12\x06\x00\x00")
+var _templatesObjc_nsurlconnection_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xa4\x56\x6d\x6f\xdb\x36\x10\xfe\xae\x5f\x71\xcb\x9a\xc2\x36\x32\x63\x9f\xe5\xba\x70\x6b\xd7\xc8\x50\xbf\x14\x56\x8c\x61\x30\x84\x81\x11\x4f\x12\x17\x9a\x54\xf9\xe2\xc0\x13\xf4\xdf\x07\x52\x2f\x56\x52\x77\x43\xb1\x4f\xa1\x78\xc7\xe7\x39\xf2\xb9\x7b\xe2\xb2\x04\x45\x44\x86\xf0\xe6\x09\xcf\x77\xf0\xe6\x4f\x08\xa7\x30\x5e\x4b\x6a\x39\x6a\xa8\xaa\x9f\xd9\xb1\x90\xca\xc0\xbb\xb2\xf4\x29\x50\x55\xef\x83\xb2\x44\x41\xab\x2a\x08\x3e\x6e\xb7\x2b\xd0\xb9\xb4\x9c\x7e\x46\x2c\x76\x56\x08\x26\x32\x98\xc2\x1f\x9f\xa2\x49\x10\xcc\x98\x30\xa8\x52\x92\x20\xdc\x3f\x3c\x7c\x59\xc8\x67\xc1\x25\xa1\x0b\xe4\x98\x11\x83\x10\xc2\x26\xda\x3e\xfe\x85\x89\x79\xb7\x89\xf6\xbb\xd5\x5c\x0a\x81\x89\x61\x52\xb4\x29\xef\xa1\x0c\x00\x00\x36\xd1\xda\x1a\xf2\xc8\x71\x41\x0c\x81\x51\x22\x85\x41\x61\xf4\x24\xa8\x82\x60\x86\x82\x3a\xae\x63\xc1\xf1\x88\xc2\x10\x07\x70\x95\x30\x08\x7e\x81\xc1\x49\x32\x3a\x4c\x3a\xa6\x70\xf0\x8a\x1a\x46\xbd\x28\x50\x46\x77\x98\x20\x3b\xe1\x0e\x75\x21\x85\xc6\xe6\x40\xfb\x09\xa3\xa1\x6a\x96\x41\x5b\xab\xe3\x7e\x91\x92\x1b\x53\x74\x5f\x53\x18\x5c\x49\xe9\x50\x26\x0d\xc8\x4a\x66\x83\xd9\x4d\x64\x88\xb1\x3a\x84\x5b\x4e\x6f\xee\xa0\x8f\x33\xd6\x3e\x34\x97\x14\x87\xed\x99\x05\xf3\x55\x13\x75\x86\x51\x8e\x84\xa2\xd2\x30\x7d\x79\x8a\x70\x7e\xef\x23\x4b\x86\x9c\xea\xfa\x64\x2a\x15\x0c\x18\x05\x27\x30\x13\xd0\x1c\x1d\x36\x8f\xdf\xaf\xe7\x76\x16\xc2\xed\xec\xe6\x0e\x7c\xbb\x1c\x5a\x12\xe9\x55\x5c\x4a\xf5\x19\xcf\xe1\x13\x9e\xe3\xa6\xa4\xca\x09\x54\x96\x2c\x85\xf1\xb6\x70\xa5\xe9\xf1\x5e\xe3\x82\x65\xa8\xcd\x07\x6b\x72\xa8\xaa\x1f\x96\xe4\x99\x71\x1e\xa1\xa0\x3b\xfc\x6a\x51\x3b\x52\x87\x84\xc2\xb0\xc4\x4b\x3f\xcf\x09\xe7\x28\xb2\x56\xa9\xef\x44\x1d\x68\xbb\x6e\x94\x63\x29\x0c\x0e\xdd\xe6\xb8\x50\xd2\xd4\x9c\x51\x41\x12\x1c\x93\x17\x40\x6b\x34\xb9\xa4\xc0\xf4\xa7\xaf\x96\xf0\x07\x19\x19\xc5\x44\x16\x5e\xa1\xac\x33\x7d\x43\xfa\x8b\xc7\xf0\xf6\x2d\x5c\x78\xa0\x50\x78\x62\xd2\xea\x25\x61\xdc\x2a\x9c\x4b\x2b\x4c\x0c\xd3\x29\xfc\xda\x57\xe0\xd0\x3b\xa1\x51\x50\x54\x31\x58\x8d\x73\x85\xd4\x51\x11\x1e\x96\x25\x8c\x2f\x9f\x50\x55\x4e\xd7\xef\xbd\x4d\x07\x16\x37\x4a\x01\x72\x8d\xff\xc1\x57\xa0\x4a\xa5\x3a\x2e\x30\x25\x96\x9b\x7b\x22\x28\x67\x22\x5b\xfe\x00\x4b\xdd\x0f\xde\x3e\xfe\xc7\x2c\x3a\x17\x70\xc9\xb5\x1b\x0c\x29\x31\xa4\x91\xf0\xd0\x5a\x03\x90\xa2\x40\x41\x7d\xa6\x8b\xc7\xde\x2b\xbe\xe5\x5c\x30\xba\x64\x82\xe9\x7c\x25\x09\x75\x02\xfe\x6b\x0d\xdd\x88\xd7\xd3\xa0\xea\x72\xe8\x4d\xd3\xee\xd7\xac\x70\xb3\xbd\xb8\x94\x53\xe8\x03\xa5\xcc\x0f\x29\x5f\x60\xc2\x89\xaa\xed\xaa\xaa\x02\x26\x0c\x1c\x09\x13\x03\xb7\x20\x2a\x4b\xee\x20\xc9\x89\x82\x11\x51\xd9\xe9\x10\xb7\xbd\x30\x23\xd6\x48\x85\x1c\x89\xc6\x42\x4a\xde\x93\xcc\x37\x80\x3c\x1e\xa5\xf8\x4d\x30\xd7\x03\xec\x6f\x84\xaa\x72\xdb\x5f\x14\x16\x44\xe1\x47\x49\x9d\x85\x77\x76\xea\x2d\xc8\x8f\x11\x8c\x54\xb3\x98\xc2\xe1\x5a\xbc\x09\xff\xce\x4c\xbe\xdf\xad\xc2\x83\x7f\x26\xd8\xef\x56\x6e\xa7\x69\x7e\xc7\xb4\x57\xae\xf5\xe2\x78\xe2\x6f\xbb\x96\x94\xa5\xe7\x16\xa3\xaa\xba\x5a\xaf\xfe\x43\x18\xd1\x76\x35\x85\xc3\xe1\x6a\x0a\xe1\x5c\x26\x31\x30\xc1\x4c\x3c\xe9\xd0\x7a\x3e\xf5\x4a\xbc\x5e\xff\x38\xcc\xd7\xf1\x1e\x9c\xbb\x47\x53\x68\xd8\xbe\x45\x5b\x4f\xd8\x2e\xe2\x49\xd0\x71\x39\xbb\xf8\xe9\x82\x3f\xec\xda\x22\x25\x8c\x23\x05\x23\x21\x51\xe8\x8a\xbe\x24\xb5\x9d\xf2\xaa\xe8\x9d\x15\x2b\x29\x0b\x18\x99\x1c\x77\xab\x5a\x82\x76\x2f\xb1\x4a\xa1\x30\xcd\x67\xef\xd2\xcf\x39\xe3\x08\x83\x6f\x9b\xce\xd9\x4b\x0d\xa4\xac\x58\x4b\x8a\xe1\x26\x6a\x66\xb6\x41\x71\x9b\xf0\x88\xa9\x54\x6e\x96\xd0\xa9\xe9\xfe\x02\x65\xda\x10\x61\x96\xd6\x58\x85\x71\x3c\x9c\x38\x0d\x23\x72\xc2\xb9\x94\x4f\xcc\xff\x22\xe8\xe6\xf8\x9f\x01\x00\xc2\xf1\xbf\x75\x3b\x08\x00\x00")
 
 func templatesObjc_nsurlconnection_fullTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -384,12 +607,12 @@ func templatesObjc_nsurlconnection_fullTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/objc_nsurlconnection_full.tpl", size: 1554, mode: os.FileMode(420), modTime: time.Unix(1426433181, 0)}
+	info := bindataFileInfo{name: "templates/objc_nsurlconnection_full.tpl", size: 2107, mode: os.FileMode(420), modTime: time.Unix(1792303037, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesObjc_nsurlsession_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x54\x6b\x6f\x1a\x3b\x10\xfd\xce\xaf\x18\xe5\x26\xd1\x82\x50\x3e\x5f\x2d\x97\x2b\x9a\x07\x4a\x15\xf2\x10\x10\x55\x15\x22\xc8\x59\x0f\xe0\xc6\xd8\x5b\x3f\xda\x52\xb4\xff\xbd\x63\xef\x6e\xb4\x50\xda\x95\x60\xed\x19\xcf\x99\x33\x9e\x33\xbb\xdb\x81\x61\x6a\x85\x70\xfa\x86\xdb\x2e\x9c\x2e\x20\xed\xc3\xc5\xbd\xe6\x5e\xa2\x85\xa2\xf8\x47\x6c\x72\x6d\x1c\xfc\x47\x07\xc3\x11\x32\xfd\xdf\xda\xed\x50\xf1\xa2\x68\x5d\x3e\x3e\x8e\xc0\xae\xb5\x97\xfc\x0e\x31\x1f\x7b\xa5\x84\x5a\x41\x1f\x3e\xdf\x4c\x7a\x74\x0a\x2e\x3e\x70\x2e\x9c\xd0\x8a\xc9\x6b\xcc\x24\x33\x2c\x6c\x08\xa3\x25\x94\x83\x0d\x13\x2a\x09\x0b\x66\x56\x59\x17\xb2\x35\x33\xd0\xa1\xf5\xb7\xd9\xbc\x0d\xbb\x16\xd0\x33\x60\xde\x69\x83\x12\x99\xc5\x5c\x6b\x59\x99\xc3\x13\xe0\xaf\xf4\x66\xa3\xd5\x47\x45\x39\x98\x14\x3f\x91\x90\x83\xf9\xc9\x60\xce\x0c\x5e\x6a\x1e\xf8\x3e\x4c\xee\xbd\x63\xaf\x12\x9f\xc7\xa3\x31\x7e\xf5\x68\x1d\x74\x4c\xb5\xe8\xc3\xec\x98\xbf\x72\x7f\x12\x6e\x4d\xd6\x94\xce\xd0\x0b\xe8\x17\x2c\x13\x67\xa8\xcc\x34\x64\x7a\x36\x92\x32\xcc\xe7\x65\xb5\x74\x6d\x62\xb9\xad\x31\xa8\xca\x9a\x6b\x0c\x9f\xa0\xb5\xa1\xfa\x8e\xad\x16\x31\x77\xc3\x61\xe9\x02\x90\x57\x3b\x82\x3c\x16\x7d\xcd\x1c\x9b\x32\xfb\xd6\x01\x47\xff\x01\xa2\x46\xe3\x95\x27\x30\xac\x28\xa4\x75\x95\x99\xde\xe4\x12\xc3\xdd\xdf\x32\xc5\x25\x9a\xf4\x25\x79\x98\x04\x2c\xe8\x84\xb8\x6e\x99\x63\x8c\x36\xd7\xca\x62\xb8\x9e\x72\x15\x1c\x37\xc6\x68\x6a\x0c\x86\x57\xbb\xd1\x80\x92\xda\xed\x74\xfa\xb4\x17\xba\x76\x2e\x7f\xdf\xf5\x21\x39\x72\xa4\x5d\xc3\xf7\x0e\xc0\x46\x7a\x95\x0c\x4e\x26\x8e\x39\x6f\x53\x38\x93\xfc\xa4\x0b\x4d\xbc\x0b\x1b\x5d\x57\x9a\x63\xfb\x30\xf6\x5a\x64\x51\x69\x66\x4b\x1c\x90\x71\x34\x96\xd2\xef\x45\x33\x29\x6f\xa3\x67\x28\x50\x72\xbb\x8f\xb0\xa4\x22\x13\xc1\x21\x88\x5c\x28\xa8\x20\x0e\x0b\x6e\xf2\x3c\x1b\x10\xc7\x01\x51\x8c\xa3\x33\xab\x93\xea\xd7\x2f\x98\xb9\xa1\x36\x77\xb8\x4d\xc9\x35\x3f\xa0\x5a\xec\xed\xc4\x32\x89\x37\x0b\xfd\x3e\x28\x21\x8f\xe7\x2b\x15\x07\xd4\x74\xfc\x11\x35\x3b\x7b\xb7\x51\x4d\x3a\x9b\x13\x63\x11\xd5\x1a\x7a\x9a\x46\x2d\x00\xaa\x8c\xf4\x48\x42\x0d\xcd\x9d\x0e\xff\x2d\x03\x6e\x2a\xeb\xbc\xf7\xc7\xba\xa2\x2e\xfa\x65\x69\x21\xe1\x5f\xf9\x73\x61\x73\xe6\xb2\xf5\xc2\x6e\x55\x96\xbc\xef\x56\xe8\x16\x61\xbe\x17\x24\x40\x8f\x49\xbb\x0b\x2f\x49\x7b\x77\xf4\x63\xf1\xf0\xd8\x83\xa2\x91\xa3\x68\x50\x9b\x45\x95\x93\x5c\xfc\x06\xc9\xdc\x18\x09\x8a\x1f\x69\x9d\x43\xc7\xad\x91\x46\x33\xce\x52\x6d\xcb\xbc\x31\xa8\x5c\xb5\x6d\xc0\x7d\x5f\x0b\x89\x90\xfc\xce\xe2\xfc\x9c\x52\x45\x20\xe3\x15\x8d\x31\xa6\x24\x28\x5c\x32\x2f\x6b\x94\x60\x84\x57\x24\x95\x20\xdd\x0f\x86\x4f\x42\x78\x87\xfa\x1d\x53\x6e\xe8\x9d\x37\x38\xaf\x7b\x5d\xb4\x8a\xd6\xaf\x00\x00\x00\xff\xff\xa5\xf2\x94\xdd\x5b\x05\x00\x00")
+var _templatesObjc_nsurlsession_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x56\xdb\x6e\xe3\x36\x13\xbe\xd7\x53\x0c\xf6\xdf\x5d\x48\x82\x7f\xa3\x97\x85\xbc\x5e\x78\xd7\x4e\x90\x62\x1d\x27\xb5\x62\x14\x85\xa1\x04\x8c\x38\x96\xd8\xd0\xa4\xc2\x43\x5a\x57\xd0\xbb\x17\xa4\x24\x1f\x12\x6f\x8b\xd6\x37\x26\x39\xc3\x6f\xbe\x39\x52\x75\x0d\x8a\x88\x02\xe1\xfd\x13\xee\x06\xf0\xfe\x01\x92\x31\x0c\xaf\x25\xb5\x1c\x35\x34\xcd\xff\xd8\xb6\x92\xca\xc0\xa7\xba\xf6\x2a\xd0\x34\x9f\x83\xba\x46\x41\x9b\x26\xf8\x7a\x73\x33\x07\x5d\x4a\xcb\xe9\x37\xc4\x6a\x69\x85\x60\xa2\x80\x31\xfc\x7a\x91\x8e\x82\xba\x66\x1b\x18\xde\x54\x86\x49\xa1\x87\x2b\x8d\x33\x56\xa0\x36\x5f\xac\x29\xa1\x69\x82\x09\x13\x06\xd5\x86\xe4\x08\x07\xc1\x0c\x39\x16\xc4\x20\x24\xb0\x48\x6f\x1e\x7f\xc3\xdc\x7c\x5a\xa4\xab\xe5\x3c\x45\xad\x99\x14\x77\x44\x3f\xf5\x3a\x9f\x83\x09\x0a\x1a\x04\x13\xb6\xad\x38\x6e\x51\x18\xe2\x4c\x9d\x41\x0b\x82\xff\x43\xf8\x22\x19\x8d\x0e\x48\x49\x78\x8c\x0b\x71\xa4\xbb\x95\x21\xfa\x29\x09\x5f\x1b\x85\x38\x72\x02\xa0\x8c\x2e\x31\x47\xf6\x82\xd3\x92\x70\x8e\xa2\xc0\x4e\xd9\x19\x44\x61\x58\xee\x59\xec\xa5\x10\x47\xf9\x7e\x9d\x4b\x47\xd5\xc9\xaf\x88\xa0\x1c\x55\xe2\x69\x41\x78\x1f\x9d\x58\x74\x58\x7b\x84\x19\xd3\x95\xd4\xcc\xdd\x1a\x80\xd7\x9a\x2a\xa4\xce\x14\xe1\x10\x47\xd1\x1b\xd0\xa0\x0e\x00\x00\xd8\x06\xc2\xf5\xde\xf6\xb0\x52\xd2\x60\xee\xd4\xd2\x8a\xe4\x38\x24\x27\x7c\xaf\xd1\x94\x92\x02\xd3\x17\xcf\x96\xf0\x3b\x99\x1a\xc5\x44\x91\x9c\xf1\xac\xd5\xbc\xba\xbb\xbb\x6d\x03\x9d\xc1\xc7\x8f\x70\xb0\x03\x95\xc2\x17\x26\xad\xbe\x24\x8c\x5b\x85\x53\x69\x85\xc9\x60\x3c\x86\x1f\x22\x68\x89\xb9\xdf\x1b\xd2\xdf\xf7\x7f\xa5\xf1\xe0\xf0\x00\xea\x1a\x86\x87\x3d\x34\x4d\x34\xf2\xa8\x0d\x20\xd7\xf8\xdf\x4c\xdc\xa2\xda\x48\xb5\x9d\xe1\x86\x58\x6e\xbc\x3a\x13\xc5\x00\x04\xe3\x3d\x7a\xd0\x04\x6d\xbd\x75\xc5\xef\x68\x7c\xa1\xd4\xa7\x85\xf0\x19\xe6\x9c\xa8\xb6\xfe\x9a\x26\x60\xc2\xc0\x96\x30\x11\xba\x05\x51\x45\x3e\x80\xbc\x24\x0a\x62\xa2\x8a\x97\x75\xd6\x07\x62\x42\xac\x91\x0a\x39\x12\x8d\x95\x94\xfc\x88\xbc\xf7\x52\x6e\xb7\x52\xfc\x24\x98\x73\x94\xfd\x89\xd0\x5a\xbd\x55\x58\x11\x85\x5f\x25\x75\xdd\xb8\x48\xaf\xad\x21\x8f\x1c\x57\xcb\xf9\x12\x9f\x2d\x6a\x03\xb1\xea\x16\x63\x58\x9f\x93\x77\xe2\x5f\x98\x29\x57\xcb\x79\xb2\xf6\x71\x81\xd5\x72\xee\x4e\xba\xcc\x3b\x4b\x2b\xe5\xe2\x9b\x65\xae\x97\xfd\x50\x60\x9b\x5d\x8f\xd1\x34\xff\xd0\xe0\xbd\x27\xa7\x6d\xd6\x77\x99\x67\x76\x24\xe8\xce\x1d\x81\xa9\x14\x1b\x56\xd8\x36\x9a\xc9\x89\xda\x89\x08\x68\x9b\xae\x73\xb2\x0c\x68\xd7\xfb\xc9\x7a\x7d\x66\xbc\x10\xce\x65\x9e\x01\x13\xcc\x1c\x54\x7f\xb6\x68\x31\x11\x8c\x7b\x87\x5d\x35\xfd\x6b\x2f\x4a\xa2\x90\x76\xbb\x6c\xd4\xd7\xca\x39\x90\x19\x31\xc4\x0d\x96\xd8\x0f\x1c\x87\xd4\x83\xd2\x4e\xe2\x62\xd1\x45\x3b\xe9\x13\xfa\x76\x80\xdc\x87\x8b\xd4\x61\x41\xec\xee\x75\x03\x62\x89\xba\x92\x42\xa3\xab\x84\x76\xe5\x04\x17\x4a\x49\x05\x31\xba\xbf\xe3\x5e\x6c\xa9\xb9\x8e\x3e\xb9\x5a\x1a\x53\xed\x77\x63\x08\xcf\xa8\x44\x3d\xfc\xe8\x15\xd8\x5c\x16\xe1\xe4\x5d\x6a\x88\xb1\x3a\x81\x0f\x9c\xbe\x1b\xc0\x31\xde\x50\x7b\xd1\x54\x52\x8c\x5e\xdf\x9d\x31\x3f\xa5\x88\xda\x41\x5c\x22\xa1\xa8\x34\x8c\x4f\x6f\x13\xce\xaf\xbc\xe4\x92\x21\xa7\xfa\x14\x61\x23\x15\x84\x8c\x82\x7b\xad\x98\x80\x0e\xe2\xb5\xc3\xc7\x3c\x3f\x4c\x12\xf8\x30\x79\x37\x00\xff\x06\xae\x7b\xa3\xd2\xbf\x3f\x97\x52\x7d\xc3\x5d\xf2\x84\xbb\xec\x15\xd5\xe6\x64\xc7\x36\xa1\x8f\xac\x1b\x75\x6e\x6e\x9c\xb5\xd7\x36\x17\xc4\x60\xf0\x0f\xdf\x9e\xeb\xfd\xd9\x51\x49\xba\xcc\xbb\x9c\x26\xbe\x16\x00\x45\x2e\xa9\xeb\x49\x97\xdc\xbb\xcb\x1f\xdb\x0b\x17\xdd\x69\x36\xfa\xae\x5f\x0e\x03\xc6\xad\x6b\xce\xe0\xdf\xf2\xa7\x4c\x57\xc4\xe4\xe5\x83\xde\x89\x3c\xdc\xef\x0a\x34\x0f\x6e\x94\x3d\x3c\xbb\xee\x08\xa3\x01\xdc\x87\x51\x7d\xf6\xd5\x5f\xdc\x8c\xa0\x39\xb2\xd1\x1c\x51\x5b\xfb\x2a\x57\xa8\xed\x16\xb3\x51\x10\x1c\xa8\x2e\xad\x98\x4b\x59\x41\x6c\x4a\x5c\xce\xdb\x96\xea\xcf\x72\xab\x14\x0a\xd3\x6d\x8f\xe0\x7e\x2f\x19\x47\x08\xdf\xb2\x70\x6f\x51\x0b\xa4\xac\xb8\x96\x14\x93\x45\xda\x8d\xf5\x0e\xc5\x1d\xc2\x23\x6e\xa4\xc2\x99\x9f\x0f\xbe\x7f\xd0\xf9\x6f\x88\x30\x97\xd6\x58\x85\x59\x16\x8d\xdc\xcc\x4b\xc9\x0b\x4e\xa5\x7c\x62\xfe\x63\x68\xff\x12\xfc\x35\x00\x06\x03\x45\x97\x36\x09\x00\x00")
 
 func templatesObjc_nsurlsession_fullTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -404,12 +627,12 @@ func templatesObjc_nsurlsession_fullTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/objc_nsurlsession_full.tpl", size: 1371, mode: os.FileMode(420), modTime: time.Unix(1426433181, 0)}
+	info := bindataFileInfo{name: "templates/objc_nsurlsession_full.tpl", size: 2358, mode: os.FileMode(420), modTime: time.Unix(1792303037, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesPhp_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x8e\x4f\x4b\xc5\x40\x0c\xc4\xef\xfd\x14\x61\x79\x87\x2d\x14\xbf\x80\x56\xf1\xcf\xc1\x8b\xe0\xc5\x93\x3c\x96\xb0\x9b\xda\x42\xdb\x5d\xd2\x28\x95\xc7\xfb\xee\x26\xad\x0a\x1e\x3c\xfe\x66\x26\x93\xb9\xba\x29\x7d\x39\x9d\xe0\xe2\x36\xa5\x41\x86\x3c\xe3\xf8\x40\x71\x44\x46\x03\x38\x9f\xcd\x7b\x66\x2a\xc8\x74\x97\xd3\xe7\x5f\xe5\x91\x30\x11\xab\x56\x1d\xa2\xac\xd0\xc2\x22\x4c\x38\x85\x98\x67\xa1\x55\x42\x54\x12\xf2\xaf\x15\x80\xeb\x45\x8a\x83\xf6\x1a\x8c\x94\x27\x92\x3e\xa7\x4d\x71\x56\xf9\xb4\xb1\x76\x6d\xf4\xdb\x6c\x70\x6f\x75\xb3\xd8\x1f\x80\x63\x75\xac\x2f\xab\x43\x57\xf4\x5d\x97\x0b\xcd\xde\x22\x2f\x3c\xaa\xdd\x80\x63\xd7\x40\x87\xe3\x42\x0d\xd8\x26\x8d\x0e\x1d\xf8\x2d\xde\xb6\xbb\x53\x6b\x0b\xad\x83\x78\x35\x3f\x90\x43\x7a\x9f\x8a\xff\x5e\xfe\x46\x12\x74\x19\x86\x84\x82\x76\x56\xff\x13\x8a\xfb\xa6\xe5\x27\xf3\x15\x00\x00\xff\xff\xb4\x0c\x94\x89\x49\x01\x00\x00")
+var _templatesPhp_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x8f\xc1\x4a\xc4\x30\x10\x86\xef\x79\x8a\xa1\xec\xa1\x85\xc5\x17\xa8\x55\x74\x15\xf4\x20\x08\xe2\x49\x96\x32\x24\xd3\x6d\xb0\x4d\xc2\x74\x76\xa9\x2c\x79\x77\x99\x6e\x15\x3c\x78\xfc\x26\xff\x7c\x99\xff\xfa\x36\xf5\xe9\x7c\x86\xab\x3b\xe7\xbc\xf8\x18\x70\x78\x20\x3b\x20\xa3\x02\xe4\xac\x6f\xaf\x4c\x09\x99\xee\xa3\xfb\xfa\x3b\x79\x22\x74\xc4\x90\xb3\xd9\x58\x99\xa1\x81\x49\x98\x70\x6c\x6d\x0c\x42\xb3\xb4\x96\x09\x85\xca\x0f\x03\x50\xf4\x22\xa9\x80\xe6\x06\x94\x00\x8a\x91\xa4\x8f\x6e\x99\xa8\xf1\x65\xc1\x55\xff\xeb\x55\xd8\xa9\x2c\xc8\x4a\xcf\x87\x10\x99\x1e\x99\x23\x4f\xfa\x31\xc0\xde\xec\xab\xda\x6c\xba\x04\x0d\x74\x31\x51\x28\x35\xf7\xce\x03\xe4\xbc\x85\x82\x8b\x2d\x74\x38\x4c\xb4\x05\x3d\xb2\xaa\xcd\x52\xf7\x28\x3d\x05\xf1\x16\x85\x56\xf3\x1b\x9e\x68\x17\xe3\xa7\x27\x15\xfb\x0e\xca\xc5\xd9\x34\x97\xf5\xca\x00\xd0\xec\xa5\xac\x6a\x73\x42\x6e\xdd\x71\x4c\xe5\xda\xf7\x40\xd2\x8e\x24\xd8\x3a\x14\xd4\xb5\xea\x9f\x90\xbd\x74\x99\x7e\x32\xdf\x03\x00\xaf\xbe\x89\x5b\x7f\x01\x00\x00")
 
 func templatesPhp_fullTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -424,12 +647,32 @@ func templatesPhp_fullTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/php_full.tpl", size: 329, mode: os.FileMode(420), modTime: time.Unix(1426433181, 0)}
+	info := bindataFileInfo{name: "templates/php_full.tpl", size: 383, mode: os.FileMode(420), modTime: time.Unix(1792311699, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesPython_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5c\x91\xc1\x6e\x83\x30\x0c\x86\xef\x3c\x85\x85\x7a\x00\x09\xf1\x00\x95\x38\x6c\xdd\xa1\x97\x4a\x7b\x83\xc8\x23\x6e\x89\x46\x13\x96\xb8\xd2\x26\x94\x77\x9f\x4d\xba\x4e\x2a\x17\xe2\x9f\xcf\xff\xef\x98\x75\x85\x88\xfe\x42\xb0\xfb\xa4\x9f\x0e\x76\x06\xf6\x03\xf4\xa7\x60\x6f\x33\x25\xc8\xd9\x5d\x97\x10\x19\x04\x53\x40\x84\x6a\x5d\xc9\xdb\x9c\x45\xe9\x5f\xac\x75\xec\x82\xc7\xf9\x8d\xc6\x19\x23\x6a\xa1\x8c\xa5\x33\x5c\xd1\xf9\xa6\xdd\x57\x20\xcf\x18\xbc\x87\x01\x26\xe6\xa5\x1f\x67\x47\x9e\x7b\xed\x3f\x88\x4c\xa3\x36\x1d\x66\x4c\x1a\xd7\xd4\xaa\x1f\x43\x62\x29\xea\x76\x6b\x56\xe5\x3d\x86\x6f\x4d\x2f\x67\x5a\x30\xd2\x6b\xb0\x4f\xca\x91\xd0\x52\xd4\xfc\xbf\xcc\x3e\xd2\xd7\x8d\x12\x17\xdb\x13\xf1\x14\xac\x1a\x77\xc5\x14\x79\xda\x1c\xdc\x59\x32\x31\xa9\x63\xce\x1d\x7c\xc8\x7b\x50\xe0\x11\x71\xbf\xf1\x9d\x2b\x39\x4a\x4e\xdb\x29\x6d\xf0\x23\xfd\x8e\x97\xe1\xa3\x6c\x71\x28\xb3\x5c\x88\xa5\x5a\x82\x4f\xd4\x94\x8f\x4b\x74\x9e\x1b\x11\xfb\xc4\xc8\xb7\xd4\x29\x2e\x33\x63\x0a\xfe\x99\x10\xd5\x36\x6d\xfb\x7f\xb5\x71\x0e\x9b\x51\x25\x53\x19\xe3\xf1\x4a\xc6\xc0\x30\x40\x6d\x8c\xae\xde\x98\xba\xec\xbe\xfc\x87\xea\x37\x00\x00\xff\xff\x90\xad\x14\x9c\xe9\x01\x00\x00")
+var _templatesPhp_moduleTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x51\xd1\x6a\xdb\x40\x10\x7c\xbf\xaf\x18\x84\x1f\x24\x30\xce\x07\xa4\x6a\x09\xae\x43\x03\x75\x1a\xec\xb4\x2f\x46\x1c\x8b\xb4\xb2\x8e\x4a\xa7\xe3\xb4\x0a\x01\xa1\x7f\x2f\x77\x52\xeb\x3e\xb4\x8f\x3b\x37\x3b\x37\x3b\xf3\xe1\x93\x6b\xdc\x34\x61\xf7\x50\x55\x46\x4c\x6f\xa9\xfd\xcc\x65\x4b\x9e\xc2\x80\x79\x9e\x26\x78\xb2\x57\xc6\xee\x71\xb4\x65\x00\x07\xcc\xb3\xba\xbb\x43\xd8\x7a\x35\xd2\x72\x98\xeb\xf5\x31\xa2\xcf\xd4\x05\x30\x8d\xba\xfe\x3a\x76\x6c\x25\x6c\x65\x98\x14\x22\xe3\xc5\xb3\x23\x1f\x48\x9b\x52\xde\x91\x63\x10\xcf\xd4\xe9\xb2\xb7\xc2\xef\xa2\x4b\xcf\x24\x9c\x5e\x14\x00\x24\x8d\x88\x4b\x90\x7f\xc4\x32\x03\x49\xc7\xd2\xf4\x55\xc4\x82\xdc\x31\x8e\x8b\xdb\xdd\x7e\xd1\xf8\xe6\xfe\x98\x0d\x2b\x85\x02\x8a\xec\x5e\x01\x9b\xda\x21\x47\xdd\x3b\xb6\xd1\xe1\x77\xdf\x62\x9e\xb7\x48\x7c\xb2\x45\x4d\xed\xc0\x5b\x04\x57\x91\x1c\x4f\x18\xa5\x61\x2b\xa6\x24\xe1\xf5\x8f\x33\xbd\xf1\xbe\xef\x7f\x1a\x0e\x1f\x78\x96\xd1\xdb\x20\x7c\xaf\x66\x35\x4d\x60\xbb\x9a\x31\x35\x76\x5f\x68\x38\x92\x09\x59\x2a\x53\x23\xf5\x4c\xad\x23\x69\xd2\x8d\x3e\x1f\x4e\x3f\x0e\xa7\x4b\x72\xde\x9f\x9e\x5e\x5e\xf5\xe3\xd3\xd7\xc3\xf3\xc3\xf1\x90\x14\x19\xf2\x1c\x3a\x02\x5a\x2f\xa9\xd5\xbd\x67\x2a\x1b\xa4\x17\xf5\xef\x4a\x80\xc5\xed\x99\x6d\xc5\x3e\x5c\x74\x73\x02\x14\xa0\x01\x9b\x81\x6d\xb5\xc8\xfd\x8e\x21\x42\x69\x3c\x15\xc1\x6d\x1a\xe1\x3c\x5f\x82\xc8\xd6\xbc\x43\x2d\xc6\x8e\xbc\xd0\xde\xc8\xeb\x6a\xec\x5c\xba\x96\x76\x65\xd1\x1d\x0b\xe9\x8a\x84\x82\x40\x96\xfd\x9f\x18\x1b\xb6\x32\xdc\x78\xf3\xdf\x99\xfd\x1a\x00\x8c\x55\xba\x44\x90\x02\x00\x00")
+
+func templatesPhp_moduleTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesPhp_moduleTpl,
+		"templates/php_module.tpl",
+	)
+}
+
+func templatesPhp_moduleTpl() (*asset, error) {
+	bytes, err := templatesPhp_moduleTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/php_module.tpl", size: 656, mode: os.FileMode(420), modTime: time.Unix(1792313765, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesPython_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5c\x90\xc1\x6a\xc3\x30\x10\x44\xef\xfe\x8a\x25\xe4\x60\x43\xd0\x07\x04\x7c\x48\xd3\x43\x2e\x85\xd2\x7e\x80\x58\xac\x4d\x22\xa2\xec\xba\xd2\xba\xb4\x08\xfd\x7b\x91\xdd\xd4\xd0\x9b\x34\x9a\x99\x27\x26\x67\x88\xc8\x17\x82\xed\x8d\xbe\x77\xb0\xb5\xb0\xef\xc1\xbc\x88\x9b\x02\x25\x28\xc5\xdf\x47\x89\x0a\x39\xcf\x06\x28\xa5\xc9\x99\xd8\x95\x92\x33\x98\x83\x73\x5e\xbd\x30\x86\x67\x1a\x02\x46\xac\x97\xea\x71\x74\x86\x3b\x7a\x6e\xbb\x7d\x03\x00\x30\x08\x33\xf4\x70\x55\x1d\xcd\x10\x3c\xb1\x9a\x9a\x3f\x0a\x33\x0d\x35\x74\x0c\x98\x2a\xae\xad\xf2\x49\x92\x42\x29\xdd\x1c\xad\xc2\x6b\x94\xaf\xca\x5e\xce\x34\x62\xa4\x27\x71\xff\x94\x13\xa1\xa3\x58\xe9\x6b\x6c\x7e\x38\x8a\xdc\x3c\xfd\x9a\xdf\xe8\x63\xa2\xa4\x0f\x5b\xa4\x04\xfd\xfc\x3d\x73\x21\x8d\x94\x46\xe1\x44\xed\x8a\x3e\x4c\x7a\x25\x56\x3f\xa0\x3e\x2a\xde\xf1\x73\xed\x1c\xa3\x67\x6d\x23\x25\x93\x14\x75\x4a\xbb\x5a\x69\x22\x61\x12\x5e\x5a\x56\x47\x24\x74\x6d\xd7\xfd\x2d\x62\x86\x20\x33\xac\xf1\x67\xb0\x96\xf1\x4e\xd6\x42\xdf\xc3\xc6\xda\xba\x9e\xb5\x9b\x65\xbe\x65\xca\xe6\x67\x00\x54\xfa\x27\xb5\xac\x01\x00\x00")
 
 func templatesPython_fullTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -444,12 +687,252 @@ func templatesPython_fullTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/python_full.tpl", size: 489, mode: os.FileMode(420), modTime: time.Unix(1426433181, 0)}
+	info := bindataFileInfo{name: "templates/python_full.tpl", size: 428, mode: os.FileMode(420), modTime: time.Unix(1792311200, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesPython_httpx_async_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5c\x90\x41\x6a\xf3\x30\x10\x85\xf7\x3e\xc5\x23\x64\x61\x43\xd0\x01\x02\x5e\x84\xfc\xdb\x1f\x4a\x7b\x00\x31\xd8\x93\x58\xc4\x96\xdc\xd1\xb8\x49\x10\xba\x7b\x91\x1d\x5a\xe8\x4e\x7a\xfa\xe6\xf1\x69\xdc\x34\x07\x51\x50\x7c\xfa\xce\x85\x2a\x25\x08\xf9\x2b\x63\x7f\xe3\xe7\x01\x7b\x8b\x63\x0b\xf3\x3f\xf4\xcb\xc8\x11\x39\xbf\xf0\x94\x56\x00\x39\x57\x29\xb1\xef\x73\x4e\x09\xe6\xd4\xf7\x4e\x5d\xf0\x34\xfe\xe3\x6e\x24\xa1\x72\x29\xcc\xda\x8e\x9e\x2f\x98\xc8\xf9\xba\x39\x56\x00\x4a\x89\x79\x13\x9e\x49\x18\x39\x6f\xcc\xdd\xe9\x80\x41\x75\x7e\x98\x53\x09\xce\xa3\x63\xaf\x75\x41\xb7\xe3\x49\xae\xcb\xc4\x5e\x8b\x4c\x03\x8a\xe8\xd6\x78\x6b\xfc\xd3\xba\x4d\x20\x67\xe1\x88\x16\x74\x27\xb7\xaa\x9b\x77\xfe\x5c\x38\x96\x97\x1f\x91\x0f\xfa\xe2\x73\x08\x37\x57\x5c\x66\x71\x5e\x6b\xe1\x68\xa2\x92\x2e\xd1\x76\xa1\xe7\x03\x4a\x20\x4c\x31\x78\x3b\x0f\x42\x91\x9b\x75\xfc\x97\x56\x7e\x68\x53\x55\xee\x02\x6b\x3d\x4d\x6c\x2d\xda\x16\x3b\x6b\xcb\xb7\xad\xdd\x6d\x96\xaf\x5d\x1b\x59\x7c\xbd\xed\xa3\xa9\xbe\x07\x00\x9f\xba\x4e\x1e\x87\x01\x00\x00")
+
+func templatesPython_httpx_async_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesPython_httpx_async_fullTpl,
+		"templates/python_httpx_async_full.tpl",
+	)
+}
+
+func templatesPython_httpx_async_fullTpl() (*asset, error) {
+	bytes, err := templatesPython_httpx_async_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/python_httpx_async_full.tpl", size: 391, mode: os.FileMode(420), modTime: time.Unix(1792303794, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesPython_httpx_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5c\x4f\x4d\x6a\xf3\x30\x10\xdd\xfb\x14\x8f\x90\x45\x0c\xc1\x07\x08\x78\x11\xf2\x6d\x3f\x28\xed\x01\x86\x21\x9a\xc4\x22\xb6\xe4\x8e\xc6\x6d\x8a\xd0\xdd\x8b\x6c\x68\xa1\x3b\xe9\xfd\xce\xcb\x19\xca\xe1\x2e\xd8\x3f\xe4\xeb\x88\x3d\xe1\xd4\xa3\xfb\x1f\xdd\x32\x4a\x42\x29\x7e\x9a\xa3\x1a\x72\x5e\x05\x28\xa5\xc9\x59\x82\x2b\x25\x67\x74\x67\xe7\xbc\xf9\x18\x78\xfc\x27\xd7\x91\x95\xeb\xa7\x6a\x9c\xdc\x30\xb1\x0f\x87\xf6\xd4\x00\xa8\xf6\xee\x45\x65\x66\x15\x94\xf2\xe9\x6d\xc0\x60\x36\x3f\xbb\xcb\xe8\x25\xd8\xa1\xf2\xdb\xf3\xac\xf7\x65\x92\x60\xb5\xbb\x05\x27\x5c\x57\x78\x8b\xf9\x13\xb5\x39\x50\x8a\x4a\x42\xbf\x32\xaf\xf2\xbe\x48\xaa\xd8\x4f\xef\x1b\x7f\xc8\x25\xc6\x87\xaf\xd5\xb3\xfa\x60\x07\x95\xd4\x25\x63\x5b\x12\x5d\xa3\x93\x23\x2a\xa0\xc2\x29\x06\x9a\x07\xe5\x24\xed\x6a\xff\x55\x9b\x3c\xad\x6d\x1a\x7f\x03\x51\xe0\x49\x88\xd0\xf7\xd8\x11\xd5\x95\x44\xbb\xed\xbe\x6d\x72\xf3\x3d\x00\xb7\x19\x0d\x41\x54\x01\x00\x00")
+
+func templatesPython_httpx_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesPython_httpx_fullTpl,
+		"templates/python_httpx_full.tpl",
+	)
+}
+
+func templatesPython_httpx_fullTpl() (*asset, error) {
+	bytes, err := templatesPython_httpx_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/python_httpx_full.tpl", size: 340, mode: os.FileMode(420), modTime: time.Unix(1792303794, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesPython_moduleTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8c\x91\xcf\x6a\xdc\x40\x0c\xc6\xef\xf3\x14\x22\xcd\xc1\x86\x65\x1e\x60\xc1\x87\x74\x4b\xd9\x4b\x4a\x69\x73\x2b\x65\x10\xb6\x76\x33\xc4\xd6\xb8\x1a\xb9\x10\x06\xbf\x7b\x99\xf1\x9f\x25\x6d\x0e\xf5\xc9\x92\x3e\xfd\x46\xfa\x94\x12\x08\xf2\x95\xe0\xfe\x85\x5e\x0f\x70\xef\xe0\xd8\x80\x7d\x0c\xdd\xd4\x53\x84\x79\xf6\xc3\x18\x44\x21\xa5\x22\x80\x79\x36\x29\x11\x77\xf3\x9c\x12\xd8\x87\xae\xf3\xea\x03\x63\xff\x89\xda\x1e\x05\x73\x00\xa5\xb6\x40\xed\xe7\x89\xdb\x9c\xcc\x28\x63\x3e\x40\xee\x7a\xf2\xda\x53\x8e\x3b\xba\x94\xc4\x17\x1c\x72\x5c\x15\xa4\x5c\xa7\x81\x58\x73\x43\x7d\x34\x00\x50\x24\x5f\x85\x46\x14\xfa\x18\xba\xd7\x85\xbf\x65\xce\x84\x1d\x49\xa6\xfd\x25\x3d\x85\xf0\xe2\x69\x15\x7f\xa3\x5f\x13\x45\xdd\x64\x42\x11\x1a\x68\x03\xb3\xbd\x92\x0a\xc5\x31\x70\xa4\xaa\xde\x19\x0f\x93\x3e\x13\xab\x6f\x51\x37\xc4\x77\xfc\x7d\x63\x0a\xe9\x24\x9c\x39\x26\x25\x20\xee\x16\x91\xbf\x80\x3d\x63\x7c\x44\xcf\x65\xdf\xbc\xe0\x80\x9e\xab\xfa\x68\x6e\x9e\x9c\x02\x33\xed\xae\x6c\x4f\xae\x26\x40\x03\xcf\xaa\xa3\x6d\x7b\x4f\xac\x36\x57\x4e\x3d\xc6\xb8\xf9\x73\x0e\x65\x8d\xda\xac\xcf\x3d\x4d\xcc\xd4\xff\xcb\xb1\x91\xd4\x69\x29\x96\xbe\x5d\x57\xbf\x99\x78\xf9\x01\x00\xb8\x04\x01\x59\x6d\xf2\x0c\x3f\xcc\xfb\x47\x84\xf5\x2b\x96\x10\x2f\xde\x1f\xcc\x1b\xd4\xcf\xe5\x6e\x37\xa7\x57\x6e\x55\xef\xf9\x51\x3c\x6b\x25\x14\x6d\x54\xd4\x29\x1e\xb2\xd2\x0a\x61\x0c\xfc\x9e\x4a\x08\xbb\xaa\xae\xff\xd7\x45\xdb\xf6\xa1\x1c\x74\x9f\xcb\xf8\x0b\x38\xc7\x38\x90\x73\xd0\x34\x70\xe7\x5c\xbe\x8c\x73\x77\xcb\xb0\xcb\x99\x76\xf9\x9f\x01\x00\xc7\x67\x12\xd1\x17\x03\x00\x00")
+
+func templatesPython_moduleTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesPython_moduleTpl,
+		"templates/python_module.tpl",
+	)
+}
+
+func templatesPython_moduleTpl() (*asset, error) {
+	bytes, err := templatesPython_moduleTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/python_module.tpl", size: 791, mode: os.FileMode(420), modTime: time.Unix(1792313367, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesPython_requests_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x44\x8e\x41\x6a\xeb\x30\x14\x45\xe7\x5a\xc5\x25\x64\x10\x43\xf0\x02\x02\x1e\x7c\x7e\xa7\x85\xd2\x2e\xe0\xf1\x88\x6e\x8a\x88\x2d\xb9\x4f\x72\x69\x11\xda\x7b\x91\x43\xe9\xf0\x5e\xce\x81\x53\x2b\x4c\xe3\x3b\x71\xbc\xf3\xfb\x8c\xa3\xe0\x32\x61\x7c\x4e\x7e\x9b\x99\xd1\x5a\x58\xd6\x64\x05\xb5\xee\x00\x5a\x73\xb5\x32\xfa\xd6\x6a\xc5\xf8\xcf\xfb\x50\x42\x8a\x3a\x3f\xf1\x3a\xab\x69\x1f\x9d\xf1\xbc\x61\xd1\x10\x4f\xc3\xc5\x01\xe8\xfa\xf8\x62\x5c\xd5\x88\xd6\x8c\x19\xd3\xfe\xbd\xf2\x63\x63\x2e\x5d\xf9\xc5\xde\xf4\x93\xff\x53\xba\x87\x4e\xae\x16\x62\x39\x19\xf3\x98\x8b\x96\x2d\xcb\x35\x79\x9e\xd1\x0f\xa3\xe6\x14\x87\xdd\xfb\xc3\x0a\xbf\xca\xe0\x5c\xb8\x41\x24\xea\x42\x11\x4c\x13\x0e\x22\xbd\x46\xe4\xf0\xc8\x79\xa4\xb9\x9f\x01\x00\x31\x1c\xda\x8c\xfc\x00\x00\x00")
+
+func templatesPython_requests_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesPython_requests_fullTpl,
+		"templates/python_requests_full.tpl",
+	)
+}
+
+func templatesPython_requests_fullTpl() (*asset, error) {
+	bytes, err := templatesPython_requests_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/python_requests_full.tpl", size: 252, mode: os.FileMode(420), modTime: time.Unix(1792303794, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesPython_requests_moduleTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6c\x8f\xc1\x6a\xf3\x30\x10\x84\xef\x7a\x8a\x21\x7f\x0e\x36\x04\x3f\x40\xc0\x87\x9f\x42\xe9\x25\xa5\x34\xbd\x95\x22\x44\xbc\x0e\x22\xf1\x2a\x5d\xc9\xa5\x45\xe8\xdd\x8b\x24\x27\xbd\x44\x27\xed\xee\xb7\x3b\x33\x31\x42\x0c\x1f\x09\xeb\x13\xfd\x6c\xb0\xd6\xd8\xf6\xe8\x76\x6e\x98\xcf\xe4\x91\x92\x9d\x2e\x4e\x02\x62\x2c\x00\x52\x52\x31\x12\x0f\x29\xdd\x16\xbb\xc7\x99\x0f\xc1\x3a\xce\xb8\x52\xff\x32\xdb\xbd\xd9\x70\xa6\x5c\x0f\x34\x96\xc6\xb3\x99\x72\xdd\xe4\xff\x7f\x39\xce\x13\x71\xc8\x0b\xed\x56\x01\x28\xc8\x8b\xd0\xc5\x48\xa6\x84\x3c\xfa\xd2\x7b\xa5\xcf\x99\x7c\xc8\x97\xae\xd8\xde\x7c\xd1\x83\x73\x27\x5b\xc9\x30\x0b\x43\xc8\xab\x18\x41\x3c\xa0\x38\xb3\x23\xba\x27\xe3\x77\xc6\x72\x31\x95\x5d\x4c\xc6\x72\xb3\xc8\x79\xf2\xde\x3a\x46\x0f\xa9\x02\xbe\xdb\xd7\x56\xd3\x16\x60\x74\x72\x1d\xc1\x32\xde\xd5\xfd\xb8\x58\x5e\xf1\x45\x3c\x90\x20\xa5\xcd\x9f\x15\x00\xf8\xa8\x92\xf9\xd5\x5c\xcb\xdd\x66\x31\xd1\xde\xc6\x17\xb1\x1c\x1a\x21\xdf\xf9\x60\xc2\xec\xf5\xc1\x0d\xb4\xc9\x5b\x9d\x90\xf1\xf7\xd1\x40\xdf\xa1\x55\xca\x8e\xd0\x9a\xcd\x44\x5a\xa3\xef\xb1\xd2\x3a\xe7\xd5\x7a\x55\xd5\x6b\xf8\x9b\xaf\xdf\x01\x00\x79\xd9\x41\xb9\xf6\x01\x00\x00")
+
+func templatesPython_requests_moduleTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesPython_requests_moduleTpl,
+		"templates/python_requests_module.tpl",
+	)
+}
+
+func templatesPython_requests_moduleTpl() (*asset, error) {
+	bytes, err := templatesPython_requests_moduleTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/python_requests_module.tpl", size: 502, mode: os.FileMode(420), modTime: time.Unix(1792307792, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesRuby_faraday_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4c\x8e\x41\x4a\x03\x41\x10\x45\xf7\x39\x45\x11\x37\xba\x99\x1b\x64\x31\x44\xdc\x09\xa2\x07\x90\x72\xfa\x63\x1a\xc7\xea\xb6\xaa\xda\x10\x86\xba\xbb\xf4\xc4\x84\x2c\xab\xde\xff\x9f\xb7\x2c\x34\xbc\xe2\xa7\x65\x85\x51\x44\x3f\xc7\x94\xb2\xe7\x22\x3c\x3f\x62\x9a\x59\xb9\x1f\x14\xb1\xe9\xf0\x45\x51\x59\x41\x11\x53\x11\xa1\x1d\x3d\xb1\x72\xe2\xd3\x20\x38\xde\xf7\xc0\xbe\x88\x60\xea\x95\x51\x3f\xdb\x37\xc4\xfb\xee\x43\x47\xcf\x39\xa5\x19\xc7\x73\xfd\x76\xad\x0b\xc0\x9c\x22\x14\x56\x8b\x18\x68\x47\x17\xb3\x33\x58\xe3\x63\xf3\x03\xc4\xf3\xc4\x8e\x7f\xd9\x37\xfe\xc5\xbe\x94\xaf\xbc\xea\xd7\xe6\x46\xdb\xbb\xe5\x32\x33\x98\xb3\x37\x0b\xba\x79\x29\xd8\x8a\xbc\xd7\x83\xb2\x21\xb6\x9b\xb5\x73\xa5\x1f\x25\x9d\xfe\x06\x00\x61\xc5\xcf\xc9\x14\x01\x00\x00")
+
+func templatesRuby_faraday_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesRuby_faraday_fullTpl,
+		"templates/ruby_faraday_full.tpl",
+	)
+}
+
+func templatesRuby_faraday_fullTpl() (*asset, error) {
+	bytes, err := templatesRuby_faraday_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/ruby_faraday_full.tpl", size: 276, mode: os.FileMode(420), modTime: time.Unix(1792305440, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesRuby_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4c\x8f\xc1\x6e\xc2\x30\x10\x44\xef\xfe\x8a\x11\xbd\x90\x4b\x3e\x00\x29\x87\xa8\x3d\xb4\x87\x22\x14\xe0\x03\xdc\x78\x0b\x56\x43\x1c\xd6\xeb\x56\x28\xec\xbf\x57\xb6\xd2\x8a\x93\x77\x9f\x47\x33\x3b\xf3\x8c\xba\xa3\x6b\xf2\x4c\x11\xaa\x79\x6d\x9d\xf3\xe2\xc3\x68\x87\x17\xea\x07\xcb\x36\x2f\x50\x35\x89\x3d\x1a\x1c\xbb\xb7\x75\x96\x1d\x79\x80\x6a\x65\xf2\xbc\x63\x9a\x2c\x13\x54\x99\xae\x89\xa2\xa0\x41\xe6\x5b\xfa\xe9\x16\xa0\x5a\x94\xef\xc1\xf9\xcf\xdb\x03\xdc\x92\x6c\x36\xaf\x87\xc3\xae\x8e\x62\x59\x8a\xf5\x3e\x4f\x2d\x9f\xd2\x85\x46\xc9\x67\x55\x70\x01\xf7\xb3\xc8\x74\x37\x00\x53\x9c\xc2\x18\x09\x0d\x32\xaa\x97\xcc\xf5\xf2\x56\x06\x25\xbc\x4d\x72\xa6\x51\x7c\x6f\x85\x96\x6a\x7b\xfb\x4d\xcf\x21\x7c\xf9\x52\x76\x4a\x12\xb1\x7a\x9a\xff\xfc\xea\x3e\x38\x52\x3c\x80\x0b\xc5\x68\x4f\xa4\x2b\x03\x14\xf5\xff\xcf\x47\x70\x37\x43\xa3\xfb\x1d\x00\x2c\x17\xae\x4d\x40\x01\x00\x00")
+
+func templatesRuby_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesRuby_fullTpl,
+		"templates/ruby_full.tpl",
+	)
+}
+
+func templatesRuby_fullTpl() (*asset, error) {
+	bytes, err := templatesRuby_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/ruby_full.tpl", size: 320, mode: os.FileMode(420), modTime: time.Unix(1792305440, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesRuby_moduleTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6c\x50\xc1\xae\xda\x30\x10\xbc\xfb\x2b\x46\xef\xf5\x00\x52\xe5\x0f\x40\xca\x01\xb5\x50\x90\x1a\x8a\x02\x9c\xaa\x2a\x72\xe3\x0d\x58\x0d\x76\xb0\x9d\x56\x28\xf8\xdf\x2b\x3b\xa4\x20\x95\x43\x94\xdd\xd9\x99\x59\xcf\xf6\x3d\x78\x41\x97\x4e\x59\x72\x08\x21\xb6\x73\x29\x95\x57\x46\x8b\xe6\x33\x55\x8d\xb0\x22\x36\xc3\xcc\x0a\x7d\x24\xf0\x65\xa7\xab\x08\x46\x05\x7b\x47\x14\xed\x95\x6f\x28\xb6\x92\xea\x04\x6c\xc4\x99\x46\x43\x7b\xec\xce\xa4\x7d\xa2\x03\x9d\x55\xc8\x70\x28\xd6\x93\x38\x3c\xd8\x06\x21\x4c\x19\x92\x6a\x6b\xa9\x15\x36\x0a\x2d\x5d\x3a\x72\x1e\xd9\xe0\x46\x7f\x8a\x3b\x90\x3c\x22\x96\x1b\xa9\xea\xeb\x03\xde\x90\x9f\xcd\x56\xfb\xfd\x96\x3b\x2f\xac\x4f\xee\xbb\x58\x3d\xef\x9f\x42\x1a\xdc\x4e\xde\xb7\x37\x06\x00\x96\x5c\x6b\xb4\x23\x64\x88\x20\xbf\xaf\x9d\xdc\xff\xd3\x44\x4a\x21\x3a\x7f\x22\xed\x55\x25\xfc\x98\x6b\x27\x7e\xd3\x27\x63\x7e\xa9\x74\xba\xd1\x89\x01\xa4\x25\x8b\x5f\xdf\xc7\x72\x60\xab\x1a\x7c\x25\x5c\x2e\x54\xbc\x25\x53\x35\xca\x72\xb9\xfe\xba\x28\x4b\x64\x19\x3e\x6c\x8b\x6f\x5f\x8a\x79\x5e\x6e\xe6\xf9\x82\x01\xdf\xd9\xeb\x6b\x8f\xaf\xd9\x91\x96\x64\x11\xc2\xc7\xc7\x12\xe0\x07\x27\x51\x9d\x52\x42\x97\x08\xff\x65\x1c\x60\x5e\x89\xa6\x49\xa3\xb6\xf3\x0e\x6f\xef\xfd\x48\xe1\x95\x91\x14\xf0\x04\x9c\xc9\x39\x71\xa4\xf0\xf6\xe0\xff\x9b\xfd\x34\xf2\xfa\x2a\xee\xdf\x01\x00\x80\x29\xff\x76\x55\x02\x00\x00")
+
+func templatesRuby_moduleTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesRuby_moduleTpl,
+		"templates/ruby_module.tpl",
+	)
+}
+
+func templatesRuby_moduleTpl() (*asset, error) {
+	bytes, err := templatesRuby_moduleTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/ruby_module.tpl", size: 597, mode: os.FileMode(420), modTime: time.Unix(1792313668, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesRust_async_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6c\x8f\x4d\x4f\x03\x21\x10\x86\xef\xfc\x8a\xb1\x5e\x20\xa9\xbb\x77\xfa\x15\xdb\x7a\x34\x9a\x7a\x6c\x7a\xc0\x65\x54\x52\x0a\x2d\x0c\xb6\xcd\x86\xff\x6e\x60\x6b\xe2\x41\x2e\x64\x9e\x3c\xef\xcb\xd0\xb6\xb0\x52\xe1\xd3\x37\xe4\x0f\x96\xb5\x2d\x6c\x35\x1e\xd1\x69\x74\x9d\xc1\xb8\x63\x7d\x0f\xcd\xfa\x0f\x81\x9c\x59\x8a\x08\x91\xb4\x94\x18\x82\x0f\x52\x3e\x95\x6b\x52\xd5\x47\xad\x0d\x19\xef\x94\x5d\x63\x67\x55\x50\x65\x28\x99\xfb\x2d\xf9\xbd\xf1\x52\x1e\x94\x71\x3b\xa6\xe2\xd5\x75\xf0\xe1\xa0\x8c\x5c\xc0\xc3\x1c\x36\x18\x93\xa5\x29\x17\x63\x58\xfa\xcb\x54\x5f\x1d\xd4\xe2\xf9\x1c\x7a\x06\x00\x50\xfa\x5f\x03\x1e\x55\x40\xc8\xd9\x22\x41\x67\x0d\x3a\x82\x19\x04\x3c\x9d\x31\x92\x94\xab\x4a\xa4\x7c\x4f\xc6\x6a\x0c\x5c\x94\xd0\x00\x97\x03\x2a\xcb\xc0\xed\x34\x55\xe3\x62\x31\xa9\xa8\x54\x16\xff\x39\x11\xe4\x1c\x30\xc2\xac\xce\x1b\x3c\x25\x8c\xf4\x9b\xac\xff\x4c\xf4\x85\x8e\x4c\xa7\xa8\x2c\x53\xd0\x9b\xfa\xc6\x95\xf7\x7b\x53\xc0\x31\x18\x47\xd6\xdd\xf1\x51\x9f\x47\x63\x08\x18\x9b\x48\x8a\x52\xe4\x42\x0c\x8f\xfd\x63\x10\x5e\x88\x8b\x46\x9d\x95\xa1\xc5\x4d\x7b\xd9\x73\x2e\x04\xcb\xec\x67\x00\xde\xf4\xc5\xa1\xa9\x01\x00\x00")
+
+func templatesRust_async_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesRust_async_fullTpl,
+		"templates/rust_async_full.tpl",
+	)
+}
+
+func templatesRust_async_fullTpl() (*asset, error) {
+	bytes, err := templatesRust_async_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/rust_async_full.tpl", size: 425, mode: os.FileMode(420), modTime: time.Unix(1792304143, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesRust_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6c\x8f\x4f\x4f\x33\x21\x10\xc6\xef\xfb\x29\xe6\xed\x09\x92\xbe\xbb\x77\xfa\x2f\xb6\xf5\x68\x34\xf5\x68\x3c\xd0\x65\xac\xa4\x14\xda\x61\xd0\x9a\x0d\xdf\xdd\xc0\xd6\xc4\x83\x7b\xd9\xcc\x8f\xdf\xf3\x30\x74\x1d\x6c\x34\x1d\x42\xcb\xe1\xe4\x9a\xae\x83\x17\x83\x67\xf4\x06\x7d\x6f\x31\xbe\x36\xc3\x00\xed\xf6\x17\x81\x9c\x9b\x14\x11\x22\x1b\xa5\x90\x28\x90\x52\xf7\xe5\x37\xab\xea\x9d\x31\x96\x6d\xf0\xda\x6d\xb1\x77\x9a\x74\x19\x4a\xe6\xcd\xc3\x49\x5b\x2f\x24\xfc\x5f\xc2\x0e\x63\x72\x3c\x17\x72\x0a\xeb\x70\x9d\x9b\x2f\x0f\xb5\x63\xb9\x84\xa1\x01\x00\x28\x55\x4f\x84\x67\x4d\x08\x39\x3b\x64\xe8\x9d\x45\xcf\xb0\x00\xc2\xcb\x27\x46\x56\x6a\xef\x42\x7f\xb4\xfe\xa0\xd4\xa6\x9e\x29\xb5\x4f\xd6\x19\x24\x21\x4b\x7c\x84\xeb\x11\x95\x0d\xe0\xf6\xb5\x55\x13\x72\x35\xab\xa8\x94\x17\xff\x21\x31\xe4\x4c\x18\x61\x51\xe7\x1d\x5e\x12\x46\xfe\x49\xd6\xc7\x25\x7e\x47\xcf\xb6\xd7\x5c\xd6\x2a\xe8\x59\x7f\xe0\x26\x84\xa3\x2d\xe0\x4c\xd6\xb3\xf3\xff\xc4\x64\xc8\x93\x29\x10\xc6\x36\xb2\xe6\x14\x85\x94\xe3\x65\x7f\x18\x8c\x57\x16\x72\x75\x13\x1e\x8f\x42\x48\xd9\xe4\xe6\x7b\x00\x64\xaf\xef\x41\x98\x01\x00\x00")
+
+func templatesRust_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesRust_fullTpl,
+		"templates/rust_full.tpl",
+	)
+}
+
+func templatesRust_fullTpl() (*asset, error) {
+	bytes, err := templatesRust_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/rust_full.tpl", size: 408, mode: os.FileMode(420), modTime: time.Unix(1792304143, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesSwift_async_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x64\x90\x4d\x6b\xdc\x30\x10\x86\xef\xfa\x15\x6f\x37\x17\x0b\x82\x73\x2d\x86\x3d\x94\x84\x25\x85\xb6\x84\xfd\xe8\x29\x97\x61\x35\x76\xc5\x2a\x92\x3b\x1a\x67\x59\x16\xfd\xf7\xe2\x8f\x36\x6d\x73\xf4\xbc\xcf\x33\xef\x58\x77\x77\x78\x21\x1f\xeb\x7c\xf6\xad\x1a\xff\xd2\x27\x51\x6c\xd2\x10\x1d\xa9\x4f\xd1\xdc\xf8\x16\x47\x8a\x9f\xa7\xa0\x7a\x0b\xbe\xb1\x9e\x93\x9c\x7c\xec\xec\x7b\xeb\x2d\x34\x37\x1c\x9d\x6f\xcd\xf5\x8a\xfa\x93\x73\x7e\x4c\x29\x3c\xf0\x31\x90\x4c\x28\x4a\x19\xb3\x07\x0e\xdc\x91\x32\x4a\x99\xd8\x27\xe1\x9e\x84\x97\xf4\x3b\x09\x4a\x81\xf0\xcf\x81\xb3\x62\x8d\xc3\xf6\xcb\x76\xfe\xa8\x06\x09\x0d\x46\xe8\x20\x01\xa5\xd8\x49\xff\x9a\x9c\x6f\x2f\x0b\xf2\xdf\xce\x1d\xe7\x3c\x17\x07\x56\x54\x8e\x94\x6e\x21\x9c\xfb\x14\x33\x5b\xac\xa1\x72\x01\x9d\xc9\x2b\xf2\x8c\xd6\x23\x53\xb5\x49\x9a\xdf\x27\x58\x33\xba\x3f\x54\xfb\xed\x22\x62\xfd\x67\x07\x28\x7f\xc0\xe3\x7e\xff\x34\x5d\x39\xcf\x4c\x2f\x3e\x6a\xb5\xda\x29\xe9\x90\x1b\x3c\x57\x7f\xcb\x75\x9e\xc6\xf7\xc9\xb1\x5d\x59\xd3\x26\x41\x75\xe2\xcb\x2d\x5e\x29\x0c\x6c\xe1\xe3\x3f\x5d\x35\x85\xf0\xc8\xe4\x58\x36\x9e\x83\xcb\xb8\x1a\x00\x58\x2a\x9e\x47\xd5\x8e\x15\xb3\xbd\xb2\xa6\x2c\xf5\x3b\x15\x1f\xbb\xca\xf1\x31\x39\x1f\xbb\x06\xf3\xcf\x53\x6e\x70\xd8\x6f\x3e\xd6\x99\x43\x6b\xe7\x17\xdc\xd1\x2b\xdf\xa7\x74\xf2\x9c\x51\xca\xaf\x01\x00\xa9\xb9\xa7\x50\x26\x02\x00\x00")
+
+func templatesSwift_async_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesSwift_async_fullTpl,
+		"templates/swift_async_full.tpl",
+	)
+}
+
+func templatesSwift_async_fullTpl() (*asset, error) {
+	bytes, err := templatesSwift_async_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/swift_async_full.tpl", size: 550, mode: os.FileMode(420), modTime: time.Unix(1792304482, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesSwift_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x64\x52\xc1\x6a\x1b\x31\x10\xbd\xef\x57\x4c\x9d\x8b\x04\x61\xd3\x63\x59\xd8\x43\x49\x08\x29\xb4\x25\xc4\x4e\x4f\xb9\x0c\xd6\xac\x3d\x58\x96\xb6\xa3\xd9\xb8\xc1\xe8\xdf\x8b\x76\xd7\x71\x5a\xfb\x62\xf6\xbd\x37\xef\x8d\x9e\x74\x73\x03\x7b\xe4\x50\xa7\x03\x77\x5a\xf1\xbe\x8f\xa2\x70\x1f\x87\xe0\x50\x39\x86\xea\x8a\x3b\x58\x63\xf8\x36\x12\xe6\x4c\xfc\x24\x3d\x44\xd9\x71\xd8\xd8\xcb\xa9\x33\x59\x5d\x51\x70\xdc\x55\xc7\x23\xd4\x5f\x9d\xe3\xc2\xa2\xbf\xa3\xb5\x47\x19\xa5\x90\x73\xe1\xee\xc8\xd3\x06\x95\x20\xe7\x51\xfb\x28\xd4\xa3\xd0\xcc\xfe\x42\x81\x9c\x41\xe8\xf7\x40\x49\xa1\x85\xe7\xa7\xef\x4f\xd3\x87\x19\xc4\x37\x50\x44\xcf\xe2\x21\x67\x3b\x8e\xff\x88\x8e\xbb\xb7\x59\xf2\x9f\xe7\x92\x52\x9a\x82\x3d\x29\x24\xda\x63\xbf\x8d\x42\xd0\xc2\x1d\xa7\x1e\x75\xbd\x5d\x9e\x30\xf3\x8a\x7e\xa0\x06\x3e\xdb\xaa\x68\x15\xd3\x0e\x5a\x48\x93\x41\xed\x50\x71\x85\x69\x67\x0e\xac\xdb\xe6\xb4\x9d\x85\x23\x14\xe6\x1a\x84\x52\x1f\x43\xa2\x6b\x20\x91\x28\xc0\xa1\x02\x00\x70\xd4\x91\xc0\xf1\x9c\x5c\x27\xde\x04\xf4\xc6\x42\x1e\x05\xdc\x41\x49\x9b\x86\xda\xf9\xff\x38\x52\xe5\xd7\x0b\x07\x35\x23\x6a\xdf\x41\x21\x1d\x64\xf2\x9f\x4c\x8a\xc3\x56\xb5\x7f\x9a\x97\x80\xf6\x7d\x1f\xc0\xf4\x09\x1e\x56\xab\xc7\xb1\xc5\x09\xab\xce\xce\x8b\xa5\xa2\x0e\xa9\x81\x17\xf3\xd1\xa0\x4e\x23\x7c\x1b\x1d\xd9\xc5\x14\xdc\x45\x01\xb3\xa3\xb7\x6b\x18\x7b\xb2\xc0\xe1\x9f\xcc\x1a\xbd\x7f\x20\x74\x24\xf7\x4c\xde\xa5\x8b\x43\x2c\x5e\xca\xb8\x2d\x51\x93\xc3\xc2\x7e\x38\xc2\xdc\x43\x29\x13\xda\xb9\xd3\x02\x28\xfd\x29\x6f\x60\xa9\xc2\x61\x63\x0a\xde\xcc\x2c\x85\x75\x74\x1c\x36\x0d\xd4\x83\x76\x5f\xec\x45\x60\x19\x3d\x45\xe4\xaa\xdc\x67\x2d\x94\x86\x3d\x19\x5b\x9d\xef\xe3\x80\xac\x66\x7a\x48\x4b\x7c\xa5\xdb\x18\x77\x4c\x09\x72\xfe\x3b\x00\xca\x21\xc4\x9b\x2d\x03\x00\x00")
+
+func templatesSwift_fullTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesSwift_fullTpl,
+		"templates/swift_full.tpl",
+	)
+}
+
+func templatesSwift_fullTpl() (*asset, error) {
+	bytes, err := templatesSwift_fullTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/swift_full.tpl", size: 813, mode: os.FileMode(420), modTime: time.Unix(1792304482, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesVim_script_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x54\x8f\xc1\x8e\xc2\x20\x14\x45\xf7\xfd\x0a\x26\xdd\xcc\x6c\xfa\x01\x93\xcc\x62\xd4\x18\x37\x26\x6e\xfc\x80\x67\xb9\x0a\x09\x42\x03\xaf\x31\x4a\xf8\x77\x41\xaa\xd6\xe5\x3b\xf7\xe6\xc0\x8d\x51\x74\xff\x52\x6a\xd6\xce\x92\x59\xa1\x37\xe4\xa9\x1c\x22\xa5\x92\xed\x3c\x06\xf2\x58\x38\x79\xfd\x24\x1b\x90\x84\xcf\xcc\x80\x45\xf8\xf5\x08\xe2\x4f\x5c\x70\xa0\x41\xb7\x8a\x79\x68\x4b\x77\x0b\x56\x4e\xe6\xd2\x77\xb9\xf6\xde\x4c\x8e\xa2\x5b\x3a\xcb\xb0\x3c\x91\x97\xee\xa7\x41\xaf\x5c\x35\x76\x81\x89\xc7\x30\x27\x67\x84\x40\x27\xcc\x51\x5f\x45\xcd\x68\xf3\x57\xbe\x2a\x8c\x51\x1f\xb3\x94\x42\xf5\xa6\xf4\x4e\xd5\x83\xe4\x06\xac\xac\x6f\xaf\x75\x9e\xae\x6f\xcf\x91\xf7\x00\x00\x00\xff\xff\x5e\x8d\x44\xdb\x13\x01\x00\x00")
+var _templatesVim_script_fullTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x54\x90\x41\x4a\x04\x31\x10\x45\xf7\x7d\x8a\x92\xd9\xe8\xa6\x0f\x20\xb8\xd0\x11\x99\x8d\x20\x88\x07\x28\x3b\x5f\x13\x8c\x49\x93\xaa\x56\x34\xd4\xdd\x25\x93\x56\x7b\x96\xf5\xfe\xe7\xbf\x90\x5a\x69\xbc\x76\x2e\x68\xc8\x89\xe3\x2d\xa6\xc8\x85\xdb\x41\x66\x2d\x7b\x28\x98\xb9\xe0\x26\xbb\xaf\x53\x72\x00\x3b\x14\x32\x8b\x50\x92\xcb\x02\xa1\x2b\xfa\xc4\x33\xcf\x61\xe7\x55\xe7\x5d\xeb\xde\x43\x7d\x76\x64\x76\xde\xae\xa7\x12\xd7\x8d\x36\xb7\xcf\x49\x91\x74\x25\x7f\x73\x17\xc3\xf1\x49\x8b\x7a\x24\x0d\x13\x2b\xd6\xc6\x23\x7f\x60\x9f\xf3\x5b\x80\x90\x19\x26\x9f\xbb\x76\x14\x65\x5d\x64\xd8\x90\x77\x88\xf0\x2b\xb6\x68\xea\xb6\x61\x49\x11\x7a\xd6\x61\xad\xe1\x85\xc6\x03\x4b\x97\x9b\xfd\xa7\xfe\x48\xa4\x56\x24\xd7\xf5\x77\x21\x71\x0c\xdf\xbf\x3f\xf1\x33\x00\x2f\xaa\x93\x2f\x38\x01\x00\x00")
 
 func templatesVim_script_fullTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -464,12 +947,12 @@ func templatesVim_script_fullTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/vim_script_full.tpl", size: 275, mode: os.FileMode(420), modTime: time.Unix(1426557658, 0)}
+	info := bindataFileInfo{name: "templates/vim_script_full.tpl", size: 312, mode: os.FileMode(420), modTime: time.Unix(1792303078, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesXhr_external_fileTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x84\x54\x4d\x6f\xdb\x38\x10\xbd\xfb\x57\xcc\xea\x62\x19\xeb\x48\x09\xb0\x87\x45\x2c\xeb\xb0\x9b\x14\x39\x24\x48\x90\xb8\x40\x8a\xa2\x07\x5a\x1c\x49\x44\x65\x92\x25\x47\xfe\x40\xe0\xff\xde\xa1\x25\xdb\x6a\x1b\x20\xbc\x58\x9c\x79\x6f\xf0\xde\x0c\xc7\xd9\x5f\x37\x8f\xff\x2f\xbe\x3c\xdd\x42\x4d\xab\x26\x1f\x65\xc7\x1f\x14\x32\x1f\x01\x9f\x6c\x85\x24\xa0\xa8\x85\xf3\x48\xf3\xa8\xa5\xf2\xe2\xdf\xa8\x4f\x91\xa2\x06\xf3\xd7\xbb\x67\x58\xa0\xa7\x2c\xed\xee\x5d\xce\xd3\xee\xf8\x2d\xd5\x3a\x91\xce\x58\x12\xae\x42\x82\xb7\x43\x30\x9c\xa5\x71\x12\x1d\x5c\x83\x37\x8d\x92\x70\x65\xb7\xb0\x6c\x44\xf1\x7d\x76\x42\x58\x21\xa5\xd2\x15\x43\xae\x2e\xed\xf6\x1c\x5f\x32\xaa\x72\xa6\xd5\xf2\xa2\x30\x8d\x09\x35\x2c\x8a\xa2\xb6\x6d\x59\x76\xa8\x7d\x27\x23\xed\x75\x64\x69\x67\x29\x5b\x1a\xb9\xe3\x1f\xd6\x04\x4a\xce\xa3\xa0\x2b\x82\xa2\x11\xde\x77\x97\x4e\x64\x94\x87\x6f\x28\x55\x83\x50\xa3\xc3\x2c\x65\x02\xd3\x7c\xe1\x94\xa5\x7c\x34\x2a\x5b\x5d\x90\x32\x1a\x1c\xfe\x68\xd9\x7c\x1c\xa0\x93\xde\xdb\x5a\x38\xd8\xd6\x0e\xe6\xa0\x71\x03\xaf\x0f\xf7\x77\x44\xf6\xb9\x07\x4e\x66\x6f\x6f\x90\x3c\x39\xb4\xc2\xe1\x7f\xac\x06\xf6\x9d\x56\x66\x24\xc6\xa2\x8e\xa3\x00\x78\x40\xaa\x8d\xe4\x5c\x34\x85\x70\xff\xec\x1a\xbe\x4c\x81\x5c\x8b\x93\xce\xe2\xa0\xce\xa3\x0d\x62\xfc\x2f\xa5\xb4\x63\xc7\x3b\x4f\x82\x90\xc7\xa7\x2b\x64\x3d\x47\xd9\xf1\x49\x6b\x38\xaa\x84\x98\x6a\xe5\x93\x03\xe3\x25\x30\x60\x3e\x87\x7f\x86\x98\xc3\x20\x4d\xd1\xae\x50\x53\xb2\x71\x8a\x30\x8e\x32\x9b\x87\x76\x5e\x47\xf0\x37\xf4\x7c\x6f\x59\x06\x2e\x70\x4b\x1c\x8b\xb2\xd4\xe6\xd1\x64\xf6\x51\x91\xa0\xb1\xf5\xe7\x32\xdd\xfd\xbd\x02\x9d\xbd\xfd\xec\xe4\xd2\xa3\x96\x71\x68\x44\xdf\x49\xc6\xee\x07\xd3\x61\xdf\xb2\xc1\x1b\x27\xaa\xc7\x35\xba\xb3\x6b\x4c\xac\xc3\x35\xab\xb8\xc1\x52\xb4\x4d\x98\xca\xbb\x3c\x63\x87\x1c\x4f\xc6\x3e\x71\x4c\x54\xe2\xd0\xc4\x5e\xd8\x7b\xc5\x8e\xcf\xe0\xf0\x82\xe6\x0c\x91\x82\xc4\xc2\x09\xed\x4b\x74\x49\x88\xfa\xaf\x97\xdf\x3a\xdc\xc1\xb3\xd2\x1a\xdd\xdd\xe2\xe1\x3e\x4c\x89\xd3\x89\x16\x2b\xfc\x63\xce\x9f\x42\x39\x1e\x32\x6b\xdd\x28\x2d\xcd\x86\xc7\xdc\x18\x21\x07\xa3\x85\x78\xf8\x0c\x49\x54\x9c\x3b\xf5\x9c\x9f\xa0\xdb\xbd\x60\x83\x05\x19\x17\x8f\x07\x6b\x39\xee\x45\x33\x21\xe1\xa5\xbb\x0d\x7e\xee\x95\x27\x64\x59\xf1\x58\x72\x07\x0d\x77\x70\x3c\xfd\xad\xa5\x53\x28\x45\xe3\xf1\x03\xb2\xb1\x03\xa2\xb1\x67\xd2\x9e\x17\xf3\xb8\x53\x59\xda\xef\x66\xda\xfd\x09\xfd\x0c\x00\x00\xff\xff\x56\xe0\xb4\x5d\x9c\x04\x00\x00")
+var _templatesXhr_external_fileTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x84\x54\x4d\x4f\xdc\x30\x10\xbd\xe7\x57\x4c\x73\xd9\xac\x80\x04\xa4\x1e\x2a\x36\xc9\xa1\x40\xc5\x01\x04\x82\xad\x44\x55\xf5\xe0\x8d\x27\x89\x45\xd6\x36\xe3\xc9\x7e\x08\xe5\xbf\x57\xde\x64\xd9\xf4\x03\xe1\x4b\x3c\xf6\xbc\xa7\xf7\x66\x3c\x49\x3f\x5d\xde\x5d\xcc\x7f\xdc\x5f\x41\xcd\xcb\x26\x0f\xd2\xfd\x07\x85\xcc\x03\x00\x80\x74\x89\x2c\xa0\xa8\x05\x39\xe4\x2c\x6c\xb9\x3c\xf9\x12\x0e\x57\xac\xb8\xc1\xfc\xe9\xfa\x01\xe6\xe8\x38\x4d\xfa\xb8\xbf\x73\xbc\xdd\xef\xa5\x5a\xc5\x92\x8c\x65\x41\x15\x32\xbc\xee\x0e\xfd\x5a\x18\x92\x48\x70\x0e\xce\x34\x4a\xc2\x99\xdd\xc0\xa2\x11\xc5\xf3\xec\x2d\xc3\x0a\x29\x95\xae\xe0\x1c\xce\x4e\xed\xe6\x70\xbe\x10\xc5\x73\x45\xa6\xd5\xf2\xa4\x30\x8d\xf1\x1c\x16\x45\x51\xdb\xb6\x2c\xfb\xac\xae\x97\x91\x0c\x3a\xd2\xa4\xb7\x94\x2e\x8c\xdc\xe6\x41\x2a\xd5\x0a\x94\xcc\x42\xaf\x2b\x84\xa2\x11\xce\x65\xe1\x41\x64\x98\xfb\x3d\x94\xaa\x41\xa8\x91\x30\x4d\xa4\x5a\xe5\x41\xea\x0a\x52\x96\xf3\x20\x28\x5b\x5d\xb0\x32\x1a\x08\x5f\x5a\x74\x1c\xf9\xd4\xe9\xe0\x6d\x25\x08\x36\x35\x41\x06\x1a\xd7\xf0\x74\x7b\x73\xcd\x6c\x1f\x86\xc4\xe9\xec\xf5\x15\xe2\x7b\x42\x2b\x08\xbf\x1a\xb9\x85\xae\xd7\xba\xa9\x29\x36\x16\x75\xe4\xef\x6f\x91\x6b\x23\xa1\xeb\x8e\xc1\x87\xdf\xa9\xd9\xed\x99\x5a\xf4\xf1\x05\xa1\x44\xcd\x4a\x34\x0e\xba\x6e\xda\x5b\x1e\xf1\xde\x59\x2f\xce\xfd\x41\xad\x09\x85\xdc\x3a\x16\x8c\x45\x2d\x74\x85\x90\xc1\xde\x46\xf4\xa6\xdd\x2f\x55\x42\xc4\xb5\x72\xf1\x0e\xf1\xe8\x11\x90\x65\xf0\x79\x9c\xe3\x97\x34\x45\xbb\x44\xcd\xf1\x9a\x14\x63\x14\xa6\x36\xf7\xe5\x3d\x0f\xe1\x08\x06\xbc\xb3\x46\x3b\x9c\xe3\x86\xe1\x08\xc2\x34\xb1\x79\x38\x9d\x7d\x44\xe2\x35\xb6\xee\x40\xd3\xc7\xff\x23\xe8\xed\x75\xb3\x37\x97\x0e\xb5\xdc\x15\x70\xa8\xec\x74\x16\x74\xa3\x6e\xd5\x42\xcb\x06\x2f\x49\x54\x77\x2b\xa4\x83\x6b\x8c\x2d\xe1\x0a\x35\x5f\x62\x29\xda\x86\xa3\x77\x70\xc6\x8e\x31\x8e\x8d\xbd\x27\x63\x45\x25\x76\x45\x9c\xce\xde\x25\xdb\x3f\x8b\xdd\x8b\xca\x00\x63\x29\x58\xcc\x49\x68\x57\x22\xc5\xfe\xd4\xfd\x3c\xfd\xd5\xe7\xed\x3c\x2b\xad\x91\xae\xe7\xb7\x37\xbe\x4b\xaa\xc1\x58\x8b\x25\xfe\xd3\xe7\x6f\x9e\xae\xeb\xbc\xd6\xb5\xd2\xd2\xac\x63\xa3\x1b\x23\xe4\xa8\xb5\x10\x8d\x9f\x25\x8b\x0a\xb2\x43\xcd\x5f\x5a\xa4\xed\x23\x36\x58\xb0\xa1\x68\x32\x1a\xd3\xc9\x20\x9a\x45\x15\x0b\x29\xaf\xbc\x9f\x1b\xe5\x18\x35\x52\x34\x91\x24\x2a\xb3\x42\x9a\x1c\xff\x55\xd2\x63\x28\x45\xe3\xf0\x03\xb0\xb1\x23\xa0\xb1\x07\x50\x17\xa4\xc9\x7e\xc6\xd2\x64\x98\xd5\xa4\xff\x29\xfd\x1e\x00\x92\x55\x53\x20\xac\x04\x00\x00")
 
 func templatesXhr_external_fileTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -484,12 +967,12 @@ func templatesXhr_external_fileTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/xhr_external_file.tpl", size: 1180, mode: os.FileMode(420), modTime: time.Unix(1426433181, 0)}
+	info := bindataFileInfo{name: "templates/xhr_external_file.tpl", size: 1196, mode: os.FileMode(420), modTime: time.Unix(1792311699, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesXhr_external_filesTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8c\x54\x4d\x6f\xeb\x36\x10\xbc\xfb\x57\xec\x13\x1e\x60\x19\xf1\x93\xf3\x80\x1e\x0a\x5b\xd2\xa1\x4d\x8a\x1c\x92\x26\x48\x5c\x20\x45\x60\x14\xb4\xb8\x92\x88\xc8\x24\x4b\x52\xfe\x80\xa1\xff\xde\xa5\x24\xc7\x4a\x10\x20\xd5\xc5\xa6\x38\x3b\x9c\x99\x5d\x31\xfe\x76\x75\xff\xfb\xf2\xef\x87\x6b\x28\xdd\xa6\x4a\x47\xf1\xe9\x07\x19\x4f\x47\x40\x4f\xbc\x41\xc7\x20\x2b\x99\xb1\xe8\x92\xa0\x76\xf9\x8f\x5f\x83\x7e\xcb\x09\x57\x61\xfa\x7c\xf3\x08\x4b\xb4\x2e\x9e\x75\xeb\x6e\xcf\xba\xc3\xe9\x3f\x17\xdb\x88\x1b\xa5\x1d\x33\x05\x3a\x38\xb6\x2f\xfd\xb3\x56\x86\xa3\x81\x39\x58\x55\x09\x0e\x3f\xf5\x1e\xd6\x15\xcb\x5e\x17\x6f\x08\xcd\x38\x17\xb2\x20\xc8\xcf\x4b\xbd\x3f\xbf\x5f\x13\xaa\x30\xaa\x96\xfc\x47\xa6\x2a\xe5\x39\x34\xb2\xac\xd4\x75\x9e\x77\xa8\xa6\x93\x31\xeb\x75\xc4\xb3\xce\x52\xbc\x56\xfc\x90\x1e\x8f\x60\x98\x2c\x10\xbe\xff\x33\x85\xef\x92\x6d\x10\xe6\x09\x44\x7f\x88\x0a\xff\xa4\x85\x85\xa6\x19\xc5\x24\x1b\x04\x4f\x02\x2f\x3d\x80\xac\x62\xd6\x76\x8b\xce\x47\xd0\x6e\x12\x53\x57\xdf\x34\x41\x3a\x58\xcc\xc1\x23\x21\x27\x46\x28\xd1\x60\x3c\x23\x3a\x02\xa0\xe4\x9e\xdb\x66\x46\x68\x97\x8e\x46\x5b\x66\x5a\x90\x85\x04\x8e\xcd\x62\x34\xca\x6b\x99\x39\xa1\x24\x18\xfc\xb7\xa6\x54\xc3\x49\x1f\x98\x47\x0a\x7e\x2b\xac\x23\xe8\xcb\xff\x70\xf0\x4e\xdb\x14\xfa\xb3\x57\x5d\x3c\x39\x65\x16\xb6\x94\xc4\x76\xb9\xa0\x9f\xb8\x67\x8f\x2a\x94\x85\x2b\xe9\xd5\xc5\xc5\x64\xd0\x2c\x91\x43\xf8\xad\x95\xfa\xd2\x01\x5f\xc4\x6a\x35\x04\xf8\xc7\xa0\xab\x8d\x3c\xf7\xa9\x19\xf4\xc2\x9f\xb6\x2f\x0d\x9d\x27\x71\x07\xcf\x77\xb7\x37\xce\xe9\xc7\x93\xcb\x05\xa9\x8d\x1e\x0c\x6a\x66\xf0\x37\xea\x91\x6f\x81\xaf\xa2\x8a\x48\x69\x94\xa1\xb7\x13\xdd\xa1\x2b\x15\x3f\x19\x82\xe8\x2f\x53\xd1\x62\x0a\xce\xd4\x38\xe9\x8e\x1d\xf0\xdc\x6b\x9f\xa4\x7d\x47\x25\x0d\xcd\xc1\xc1\x3a\xe6\x90\x86\xda\x27\x98\xc0\x29\xf3\x10\x3f\x1a\x76\xa5\xb0\x51\x5b\xf1\xe4\x2b\x20\x49\xe0\x97\x8f\x9e\xb9\xca\xea\x0d\x4a\x17\xed\x8c\x70\x18\x06\xb1\x4e\xfd\x90\xcd\x03\xb8\x80\xbe\xde\x6a\x92\x81\x4b\xdc\x3b\x7a\x17\xc4\x33\x9d\x06\x93\xc5\x57\x24\x5e\x63\x6d\xcf\x34\xdd\xfa\x33\x82\x3e\xe5\xc5\x9b\x4b\x4b\xad\x0e\x7d\x10\x7d\x92\x84\x6d\x06\xa3\x45\xbe\x79\x85\x57\x86\x15\xf7\x5b\x34\x67\xd7\x18\x69\x83\x5b\x52\x71\x85\x39\xab\x2b\xdf\x95\x4f\xeb\x94\xf6\x56\x42\xc7\x8a\x29\x0c\x8a\xad\x53\xfa\x81\x36\x59\xc1\xda\x34\x7b\x85\x9f\xb1\x9e\xe6\xa1\xfd\x3e\x12\x82\x70\xe6\xd8\x92\x26\xda\xe6\x68\xa2\x6e\xca\x2e\xfb\x51\x6d\xcd\x0b\x29\xd1\xdc\x2c\xef\x6e\x7d\xbb\x68\x3b\xf2\x83\xdd\x8f\x72\x8b\x7e\xc5\xc3\xaa\xdf\x6b\x45\xef\x84\xe4\x6a\x47\xfd\xae\x14\xe3\x83\x1e\xc3\xbb\x0f\x8a\x2c\xd0\xde\x5b\xf8\x34\x8b\xe6\xf0\x84\x15\x66\x4e\x99\x70\x3c\xb8\xb5\xc6\xbd\x68\x2a\x88\xe8\x4e\xba\xf6\x7e\xfc\x47\x80\x24\x2b\x1c\x73\x8a\x52\x51\x94\xe3\xe9\x87\x6c\xa7\x90\xb3\xca\xe2\x17\xc5\x4a\x0f\x0a\x95\x3e\x17\xd1\x4d\x31\x3b\x5d\x15\xf1\xac\xbd\xba\xfc\x4d\xd6\xde\xd1\xff\x05\x00\x00\xff\xff\x6a\xf1\x88\x57\xbb\x05\x00\x00")
+var _templatesXhr_external_filesTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8c\x54\x4d\x6f\xdb\x46\x10\xbd\xf3\x57\x4c\x88\x00\xa6\x60\x85\x72\x80\x1e\x0a\xf1\xe3\xd0\x38\x85\x0f\x76\x6d\x24\x2a\x90\xc2\x30\x8a\x35\x77\x48\x2e\x4c\xed\x6e\x66\x87\xb2\x04\x82\xff\xbd\x58\x92\x8a\x68\xa3\x81\xa3\x8b\xb8\x9c\x79\x8f\xf3\xde\xcc\x4e\xfa\xee\xf2\xf6\xd3\xe6\x9f\xbb\xcf\x50\xf3\xb6\xc9\x83\xf4\xf8\x87\x42\xe6\x01\x00\x40\xba\x45\x16\x50\xd4\x82\x1c\x72\x16\xb6\x5c\x7e\xf8\x3d\x9c\x42\xac\xb8\xc1\xfc\xdb\xd5\x17\xd8\xa0\xe3\x74\x35\x9e\xc7\x98\xe3\xc3\xf1\x59\xaa\x5d\x2c\xc9\x58\x16\x54\x21\x43\x37\xbc\xf4\xbf\x47\x43\x12\x09\xd6\xe0\x4c\xa3\x24\x7c\xb4\x7b\x78\x6c\x44\xf1\x94\xfc\xc8\xb0\x42\x4a\xa5\x2b\x58\xc3\xc7\x0b\xbb\x3f\xbd\x7f\x14\xc5\x53\x45\xa6\xd5\xf2\x43\x61\x1a\xe3\x39\x2c\x8a\xa2\xb6\x6d\x59\x8e\x59\xfd\x58\xc6\x6a\xaa\x23\x5d\x8d\x92\xd2\x47\x23\x0f\x79\xd7\x01\x09\x5d\x21\xbc\xff\x77\x09\xef\xb5\xd8\x22\xac\x33\x88\xff\x54\x0d\xfe\x25\xb6\xe8\xa0\xef\x83\x54\xaa\x1d\x28\x99\x85\xbe\xf4\x10\x8a\x46\x38\x97\x85\x27\x1d\xe1\x10\xec\xba\x09\xdf\xf7\x61\x3e\x3b\xac\xc1\x67\x42\xa9\x1a\x84\x1a\x09\xd3\x95\x54\xbb\xbc\xeb\x50\x4b\xcf\xed\x0a\x52\x96\xf3\x20\xd8\x09\x1a\x92\x1c\x64\xd0\xf5\x49\x10\x94\xad\x2e\x58\x19\x0d\x84\xdf\x5b\x74\x1c\x2d\x26\xc3\x7c\xa6\x92\xd7\xca\x31\x64\x70\xff\x0b\x0a\x5e\xd4\xb6\x84\xe9\xdb\x0f\xa3\x3d\xa5\x21\x88\x06\x4a\xc8\xe0\x22\x01\x05\xe9\xc4\x1e\x37\xa8\x2b\xae\x13\x50\xe7\xe7\x8b\x59\xb3\x54\x09\xd1\xbb\xa1\xd4\xfb\x31\xf1\x5e\x3d\x3c\xcc\x13\xfc\x8f\x90\x5b\xd2\xa7\x3e\xf5\xb3\x5e\xf8\xaf\xed\x6b\x82\x0c\x34\x3e\xc3\xb7\x9b\xeb\x2b\x66\xfb\xe5\xa8\x32\xe9\x3a\x88\xef\x08\xad\x20\xfc\xc3\xc8\x03\xf4\x23\x6a\x5f\x53\x6c\x2c\xea\xc8\xc7\x6f\x90\x6b\x23\xa1\xef\xbd\x1c\x88\xff\xa6\x66\x78\x66\x6a\xd1\x9f\x3f\x11\x4a\xd4\xac\x44\xe3\xf5\x2f\xc6\x32\x66\xbc\xb7\xd6\x3b\xeb\x5e\x50\x6b\x42\x21\x0f\x8e\x05\x63\x51\x0f\x8e\x66\x70\xec\x41\x84\xaf\x0d\xe0\x5a\xb9\x78\x40\x7c\xf5\x08\xc8\x32\xf8\xed\xb5\x07\xd2\x14\xed\x16\x35\xc7\xcf\xa4\x18\xa3\x30\xb5\xb9\x1f\xba\x75\x08\xe7\x30\xe1\x9d\x35\xda\xe1\x06\xf7\x0c\xe7\x10\xa6\x2b\x9b\x87\x8b\xe4\x2d\x12\x5f\x63\xeb\x4e\x34\xe3\xf9\xff\x08\x26\xd7\x93\x1f\x2a\x1d\x6a\x39\x18\x38\x39\xbb\x48\x82\x7e\x36\x6a\xb5\xd0\xb2\xc1\x4b\x12\xd5\xed\x0e\xe9\xa4\x1a\x63\x4b\xb8\x43\xcd\x97\x58\x8a\xb6\xe1\xe8\x27\x38\x63\xbd\x94\x88\x45\xb5\x84\x19\xd8\xb1\xb1\x77\x64\xac\xa8\xc4\xe0\xe6\x22\xf9\x29\xeb\x71\x3e\x86\xfb\x92\x01\xc6\x52\xb0\xd8\x90\xd0\xae\x44\x8a\xc7\xa9\xbb\x98\x46\x77\x10\xaf\xb4\x46\xba\xda\xdc\x5c\xfb\x76\xa9\x06\x63\x3f\xe8\xd3\x68\x0f\xd9\x4f\x78\x78\x98\x62\x43\xd1\xcf\x4a\x4b\xf3\x1c\x1b\xdd\x18\x21\x67\x3d\x86\x17\x17\x8c\x45\x05\xd9\xc9\xfc\xef\x2d\xd2\xe1\x2b\x36\x58\xb0\xa1\xe8\x6c\xb6\xc5\xce\xa6\xa2\x59\x54\xb1\x90\xf2\xb3\xd7\xe3\x2f\x05\x6a\xa4\xe8\x4c\x92\xa8\xcc\x0e\xe9\x6c\xf9\xca\xdb\x25\x94\xa2\x71\xf8\x06\xd8\xd8\x19\xd0\xd8\x13\xa8\x0f\xd2\xd5\x71\x75\xa4\xab\x61\x95\xf9\xcd\x36\xec\xec\xff\x06\x00\xf1\x18\x1c\x69\xcb\x05\x00\x00")
 
 func templatesXhr_external_filesTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -504,12 +987,12 @@ func templatesXhr_external_filesTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/xhr_external_files.tpl", size: 1467, mode: os.FileMode(420), modTime: time.Unix(1426433181, 0)}
+	info := bindataFileInfo{name: "templates/xhr_external_files.tpl", size: 1483, mode: os.FileMode(420), modTime: time.Unix(1792311699, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesXhr_simpleTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x52\x4d\x6f\xa3\x40\x0c\xbd\xf3\x2b\xbc\x73\x22\xda\x2c\x5c\xf6\xb0\x5a\x06\x0e\xfd\x90\x72\x68\x94\x28\x4d\xa5\xf4\x48\xc1\x29\x48\x64\x98\xce\x98\x92\x28\xe2\xbf\xd7\xc3\x47\x9a\x4a\x55\x7d\x19\x19\xbf\xf7\xf4\xec\x87\xfc\x75\xb7\xba\xdd\x3e\xaf\xef\xa1\xa0\x43\x95\x78\x72\x7a\x30\xcd\x13\x0f\xb8\xe4\x01\x29\x85\xac\x48\x8d\x45\x8a\x45\x43\xfb\x3f\xff\xc4\x38\xa2\x92\x2a\x4c\x76\x8b\x0d\x6c\xd1\x92\x0c\x87\xde\x93\xe1\x40\x97\x2f\x75\x7e\xe2\xc7\x66\xa6\xd4\x94\x78\xfb\x46\x65\x54\xd6\x0a\x0c\xbe\x35\x4c\xf0\x67\x70\xee\x85\xde\x53\x03\xc7\xc2\x40\x0c\x0a\x5b\xd8\x2d\x1f\x16\x44\x7a\x33\x81\xa2\xf3\x19\x82\xb5\x41\x9d\x1a\xbc\x61\x45\xe8\xba\x9e\xc5\x8c\xa0\xd6\xa8\x7c\xe1\x00\x4b\xa4\xa2\xce\x79\x26\xe6\xe0\xfa\x27\x53\x71\x33\x07\x32\x0d\xce\xa2\x9e\x70\xa5\xb3\xd2\xce\x88\xfd\x22\xa5\x0c\xbb\x3e\x59\x4a\x09\x79\x5d\xf5\x8a\xec\x67\xb2\xec\xe3\xe4\xd5\x55\xb9\x07\x9f\x8a\xd2\x06\x3d\xe3\xd1\x31\x20\x8e\xe1\xef\x35\xc6\x55\x5e\x67\xcd\x01\x15\x05\xad\x29\x09\x7d\x21\x75\xe2\x4e\xf2\x5f\xc0\x6f\x18\xf9\x56\xb3\x0d\xdc\xe2\x91\xf8\x9b\x90\xa1\x4e\xc4\xe8\xf6\x07\x11\xe7\xb1\xb1\x9f\x32\x43\xff\x9d\xc0\xb0\x5e\x17\x5d\xb6\xb4\xa8\x72\xdf\x1d\x62\xbc\x24\x63\x3b\xaf\x2d\x55\x5e\xb7\x7c\x81\xaa\x4e\xf3\xab\xad\xe1\x92\xd0\x25\x31\x86\x47\x1c\xf0\x14\xa9\x0c\xc7\x8c\xc3\xe1\xc7\xf9\x08\x00\x00\xff\xff\xcc\xcd\x1a\x56\x50\x02\x00\x00")
+var _templatesXhr_simpleTpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x52\x4d\x8b\xdb\x30\x14\xbc\xeb\x57\xbc\xfa\xe4\x90\xd6\xbe\xf4\x50\x6a\xd9\x87\xa6\x85\x1c\x1a\x12\xd2\x14\xd2\xa3\x6a\xbd\xd4\x02\x47\x52\xa5\xe7\x75\x42\xd0\x7f\x5f\xe4\x8f\x6c\x16\x96\xd5\xc5\x1e\xf4\x66\x98\x79\x23\xfe\xe1\xfb\x76\x75\xf8\xb3\xfb\x01\x0d\x9d\xdb\x8a\xf1\xf9\x83\x42\x56\x0c\x00\x80\x9f\x91\x04\xd4\x8d\x70\x1e\xa9\x4c\x3a\x3a\x7d\xfa\x92\x4c\x57\xa4\xa8\xc5\xea\xb8\xde\xc3\x01\x3d\xf1\x7c\xc4\x8c\xe7\x23\x9d\xff\x35\xf2\x5a\x31\xee\x6b\xa7\x2c\x55\xec\xd4\xe9\x9a\x94\xd1\xe0\xf0\x7f\x87\x9e\xd2\x05\xdc\x06\xa1\x27\xe1\xe0\xd2\x38\x28\x41\x63\x0f\xc7\xcd\xcf\x35\x91\xdd\xcf\x43\xc5\xed\x06\xd9\xce\xa1\x15\x0e\xbf\x19\x79\x85\x10\x06\xd6\xa5\x71\x99\xb1\xa8\xd3\x78\xbf\x41\x6a\x8c\x84\x10\x3e\x42\x84\xbf\x5d\x3b\xfc\x93\xeb\x30\xe2\x95\x43\x89\x9a\x94\x68\x3d\x84\xb0\x28\x06\x81\x07\xdd\xad\x8d\xc6\xfc\x2b\x69\xed\x50\xc8\xab\x27\x41\x58\x37\x42\xff\x43\x28\x61\x8e\x90\xe2\xec\x3d\x1e\x75\x82\x94\x1a\xe5\xb3\x81\xf1\x2b\x32\xa0\x2c\xe1\xf3\xe3\x4c\x3c\xd2\xd4\xdd\x19\x35\x65\xbd\x53\x84\x69\xc2\x6d\x15\x57\xf4\x35\x81\x25\x4c\x7c\x6f\x8d\xf6\x78\xc0\x0b\xc1\x12\x12\x9e\xdb\x2a\x99\xdc\xbe\x23\x12\x3d\x76\xfe\x45\x66\xc4\x6f\x09\x8c\xf1\x42\x71\x4f\xe9\x51\xcb\x61\x81\xd3\x66\x17\x05\x0b\xac\x57\x5a\x9a\x3e\x33\xba\x35\x42\x3e\xa4\x86\x7b\x63\xf7\x06\x0b\x16\x0a\xc6\xf3\xb9\x62\x9e\x4f\x9d\xe7\xe3\x43\x7a\x1e\x00\x04\x4b\x0b\x25\x60\x02\x00\x00")
 
 func templatesXhr_simpleTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -524,7 +1007,7 @@ func templatesXhr_simpleTpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/xhr_simple.tpl", size: 592, mode: os.FileMode(420), modTime: time.Unix(1426433181, 0)}
+	info := bindataFileInfo{name: "templates/xhr_simple.tpl", size: 608, mode: os.FileMode(420), modTime: time.Unix(1792311699, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -581,8 +1064,15 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"templates/csharp_full.tpl":               templatesCsharp_fullTpl,
+	"templates/fetch_browser_full.tpl":        templatesFetch_browser_fullTpl,
+	"templates/fetch_node_full.tpl":           templatesFetch_node_fullTpl,
+	"templates/fetch_node_module.tpl":         templatesFetch_node_moduleTpl,
+	"templates/fetch_ts_full.tpl":             templatesFetch_ts_fullTpl,
+	"templates/fetch_ts_module.tpl":           templatesFetch_ts_moduleTpl,
 	"templates/go_full.tpl":                   templatesGo_fullTpl,
 	"templates/go_get_with_data_url.tpl":      templatesGo_get_with_data_urlTpl,
+	"templates/go_module.tpl":                 templatesGo_moduleTpl,
 	"templates/go_post_form.tpl":              templatesGo_post_formTpl,
 	"templates/go_post_single_file.tpl":       templatesGo_post_single_fileTpl,
 	"templates/go_post_text.tpl":              templatesGo_post_textTpl,
@@ -591,6 +1081,9 @@ var _bindata = map[string]func() (*asset, error){
 	"templates/go_simple_method.tpl":          templatesGo_simple_methodTpl,
 	"templates/go_simple_post.tpl":            templatesGo_simple_postTpl,
 	"templates/java_full.tpl":                 templatesJava_fullTpl,
+	"templates/java_httpclient_full.tpl":      templatesJava_httpclient_fullTpl,
+	"templates/java_module.tpl":               templatesJava_moduleTpl,
+	"templates/kotlin_okhttp_full.tpl":        templatesKotlin_okhttp_fullTpl,
 	"templates/nodejs_external_file.tpl":      templatesNodejs_external_fileTpl,
 	"templates/nodejs_external_files.tpl":     templatesNodejs_external_filesTpl,
 	"templates/nodejs_full.tpl":               templatesNodejs_fullTpl,
@@ -598,7 +1091,20 @@ var _bindata = map[string]func() (*asset, error){
 	"templates/objc_nsurlconnection_full.tpl": templatesObjc_nsurlconnection_fullTpl,
 	"templates/objc_nsurlsession_full.tpl":    templatesObjc_nsurlsession_fullTpl,
 	"templates/php_full.tpl":                  templatesPhp_fullTpl,
+	"templates/php_module.tpl":                templatesPhp_moduleTpl,
 	"templates/python_full.tpl":               templatesPython_fullTpl,
+	"templates/python_httpx_async_full.tpl":   templatesPython_httpx_async_fullTpl,
+	"templates/python_httpx_full.tpl":         templatesPython_httpx_fullTpl,
+	"templates/python_module.tpl":             templatesPython_moduleTpl,
+	"templates/python_requests_full.tpl":      templatesPython_requests_fullTpl,
+	"templates/python_requests_module.tpl":    templatesPython_requests_moduleTpl,
+	"templates/ruby_faraday_full.tpl":         templatesRuby_faraday_fullTpl,
+	"templates/ruby_full.tpl":                 templatesRuby_fullTpl,
+	"templates/ruby_module.tpl":               templatesRuby_moduleTpl,
+	"templates/rust_async_full.tpl":           templatesRust_async_fullTpl,
+	"templates/rust_full.tpl":                 templatesRust_fullTpl,
+	"templates/swift_async_full.tpl":          templatesSwift_async_fullTpl,
+	"templates/swift_full.tpl":                templatesSwift_fullTpl,
 	"templates/vim_script_full.tpl":           templatesVim_script_fullTpl,
 	"templates/xhr_external_file.tpl":         templatesXhr_external_fileTpl,
 	"templates/xhr_external_files.tpl":        templatesXhr_external_filesTpl,
@@ -647,8 +1153,15 @@ type bintree struct {
 
 var _bintree = &bintree{nil, map[string]*bintree{
 	"templates": &bintree{nil, map[string]*bintree{
+		"csharp_full.tpl":               &bintree{templatesCsharp_fullTpl, map[string]*bintree{}},
+		"fetch_browser_full.tpl":        &bintree{templatesFetch_browser_fullTpl, map[string]*bintree{}},
+		"fetch_node_full.tpl":           &bintree{templatesFetch_node_fullTpl, map[string]*bintree{}},
+		"fetch_node_module.tpl":         &bintree{templatesFetch_node_moduleTpl, map[string]*bintree{}},
+		"fetch_ts_full.tpl":             &bintree{templatesFetch_ts_fullTpl, map[string]*bintree{}},
+		"fetch_ts_module.tpl":           &bintree{templatesFetch_ts_moduleTpl, map[string]*bintree{}},
 		"go_full.tpl":                   &bintree{templatesGo_fullTpl, map[string]*bintree{}},
 		"go_get_with_data_url.tpl":      &bintree{templatesGo_get_with_data_urlTpl, map[string]*bintree{}},
+		"go_module.tpl":                 &bintree{templatesGo_moduleTpl, map[string]*bintree{}},
 		"go_post_form.tpl":              &bintree{templatesGo_post_formTpl, map[string]*bintree{}},
 		"go_post_single_file.tpl":       &bintree{templatesGo_post_single_fileTpl, map[string]*bintree{}},
 		"go_post_text.tpl":              &bintree{templatesGo_post_textTpl, map[string]*bintree{}},
@@ -657,6 +1170,9 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"go_simple_method.tpl":          &bintree{templatesGo_simple_methodTpl, map[string]*bintree{}},
 		"go_simple_post.tpl":            &bintree{templatesGo_simple_postTpl, map[string]*bintree{}},
 		"java_full.tpl":                 &bintree{templatesJava_fullTpl, map[string]*bintree{}},
+		"java_httpclient_full.tpl":      &bintree{templatesJava_httpclient_fullTpl, map[string]*bintree{}},
+		"java_module.tpl":               &bintree{templatesJava_moduleTpl, map[string]*bintree{}},
+		"kotlin_okhttp_full.tpl":        &bintree{templatesKotlin_okhttp_fullTpl, map[string]*bintree{}},
 		"nodejs_external_file.tpl":      &bintree{templatesNodejs_external_fileTpl, map[string]*bintree{}},
 		"nodejs_external_files.tpl":     &bintree{templatesNodejs_external_filesTpl, map[string]*bintree{}},
 		"nodejs_full.tpl":               &bintree{templatesNodejs_fullTpl, map[string]*bintree{}},
@@ -664,7 +1180,20 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"objc_nsurlconnection_full.tpl": &bintree{templatesObjc_nsurlconnection_fullTpl, map[string]*bintree{}},
 		"objc_nsurlsession_full.tpl":    &bintree{templatesObjc_nsurlsession_fullTpl, map[string]*bintree{}},
 		"php_full.tpl":                  &bintree{templatesPhp_fullTpl, map[string]*bintree{}},
+		"php_module.tpl":                &bintree{templatesPhp_moduleTpl, map[string]*bintree{}},
 		"python_full.tpl":               &bintree{templatesPython_fullTpl, map[string]*bintree{}},
+		"python_httpx_async_full.tpl":   &bintree{templatesPython_httpx_async_fullTpl, map[string]*bintree{}},
+		"python_httpx_full.tpl":         &bintree{templatesPython_httpx_fullTpl, map[string]*bintree{}},
+		"python_module.tpl":             &bintree{templatesPython_moduleTpl, map[string]*bintree{}},
+		"python_requests_full.tpl":      &bintree{templatesPython_requests_fullTpl, map[string]*bintree{}},
+		"python_requests_module.tpl":    &bintree{templatesPython_requests_moduleTpl, map[string]*bintree{}},
+		"ruby_faraday_full.tpl":         &bintree{templatesRuby_faraday_fullTpl, map[string]*bintree{}},
+		"ruby_full.tpl":                 &bintree{templatesRuby_fullTpl, map[string]*bintree{}},
+		"ruby_module.tpl":               &bintree{templatesRuby_moduleTpl, map[string]*bintree{}},
+		"rust_async_full.tpl":           &bintree{templatesRust_async_fullTpl, map[string]*bintree{}},
+		"rust_full.tpl":                 &bintree{templatesRust_fullTpl, map[string]*bintree{}},
+		"swift_async_full.tpl":          &bintree{templatesSwift_async_fullTpl, map[string]*bintree{}},
+		"swift_full.tpl":                &bintree{templatesSwift_fullTpl, map[string]*bintree{}},
 		"vim_script_full.tpl":           &bintree{templatesVim_script_fullTpl, map[string]*bintree{}},
 		"xhr_external_file.tpl":         &bintree{templatesXhr_external_fileTpl, map[string]*bintree{}},
 		"xhr_external_files.tpl":        &bintree{templatesXhr_external_filesTpl, map[string]*bintree{}},
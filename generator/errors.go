@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/syntax"
+	"strings"
+)
+
+type UnknownTargetError struct {
+	Target string
+}
+
+func (self *UnknownTargetError) Error() string {
+	return fmt.Sprintf("'%s' is not supported as a target", self.Target)
+}
+
+/*
+	Template is broken or generated code can't be formatted.
+*/
+type TemplateError struct {
+	Template string
+	Err      error
+}
+
+func (self *TemplateError) Error() string {
+	return fmt.Sprintf("template %s: %s", self.Template, self.Err)
+}
+
+/*
+	The target can't generate one source file that has functions of several requests.
+*/
+type UnsupportedModuleError struct {
+	Target string
+}
+
+func (self *UnsupportedModuleError) Error() string {
+	return fmt.Sprintf("'%s' can't generate functions of requests in one file. Generate one file for each request instead. Functions are supported by %s", self.Target, strings.Join(ModuleTargets(), ", "))
+}
+
+/*
+	The target can't generate a function instead of a program.
+*/
+type UnsupportedFunctionError struct {
+	Target string
+}
+
+func (self *UnsupportedFunctionError) Error() string {
+	return fmt.Sprintf("'%s' can't generate a function. Generate a program instead. Functions are supported by %s", self.Target, strings.Join(ModuleTargets(), ", "))
+}
+
+/*
+	Generating code of one of several requests failed.
+*/
+type RequestError struct {
+	Name string
+	Err  error
+}
+
+func (self *RequestError) Error() string {
+	return fmt.Sprintf("%s: %s", self.Name, self.Err)
+}
+
+/*
+	Generated code has a syntax error. It is a bug of the generator or the template,
+	or a value of the command that the generator doesn't escape.
+*/
+type SyntaxError struct {
+	Language string
+	Template string
+	Err      *syntax.Error
+	Excerpt  string
+}
+
+func (self *SyntaxError) Error() string {
+	message := fmt.Sprintf("generated %s code has a syntax error at %s", self.Language, self.Err)
+	if self.Template != "" {
+		message += fmt.Sprintf(" (template %s)", self.Template)
+	}
+	if self.Excerpt != "" {
+		message += "\n" + self.Excerpt
+	}
+	return message
+}
@@ -2,18 +2,28 @@ package generator
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"github.com/shibukawa/curl_as_dsl/client/cli"
+	"github.com/shibukawa/curl_as_dsl/client/csharp"
+	"github.com/shibukawa/curl_as_dsl/client/export"
+	"github.com/shibukawa/curl_as_dsl/client/fetch"
 	"github.com/shibukawa/curl_as_dsl/client/golang"
 	"github.com/shibukawa/curl_as_dsl/client/java"
+	"github.com/shibukawa/curl_as_dsl/client/kotlin"
 	"github.com/shibukawa/curl_as_dsl/client/nodejs"
 	"github.com/shibukawa/curl_as_dsl/client/objc"
 	"github.com/shibukawa/curl_as_dsl/client/php"
 	"github.com/shibukawa/curl_as_dsl/client/python"
+	"github.com/shibukawa/curl_as_dsl/client/ruby"
+	"github.com/shibukawa/curl_as_dsl/client/rust"
+	"github.com/shibukawa/curl_as_dsl/client/swift"
 	"github.com/shibukawa/curl_as_dsl/client/vimscript"
 	"github.com/shibukawa/curl_as_dsl/client/xhr"
 	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/syntax"
 	"go/format"
-	"log"
+	"go/scanner"
 	"text/template"
 )
 
@@ -22,6 +32,12 @@ var LanguageMap map[string]string = map[string]string{
 	"golang":             "go",
 	"py":                 "python",
 	"python":             "python",
+	"py.requests":        "python_requests",
+	"python.requests":    "python_requests",
+	"py.httpx":           "python_httpx",
+	"python.httpx":       "python_httpx",
+	"py.httpx.async":     "python_httpx_async",
+	"python.httpx.async": "python_httpx_async",
 	"node":               "node",
 	"nodejs":             "node",
 	"js.node":            "node",
@@ -31,76 +47,266 @@ var LanguageMap map[string]string = map[string]string{
 	"javascript.xhr":     "xhr",
 	"js.browser":         "xhr",
 	"javascript.browser": "xhr",
+	"js.fetch":           "fetch_browser",
+	"javascript.fetch":   "fetch_browser",
+	"js.fetch.node":      "fetch_node",
+	"ts.fetch":           "fetch_ts",
+	"typescript.fetch":   "fetch_ts",
 	"java":               "java",
+	"java.httpclient":    "java_httpclient",
+	"csharp":             "csharp",
+	"cs":                 "csharp",
+	"csharp.httpclient":  "csharp",
+	"kotlin":             "kotlin_okhttp",
+	"kotlin.okhttp":      "kotlin_okhttp",
 	"objc":               "objc_nsurlsession",
 	"objc.session":       "objc_nsurlsession",
 	"objc.nsurlsession":  "objc_nsurlsession",
 	"objc.connection":    "objc_nsurlconnection",
 	"objc.urlconnection": "objc_nsurlconnection",
 	"php":                "php",
+	"ruby":               "ruby",
+	"ruby.nethttp":       "ruby",
+	"ruby.faraday":       "ruby_faraday",
+	"rust":               "rust",
+	"rust.reqwest":       "rust",
+	"rust.async":         "rust_async",
+	"rust.reqwest.async": "rust_async",
+	"swift":              "swift",
+	"swift.urlsession":   "swift",
+	"swift.async":        "swift_async",
 	"vim":                "vim",
+	"http":               "httpie",
+	"httpie":             "httpie",
+	"wget":               "wget",
+	"powershell":         "powershell",
+	"pwsh":               "powershell",
+	"ps":                 "powershell",
+	"curl":               "curl",
+	"curl.oneline":       "curl_oneline",
+	"curl.cmd":           "curl_cmd",
+	"curl.cmd.oneline":   "curl_cmd_oneline",
+	"har":                "har",
+	"postman":            "postman",
+	"openapi":            "openapi",
 }
 
-func render(lang, key string, options interface{}) string {
-	src, _ := Asset(fmt.Sprintf("templates/%s_%s.tpl", lang, key))
+/*
+	Result of code generation.
+	Context is the value passed to the template. It is useful to debug templates.
+	Command line targets (httpie, wget, powershell, curl) and document targets (har, postman, openapi)
+	don't use templates. Their TemplateName is empty and Context builds the source code by itself.
+*/
+type Result struct {
+	SourceCode   string
+	Language     string
+	TemplateName string
+	Context      interface{}
+	Warnings     []string
+}
+
+type sourceCodeEmitter interface {
+	SourceCode() string
+}
+
+func templatePath(lang, key string) string {
+	return fmt.Sprintf("templates/%s_%s.tpl", lang, key)
+}
+
+func render(lang, key string, options interface{}) (string, error) {
+	name := templatePath(lang, key)
+	src, err := Asset(name)
+	if err != nil {
+		return "", &TemplateError{Template: name, Err: err}
+	}
+	tpl, err := template.New(key).Parse(string(src))
+	if err != nil {
+		return "", &TemplateError{Template: name, Err: err}
+	}
 	var buffer bytes.Buffer
-	var err error
-	tmpTpl, err := template.New(key).Parse(string(src))
-	tpl := template.Must(tmpTpl, err)
 	err = tpl.Execute(&buffer, options)
 	if err != nil {
-		log.Fatal(err)
+		return "", &TemplateError{Template: name, Err: err}
 	}
 	if lang == "go" {
 		gosrc, err := format.Source(buffer.Bytes())
-		if err != nil {
-			log.Fatal(err)
+		if errors, ok := err.(scanner.ErrorList); ok && len(errors) > 0 {
+			// unformatted code is returned to show the error
+			syntaxError := &syntax.Error{Line: errors[0].Pos.Line, Column: errors[0].Pos.Column, Message: errors[0].Msg}
+			return buffer.String(), &SyntaxError{Language: lang, Template: name, Err: syntaxError, Excerpt: syntaxError.Excerpt(buffer.String())}
+		} else if err != nil {
+			return "", &TemplateError{Template: name, Err: err}
 		}
-		return string(gosrc)
+		return string(gosrc), nil
 	}
-	return buffer.String()
+	return buffer.String(), nil
 }
 
-func GenerateCode(target string, curlOptions *common.CurlOptions) (string, string, string, interface{}) {
-	var langName string
-	var templateName string
-	var option interface{}
+/*
+	Generate source code of target language from curl options.
+	Passed options are not modified. Errors are one of UnknownTargetError, TemplateError, SyntaxError,
+	common.URLParseError, common.InvalidOptionError and common.UnsupportedOptionError
+	(or context error). Result has the source code even if it has SyntaxError. Go code is not formatted then.
+*/
+func Generate(ctx context.Context, target string, curlOptions *common.CurlOptions) (Result, error) {
+	var result Result
+	var err error
 
 	lang, ok := LanguageMap[target]
 	if !ok {
-		return "", "", "", nil
+		return result, &UnknownTargetError{Target: target}
+	}
+	if err = ctx.Err(); err != nil {
+		return result, err
+	}
+	options := curlOptions.Clone()
+	if err = options.CheckError(); err != nil {
+		return result, err
+	}
+	if len(options.Parameters) > 0 && (lang == "har" || lang == "openapi") {
+		if len(options.FunctionParameters()) > 0 {
+			options.AddWarning("%s can't have parameters. Values of the command are used.", target)
+		}
+		if len(options.FunctionParameters()) < len(options.Parameters) {
+			options.AddWarning("%s can't read environment variables. Secrets are written as references like ${PASSWORD}.", target)
+		}
+		options.ClearParameters()
 	}
 
 	switch lang {
 	case "go":
-		langName = "go"
-		templateName, option = golang.ProcessCurlCommand(curlOptions)
+		result.Language = "go"
+		result.TemplateName, result.Context, err = golang.ProcessCurlCommand(options)
 	case "python":
-		langName = "python"
-		templateName, option = python.ProcessCurlCommand(curlOptions)
+		result.Language = "python"
+		result.TemplateName, result.Context, err = python.ProcessCurlCommand(options)
+	case "python_requests":
+		result.Language = "python_requests"
+		result.TemplateName, result.Context, err = python.ProcessCurlCommandForRequests(options)
+	case "python_httpx":
+		result.Language = "python_httpx"
+		result.TemplateName, result.Context, err = python.ProcessCurlCommandForHttpx(options)
+	case "python_httpx_async":
+		result.Language = "python_httpx_async"
+		result.TemplateName, result.Context, err = python.ProcessCurlCommandForHttpx(options)
 	case "node":
-		langName = "nodejs"
-		templateName, option = nodejs.ProcessCurlCommand(curlOptions)
+		result.Language = "nodejs"
+		result.TemplateName, result.Context, err = nodejs.ProcessCurlCommand(options)
 	case "java":
-		langName = "java"
-		templateName, option = java.ProcessCurlCommand(curlOptions)
+		result.Language = "java"
+		result.TemplateName, result.Context, err = java.ProcessCurlCommand(options)
+	case "java_httpclient":
+		result.Language = "java_httpclient"
+		result.TemplateName, result.Context, err = java.ProcessCurlCommandForHttpClient(options)
+	case "kotlin_okhttp":
+		result.Language = "kotlin_okhttp"
+		result.TemplateName, result.Context, err = kotlin.ProcessCurlCommand(options)
+	case "csharp":
+		result.Language = "csharp"
+		result.TemplateName, result.Context, err = csharp.ProcessCurlCommand(options)
 	case "objc_nsurlsession":
-		langName = "objc_nsurlsession"
-		templateName, option = objc.ProcessCurlCommand(curlOptions)
+		result.Language = "objc_nsurlsession"
+		result.TemplateName, result.Context, err = objc.ProcessCurlCommand(options)
 	case "objc_nsurlconnection":
-		langName = "objc_nsurlconnection"
-		templateName, option = objc.ProcessCurlCommand(curlOptions)
+		result.Language = "objc_nsurlconnection"
+		result.TemplateName, result.Context, err = objc.ProcessCurlCommand(options)
 	case "xhr":
-		langName = "xhr"
-		templateName, option = xhr.ProcessCurlCommand(curlOptions)
+		result.Language = "xhr"
+		result.TemplateName, result.Context, err = xhr.ProcessCurlCommand(options)
+	case "fetch_browser":
+		result.Language = "fetch_browser"
+		result.TemplateName, result.Context, err = fetch.ProcessCurlCommand(options)
+	case "fetch_node":
+		result.Language = "fetch_node"
+		result.TemplateName, result.Context, err = fetch.ProcessCurlCommandForNode(options)
+	case "fetch_ts":
+		result.Language = "fetch_ts"
+		result.TemplateName, result.Context, err = fetch.ProcessCurlCommandForTypeScript(options)
 	case "php":
-		langName = "php"
-		templateName, option = php.ProcessCurlCommand(curlOptions)
+		result.Language = "php"
+		result.TemplateName, result.Context, err = php.ProcessCurlCommand(options)
+	case "ruby":
+		result.Language = "ruby"
+		result.TemplateName, result.Context, err = ruby.ProcessCurlCommand(options)
+	case "ruby_faraday":
+		result.Language = "ruby_faraday"
+		result.TemplateName, result.Context, err = ruby.ProcessCurlCommandForFaraday(options)
+	case "rust":
+		result.Language = "rust"
+		result.TemplateName, result.Context, err = rust.ProcessCurlCommand(options)
+	case "rust_async":
+		result.Language = "rust_async"
+		result.TemplateName, result.Context, err = rust.ProcessCurlCommandForAsync(options)
+	case "swift":
+		result.Language = "swift"
+		result.TemplateName, result.Context, err = swift.ProcessCurlCommand(options)
+	case "swift_async":
+		result.Language = "swift_async"
+		result.TemplateName, result.Context, err = swift.ProcessCurlCommand(options)
 	case "vim":
-		langName = "vim_script"
-		templateName, option = vimscript.ProcessCurlCommand(curlOptions)
-	default:
+		result.Language = "vim_script"
+		result.TemplateName, result.Context, err = vimscript.ProcessCurlCommand(options)
+	case "httpie":
+		result.Language = "httpie"
+		result.TemplateName, result.Context, err = cli.ProcessCurlCommandForHttpie(options)
+	case "wget":
+		result.Language = "wget"
+		result.TemplateName, result.Context, err = cli.ProcessCurlCommandForWget(options)
+	case "powershell":
+		result.Language = "powershell"
+		result.TemplateName, result.Context, err = cli.ProcessCurlCommandForPowerShell(options)
+	case "curl":
+		result.Language = "curl"
+		result.TemplateName, result.Context, err = cli.ProcessCurlCommandForCurl(options, common.CurlStyle{Multiline: true})
+	case "curl_oneline":
+		result.Language = "curl"
+		result.TemplateName, result.Context, err = cli.ProcessCurlCommandForCurl(options, common.CurlStyle{})
+	case "curl_cmd":
+		result.Language = "curl"
+		result.TemplateName, result.Context, err = cli.ProcessCurlCommandForCurl(options, common.CurlStyle{Multiline: true, Windows: true})
+	case "curl_cmd_oneline":
+		result.Language = "curl"
+		result.TemplateName, result.Context, err = cli.ProcessCurlCommandForCurl(options, common.CurlStyle{Windows: true})
+	case "har":
+		result.Language = "har"
+		result.TemplateName, result.Context, err = export.ProcessCurlCommandForHar(options)
+	case "postman":
+		result.Language = "postman"
+		result.TemplateName, result.Context, err = export.ProcessCurlCommandForPostman(options)
+	case "openapi":
+		result.Language = "openapi"
+		result.TemplateName, result.Context, err = export.ProcessCurlCommandForOpenApi(options)
+	}
+	if err != nil {
+		return result, err
+	}
+	if err = ctx.Err(); err != nil {
+		return result, err
+	}
+	if emitter, ok := result.Context.(sourceCodeEmitter); ok {
+		result.SourceCode = emitter.SourceCode()
+	} else {
+		result.SourceCode, err = render(result.Language, result.TemplateName, result.Context)
+	}
+	result.Warnings = options.Warnings
+	if err == nil && len(options.Parameters) > 0 {
+		var warnings []string
+		result.SourceCode, warnings = substituteParameters(lang, result.SourceCode, options.Parameters, nil)
+		result.Warnings = append(result.Warnings, warnings...)
+	}
+	if err == nil {
+		err = validate(lang, result.Language, result.TemplateName, result.SourceCode)
+	}
+	return result, err
+}
+
+/*
+	Old style API. It returns empty strings when it fails. Use Generate() to know the reason.
+*/
+func GenerateCode(target string, curlOptions *common.CurlOptions) (string, string, string, interface{}) {
+	result, err := Generate(context.Background(), target, curlOptions)
+	if err != nil {
+		return "", "", "", nil
 	}
-	sourceCode := render(langName, templateName, option)
-	return sourceCode, langName, templateName, option
+	return result.SourceCode, result.Language, result.TemplateName, result.Context
 }
@@ -0,0 +1,374 @@
+package generator_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jessevdk/go-flags"
+	"github.com/shibukawa/curl_as_dsl/client/golang"
+	"github.com/shibukawa/curl_as_dsl/client/nodejs"
+	"github.com/shibukawa/curl_as_dsl/client/python"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/generator"
+	. "gopkg.in/check.v1"
+	"strings"
+)
+
+type GeneratorTest struct{}
+
+var _ = Suite(&GeneratorTest{})
+
+func parseOptions(c *C, args ...string) *common.CurlOptions {
+	var curlOptions common.CurlOptions
+	curlOptions.Init()
+	urls, err := flags.ParseArgs(&curlOptions, args)
+	c.Assert(err, IsNil)
+	if len(urls) > 0 {
+		curlOptions.Url = urls[0]
+	}
+	return &curlOptions
+}
+
+func (s *GeneratorTest) Test_Generate(c *C) {
+	result, err := generator.Generate(context.Background(), "py", parseOptions(c, "http://localhost:18888"))
+	c.Assert(err, IsNil)
+	c.Check(result.Language, Equals, "python")
+	c.Check(result.TemplateName, Equals, "full")
+	c.Check(strings.Contains(result.SourceCode, "http.client.HTTPConnection(\"localhost:18888\")"), Equals, true)
+	c.Check(result.Warnings, IsNil)
+}
+
+func (s *GeneratorTest) Test_Generate_KeepsOptions(c *C) {
+	options := parseOptions(c, "-d", "hello=world", "http://localhost:18888")
+	_, err := generator.Generate(context.Background(), "go", options)
+	c.Assert(err, IsNil)
+	c.Check(options.Header, IsNil)
+}
+
+func (s *GeneratorTest) Test_Generate_Warnings(c *C) {
+	result, err := generator.Generate(context.Background(), "go", parseOptions(c, "-H", "wrong header", "http://localhost:18888"))
+	c.Assert(err, IsNil)
+	c.Check(result.Warnings, DeepEquals, []string{"wrong header is wrong style header."})
+}
+
+func (s *GeneratorTest) Test_Generate_UnknownTarget(c *C) {
+	_, err := generator.Generate(context.Background(), "cobol", parseOptions(c, "http://localhost:18888"))
+	c.Check(err, FitsTypeOf, &generator.UnknownTargetError{})
+}
+
+func (s *GeneratorTest) Test_Generate_URLParseError(c *C) {
+	_, err := generator.Generate(context.Background(), "go", parseOptions(c, "http://[::1"))
+	c.Check(err, FitsTypeOf, &common.URLParseError{})
+}
+
+func (s *GeneratorTest) Test_Generate_MissingUrl(c *C) {
+	_, err := generator.Generate(context.Background(), "go", parseOptions(c))
+	c.Check(err, FitsTypeOf, &common.InvalidOptionError{})
+}
+
+func (s *GeneratorTest) Test_Generate_InvalidForm(c *C) {
+	_, err := generator.Generate(context.Background(), "go", parseOptions(c, "-F", "field", "http://localhost:18888"))
+	c.Check(err, FitsTypeOf, &common.InvalidOptionError{})
+}
+
+func (s *GeneratorTest) Test_Generate_UnsupportedOption(c *C) {
+	_, err := generator.Generate(context.Background(), "vim", parseOptions(c, "-X", "PUT", "http://localhost:18888"))
+	c.Check(err, FitsTypeOf, &common.UnsupportedOptionError{})
+
+	_, err = generator.Generate(context.Background(), "xhr", parseOptions(c, "-F", "file=@test.txt;type=text/plain", "http://localhost:18888"))
+	c.Check(err, FitsTypeOf, &common.UnsupportedOptionError{})
+}
+
+func (s *GeneratorTest) Test_Generate_GetWithForm(c *C) {
+	commands := [][]string{
+		{"-G", "-F", "a=<f.txt", "http://localhost:18888"},
+		{"-G", "-F", "name=@file.txt;type=text/plain", "http://localhost:18888"},
+		{"-I", "-F", "name=@file.txt;type=text/plain", "http://localhost:18888"},
+	}
+	for _, command := range commands {
+		for _, target := range goldenTargets {
+			_, err := generator.Generate(context.Background(), target, parseOptions(c, command...))
+			c.Check(err, FitsTypeOf, &common.UnsupportedOptionError{}, Commentf("%s: %v", target, command))
+		}
+	}
+}
+
+func (s *GeneratorTest) Test_ProcessCurlCommand_UnexpectedData(c *C) {
+	// generators return errors instead of panicking even if CheckError() is skipped
+	args := []string{"-G", "-d", "a=1", "-F", "a=<f.txt", "http://localhost:18888"}
+	_, _, err := golang.ProcessCurlCommand(parseOptions(c, args...))
+	c.Check(err, FitsTypeOf, &common.UnsupportedOptionError{})
+	_, _, err = nodejs.ProcessCurlCommand(parseOptions(c, args...))
+	c.Check(err, FitsTypeOf, &common.UnsupportedOptionError{})
+	_, _, err = python.ProcessCurlCommand(parseOptions(c, args...))
+	c.Check(err, FitsTypeOf, &common.UnsupportedOptionError{})
+}
+
+func (s *GeneratorTest) Test_Generate_Escaping(c *C) {
+	options := parseOptions(c, "-X", `GE"T`, "-H", `X-Quote: he said "hi" \ $HOME #{x}`, "http://localhost:18888")
+	result, err := generator.Generate(context.Background(), "java", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, `"he said \"hi\" \\ $HOME #{x}"`), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, `conn.setRequestMethod("GE\"T")`), Equals, true)
+
+	for _, target := range []string{"python", "node", "xhr", "go", "php"} {
+		_, err = generator.Generate(context.Background(), target, options)
+		c.Check(err, IsNil, Commentf("%s", target))
+	}
+	_, err = generator.GenerateFunction(context.Background(), "python", options, "", "send")
+	c.Check(err, IsNil)
+
+	result, err = generator.Generate(context.Background(), "ruby", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, `"he said \"hi\" \\ $HOME \#{x}"`), Equals, true)
+}
+
+func (s *GeneratorTest) Test_Generate_Canceled(c *C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := generator.Generate(ctx, "go", parseOptions(c, "http://localhost:18888"))
+	c.Check(err, Equals, context.Canceled)
+}
+
+func (s *GeneratorTest) Test_Generate_PythonLibraries(c *C) {
+	options := parseOptions(c, "-k", "-u", "user:pass", "-d", "hello=world", "http://localhost:18888")
+	result, err := generator.Generate(context.Background(), "python.requests", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "requests.post(\"http://localhost:18888\", data=data, auth=(\"user\", \"pass\"), verify=False)"), Equals, true)
+
+	result, err = generator.Generate(context.Background(), "python.httpx.async", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "async with httpx.AsyncClient(verify=False) as client:"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "res = await client.post(\"http://localhost:18888\", data=data, auth=(\"user\", \"pass\"))"), Equals, true)
+}
+
+func (s *GeneratorTest) Test_Generate_Ruby(c *C) {
+	options := parseOptions(c, "-k", "-u", "user:pass", "-x", "proxy.example.com:3128", "-F", "file=@test.txt;type=text/plain", "-F", "hello=world", "http://localhost:18888")
+	result, err := generator.Generate(context.Background(), "ruby", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "[\"file\", File.binread(\"test.txt\"), { filename: \"test.txt\", content_type: \"text/plain\" }],"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "request.basic_auth(\"user\", \"pass\")"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "Net::HTTP.start(uri.hostname, uri.port, \"proxy.example.com\", 3128, use_ssl: uri.scheme == \"https\", verify_mode: OpenSSL::SSL::VERIFY_NONE)"), Equals, true)
+
+	result, err = generator.Generate(context.Background(), "ruby.faraday", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "\"file\" => Faraday::Multipart::FilePart.new(\"test.txt\", \"text/plain\", \"test.txt\"),"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "conn = Faraday.new(url: \"http://localhost:18888\", proxy: \"http://proxy.example.com:3128\", ssl: { verify: false }) do |f|"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "f.request :authorization, :basic, \"user\", \"pass\""), Equals, true)
+
+	options = parseOptions(c, "-X", "PROPFIND", "http://localhost:18888")
+	result, err = generator.Generate(context.Background(), "ruby", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "Net::HTTPGenericRequest.new(\"PROPFIND\", false, true, uri)"), Equals, true)
+	_, err = generator.Generate(context.Background(), "ruby.faraday", options)
+	c.Check(err, FitsTypeOf, &common.UnsupportedOptionError{})
+}
+
+func (s *GeneratorTest) Test_Generate_Rust(c *C) {
+	options := parseOptions(c, "-k", "-u", "user:pass", "-F", "file=@test.txt", "http://localhost:18888")
+	result, err := generator.Generate(context.Background(), "rust", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "features = [\"blocking\", \"multipart\"]"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, ".part(\"file\", reqwest::blocking::multipart::Part::file(\"test.txt\")?)"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, ".danger_accept_invalid_certs(true)"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, ".basic_auth(\"user\", Some(\"pass\"))"), Equals, true)
+
+	result, err = generator.Generate(context.Background(), "rust.async", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "Part::file(\"test.txt\").await?"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, ".send().await?;"), Equals, true)
+}
+
+func (s *GeneratorTest) Test_Generate_CSharp(c *C) {
+	options := parseOptions(c, "-k", "-u", "user:pass", "-x", "proxy.example.com:3128", "-b", "a=b", "-F", "file=@test.txt;type=text/plain", "http://localhost:18888")
+	result, err := generator.Generate(context.Background(), "csharp", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "file1.Headers.ContentType = new MediaTypeHeaderValue(\"text/plain\");"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "form.Add(file1, \"file\", \"test.txt\");"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "Proxy = new WebProxy(\"http://proxy.example.com:3128\"),"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "ServerCertificateCustomValidationCallback = HttpClientHandler.DangerousAcceptAnyServerCertificateValidator,"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "cookies.Add(new Uri(\"http://localhost:18888\"), new Cookie(\"a\", \"b\"));"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "new AuthenticationHeaderValue(\"Basic\", Convert.ToBase64String(Encoding.UTF8.GetBytes(\"user:pass\")))"), Equals, true)
+
+	options = parseOptions(c, "-d", "hello=world", "-d", "foo=bar", "http://localhost:18888")
+	result, err = generator.Generate(context.Background(), "csharp", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "new FormUrlEncodedContent(new List<KeyValuePair<string, string>>\n{\n    new(\"hello\", \"world\"),\n    new(\"foo\", \"bar\"),\n});"), Equals, true)
+}
+
+func (s *GeneratorTest) Test_Generate_Fetch(c *C) {
+	options := parseOptions(c, "-k", "--digest", "-u", "user:pass", "-m", "10", "-F", "file=@test.txt", "http://localhost:18888")
+	result, err := generator.Generate(context.Background(), "js.fetch.node", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "form.append(\"file\", await openAsBlob(\"test.txt\", { type: \"text/plain\" }), \"test.txt\");"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "const dispatcher = new Agent({\n    connect: { rejectUnauthorized: false },\n});"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "setTimeout(() => controller.abort(), 10000);"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "headers[\"Authorization\"] = digestAuthorization("), Equals, true)
+
+	result, err = generator.Generate(context.Background(), "ts.fetch", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "function digestAuthorization(challenge: string, "), Equals, true)
+
+	result, err = generator.Generate(context.Background(), "js.fetch", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "<input type=\"file\" id=\"file1\">"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "form.append(\"file\", selectedFile(\"file1\"), \"test.txt\");"), Equals, true)
+	c.Check(len(result.Warnings), Equals, 2)
+}
+
+func (s *GeneratorTest) Test_Generate_Swift(c *C) {
+	options := parseOptions(c, "-k", "-u", "user:pass", "-x", "proxy.example.com:3128", "-F", "file=@test.txt", "http://localhost:18888")
+	result, err := generator.Generate(context.Background(), "swift", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "(\"file\", \"test.txt\", \"test.txt\", mimeType(\"test.txt\")),"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "request.httpBody = try encodeMultiPartBody(boundary, fields, files)"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "URLCredential(trust: serverTrust)"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "\"Basic \\(Data(\"user:pass\".utf8).base64EncodedString())\""), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "\"HTTPSProxy\": \"proxy.example.com\", \"HTTPSPort\": 3128"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "session.dataTask(with: request)"), Equals, true)
+
+	result, err = generator.Generate(context.Background(), "swift.async", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "try await session.data(for: request)"), Equals, true)
+}
+
+func (s *GeneratorTest) Test_Generate_JavaHttpClient(c *C) {
+	options := parseOptions(c, "--http2", "-k", "-u", "user:pass", "-x", "proxy.example.com:3128", "-F", "file=@test.txt", "-H", "Host: example.com", "https://localhost:18888")
+	result, err := generator.Generate(context.Background(), "java.httpclient", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, ".version(HttpClient.Version.HTTP_2)"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, ".sslContext(insecureSSLContext())"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, ".proxy(ProxySelector.of(new InetSocketAddress(\"proxy.example.com\", 3128)))"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, ".header(\"Content-Type\", \"multipart/form-data; boundary=\" + boundary)"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "Base64.getEncoder().encodeToString(\"user:pass\""), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, ".header(\"Host\""), Equals, false)
+	c.Check(result.Warnings, HasLen, 1)
+}
+
+func (s *GeneratorTest) Test_Generate_KotlinOkHttp(c *C) {
+	options := parseOptions(c, "-k", "-u", "user:pass", "-x", "socks5://proxy.example.com", "-F", "file=@test.txt", "-b", "a=b", "-c", "jar.txt", "https://localhost:18888")
+	result, err := generator.Generate(context.Background(), "kotlin.okhttp", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, ".addFormDataPart(\"file\", \"test.txt\", File(\"test.txt\").asRequestBody("), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, ".sslSocketFactory(sslContext.socketFactory, trustAllManager)"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, ".proxy(Proxy(Proxy.Type.SOCKS, InetSocketAddress(\"proxy.example.com\", 1080)))"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "Credentials.basic(\"user\", \"pass\")"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, ".cookieJar(JavaNetCookieJar(cookieManager))"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "okhttp-urlconnection"), Equals, true)
+}
+
+func (s *GeneratorTest) Test_Generate_CommandLine(c *C) {
+	options := parseOptions(c, "-k", "--digest", "-u", "user:pass", "-H", "X-Test: a b", "-d", "hello=world", "http://localhost:18888")
+	result, err := generator.Generate(context.Background(), "httpie", options)
+	c.Assert(err, IsNil)
+	c.Check(result.TemplateName, Equals, "")
+	c.Check(result.SourceCode, Equals, "http --form --auth-type=digest --auth=user:pass --verify=no http://localhost:18888 hello=world 'X-Test:a b'")
+
+	result, err = generator.Generate(context.Background(), "wget", options)
+	c.Assert(err, IsNil)
+	c.Check(result.SourceCode, Equals, "wget -q -O - --content-on-error --post-data=hello=world '--header=X-Test: a b' --user=user --password=pass --no-check-certificate http://localhost:18888")
+	c.Check(result.Warnings, DeepEquals, []string{"wget can't select Digest authentication. It uses the scheme of the server's challenge."})
+
+	result, err = generator.Generate(context.Background(), "powershell", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "    'hello' = 'world'\n"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "    SkipCertificateCheck = $true\n"), Equals, true)
+	c.Check(strings.HasSuffix(result.SourceCode, "Invoke-RestMethod @params"), Equals, true)
+
+	options = parseOptions(c, "-F", "file=@test.txt", "--http2", "http://localhost:18888")
+	result, err = generator.Generate(context.Background(), "wget", options)
+	c.Assert(err, IsNil)
+	c.Check(result.Warnings, HasLen, 2)
+	result, err = generator.Generate(context.Background(), "httpie", options)
+	c.Assert(err, IsNil)
+	c.Check(result.SourceCode, Equals, "http --multipart http://localhost:18888 file@test.txt")
+	c.Check(result.Warnings, HasLen, 1)
+}
+
+func (s *GeneratorTest) Test_Generate_CommandLine_AWSV2(c *C) {
+	warnings := map[string]string{
+		"httpie":     "HTTPie doesn't support AWS V2 signature. --awsv2 is ignored.",
+		"wget":       "wget doesn't support AWS V2 signature. --awsv2 is ignored.",
+		"powershell": "Invoke-RestMethod doesn't support AWS V2 signature. --awsv2 is ignored.",
+	}
+	for target, warning := range warnings {
+		result, err := generator.Generate(context.Background(), target, parseOptions(c, "--awsv2", "AK:SK", "http://localhost:18888"))
+		c.Assert(err, IsNil)
+		c.Check(result.Warnings, DeepEquals, []string{warning}, Commentf("%s", target))
+	}
+}
+
+func (s *GeneratorTest) Test_Generate_Curl(c *C) {
+	options := parseOptions(c, "-A", "agent", "-H", "Accept: text/html", "-X", "POST", "-d", "a=b", "http://localhost:18888")
+	result, err := generator.Generate(context.Background(), "curl.oneline", options)
+	c.Assert(err, IsNil)
+	c.Check(result.SourceCode, Equals, "curl http://localhost:18888 -A agent -H 'Accept: text/html' -d a=b")
+	result, err = generator.Generate(context.Background(), "curl.cmd", options)
+	c.Assert(err, IsNil)
+	c.Check(result.SourceCode, Equals, "curl http://localhost:18888 ^\n  -A agent ^\n  -H ^\"Accept: text/html^\" ^\n  -d ^\"a=b^\"")
+}
+
+func (s *GeneratorTest) Test_Generate_Har(c *C) {
+	options := parseOptions(c, "-u", "user:pass", "-F", "file=@test.png;type=image/png", "-F", "hello=world", "http://localhost:18888/upload?a=1")
+	result, err := generator.Generate(context.Background(), "har", options)
+	c.Assert(err, IsNil)
+	var har struct {
+		Log struct {
+			Entries []struct {
+				Request struct {
+					Method      string
+					QueryString []map[string]string
+					Headers     []map[string]string
+					PostData    struct {
+						MimeType string
+						Params   []map[string]string
+					}
+				}
+			}
+		}
+	}
+	c.Assert(json.Unmarshal([]byte(result.SourceCode), &har), IsNil)
+	request := har.Log.Entries[0].Request
+	c.Check(request.Method, Equals, "POST")
+	c.Check(request.QueryString, DeepEquals, []map[string]string{{"name": "a", "value": "1"}})
+	c.Check(request.Headers, DeepEquals, []map[string]string{{"name": "Content-Type", "value": "multipart/form-data"}, {"name": "Authorization", "value": "Basic dXNlcjpwYXNz"}})
+	c.Check(request.PostData.Params, DeepEquals, []map[string]string{{"name": "file", "fileName": "test.png", "contentType": "image/png"}, {"name": "hello", "value": "world"}})
+}
+
+func (s *GeneratorTest) Test_Generate_Postman(c *C) {
+	options := parseOptions(c, "-k", "--digest", "-u", "user:pass", "-d", "hello=world", "-x", "proxy.example.com:3128", "http://localhost:18888/api/users")
+	result, err := generator.Generate(context.Background(), "postman", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, `"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"`), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, `"name": "POST /api/users"`), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, `"mode": "urlencoded"`), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, `"type": "digest"`), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, `"strictSSL": false`), Equals, true)
+	c.Check(result.Warnings, HasLen, 1)
+}
+
+func (s *GeneratorTest) Test_Generate_OpenApi(c *C) {
+	options := parseOptions(c, "-H", "Content-Type: application/json", "-d", `{"name":"x","age":3,"tags":["a"]}`, "http://localhost:18888/api/users?limit=10")
+	result, err := generator.Generate(context.Background(), "openapi", options)
+	c.Assert(err, IsNil)
+	var document map[string]interface{}
+	c.Assert(json.Unmarshal([]byte(result.SourceCode), &document), IsNil)
+	operation := document["paths"].(map[string]interface{})["/api/users"].(map[string]interface{})["post"].(map[string]interface{})
+	c.Check(operation["parameters"], DeepEquals, []interface{}{
+		map[string]interface{}{"name": "limit", "in": "query", "schema": map[string]interface{}{"type": "integer"}, "example": 10.0},
+	})
+	schema := operation["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	c.Check(schema["properties"], DeepEquals, map[string]interface{}{
+		"name": map[string]interface{}{"type": "string"},
+		"age":  map[string]interface{}{"type": "integer"},
+		"tags": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+	})
+
+	options = parseOptions(c, "-F", "file=@test.png", "-F", "count=2", "http://localhost:18888/upload")
+	result, err = generator.Generate(context.Background(), "openapi", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, `"multipart/form-data": {`), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "\"file\": {\n                    \"type\": \"string\",\n                    \"format\": \"binary\"\n"), Equals, true)
+
+	_, err = generator.Generate(context.Background(), "openapi", parseOptions(c, "-X", "PROPFIND", "http://localhost:18888"))
+	c.Check(err, FitsTypeOf, &common.UnsupportedOptionError{})
+}
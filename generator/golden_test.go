@@ -0,0 +1,114 @@
+package generator_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/generator"
+	. "gopkg.in/check.v1"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var update = flag.Bool("update", false, "Update golden files of generated code")
+
+/*
+	Targets of golden tests. Each language of generator.LanguageMap needs one of them.
+*/
+var goldenTargets = []string{
+	"go", "python", "python.requests", "python.httpx", "python.httpx.async",
+	"js.node", "js.xhr", "js.fetch", "js.fetch.node", "ts.fetch",
+	"java", "java.httpclient", "csharp", "kotlin", "objc", "objc.connection",
+	"php", "ruby", "ruby.faraday", "rust", "rust.async", "swift", "swift.async", "vim",
+	"httpie", "wget", "powershell", "curl", "curl.oneline", "curl.cmd", "curl.cmd.oneline",
+	"har", "postman", "openapi",
+}
+
+/*
+	Commands of golden tests. Generated code of a command is in testdata/golden/NAME/TARGET.golden.
+*/
+var goldenCommands = []struct {
+	name    string
+	command string
+}{
+	{"simple_get", `curl http://localhost:18888`},
+	{"get_with_data", `curl -G -d hello=world -d 'q=a b' http://localhost:18888/search`},
+	{"get_with_value", `curl -G -d a=1 -d x http://localhost/`},
+	{"multiple_data", `curl -d test -d hello http://localhost:18888`},
+	{"data_raw", `curl --data-raw @hello --data-raw a=1 http://localhost:18888`},
+	{"data_urlencode", `curl --data-urlencode 'test% =' --data-urlencode @test.txt http://localhost:18888`},
+	{"form_files", `curl -F hello=world -F file=@test.txt -F 'doc=@test.txt;filename=nameinpost;type=text/plain' -F 'text=<test.txt' http://localhost:18888`},
+	{"upload_file", `curl -T test.txt http://localhost:18888/upload`},
+	{"basic_auth", `curl -u USER:PASS http://localhost:18888`},
+	{"proxy", `curl -x http://localhost:8080 http://localhost:18888`},
+	{"insecure", `curl -k https://localhost:18889`},
+	{"http2", `curl -k --http2 https://localhost:18889`},
+}
+
+type GoldenTest struct{}
+
+var _ = Suite(&GoldenTest{})
+
+func (s *GoldenTest) Test_Targets(c *C) {
+	languages := make(map[string]bool)
+	for _, target := range goldenTargets {
+		languages[generator.LanguageMap[target]] = true
+	}
+	for target, language := range generator.LanguageMap {
+		c.Check(languages[language], Equals, true, Commentf("golden test of %s is missing", target))
+	}
+}
+
+func (s *GoldenTest) Test_Golden(c *C) {
+	for _, golden := range goldenCommands {
+		for _, target := range goldenTargets {
+			options, err := common.ParseCurlCommand(golden.command)
+			c.Assert(err, IsNil)
+			// Generate() runs the syntax checker of the language before the code is compared or written
+			result, err := generator.Generate(context.Background(), target, options)
+			var syntaxError *generator.SyntaxError
+			if errors.As(err, &syntaxError) {
+				c.Errorf("%s: %s: broken code can't be a golden file: %v", target, golden.command, err)
+				continue
+			}
+			sourceCode := result.SourceCode
+			if err != nil {
+				// golden file has the reason of the error
+				sourceCode = "error: " + err.Error()
+			}
+
+			fileName := filepath.Join("testdata", "golden", golden.name, target+".golden")
+			if *update {
+				c.Assert(os.MkdirAll(filepath.Dir(fileName), 0755), IsNil)
+				c.Assert(ioutil.WriteFile(fileName, []byte(sourceCode), 0644), IsNil)
+				continue
+			}
+			expected, err := ioutil.ReadFile(fileName)
+			if !c.Check(err, IsNil, Commentf("run 'go test ./generator/ -update' to create golden files")) {
+				continue
+			}
+			if sourceCode != string(expected) {
+				c.Errorf("%s: %s: generated code is different from %s\n%s", target, golden.command, fileName, diffLine(string(expected), sourceCode))
+			}
+		}
+	}
+}
+
+/*
+	First line that is different. Whole code is too long to find the difference.
+*/
+func diffLine(expected, actual string) string {
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+	for i, line := range actualLines {
+		if i >= len(expectedLines) {
+			return "extra line: " + line
+		} else if line != expectedLines[i] {
+			return "expected: " + expectedLines[i] + "\nactual:   " + line
+		}
+	}
+	return "missing line: " + expectedLines[len(actualLines)]
+}
@@ -0,0 +1,261 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/client/fetch"
+	"github.com/shibukawa/curl_as_dsl/client/golang"
+	"github.com/shibukawa/curl_as_dsl/client/java"
+	"github.com/shibukawa/curl_as_dsl/client/php"
+	"github.com/shibukawa/curl_as_dsl/client/python"
+	"github.com/shibukawa/curl_as_dsl/client/ruby"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"path"
+	"strings"
+)
+
+var fileExtensions = map[string]string{
+	"go":                   ".go",
+	"python":               ".py",
+	"python_requests":      ".py",
+	"python_httpx":         ".py",
+	"python_httpx_async":   ".py",
+	"node":                 ".js",
+	"xhr":                  ".js",
+	"fetch_browser":        ".js",
+	"fetch_node":           ".mjs",
+	"fetch_ts":             ".ts",
+	"java":                 ".java",
+	"java_httpclient":      ".java",
+	"kotlin_okhttp":        ".kt",
+	"csharp":               ".cs",
+	"objc_nsurlsession":    ".m",
+	"objc_nsurlconnection": ".m",
+	"php":                  ".php",
+	"ruby":                 ".rb",
+	"ruby_faraday":         ".rb",
+	"rust":                 ".rs",
+	"rust_async":           ".rs",
+	"swift":                ".swift",
+	"swift_async":          ".swift",
+	"vim":                  ".vim",
+	"httpie":               ".sh",
+	"wget":                 ".sh",
+	"powershell":           ".ps1",
+	"curl":                 ".sh",
+	"curl_oneline":         ".sh",
+	"curl_cmd":             ".bat",
+	"curl_cmd_oneline":     ".bat",
+	"har":                  ".har",
+	"postman":              ".postman_collection.json",
+	"openapi":              ".json",
+}
+
+/*
+	File name extension of generated code like ".go". It returns empty string for unknown targets.
+*/
+func FileExtension(target string) string {
+	return fileExtensions[LanguageMap[target]]
+}
+
+/*
+	Output of GenerateFiles(). Name is a relative path like "users/create_user.go".
+	Groups of the request become directories.
+*/
+type File struct {
+	Name   string
+	Result Result
+}
+
+func requestWarnings(request *common.NamedRequest, warnings []string) []string {
+	var result []string
+	for _, warning := range warnings {
+		result = append(result, fmt.Sprintf("%s: %s", request.Name, warning))
+	}
+	return result
+}
+
+/*
+	Generate one file for each request. Every file is a complete program of the target.
+	Java programs are renamed from Main because the class name must be the same as the file name.
+*/
+func GenerateFiles(ctx context.Context, target string, requests []common.NamedRequest) ([]File, error) {
+	lang, ok := LanguageMap[target]
+	if !ok {
+		return nil, &UnknownTargetError{Target: target}
+	}
+	var files []File
+	used := make(map[string]bool)
+	for i := range requests {
+		request := &requests[i]
+		result, err := Generate(ctx, target, request.Options)
+		if err != nil {
+			return nil, &RequestError{Name: request.Name, Err: err}
+		}
+		var directories []string
+		for _, group := range request.Groups {
+			directories = append(directories, common.SnakeCase(common.IdentifierWords(group)))
+		}
+		var name string
+		if lang == "java" || lang == "java_httpclient" {
+			name = common.PascalCase(common.IdentifierWords(request.Name))
+			name = path.Base(common.UniqueName(path.Join(append(directories, name)...), used))
+			result.SourceCode = strings.Replace(result.SourceCode, "public class Main {", "public class "+name+" {", 1)
+		} else {
+			name = path.Base(common.UniqueName(path.Join(append(directories, common.SnakeCase(common.IdentifierWords(request.Name)))...), used))
+		}
+		result.Warnings = requestWarnings(request, result.Warnings)
+		files = append(files, File{Name: path.Join(append(directories, name+fileExtensions[lang])...), Result: result})
+	}
+	return files, nil
+}
+
+/*
+	Languages that can write requests as functions. Functions take a client (a connection of http.client)
+	if the language has it, and return responses.
+*/
+var moduleProcessors = map[string]func([]common.NamedRequest, string, bool) (string, interface{}, error){
+	"go":              golang.ProcessCurlCommandsForModule,
+	"python":          python.ProcessCurlCommandsForModule,
+	"python_requests": python.ProcessCurlCommandsForRequestsModule,
+	"fetch_node":      fetch.ProcessCurlCommandsForNodeModule,
+	"fetch_ts":        fetch.ProcessCurlCommandsForTypeScriptModule,
+	"java":            java.ProcessCurlCommandsForModule,
+	"ruby":            ruby.ProcessCurlCommandsForModule,
+	"php":             php.ProcessCurlCommandsForModule,
+}
+
+/*
+	Targets of GenerateModule() and GenerateFunction(). Aliases of them like "golang" are supported too.
+*/
+func ModuleTargets() []string {
+	return []string{"go", "python", "python.requests", "js.fetch.node", "ts.fetch", "java", "ruby", "php"}
+}
+
+/*
+	True if GenerateModule() and GenerateFunction() support the target.
+*/
+func HasModule(target string) bool {
+	_, ok := moduleProcessors[LanguageMap[target]]
+	return ok
+}
+
+/*
+	Languages that write packageName of GenerateModule() and GenerateFunction() into the code.
+*/
+var packageLanguages = map[string]bool{
+	"go":   true,
+	"java": true,
+}
+
+/*
+	True if the target uses packageName. Other targets ignore it.
+*/
+func HasPackage(target string) bool {
+	return packageLanguages[LanguageMap[target]]
+}
+
+var parameterNames = map[string]func(string) string{
+	"go":              golang.ParameterName,
+	"python":          python.ParameterName,
+	"python_requests": python.ParameterName,
+	"fetch_node":      fetch.ParameterName,
+	"fetch_ts":        fetch.ParameterName,
+	"java":            java.ParameterName,
+	"ruby":            ruby.ParameterName,
+	"php":             php.ParameterName,
+}
+
+/*
+	Generate one source file that has a function for each request.
+	Function names come from groups and names of requests. Imports, helper functions and the client are shared.
+	packageName is the package of Go and Java. Package "main" has main() that calls all functions (Java code
+	doesn't have package declaration then). Other languages call them only when the file is executed directly.
+	Targets of ModuleTargets() are supported. Other targets return UnsupportedModuleError.
+	Use GenerateFiles() for them.
+*/
+func GenerateModule(ctx context.Context, target string, requests []common.NamedRequest, packageName string) (Result, error) {
+	var result Result
+	lang, ok := LanguageMap[target]
+	if !ok {
+		return result, &UnknownTargetError{Target: target}
+	}
+	if _, ok := moduleProcessors[lang]; !ok {
+		return result, &UnsupportedModuleError{Target: target}
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	cloned := make([]common.NamedRequest, len(requests))
+	for i, request := range requests {
+		cloned[i] = request
+		cloned[i].Options = request.Options.Clone()
+		if err := cloned[i].Options.CheckError(); err != nil {
+			return result, &RequestError{Name: request.Name, Err: err}
+		}
+	}
+	result, err := renderModule(lang, cloned, packageName, packageName == "main")
+	for i := range cloned {
+		result.Warnings = append(result.Warnings, requestWarnings(&cloned[i], cloned[i].Options.Warnings)...)
+	}
+	return result, err
+}
+
+/*
+	Generate a function that sends the request instead of a program. It takes a context (if the language has it)
+	and a client, and returns the response and error without printing it.
+	functionName is converted to the naming style of the language like "CreateUser" or "create_user".
+	packageName is the package of Go and Java like GenerateModule(). Go code also has NewClient() that creates
+	the client for the function.
+	Targets of GenerateModule() are supported. Other targets return UnsupportedFunctionError.
+*/
+func GenerateFunction(ctx context.Context, target string, curlOptions *common.CurlOptions, packageName, functionName string) (Result, error) {
+	var result Result
+	lang, ok := LanguageMap[target]
+	if !ok {
+		return result, &UnknownTargetError{Target: target}
+	}
+	if _, ok := moduleProcessors[lang]; !ok {
+		return result, &UnsupportedFunctionError{Target: target}
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	options := curlOptions.Clone()
+	if err := options.CheckError(); err != nil {
+		return result, err
+	}
+	if functionName == "" {
+		functionName = "send request"
+	}
+	result, err := renderModule(lang, []common.NamedRequest{{Name: functionName, Options: options}}, packageName, false)
+	result.Warnings = append(options.Warnings, result.Warnings...)
+	return result, err
+}
+
+func renderModule(lang string, requests []common.NamedRequest, packageName string, hasMain bool) (Result, error) {
+	var err error
+	result := Result{Language: lang}
+	result.TemplateName, result.Context, err = moduleProcessors[lang](requests, packageName, hasMain)
+	if err != nil {
+		return result, err
+	}
+	result.SourceCode, err = render(result.Language, result.TemplateName, result.Context)
+	if err != nil {
+		return result, err
+	}
+	// parameters of requests are parameters of functions
+	var parameters []common.Parameter
+	for _, request := range requests {
+		parameters = append(parameters, request.Options.Parameters...)
+	}
+	if len(parameters) > 0 {
+		parameterName := parameterNames[lang]
+		var warnings []string
+		result.SourceCode, warnings = substituteParameters(lang, result.SourceCode, parameters, func(parameter common.Parameter) string {
+			return parameterName(parameter.Name)
+		})
+		result.Warnings = append(result.Warnings, warnings...)
+	}
+	return result, validate(lang, result.Language, result.TemplateName, result.SourceCode)
+}
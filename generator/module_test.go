@@ -0,0 +1,232 @@
+package generator_test
+
+import (
+	"context"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/generator"
+	. "gopkg.in/check.v1"
+	"strings"
+)
+
+type ModuleTest struct{}
+
+var _ = Suite(&ModuleTest{})
+
+func namedRequests(c *C) []common.NamedRequest {
+	return []common.NamedRequest{
+		{Name: "GET /users", Options: parseOptions(c, "http://localhost:18888/users")},
+		{Name: "Create user", Groups: []string{"Users", "Admin"}, Options: parseOptions(c, "-u", "admin:secret", "--digest", "-d", "name=bob", "http://localhost:18888/users")},
+		{Name: "Delete user", Groups: []string{"Users", "Admin"}, Options: parseOptions(c, "-u", "admin:secret", "--digest", "-X", "DELETE", "http://localhost:18888/users/1")},
+		{Name: "GET /users", Options: parseOptions(c, "-H", "Accept: text/plain", "http://localhost:18888/users")},
+	}
+}
+
+func (s *ModuleTest) Test_GenerateModule(c *C) {
+	result, err := generator.GenerateModule(context.Background(), "go", namedRequests(c), "main")
+	c.Assert(err, IsNil)
+	c.Check(result.Language, Equals, "go")
+	c.Check(result.TemplateName, Equals, "module")
+	c.Check(strings.Count(result.SourceCode, "\nimport ("), Equals, 1)
+	c.Check(strings.Count(result.SourceCode, "func DigestAuthorization("), Equals, 1)
+	c.Check(strings.Contains(result.SourceCode, "func NewClient() *http.Client {"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "// GetUsers sends \"GET /users\".\nfunc GetUsers(ctx context.Context, client *http.Client) (*http.Response, error) {"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "// UsersAdminCreateUser sends \"Users / Admin / Create user\".\nfunc UsersAdminCreateUser(ctx context.Context, client *http.Client) (*http.Response, error) {"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "\t\tGetUsers,\n\t\tUsersAdminCreateUser,\n\t\tUsersAdminDeleteUser,\n\t\tGetUsers2,\n"), Equals, true)
+	c.Check(strings.Count(result.SourceCode, "log.Fatal"), Equals, 2)
+}
+
+func (s *ModuleTest) Test_GenerateModule_SharedClient(c *C) {
+	requests := []common.NamedRequest{
+		{Name: "first", Options: parseOptions(c, "-x", "http://proxy1:8080", "-b", "cookies.txt", "http://localhost:18888")},
+		{Name: "second", Options: parseOptions(c, "-k", "-x", "http://proxy2:8080", "-c", "cookies.txt", "http://localhost:18888")},
+	}
+	result, err := generator.GenerateModule(context.Background(), "go", requests, "main")
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "proxyUrl, _ := url.Parse(\"http://proxy1:8080\")"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "InsecureSkipVerify: true"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "SaveCookies(client.Jar, request.URL, \"cookies.txt\")"), Equals, true)
+	c.Check(result.Warnings, DeepEquals, []string{
+		"second: The module shares one client. -k is applied to all requests.",
+		"second: The module shares one client. Proxy http://proxy1:8080 of the first request is used.",
+	})
+}
+
+func (s *ModuleTest) Test_GenerateModule_Python(c *C) {
+	requests := []common.NamedRequest{
+		{Name: "upload", Options: parseOptions(c, "-F", "a=b", "http://localhost:18888")},
+		{Name: "upload", Options: parseOptions(c, "-F", "c=d", "http://localhost:18888")},
+	}
+	result, err := generator.GenerateModule(context.Background(), "python", requests, "main")
+	c.Assert(err, IsNil)
+	c.Check(strings.Count(result.SourceCode, "def encode_multipart_formdata("), Equals, 1)
+	c.Check(strings.Contains(result.SourceCode, "def upload(conn):"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "def upload2(conn):"), Equals, true)
+	c.Check(strings.Count(result.SourceCode, "http.client.HTTPConnection("), Equals, 1)
+	c.Check(strings.Contains(result.SourceCode, "        lambda: upload(conn),\n        lambda: upload2(conn),\n"), Equals, true)
+
+	result, err = generator.GenerateModule(context.Background(), "python.requests", requests, "main")
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "def upload(session):"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "res = session.post("), Equals, true)
+}
+
+func (s *ModuleTest) Test_GenerateModule_PythonConnections(c *C) {
+	requests := []common.NamedRequest{
+		{Name: "local", Options: parseOptions(c, "http://localhost:18888/a")},
+		{Name: "proxy", Options: parseOptions(c, "-x", "http://proxy:8080", "http://example.com/")},
+		{Name: "local", Options: parseOptions(c, "http://localhost:18888/b")},
+	}
+	result, err := generator.GenerateModule(context.Background(), "python", requests, "main")
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "    conn = http.client.HTTPConnection(\"localhost:18888\")\n"+
+		"    conn2 = http.client.HTTPConnection(\"proxy:8080\")\n"+
+		"    conn2.set_tunnel(\"example.com\")\n"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "        lambda: local(conn),\n        lambda: proxy(conn2),\n        lambda: local2(conn),\n"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "    conn.close()\n    conn2.close()\n"), Equals, true)
+}
+
+func (s *ModuleTest) Test_GenerateModule_OtherLanguages(c *C) {
+	expected := map[string][]string{
+		"js.fetch.node": {
+			"function digestAuthorization(",
+			"export async function getUsers() {",
+			"export async function usersAdminCreateUser() {",
+			"if (import.meta.url === pathToFileURL(process.argv[1]).href) {",
+			"        getUsers,\n        usersAdminCreateUser,\n        usersAdminDeleteUser,\n        getUsers2,\n",
+		},
+		"ts.fetch": {
+			"export async function getUsers(): Promise<Response> {",
+		},
+		"java": {
+			"public static HttpURLConnection getUsers() throws IOException {",
+			"public static HttpURLConnection usersAdminCreateUser() throws IOException {",
+			"        print(getUsers());\n        print(usersAdminCreateUser());\n        print(usersAdminDeleteUser());\n        print(getUsers2());\n",
+		},
+		"ruby": {
+			"def get_users\n",
+			"def users_admin_create_user\n",
+			"if __FILE__ == $PROGRAM_NAME\n",
+			"    -> { get_users },\n    -> { users_admin_create_user },\n    -> { users_admin_delete_user },\n    -> { get_users2 },\n",
+		},
+		"php": {
+			"function digest_authorization(",
+			"function get_users() {",
+			"function users_admin_create_user() {",
+			"    function () { return get_users(); },\n    function () { return users_admin_create_user(); },\n",
+		},
+	}
+	for target, fragments := range expected {
+		result, err := generator.GenerateModule(context.Background(), target, namedRequests(c), "main")
+		c.Assert(err, IsNil, Commentf(target))
+		c.Check(result.TemplateName, Equals, "module", Commentf(target))
+		for _, fragment := range fragments {
+			c.Check(strings.Contains(result.SourceCode, fragment), Equals, true, Commentf("%s: %s", target, fragment))
+		}
+	}
+
+	result, err := generator.GenerateFunction(context.Background(), "ruby", parseOptions(c, "http://localhost:18888"), "", "get")
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "def get\n"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "$PROGRAM_NAME"), Equals, false)
+}
+
+func (s *ModuleTest) Test_GenerateModule_Error(c *C) {
+	_, err := generator.GenerateModule(context.Background(), "csharp", namedRequests(c), "main")
+	c.Check(err, FitsTypeOf, &generator.UnsupportedModuleError{})
+	c.Check(err, ErrorMatches, "'csharp' can't generate functions of requests in one file. .* supported by go, python, .*")
+	requests := []common.NamedRequest{{Name: "broken", Options: parseOptions(c, "-X", "PUT", "-F", "a=b")}}
+	_, err = generator.GenerateModule(context.Background(), "go", requests, "main")
+	c.Check(err, ErrorMatches, "broken: option --url: .*")
+}
+
+func (s *ModuleTest) Test_GenerateFiles(c *C) {
+	files, err := generator.GenerateFiles(context.Background(), "py", namedRequests(c))
+	c.Assert(err, IsNil)
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name)
+	}
+	c.Check(names, DeepEquals, []string{"get_users.py", "users/admin/create_user.py", "users/admin/delete_user.py", "get_users2.py"})
+
+	files, err = generator.GenerateFiles(context.Background(), "java", namedRequests(c)[:1])
+	c.Assert(err, IsNil)
+	c.Check(files[0].Name, Equals, "GetUsers.java")
+	c.Check(strings.Contains(files[0].Result.SourceCode, "public class GetUsers {"), Equals, true)
+}
+
+func (s *ModuleTest) Test_ExportAndImport(c *C) {
+	options := parseOptions(c, "-H", "Accept: application/json", "-b", "a=b", "-u", "user:pass", "-F", "file=@a.txt;type=text/plain", "-F", "title=hello", "-k", "http://localhost:18888/upload?x=1")
+	for _, target := range []string{"har", "postman"} {
+		result, err := generator.Generate(context.Background(), target, options)
+		c.Assert(err, IsNil)
+		var requests []common.NamedRequest
+		if target == "har" {
+			requests, err = common.ParseHar([]byte(result.SourceCode))
+		} else {
+			requests, err = common.ParsePostmanCollection([]byte(result.SourceCode))
+		}
+		c.Assert(err, IsNil)
+		c.Assert(requests, HasLen, 1)
+		imported := requests[0].Options
+		c.Check(imported.Url, Equals, options.Url, Commentf(target))
+		c.Check(imported.Method(), Equals, "POST", Commentf(target))
+		c.Check(imported.ProcessedData, DeepEquals, options.ProcessedData, Commentf(target))
+		c.Check(imported.Cookies(), DeepEquals, options.Cookies(), Commentf(target))
+		c.Check(imported.FindContentTypeHeader(), Equals, "", Commentf(target))
+	}
+}
+
+func (s *ModuleTest) Test_GenerateFunction(c *C) {
+	options := parseOptions(c, "-u", "user:pass", "--digest", "-d", "name=bob", "http://localhost:18888/users")
+	result, err := generator.GenerateFunction(context.Background(), "go", options, "api", "create user")
+	c.Assert(err, IsNil)
+	c.Check(strings.HasPrefix(result.SourceCode, "package api\n"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "func CreateUser(ctx context.Context, client *http.Client) (*http.Response, error) {"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "func NewClient() *http.Client {"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "func main()"), Equals, false)
+	c.Check(strings.Contains(result.SourceCode, "log."), Equals, false)
+
+	result, err = generator.GenerateFunction(context.Background(), "python", options, "", "CreateUser")
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "def create_user(conn):"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "http.client.HTTPConnection("), Equals, false)
+	c.Check(strings.Contains(result.SourceCode, "print("), Equals, false)
+
+	result, err = generator.GenerateFunction(context.Background(), "python.requests", options, "", "")
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "def send_request(session):"), Equals, true)
+
+	result, err = generator.GenerateFunction(context.Background(), "java", options, "com.example.api", "create user")
+	c.Assert(err, IsNil)
+	c.Check(strings.HasPrefix(result.SourceCode, "package com.example.api;\n\nimport "), Equals, true)
+	result, err = generator.GenerateFunction(context.Background(), "java", options, "main", "create user")
+	c.Assert(err, IsNil)
+	c.Check(strings.HasPrefix(result.SourceCode, "import "), Equals, true)
+}
+
+func (s *ModuleTest) Test_ModuleTargets(c *C) {
+	languages := make(map[string]bool)
+	for _, target := range generator.ModuleTargets() {
+		c.Check(generator.HasModule(target), Equals, true, Commentf(target))
+		languages[generator.LanguageMap[target]] = true
+	}
+	for target, lang := range generator.LanguageMap {
+		c.Check(generator.HasModule(target), Equals, languages[lang], Commentf(target))
+	}
+	c.Check(generator.HasPackage("golang"), Equals, true)
+	c.Check(generator.HasPackage("java"), Equals, true)
+	c.Check(generator.HasPackage("python"), Equals, false)
+}
+
+func (s *ModuleTest) Test_GenerateFunction_Error(c *C) {
+	_, err := generator.GenerateFunction(context.Background(), "csharp", parseOptions(c, "http://localhost:18888"), "main", "get")
+	c.Check(err, FitsTypeOf, &generator.UnsupportedFunctionError{})
+	c.Check(err, ErrorMatches, "'csharp' can't generate a function. .* supported by go, python, .*")
+	_, err = generator.GenerateFunction(context.Background(), "go", parseOptions(c, "-X", "PUT", "-F", "a=b"), "main", "put")
+	c.Check(err, FitsTypeOf, &common.InvalidOptionError{})
+
+	// Go code that gofmt can't format is returned as is
+	result, err := generator.GenerateFunction(context.Background(), "go", parseOptions(c, "http://localhost:18888"), "my-api", "get")
+	c.Check(err, FitsTypeOf, &generator.SyntaxError{})
+	c.Check(strings.HasPrefix(result.SourceCode, "package my-api\n"), Equals, true)
+}
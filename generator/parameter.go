@@ -0,0 +1,398 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+/*
+	Rules to find string literals in generated code.
+*/
+type stringSyntax struct {
+	quotes      string // characters that start string literals
+	rawQuotes   string // quotes without escape sequences
+	doubled     string // quotes that are escaped by writing twice
+	escape      byte   // escape character in literals like '\\'
+	prefixes    string // characters just before quotes like r"" and @""
+	lineComment string
+	// backslash escapes characters out of quotes like shell
+	escapeOutside bool
+
+	// interpolation in literal like "\(value)" of Swift
+	interpolationQuote byte
+	interpolationOpen  string
+	interpolationClose byte
+}
+
+var cStrings = stringSyntax{quotes: "\"'", escape: '\\', prefixes: "@", lineComment: "//"}
+
+/*
+	String literal in generated code. source[begin:start] is the prefix and the opening quote,
+	source[start:end] is the content and source[begin:stop] is the whole literal.
+	Literals that have interpolations are split into parts between interpolations.
+*/
+type literal struct {
+	begin, start, end, stop int
+	prefix                  string
+	quote                   byte
+	interpolated            bool
+}
+
+/*
+	Find string literals. Quotes in comments are ignored.
+*/
+func (self *stringSyntax) literals(source string) []literal {
+	var result []literal
+	var current literal
+	var stack []literal // literals that have interpolations
+	var depth []int
+	inLiteral := false
+	for i := 0; i < len(source); {
+		ch := source[i]
+		if !inLiteral {
+			if self.lineComment != "" && strings.HasPrefix(source[i:], self.lineComment) {
+				for i < len(source) && source[i] != '\n' {
+					i++
+				}
+				continue
+			}
+			if len(stack) > 0 {
+				if ch == self.interpolationOpen[len(self.interpolationOpen)-1] {
+					depth[len(depth)-1]++
+				} else if ch == self.interpolationClose {
+					depth[len(depth)-1]--
+					if depth[len(depth)-1] == 0 {
+						current, stack = stack[len(stack)-1], stack[:len(stack)-1]
+						depth = depth[:len(depth)-1]
+						current.begin, current.start = i+1, i+1
+						inLiteral = true
+					}
+				}
+			}
+			if !inLiteral && strings.IndexByte(self.quotes, ch) != -1 {
+				begin := i
+				for begin > 0 && strings.IndexByte(self.prefixes, source[begin-1]) != -1 {
+					begin--
+				}
+				current = literal{begin: begin, start: i + 1, prefix: source[begin:i], quote: ch,
+					interpolated: self.interpolationOpen != "" && ch == self.interpolationQuote}
+				inLiteral = true
+			} else if !inLiteral && ch == '\\' && self.escapeOutside {
+				i++
+			}
+			i++
+			continue
+		}
+		switch {
+		case strings.IndexByte(self.doubled, ch) != -1 && ch == current.quote && i+1 < len(source) && source[i+1] == ch:
+			i += 2
+		case ch == current.quote:
+			current.end, current.stop = i, i+1
+			result = append(result, current)
+			inLiteral = false
+			i++
+		case current.quote == self.interpolationQuote && self.interpolationOpen != "" && strings.HasPrefix(source[i:], self.interpolationOpen):
+			part := current
+			part.end, part.stop = i, i
+			result = append(result, part)
+			stack = append(stack, current)
+			depth = append(depth, 1)
+			inLiteral = false
+			i += len(self.interpolationOpen)
+		case ch == self.escape && strings.IndexByte(self.rawQuotes, current.quote) == -1 && !strings.Contains(current.prefix, "@"):
+			i += 2
+		default:
+			i++
+		}
+	}
+	return result
+}
+
+/*
+	Substitution styles of parameters.
+*/
+const (
+	concatStyle     = iota // ("Bearer " + os.Getenv("TOKEN"))
+	formatStyle            // format!("Bearer {}", std::env::var("TOKEN").unwrap())
+	shellStyle             // 'Bearer '"${TOKEN}"
+	powerShellStyle        // "Bearer ${env:TOKEN}"
+	replaceStyle           // %TOKEN%
+)
+
+/*
+	How to write parameters in generated code of the language.
+*/
+type parameterSyntax struct {
+	strings stringSyntax
+	style   int
+	// operator of concatStyle. Format function of formatStyle like `format!("%s", %s)`
+	concat string
+	// placeholder in the format string of formatStyle and characters escaped by doubling in the format string
+	placeholder, formatEscapes string
+	// environment variable expression like `os.Getenv("%s")`
+	environment string
+	// modify the whole code for environment variables. e.g. adding imports
+	prepare func(source string, parameters []common.Parameter) string
+}
+
+var parameterSyntaxes = map[string]parameterSyntax{
+	"go":                   {strings: stringSyntax{quotes: "\"'`", rawQuotes: "`", escape: '\\', lineComment: "//"}, concat: " + ", environment: `os.Getenv("%s")`, prepare: addGoImport},
+	"python":               {strings: stringSyntax{quotes: "\"'", escape: '\\', prefixes: "rbfuRBFU", lineComment: "#"}, concat: " + ", environment: `os.environ['%s']`, prepare: addPythonImport},
+	"python_requests":      {strings: stringSyntax{quotes: "\"'", escape: '\\', prefixes: "rbfuRBFU", lineComment: "#"}, concat: " + ", environment: `os.environ['%s']`, prepare: addPythonImport},
+	"python_httpx":         {strings: stringSyntax{quotes: "\"'", escape: '\\', prefixes: "rbfuRBFU", lineComment: "#"}, concat: " + ", environment: `os.environ['%s']`, prepare: addPythonImport},
+	"python_httpx_async":   {strings: stringSyntax{quotes: "\"'", escape: '\\', prefixes: "rbfuRBFU", lineComment: "#"}, concat: " + ", environment: `os.environ['%s']`, prepare: addPythonImport},
+	"node":                 {strings: jsStrings, concat: " + ", environment: "process.env.%s"},
+	"fetch_node":           {strings: jsStrings, concat: " + ", environment: "process.env.%s"},
+	"fetch_ts":             {strings: jsStrings, concat: " + ", environment: `(process.env.%s ?? "")`},
+	"xhr":                  {strings: jsStrings, concat: " + ", environment: "%s", prepare: addJavaScriptConstants},
+	"fetch_browser":        {strings: jsStrings, concat: " + ", environment: "%s", prepare: addJavaScriptConstants},
+	"java":                 {strings: cStrings, concat: " + ", environment: `System.getenv("%s")`},
+	"java_httpclient":      {strings: cStrings, concat: " + ", environment: `System.getenv("%s")`},
+	"kotlin_okhttp":        {strings: cStrings, concat: " + ", environment: `System.getenv("%s")`},
+	"csharp":               {strings: stringSyntax{quotes: "\"'", doubled: "\"", escape: '\\', prefixes: "@", lineComment: "//"}, concat: " + ", environment: `Environment.GetEnvironmentVariable("%s")`},
+	"objc_nsurlsession":    {strings: cStrings, style: formatStyle, concat: "[NSString stringWithFormat:@\"%s\", %s]", placeholder: "%@", formatEscapes: "%", environment: `NSProcessInfo.processInfo.environment[@"%s"]`},
+	"objc_nsurlconnection": {strings: cStrings, style: formatStyle, concat: "[NSString stringWithFormat:@\"%s\", %s]", placeholder: "%@", formatEscapes: "%", environment: `NSProcessInfo.processInfo.environment[@"%s"]`},
+	"php":                  {strings: stringSyntax{quotes: "\"'", escape: '\\', lineComment: "//"}, concat: " . ", environment: "getenv('%s')"},
+	"ruby":                 {strings: stringSyntax{quotes: "\"'", escape: '\\', lineComment: "#"}, concat: " + ", environment: "ENV.fetch('%s')"},
+	"ruby_faraday":         {strings: stringSyntax{quotes: "\"'", escape: '\\', lineComment: "#"}, concat: " + ", environment: "ENV.fetch('%s')"},
+	"rust":                 {strings: stringSyntax{quotes: "\"", escape: '\\', lineComment: "//"}, style: formatStyle, concat: "format!(\"%s\", %s)", placeholder: "{}", formatEscapes: "{}", environment: `std::env::var("%s").unwrap()`},
+	"rust_async":           {strings: stringSyntax{quotes: "\"", escape: '\\', lineComment: "//"}, style: formatStyle, concat: "format!(\"%s\", %s)", placeholder: "{}", formatEscapes: "{}", environment: `std::env::var("%s").unwrap()`},
+	"swift":                {strings: swiftStrings, concat: " + ", environment: `ProcessInfo.processInfo.environment["%s"]!`},
+	"swift_async":          {strings: swiftStrings, concat: " + ", environment: `ProcessInfo.processInfo.environment["%s"]!`},
+	"vim":                  {strings: stringSyntax{quotes: "\"'", rawQuotes: "'", doubled: "'", escape: '\\'}, concat: " . ", environment: "$%s"},
+	"httpie":               {strings: shellStrings, style: shellStyle, environment: "${%s}"},
+	"wget":                 {strings: shellStrings, style: shellStyle, environment: "${%s}"},
+	"curl":                 {strings: shellStrings, style: shellStyle, environment: "${%s}"},
+	"curl_oneline":         {strings: shellStrings, style: shellStyle, environment: "${%s}"},
+	"curl_cmd":             {style: replaceStyle, environment: "%%%s%%"},
+	"curl_cmd_oneline":     {style: replaceStyle, environment: "%%%s%%"},
+	"powershell":           {strings: stringSyntax{quotes: "\"'", rawQuotes: "'", doubled: "'\"", escape: '`', lineComment: "#"}, style: powerShellStyle, environment: "${env:%s}"},
+}
+
+var jsStrings = stringSyntax{quotes: "\"'`", escape: '\\', lineComment: "//", interpolationQuote: '`', interpolationOpen: "${", interpolationClose: '}'}
+var swiftStrings = stringSyntax{quotes: "\"", escape: '\\', lineComment: "//", interpolationQuote: '"', interpolationOpen: "\\(", interpolationClose: ')'}
+var shellStrings = stringSyntax{quotes: "\"'", rawQuotes: "'", escape: '\\', escapeOutside: true}
+
+/*
+	Replace markers of parameters in generated code with expressions of the target language.
+	expression returns the expression of the parameter. When it is nil, parameters are read from environment variables.
+	Secrets are always read from environment variables.
+	Postman uses its variables like {{token}}. Markers out of string literals get the original text of the command
+	with warnings.
+*/
+func substituteParameters(lang, source string, parameters []common.Parameter, expression func(common.Parameter) string) (string, []string) {
+	if !common.ParameterMarkerPattern.MatchString(source) {
+		return source, nil
+	}
+	find := func(name string) common.Parameter {
+		for _, parameter := range parameters {
+			if parameter.Name == name {
+				return parameter
+			}
+		}
+		return common.Parameter{Name: name, Original: name}
+	}
+	if lang == "postman" {
+		return common.ParameterMarkerPattern.ReplaceAllString(source, "{{$1}}"), nil
+	}
+	syntax, ok := parameterSyntaxes[lang]
+	if !ok {
+		return common.RestoreParameters(source, parameters), []string{fmt.Sprintf("%s doesn't support parameters. Values of the command are used.", lang)}
+	}
+	// secrets are always read from environment variables
+	environment := false
+	parameterExpression := expression
+	expression = func(parameter common.Parameter) string {
+		if parameterExpression == nil || parameter.Secret {
+			environment = true
+			return fmt.Sprintf(syntax.environment, parameter.EnvironmentVariable())
+		}
+		return parameterExpression(parameter)
+	}
+
+	var warnings []string
+	var buffer bytes.Buffer
+	position := 0
+	// markers in literals are replaced with the literals
+	if syntax.style != replaceStyle {
+		for _, literal := range syntax.strings.literals(source) {
+			content := source[literal.start:literal.end]
+			if !common.ParameterMarkerPattern.MatchString(content) {
+				continue
+			}
+			buffer.WriteString(substituteOutOfLiteral(source[position:literal.begin], &syntax, find, expression, &warnings))
+			buffer.WriteString(syntax.rewrite(literal, source[literal.begin:literal.stop], content, find, expression))
+			position = literal.stop
+		}
+	}
+	buffer.WriteString(substituteOutOfLiteral(source[position:], &syntax, find, expression, &warnings))
+	result := buffer.String()
+	if syntax.prepare != nil && environment {
+		result = syntax.prepare(result, parameters)
+	}
+	if lang == "go" {
+		if formatted, err := format.Source([]byte(result)); err == nil {
+			result = string(formatted)
+		}
+	}
+	return result, warnings
+}
+
+/*
+	Shell and cmd.exe can use environment variables out of quotes. Other languages restore original values
+	(or references of secrets).
+*/
+func substituteOutOfLiteral(source string, syntax *parameterSyntax, find func(string) common.Parameter, expression func(common.Parameter) string, warnings *[]string) string {
+	return common.ParameterMarkerPattern.ReplaceAllStringFunc(source, func(marker string) string {
+		parameter := find(common.ParameterMarkerPattern.FindStringSubmatch(marker)[1])
+		switch syntax.style {
+		case replaceStyle:
+			return expression(parameter)
+		case shellStyle:
+			return "\"" + expression(parameter) + "\""
+		case powerShellStyle:
+			return "\"" + expression(parameter) + "\""
+		}
+		*warnings = append(*warnings, fmt.Sprintf("Parameter %s is not in a string literal. %s is written instead.", parameter.Name, parameter.Fallback()))
+		return parameter.Fallback()
+	})
+}
+
+/*
+	Write the literal with parameters. text is the whole literal and content is between the quotes.
+*/
+func (self *parameterSyntax) rewrite(literal literal, text, content string, find func(string) common.Parameter, expression func(common.Parameter) string) string {
+	if literal.interpolated {
+		return common.ParameterMarkerPattern.ReplaceAllStringFunc(text, func(marker string) string {
+			value := expression(find(common.ParameterMarkerPattern.FindStringSubmatch(marker)[1]))
+			return self.strings.interpolationOpen + value + string(self.strings.interpolationClose)
+		})
+	}
+	matches := common.ParameterMarkerPattern.FindAllStringSubmatchIndex(content, -1)
+	quote := string(literal.quote)
+	switch self.style {
+	case shellStyle:
+		if literal.quote == '"' {
+			return quote + common.ParameterMarkerPattern.ReplaceAllStringFunc(content, func(marker string) string {
+				return expression(find(common.ParameterMarkerPattern.FindStringSubmatch(marker)[1]))
+			}) + quote
+		}
+		// variables are not expanded in single quotes. 'Bearer '"${TOKEN}"
+		var buffer bytes.Buffer
+		position := 0
+		for _, match := range matches {
+			if match[0] > position {
+				buffer.WriteString(quote + content[position:match[0]] + quote)
+			}
+			buffer.WriteString("\"" + expression(find(content[match[2]:match[3]])) + "\"")
+			position = match[1]
+		}
+		if position < len(content) {
+			buffer.WriteString(quote + content[position:] + quote)
+		}
+		return buffer.String()
+	case powerShellStyle:
+		if literal.quote == '\'' {
+			// single quoted string can't have variables
+			content = strings.Replace(content, "''", "'", -1)
+			content = strings.NewReplacer("`", "``", "\"", "`\"", "$", "`$").Replace(content)
+		}
+		return literal.prefix + "\"" + common.ParameterMarkerPattern.ReplaceAllStringFunc(content, func(marker string) string {
+			return expression(find(common.ParameterMarkerPattern.FindStringSubmatch(marker)[1]))
+		}) + "\""
+	case formatStyle:
+		var format bytes.Buffer
+		var arguments []string
+		escape := func(text string) string {
+			for _, ch := range self.formatEscapes {
+				text = strings.Replace(text, string(ch), string(ch)+string(ch), -1)
+			}
+			return text
+		}
+		position := 0
+		for _, match := range matches {
+			format.WriteString(escape(content[position:match[0]]))
+			format.WriteString(self.placeholder)
+			arguments = append(arguments, expression(find(content[match[2]:match[3]])))
+			position = match[1]
+		}
+		format.WriteString(escape(content[position:]))
+		return fmt.Sprintf(self.concat, format.String(), strings.Join(arguments, ", "))
+	}
+	var pieces []string
+	position := 0
+	addText := func(text string) {
+		if text != "" {
+			pieces = append(pieces, literal.prefix+quote+text+quote)
+		}
+	}
+	for _, match := range matches {
+		addText(content[position:match[0]])
+		value := expression(find(content[match[2]:match[3]]))
+		if strings.ContainsAny(literal.prefix, "bB") {
+			// Python's bytes literal
+			value += ".encode()"
+		}
+		pieces = append(pieces, value)
+		position = match[1]
+	}
+	addText(content[position:])
+	if len(pieces) == 1 {
+		return pieces[0]
+	}
+	return "(" + strings.Join(pieces, self.concat) + ")"
+}
+
+func addGoImport(source string, parameters []common.Parameter) string {
+	if strings.Contains(source, "\"os\"") || !strings.Contains(source, "os.Getenv(") {
+		return source
+	}
+	// imports are sorted by gofmt later
+	return strings.Replace(source, "import (\n", "import (\n\t\"os\"\n", 1)
+}
+
+func addPythonImport(source string, parameters []common.Parameter) string {
+	if !strings.Contains(source, "os.environ[") {
+		return source
+	}
+	lines := strings.Split(source, "\n")
+	index := 0
+	for ; index < len(lines) && strings.HasPrefix(lines[index], "import "); index++ {
+		if lines[index] == "import os" {
+			return source
+		} else if lines[index] > "import os" {
+			break
+		}
+	}
+	lines = append(lines[:index], append([]string{"import os"}, lines[index:]...)...)
+	return strings.Join(lines, "\n")
+}
+
+/*
+	Browsers don't have environment variables. Parameters are constants at the top of the script.
+	Browser targets generate HTML, so they are inserted into the first <script> element.
+*/
+func addJavaScriptConstants(source string, parameters []common.Parameter) string {
+	var names []string
+	for _, parameter := range parameters {
+		names = append(names, parameter.EnvironmentVariable())
+	}
+	sort.Strings(names)
+	var buffer bytes.Buffer
+	buffer.WriteString("// parameters of the request\n")
+	for _, name := range names {
+		fmt.Fprintf(&buffer, "const %s = \"\";\n", name)
+	}
+	buffer.WriteString("\n")
+	if start := strings.Index(source, "<script"); start != -1 {
+		if end := strings.IndexByte(source[start:], '\n'); end != -1 {
+			position := start + end + 1
+			return source[:position] + buffer.String() + source[position:]
+		}
+	}
+	return buffer.String() + source
+}
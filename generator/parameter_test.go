@@ -0,0 +1,121 @@
+package generator_test
+
+import (
+	"context"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/generator"
+	. "gopkg.in/check.v1"
+	"strings"
+)
+
+type ParameterTest struct{}
+
+var _ = Suite(&ParameterTest{})
+
+func parameterizedOptions(c *C) *common.CurlOptions {
+	options := parseOptions(c, "-H", "Authorization: Bearer {{token}}", "-d", `{"name": "{{name}}"}`, "http://localhost:18888/users/:id")
+	options.Parameterize(false)
+	return options
+}
+
+func generateWithParameters(c *C, target string) generator.Result {
+	result, err := generator.Generate(context.Background(), target, parameterizedOptions(c))
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "CURLASDSLPARAM"), Equals, false)
+	return result
+}
+
+func (s *ParameterTest) Test_Generate_Go(c *C) {
+	result := generateWithParameters(c, "go")
+	c.Check(result.Warnings, HasLen, 0)
+	c.Check(strings.Contains(result.SourceCode, "\t\"os\"\n"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, `("http://localhost:18888/users/" + os.Getenv("ID"))`), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, `("Bearer " + os.Getenv("TOKEN"))`), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "(`{\"name\": \"` + os.Getenv(\"NAME\") + `\"}`)"), Equals, true)
+}
+
+func (s *ParameterTest) Test_Generate_Python(c *C) {
+	result := generateWithParameters(c, "python.requests")
+	c.Check(strings.HasPrefix(result.SourceCode, "import os\nimport requests\n"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, `("Bearer " + os.environ['TOKEN'])`), Equals, true)
+}
+
+func (s *ParameterTest) Test_Generate_Languages(c *C) {
+	expected := map[string]string{
+		"js.fetch.node":   `("Bearer " + process.env.TOKEN)`,
+		"java.httpclient": `("Bearer " + System.getenv("TOKEN"))`,
+		"csharp":          `("Bearer " + Environment.GetEnvironmentVariable("TOKEN"))`,
+		"objc":            `[NSString stringWithFormat:@"Bearer %@", NSProcessInfo.processInfo.environment[@"TOKEN"]]`,
+		"ruby":            `("Bearer " + ENV.fetch('TOKEN'))`,
+		"rust":            `format!("{{\"name\": \"{}\"}}", std::env::var("NAME").unwrap())`,
+		"swift":           `"Bearer \(ProcessInfo.processInfo.environment["TOKEN"]!)"`,
+		"curl":            `'Authorization: Bearer '"${TOKEN}"`,
+		"curl.cmd":        `^"Authorization: Bearer %TOKEN%^"`,
+		"powershell":      `"Bearer ${env:TOKEN}"`,
+		"postman":         `"Bearer {{token}}"`,
+	}
+	for target, code := range expected {
+		result := generateWithParameters(c, target)
+		c.Check(strings.Contains(result.SourceCode, code), Equals, true, Commentf("%s: %s", target, result.SourceCode))
+	}
+}
+
+func (s *ParameterTest) Test_Generate_Browser(c *C) {
+	for _, target := range []string{"js.xhr", "js.fetch"} {
+		result := generateWithParameters(c, target)
+		c.Check(strings.HasPrefix(result.SourceCode, "<!DOCTYPE html>"), Equals, true, Commentf(target))
+		script := strings.Index(result.SourceCode, "<script")
+		constants := strings.Index(result.SourceCode, "// parameters of the request\n")
+		function := strings.Index(result.SourceCode, "function request(")
+		c.Check(script < constants && constants < function, Equals, true, Commentf("%s: %s", target, result.SourceCode))
+		c.Check(strings.Contains(result.SourceCode, "const ID = \"\";\nconst NAME = \"\";\nconst TOKEN = \"\";\n"), Equals, true, Commentf(target))
+	}
+}
+
+func (s *ParameterTest) Test_Generate_Har(c *C) {
+	result := generateWithParameters(c, "har")
+	c.Check(result.Warnings, DeepEquals, []string{"har can't have parameters. Values of the command are used."})
+	c.Check(strings.Contains(result.SourceCode, `"Bearer {{token}}"`), Equals, true)
+}
+
+func (s *ParameterTest) Test_GenerateFunction(c *C) {
+	result, err := generator.GenerateFunction(context.Background(), "go", parameterizedOptions(c), "api", "get user")
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "func GetUser(ctx context.Context, client *http.Client, id string, token string, name string) (*http.Response, error) {"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, `("Bearer " + token)`), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "os."), Equals, false)
+
+	result, err = generator.GenerateFunction(context.Background(), "python", parameterizedOptions(c), "", "get user")
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "def get_user(conn, id, token, name):"), Equals, true)
+
+	result, err = generator.GenerateFunction(context.Background(), "php", parameterizedOptions(c), "", "get user")
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "function get_user($id, $token, $name) {"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, `("Authorization: Bearer " . $token . "\n")`), Equals, true)
+}
+
+func (s *ParameterTest) Test_GenerateModule(c *C) {
+	requests := []common.NamedRequest{{Name: "get user", Options: parameterizedOptions(c)}}
+	result, err := generator.GenerateModule(context.Background(), "python", requests, "main")
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "import os\n"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "lambda: get_user(conn, os.environ['ID'], os.environ['TOKEN'], os.environ['NAME']),"), Equals, true)
+}
+
+func (s *ParameterTest) Test_Generate_Redact(c *C) {
+	options := parseOptions(c, "-u", "admin:secret", "-H", "X-Api-Key: key", "http://localhost:18888/users/:id")
+	options.Redact()
+	options.Parameterize(false)
+	result, err := generator.GenerateFunction(context.Background(), "go", options, "api", "get user")
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, "secret"), Equals, false)
+	c.Check(strings.Contains(result.SourceCode, "func GetUser(ctx context.Context, client *http.Client, id string) (*http.Response, error) {"), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, `request.Header.Add("X-Api-Key", os.Getenv("API_KEY"))`), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "\t\"os\"\n"), Equals, true)
+
+	result, err = generator.Generate(context.Background(), "har", options)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(result.SourceCode, `"${API_KEY}"`), Equals, true)
+	c.Check(strings.Contains(result.SourceCode, "secret"), Equals, false)
+}
@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"errors"
+	"github.com/shibukawa/curl_as_dsl/syntax"
+)
+
+/*
+	Syntax checkers of languages. Generated code of them is parsed before it is returned.
+	HTML of browser targets is checked by its scripts.
+*/
+var syntaxCheckers = map[string]func(string) error{
+	"python":             syntax.CheckPython,
+	"python_requests":    syntax.CheckPython,
+	"python_httpx":       syntax.CheckPython,
+	"python_httpx_async": syntax.CheckPython,
+	"node":               syntax.CheckJavaScript,
+	"fetch_node":         syntax.CheckJavaScript,
+	"xhr":                syntax.CheckHTMLScripts,
+	"fetch_browser":      syntax.CheckHTMLScripts,
+	"java":               syntax.CheckJava,
+	"java_httpclient":    syntax.CheckJava,
+}
+
+/*
+	Check syntax of generated code. It returns SyntaxError if the code is broken.
+	Languages without checkers are not checked.
+*/
+func validate(lang, language, templateName, sourceCode string) error {
+	checker, ok := syntaxCheckers[lang]
+	if !ok {
+		return nil
+	}
+	err := checker(sourceCode)
+	if err == nil {
+		return nil
+	}
+	var syntaxError *syntax.Error
+	if !errors.As(err, &syntaxError) {
+		return err
+	}
+	result := &SyntaxError{Language: language, Err: syntaxError, Excerpt: syntaxError.Excerpt(sourceCode)}
+	if templateName != "" {
+		result.Template = templatePath(language, templateName)
+	}
+	return result
+}
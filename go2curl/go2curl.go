@@ -0,0 +1,904 @@
+package go2curl
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"mime"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+/*
+	Request is a HTTP request found in Go source code.
+	Options keeps the equivalent curl options.
+*/
+type Request struct {
+	Options  *common.CurlOptions
+	Position token.Position
+	Warnings []string
+}
+
+func newRequest(position token.Position) *Request {
+	var options common.CurlOptions
+	options.Init()
+	return &Request{Options: &options, Position: position}
+}
+
+func (self *Request) warning(format string, a ...interface{}) {
+	self.Warnings = append(self.Warnings, fmt.Sprintf("%s: %s", self.Position, fmt.Sprintf(format, a...)))
+}
+
+func CreateRequestsFromReader(reader io.Reader) ([]*Request, error) {
+	src, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return CreateRequestsFromSource("main.go", src)
+}
+
+func CreateRequestsFromString(source string) ([]*Request, error) {
+	return CreateRequestsFromSource("main.go", []byte(source))
+}
+
+func CreateRequestsFromSource(fileName string, src []byte) ([]*Request, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, fileName, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	signers := findAWSV2Signers(file)
+
+	var result []*Request
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		scope := newScope(fileSet, signers)
+		scope.walkBlock(funcDecl.Body)
+		scope.finish(funcDecl.Body)
+		result = append(result, scope.requests...)
+	}
+	if len(result) == 0 {
+		return nil, errors.New("http.NewRequest or http.Get/Post/PostForm call is not in Go source.")
+	}
+	return result, nil
+}
+
+/*
+	It finds functions like SignAWSV2 that client/golang writes for --awsv2 option
+	and returns a map from function name to "ACCESS-KEY:SECRET-KEY".
+*/
+func findAWSV2Signers(file *ast.File) map[string]string {
+	result := make(map[string]string)
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		var accessKey, secretKey string
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if isCall(call, "hmac", "New") && len(call.Args) == 2 {
+				if value, ok := stringLiteral(unwrapConversion(call.Args[1])); ok {
+					secretKey = value
+				}
+			} else if isCall(call, "fmt", "Sprintf") && len(call.Args) == 3 {
+				format, ok := stringLiteral(call.Args[0])
+				if ok && strings.HasPrefix(format, "AWS ") {
+					if value, ok := stringLiteral(call.Args[1]); ok {
+						accessKey = value
+					}
+				}
+			}
+			return true
+		})
+		if accessKey != "" && secretKey != "" {
+			result[funcDecl.Name.Name] = fmt.Sprintf("%s:%s", accessKey, secretKey)
+		}
+	}
+	return result
+}
+
+// body is a data source that is sent as request body (bytes.Buffer, url.Values, os.File and so on)
+type body struct {
+	data common.DataOptions
+}
+
+// part is a writer returned from multipart.Writer's CreateFormFile or CreatePart
+type part struct {
+	body        *body
+	name        string
+	fileName    string
+	contentType string
+}
+
+type scope struct {
+	fileSet  *token.FileSet
+	signers  map[string]string
+	strings  map[string]string
+	bodies   map[string]*body
+	files    map[string]string // variable -> path (os.Open)
+	contents map[string]string // variable -> path (ioutil.ReadFile)
+	parts    map[string]*part
+	headers  map[string]map[string]string // textproto.MIMEHeader
+	proxyUrl map[string]string
+	request  map[string]*Request
+	requests []*Request
+}
+
+func newScope(fileSet *token.FileSet, signers map[string]string) *scope {
+	return &scope{
+		fileSet:  fileSet,
+		signers:  signers,
+		strings:  make(map[string]string),
+		bodies:   make(map[string]*body),
+		files:    make(map[string]string),
+		contents: make(map[string]string),
+		parts:    make(map[string]*part),
+		headers:  make(map[string]map[string]string),
+		proxyUrl: make(map[string]string),
+		request:  make(map[string]*Request),
+	}
+}
+
+//--- Statement walker
+
+func (self *scope) walkBlock(block *ast.BlockStmt) {
+	if block == nil {
+		return
+	}
+	for _, stmt := range block.List {
+		self.walkStmt(stmt)
+	}
+}
+
+func (self *scope) walkStmt(stmt ast.Stmt) {
+	switch stmt := stmt.(type) {
+	case *ast.BlockStmt:
+		self.walkBlock(stmt)
+		return
+	case *ast.IfStmt:
+		if stmt.Init != nil {
+			self.walkStmt(stmt.Init)
+		}
+		self.walkBlock(stmt.Body)
+		if stmt.Else != nil {
+			self.walkStmt(stmt.Else)
+		}
+		return
+	case *ast.ForStmt:
+		self.walkBlock(stmt.Body)
+		return
+	case *ast.RangeStmt:
+		self.walkBlock(stmt.Body)
+		return
+	case *ast.DeclStmt:
+		genDecl, ok := stmt.Decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			return
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			if len(valueSpec.Values) == 0 {
+				if isType(valueSpec.Type, "bytes", "Buffer") || isType(valueSpec.Type, "strings", "Builder") {
+					for _, name := range valueSpec.Names {
+						self.bodies[name.Name] = &body{}
+					}
+				}
+				continue
+			}
+			for i, name := range valueSpec.Names {
+				if i < len(valueSpec.Values) {
+					self.assign([]string{name.Name}, valueSpec.Values[i])
+				}
+			}
+		}
+	case *ast.AssignStmt:
+		if len(stmt.Rhs) != 1 {
+			for i, rhs := range stmt.Rhs {
+				if ident, ok := stmt.Lhs[i].(*ast.Ident); ok {
+					self.assign([]string{ident.Name}, rhs)
+				}
+			}
+			return
+		}
+		var names []string
+		for _, lhs := range stmt.Lhs {
+			if ident, ok := lhs.(*ast.Ident); ok {
+				names = append(names, ident.Name)
+			} else {
+				names = append(names, "_")
+			}
+		}
+		self.assign(names, stmt.Rhs[0])
+	case *ast.ExprStmt:
+		if call, ok := stmt.X.(*ast.CallExpr); ok {
+			self.call(call)
+		}
+	}
+	// Function literals (callbacks, goroutines) share the variables of their parent function.
+	ast.Inspect(stmt, func(node ast.Node) bool {
+		if funcLit, ok := node.(*ast.FuncLit); ok {
+			self.walkBlock(funcLit.Body)
+			return false
+		}
+		return true
+	})
+}
+
+func (self *scope) assign(names []string, rhs ast.Expr) {
+	name := names[0]
+	if name == "_" {
+		return
+	}
+	if call, ok := rhs.(*ast.CallExpr); ok {
+		switch {
+		case isCall(call, "http", "NewRequest"), isCall(call, "http", "NewRequestWithContext"):
+			self.request[name] = self.newRequest(call)
+			return
+		case isCall(call, "http", "Get"), isCall(call, "http", "Head"), isCall(call, "http", "Post"), isCall(call, "http", "PostForm"):
+			self.call(call)
+			return
+		case isCall(call, "multipart", "NewWriter") && len(call.Args) == 1:
+			b := self.bodyFromExpr(call.Args[0])
+			if b == nil {
+				b = &body{}
+			}
+			self.bodies[name] = b
+			return
+		case isCall(call, "os", "Open") && len(call.Args) == 1:
+			if path, ok := self.stringValue(call.Args[0]); ok {
+				self.files[name] = path
+			}
+			return
+		case isCall(call, "ioutil", "ReadFile") && len(call.Args) == 1, isCall(call, "os", "ReadFile") && len(call.Args) == 1:
+			if path, ok := self.stringValue(call.Args[0]); ok {
+				self.contents[name] = path
+			}
+			return
+		case isCall(call, "url", "Parse") && len(call.Args) == 1:
+			if value, ok := self.stringValue(call.Args[0]); ok {
+				self.proxyUrl[name] = value
+			}
+			return
+		case isCall(call, "", "make") && len(call.Args) > 0 && isType(call.Args[0], "textproto", "MIMEHeader"):
+			self.headers[name] = make(map[string]string)
+			return
+		}
+		if selector, ok := call.Fun.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				if writer, ok := self.bodies[ident.Name]; ok {
+					switch selector.Sel.Name {
+					case "CreateFormFile":
+						if len(call.Args) == 2 {
+							key, _ := self.stringValue(call.Args[0])
+							fileName, _ := self.stringValue(call.Args[1])
+							self.parts[name] = &part{body: writer, name: key, fileName: fileName}
+						}
+						return
+					case "CreateFormField":
+						if len(call.Args) == 1 {
+							key, _ := self.stringValue(call.Args[0])
+							self.parts[name] = &part{body: writer, name: key}
+						}
+						return
+					case "CreatePart":
+						if len(call.Args) == 1 {
+							self.parts[name] = self.partFromHeader(writer, call.Args[0])
+						}
+						return
+					}
+				}
+			}
+		}
+	}
+	if b := self.bodyFromExpr(rhs); b != nil {
+		self.bodies[name] = b
+		return
+	}
+	if value, ok := self.stringValue(rhs); ok {
+		self.strings[name] = value
+	}
+}
+
+func (self *scope) partFromHeader(writer *body, expr ast.Expr) *part {
+	result := &part{body: writer}
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return result
+	}
+	header := self.headers[ident.Name]
+	if header == nil {
+		return result
+	}
+	_, params, err := mime.ParseMediaType(header["content-disposition"])
+	if err == nil {
+		result.name = params["name"]
+		result.fileName = params["filename"]
+	}
+	result.contentType = header["content-type"]
+	return result
+}
+
+func (self *scope) call(call *ast.CallExpr) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		if ident, ok := call.Fun.(*ast.Ident); ok {
+			if keys, ok := self.signers[ident.Name]; ok && len(call.Args) > 0 {
+				if request := self.requestFromExpr(call.Args[0]); request != nil {
+					request.Options.AWSV2 = keys
+				}
+			}
+		}
+		return
+	}
+	method := selector.Sel.Name
+
+	// http.Get, http.Post...
+	if pkg, ok := selector.X.(*ast.Ident); ok && pkg.Name == "http" {
+		request := newRequest(self.fileSet.Position(call.Pos()))
+		switch method {
+		case "Get", "Head":
+			if len(call.Args) != 1 {
+				return
+			}
+			self.setUrl(request, call.Args[0])
+			if method == "Head" {
+				request.Options.Head = true
+			}
+		case "Post":
+			if len(call.Args) != 3 {
+				return
+			}
+			self.setUrl(request, call.Args[0])
+			self.setBody(request, call.Args[2])
+			if contentType, _ := self.stringValue(call.Args[1]); contentType != "" {
+				self.addHeader(request, "Content-Type", contentType)
+			}
+			if request.Options.Method() != "POST" {
+				request.Options.Request = "POST"
+			}
+		case "PostForm":
+			if len(call.Args) != 2 {
+				return
+			}
+			self.setUrl(request, call.Args[0])
+			self.setBody(request, call.Args[1])
+			if request.Options.Method() != "POST" {
+				request.Options.Request = "POST"
+			}
+		default:
+			return
+		}
+		self.requests = append(self.requests, request)
+		return
+	}
+
+	// request.Header.Add(), request.Header.Set()
+	if headerSelector, ok := selector.X.(*ast.SelectorExpr); ok && headerSelector.Sel.Name == "Header" {
+		if method != "Add" && method != "Set" || len(call.Args) != 2 {
+			return
+		}
+		request := self.requestFromExpr(headerSelector.X)
+		if request == nil {
+			return
+		}
+		key, ok := self.stringValue(call.Args[0])
+		if !ok {
+			request.warning("header name is not a constant string")
+			return
+		}
+		value, ok := self.stringValue(call.Args[1])
+		if !ok && !strings.EqualFold(key, "Content-Type") {
+			request.warning("the value of header '%s' is not a constant string", key)
+			return
+		}
+		self.addHeader(request, key, value)
+		return
+	}
+
+	ident, ok := selector.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+	name := ident.Name
+
+	if request, ok := self.request[name]; ok {
+		switch method {
+		case "SetBasicAuth":
+			if len(call.Args) == 2 {
+				user, ok1 := self.stringValue(call.Args[0])
+				password, ok2 := self.stringValue(call.Args[1])
+				if ok1 && ok2 {
+					request.Options.User = fmt.Sprintf("%s:%s", user, password)
+				} else {
+					request.warning("SetBasicAuth() parameters are not constant strings")
+				}
+			}
+		case "AddCookie":
+			if len(call.Args) == 1 {
+				self.addCookie(request, call.Args[0])
+			}
+		}
+		return
+	}
+
+	if header, ok := self.headers[name]; ok {
+		if (method == "Add" || method == "Set") && len(call.Args) == 2 {
+			key, _ := self.stringValue(call.Args[0])
+			value, _ := self.stringValue(call.Args[1])
+			header[strings.ToLower(key)] = value
+		}
+		return
+	}
+
+	if b, ok := self.bodies[name]; ok {
+		switch method {
+		case "WriteString", "Write":
+			if len(call.Args) == 1 {
+				if data, ok := self.dataValue(call.Args[0]); ok {
+					b.data = append(b.data, data)
+				}
+			}
+		case "WriteField":
+			if len(call.Args) == 2 {
+				key, _ := self.stringValue(call.Args[0])
+				value, _ := self.stringValue(call.Args[1])
+				formType := common.FormType
+				if strings.HasPrefix(value, "@") || strings.HasPrefix(value, "<") {
+					formType = common.FormStringType
+				}
+				b.data.Append(fmt.Sprintf("%s=%s", key, value), formType)
+			}
+		case "Add", "Set":
+			// url.Values
+			if len(call.Args) == 2 {
+				key, _ := self.stringValue(call.Args[0])
+				value, _ := self.stringValue(call.Args[1])
+				b.data.Append(fmt.Sprintf("%s=%s", key, value), common.DataAsciiType)
+			}
+		}
+		return
+	}
+
+	// io.Copy(fileWriter, file), io.Copy(&buffer, file)
+	if name == "io" && method == "Copy" && len(call.Args) == 2 {
+		source, ok := call.Args[1].(*ast.Ident)
+		if !ok {
+			return
+		}
+		path, ok := self.files[source.Name]
+		if !ok {
+			return
+		}
+		if destination, ok := call.Args[0].(*ast.Ident); ok {
+			if p, ok := self.parts[destination.Name]; ok {
+				p.body.data.Append(p.formValue(path), common.FormType)
+				return
+			}
+		}
+		if b := self.bodyFromExpr(call.Args[0]); b != nil {
+			b.data.Append("@"+path, common.DataBinaryType)
+		}
+	}
+}
+
+func (self *part) formValue(path string) string {
+	var buffer bytes.Buffer
+	if self.fileName != "" {
+		fmt.Fprintf(&buffer, "%s=@%s", self.name, path)
+		if self.fileName != path && self.fileName != filepath.Base(path) {
+			fmt.Fprintf(&buffer, ";filename=%s", self.fileName)
+		}
+	} else {
+		fmt.Fprintf(&buffer, "%s=<%s", self.name, path)
+	}
+	if self.contentType != "" {
+		fmt.Fprintf(&buffer, ";type=%s", self.contentType)
+	}
+	return buffer.String()
+}
+
+func (self *scope) newRequest(call *ast.CallExpr) *Request {
+	args := call.Args
+	if isCall(call, "http", "NewRequestWithContext") && len(args) > 0 {
+		args = args[1:]
+	}
+	request := newRequest(self.fileSet.Position(call.Pos()))
+	self.requests = append(self.requests, request)
+	if len(args) != 3 {
+		request.warning("http.NewRequest() should have 3 parameters")
+		return request
+	}
+	method, ok := self.stringValue(args[0])
+	if !ok {
+		request.warning("method is not a constant string")
+	}
+	self.setUrl(request, args[1])
+	self.setBody(request, args[2])
+	method = strings.ToUpper(method)
+	if method != request.Options.Method() {
+		request.Options.Request = method
+	}
+	return request
+}
+
+func isSingleFileUpload(options *common.CurlOptions) bool {
+	return len(options.ProcessedData) == 1 && options.ProcessedData[0].Type == common.DataBinaryType && options.ProcessedData[0].UseExternalFile()
+}
+
+func (self *scope) setUrl(request *Request, expr ast.Expr) {
+	// "http://example.com" + "?" + values.Encode()
+	if binary, ok := expr.(*ast.BinaryExpr); ok && binary.Op == token.ADD {
+		if call, ok := binary.Y.(*ast.CallExpr); ok {
+			if selector, ok := call.Fun.(*ast.SelectorExpr); ok && (selector.Sel.Name == "Encode" || selector.Sel.Name == "String") {
+				if b := self.bodyFromExpr(selector.X); b != nil {
+					prefix, ok := self.stringValue(binary.X)
+					if ok {
+						request.Options.Url = strings.TrimSuffix(prefix, "?")
+						request.Options.Get = true
+						request.Options.ProcessedData = append(request.Options.ProcessedData, b.data...)
+						return
+					}
+				}
+			}
+		}
+	}
+	value, ok := self.stringValue(expr)
+	if !ok {
+		request.warning("URL is not a constant string")
+	}
+	request.Options.Url = value
+}
+
+func (self *scope) setBody(request *Request, expr ast.Expr) {
+	if ident, ok := expr.(*ast.Ident); ok && ident.Name == "nil" {
+		return
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		if path, ok := self.files[ident.Name]; ok {
+			request.Options.ProcessedData.Append("@"+path, common.DataBinaryType)
+			return
+		}
+	}
+	b := self.bodyFromExpr(expr)
+	if b == nil {
+		request.warning("request body is not a supported expression")
+		return
+	}
+	request.Options.ProcessedData = append(request.Options.ProcessedData, b.data...)
+}
+
+func (self *scope) requestFromExpr(expr ast.Expr) *Request {
+	if ident, ok := expr.(*ast.Ident); ok {
+		return self.request[ident.Name]
+	}
+	return nil
+}
+
+func (self *scope) addHeader(request *Request, key, value string) {
+	options := request.Options
+	switch strings.ToLower(key) {
+	case "content-type":
+		// client/golang adds them for -d and -F
+		if strings.HasPrefix(value, "multipart/form-data") && options.ProcessedData.HasForm() {
+			return
+		}
+		if value == "application/x-www-form-urlencoded" && options.ProcessedData.HasData() {
+			return
+		}
+		if value == "" {
+			request.warning("the value of header '%s' is not a constant string", key)
+			return
+		}
+	case "authorization":
+		if strings.HasPrefix(value, "Basic ") {
+			decoded, err := base64.StdEncoding.DecodeString(value[6:])
+			if err == nil && strings.Contains(string(decoded), ":") {
+				options.User = string(decoded)
+				return
+			}
+		}
+	case "cookie":
+		options.Cookie = append(options.Cookie, value)
+		return
+	}
+	options.Header = append(options.Header, fmt.Sprintf("%s: %s", key, value))
+}
+
+func (self *scope) addCookie(request *Request, expr ast.Expr) {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	literal, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		request.warning("AddCookie() parameter is not a composite literal")
+		return
+	}
+	var name, value string
+	for _, element := range literal.Elts {
+		keyValue, ok := element.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := keyValue.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		switch key.Name {
+		case "Name":
+			name, _ = self.stringValue(keyValue.Value)
+		case "Value":
+			value, _ = self.stringValue(keyValue.Value)
+		}
+	}
+	request.Options.Cookie = append(request.Options.Cookie, fmt.Sprintf("%s=%s", name, value))
+}
+
+/*
+	It reads client settings (-k and -x) from http.Client/http.Transport literals.
+	They are applied to all requests in the function.
+*/
+func (self *scope) finish(block *ast.BlockStmt) {
+	var insecure bool
+	var proxy string
+	ast.Inspect(block, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.KeyValueExpr:
+			if key, ok := node.Key.(*ast.Ident); ok && key.Name == "InsecureSkipVerify" {
+				if value, ok := node.Value.(*ast.Ident); ok && value.Name == "true" {
+					insecure = true
+				}
+			}
+		case *ast.CallExpr:
+			if isCall(node, "http", "ProxyURL") && len(node.Args) == 1 {
+				if ident, ok := node.Args[0].(*ast.Ident); ok {
+					proxy = self.proxyUrl[ident.Name]
+				}
+			}
+		}
+		return true
+	})
+	for _, request := range self.requests {
+		request.Options.Insecure = insecure
+		request.Options.Proxy = proxy
+	}
+}
+
+//--- Expression evaluators
+
+func (self *scope) bodyFromExpr(expr ast.Expr) *body {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return self.bodies[expr.Name]
+	case *ast.UnaryExpr:
+		if expr.Op == token.AND {
+			return self.bodyFromExpr(expr.X)
+		}
+	case *ast.ParenExpr:
+		return self.bodyFromExpr(expr.X)
+	case *ast.CompositeLit:
+		if isType(expr.Type, "url", "Values") {
+			return self.valuesFromLiteral(expr)
+		}
+	case *ast.CallExpr:
+		if isCall(expr, "bytes", "NewBufferString") || isCall(expr, "strings", "NewReader") ||
+			isCall(expr, "bytes", "NewReader") || isCall(expr, "bytes", "NewBuffer") {
+			if len(expr.Args) != 1 {
+				return nil
+			}
+			arg := unwrapConversion(expr.Args[0])
+			// strings.NewReader(values.Encode())
+			if call, ok := arg.(*ast.CallExpr); ok {
+				if selector, ok := call.Fun.(*ast.SelectorExpr); ok && selector.Sel.Name == "Encode" {
+					return self.bodyFromExpr(selector.X)
+				}
+			}
+			if data, ok := self.dataValue(arg); ok {
+				return &body{data: common.DataOptions{data}}
+			}
+		}
+	}
+	return nil
+}
+
+func (self *scope) valuesFromLiteral(literal *ast.CompositeLit) *body {
+	result := &body{}
+	for _, element := range literal.Elts {
+		keyValue, ok := element.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, _ := self.stringValue(keyValue.Key)
+		values, ok := keyValue.Value.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		for _, valueExpr := range values.Elts {
+			value, _ := self.stringValue(valueExpr)
+			result.data.Append(fmt.Sprintf("%s=%s", key, value), common.DataAsciiType)
+		}
+	}
+	return result
+}
+
+func (self *scope) dataValue(expr ast.Expr) (common.DataOption, bool) {
+	expr = unwrapConversion(expr)
+	// "name=" + url.QueryEscape(content) is name=content or name@file of --data-urlencode
+	if binary, ok := expr.(*ast.BinaryExpr); ok && binary.Op == token.ADD {
+		if name, ok := self.stringValue(binary.X); ok && strings.HasSuffix(name, "=") {
+			if call, ok := unwrapConversion(binary.Y).(*ast.CallExpr); ok && isCall(call, "url", "QueryEscape") && len(call.Args) == 1 {
+				name = name[:len(name)-1]
+				if path, ok := self.fileContent(call.Args[0]); ok {
+					return common.DataOption{Value: name + "@" + path, Type: common.DataUrlEncodeType}, true
+				}
+				if value, ok := self.stringValue(call.Args[0]); ok {
+					return common.DataOption{Value: name + "=" + value, Type: common.DataUrlEncodeType}, true
+				}
+			}
+		}
+	}
+	if call, ok := expr.(*ast.CallExpr); ok {
+		switch {
+		case isCall(call, "url", "QueryEscape") && len(call.Args) == 1:
+			if path, ok := self.fileContent(call.Args[0]); ok {
+				return common.DataOption{Value: "@" + path, Type: common.DataUrlEncodeType}, true
+			}
+			if value, ok := self.stringValue(call.Args[0]); ok {
+				if strings.ContainsAny(value, "=@") {
+					// "=content" style keeps = and @ of the content
+					value = "=" + value
+				}
+				return common.DataOption{Value: value, Type: common.DataUrlEncodeType}, true
+			}
+			return common.DataOption{}, false
+		case isCall(call, "strings", "Replace") && len(call.Args) == 4, isCall(call, "strings", "ReplaceAll") && len(call.Args) == 3:
+			if path, ok := self.fileContent(call.Args[0]); ok {
+				return common.DataOption{Value: "@" + path, Type: common.DataAsciiType}, true
+			}
+		}
+	}
+	if path, ok := self.fileContent(expr); ok {
+		return common.DataOption{Value: "@" + path, Type: common.DataBinaryType}, true
+	}
+	if value, ok := self.stringValue(expr); ok {
+		return common.DataOption{Value: value, Type: common.DataAsciiType}, true
+	}
+	return common.DataOption{}, false
+}
+
+func (self *scope) fileContent(expr ast.Expr) (string, bool) {
+	if ident, ok := unwrapConversion(expr).(*ast.Ident); ok {
+		path, ok := self.contents[ident.Name]
+		return path, ok
+	}
+	return "", false
+}
+
+/*
+	It evaluates constant string expressions.
+	When a part of the expression can't be evaluated, it returns the evaluated prefix and false.
+*/
+func (self *scope) stringValue(expr ast.Expr) (string, bool) {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		return stringLiteral(expr)
+	case *ast.Ident:
+		value, ok := self.strings[expr.Name]
+		return value, ok
+	case *ast.ParenExpr:
+		return self.stringValue(expr.X)
+	case *ast.BinaryExpr:
+		if expr.Op != token.ADD {
+			return "", false
+		}
+		left, ok := self.stringValue(expr.X)
+		if !ok {
+			return left, false
+		}
+		right, ok := self.stringValue(expr.Y)
+		return left + right, ok
+	case *ast.SelectorExpr:
+		// http.MethodGet, http.MethodPost...
+		if pkg, ok := expr.X.(*ast.Ident); ok && pkg.Name == "http" && strings.HasPrefix(expr.Sel.Name, "Method") {
+			return strings.ToUpper(expr.Sel.Name[6:]), true
+		}
+	case *ast.CallExpr:
+		if selector, ok := expr.Fun.(*ast.SelectorExpr); ok && selector.Sel.Name == "EncodeToString" && len(expr.Args) == 1 {
+			if value, ok := self.stringValue(unwrapConversion(expr.Args[0])); ok {
+				return base64.StdEncoding.EncodeToString([]byte(value)), true
+			}
+		}
+		if len(expr.Args) == 1 && (isConversion(expr, "string") || isByteSliceConversion(expr)) {
+			return self.stringValue(expr.Args[0])
+		}
+	}
+	return "", false
+}
+
+//--- AST helper functions
+
+func stringLiteral(expr ast.Expr) (string, bool) {
+	literal, ok := expr.(*ast.BasicLit)
+	if !ok || literal.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(literal.Value)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+func isCall(call *ast.CallExpr, pkg, name string) bool {
+	if pkg == "" {
+		ident, ok := call.Fun.(*ast.Ident)
+		return ok && ident.Name == name
+	}
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != name {
+		return false
+	}
+	ident, ok := selector.X.(*ast.Ident)
+	return ok && ident.Name == pkg
+}
+
+func isType(expr ast.Expr, pkg, name string) bool {
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != name {
+		return false
+	}
+	ident, ok := selector.X.(*ast.Ident)
+	return ok && ident.Name == pkg
+}
+
+func isConversion(call *ast.CallExpr, typeName string) bool {
+	ident, ok := call.Fun.(*ast.Ident)
+	return ok && ident.Name == typeName
+}
+
+func isByteSliceConversion(call *ast.CallExpr) bool {
+	array, ok := call.Fun.(*ast.ArrayType)
+	if !ok || array.Len != nil {
+		return false
+	}
+	ident, ok := array.Elt.(*ast.Ident)
+	return ok && ident.Name == "byte"
+}
+
+// It removes string() and []byte() conversions.
+func unwrapConversion(expr ast.Expr) ast.Expr {
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		if isConversion(call, "string") || isByteSliceConversion(call) {
+			return unwrapConversion(call.Args[0])
+		}
+	}
+	return expr
+}
+
+//--- curl command builder
+
+/*
+	Build one line curl command of the request with CurlOptions.ToCurl().
+*/
+func (self *Request) MakeCurlCommand() string {
+	options := self.Options.Clone()
+	// -T sets PUT automatically
+	if options.Request == "PUT" && isSingleFileUpload(options) {
+		options.ProcessedData[0].Upload = true
+	}
+	return options.ToCurl(common.CurlStyle{})
+}
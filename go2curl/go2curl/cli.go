@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/go2curl"
+	"io/ioutil"
+	"os"
+)
+
+func Usage() {
+	fmt.Fprintln(os.Stderr, `Usage:
+  go2curl [OPTIONS] [input go file]
+
+Options
+  -h, --help          Show this help message	`)
+	os.Exit(1)
+}
+
+func main() {
+	var requests []*go2curl.Request
+	var err error
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "-h":
+			Usage()
+		case "--help":
+			Usage()
+		default:
+			// input from file
+			var src []byte
+			src, err = ioutil.ReadFile(os.Args[1])
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			requests, err = go2curl.CreateRequestsFromSource(os.Args[1], src)
+		}
+	} else {
+		// input from stdin
+		requests, err = go2curl.CreateRequestsFromReader(os.Stdin)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	for _, request := range requests {
+		for _, warning := range request.Warnings {
+			fmt.Fprintln(os.Stderr, warning)
+		}
+		fmt.Println(request.MakeCurlCommand())
+	}
+}
@@ -0,0 +1,214 @@
+package go2curl
+
+import (
+	"github.com/jessevdk/go-flags"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/generator"
+	. "gopkg.in/check.v1"
+	"strings"
+)
+
+type Go2CurlTest struct{}
+
+var _ = Suite(&Go2CurlTest{})
+
+func parseSingleRequest(c *C, src string) *Request {
+	requests, err := CreateRequestsFromString(src)
+	c.Assert(err, IsNil)
+	c.Assert(len(requests), Equals, 1)
+	return requests[0]
+}
+
+func (s *Go2CurlTest) Test_RequestIsGone(c *C) {
+	src := `package main
+
+func main() {
+}
+`
+	requests, err := CreateRequestsFromString(src)
+	c.Assert(err, NotNil)
+	c.Assert(requests, IsNil)
+}
+
+func (s *Go2CurlTest) Test_SyntaxError(c *C) {
+	requests, err := CreateRequestsFromString(`package main func`)
+	c.Assert(err, NotNil)
+	c.Assert(requests, IsNil)
+}
+
+func (s *Go2CurlTest) Test_SimpleGet(c *C) {
+	src := `package main
+
+import "net/http"
+
+func main() {
+	resp, err := http.Get("http://localhost:18888")
+}
+`
+	request := parseSingleRequest(c, src)
+	c.Check(request.MakeCurlCommand(), Equals, "curl http://localhost:18888")
+}
+
+func (s *Go2CurlTest) Test_NewRequestWithHeaders(c *C) {
+	src := `package main
+
+import "net/http"
+
+func main() {
+	client := &http.Client{}
+	request, err := http.NewRequest(http.MethodDelete, "http://localhost:18888/users/1", nil)
+	request.Header.Add("Accept", "text/html")
+	request.Header.Set("X-Token", "abc" + "def")
+	resp, err := client.Do(request)
+}
+`
+	request := parseSingleRequest(c, src)
+	c.Check(request.Options.Method(), Equals, "DELETE")
+	c.Check(request.MakeCurlCommand(), Equals, "curl http://localhost:18888/users/1 -X DELETE -H 'Accept: text/html' -H 'X-Token: abcdef'")
+}
+
+func (s *Go2CurlTest) Test_BasicAuthAndCookie(c *C) {
+	src := `package main
+
+import "net/http"
+
+func main() {
+	user := "USER"
+	request, err := http.NewRequest("GET", "http://localhost:18888", nil)
+	request.SetBasicAuth(user, "PASS")
+	request.AddCookie(&http.Cookie{Name: "session", Value: "1234"})
+}
+`
+	request := parseSingleRequest(c, src)
+	c.Check(request.Options.User, Equals, "USER:PASS")
+	c.Check(request.Options.Cookie, DeepEquals, []string{"session=1234"})
+	c.Check(request.MakeCurlCommand(), Equals, "curl http://localhost:18888 -b session=1234 -u USER:PASS")
+}
+
+func (s *Go2CurlTest) Test_ClientSettings(c *C) {
+	src := `package main
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+)
+
+func main() {
+	proxyUrl, err := url.Parse("http://proxy:8080")
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		Proxy: http.ProxyURL(proxyUrl),
+	}}
+	request, err := http.NewRequest("GET", "https://localhost:18889", nil)
+	resp, err := client.Do(request)
+}
+`
+	request := parseSingleRequest(c, src)
+	c.Check(request.MakeCurlCommand(), Equals, "curl https://localhost:18889 -x http://proxy:8080 -k")
+}
+
+func (s *Go2CurlTest) Test_MultipleRequests(c *C) {
+	src := `package main
+
+import "net/http"
+
+func first() {
+	http.Get("http://localhost:18888/first")
+}
+
+func second() {
+	request, _ := http.NewRequest("POST", "http://localhost:18888/second", nil)
+}
+`
+	requests, err := CreateRequestsFromString(src)
+	c.Assert(err, IsNil)
+	c.Assert(len(requests), Equals, 2)
+	c.Check(requests[0].MakeCurlCommand(), Equals, "curl http://localhost:18888/first")
+	c.Check(requests[1].MakeCurlCommand(), Equals, "curl http://localhost:18888/second -X POST")
+	c.Check(requests[1].Position.Line, Equals, 10)
+}
+
+func (s *Go2CurlTest) Test_UnresolvedUrl(c *C) {
+	src := `package main
+
+import (
+	"net/http"
+	"os"
+)
+
+func main() {
+	http.Get(os.Args[1])
+}
+`
+	request := parseSingleRequest(c, src)
+	c.Check(len(request.Warnings), Equals, 1)
+}
+
+func (s *Go2CurlTest) Test_NewRequestWithContextWithoutArgs(c *C) {
+	src := `package main
+
+import "net/http"
+
+func main() {
+	request, err := http.NewRequestWithContext()
+}
+`
+	request := parseSingleRequest(c, src)
+	c.Check(len(request.Warnings), Equals, 1)
+	c.Check(strings.HasSuffix(request.Warnings[0], "http.NewRequest() should have 3 parameters"), Equals, true)
+}
+
+// Round trip: curl options -> client/golang -> go2curl -> curl options
+
+func roundTrip(c *C, args ...string) string {
+	var curlOptions common.CurlOptions
+	curlOptions.Init()
+	urls, err := flags.ParseArgs(&curlOptions, args)
+	c.Assert(err, IsNil)
+	curlOptions.Url = urls[0]
+	sourceCode, _, _, _ := generator.GenerateCode("go", &curlOptions)
+	request := parseSingleRequest(c, sourceCode)
+	c.Check(request.Warnings, IsNil)
+	return request.MakeCurlCommand()
+}
+
+func (s *Go2CurlTest) Test_RoundTrip_PostData(c *C) {
+	c.Check(roundTrip(c, "-d", "test", "-d", "hello", "http://localhost:18888"), Equals,
+		"curl http://localhost:18888 -d test -d hello")
+}
+
+func (s *Go2CurlTest) Test_RoundTrip_UrlEncode(c *C) {
+	c.Check(roundTrip(c, "--data-urlencode", "test% =", "http://localhost:18888"), Equals,
+		"curl http://localhost:18888 --data-urlencode 'test% ='")
+}
+
+func (s *Go2CurlTest) Test_RoundTrip_GetWithData(c *C) {
+	c.Check(roundTrip(c, "-H", "Accept: text/html", "-G", "-d", "hello=world", "http://localhost:18888"), Equals,
+		"curl http://localhost:18888 -G -H 'Accept: text/html' -d hello=world")
+}
+
+func (s *Go2CurlTest) Test_RoundTrip_PostWithDataUrl(c *C) {
+	c.Check(roundTrip(c, "-X", "POST", "-G", "-d", "hello=world", "http://localhost:18888"), Equals,
+		"curl http://localhost:18888 -X POST -G -d hello=world")
+}
+
+func (s *Go2CurlTest) Test_RoundTrip_Form(c *C) {
+	c.Check(roundTrip(c, "-F", "hello=world", "-F", "file=@test.go;filename=nameinpost;type=text/plain", "-F", "text=<test.go", "http://localhost:18888"), Equals,
+		"curl http://localhost:18888 -F hello=world -F 'file=@test.go;filename=nameinpost;type=text/plain' -F 'text=<test.go'")
+}
+
+func (s *Go2CurlTest) Test_RoundTrip_Upload(c *C) {
+	c.Check(roundTrip(c, "-T", "test.go", "-H", "X-Test: 1", "http://localhost:18888"), Equals,
+		"curl http://localhost:18888 -H 'X-Test: 1' -T test.go")
+}
+
+func (s *Go2CurlTest) Test_RoundTrip_FullClient(c *C) {
+	c.Check(roundTrip(c, "--compressed", "-u", "USER:PASS", "-b", "a=b", "-k", "-x", "http://proxy:8080", "-X", "DELETE", "https://localhost:18889"), Equals,
+		"curl https://localhost:18889 -X DELETE --compressed -b a=b -u USER:PASS -x http://proxy:8080 -k")
+}
+
+func (s *Go2CurlTest) Test_RoundTrip_AWSV2(c *C) {
+	c.Check(roundTrip(c, "--awsv2", "ACCESS:SECRET", "http://localhost:18888"), Equals,
+		"curl http://localhost:18888 --awsv2 ACCESS:SECRET")
+}
@@ -1,18 +1,59 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/jessevdk/go-flags"
 	"github.com/shibukawa/curl_as_dsl/common"
 	"github.com/shibukawa/curl_as_dsl/generator"
-	"log"
+	"github.com/shibukawa/curl_as_dsl/runner"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 )
 
+/*
+	Options of har, postman and batch commands. The file of requests is passed as a parameter.
+*/
+type ImportOptions struct {
+	OutputDir string `short:"o" long:"output-dir" value-name:"DIR" description:"Write one file for each request into DIR instead of printing functions. Targets except go, python, python.requests, js.fetch.node, ts.fetch, java, ruby and php need it"`
+	Args      struct {
+		File string `positional-arg-name:"FILE" description:"File to read ('-' means stdin)"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+/*
+	Options of run command. It has curl options and output options of curl.
+*/
+type RunOptions struct {
+	Include  bool   `short:"i" long:"include" description:"Include protocol response headers in the output"`
+	Output   string `short:"o" long:"output" value-name:"FILE" description:"Write to FILE instead of stdout"`
+	WriteOut string `short:"w" long:"write-out" value-name:"FORMAT" description:"Use output FORMAT after completion like '%{http_code}\\n'"`
+	common.CurlOptions
+}
+
 type GlobalOptions struct {
-	Target string `short:"t" long:"target" value-name:"NAME" description:"Target name of code generator" default:"go"`
-	Debug  bool   `short:"d" long:"debug" description:"Debug option"`
+	Target         string `short:"t" long:"target" value-name:"NAME" description:"Target name of code generator" default:"go"`
+	Debug          bool   `short:"d" long:"debug" description:"Debug option"`
+	Input          string `short:"i" long:"input" value-name:"FILE" description:"Read whole curl command from FILE ('-' means stdin)"`
+	Function       string `long:"function" value-name:"NAME" description:"Generate a function NAME that returns the response instead of a program (go, python, python.requests, js.fetch.node, ts.fetch, java, ruby and php)"`
+	Package        string `long:"package" value-name:"NAME" description:"Package name of generated Go and Java code. Package main has main() that calls all functions" default:"main"`
+	Parameters     string `long:"parameters" value-name:"MODE" choice:"placeholders" choice:"auto" description:"Make {{name}} and :name placeholders (and tokens, passwords and numeric ids with auto) parameters"`
+	Redact         bool   `long:"redact" description:"Read credentials (-u, --awsv2, Authorization, Cookie and X-Api-Key headers) from environment variables"`
+	SecretsFromEnv bool   `long:"secrets-from-env" description:"Same as --redact"`
+}
+
+/*
+	Replace credentials of --redact option and values of --parameters option with parameters.
+*/
+func Parameterize(globalOptions *GlobalOptions, curlOptions *common.CurlOptions) {
+	if globalOptions.Redact || globalOptions.SecretsFromEnv {
+		curlOptions.Redact()
+	}
+	if globalOptions.Parameters != "" {
+		curlOptions.Parameterize(globalOptions.Parameters == "auto")
+	}
 }
 
 func PrintLangHelp(target string) {
@@ -22,13 +63,182 @@ This program supports one of the following targets:
 
 * go, golang         : Golang      (net/http)
 * py, python         : Python 3    (http.client)
+* python.requests    : Python 3    (requests)
+* python.httpx       : Python 3    (httpx)
+* python.httpx.async : Python 3    (httpx.AsyncClient)
 * node, js.node      : node.js     (http.request)
 * xhr, js.xhr        : Browser     (XMLHttpRequest)
+* js.fetch           : Browser     (fetch)
+* js.fetch.node      : Node.js 20  (fetch)
+* ts.fetch           : TypeScript  (fetch on Node.js 20)
 * java               : Java        (java.net.HttpURLConnection)
+* java.httpclient    : Java 11     (java.net.http.HttpClient)
+* kotlin.okhttp      : Kotlin      (OkHttp)
+* csharp, cs         : C#          (System.Net.Http.HttpClient)
 * objc, objc.session : Objective-C (NSURLSession)
 * objc.connection    : Objective-C (NSURLConnection)
 * php                : PHP         (fopen)
-* vim                : Vim script  (webapi-vim)`, target)
+* ruby, ruby.nethttp : Ruby        (Net::HTTP)
+* ruby.faraday       : Ruby        (Faraday)
+* rust, rust.reqwest : Rust        (reqwest::blocking)
+* rust.async         : Rust        (reqwest + tokio)
+* swift              : Swift       (URLSession)
+* swift.async        : Swift       (URLSession + async/await)
+* vim                : Vim script  (webapi-vim)
+* http, httpie       : HTTPie      (http command)
+* wget               : GNU Wget    (wget command)
+* powershell, pwsh   : PowerShell  (Invoke-RestMethod)
+* curl               : curl        (normalized, multi-line)
+* curl.oneline       : curl        (normalized, one line)
+* curl.cmd           : curl        (normalized for cmd.exe, multi-line)
+* curl.cmd.oneline   : curl        (normalized for cmd.exe, one line)
+* har                : HAR 1.2     (HTTP Archive)
+* postman            : Postman     (Collection v2.1)
+* openapi            : OpenAPI 3.0 (single path item)`, target)
+}
+
+/*
+	Check global options that depend on the target before reading the request.
+*/
+func CheckGlobalOptions(globalOptions *GlobalOptions) {
+	if _, ok := generator.LanguageMap[globalOptions.Target]; !ok {
+		PrintLangHelp(globalOptions.Target)
+		os.Exit(1)
+	}
+	if globalOptions.Function != "" && !generator.HasModule(globalOptions.Target) {
+		fmt.Fprintln(os.Stderr, &generator.UnsupportedFunctionError{Target: globalOptions.Target})
+		os.Exit(1)
+	}
+	if globalOptions.Package != "main" && !generator.HasPackage(globalOptions.Target) {
+		fmt.Fprintf(os.Stderr, "'%s' doesn't use --package. It is used by go and java\n", globalOptions.Target)
+		os.Exit(1)
+	}
+}
+
+func GenerateAndPrint(globalOptions *GlobalOptions, curlOptions *common.CurlOptions) {
+	var result generator.Result
+	var err error
+	Parameterize(globalOptions, curlOptions)
+	if globalOptions.Function != "" {
+		result, err = generator.GenerateFunction(context.Background(), globalOptions.Target, curlOptions, globalOptions.Package, globalOptions.Function)
+	} else {
+		result, err = generator.Generate(context.Background(), globalOptions.Target, curlOptions)
+	}
+	if err != nil {
+		PrintGenerateError(globalOptions, err)
+	}
+	PrintWarnings(result.Warnings)
+	if globalOptions.Debug {
+		st := reflect.TypeOf(result.Context)
+		v := reflect.ValueOf(result.Context)
+		if result.TemplateName != "" {
+			fmt.Fprintf(os.Stderr, "Debug: template name=%s_%s\n", result.Language, result.TemplateName)
+		}
+		fmt.Fprintf(os.Stderr, "Debug: template context=%s\n", st.Name())
+		num := st.NumField()
+		for i := 0; i < num; i++ {
+			fmt.Fprintf(os.Stderr, "    %s: %s\n", st.Field(i).Name, v.Field(i).String())
+		}
+	}
+	fmt.Println(result.SourceCode)
+}
+
+func PrintWarnings(warnings []string) {
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "[warning] %s\n", warning)
+	}
+}
+
+func PrintGenerateError(globalOptions *GlobalOptions, err error) {
+	if _, ok := err.(*generator.UnknownTargetError); ok {
+		PrintLangHelp(globalOptions.Target)
+	} else {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	os.Exit(1)
+}
+
+/*
+	Print a file that has functions of all requests, or write one file for each request into the directory.
+*/
+func ImportAndGenerate(globalOptions *GlobalOptions, importOptions *ImportOptions, parse func([]byte) ([]common.NamedRequest, error)) {
+	if importOptions.OutputDir == "" && !generator.HasModule(globalOptions.Target) {
+		fmt.Fprintln(os.Stderr, &generator.UnsupportedModuleError{Target: globalOptions.Target})
+		os.Exit(1)
+	}
+	content, err := ReadInput(importOptions.Args.File)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	requests, err := parse([]byte(content))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for _, request := range requests {
+		Parameterize(globalOptions, request.Options)
+	}
+	if importOptions.OutputDir == "" {
+		result, err := generator.GenerateModule(context.Background(), globalOptions.Target, requests, globalOptions.Package)
+		if err != nil {
+			PrintGenerateError(globalOptions, err)
+		}
+		PrintWarnings(result.Warnings)
+		if globalOptions.Debug {
+			fmt.Fprintf(os.Stderr, "Debug: template name=%s_%s\n", result.Language, result.TemplateName)
+		}
+		fmt.Println(result.SourceCode)
+		return
+	}
+	files, err := generator.GenerateFiles(context.Background(), globalOptions.Target, requests)
+	if err != nil {
+		PrintGenerateError(globalOptions, err)
+	}
+	for _, file := range files {
+		PrintWarnings(file.Result.Warnings)
+		fileName := filepath.Join(importOptions.OutputDir, filepath.FromSlash(file.Name))
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if err := ioutil.WriteFile(fileName, []byte(file.Result.SourceCode+"\n"), 0644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if globalOptions.Debug {
+			fmt.Fprintf(os.Stderr, "Debug: %s: template name=%s_%s\n", fileName, file.Result.Language, file.Result.TemplateName)
+		}
+	}
+}
+
+/*
+	Send the request with net/http and print the response like curl.
+*/
+func RunAndPrint(globalOptions *GlobalOptions, runOptions *RunOptions, curlOptions *common.CurlOptions) {
+	if globalOptions.Debug {
+		fmt.Fprintf(os.Stderr, "Debug: %s %s\n", curlOptions.Method(), curlOptions.Url)
+	}
+	output := runner.Output{
+		Include:  runOptions.Include,
+		File:     runOptions.Output,
+		WriteOut: runOptions.WriteOut,
+	}
+	if err := runner.Run(context.Background(), curlOptions, output, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func ReadInput(fileName string) (string, error) {
+	var content []byte
+	var err error
+	if fileName == "-" {
+		content, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		content, err = ioutil.ReadFile(fileName)
+	}
+	return string(content), err
 }
 
 func main() {
@@ -37,42 +247,91 @@ func main() {
 	curlOptions.Init()
 
 	parser := flags.NewParser(&globalOptions, flags.Default)
+	// curl command is not needed when whole command is passed by --input
+	parser.SubcommandsOptional = true
 	curlCommand, err := parser.AddCommand("curl",
 		"Generate code from curl options",
 		"This command has almost same options of curl and generate code",
 		&curlOptions)
+	var runOptions RunOptions
+	runOptions.Init()
+	runCommand, err := parser.AddCommand("run",
+		"Send request of curl options",
+		"Send the request with net/http of Go and print the response like curl. Redirects are not followed",
+		&runOptions)
+	var harOptions, postmanOptions, batchOptions ImportOptions
+	harCommand, err := parser.AddCommand("har",
+		"Generate code from HAR file",
+		"Generate code for every request in HAR file. Functions of requests are printed in one file or written in DIR",
+		&harOptions)
+	postmanCommand, err := parser.AddCommand("postman",
+		"Generate code from Postman collection",
+		"Generate code for every request in Postman collection v2.1. Folders become prefixes of function names or directories",
+		&postmanOptions)
+	batchCommand, err := parser.AddCommand("batch",
+		"Generate code from file of curl commands",
+		"Generate code for every curl command in the file. A comment line before the command is the name of the request",
+		&batchOptions)
 	urls, err := parser.Parse()
 	if err != nil {
 		os.Exit(1)
 	}
-	if len(urls) > 1 {
-		fmt.Println("It accept only one url. Remained urls are ignored.")
+	if parser.Active != runCommand {
+		CheckGlobalOptions(&globalOptions)
 	}
 	if parser.Active == curlCommand {
+		if len(urls) > 1 {
+			fmt.Fprintln(os.Stderr, "It accept only one url. Remained urls are ignored.")
+		}
 		// --url option has higher priority than params.
-		if curlOptions.Url == "" {
-			if len(urls) > 0 {
-				curlOptions.Url = urls[0]
-			} else {
-				log.Fatalln("Both --url option and url parameters are missing")
-			}
+		if curlOptions.Url == "" && len(urls) > 0 {
+			curlOptions.Url = urls[0]
+		}
+		curlOptions.AppendUploadFileName()
+		GenerateAndPrint(&globalOptions, &curlOptions)
+	} else if parser.Active == runCommand {
+		if len(urls) > 1 {
+			fmt.Fprintln(os.Stderr, "It accept only one url. Remained urls are ignored.")
 		}
-		sourceCode, langName, templateName, option := generator.GenerateCode(globalOptions.Target, &curlOptions)
-		if templateName != "" {
-			if globalOptions.Debug {
-				st := reflect.TypeOf(option)
-				v := reflect.ValueOf(option)
-				fmt.Fprintf(os.Stderr, "Debug: template name=%s_%s\n", langName, templateName)
-				fmt.Fprintf(os.Stderr, "Debug: template context=%s\n", st.Name())
-				num := st.NumField()
-				for i := 0; i < num; i++ {
-					fmt.Fprintf(os.Stderr, "    %s: %s\n", st.Field(i).Name, v.Field(i).String())
-				}
+		curlOptions := &runOptions.CurlOptions
+		if globalOptions.Input != "" {
+			command, err := ReadInput(globalOptions.Input)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			curlOptions, err = common.ParseCurlCommand(command)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
 			}
-			fmt.Println(sourceCode)
 		} else {
-			PrintLangHelp(globalOptions.Target)
+			if curlOptions.Url == "" && len(urls) > 0 {
+				curlOptions.Url = urls[0]
+			}
+			curlOptions.AppendUploadFileName()
+		}
+		RunAndPrint(&globalOptions, &runOptions, curlOptions)
+	} else if parser.Active == harCommand {
+		ImportAndGenerate(&globalOptions, &harOptions, common.ParseHar)
+	} else if parser.Active == postmanCommand {
+		ImportAndGenerate(&globalOptions, &postmanOptions, common.ParsePostmanCollection)
+	} else if parser.Active == batchCommand {
+		ImportAndGenerate(&globalOptions, &batchOptions, common.ParseCurlBatch)
+	} else if globalOptions.Input != "" {
+		command, err := ReadInput(globalOptions.Input)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		options, err := common.ParseCurlCommand(command)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
 		}
+		GenerateAndPrint(&globalOptions, options)
+	} else {
+		parser.WriteHelp(os.Stderr)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,318 @@
+package literal_test
+
+import (
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/literal"
+	"github.com/shibukawa/curl_as_dsl/syntax"
+	"go/parser"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+/*
+	Escape sequences of a language that decoders in tests understand.
+*/
+type rules struct {
+	prefix string
+	quote  byte
+	// single character escapes like \n
+	simple map[byte]byte
+	// \xNN
+	hex bool
+	// \NNN (up to 3 digits)
+	octal bool
+	// \uXXXX
+	unicode4 bool
+	// \UXXXXXXXX
+	unicode8 bool
+	// \u{X}
+	braceUnicode bool
+	// \x and \NNN are bytes, not characters
+	bytes bool
+	// \uXXXX are processed before lexing (Java)
+	preUnicode bool
+	// single quoted PHP: only \\ and \' are escapes
+	phpSingle bool
+}
+
+var cEscapes = map[byte]byte{'n': '\n', 'r': '\r', 't': '\t', '"': '"', '\\': '\\'}
+
+func withEscapes(escapes map[byte]byte) map[byte]byte {
+	result := map[byte]byte{}
+	for key, value := range cEscapes {
+		result[key] = value
+	}
+	for key, value := range escapes {
+		result[key] = value
+	}
+	return result
+}
+
+type language struct {
+	name   string
+	encode func(string) string
+	rules  rules
+	// invalid UTF-8 is kept as bytes
+	keepsBytes bool
+	// source code to check the syntax of the literal
+	check func(literal string) error
+}
+
+var languages = []language{
+	{name: "go", encode: literal.Go, keepsBytes: true},
+	{name: "go raw", encode: literal.GoRaw, keepsBytes: true},
+	{name: "python", encode: literal.Python,
+		rules: rules{quote: '"', simple: cEscapes, hex: true, unicode4: true, unicode8: true},
+		check: func(lit string) error { return syntax.CheckPython("x = " + lit + "\n") }},
+	{name: "python bytes", encode: literal.PythonBytes, keepsBytes: true,
+		rules: rules{prefix: "b", quote: '"', simple: cEscapes, hex: true, bytes: true},
+		check: func(lit string) error { return syntax.CheckPython("x = " + lit + "\n") }},
+	{name: "javascript", encode: literal.JavaScript,
+		rules: rules{quote: '"', simple: withEscapes(map[byte]byte{'/': '/'}), hex: true, unicode4: true},
+		check: func(lit string) error {
+			if err := syntax.CheckJavaScript("var x = " + lit + ";\n"); err != nil {
+				return err
+			}
+			return syntax.CheckHTMLScripts("<script>var x = " + lit + ";</script>\n")
+		}},
+	{name: "java", encode: literal.Java,
+		rules: rules{quote: '"', simple: cEscapes, octal: true, unicode4: true, preUnicode: true},
+		check: func(lit string) error { return syntax.CheckJava("class A { String x = " + lit + "; }\n") }},
+	{name: "kotlin", encode: literal.Kotlin,
+		rules: rules{quote: '"', simple: withEscapes(map[byte]byte{'$': '$'}), unicode4: true}},
+	{name: "csharp", encode: literal.CSharp,
+		rules: rules{quote: '"', simple: cEscapes, unicode4: true, unicode8: true}},
+	{name: "swift", encode: literal.Swift,
+		rules: rules{quote: '"', simple: cEscapes, braceUnicode: true}},
+	{name: "rust", encode: literal.Rust,
+		rules: rules{quote: '"', simple: cEscapes, braceUnicode: true}},
+	{name: "ruby", encode: literal.Ruby,
+		rules: rules{quote: '"', simple: withEscapes(map[byte]byte{'#': '#'}), hex: true, braceUnicode: true}},
+	{name: "php", encode: literal.PHP, keepsBytes: true,
+		rules: rules{quote: '"', simple: withEscapes(map[byte]byte{'$': '$'}), hex: true, braceUnicode: true, bytes: true}},
+	{name: "objective-c", encode: literal.ObjectiveC,
+		rules: rules{prefix: "@", quote: '"', simple: cEscapes, octal: true, unicode4: true, unicode8: true}},
+	{name: "vim", encode: literal.Vim,
+		rules: rules{quote: '"', simple: cEscapes, hex: true, unicode4: true, unicode8: true}},
+}
+
+func isHex(s string) bool {
+	_, err := strconv.ParseUint(s, 16, 32)
+	return err == nil
+}
+
+/*
+	Replaces \uXXXX before lexing like Java compilers. "\\u" is not an escape.
+*/
+func processUnicode(src string) (string, error) {
+	var units []uint16
+	var buffer strings.Builder
+	flush := func() {
+		buffer.WriteString(string(utf16.Decode(units)))
+		units = nil
+	}
+	for i := 0; i < len(src); i++ {
+		if src[i] == '\\' && i+1 < len(src) && src[i+1] == 'u' {
+			if i+6 > len(src) || !isHex(src[i+2:i+6]) {
+				return "", fmt.Errorf("invalid \\u at %d", i)
+			}
+			value, _ := strconv.ParseUint(src[i+2:i+6], 16, 16)
+			units = append(units, uint16(value))
+			i += 5
+			continue
+		}
+		flush()
+		if src[i] == '\\' && i+1 < len(src) {
+			buffer.WriteString(src[i : i+2])
+			i++
+			continue
+		}
+		buffer.WriteByte(src[i])
+	}
+	flush()
+	return buffer.String(), nil
+}
+
+func decode(lit string, r rules) (string, error) {
+	if !strings.HasPrefix(lit, r.prefix) {
+		return "", fmt.Errorf("no prefix %q", r.prefix)
+	}
+	lit = lit[len(r.prefix):]
+	if r.preUnicode {
+		var err error
+		if lit, err = processUnicode(lit); err != nil {
+			return "", err
+		}
+	}
+	quote := r.quote
+	if len(lit) > 0 && lit[0] == '\'' && r.simple['$'] != 0 {
+		r = rules{quote: '\'', phpSingle: true}
+		quote = '\''
+	}
+	if len(lit) < 2 || lit[0] != quote || lit[len(lit)-1] != quote {
+		return "", fmt.Errorf("not quoted")
+	}
+	body := lit[1 : len(lit)-1]
+	var buffer strings.Builder
+	var units []uint16
+	flush := func() {
+		buffer.WriteString(string(utf16.Decode(units)))
+		units = nil
+	}
+	writeRune := func(value uint64) {
+		if r.bytes && value < 0x100 {
+			buffer.WriteByte(byte(value))
+		} else {
+			buffer.WriteRune(rune(value))
+		}
+	}
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c == quote || c == '\n' || c == '\r' {
+			return "", fmt.Errorf("raw %q at %d", c, i)
+		}
+		if c != '\\' {
+			flush()
+			buffer.WriteByte(c)
+			continue
+		}
+		if i+1 == len(body) {
+			return "", fmt.Errorf("backslash at the end")
+		}
+		next := body[i+1]
+		if r.phpSingle {
+			flush()
+			if next == '\\' || next == '\'' {
+				buffer.WriteByte(next)
+				i++
+			} else {
+				buffer.WriteByte('\\')
+			}
+			continue
+		}
+		switch {
+		case r.unicode4 && next == 'u' && i+6 <= len(body) && isHex(body[i+2:i+6]):
+			value, _ := strconv.ParseUint(body[i+2:i+6], 16, 16)
+			units = append(units, uint16(value))
+			i += 5
+			continue
+		case r.braceUnicode && next == 'u' && i+2 < len(body) && body[i+2] == '{':
+			end := strings.IndexByte(body[i:], '}')
+			if end < 0 || !isHex(body[i+3:i+end]) {
+				return "", fmt.Errorf("invalid \\u{} at %d", i)
+			}
+			value, _ := strconv.ParseUint(body[i+3:i+end], 16, 32)
+			flush()
+			buffer.WriteRune(rune(value))
+			i += end
+			continue
+		}
+		flush()
+		switch {
+		case r.simple[next] != 0:
+			buffer.WriteByte(r.simple[next])
+			i++
+		case r.hex && next == 'x' && i+4 <= len(body) && isHex(body[i+2:i+4]):
+			value, _ := strconv.ParseUint(body[i+2:i+4], 16, 8)
+			writeRune(value)
+			i += 3
+		case r.octal && next >= '0' && next <= '7':
+			end := i + 1
+			for end < len(body) && end < i+4 && body[end] >= '0' && body[end] <= '7' {
+				end++
+			}
+			value, _ := strconv.ParseUint(body[i+1:end], 8, 16)
+			writeRune(value)
+			i = end - 1
+		case r.unicode8 && next == 'U' && i+10 <= len(body) && isHex(body[i+2:i+10]):
+			value, _ := strconv.ParseUint(body[i+2:i+10], 16, 32)
+			buffer.WriteRune(rune(value))
+			i += 9
+		default:
+			return "", fmt.Errorf("unknown escape \\%c at %d", next, i)
+		}
+	}
+	flush()
+	return buffer.String(), nil
+}
+
+func roundTrip(t *testing.T, src string) {
+	for _, lang := range languages {
+		lit := lang.encode(src)
+		if strings.ContainsAny(lit, "\n\r") {
+			t.Errorf("%s: literal of %q has line breaks: %s", lang.name, src, lit)
+		}
+		var result string
+		var err error
+		if strings.HasPrefix(lang.name, "go") {
+			if _, err = parser.ParseExpr(lit); err == nil {
+				result, err = strconv.Unquote(lit)
+			}
+		} else {
+			result, err = decode(lit, lang.rules)
+		}
+		if err != nil {
+			t.Errorf("%s: can't decode literal of %q: %s: %s", lang.name, src, lit, err)
+			continue
+		}
+		expected := src
+		if !lang.keepsBytes {
+			// each byte of invalid UTF-8 is U+FFFD
+			expected = string([]rune(src))
+		}
+		if result != expected {
+			t.Errorf("%s: literal of %q is %s, it means %q", lang.name, src, lit, result)
+		}
+		if lang.check != nil {
+			if err := lang.check(lit); err != nil {
+				t.Errorf("%s: literal of %q has a syntax error: %s: %s", lang.name, src, lit, err)
+			}
+		}
+	}
+}
+
+var fuzzSeeds = []string{
+	"",
+	"hello world",
+	`{"name": "value", "path": "C:\\temp\\"}`,
+	`it's "quoted" \ \\ \n`,
+	"line1\nline2\r\n\ttab",
+	"\x00\x01\x1f\x7f",
+	"$x ${x} #{x} \\(x) {x} %s %d",
+	"</script><!-- -->",
+	"日本語 é \u00a0 \u200b \u2028\u2029 \ufeff",
+	"\U0001F600 emoji",
+	"\u0085 \u009f C1 controls",
+	"\xff\xfe invalid \xe3\x81",
+	"\\u000a \\x41 \\101",
+	"\\",
+	"`backquote`",
+	"a\x00" + "1",
+	"\x01" + "7",
+}
+
+func Test_RoundTrip(t *testing.T) {
+	for _, seed := range fuzzSeeds {
+		roundTrip(t, seed)
+	}
+	// all runes up to U+10000 in one string
+	var buffer strings.Builder
+	for r := rune(0); r <= 0x10000; r++ {
+		if utf8.ValidRune(r) {
+			buffer.WriteRune(r)
+		}
+	}
+	roundTrip(t, buffer.String())
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, src string) {
+		roundTrip(t, src)
+	})
+}
@@ -0,0 +1,84 @@
+package literal_test
+
+import (
+	"encoding/hex"
+	"github.com/shibukawa/curl_as_dsl/literal"
+	. "gopkg.in/check.v1"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+/*
+	Interpreter that prints each literal as hex of bytes in one line.
+*/
+type interpreter struct {
+	name       string
+	encode     func(string) string
+	keepsBytes bool
+	fileName   string
+	command    []string
+	// source code of the program. %s is replaced with comma separated literals
+	program string
+}
+
+var interpreters = []interpreter{
+	{"python", literal.Python, false, "test.py", []string{"python3", "test.py"},
+		"for s in [%s]:\n    print(s.encode('utf-8').hex())\n"},
+	{"python bytes", literal.PythonBytes, true, "test.py", []string{"python3", "test.py"},
+		"for s in [%s]:\n    print(s.hex())\n"},
+	{"javascript", literal.JavaScript, false, "test.js", []string{"node", "test.js"},
+		"for (const s of [%s]) console.log(Buffer.from(s, 'utf8').toString('hex'));\n"},
+	{"php", literal.PHP, true, "test.php", []string{"php", "test.php"},
+		"<?php foreach ([%s] as $s) { echo bin2hex($s), \"\\n\"; }\n"},
+	{"ruby", literal.Ruby, false, "test.rb", []string{"ruby", "test.rb"},
+		"[%s].each { |s| puts s.unpack1('H*') }\n"},
+}
+
+type InterpreterTest struct{}
+
+var _ = Suite(&InterpreterTest{})
+
+func (s *InterpreterTest) Test_Interpreters(c *C) {
+	if testing.Short() {
+		c.Skip("interpreters of languages are needed")
+	}
+	samples := append([]string{}, fuzzSeeds...)
+	var buffer strings.Builder
+	for r := rune(0); r <= 0x10000; r++ {
+		if utf8.ValidRune(r) {
+			buffer.WriteRune(r)
+		}
+	}
+	samples = append(samples, buffer.String())
+
+	for _, interpreter := range interpreters {
+		if _, err := exec.LookPath(interpreter.command[0]); err != nil {
+			c.Logf("%s is skipped: %s is not installed", interpreter.name, interpreter.command[0])
+			continue
+		}
+		var literals []string
+		for _, sample := range samples {
+			literals = append(literals, interpreter.encode(sample))
+		}
+		dir := c.MkDir()
+		program := strings.Replace(interpreter.program, "%s", strings.Join(literals, ", "), 1)
+		c.Assert(ioutil.WriteFile(filepath.Join(dir, interpreter.fileName), []byte(program), 0644), IsNil)
+		cmd := exec.Command(interpreter.command[0], interpreter.command[1:]...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		c.Assert(err, IsNil, Commentf("%s: %s", interpreter.name, output))
+		lines := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n")
+		c.Assert(lines, HasLen, len(samples), Commentf("%s: %s", interpreter.name, output))
+		for i, sample := range samples {
+			expected := sample
+			if !interpreter.keepsBytes {
+				expected = string([]rune(sample))
+			}
+			c.Check(lines[i], Equals, hex.EncodeToString([]byte(expected)), Commentf("%s: %s", interpreter.name, literals[i]))
+		}
+	}
+}
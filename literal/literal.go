@@ -0,0 +1,313 @@
+/*
+	String literals of target languages. Generators write every value of commands (URLs, headers, bodies,
+	form fields, file names and credentials) with them.
+	Encoders escape quotes, backslashes, control characters and interpolations like "$x" and "#{x}"
+	so that the literal has the same string as the value. Literals are always one line.
+	Invalid UTF-8 is kept as bytes by languages that can write bytes in literals (Go, Python bytes and PHP)
+	and is replaced with U+FFFD by others.
+*/
+package literal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/*
+	Rules to write a literal of the language.
+*/
+type encoder struct {
+	prefix string
+	quote  byte
+	// characters that have escape sequences. Quote and backslash are always escaped
+	escapes map[rune]string
+	// format of other control characters and non printable characters
+	control func(r rune) string
+	// non-ASCII characters are written with control too
+	asciiOnly bool
+	// format of bytes of invalid UTF-8. nil replaces them with U+FFFD
+	invalid func(b byte) string
+}
+
+var commonEscapes = map[rune]string{'\n': `\n`, '\r': `\r`, '\t': `\t`}
+
+func hexByte(r rune) string {
+	return fmt.Sprintf(`\x%02x`, r)
+}
+
+func octalByte(r rune) string {
+	return fmt.Sprintf(`\%03o`, r)
+}
+
+func braceUnicode(r rune) string {
+	return fmt.Sprintf(`\u{%x}`, r)
+}
+
+func hexOrBraceUnicode(r rune) string {
+	if r < 0x80 {
+		return hexByte(r)
+	}
+	return braceUnicode(r)
+}
+
+/*
+	\uXXXX. Characters out of BMP are written as surrogate pairs.
+*/
+func utf16Unicode(r rune) string {
+	if r > 0xFFFF {
+		r -= 0x10000
+		return fmt.Sprintf(`\u%04x\u%04x`, 0xD800+(r>>10), 0xDC00+(r&0x3FF))
+	}
+	return fmt.Sprintf(`\u%04x`, r)
+}
+
+func (self *encoder) encode(src string) string {
+	var buffer strings.Builder
+	buffer.WriteString(self.prefix)
+	buffer.WriteByte(self.quote)
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		if r == utf8.RuneError && size <= 1 {
+			if self.invalid != nil {
+				buffer.WriteString(self.invalid(src[i]))
+			} else {
+				self.writeRune(&buffer, unicode.ReplacementChar)
+			}
+			i++
+			continue
+		}
+		self.writeRune(&buffer, r)
+		i += size
+	}
+	buffer.WriteByte(self.quote)
+	return buffer.String()
+}
+
+func (self *encoder) writeRune(buffer *strings.Builder, r rune) {
+	switch {
+	case r == rune(self.quote) || r == '\\':
+		buffer.WriteByte('\\')
+		buffer.WriteRune(r)
+	case self.escapes[r] != "":
+		buffer.WriteString(self.escapes[r])
+	case r < 0x20 || r == 0x7F || (r >= 0x80 && self.asciiOnly) || !unicode.IsPrint(r):
+		buffer.WriteString(self.control(r))
+	default:
+		buffer.WriteRune(r)
+	}
+}
+
+/*
+	Interpreted string literal of Go.
+*/
+func Go(src string) string {
+	return strconv.Quote(src)
+}
+
+/*
+	Raw string literal of Go like `{"name": "value"}` for values that have quotes or backslashes.
+	Other values and values that raw strings can't have (back quotes, carriage returns and
+	control characters) are written as interpreted string literals.
+*/
+func GoRaw(src string) string {
+	if !utf8.ValidString(src) || !strings.ContainsAny(src, `"\`) {
+		return Go(src)
+	}
+	for _, r := range src {
+		if r == '`' || r == '\uFEFF' || (r != '\t' && !unicode.IsPrint(r)) {
+			return Go(src)
+		}
+	}
+	return "`" + src + "`"
+}
+
+var pythonEncoder = encoder{quote: '"', escapes: commonEscapes, control: pythonControl}
+
+func pythonControl(r rune) string {
+	switch {
+	case r <= 0xFF:
+		return hexByte(r)
+	case r <= 0xFFFF:
+		return fmt.Sprintf(`\u%04x`, r)
+	}
+	return fmt.Sprintf(`\U%08x`, r)
+}
+
+/*
+	String literal of Python 3 (str).
+*/
+func Python(src string) string {
+	return pythonEncoder.encode(src)
+}
+
+/*
+	Bytes literal of Python 3 like b"\xe3\x81\x82". Non-ASCII characters are written as bytes of UTF-8.
+*/
+func PythonBytes(src string) string {
+	var buffer strings.Builder
+	buffer.WriteString(`b"`)
+	for i := 0; i < len(src); i++ {
+		b := src[i]
+		switch {
+		case b == '"' || b == '\\':
+			buffer.WriteByte('\\')
+			buffer.WriteByte(b)
+		case commonEscapes[rune(b)] != "":
+			buffer.WriteString(commonEscapes[rune(b)])
+		case b < 0x20 || b >= 0x7F:
+			buffer.WriteString(hexByte(rune(b)))
+		default:
+			buffer.WriteByte(b)
+		}
+	}
+	buffer.WriteByte('"')
+	return buffer.String()
+}
+
+var javaScriptEncoder = encoder{quote: '"', escapes: commonEscapes, control: javaScriptControl}
+
+func javaScriptControl(r rune) string {
+	if r <= 0xFF {
+		return hexByte(r)
+	}
+	return utf16Unicode(r)
+}
+
+/*
+	String literal of JavaScript and TypeScript. "</" is written as "<\/" to embed it in <script> of HTML.
+*/
+func JavaScript(src string) string {
+	return strings.Replace(javaScriptEncoder.encode(src), "</", `<\/`, -1)
+}
+
+// \u escapes are processed before lexing Java source. "\u000a" in a literal is a line break.
+var javaEncoder = encoder{quote: '"', escapes: commonEscapes, asciiOnly: true, control: javaControl}
+
+func javaControl(r rune) string {
+	if r < 0x80 {
+		return octalByte(r)
+	}
+	return utf16Unicode(r)
+}
+
+/*
+	String literal of Java. Non-ASCII characters are written as \uXXXX because encodings of Java sources vary.
+*/
+func Java(src string) string {
+	return javaEncoder.encode(src)
+}
+
+var kotlinEncoder = encoder{quote: '"', escapes: map[rune]string{'\n': `\n`, '\r': `\r`, '\t': `\t`, '$': `\$`}, control: utf16Unicode}
+
+/*
+	String literal of Kotlin. "$" of templates is escaped.
+*/
+func Kotlin(src string) string {
+	return kotlinEncoder.encode(src)
+}
+
+// \x of C# takes up to 4 hex digits
+var cSharpEncoder = encoder{quote: '"', escapes: commonEscapes, control: cSharpControl}
+
+func cSharpControl(r rune) string {
+	if r > 0xFFFF {
+		return fmt.Sprintf(`\U%08x`, r)
+	}
+	return fmt.Sprintf(`\u%04x`, r)
+}
+
+/*
+	Regular string literal of C#.
+*/
+func CSharp(src string) string {
+	return cSharpEncoder.encode(src)
+}
+
+var swiftEncoder = encoder{quote: '"', escapes: commonEscapes, control: braceUnicode}
+
+/*
+	String literal of Swift. "\(" of interpolations can't appear because backslashes are escaped.
+*/
+func Swift(src string) string {
+	return swiftEncoder.encode(src)
+}
+
+var rustEncoder = encoder{quote: '"', escapes: commonEscapes, control: braceUnicode}
+
+/*
+	String literal of Rust.
+*/
+func Rust(src string) string {
+	return rustEncoder.encode(src)
+}
+
+var rubyEncoder = encoder{quote: '"', escapes: map[rune]string{'\n': `\n`, '\r': `\r`, '\t': `\t`, '#': `\#`}, control: hexOrBraceUnicode}
+
+/*
+	Double quoted string literal of Ruby. "#" of interpolations is escaped.
+*/
+func Ruby(src string) string {
+	return rubyEncoder.encode(src)
+}
+
+var phpEncoder = encoder{quote: '"', escapes: map[rune]string{'\n': `\n`, '\r': `\r`, '\t': `\t`, '$': `\$`}, control: hexOrBraceUnicode,
+	invalid: func(b byte) string { return hexByte(rune(b)) }}
+
+/*
+	String literal of PHP. It is single quoted if the value has no control characters.
+	Otherwise it is double quoted and "$" of interpolations is escaped.
+*/
+func PHP(src string) string {
+	for i := 0; i < len(src); i++ {
+		if src[i] < 0x20 || src[i] == 0x7F {
+			return phpEncoder.encode(src)
+		}
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(src) + "'"
+}
+
+// \x of C takes all following hex digits. Octal escapes have 3 digits at most.
+var objectiveCEncoder = encoder{prefix: "@", quote: '"', escapes: commonEscapes, control: objectiveCControl}
+
+func objectiveCControl(r rune) string {
+	if r < 0x80 {
+		return octalByte(r)
+	}
+	if r < 0xA0 {
+		// universal character names can't have C1 control characters
+		return string(r)
+	}
+	if r > 0xFFFF {
+		return fmt.Sprintf(`\U%08x`, r)
+	}
+	return fmt.Sprintf(`\u%04x`, r)
+}
+
+/*
+	NSString literal of Objective-C like @"value".
+*/
+func ObjectiveC(src string) string {
+	return objectiveCEncoder.encode(src)
+}
+
+var vimEncoder = encoder{quote: '"', escapes: commonEscapes, control: vimControl}
+
+func vimControl(r rune) string {
+	if r < 0x80 {
+		return hexByte(r)
+	}
+	if r > 0xFFFF {
+		return fmt.Sprintf(`\U%08x`, r)
+	}
+	return fmt.Sprintf(`\u%04x`, r)
+}
+
+/*
+	Double quoted string of Vim script.
+*/
+func Vim(src string) string {
+	return vimEncoder.encode(src)
+}
@@ -0,0 +1,48 @@
+package literal_test
+
+import (
+	"github.com/shibukawa/curl_as_dsl/literal"
+	. "gopkg.in/check.v1"
+)
+
+type LiteralTest struct{}
+
+var _ = Suite(&LiteralTest{})
+
+func (s *LiteralTest) Test_Go(c *C) {
+	c.Check(literal.Go(`a"b\c`), Equals, `"a\"b\\c"`)
+	c.Check(literal.Go("\xff\n"), Equals, `"\xff\n"`)
+	c.Check(literal.GoRaw(`{"a": "\n"}`), Equals, "`{\"a\": \"\\n\"}`")
+	c.Check(literal.GoRaw("a`b\\"), Equals, "\"a`b\\\\\"")
+	c.Check(literal.GoRaw("ab"), Equals, `"ab"`)
+	c.Check(literal.GoRaw("a\nb"), Equals, `"a\nb"`)
+}
+
+func (s *LiteralTest) Test_Python(c *C) {
+	c.Check(literal.Python(`it's "x" \ 日本`), Equals, `"it's \"x\" \\ 日本"`)
+	c.Check(literal.Python("\x00\u2028\U0001F600\xff"), Equals, `"\x00\u2028😀�"`)
+	c.Check(literal.PythonBytes("日\"\n"), Equals, `b"\xe6\x97\xa5\"\n"`)
+	c.Check(literal.PythonBytes("\xff"), Equals, `b"\xff"`)
+}
+
+func (s *LiteralTest) Test_JavaScript(c *C) {
+	c.Check(literal.JavaScript(`"</script>" \`), Equals, `"\"<\/script>\" \\"`)
+	c.Check(literal.JavaScript("\u2028\x7f${a}"), Equals, `"\u2028\x7f${a}"`)
+}
+
+func (s *LiteralTest) Test_Java(c *C) {
+	c.Check(literal.Java("a\"\\\n\x01"), Equals, `"a\"\\\n\001"`)
+	c.Check(literal.Java("é😀"), Equals, `"\u00e9\ud83d\ude00"`)
+	c.Check(literal.Kotlin("$a ${b} é"), Equals, `"\$a \${b} é"`)
+}
+
+func (s *LiteralTest) Test_OtherLanguages(c *C) {
+	c.Check(literal.CSharp("a\"\x01"), Equals, `"a\"\u0001"`)
+	c.Check(literal.Swift(`\(x)`+"\x01"), Equals, `"\\(x)\u{1}"`)
+	c.Check(literal.Rust("{}\"\x01"), Equals, `"{}\"\u{1}"`)
+	c.Check(literal.Ruby(`#{x} "a"`), Equals, `"\#{x} \"a\""`)
+	c.Check(literal.PHP(`it's $x \n`), Equals, `'it\'s $x \\n'`)
+	c.Check(literal.PHP("$x\n\"\xff"), Equals, `"\$x\n\"\xff"`)
+	c.Check(literal.ObjectiveC("a\"\x01é"), Equals, `@"a\"\001é"`)
+	c.Check(literal.Vim("a\"\\\x01"), Equals, `"a\"\\\x01"`)
+}
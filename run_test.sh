@@ -7,8 +7,25 @@ set -e
 
 ./run_test_go.sh
 ./run_test_py.sh
+./run_test_py_requests.sh
+./run_test_py_httpx.sh
+./run_test_py_httpx_async.sh
 ./run_test_nodejs.sh
+./run_test_fetch_node.sh
+./run_test_fetch_node.sh ts.fetch
 ./run_test_java.sh
+./run_test_java_httpclient.sh
+./run_test_kotlin.sh
+./run_test_csharp.sh
 ./run_test_objc.sh
 ./run_test_objc_connection.sh
 ./run_test_php.sh
+./run_test_ruby.sh
+./run_test_ruby.sh ruby.faraday
+./run_test_rust.sh
+./run_test_rust.sh rust.async
+./run_test_swift.sh
+./run_test_swift.sh swift.async
+./run_test_cli.sh httpie
+./run_test_cli.sh wget
+./run_test_cli.sh powershell
@@ -0,0 +1,125 @@
+#!/bin/bash
+
+set -e
+mkdir -p test/csharp
+cat > test/csharp/test.csproj <<EOT
+<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <OutputType>Exe</OutputType>
+    <TargetFramework>net8.0</TargetFramework>
+    <Nullable>enable</Nullable>
+  </PropertyGroup>
+</Project>
+EOT
+
+echo "case 1: simple get"
+./httpgen -t csharp curl http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 2: simple post with data"
+./httpgen -t csharp curl -d test http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 3: post multiple datas"
+./httpgen -t csharp curl -d test -d hello http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 4: post url encoded data"
+./httpgen -t csharp curl --data-urlencode="test% =" http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 5: get with parameter"
+./httpgen -t csharp curl -G -d hello http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 6: get with aprameter (key=value style)"
+./httpgen -t csharp curl -G -d hello=world http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 7: post with aprameter (key=value style)"
+./httpgen -t csharp curl -X POST -G -d hello=world http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 8: simple post without data"
+./httpgen -t csharp curl -X POST http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 9: simple post with local file content"
+./httpgen -t csharp curl -X POST -T test.csproj http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 10: post form"
+./httpgen -t csharp curl -F hello=world http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 10-2: post form (2)"
+./httpgen -t csharp curl -F hello=world -F good=morning http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 11: post text data from local file"
+./httpgen -t csharp curl --data-ascii @test.csproj http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 12: post text data from local files"
+./httpgen -t csharp curl --data-ascii @test.csproj --data-ascii @test.csproj http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 13: post data from local file"
+./httpgen -t csharp curl --data-binary @test.csproj http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 14: post data from local files"
+./httpgen -t csharp curl --data-binary @test.csproj --data-binary @test.csproj http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 15: post url encoded data from local file"
+./httpgen -t csharp curl --data-urlencode @test.csproj http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 16: post url encoded data from local files"
+./httpgen -t csharp curl --data-urlencode @test.csproj --data-urlencode @test.csproj http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 17: send file in form protocol"
+./httpgen -t csharp curl -F "file=@test.csproj" http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 18: send file in form protocol with explicit name and type"
+./httpgen -t csharp curl -F "file=@test.csproj;filename=nameinpost;type=text/plain" http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 19: send file in form protocol (2)"
+./httpgen -t csharp curl -F "file=<test.csproj" http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 20: send file in form protocol with explicit type (2)"
+./httpgen -t csharp curl -F "file=<test.csproj;type=text/plain" http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 21: get with aprameter and header"
+./httpgen -t csharp curl -H "Accept: text/html" -G -d hello=world http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 22: simple post with data and user-agent"
+./httpgen -t csharp curl --user-agent="Netscape 4.7" -d test http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 23: post url encoded data from local files with compressed option"
+./httpgen -t csharp curl --compressed --data-urlencode @test.csproj --data-urlencode @test.csproj http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 24: Basic authentication"
+./httpgen -t csharp curl -u USER:PASS http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 25: Digest authentication"
+./httpgen -t csharp curl --digest -u user:pass http://localhost:18888/auth > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 26: Cookie and cookie jar"
+./httpgen -t csharp curl -b "a=b" -c cookiejar.txt http://localhost:18888/cookie > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
+
+echo "case 27: Timeout"
+./httpgen -t csharp curl -m 10 --connect-timeout 5 http://localhost:18888 > test/csharp/Program.cs
+pushd test/csharp;dotnet run;popd
@@ -0,0 +1,133 @@
+#!/bin/bash
+
+TARGET=${1:-js.fetch.node}
+if [ "$TARGET" = "ts.fetch" ]; then
+    FILE=test.mts
+    RUN="npx -y tsx"
+else
+    FILE=test.mjs
+    RUN=node
+fi
+
+set -e
+mkdir -p test
+echo "hello world" > test/input.txt
+
+echo "case 1: simple get"
+./httpgen -t $TARGET curl http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 2: simple post with data"
+./httpgen -t $TARGET curl -d test http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 3: post multiple datas"
+./httpgen -t $TARGET curl -d test -d hello http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 4: post url encoded data"
+./httpgen -t $TARGET curl --data-urlencode="test% =" http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 5: get with parameter"
+./httpgen -t $TARGET curl -G -d hello http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 6: get with aprameter (key=value style)"
+./httpgen -t $TARGET curl -G -d hello=world http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 7: post with aprameter (key=value style)"
+./httpgen -t $TARGET curl -X POST -G -d hello=world http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 8: simple post without data"
+./httpgen -t $TARGET curl -X POST http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 9: simple post with local file content"
+./httpgen -t $TARGET curl -X POST -T input.txt http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 10: post form"
+./httpgen -t $TARGET curl -F hello=world http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 10-2: post form (2)"
+./httpgen -t $TARGET curl -F hello=world -F good=morning http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 11: post text data from local file"
+./httpgen -t $TARGET curl --data-ascii @input.txt http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 12: post text data from local files"
+./httpgen -t $TARGET curl --data-ascii @input.txt --data-ascii @input.txt http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 13: post data from local file"
+./httpgen -t $TARGET curl --data-binary @input.txt http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 14: post data from local files"
+./httpgen -t $TARGET curl --data-binary @input.txt --data-binary @input.txt http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 15: post url encoded data from local file"
+./httpgen -t $TARGET curl --data-urlencode @input.txt http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 16: post url encoded data from local files"
+./httpgen -t $TARGET curl --data-urlencode @input.txt --data-urlencode @input.txt http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 17: send file in form protocol"
+./httpgen -t $TARGET curl -F "file=@input.txt" http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 18: send file in form protocol with explicit name and type"
+./httpgen -t $TARGET curl -F "file=@input.txt;filename=nameinpost;type=text/plain" http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 19: send file in form protocol (2)"
+./httpgen -t $TARGET curl -F "file=<input.txt" http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 20: send file in form protocol with explicit type (2)"
+./httpgen -t $TARGET curl -F "file=<input.txt;type=text/plain" http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 21: get with prameter and header"
+./httpgen -t $TARGET curl -H "Accept: text/html" -G -d hello=world http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 22: simple post with data and user-agent"
+./httpgen -t $TARGET curl --user-agent="Netscape 4.7" -d test http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 23: post url encoded data from local files with compressed option"
+./httpgen -t $TARGET curl --compressed --data-urlencode @input.txt --data-urlencode @input.txt http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 24: Basic authentication"
+./httpgen -t $TARGET curl -u USER:PASS http://localhost:18888 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+# -k and --http2 use undici's Agent
+pushd test;npm install --no-save undici;popd
+
+echo "case 25: simple get with https"
+./httpgen -t $TARGET curl --insecure https://localhost:18889 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 26: simple get with http2"
+./httpgen -t $TARGET curl --insecure --http2 https://localhost:18889 > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 27: Digest authentication"
+./httpgen -t $TARGET curl --digest -u user:pass http://localhost:18888/auth > test/$FILE
+pushd test;$RUN $FILE;popd
+
+echo "case 28: Cookie and cookie jar"
+./httpgen -t $TARGET curl -b "a=b" -c cookiejar.txt http://localhost:18888/cookie > test/$FILE
+pushd test;$RUN $FILE;popd
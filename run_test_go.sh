@@ -110,3 +110,10 @@ echo "case 26: simple get with http2"
 ./httpgen curl --insecure --http2 https://localhost:18889 > test/test.go
 pushd test;go build;./test;popd
 
+echo "case 27: Digest authentication"
+./httpgen curl --digest -u user:pass http://localhost:18888/auth > test/test.go
+pushd test;go build;./test;popd
+
+echo "case 28: Cookie and cookie jar"
+./httpgen curl -b "a=b" -c cookiejar.txt http://localhost:18888/cookie > test/test.go
+pushd test;go build;./test;popd
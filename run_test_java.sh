@@ -101,3 +101,10 @@ echo "case 24: Basic authentication"
 ./httpgen -t java curl -u USER:PASS http://localhost:18888 > test/Main.java
 pushd test;javac Main.java;java Main;popd
 
+echo "case 25: Digest authentication"
+./httpgen -t java curl --digest -u user:pass http://localhost:18888/auth > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 26: Cookie and cookie jar"
+./httpgen -t java curl -b "a=b" -c cookiejar.txt http://localhost:18888/cookie > test/Main.java
+pushd test;javac Main.java;java Main;popd
@@ -0,0 +1,114 @@
+#!/bin/bash
+
+set -e
+echo "case 1: simple get"
+./httpgen -t java.httpclient curl http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 2: simple post with data"
+./httpgen -t java.httpclient curl -d test http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 3: post multiple datas"
+./httpgen -t java.httpclient curl -d test -d hello http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 4: post url encoded data"
+./httpgen -t java.httpclient curl --data-urlencode="test% =" http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 5: get with parameter"
+./httpgen -t java.httpclient curl -G -d hello http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 6: get with aprameter (key=value style)"
+./httpgen -t java.httpclient curl -G -d hello=world http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 7: post with aprameter (key=value style)"
+./httpgen -t java.httpclient curl -X POST -G -d hello=world http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 8: simple post without data"
+./httpgen -t java.httpclient curl -X POST http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 9: simple post with local file content"
+./httpgen -t java.httpclient curl -X POST -T Main.java http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 10: post form"
+./httpgen -t java.httpclient curl -F hello=world http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 10-2: post form (2)"
+./httpgen -t java.httpclient curl -F hello=world -F good=morning http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 11: post text data from local file"
+./httpgen -t java.httpclient curl --data-ascii @Main.java http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 12: post text data from local files"
+./httpgen -t java.httpclient curl --data-ascii @Main.java --data-ascii @Main.java http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 13: post data from local file"
+./httpgen -t java.httpclient curl --data-binary @Main.java http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 14: post data from local files"
+./httpgen -t java.httpclient curl --data-binary @Main.java --data-binary @Main.java http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 15: post url encoded data from local file"
+./httpgen -t java.httpclient curl --data-urlencode @Main.java http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 16: post url encoded data from local files"
+./httpgen -t java.httpclient curl --data-urlencode @Main.java --data-urlencode @Main.java http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 17: send file in form protocol"
+./httpgen -t java.httpclient curl -F "file=@Main.java" http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 18: send file in form protocol with explicit name and type"
+./httpgen -t java.httpclient curl -F "file=@Main.java;filename=nameinpost;type=text/plain" http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 19: send file in form protocol (2)"
+./httpgen -t java.httpclient curl -F "file=<Main.java" http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 20: send file in form protocol with explicit type (2)"
+./httpgen -t java.httpclient curl -F "file=<Main.java;type=text/plain" http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 21: get with aprameter and header"
+./httpgen -t java.httpclient curl -H "Accept: text/html" -G -d hello=world http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 22: simple post with data and user-agent"
+./httpgen -t java.httpclient curl --user-agent="Netscape 4.7" -d test http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 23: post url encoded data from local files with compressed option"
+./httpgen -t java.httpclient curl --compressed --data-urlencode @Main.java --data-urlencode @Main.java http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 24: Basic authentication"
+./httpgen -t java.httpclient curl -u USER:PASS http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 25: Digest authentication"
+./httpgen -t java.httpclient curl --digest -u user:pass http://localhost:18888/auth > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 26: Cookie and cookie jar"
+./httpgen -t java.httpclient curl -b "a=b" -c cookiejar.txt http://localhost:18888/cookie > test/Main.java
+pushd test;javac Main.java;java Main;popd
+
+echo "case 27: Timeout"
+./httpgen -t java.httpclient curl -m 10 --connect-timeout 5 http://localhost:18888 > test/Main.java
+pushd test;javac Main.java;java Main;popd
@@ -0,0 +1,135 @@
+#!/bin/bash
+
+set -e
+mkdir -p test/kotlin/src/main/kotlin
+cat > test/kotlin/build.gradle.kts <<EOT
+plugins {
+    kotlin("jvm") version "1.9.24"
+    application
+}
+
+repositories {
+    mavenCentral()
+}
+
+dependencies {
+    implementation("com.squareup.okhttp3:okhttp:4.12.0")
+    implementation("com.squareup.okhttp3:okhttp-urlconnection:4.12.0")
+}
+
+application {
+    mainClass.set("MainKt")
+}
+EOT
+
+echo "case 1: simple get"
+./httpgen -t kotlin.okhttp curl http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 2: simple post with data"
+./httpgen -t kotlin.okhttp curl -d test http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 3: post multiple datas"
+./httpgen -t kotlin.okhttp curl -d test -d hello http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 4: post url encoded data"
+./httpgen -t kotlin.okhttp curl --data-urlencode="test% =" http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 5: get with parameter"
+./httpgen -t kotlin.okhttp curl -G -d hello http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 6: get with aprameter (key=value style)"
+./httpgen -t kotlin.okhttp curl -G -d hello=world http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 7: post with aprameter (key=value style)"
+./httpgen -t kotlin.okhttp curl -X POST -G -d hello=world http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 8: simple post without data"
+./httpgen -t kotlin.okhttp curl -X POST http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 9: simple post with local file content"
+./httpgen -t kotlin.okhttp curl -X POST -T build.gradle.kts http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 10: post form"
+./httpgen -t kotlin.okhttp curl -F hello=world http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 10-2: post form (2)"
+./httpgen -t kotlin.okhttp curl -F hello=world -F good=morning http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 11: post text data from local file"
+./httpgen -t kotlin.okhttp curl --data-ascii @build.gradle.kts http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 12: post text data from local files"
+./httpgen -t kotlin.okhttp curl --data-ascii @build.gradle.kts --data-ascii @build.gradle.kts http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 13: post data from local file"
+./httpgen -t kotlin.okhttp curl --data-binary @build.gradle.kts http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 14: post data from local files"
+./httpgen -t kotlin.okhttp curl --data-binary @build.gradle.kts --data-binary @build.gradle.kts http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 15: post url encoded data from local file"
+./httpgen -t kotlin.okhttp curl --data-urlencode @build.gradle.kts http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 16: post url encoded data from local files"
+./httpgen -t kotlin.okhttp curl --data-urlencode @build.gradle.kts --data-urlencode @build.gradle.kts http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 17: send file in form protocol"
+./httpgen -t kotlin.okhttp curl -F "file=@build.gradle.kts" http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 18: send file in form protocol with explicit name and type"
+./httpgen -t kotlin.okhttp curl -F "file=@build.gradle.kts;filename=nameinpost;type=text/plain" http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 19: send file in form protocol (2)"
+./httpgen -t kotlin.okhttp curl -F "file=<build.gradle.kts" http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 20: send file in form protocol with explicit type (2)"
+./httpgen -t kotlin.okhttp curl -F "file=<build.gradle.kts;type=text/plain" http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 21: get with aprameter and header"
+./httpgen -t kotlin.okhttp curl -H "Accept: text/html" -G -d hello=world http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 22: simple post with data and user-agent"
+./httpgen -t kotlin.okhttp curl --user-agent="Netscape 4.7" -d test http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 23: post url encoded data from local files with compressed option"
+./httpgen -t kotlin.okhttp curl --compressed --data-urlencode @build.gradle.kts --data-urlencode @build.gradle.kts http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 24: Basic authentication"
+./httpgen -t kotlin.okhttp curl -u USER:PASS http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 25: Digest authentication"
+./httpgen -t kotlin.okhttp curl --digest -u user:pass http://localhost:18888/auth > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 26: Cookie and cookie jar"
+./httpgen -t kotlin.okhttp curl -b "a=b" -c cookiejar.txt http://localhost:18888/cookie > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
+
+echo "case 27: Timeout"
+./httpgen -t kotlin.okhttp curl -m 10 --connect-timeout 5 http://localhost:18888 > test/kotlin/src/main/kotlin/Main.kt
+pushd test/kotlin;gradle run -q;popd
@@ -108,3 +108,11 @@ pushd test;node test.js;popd
 echo "case 26: simple get with http2"
 ./httpgen -t node curl --insecure --http2 https://localhost:18889 > test/test.js
 pushd test;node test.js;popd
+
+echo "case 27: Digest authentication"
+./httpgen -t node curl --digest -u user:pass http://localhost:18888/auth > test/test.js
+pushd test;node test.js;popd
+
+echo "case 28: Cookie and cookie jar"
+./httpgen -t node curl -b "a=b" -c cookiejar.txt http://localhost:18888/cookie > test/test.js
+pushd test;node test.js;popd
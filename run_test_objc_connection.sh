@@ -102,3 +102,10 @@ echo "case 24: Basic authentication"
 ./httpgen -t objc.connection curl -u USER:PASS http://localhost:18888 > test/test.m
 pushd test;clang test.m -framework Foundation -framework AppKit -o test;./test;popd
 
+echo "case 25: Digest authentication"
+./httpgen -t objc.connection curl --digest -u user:pass http://localhost:18888/auth > test/test.m
+pushd test;clang test.m -framework Foundation -framework AppKit -o test;./test;popd
+
+echo "case 26: Cookie and cookie jar"
+./httpgen -t objc.connection curl -b "a=b" -c cookiejar.txt http://localhost:18888/cookie > test/test.m
+pushd test;clang test.m -framework Foundation -framework AppKit -o test;./test;popd
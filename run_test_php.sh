@@ -101,3 +101,10 @@ echo "case 24: Basic authentication"
 ./httpgen -t php curl -u USER:PASS http://localhost:18888 > test/test.php
 pushd test;php56 test.php;popd
 
+echo "case 25: Digest authentication"
+./httpgen -t php curl --digest -u user:pass http://localhost:18888/auth > test/test.php
+pushd test;php56 test.php;popd
+
+echo "case 26: Cookie and cookie jar"
+./httpgen -t php curl -b "a=b" -c cookiejar.txt http://localhost:18888/cookie > test/test.php
+pushd test;php56 test.php;popd
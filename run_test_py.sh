@@ -101,3 +101,10 @@ echo "case 24: Basic authentication"
 ./httpgen -t py curl -u USER:PASS http://localhost:18888 > test/test.py
 pushd test;python3 test.py;popd
 
+echo "case 25: Digest authentication"
+./httpgen -t py curl --digest -u user:pass http://localhost:18888/auth > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 26: Cookie and cookie jar"
+./httpgen -t py curl -b "a=b" -c cookiejar.txt http://localhost:18888/cookie > test/test.py
+pushd test;python3 test.py;popd
@@ -0,0 +1,114 @@
+#!/bin/bash
+
+set -e
+echo "case 1: simple get"
+./httpgen -t python.httpx.async curl http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 2: simple post with data"
+./httpgen -t python.httpx.async curl -d test http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 3: post multiple datas"
+./httpgen -t python.httpx.async curl -d test -d hello http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 4: post url encoded data"
+./httpgen -t python.httpx.async curl --data-urlencode="test% =" http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 5: get with parameter"
+./httpgen -t python.httpx.async curl -G -d hello http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 6: get with aprameter (key=value style)"
+./httpgen -t python.httpx.async curl -G -d hello=world http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 7: post with aprameter (key=value style)"
+./httpgen -t python.httpx.async curl -X POST -G -d hello=world http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 8: simple post without data"
+./httpgen -t python.httpx.async curl -X POST http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 9: simple post with local file content"
+./httpgen -t python.httpx.async curl -X POST -T test.py http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 10: post form"
+./httpgen -t python.httpx.async curl -F hello=world http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 10-2: post form (2)"
+./httpgen -t python.httpx.async curl -F hello=world -F good=morning http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 11: post text data from local file"
+./httpgen -t python.httpx.async curl --data-ascii @test.py http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 12: post text data from local files"
+./httpgen -t python.httpx.async curl --data-ascii @test.py --data-ascii @test.py http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 13: post data from local file"
+./httpgen -t python.httpx.async curl --data-binary @test.py http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 14: post data from local files"
+./httpgen -t python.httpx.async curl --data-binary @test.py --data-binary @test.py http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 15: post url encoded data from local file"
+./httpgen -t python.httpx.async curl --data-urlencode @test.py http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 16: post url encoded data from local files"
+./httpgen -t python.httpx.async curl --data-urlencode @test.py --data-urlencode @test.py http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 17: send file in form protocol"
+./httpgen -t python.httpx.async curl -F "file=@test.py" http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 18: send file in form protocol with explicit name and type"
+./httpgen -t python.httpx.async curl -F "file=@test.py;filename=nameinpost;type=text/plain" http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 19: send file in form protocol (2)"
+./httpgen -t python.httpx.async curl -F "file=<test.py" http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 20: send file in form protocol with explicit type (2)"
+./httpgen -t python.httpx.async curl -F "file=<test.py;type=text/plain" http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 21: get with aprameter and header"
+./httpgen -t python.httpx.async curl -H "Accept: text/html" -G -d hello=world http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 22: simple post with data and user-agent"
+./httpgen -t python.httpx.async curl --user-agent="Netscape 4.7" -d test http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 23: post url encoded data from local files with compressed option"
+./httpgen -t python.httpx.async curl --compressed --data-urlencode @test.py --data-urlencode @test.py http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 24: Basic authentication"
+./httpgen -t python.httpx.async curl -u USER:PASS http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 25: Digest authentication"
+./httpgen -t python.httpx.async curl --digest -u user:pass http://localhost:18888/auth > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 26: Cookie and cookie jar"
+./httpgen -t python.httpx.async curl -b "a=b" -c cookiejar.txt http://localhost:18888/cookie > test/test.py
+pushd test;python3 test.py;popd
+
+echo "case 27: Timeout"
+./httpgen -t python.httpx.async curl -m 10 --connect-timeout 5 http://localhost:18888 > test/test.py
+pushd test;python3 test.py;popd
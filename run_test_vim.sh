@@ -101,3 +101,10 @@ echo "case 24: Basic authentication"
 ./httpgen -t vim curl -u USER:PASS http://localhost:18888 > test/test.vim
 pushd test;vim -S test.vim;popd
 
+echo "case 25: Digest authentication"
+./httpgen -t vim curl --digest -u user:pass http://localhost:18888/auth > test/test.vim
+pushd test;vim -S test.vim;popd
+
+echo "case 26: Cookie and cookie jar"
+./httpgen -t vim curl -b "a=b" -c cookiejar.txt http://localhost:18888/cookie > test/test.vim
+pushd test;vim -S test.vim;popd
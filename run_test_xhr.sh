@@ -106,3 +106,10 @@ echo "case 24: Basic authentication"
 ./httpgen -t xhr curl -u USER:PASS http://localhost:18888 > testserver/test.html
 open http://localhost:18888/js?case24;sleep 1
 
+echo "case 25: Digest authentication"
+./httpgen -t xhr curl --digest -u user:pass http://localhost:18888/auth > testserver/test.html
+open http://localhost:18888/js?case25;sleep 1
+
+echo "case 26: Cookie and cookie jar"
+./httpgen -t xhr curl -b "a=b" -c cookiejar.txt http://localhost:18888/cookie > testserver/test.html
+open http://localhost:18888/js?case26;sleep 1
@@ -0,0 +1,55 @@
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+	Read Netscape format cookie file of -b option. Missing file is ignored like curl.
+*/
+func loadCookies(jar http.CookieJar, fileName string) {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimPrefix(strings.TrimSpace(line), "#HttpOnly_")
+		fields := strings.Split(line, "\t")
+		if strings.HasPrefix(line, "#") || len(fields) != 7 {
+			continue
+		}
+		cookie := &http.Cookie{Name: fields[5], Value: fields[6], Path: fields[2], Secure: fields[3] == "TRUE"}
+		if fields[1] == "TRUE" {
+			cookie.Domain = fields[0]
+		}
+		if expires, _ := strconv.ParseInt(fields[4], 10, 64); expires > 0 {
+			cookie.Expires = time.Unix(expires, 0)
+		}
+		scheme := "http"
+		if cookie.Secure {
+			scheme = "https"
+		}
+		jar.SetCookies(&url.URL{Scheme: scheme, Host: strings.TrimPrefix(fields[0], "."), Path: fields[2]}, []*http.Cookie{cookie})
+	}
+}
+
+/*
+	Write cookies of the URL to Netscape format cookie file of -c option.
+	http.CookieJar only returns names and values of cookies for the URL.
+*/
+func saveCookies(jar http.CookieJar, u *url.URL, fileName string) error {
+	var buffer bytes.Buffer
+	buffer.WriteString("# Netscape HTTP Cookie File\n")
+	secure := strings.ToUpper(strconv.FormatBool(u.Scheme == "https"))
+	for _, cookie := range jar.Cookies(u) {
+		fmt.Fprintf(&buffer, "%s\tFALSE\t/\t%s\t0\t%s\t%s\n", u.Hostname(), secure, cookie.Name, cookie.Value)
+	}
+	return ioutil.WriteFile(fileName, buffer.Bytes(), 0644)
+}
@@ -0,0 +1,410 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/textproto"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+/*
+	Output options of run command. They are same as curl's.
+*/
+type Output struct {
+	Include  bool   // -i: write the status line and headers before the body
+	File     string // -o: write the output to the file instead of stdout
+	WriteOut string // -w: write the format like "%{http_code}\n" to stdout after the body
+}
+
+/*
+	Send the request of the options and write the response like curl.
+	Redirects are not followed like curl without -L option.
+*/
+func Run(ctx context.Context, options *common.CurlOptions, output Output, stdout io.Writer) error {
+	start := time.Now()
+	resp, err := Do(ctx, options)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	writer := stdout
+	if output.File != "" {
+		file, err := os.Create(output.File)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer = file
+	}
+	if output.Include || options.Head {
+		writeHeader(writer, resp)
+	}
+	size, err := io.Copy(writer, resp.Body)
+	if err != nil {
+		return err
+	}
+	if output.WriteOut != "" {
+		io.WriteString(stdout, writeOut(output.WriteOut, resp, size, time.Since(start)))
+	}
+	return nil
+}
+
+func writeHeader(writer io.Writer, resp *http.Response) {
+	fmt.Fprintf(writer, "%s %s\r\n", resp.Proto, resp.Status)
+	var keys []string
+	for key := range resp.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range resp.Header[key] {
+			fmt.Fprintf(writer, "%s: %s\r\n", key, value)
+		}
+	}
+	io.WriteString(writer, "\r\n")
+}
+
+/*
+	Send the request of the options. Digest authentication retries the request with the challenge of the server.
+	Cookies are saved to the file of -c option.
+*/
+func Do(ctx context.Context, options *common.CurlOptions) (*http.Response, error) {
+	if err := options.CheckError(); err != nil {
+		return nil, err
+	}
+	client, err := NewClient(options)
+	if err != nil {
+		return nil, err
+	}
+	request, err := NewRequest(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if options.UseDigestAuth() && resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		user, password := options.UserAndPassword()
+		request.Header.Set("Authorization", digestAuthorization(resp.Header.Get("WWW-Authenticate"), request.Method, request.URL.RequestURI(), user, password))
+		if request.GetBody != nil {
+			request.Body, _ = request.GetBody()
+		}
+		resp, err = client.Do(request)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if options.CookieJar != "" {
+		if err := saveCookies(client.Jar, request.URL, options.CookieJar); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+/*
+	Client with -k, -x, -b FILE, -c, --connect-timeout and -m options.
+*/
+func NewClient(options *common.CurlOptions) (*http.Client, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if options.ConnectTimeout > 0 {
+		dialer.Timeout = time.Duration(options.ConnectTimeout * float64(time.Second))
+	}
+	transport := &http.Transport{
+		Proxy:             http.ProxyFromEnvironment,
+		DialContext:       dialer.DialContext,
+		ForceAttemptHTTP2: true,
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: options.Insecure},
+	}
+	if options.Proxy != "" {
+		proxy := options.Proxy
+		// curl uses http:// when the proxy doesn't have scheme
+		if !strings.Contains(proxy, "://") {
+			proxy = "http://" + proxy
+		}
+		proxyUrl, err := url.Parse(proxy)
+		if err != nil {
+			return nil, &common.URLParseError{Url: options.Proxy, Err: err}
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+	client := &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	if options.MaxTime > 0 {
+		client.Timeout = time.Duration(options.MaxTime * float64(time.Second))
+	}
+	if options.UseCookieJar() {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, fileName := range options.CookieFiles() {
+			loadCookies(jar, fileName)
+		}
+		client.Jar = jar
+	}
+	return client, nil
+}
+
+/*
+	Request with the method, data, headers, cookies and authentication of the options.
+	Files of -d @FILE, -F and -T options are read here.
+*/
+func NewRequest(ctx context.Context, options *common.CurlOptions) (*http.Request, error) {
+	targetUrl := options.Url
+	if options.ProcessedData.UploadFile() != "" {
+		// curl appends the file name of -T to the URL like "http://host/dir/". Options that
+		// ParseCurlCommand() didn't make may not have it yet
+		uploadOptions := options.Clone()
+		uploadOptions.AppendUploadFileName()
+		targetUrl = uploadOptions.Url
+	}
+	// curl uses http:// when the URL doesn't have scheme
+	if !strings.Contains(targetUrl, "://") {
+		targetUrl = "http://" + targetUrl
+	}
+	var body []byte
+	var contentType string
+	var err error
+	if fileName := options.ProcessedData.UploadFile(); fileName != "" {
+		// -T sends the file as is without Content-Type
+		body, err = ioutil.ReadFile(fileName)
+	} else if options.ProcessedData.HasForm() {
+		body, contentType, err = formBody(options.ProcessedData)
+	} else if options.ProcessedData.HasData() {
+		body, err = dataBody(options.ProcessedData)
+		contentType = "application/x-www-form-urlencoded"
+		if options.Get {
+			if strings.Contains(targetUrl, "?") {
+				targetUrl += "&" + string(body)
+			} else {
+				targetUrl += "?" + string(body)
+			}
+			body, contentType = nil, ""
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, options.Method(), targetUrl, reader)
+	if err != nil {
+		return nil, &common.URLParseError{Url: options.Url, Err: err}
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	for _, header := range options.Headers() {
+		key := textproto.CanonicalMIMEHeaderKey(header[0])
+		switch {
+		case header[1] == "":
+			// "-H 'Name:'" removes the header like curl
+			request.Header.Del(key)
+		case key == "Host":
+			request.Host = header[1]
+		case key == "Content-Type" && strings.HasPrefix(contentType, "multipart/form-data") && !strings.Contains(header[1], "boundary="):
+			request.Header.Set(key, header[1]+contentType[len("multipart/form-data"):])
+		case key == "Content-Type":
+			request.Header.Set(key, header[1])
+		default:
+			request.Header.Add(key, header[1])
+		}
+	}
+	if options.UseBasicAuth() {
+		request.SetBasicAuth(options.UserAndPassword())
+	}
+	for _, cookie := range options.Cookies() {
+		request.AddCookie(&http.Cookie{Name: cookie[0], Value: cookie[1]})
+	}
+	if keys := strings.SplitN(options.AWSV2, ":", 2); len(keys) == 2 {
+		signAWSV2(request, keys[0], keys[1], "", request.Header.Get("Content-Type"))
+	}
+	return request, nil
+}
+
+/*
+	Body of -d, --data-binary, --data-urlencode and -T options. Multiple data are joined with "&".
+*/
+func dataBody(data common.DataOptions) ([]byte, error) {
+	var buffer bytes.Buffer
+	for i, option := range data {
+		if i > 0 {
+			buffer.WriteByte('&')
+		}
+		switch option.Type {
+		case common.DataAsciiType:
+			content, err := readValue(&option)
+			if err != nil {
+				return nil, err
+			}
+			// curl removes carriage returns and newlines of -d option
+			buffer.WriteString(strings.NewReplacer("\r", "", "\n", "").Replace(content))
+		case common.DataBinaryType:
+			content, err := readValue(&option)
+			if err != nil {
+				return nil, err
+			}
+			buffer.WriteString(content)
+		case common.DataUrlEncodeType:
+			content, err := urlEncode(&option)
+			if err != nil {
+				return nil, err
+			}
+			buffer.WriteString(content)
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+func readValue(option *common.DataOption) (string, error) {
+	if option.Raw || !strings.HasPrefix(option.Value, "@") {
+		return option.Value, nil
+	}
+	content, err := ioutil.ReadFile(option.Value[1:])
+	return string(content), err
+}
+
+/*
+	Value of --data-urlencode. Only the content is encoded like curl.
+*/
+func urlEncode(option *common.DataOption) (string, error) {
+	name, content, isFile := option.UrlEncodeParts()
+	if isFile {
+		file, err := ioutil.ReadFile(content)
+		if err != nil {
+			return "", err
+		}
+		content = string(file)
+	}
+	if name != "" {
+		name += "="
+	}
+	return name + url.QueryEscape(content), nil
+}
+
+/*
+	multipart/form-data body of -F and --form-string options. It returns the content type with the boundary.
+*/
+func formBody(data common.DataOptions) ([]byte, string, error) {
+	var buffer bytes.Buffer
+	writer := multipart.NewWriter(&buffer)
+	for _, option := range data {
+		field := strings.SplitN(option.Value, "=", 2)
+		if option.Type == common.FormStringType || !(strings.HasPrefix(field[1], "@") || strings.HasPrefix(field[1], "<")) {
+			writer.WriteField(field[0], field[1])
+			continue
+		}
+		fragments := strings.Split(field[1][1:], ";")
+		sourceFile := fragments[0]
+		sentFileName := fragments[0]
+		contentType := ""
+		for _, fragment := range fragments[1:] {
+			if strings.HasPrefix(fragment, "filename=") {
+				sentFileName = fragment[9:]
+			} else if strings.HasPrefix(fragment, "type=") {
+				contentType = fragment[5:]
+			}
+		}
+		content, err := ioutil.ReadFile(sourceFile)
+		if err != nil {
+			return nil, "", err
+		}
+		header := make(textproto.MIMEHeader)
+		if option.SendAsFormFile() {
+			if contentType == "" {
+				contentType = common.FormFileContentType(sourceFile)
+			}
+			header.Set("Content-Disposition", fmt.Sprintf("form-data; name=%q; filename=%q", field[0], sentFileName))
+		} else {
+			// name=<file sends the content as a field value
+			header.Set("Content-Disposition", fmt.Sprintf("form-data; name=%q", field[0]))
+		}
+		if contentType != "" {
+			header.Set("Content-Type", contentType)
+		}
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		part.Write(content)
+	}
+	writer.Close()
+	return buffer.Bytes(), writer.FormDataContentType(), nil
+}
+
+/*
+	Same signature as SignAWSV2() that client/golang writes for --awsv2 option.
+*/
+func signAWSV2(req *http.Request, accessKey, secretKey, md5, contentType string) {
+	dateStr := time.Now().UTC().Format(time.RFC1123Z)
+	req.Header.Set("Date", dateStr)
+	if md5 != "" {
+		req.Header.Set("Content-MD5", md5)
+	}
+	strToSign := fmt.Sprintf("%s\n%s\n%s\n%s\n%s", req.Method, md5, contentType, dateStr, req.URL.Path)
+	hash := hmac.New(sha1.New, []byte(secretKey))
+	hash.Write([]byte(strToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS %s:%s", accessKey, base64.StdEncoding.EncodeToString(hash.Sum(nil))))
+}
+
+var challengePattern = regexp.MustCompile(`(\w+)=(?:"([^"]*)"|([^,\s]*))`)
+
+/*
+	Same as DigestAuthorization() that client/golang writes for --digest option.
+*/
+func digestAuthorization(challenge, method, uri, username, password string) string {
+	params := make(map[string]string)
+	for _, match := range challengePattern.FindAllStringSubmatch(challenge, -1) {
+		params[match[1]] = match[2] + match[3]
+	}
+	md5Hex := func(text string) string {
+		return fmt.Sprintf("%x", md5.Sum([]byte(text)))
+	}
+	ha1 := md5Hex(strings.Join([]string{username, params["realm"], password}, ":"))
+	ha2 := md5Hex(method + ":" + uri)
+	authorization := fmt.Sprintf("Digest username=\"%s\", realm=\"%s\", nonce=\"%s\", uri=\"%s\"", username, params["realm"], params["nonce"], uri)
+	if params["qop"] != "" {
+		cnonce := make([]byte, 8)
+		rand.Read(cnonce)
+		response := md5Hex(strings.Join([]string{ha1, params["nonce"], "00000001", hex.EncodeToString(cnonce), "auth", ha2}, ":"))
+		authorization += fmt.Sprintf(", qop=auth, nc=00000001, cnonce=\"%x\", response=\"%s\"", cnonce, response)
+	} else {
+		authorization += fmt.Sprintf(", response=\"%s\"", md5Hex(strings.Join([]string{ha1, params["nonce"], ha2}, ":")))
+	}
+	if params["opaque"] != "" {
+		authorization += fmt.Sprintf(", opaque=\"%s\"", params["opaque"])
+	}
+	return authorization + ", algorithm=MD5"
+}
@@ -0,0 +1,208 @@
+package runner_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/runner"
+	. "gopkg.in/check.v1"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type RunnerTest struct {
+	server   *httptest.Server
+	received *http.Request
+	body     string
+	dir      string
+}
+
+var _ = Suite(&RunnerTest{})
+
+func (s *RunnerTest) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := ioutil.ReadAll(r.Body)
+		s.received, s.body = r, string(content)
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, "hello")
+	}))
+}
+
+func (s *RunnerTest) TearDownTest(c *C) {
+	s.server.Close()
+}
+
+func (s *RunnerTest) run(c *C, output runner.Output, command string) string {
+	options, err := common.ParseCurlCommand(strings.Replace(command, "URL", s.server.URL, -1))
+	c.Assert(err, IsNil)
+	var buffer bytes.Buffer
+	c.Assert(runner.Run(context.Background(), options, output, &buffer), IsNil)
+	return buffer.String()
+}
+
+func (s *RunnerTest) file(c *C, name, content string) string {
+	fileName := filepath.Join(s.dir, name)
+	c.Assert(ioutil.WriteFile(fileName, []byte(content), 0644), IsNil)
+	return fileName
+}
+
+func (s *RunnerTest) Test_Run(c *C) {
+	c.Check(s.run(c, runner.Output{}, `curl -H 'X-Test: 1' URL/path`), Equals, "hello")
+	c.Check(s.received.Method, Equals, "GET")
+	c.Check(s.received.URL.Path, Equals, "/path")
+	c.Check(s.received.Header.Get("X-Test"), Equals, "1")
+
+	result := s.run(c, runner.Output{Include: true, WriteOut: `\n%{http_code} %{content_type} %{size_download} %header{set-cookie}`}, `curl URL`)
+	c.Check(strings.HasPrefix(result, "HTTP/1.1 200 OK\r\n"), Equals, true)
+	c.Check(strings.Contains(result, "\r\nContent-Type: text/plain\r\n"), Equals, true)
+	c.Check(strings.HasSuffix(result, "\r\n\r\nhello\n200 text/plain 5 session=abc"), Equals, true)
+}
+
+func (s *RunnerTest) Test_Run_OutputFile(c *C) {
+	fileName := filepath.Join(s.dir, "out.txt")
+	c.Check(s.run(c, runner.Output{File: fileName, WriteOut: "%{http_code}"}, `curl URL`), Equals, "200")
+	content, err := ioutil.ReadFile(fileName)
+	c.Assert(err, IsNil)
+	c.Check(string(content), Equals, "hello")
+}
+
+func (s *RunnerTest) Test_Run_Data(c *C) {
+	dataFile := s.file(c, "data.txt", "c=3\nd=4\n")
+	s.run(c, runner.Output{}, `curl -d a=1 -d @`+dataFile+` --data-urlencode 'q=a b&c' URL`)
+	c.Check(s.received.Method, Equals, "POST")
+	c.Check(s.received.Header.Get("Content-Type"), Equals, "application/x-www-form-urlencoded")
+	c.Check(s.body, Equals, "a=1&c=3d=4&q=a+b%26c")
+
+	// --data-raw sends "@" as is
+	s.run(c, runner.Output{}, `curl --data-raw @hello --data-raw a=1 URL`)
+	c.Check(s.body, Equals, "@hello&a=1")
+
+	s.run(c, runner.Output{}, `curl -G -d a=1 -d b=2 'URL/search?x=0'`)
+	c.Check(s.received.Method, Equals, "GET")
+	c.Check(s.received.URL.RawQuery, Equals, "x=0&a=1&b=2")
+	c.Check(s.body, Equals, "")
+}
+
+func (s *RunnerTest) Test_Run_Upload(c *C) {
+	uploadFile := s.file(c, "upload.bin", "binary\ncontent")
+	s.run(c, runner.Output{}, `curl -T `+uploadFile+` URL/upload`)
+	c.Check(s.received.Method, Equals, "PUT")
+	c.Check(s.received.URL.Path, Equals, "/upload")
+	c.Check(s.received.Header.Get("Content-Type"), Equals, "")
+	c.Check(s.body, Equals, "binary\ncontent")
+
+	// curl appends the file name to the URL that ends with "/"
+	s.run(c, runner.Output{}, `curl -T `+uploadFile+` 'URL/files/?x=1'`)
+	c.Check(s.received.URL.Path, Equals, "/files/upload.bin")
+	c.Check(s.received.URL.RawQuery, Equals, "x=1")
+
+	// options that are not made by ParseCurlCommand()
+	options := &common.CurlOptions{Url: s.server.URL + "/files/"}
+	options.Init()
+	options.Transfer(uploadFile)
+	c.Assert(runner.Run(context.Background(), options, runner.Output{}, ioutil.Discard), IsNil)
+	c.Check(s.received.URL.Path, Equals, "/files/upload.bin")
+	c.Check(options.Url, Equals, s.server.URL+"/files/")
+}
+
+func (s *RunnerTest) Test_Run_Form(c *C) {
+	uploadFile := s.file(c, "upload.txt", "file content")
+	s.run(c, runner.Output{}, `curl -F name=bob -F 'file=@`+uploadFile+`;type=text/plain;filename=a.txt' -F 'text=<`+uploadFile+`' --form-string 'raw=@raw' URL`)
+	c.Assert(strings.HasPrefix(s.received.Header.Get("Content-Type"), "multipart/form-data; boundary="), Equals, true)
+	request, _ := http.NewRequest("POST", "/", strings.NewReader(s.body))
+	request.Header.Set("Content-Type", s.received.Header.Get("Content-Type"))
+	c.Assert(request.ParseMultipartForm(1024), IsNil)
+	c.Check(request.MultipartForm.Value["name"], DeepEquals, []string{"bob"})
+	c.Check(request.MultipartForm.Value["text"], DeepEquals, []string{"file content"})
+	c.Check(request.MultipartForm.Value["raw"], DeepEquals, []string{"@raw"})
+	c.Assert(request.MultipartForm.File["file"], HasLen, 1)
+	c.Check(request.MultipartForm.File["file"][0].Filename, Equals, "a.txt")
+	c.Check(request.MultipartForm.File["file"][0].Header.Get("Content-Type"), Equals, "text/plain")
+}
+
+func (s *RunnerTest) Test_Run_Authentication(c *C) {
+	s.run(c, runner.Output{}, `curl -u admin:secret URL`)
+	user, password, ok := s.received.BasicAuth()
+	c.Check([]interface{}{user, password, ok}, DeepEquals, []interface{}{"admin", "secret", true})
+
+	s.run(c, runner.Output{}, `curl --awsv2 AK:SK URL/bucket`)
+	c.Check(strings.HasPrefix(s.received.Header.Get("Authorization"), "AWS AK:"), Equals, true)
+	c.Check(s.received.Header.Get("Date"), Not(Equals), "")
+}
+
+func (s *RunnerTest) Test_Run_Digest(c *C) {
+	s.server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := ioutil.ReadAll(r.Body)
+		s.received, s.body = r, string(content)
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Digest ") {
+			w.Header().Set("WWW-Authenticate", `Digest realm="test", nonce="abc", qop="auth"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, "authorized")
+	})
+	c.Check(s.run(c, runner.Output{}, `curl --digest -u admin:secret -d a=1 URL`), Equals, "authorized")
+	c.Check(strings.Contains(s.received.Header.Get("Authorization"), `username="admin", realm="test", nonce="abc", uri="/"`), Equals, true)
+	c.Check(s.body, Equals, "a=1")
+}
+
+func (s *RunnerTest) Test_Run_Cookies(c *C) {
+	host := strings.TrimPrefix(s.server.URL, "http://")
+	hostname := host[:strings.IndexByte(host, ':')]
+	cookieFile := s.file(c, "cookies.txt", hostname+"\tFALSE\t/\tFALSE\t0\tfromfile\t1\n")
+	jarFile := filepath.Join(s.dir, "jar.txt")
+	s.run(c, runner.Output{}, `curl -b 'a=1; b=2' -b `+cookieFile+` -c `+jarFile+` URL`)
+	var cookies []string
+	for _, cookie := range s.received.Cookies() {
+		cookies = append(cookies, cookie.String())
+	}
+	sort.Strings(cookies)
+	c.Check(cookies, DeepEquals, []string{"a=1", "b=2", "fromfile=1"})
+	content, err := ioutil.ReadFile(jarFile)
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(string(content), "\tsession\tabc\n"), Equals, true)
+}
+
+func (s *RunnerTest) Test_Run_Proxy(c *C) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.RequestURI
+		fmt.Fprint(w, "proxy")
+	}))
+	defer proxy.Close()
+	c.Check(s.run(c, runner.Output{}, `curl -x `+proxy.URL+` http://example.com/path`), Equals, "proxy")
+	c.Check(proxied, Equals, "http://example.com/path")
+}
+
+func (s *RunnerTest) Test_Run_Insecure(c *C) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "secure")
+	}))
+	// the first request fails with handshake error
+	server.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+	options, err := common.ParseCurlCommand("curl " + server.URL)
+	c.Assert(err, IsNil)
+	c.Check(runner.Run(context.Background(), options, runner.Output{}, ioutil.Discard), NotNil)
+	options.Insecure = true
+	var buffer bytes.Buffer
+	c.Assert(runner.Run(context.Background(), options, runner.Output{}, &buffer), IsNil)
+	c.Check(buffer.String(), Equals, "secure")
+}
+
+func (s *RunnerTest) Test_Run_Error(c *C) {
+	options, err := common.ParseCurlCommand("curl -d @" + filepath.Join(s.dir, "missing.txt") + " " + s.server.URL)
+	c.Assert(err, IsNil)
+	err = runner.Run(context.Background(), options, runner.Output{}, ioutil.Discard)
+	c.Check(os.IsNotExist(err), Equals, true)
+}
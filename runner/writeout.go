@@ -0,0 +1,65 @@
+package runner
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var writeOutPattern = regexp.MustCompile(`%%|%\{([a-z_]+)\}|%header\{([^}]+)\}|\\[nrt\\]`)
+
+/*
+	Expand variables of -w option. These variables of curl are supported:
+
+		%{http_code}, %{response_code}, %{http_version}, %{content_type}, %{method},
+		%{size_download}, %{time_total}, %{url_effective}, %{redirect_url} and %header{NAME}
+
+	Unknown variables are written as they are.
+*/
+func writeOut(format string, resp *http.Response, size int64, elapsed time.Duration) string {
+	return writeOutPattern.ReplaceAllStringFunc(format, func(text string) string {
+		switch text {
+		case "%%":
+			return "%"
+		case "\\n":
+			return "\n"
+		case "\\r":
+			return "\r"
+		case "\\t":
+			return "\t"
+		case "\\\\":
+			return "\\"
+		}
+		match := writeOutPattern.FindStringSubmatch(text)
+		if match[2] != "" {
+			return strings.Join(resp.Header.Values(match[2]), ", ")
+		}
+		switch match[1] {
+		case "http_code", "response_code":
+			return fmt.Sprintf("%03d", resp.StatusCode)
+		case "http_version":
+			if resp.ProtoMajor == 2 {
+				return "2"
+			}
+			return fmt.Sprintf("%d.%d", resp.ProtoMajor, resp.ProtoMinor)
+		case "content_type":
+			return resp.Header.Get("Content-Type")
+		case "method":
+			return resp.Request.Method
+		case "size_download":
+			return fmt.Sprintf("%d", size)
+		case "time_total":
+			return fmt.Sprintf("%.6f", elapsed.Seconds())
+		case "url_effective":
+			return resp.Request.URL.String()
+		case "redirect_url":
+			if location, err := resp.Location(); err == nil {
+				return location.String()
+			}
+			return ""
+		}
+		return text
+	})
+}
@@ -0,0 +1,1200 @@
+package syntax
+
+import (
+	"strings"
+)
+
+// ">" is always one token to close nested type arguments. Parsers join ">>", ">=" and ">>=".
+var javaPunctuators = []string{
+	"...", "<<=",
+	"->", "::", "==", "!=", "<=", "&&", "||", "++", "--",
+	"+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=", "<<",
+	"(", ")", "{", "}", "[", "]", ";", ",", ".", "@", "=", ">", "<", "!", "~", "?", ":",
+	"+", "-", "*", "/", "&", "|", "^", "%",
+}
+
+var javaKeywords = map[string]bool{
+	"abstract": true, "assert": true, "boolean": true, "break": true, "byte": true, "case": true,
+	"catch": true, "char": true, "class": true, "const": true, "continue": true, "default": true,
+	"do": true, "double": true, "else": true, "enum": true, "extends": true, "final": true,
+	"finally": true, "float": true, "for": true, "goto": true, "if": true, "implements": true,
+	"import": true, "instanceof": true, "int": true, "interface": true, "long": true, "native": true,
+	"new": true, "package": true, "private": true, "protected": true, "public": true, "return": true,
+	"short": true, "static": true, "strictfp": true, "super": true, "switch": true, "synchronized": true,
+	"this": true, "throw": true, "throws": true, "transient": true, "try": true, "void": true,
+	"volatile": true, "while": true, "true": true, "false": true, "null": true,
+}
+
+var javaPrimitiveTypes = map[string]bool{
+	"boolean": true, "byte": true, "char": true, "short": true, "int": true, "long": true,
+	"float": true, "double": true, "void": true,
+}
+
+var javaModifiers = map[string]bool{
+	"public": true, "protected": true, "private": true, "static": true, "final": true, "abstract": true,
+	"native": true, "synchronized": true, "transient": true, "volatile": true, "strictfp": true,
+	"default": true, "sealed": true,
+}
+
+var javaAssignments = map[string]bool{
+	"=": true, "+=": true, "-=": true, "*=": true, "/=": true, "%=": true, "&=": true, "|=": true,
+	"^=": true, "<<=": true, ">>=": true, ">>>=": true,
+}
+
+var javaBinaryOperators = [][]string{
+	{"||"}, {"&&"}, {"|"}, {"^"}, {"&"},
+	{"==", "!="},
+	{"<", ">", "<=", ">=", "instanceof"},
+	{"<<", ">>", ">>>"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+type javaLexer struct {
+	*scanner
+	tokens   []token
+	adjacent bool
+}
+
+func lexJava(source string) []token {
+	lexer := &javaLexer{scanner: newScanner(source)}
+	lexer.lex()
+	return lexer.tokens
+}
+
+func (self *javaLexer) add(kind tokenKind, text string, line, column int) {
+	self.tokens = append(self.tokens, token{kind: kind, text: text, line: line, column: column, adjacent: self.adjacent})
+	self.adjacent = true
+}
+
+func (self *javaLexer) lex() {
+	for !self.eof() {
+		ch := self.peek(0)
+		line, column := self.line, self.column
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n' || ch == '\f':
+			self.adjacent = false
+			self.advance(1)
+		case self.hasPrefix("//"):
+			self.adjacent = false
+			for !self.eof() && self.peek(0) != '\n' {
+				self.advance(1)
+			}
+		case self.hasPrefix("/*"):
+			self.adjacent = false
+			self.advance(2)
+			for !self.hasPrefix("*/") {
+				if self.eof() {
+					panic(&Error{Line: line, Column: column, Message: "unclosed comment"})
+				}
+				self.advance(1)
+			}
+			self.advance(2)
+		case isDigit(ch) || (ch == '.' && isDigit(self.peek(1))):
+			text := self.number()
+			if suffix := strings.TrimLeft(strings.ToLower(text), "0123456789._abcdefx+-"); suffix != "" && suffix != "l" && suffix != "f" && suffix != "d" {
+				panic(&Error{Line: line, Column: column, Message: "invalid number: " + text})
+			}
+			self.add(numberToken, text, line, column)
+		case isIdentStart(ch):
+			self.add(identToken, self.ident(), line, column)
+		case self.hasPrefix(`"""`):
+			self.add(stringToken, self.textBlock(), line, column)
+		case ch == '"':
+			self.add(stringToken, self.quoted('"', "unclosed string literal"), line, column)
+		case ch == '\'':
+			text := self.quoted('\'', "unclosed character literal")
+			if text == "''" {
+				panic(&Error{Line: line, Column: column, Message: "empty character literal"})
+			}
+			self.add(charToken, text, line, column)
+		default:
+			punctuator := self.punctuator(javaPunctuators)
+			if punctuator == "" {
+				self.errorf("illegal character '%c'", rune(ch))
+			}
+			self.advance(len(punctuator))
+			self.add(punctToken, punctuator, line, column)
+		}
+	}
+	self.add(eofToken, "", self.line, self.column)
+}
+
+func (self *javaLexer) quoted(quote byte, message string) string {
+	start := self.offset
+	line, column := self.line, self.column
+	self.advance(1)
+	for self.peek(0) != quote {
+		if self.eof() || self.peek(0) == '\n' {
+			panic(&Error{Line: line, Column: column, Message: message})
+		}
+		if self.peek(0) == '\\' {
+			self.advance(1)
+		}
+		self.advance(1)
+	}
+	self.advance(1)
+	return self.source[start:self.offset]
+}
+
+func (self *javaLexer) textBlock() string {
+	start := self.offset
+	line, column := self.line, self.column
+	self.advance(3)
+	for !self.hasPrefix(`"""`) {
+		if self.eof() {
+			panic(&Error{Line: line, Column: column, Message: "unclosed text block"})
+		}
+		if self.peek(0) == '\\' {
+			self.advance(1)
+		}
+		self.advance(1)
+	}
+	self.advance(3)
+	return self.source[start:self.offset]
+}
+
+/*
+	Kinds of expressions to check statements and targets of assignments.
+*/
+type javaExpression int
+
+const (
+	javaOtherExpression javaExpression = iota
+	javaVariableExpression
+	javaStatementExpression
+)
+
+type javaParser struct {
+	parser
+}
+
+/*
+	Check syntax of a Java compilation unit.
+*/
+func CheckJava(source string) error {
+	return check(func() {
+		parser := &javaParser{parser: parser{tokens: lexJava(source)}}
+		parser.compilationUnit()
+	})
+}
+
+func (self *javaParser) unexpected() {
+	token := self.peek()
+	if token.kind == eofToken {
+		self.errorf("reached end of file while parsing")
+	}
+	self.errorf("illegal start of expression: %s", token)
+}
+
+func (self *javaParser) identifier() token {
+	token := self.peek()
+	if token.kind != identToken || javaKeywords[token.text] {
+		self.errorf("<identifier> expected but found %s", token)
+	}
+	return self.next()
+}
+
+func (self *javaParser) isIdentifier() bool {
+	token := self.peek()
+	return token.kind == identToken && !javaKeywords[token.text]
+}
+
+func (self *javaParser) semicolon() {
+	if !self.is(";") {
+		self.errorf("';' expected but found %s", self.peek())
+	}
+	self.next()
+}
+
+func (self *javaParser) qualifiedName() {
+	self.identifier()
+	for self.is(".") && self.peekAt(1).kind == identToken && !javaKeywords[self.peekAt(1).text] {
+		self.next()
+		self.next()
+	}
+}
+
+func (self *javaParser) compilationUnit() {
+	pos := self.pos
+	self.modifiers()
+	if self.accept("package") {
+		self.qualifiedName()
+		self.semicolon()
+	} else {
+		self.pos = pos
+	}
+	for self.accept("import") {
+		self.accept("static")
+		self.qualifiedName()
+		if self.accept(".") {
+			self.expect("*")
+		}
+		self.semicolon()
+	}
+	for self.peek().kind != eofToken {
+		if self.accept(";") {
+			continue
+		}
+		self.modifiers()
+		self.typeDeclaration()
+	}
+}
+
+func (self *javaParser) modifiers() {
+	for {
+		switch {
+		case self.is("@") && self.peekAt(1).text != "interface":
+			self.annotation()
+		case self.peek().kind == identToken && javaModifiers[self.peek().text]:
+			self.next()
+		default:
+			return
+		}
+	}
+}
+
+func (self *javaParser) annotation() {
+	self.expect("@")
+	self.qualifiedName()
+	if self.accept("(") {
+		for !self.accept(")") {
+			if self.isIdentifier() && self.peekAt(1).text == "=" {
+				self.next()
+				self.next()
+			}
+			self.elementValue()
+			if !self.is(")") {
+				self.expect(",")
+			}
+		}
+	}
+}
+
+func (self *javaParser) elementValue() {
+	switch {
+	case self.is("@"):
+		self.annotation()
+	case self.accept("{"):
+		for !self.accept("}") {
+			self.elementValue()
+			if !self.is("}") {
+				self.expect(",")
+			}
+		}
+	default:
+		self.conditional()
+	}
+}
+
+func (self *javaParser) isTypeDeclaration() bool {
+	return self.is("class") || self.is("interface") || self.is("enum") || (self.is("@") && self.peekAt(1).text == "interface") ||
+		(self.is("record") && self.peekAt(1).kind == identToken && self.peekAt(2).text != "=" && self.peekAt(2).text != ";")
+}
+
+func (self *javaParser) typeDeclaration() {
+	switch {
+	case self.accept("class"):
+		self.identifier()
+		self.typeParameters()
+		if self.accept("extends") {
+			self.classType()
+		}
+		if self.accept("implements") {
+			self.typeList()
+		}
+		if self.accept("permits") {
+			self.typeList()
+		}
+		self.classBody()
+	case self.accept("interface"):
+		self.identifier()
+		self.typeParameters()
+		if self.accept("extends") {
+			self.typeList()
+		}
+		if self.accept("permits") {
+			self.typeList()
+		}
+		self.classBody()
+	case self.is("@"):
+		self.next()
+		self.expect("interface")
+		self.identifier()
+		self.classBody()
+	case self.accept("enum"):
+		self.identifier()
+		if self.accept("implements") {
+			self.typeList()
+		}
+		self.enumBody()
+	case self.accept("record"):
+		self.identifier()
+		self.typeParameters()
+		self.parameters()
+		if self.accept("implements") {
+			self.typeList()
+		}
+		self.classBody()
+	default:
+		self.errorf("class, interface, enum, or record expected but found %s", self.peek())
+	}
+}
+
+func (self *javaParser) typeList() {
+	self.classType()
+	for self.accept(",") {
+		self.classType()
+	}
+}
+
+func (self *javaParser) typeParameters() {
+	if !self.accept("<") {
+		return
+	}
+	for {
+		self.modifiers()
+		self.identifier()
+		if self.accept("extends") {
+			self.classType()
+			for self.accept("&") {
+				self.classType()
+			}
+		}
+		if !self.accept(",") {
+			break
+		}
+	}
+	self.expect(">")
+}
+
+func (self *javaParser) typeArguments() {
+	self.expect("<")
+	if self.accept(">") {
+		// diamond
+		return
+	}
+	for {
+		self.modifiers()
+		if self.accept("?") {
+			if self.accept("extends") || self.accept("super") {
+				self.referenceType()
+			}
+		} else {
+			self.referenceType()
+		}
+		if !self.accept(",") {
+			break
+		}
+	}
+	self.expect(">")
+}
+
+func (self *javaParser) classType() {
+	self.modifiers()
+	self.identifier()
+	if self.is("<") {
+		self.typeArguments()
+	}
+	for self.is(".") && self.peekAt(1).kind == identToken {
+		self.next()
+		self.identifier()
+		if self.is("<") {
+			self.typeArguments()
+		}
+	}
+}
+
+func (self *javaParser) dimensions() {
+	for self.is("[") && self.peekAt(1).text == "]" {
+		self.next()
+		self.next()
+	}
+}
+
+func (self *javaParser) referenceType() {
+	if self.peek().kind == identToken && javaPrimitiveTypes[self.peek().text] && self.peek().text != "void" {
+		self.next()
+		if !self.is("[") {
+			self.errorf("'[' expected but found %s", self.peek())
+		}
+	} else {
+		self.classType()
+	}
+	self.dimensions()
+}
+
+/*
+	Type including primitive types and void.
+*/
+func (self *javaParser) javaType() {
+	if self.peek().kind == identToken && javaPrimitiveTypes[self.peek().text] {
+		self.next()
+	} else {
+		self.classType()
+	}
+	self.dimensions()
+}
+
+func (self *javaParser) classBody() {
+	self.expect("{")
+	for !self.accept("}") {
+		if self.peek().kind == eofToken {
+			self.unexpected()
+		}
+		self.member()
+	}
+}
+
+func (self *javaParser) enumBody() {
+	self.expect("{")
+	for self.isIdentifier() || self.is("@") {
+		self.modifiers()
+		self.identifier()
+		if self.is("(") {
+			self.arguments()
+		}
+		if self.is("{") {
+			self.classBody()
+		}
+		if !self.accept(",") {
+			break
+		}
+	}
+	if self.accept(";") {
+		for !self.is("}") {
+			if self.peek().kind == eofToken {
+				self.unexpected()
+			}
+			self.member()
+		}
+	}
+	self.expect("}")
+}
+
+func (self *javaParser) member() {
+	if self.accept(";") {
+		return
+	}
+	if self.is("{") || (self.is("static") && self.peekAt(1).text == "{") {
+		self.accept("static")
+		self.block()
+		return
+	}
+	self.modifiers()
+	if self.isTypeDeclaration() {
+		self.typeDeclaration()
+		return
+	}
+	self.typeParameters()
+	if self.isIdentifier() && (self.peekAt(1).text == "(" || self.peekAt(1).text == "{") {
+		// constructor or compact constructor of record
+		self.next()
+		if self.is("(") {
+			self.parameters()
+		}
+		self.throws()
+		self.block()
+		return
+	}
+	self.javaType()
+	self.identifier()
+	if self.is("(") {
+		self.parameters()
+		self.dimensions()
+		self.throws()
+		if self.accept("default") {
+			self.elementValue()
+		}
+		if !self.accept(";") {
+			self.block()
+		}
+		return
+	}
+	self.variableDeclaratorsRest()
+	self.semicolon()
+}
+
+func (self *javaParser) throws() {
+	if self.accept("throws") {
+		self.typeList()
+	}
+}
+
+func (self *javaParser) parameters() {
+	self.expect("(")
+	for !self.accept(")") {
+		self.modifiers()
+		self.javaType()
+		if self.accept("...") {
+			self.identifier()
+			self.expect(")")
+			return
+		}
+		if self.accept("this") {
+			// receiver parameter
+		} else {
+			self.identifier()
+			self.dimensions()
+		}
+		if !self.is(")") {
+			self.expect(",")
+		}
+	}
+}
+
+/*
+	Rest of variable declarators after the first name.
+*/
+func (self *javaParser) variableDeclaratorsRest() {
+	for {
+		self.dimensions()
+		if self.accept("=") {
+			self.variableInitializer()
+		}
+		if !self.accept(",") {
+			return
+		}
+		self.identifier()
+	}
+}
+
+func (self *javaParser) variableInitializer() {
+	if self.is("{") {
+		self.arrayInitializer()
+	} else {
+		self.expression()
+	}
+}
+
+func (self *javaParser) arrayInitializer() {
+	self.expect("{")
+	for !self.accept("}") {
+		self.variableInitializer()
+		if !self.is("}") {
+			self.expect(",")
+		}
+	}
+}
+
+func (self *javaParser) block() {
+	self.expect("{")
+	for !self.accept("}") {
+		if self.peek().kind == eofToken {
+			self.unexpected()
+		}
+		self.blockStatement()
+	}
+}
+
+/*
+	A local variable declaration starts at the current position.
+*/
+func (self *javaParser) isLocalVariableDeclaration() bool {
+	pos := self.pos
+	defer func() { self.pos = pos }()
+	return self.try(func() {
+		self.modifiers()
+		self.javaType()
+		self.identifier()
+		if !self.is("=") && !self.is(",") && !self.is(";") && !self.is("[") && !self.is(":") {
+			self.unexpected()
+		}
+	})
+}
+
+func (self *javaParser) localVariableDeclaration() {
+	self.modifiers()
+	self.javaType()
+	self.identifier()
+	self.variableDeclaratorsRest()
+}
+
+func (self *javaParser) blockStatement() {
+	pos := self.pos
+	self.modifiers()
+	if self.isTypeDeclaration() {
+		self.typeDeclaration()
+		return
+	}
+	self.pos = pos
+	if self.isLocalVariableDeclaration() {
+		self.localVariableDeclaration()
+		self.semicolon()
+		return
+	}
+	self.statement()
+}
+
+func (self *javaParser) parenthesized() {
+	self.expect("(")
+	self.expression()
+	self.expect(")")
+}
+
+func (self *javaParser) statement() {
+	switch {
+	case self.is("{"):
+		self.block()
+	case self.accept(";"):
+	case self.accept("if"):
+		self.parenthesized()
+		self.statement()
+		if self.accept("else") {
+			self.statement()
+		}
+	case self.accept("while"):
+		self.parenthesized()
+		self.statement()
+	case self.accept("do"):
+		self.statement()
+		self.expect("while")
+		self.parenthesized()
+		self.semicolon()
+	case self.is("for"):
+		self.forStatement()
+	case self.is("try"):
+		self.tryStatement()
+	case self.is("switch"):
+		self.switchBlock()
+	case self.accept("synchronized"):
+		self.parenthesized()
+		self.block()
+	case self.accept("return"):
+		if !self.is(";") {
+			self.expression()
+		}
+		self.semicolon()
+	case self.accept("throw"):
+		self.expression()
+		self.semicolon()
+	case self.is("break"), self.is("continue"):
+		self.next()
+		if self.isIdentifier() {
+			self.next()
+		}
+		self.semicolon()
+	case self.is("yield") && self.peekAt(1).text != "=" && self.peekAt(1).text != "(" && self.peekAt(1).text != ".":
+		self.next()
+		self.expression()
+		self.semicolon()
+	case self.accept("assert"):
+		self.expression()
+		if self.accept(":") {
+			self.expression()
+		}
+		self.semicolon()
+	case self.isIdentifier() && self.peekAt(1).text == ":" && self.peekAt(1).kind == punctToken:
+		self.next()
+		self.next()
+		self.statement()
+	case self.is("else"), self.is("catch"), self.is("finally"), self.is("case"):
+		self.errorf("%s without preceding statement", self.peek())
+	default:
+		start := self.peek()
+		if self.expression() != javaStatementExpression {
+			self.errorAt(start, "not a statement")
+		}
+		self.semicolon()
+	}
+}
+
+func (self *javaParser) forStatement() {
+	self.expect("for")
+	self.expect("(")
+	if self.isLocalVariableDeclaration() {
+		self.modifiers()
+		self.javaType()
+		self.identifier()
+		if self.accept(":") {
+			self.expression()
+			self.expect(")")
+			self.statement()
+			return
+		}
+		self.variableDeclaratorsRest()
+	} else {
+		self.statementExpressions(";")
+	}
+	self.semicolon()
+	if !self.is(";") {
+		self.expression()
+	}
+	self.semicolon()
+	self.statementExpressions(")")
+	self.expect(")")
+	self.statement()
+}
+
+func (self *javaParser) statementExpressions(end string) {
+	for !self.is(end) {
+		start := self.peek()
+		if self.expression() != javaStatementExpression {
+			self.errorAt(start, "not a statement")
+		}
+		if !self.accept(",") {
+			break
+		}
+	}
+}
+
+func (self *javaParser) tryStatement() {
+	self.expect("try")
+	resources := false
+	if self.accept("(") {
+		resources = true
+		for !self.accept(")") {
+			if self.isLocalVariableDeclaration() {
+				self.modifiers()
+				self.javaType()
+				self.identifier()
+				self.expect("=")
+				self.expression()
+			} else {
+				self.expression()
+			}
+			if !self.is(")") {
+				self.semicolon()
+			}
+		}
+	}
+	self.block()
+	handled := false
+	for self.accept("catch") {
+		self.expect("(")
+		self.modifiers()
+		self.classType()
+		for self.accept("|") {
+			self.classType()
+		}
+		self.identifier()
+		self.expect(")")
+		self.block()
+		handled = true
+	}
+	if self.accept("finally") {
+		self.block()
+	} else if !handled && !resources {
+		self.errorf("'try' without 'catch', 'finally' or resource declarations")
+	}
+}
+
+/*
+	Switch statement or switch expression with "case L:" or "case L ->" labels.
+*/
+func (self *javaParser) switchBlock() {
+	self.expect("switch")
+	self.parenthesized()
+	self.expect("{")
+	for !self.accept("}") {
+		if self.accept("case") {
+			for {
+				self.conditional()
+				if !self.accept(",") {
+					break
+				}
+			}
+		} else if !self.accept("default") {
+			self.errorf("'case', 'default', or '}' expected but found %s", self.peek())
+		}
+		if self.accept("->") {
+			switch {
+			case self.is("{"):
+				self.block()
+			case self.accept("throw"):
+				self.expression()
+				self.semicolon()
+			default:
+				self.expression()
+				self.semicolon()
+			}
+			continue
+		}
+		self.expect(":")
+		for !self.is("case") && !self.is("default") && !self.is("}") {
+			if self.peek().kind == eofToken {
+				self.unexpected()
+			}
+			self.blockStatement()
+		}
+	}
+}
+
+/*
+	Operator at the current position and the number of tokens of it. ">" tokens are joined.
+*/
+func (self *javaParser) operator() (string, int) {
+	token := self.peek()
+	if token.kind != punctToken && !(token.kind == identToken && token.text == "instanceof") {
+		return "", 0
+	}
+	if token.text != ">" {
+		return token.text, 1
+	}
+	text := ">"
+	count := 1
+	for count < 3 && self.peekAt(count).text == ">" && self.peekAt(count).adjacent {
+		text += ">"
+		count++
+	}
+	if next := self.peekAt(count); next.kind == punctToken && next.text == "=" && next.adjacent {
+		text += "="
+		count++
+	}
+	return text, count
+}
+
+func (self *javaParser) isLambda() bool {
+	if self.isIdentifier() {
+		return self.peekAt(1).text == "->"
+	}
+	if !self.is("(") {
+		return false
+	}
+	end := self.matchingBracket()
+	return end != -1 && end+1 < len(self.tokens) && self.tokens[end+1].text == "->"
+}
+
+func (self *javaParser) lambda() {
+	if self.isIdentifier() {
+		self.next()
+	} else {
+		self.expect("(")
+		for !self.accept(")") {
+			if self.isIdentifier() && (self.peekAt(1).text == "," || self.peekAt(1).text == ")") {
+				self.next()
+			} else {
+				self.modifiers()
+				self.javaType()
+				self.identifier()
+			}
+			if !self.is(")") {
+				self.expect(",")
+			}
+		}
+	}
+	self.expect("->")
+	if self.is("{") {
+		self.block()
+	} else {
+		self.expression()
+	}
+}
+
+func (self *javaParser) expression() javaExpression {
+	if self.isLambda() {
+		self.lambda()
+		return javaOtherExpression
+	}
+	start := self.peek()
+	kind := self.conditional()
+	operator, count := self.operator()
+	if javaAssignments[operator] {
+		if kind != javaVariableExpression {
+			self.errorAt(start, "unexpected type: required variable, found value")
+		}
+		self.pos += count
+		self.expression()
+		return javaStatementExpression
+	}
+	return kind
+}
+
+func (self *javaParser) conditional() javaExpression {
+	kind := self.binary(0)
+	if self.accept("?") {
+		self.expression()
+		self.expect(":")
+		if self.isLambda() {
+			self.lambda()
+		} else {
+			self.conditional()
+		}
+		return javaOtherExpression
+	}
+	return kind
+}
+
+func (self *javaParser) binary(level int) javaExpression {
+	if level == len(javaBinaryOperators) {
+		return self.unary()
+	}
+	kind := self.binary(level + 1)
+	for {
+		operator, count := self.operator()
+		matched := false
+		for _, candidate := range javaBinaryOperators[level] {
+			if operator == candidate {
+				matched = true
+			}
+		}
+		if !matched {
+			return kind
+		}
+		self.pos += count
+		if operator == "instanceof" {
+			self.accept("final")
+			self.referenceType()
+			if self.isIdentifier() {
+				self.next()
+			}
+		} else {
+			self.binary(level + 1)
+		}
+		kind = javaOtherExpression
+	}
+}
+
+func (self *javaParser) unary() javaExpression {
+	switch {
+	case self.accept("+"), self.accept("-"), self.accept("!"), self.accept("~"):
+		self.unary()
+		return javaOtherExpression
+	case self.is("++"), self.is("--"):
+		self.next()
+		start := self.peek()
+		if self.unary() != javaVariableExpression {
+			self.errorAt(start, "unexpected type: required variable, found value")
+		}
+		return javaStatementExpression
+	case self.is("(") && self.isCast():
+		self.expect("(")
+		self.referenceTypeOrPrimitive()
+		for self.accept("&") {
+			self.classType()
+		}
+		self.expect(")")
+		if self.isLambda() {
+			self.lambda()
+		} else {
+			self.unary()
+		}
+		return javaOtherExpression
+	}
+	start := self.peek()
+	kind := self.postfix()
+	if self.is("++") || self.is("--") {
+		if kind != javaVariableExpression {
+			self.errorAt(start, "unexpected type: required variable, found value")
+		}
+		self.next()
+		return javaStatementExpression
+	}
+	return kind
+}
+
+func (self *javaParser) referenceTypeOrPrimitive() {
+	if self.peek().kind == identToken && javaPrimitiveTypes[self.peek().text] {
+		self.next()
+		self.dimensions()
+	} else {
+		self.referenceType()
+	}
+}
+
+/*
+	The parenthesis at the current position is a cast.
+*/
+func (self *javaParser) isCast() bool {
+	next := self.peekAt(1)
+	if next.kind == identToken && javaPrimitiveTypes[next.text] {
+		return true
+	}
+	pos := self.pos
+	defer func() { self.pos = pos }()
+	return self.try(func() {
+		self.expect("(")
+		self.referenceType()
+		for self.accept("&") {
+			self.classType()
+		}
+		self.expect(")")
+		// the operand of a cast to a reference type doesn't start with + or -
+		token := self.peek()
+		switch token.kind {
+		case identToken:
+			if javaKeywords[token.text] && token.text != "this" && token.text != "super" && token.text != "new" &&
+				token.text != "true" && token.text != "false" && token.text != "null" && token.text != "switch" {
+				self.unexpected()
+			}
+		case numberToken, stringToken, charToken:
+		case punctToken:
+			if token.text != "(" && token.text != "!" && token.text != "~" {
+				self.unexpected()
+			}
+		default:
+			self.unexpected()
+		}
+	})
+}
+
+func (self *javaParser) postfix() javaExpression {
+	kind := self.primary()
+	for {
+		switch {
+		case self.accept("."):
+			switch {
+			case self.accept("new"):
+				self.creator()
+				kind = javaStatementExpression
+			case self.accept("this"), self.accept("class"):
+				kind = javaOtherExpression
+			default:
+				if self.is("<") {
+					self.typeArguments()
+				}
+				self.identifier()
+				kind = javaVariableExpression
+				if self.is("(") {
+					self.arguments()
+					kind = javaStatementExpression
+				}
+			}
+		case self.accept("["):
+			self.expression()
+			self.expect("]")
+			kind = javaVariableExpression
+		case self.accept("::"):
+			if !self.accept("new") {
+				self.identifier()
+			}
+			kind = javaOtherExpression
+		default:
+			return kind
+		}
+	}
+}
+
+func (self *javaParser) primary() javaExpression {
+	token := self.peek()
+	switch token.kind {
+	case numberToken, stringToken, charToken:
+		self.next()
+		return javaOtherExpression
+	case identToken:
+		switch {
+		case token.text == "true", token.text == "false", token.text == "null":
+			self.next()
+			return javaOtherExpression
+		case token.text == "this":
+			self.next()
+			if self.is("(") {
+				self.arguments()
+				return javaStatementExpression
+			}
+			return javaOtherExpression
+		case token.text == "super":
+			self.next()
+			if self.is("(") {
+				self.arguments()
+				return javaStatementExpression
+			}
+			if !self.is(".") && !self.is("::") {
+				self.errorf("'.' expected but found %s", self.peek())
+			}
+			return javaOtherExpression
+		case token.text == "new":
+			self.next()
+			self.creator()
+			return javaStatementExpression
+		case token.text == "switch":
+			self.switchBlock()
+			return javaOtherExpression
+		case javaPrimitiveTypes[token.text]:
+			self.next()
+			self.dimensions()
+			if !self.accept("::") {
+				self.expect(".")
+				self.expect("class")
+			} else {
+				self.expect("new")
+			}
+			return javaOtherExpression
+		}
+		self.identifier()
+		switch {
+		case self.is("("):
+			self.arguments()
+			return javaStatementExpression
+		case self.is("[") && self.peekAt(1).text == "]":
+			// String[].class or String[]::new
+			self.dimensions()
+			if !self.is(".") && !self.is("::") {
+				self.errorf("'.class' expected but found %s", self.peek())
+			}
+			return javaOtherExpression
+		case self.is("<") && self.isTypeArgumentsBeforeMethodReference():
+			self.typeArguments()
+			return javaOtherExpression
+		}
+		return javaVariableExpression
+	}
+	if self.accept("(") {
+		kind := self.expression()
+		self.expect(")")
+		if kind == javaStatementExpression {
+			return javaOtherExpression
+		}
+		return kind
+	}
+	self.unexpected()
+	return javaOtherExpression
+}
+
+/*
+	Type arguments of a method reference like List<String>::new.
+*/
+func (self *javaParser) isTypeArgumentsBeforeMethodReference() bool {
+	pos := self.pos
+	defer func() { self.pos = pos }()
+	return self.try(func() {
+		self.typeArguments()
+		self.dimensions()
+		self.expect("::")
+	})
+}
+
+func (self *javaParser) creator() {
+	if self.is("<") {
+		self.typeArguments()
+	}
+	if self.peek().kind == identToken && javaPrimitiveTypes[self.peek().text] {
+		self.next()
+		if !self.is("[") {
+			self.errorf("'[' expected but found %s", self.peek())
+		}
+	} else {
+		self.classType()
+	}
+	if self.is("[") {
+		if self.peekAt(1).text == "]" {
+			self.dimensions()
+			self.arrayInitializer()
+			return
+		}
+		for self.is("[") && self.peekAt(1).text != "]" {
+			self.next()
+			self.expression()
+			self.expect("]")
+		}
+		self.dimensions()
+		return
+	}
+	if !self.is("(") {
+		self.errorf("'(' or '[' expected but found %s", self.peek())
+	}
+	self.arguments()
+	if self.is("{") {
+		self.classBody()
+	}
+}
+
+func (self *javaParser) arguments() {
+	self.expect("(")
+	for !self.accept(")") {
+		self.expression()
+		if !self.is(")") {
+			self.expect(",")
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package syntax_test
+
+import (
+	"github.com/shibukawa/curl_as_dsl/syntax"
+	. "gopkg.in/check.v1"
+)
+
+type JavaTest struct{}
+
+var _ = Suite(&JavaTest{})
+
+func (s *JavaTest) Test_Valid(c *C) {
+	checkValid(c, syntax.CheckJava, []string{
+		"",
+		"package a.b;\nimport java.util.*;\nimport static java.lang.Math.max;\n",
+		"public class Main {\n    public static void main(String[] args) throws Exception {\n    }\n}",
+		"class A<T extends Comparable<T>> extends B<Map<String, List<T>>> implements C, D<T> {\n    private final Map<String, List<Integer>> map = new HashMap<>();\n    int[] a = {1, 2}, b[];\n}",
+		"class A { void f() {\n    int a = 1, b = a >> 2, c = a >>> 1;\n    a >>= 1; a >>>= 2; boolean d = a >= b && b > c;\n    List<List<String>> x = null;\n} }",
+		"class A { void f() {\n    String s = (String) o;\n    int i = (int) 1.5;\n    long n = (a) + b;\n    Object x = (Runnable & Serializable) () -> {};\n} }",
+		"class A { void f() {\n    list.forEach(x -> System.out.println(x));\n    map.forEach((k, v) -> { return; });\n    Function<String, Integer> g = String::length;\n    Supplier<List<String>> h = ArrayList::new;\n} }",
+		"class A { void f() {\n    try (InputStream in = conn.getInputStream(); var r = new Reader(in)) {\n    } catch (IOException | RuntimeException e) {\n    } finally {}\n} }",
+		"class A { void f() {\n    for (int i = 0, j = 1; i < 10; i++, j--) {}\n    for (String s : list) continue;\n    for (;;) break;\n    label: while (true) { break label; }\n    do { x++; } while (x < 10);\n} }",
+		"class A { void f() {\n    switch (a) { case 1: case 2: b(); break; default: c(); }\n    int r = switch (a) { case 1, 2 -> 3; default -> { yield 4; } };\n} }",
+		"class A { void f() {\n    String[] a = new String[] {\"a\", \"b\"};\n    int[][] m = new int[3][];\n    Class<?> k = String[].class;\n    Class<?> p = int.class;\n} }",
+		"class A { void f() {\n    Runnable r = new Runnable() {\n        @Override\n        public void run() {}\n    };\n    new Thread(r).start();\n    this.x = super.y;\n} }",
+		"class A { void f() {\n    char c = '\\'';\n    String s = \"a\\\"b\" + 'c' + 1L + 2.0f + 0xFF + 1_000;\n    boolean b = o instanceof String str && !str.isEmpty();\n    x = a ? b : c;\n} }",
+		"@SuppressWarnings(\"unchecked\")\npublic final class A {\n    @Deprecated(since = \"1\", forRemoval = true) static int x;\n    static { x = 1; }\n    A() { this(1); }\n    A(int a) {}\n}",
+		"enum E { A, B(1) { void f() {} }, C; E() {} E(int a) {} void f() {} }\ninterface I { default void f() {} void g(); }\nrecord R(int a, String b) {}",
+		"class A { void f() {\n    String body = \"\"\"\n        {\"a\": 1}\n        \"\"\";\n    HttpRequest.BodyPublishers.ofString(body);\n} }",
+	})
+}
+
+func (s *JavaTest) Test_Invalid(c *C) {
+	checkInvalid(c, syntax.CheckJava, []invalidCode{
+		{"class A { void f() {\n    conn.setRequestProperty(\"X\", \"he said \"hi\"\");\n} }", 2, 44},
+		{"class A { void f() {\n    String s = \"abc;\n} }", 2, 16},
+		{"class A { void f() {\n    char c = '';\n} }", 2, 14},
+		{"class A { void f() {\n    int a = 1\n} }", 3, 1},
+		{"class A { void f() {\n    a + b;\n} }", 2, 5},
+		{"class A { void f() {\n    f() = 1;\n} }", 2, 5},
+		{"class A { void f() {\n    1++;\n} }", 2, 5},
+		{"class A { void f() {\n    foo(1, 2;\n} }", 2, 13},
+		{"class A { void f() {\n    try {}\n} }", 3, 1},
+		{"class A { void f() {\n    int class = 1;\n} }", 2, 9},
+		{"class A { void f() {\n    x = 5px;\n} }", 2, 9},
+		{"class A { void f() {\n    String s = \"a\\\"b\\\";\n} }", 2, 16},
+		{"class A { void f() {\n}", 2, 2},
+		{"class A { void f() {\n    else {}\n} }", 2, 5},
+		{"class A { int f( {} }", 1, 18},
+		{"public void main() {}", 1, 8},
+		{"class A { void f() {\n    x = a # b;\n} }", 2, 11},
+	})
+}
@@ -0,0 +1,1087 @@
+package syntax
+
+var javaScriptPunctuators = []string{
+	">>>=",
+	"...", "===", "!==", "**=", "<<=", ">>=", ">>>", "&&=", "||=", "??=",
+	"=>", "==", "!=", "<=", ">=", "&&", "||", "??", "?.", "++", "--",
+	"+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=", "**", "<<", ">>",
+	"{", "}", "(", ")", "[", "]", ";", ",", "<", ">", "+", "-", "*", "/", "%",
+	"&", "|", "^", "!", "~", "?", ":", "=", ".", "@",
+}
+
+var javaScriptReservedWords = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"debugger": true, "default": true, "delete": true, "do": true, "else": true, "export": true,
+	"extends": true, "finally": true, "for": true, "function": true, "if": true, "import": true,
+	"in": true, "instanceof": true, "new": true, "return": true, "super": true, "switch": true,
+	"this": true, "throw": true, "try": true, "typeof": true, "var": true, "void": true,
+	"while": true, "with": true, "null": true, "true": true, "false": true, "enum": true,
+}
+
+/*
+	Keywords after that a slash starts a regular expression.
+*/
+var javaScriptRegexpKeywords = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true, "new": true,
+	"delete": true, "void": true, "throw": true, "case": true, "do": true, "else": true,
+	"yield": true, "await": true,
+}
+
+var javaScriptAssignments = map[string]bool{
+	"=": true, "+=": true, "-=": true, "*=": true, "/=": true, "%=": true, "**=": true,
+	"<<=": true, ">>=": true, ">>>=": true, "&=": true, "|=": true, "^=": true,
+	"&&=": true, "||=": true, "??=": true,
+}
+
+var javaScriptBinaryOperators = [][]string{
+	{"??"}, {"||"}, {"&&"}, {"|"}, {"^"}, {"&"},
+	{"==", "!=", "===", "!=="},
+	{"<", ">", "<=", ">=", "instanceof", "in"},
+	{"<<", ">>", ">>>"},
+	{"+", "-"},
+	{"*", "/", "%"},
+	{"**"},
+}
+
+type javaScriptLexer struct {
+	*scanner
+	tokens []token
+	// true for braces of template substitutions
+	braces  []bool
+	newline bool
+}
+
+func lexJavaScript(source string) []token {
+	lexer := &javaScriptLexer{scanner: newScanner(source)}
+	lexer.lex()
+	return lexer.tokens
+}
+
+func (self *javaScriptLexer) add(kind tokenKind, text string, line, column int) {
+	self.tokens = append(self.tokens, token{kind: kind, text: text, line: line, column: column, newline: self.newline})
+	self.newline = false
+}
+
+func (self *javaScriptLexer) lex() {
+	if self.hasPrefix("#!") {
+		for !self.eof() && self.peek(0) != '\n' {
+			self.advance(1)
+		}
+	}
+	for !self.eof() {
+		ch := self.peek(0)
+		line, column := self.line, self.column
+		switch {
+		case ch == '\n':
+			self.newline = true
+			self.advance(1)
+		case ch == ' ' || ch == '\t' || ch == '\r' || ch == '\f' || ch == '\v':
+			self.advance(1)
+		case self.hasPrefix("//"):
+			for !self.eof() && self.peek(0) != '\n' {
+				self.advance(1)
+			}
+		case self.hasPrefix("/*"):
+			self.advance(2)
+			for !self.hasPrefix("*/") {
+				if self.eof() {
+					panic(&Error{Line: line, Column: column, Message: "unterminated comment"})
+				}
+				if self.peek(0) == '\n' {
+					self.newline = true
+				}
+				self.advance(1)
+			}
+			self.advance(2)
+		case isDigit(ch) || (ch == '.' && isDigit(self.peek(1))):
+			text := self.number()
+			last := text[len(text)-1]
+			if isIdentStart(last) && last != 'n' && !isHexNumber(text) {
+				panic(&Error{Line: line, Column: column, Message: "identifier starts immediately after numeric literal"})
+			}
+			self.add(numberToken, text, line, column)
+		case isIdentStart(ch):
+			self.add(identToken, self.ident(), line, column)
+		case ch == '#' && isIdentStart(self.peek(1)):
+			self.advance(1)
+			self.add(identToken, "#"+self.ident(), line, column)
+		case ch == '"' || ch == '\'':
+			self.add(stringToken, self.string(ch), line, column)
+		case ch == '`':
+			self.advance(1)
+			self.template(templateToken, templateHeadToken, line, column)
+		case ch == '/' && self.regexpAllowed():
+			self.add(regexpToken, self.regexp(), line, column)
+		case ch == '{':
+			self.braces = append(self.braces, false)
+			self.advance(1)
+			self.add(punctToken, "{", line, column)
+		case ch == '}' && len(self.braces) > 0 && self.braces[len(self.braces)-1]:
+			self.braces = self.braces[:len(self.braces)-1]
+			self.advance(1)
+			self.template(templateTailToken, templateMiddleToken, line, column)
+		default:
+			punctuator := self.punctuator(javaScriptPunctuators)
+			if punctuator == "" {
+				self.errorf("invalid character '%c'", rune(ch))
+			}
+			if punctuator == "?." && isDigit(self.peek(2)) {
+				// a ? .5 : 1
+				punctuator = "?"
+			}
+			if punctuator == "}" && len(self.braces) > 0 {
+				self.braces = self.braces[:len(self.braces)-1]
+			}
+			self.advance(len(punctuator))
+			self.add(punctToken, punctuator, line, column)
+		}
+	}
+	if len(self.braces) > 0 && self.braces[len(self.braces)-1] {
+		self.errorf("unterminated template literal")
+	}
+	self.add(eofToken, "", self.line, self.column)
+}
+
+func isHexNumber(text string) bool {
+	return len(text) > 1 && text[0] == '0' && (text[1] == 'x' || text[1] == 'X')
+}
+
+func (self *javaScriptLexer) regexpAllowed() bool {
+	if len(self.tokens) == 0 {
+		return true
+	}
+	previous := self.tokens[len(self.tokens)-1]
+	switch previous.kind {
+	case identToken:
+		return javaScriptRegexpKeywords[previous.text]
+	case punctToken:
+		switch previous.text {
+		case ")", "]", "}", "++", "--":
+			return false
+		}
+		return true
+	case templateHeadToken, templateMiddleToken:
+		return true
+	}
+	return false
+}
+
+func (self *javaScriptLexer) string(quote byte) string {
+	start := self.offset
+	line, column := self.line, self.column
+	self.advance(1)
+	for self.peek(0) != quote {
+		if self.eof() || self.peek(0) == '\n' {
+			panic(&Error{Line: line, Column: column, Message: "unterminated string literal"})
+		}
+		if self.peek(0) == '\\' {
+			self.advance(1)
+		}
+		self.advance(1)
+	}
+	self.advance(1)
+	return self.source[start:self.offset]
+}
+
+/*
+	Read text of template literal until "`" or "${".
+*/
+func (self *javaScriptLexer) template(end, substitution tokenKind, line, column int) {
+	start := self.offset
+	for {
+		switch {
+		case self.eof():
+			panic(&Error{Line: line, Column: column, Message: "unterminated template literal"})
+		case self.peek(0) == '`':
+			self.advance(1)
+			self.add(end, self.source[start:self.offset], line, column)
+			return
+		case self.hasPrefix("${"):
+			self.advance(2)
+			self.braces = append(self.braces, true)
+			self.add(substitution, self.source[start:self.offset], line, column)
+			return
+		case self.peek(0) == '\\':
+			self.advance(2)
+		default:
+			self.advance(1)
+		}
+	}
+}
+
+func (self *javaScriptLexer) regexp() string {
+	start := self.offset
+	line, column := self.line, self.column
+	self.advance(1)
+	inClass := false
+	for inClass || self.peek(0) != '/' {
+		if self.eof() || self.peek(0) == '\n' {
+			panic(&Error{Line: line, Column: column, Message: "unterminated regular expression"})
+		}
+		switch self.peek(0) {
+		case '\\':
+			self.advance(1)
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		}
+		self.advance(1)
+	}
+	self.advance(1)
+	self.ident()
+	return self.source[start:self.offset]
+}
+
+/*
+	Kinds of expressions to check targets of assignments.
+*/
+type javaScriptExpression int
+
+const (
+	jsOtherExpression javaScriptExpression = iota
+	jsIdentifierExpression
+	jsMemberExpression
+	jsPatternExpression
+)
+
+type javaScriptParser struct {
+	parser
+}
+
+/*
+	Check syntax of JavaScript (ES2020 script or module).
+*/
+func CheckJavaScript(source string) error {
+	return check(func() {
+		parser := &javaScriptParser{parser: parser{tokens: lexJavaScript(source)}}
+		for parser.peek().kind != eofToken {
+			parser.statementListItem()
+		}
+	})
+}
+
+func (self *javaScriptParser) unexpected() {
+	token := self.peek()
+	if token.kind == eofToken {
+		self.errorf("unexpected end of input")
+	}
+	self.errorf("unexpected token %s", token)
+}
+
+func (self *javaScriptParser) consumeSemicolon() {
+	if self.accept(";") || self.is("}") || self.peek().kind == eofToken || self.peek().newline {
+		return
+	}
+	self.errorf("missing ';' before %s", self.peek())
+}
+
+func (self *javaScriptParser) identifier() token {
+	token := self.peek()
+	if token.kind != identToken || token.text[0] == '#' {
+		self.unexpected()
+	}
+	if javaScriptReservedWords[token.text] {
+		self.errorf("unexpected keyword %s", token)
+	}
+	return self.next()
+}
+
+func (self *javaScriptParser) isIdentifier() bool {
+	token := self.peek()
+	return token.kind == identToken && token.text[0] != '#' && !javaScriptReservedWords[token.text]
+}
+
+/*
+	"async" followed by function on the same line.
+*/
+func (self *javaScriptParser) isAsyncFunction() bool {
+	return self.is("async") && self.peekAt(1).text == "function" && !self.peekAt(1).newline
+}
+
+func (self *javaScriptParser) statementListItem() {
+	switch {
+	case self.is("function"), self.isAsyncFunction():
+		self.function(true)
+	case self.is("class"):
+		self.class(true)
+	case self.is("let") && (self.peekAt(1).kind == identToken || self.peekAt(1).text == "[" || self.peekAt(1).text == "{"),
+		self.is("const"):
+		self.next()
+		self.bindingList(true)
+		self.consumeSemicolon()
+	case self.is("import") && self.peekAt(1).text != "(" && self.peekAt(1).text != ".":
+		self.importDeclaration()
+	case self.is("export"):
+		self.exportDeclaration()
+	default:
+		self.statement()
+	}
+}
+
+func (self *javaScriptParser) statement() {
+	switch {
+	case self.is("{"):
+		self.block()
+	case self.accept(";"):
+	case self.accept("var"):
+		self.bindingList(true)
+		self.consumeSemicolon()
+	case self.accept("if"):
+		self.parenthesized()
+		self.statement()
+		if self.accept("else") {
+			self.statement()
+		}
+	case self.is("for"):
+		self.forStatement()
+	case self.accept("while"):
+		self.parenthesized()
+		self.statement()
+	case self.accept("do"):
+		self.statement()
+		self.expect("while")
+		self.parenthesized()
+		self.accept(";")
+	case self.is("continue"), self.is("break"):
+		self.next()
+		if self.isIdentifier() && !self.peek().newline {
+			self.next()
+		}
+		self.consumeSemicolon()
+	case self.accept("return"):
+		if !self.is(";") && !self.is("}") && self.peek().kind != eofToken && !self.peek().newline {
+			self.expression()
+		}
+		self.consumeSemicolon()
+	case self.accept("throw"):
+		if self.peek().newline {
+			self.errorf("illegal newline after throw")
+		}
+		self.expression()
+		self.consumeSemicolon()
+	case self.is("try"):
+		self.tryStatement()
+	case self.is("switch"):
+		self.switchStatement()
+	case self.accept("debugger"):
+		self.consumeSemicolon()
+	case self.isIdentifier() && self.peekAt(1).kind == punctToken && self.peekAt(1).text == ":":
+		self.next()
+		self.next()
+		self.statement()
+	case self.is("function"), self.is("class"), self.is("const"), self.is("import") && self.peekAt(1).text != "(" && self.peekAt(1).text != ".":
+		self.errorf("declaration is not allowed here: %s", self.peek())
+	default:
+		self.expression()
+		self.consumeSemicolon()
+	}
+}
+
+func (self *javaScriptParser) block() {
+	self.expect("{")
+	for !self.is("}") {
+		if self.peek().kind == eofToken {
+			self.errorf("missing '}' before end of input")
+		}
+		self.statementListItem()
+	}
+	self.next()
+}
+
+func (self *javaScriptParser) parenthesized() {
+	self.expect("(")
+	self.expression()
+	self.expect(")")
+}
+
+func (self *javaScriptParser) forStatement() {
+	self.expect("for")
+	self.accept("await")
+	self.expect("(")
+	switch {
+	case self.accept(";"):
+		self.forRest()
+		return
+	case self.is("var"), self.is("let"), self.is("const"):
+		self.next()
+		self.bindingList(false)
+	default:
+		iteration := self.try(func() {
+			if self.leftHandSide() == jsOtherExpression || !(self.is("of") || self.is("in")) {
+				self.unexpected()
+			}
+		})
+		if !iteration {
+			self.expression()
+		}
+	}
+	if self.accept("of") {
+		self.assignment()
+		self.expect(")")
+		self.statement()
+		return
+	}
+	if self.accept("in") {
+		self.expression()
+		self.expect(")")
+		self.statement()
+		return
+	}
+	self.expect(";")
+	self.forRest()
+}
+
+func (self *javaScriptParser) forRest() {
+	if !self.is(";") {
+		self.expression()
+	}
+	self.expect(";")
+	if !self.is(")") {
+		self.expression()
+	}
+	self.expect(")")
+	self.statement()
+}
+
+func (self *javaScriptParser) tryStatement() {
+	self.expect("try")
+	self.block()
+	handled := false
+	if self.accept("catch") {
+		if self.accept("(") {
+			self.bindingTarget()
+			self.expect(")")
+		}
+		self.block()
+		handled = true
+	}
+	if self.accept("finally") {
+		self.block()
+	} else if !handled {
+		self.errorf("missing catch or finally after try")
+	}
+}
+
+func (self *javaScriptParser) switchStatement() {
+	self.expect("switch")
+	self.parenthesized()
+	self.expect("{")
+	for !self.accept("}") {
+		if self.accept("case") {
+			self.expression()
+		} else if !self.accept("default") {
+			self.unexpected()
+		}
+		self.expect(":")
+		for !self.is("case") && !self.is("default") && !self.is("}") {
+			if self.peek().kind == eofToken {
+				self.unexpected()
+			}
+			self.statementListItem()
+		}
+	}
+}
+
+/*
+	Bindings of var, let and const.
+*/
+func (self *javaScriptParser) bindingList(initializer bool) {
+	for {
+		pattern := self.is("{") || self.is("[")
+		self.bindingTarget()
+		if self.accept("=") {
+			self.assignment()
+		} else if pattern && initializer {
+			self.errorf("missing '=' in destructuring declaration")
+		}
+		if !self.accept(",") {
+			break
+		}
+	}
+}
+
+func (self *javaScriptParser) bindingTarget() {
+	switch {
+	case self.accept("{"):
+		for !self.accept("}") {
+			if self.accept("...") {
+				self.identifier()
+			} else {
+				shorthand := self.isIdentifier()
+				self.propertyName()
+				if self.accept(":") {
+					self.bindingElement()
+				} else if !shorthand {
+					self.unexpected()
+				} else if self.accept("=") {
+					self.assignment()
+				}
+			}
+			if !self.is("}") {
+				self.expect(",")
+			}
+		}
+	case self.accept("["):
+		for !self.accept("]") {
+			if self.accept(",") {
+				continue
+			}
+			if self.accept("...") {
+				self.bindingTarget()
+			} else {
+				self.bindingElement()
+			}
+			if !self.is("]") {
+				self.expect(",")
+			}
+		}
+	default:
+		self.identifier()
+	}
+}
+
+func (self *javaScriptParser) bindingElement() {
+	self.bindingTarget()
+	if self.accept("=") {
+		self.assignment()
+	}
+}
+
+func (self *javaScriptParser) parameters() {
+	self.expect("(")
+	for !self.accept(")") {
+		if self.accept("...") {
+			self.bindingTarget()
+			self.expect(")")
+			return
+		}
+		self.bindingElement()
+		if !self.is(")") {
+			self.expect(",")
+		}
+	}
+}
+
+func (self *javaScriptParser) function(declaration bool) {
+	self.accept("async")
+	self.expect("function")
+	self.accept("*")
+	if declaration || self.isIdentifier() {
+		self.identifier()
+	}
+	self.parameters()
+	self.block()
+}
+
+func (self *javaScriptParser) class(declaration bool) {
+	self.expect("class")
+	if (declaration || self.isIdentifier()) && !self.is("extends") {
+		self.identifier()
+	}
+	if self.accept("extends") {
+		self.leftHandSide()
+	}
+	self.expect("{")
+	for !self.accept("}") {
+		if self.accept(";") {
+			continue
+		}
+		if self.is("static") && self.peekAt(1).text != "(" && self.peekAt(1).text != "=" {
+			self.next()
+		}
+		if self.is("async") && self.peekAt(1).text != "(" && !self.peekAt(1).newline {
+			self.next()
+		}
+		if (self.is("get") || self.is("set")) && self.peekAt(1).text != "(" && self.peekAt(1).text != "=" && self.peekAt(1).text != ";" {
+			self.next()
+		}
+		generator := self.accept("*")
+		self.propertyName()
+		if self.is("(") || generator {
+			self.parameters()
+			self.block()
+			continue
+		}
+		if self.accept("=") {
+			self.assignment()
+		}
+		self.consumeSemicolon()
+	}
+}
+
+func (self *javaScriptParser) propertyName() {
+	token := self.peek()
+	switch {
+	case token.kind == identToken, token.kind == stringToken, token.kind == numberToken:
+		self.next()
+	case self.accept("["):
+		self.assignment()
+		self.expect("]")
+	default:
+		self.unexpected()
+	}
+}
+
+func (self *javaScriptParser) moduleSpecifier() {
+	if self.peek().kind != stringToken {
+		self.errorf("expected module name but found %s", self.peek())
+	}
+	self.next()
+}
+
+func (self *javaScriptParser) namedImportsOrExports() {
+	self.expect("{")
+	for !self.accept("}") {
+		if self.peek().kind != identToken && self.peek().kind != stringToken {
+			self.unexpected()
+		}
+		self.next()
+		if self.accept("as") {
+			if self.peek().kind != identToken && self.peek().kind != stringToken {
+				self.unexpected()
+			}
+			self.next()
+		}
+		if !self.is("}") {
+			self.expect(",")
+		}
+	}
+}
+
+func (self *javaScriptParser) importDeclaration() {
+	self.expect("import")
+	if self.peek().kind == stringToken {
+		self.next()
+		self.consumeSemicolon()
+		return
+	}
+	if self.isIdentifier() {
+		self.next()
+		if !self.accept(",") {
+			self.expect("from")
+			self.moduleSpecifier()
+			self.consumeSemicolon()
+			return
+		}
+	}
+	if self.accept("*") {
+		self.expect("as")
+		self.identifier()
+	} else {
+		self.namedImportsOrExports()
+	}
+	self.expect("from")
+	self.moduleSpecifier()
+	self.consumeSemicolon()
+}
+
+func (self *javaScriptParser) exportDeclaration() {
+	self.expect("export")
+	switch {
+	case self.accept("default"):
+		switch {
+		case self.is("function"), self.isAsyncFunction():
+			self.function(false)
+		case self.is("class"):
+			self.class(false)
+		default:
+			self.assignment()
+			self.consumeSemicolon()
+		}
+	case self.accept("*"):
+		if self.accept("as") {
+			self.next()
+		}
+		self.expect("from")
+		self.moduleSpecifier()
+		self.consumeSemicolon()
+	case self.is("{"):
+		self.namedImportsOrExports()
+		if self.accept("from") {
+			self.moduleSpecifier()
+		}
+		self.consumeSemicolon()
+	case self.is("var"), self.is("let"), self.is("const"):
+		self.next()
+		self.bindingList(true)
+		self.consumeSemicolon()
+	case self.is("function"), self.isAsyncFunction():
+		self.function(true)
+	case self.is("class"):
+		self.class(true)
+	default:
+		self.unexpected()
+	}
+}
+
+func (self *javaScriptParser) expression() {
+	self.assignment()
+	for self.accept(",") {
+		self.assignment()
+	}
+}
+
+/*
+	Arrow function starts at the current position.
+*/
+func (self *javaScriptParser) isArrow() bool {
+	offset := 0
+	if self.is("async") && !self.peekAt(1).newline && (self.peekAt(1).kind == identToken || self.peekAt(1).text == "(") {
+		offset = 1
+	}
+	next := self.peekAt(offset)
+	if next.kind == identToken && next.text != "async" || (next.text == "async" && offset == 0) {
+		arrow := self.peekAt(offset + 1)
+		return arrow.kind == punctToken && arrow.text == "=>" && !arrow.newline
+	}
+	if next.kind == punctToken && next.text == "(" {
+		pos := self.pos
+		self.pos += offset
+		end := self.matchingBracket()
+		self.pos = pos
+		if end == -1 || end+1 >= len(self.tokens) {
+			return false
+		}
+		arrow := self.tokens[end+1]
+		return arrow.kind == punctToken && arrow.text == "=>" && !arrow.newline
+	}
+	return false
+}
+
+func (self *javaScriptParser) arrowFunction() {
+	if self.is("async") && self.peekAt(1).text != "=>" {
+		self.next()
+	}
+	if self.is("(") {
+		self.parameters()
+	} else {
+		self.identifier()
+	}
+	self.expect("=>")
+	if self.is("{") {
+		self.block()
+	} else {
+		self.assignment()
+	}
+}
+
+func (self *javaScriptParser) assignment() javaScriptExpression {
+	if self.isArrow() {
+		self.arrowFunction()
+		return jsOtherExpression
+	}
+	if self.is("yield") {
+		self.next()
+		self.accept("*")
+		if !self.peek().newline && !self.is(")") && !self.is("]") && !self.is("}") && !self.is(",") && !self.is(";") && !self.is(":") && self.peek().kind != eofToken {
+			self.assignment()
+		}
+		return jsOtherExpression
+	}
+	start := self.peek()
+	kind := self.conditional()
+	token := self.peek()
+	if token.kind == punctToken && javaScriptAssignments[token.text] {
+		if kind == jsOtherExpression || (kind == jsPatternExpression && token.text != "=") {
+			self.errorAt(start, "invalid assignment left-hand side")
+		}
+		self.next()
+		self.assignment()
+		return jsOtherExpression
+	}
+	return kind
+}
+
+func (self *javaScriptParser) conditional() javaScriptExpression {
+	kind := self.binary(0)
+	if self.accept("?") {
+		self.assignment()
+		self.expect(":")
+		self.assignment()
+		return jsOtherExpression
+	}
+	return kind
+}
+
+func (self *javaScriptParser) binary(level int) javaScriptExpression {
+	if level == len(javaScriptBinaryOperators) {
+		return self.unary()
+	}
+	kind := self.binary(level + 1)
+	for {
+		token := self.peek()
+		matched := false
+		if token.kind == punctToken || token.kind == identToken {
+			for _, operator := range javaScriptBinaryOperators[level] {
+				if token.text == operator {
+					matched = true
+				}
+			}
+		}
+		if !matched {
+			return kind
+		}
+		self.next()
+		self.binary(level + 1)
+		kind = jsOtherExpression
+	}
+}
+
+func (self *javaScriptParser) unary() javaScriptExpression {
+	switch {
+	case self.accept("delete"), self.accept("void"), self.accept("typeof"), self.accept("await"),
+		self.accept("+"), self.accept("-"), self.accept("~"), self.accept("!"):
+		self.unary()
+		return jsOtherExpression
+	case self.is("++"), self.is("--"):
+		self.next()
+		start := self.peek()
+		if kind := self.unary(); kind != jsIdentifierExpression && kind != jsMemberExpression {
+			self.errorAt(start, "invalid operand of prefix operation")
+		}
+		return jsOtherExpression
+	}
+	start := self.peek()
+	kind := self.leftHandSide()
+	if (self.is("++") || self.is("--")) && !self.peek().newline {
+		if kind != jsIdentifierExpression && kind != jsMemberExpression {
+			self.errorAt(start, "invalid operand of postfix operation")
+		}
+		self.next()
+		return jsOtherExpression
+	}
+	return kind
+}
+
+/*
+	Member accesses, calls and new.
+*/
+func (self *javaScriptParser) leftHandSide() javaScriptExpression {
+	var kind javaScriptExpression
+	if self.is("new") {
+		kind = self.newExpression()
+	} else {
+		kind = self.primary()
+	}
+	for {
+		switch {
+		case self.accept("."):
+			self.propertyAccess()
+			kind = jsMemberExpression
+		case self.accept("?."):
+			switch {
+			case self.is("("):
+				self.arguments()
+			case self.accept("["):
+				self.expression()
+				self.expect("]")
+			default:
+				self.propertyAccess()
+			}
+			kind = jsOtherExpression
+		case self.accept("["):
+			self.expression()
+			self.expect("]")
+			kind = jsMemberExpression
+		case self.is("("):
+			self.arguments()
+			kind = jsOtherExpression
+		case self.peek().kind == templateToken || self.peek().kind == templateHeadToken:
+			self.template()
+			kind = jsOtherExpression
+		default:
+			return kind
+		}
+	}
+}
+
+func (self *javaScriptParser) propertyAccess() {
+	if self.peek().kind != identToken {
+		self.unexpected()
+	}
+	self.next()
+}
+
+func (self *javaScriptParser) newExpression() javaScriptExpression {
+	self.expect("new")
+	if self.accept(".") {
+		if !self.is("target") {
+			self.unexpected()
+		}
+		self.next()
+		return jsOtherExpression
+	}
+	if self.is("new") {
+		self.newExpression()
+	} else {
+		self.primary()
+	}
+	for {
+		switch {
+		case self.accept("."):
+			self.propertyAccess()
+		case self.accept("["):
+			self.expression()
+			self.expect("]")
+		case self.peek().kind == templateToken || self.peek().kind == templateHeadToken:
+			self.template()
+		default:
+			if self.is("(") {
+				self.arguments()
+			}
+			return jsOtherExpression
+		}
+	}
+}
+
+func (self *javaScriptParser) arguments() {
+	self.expect("(")
+	for !self.accept(")") {
+		self.accept("...")
+		self.assignment()
+		if !self.is(")") {
+			self.expect(",")
+		}
+	}
+}
+
+func (self *javaScriptParser) template() {
+	if self.next().kind == templateToken {
+		return
+	}
+	for {
+		self.expression()
+		switch self.peek().kind {
+		case templateMiddleToken:
+			self.next()
+		case templateTailToken:
+			self.next()
+			return
+		default:
+			self.errorf("expected '}' of template substitution but found %s", self.peek())
+		}
+	}
+}
+
+func (self *javaScriptParser) primary() javaScriptExpression {
+	token := self.peek()
+	switch token.kind {
+	case numberToken, stringToken, regexpToken:
+		self.next()
+		return jsOtherExpression
+	case templateToken, templateHeadToken:
+		self.template()
+		return jsOtherExpression
+	case identToken:
+		switch token.text {
+		case "this", "null", "true", "false", "super":
+			self.next()
+			return jsOtherExpression
+		case "function":
+			self.function(false)
+			return jsOtherExpression
+		case "class":
+			self.class(false)
+			return jsOtherExpression
+		case "import":
+			self.next()
+			if self.accept(".") {
+				if !self.is("meta") {
+					self.unexpected()
+				}
+				self.next()
+				return jsOtherExpression
+			}
+			self.expect("(")
+			self.assignment()
+			self.expect(")")
+			return jsOtherExpression
+		case "async":
+			if self.isAsyncFunction() {
+				self.function(false)
+				return jsOtherExpression
+			}
+		}
+		self.identifier()
+		return jsIdentifierExpression
+	}
+	switch {
+	case self.accept("("):
+		kind := self.assignment()
+		if self.is(",") {
+			kind = jsOtherExpression
+			for self.accept(",") {
+				self.assignment()
+			}
+		}
+		self.expect(")")
+		if kind == jsPatternExpression {
+			return jsOtherExpression
+		}
+		return kind
+	case self.accept("["):
+		for !self.accept("]") {
+			if self.accept(",") {
+				continue
+			}
+			self.accept("...")
+			self.assignment()
+			if !self.is("]") {
+				self.expect(",")
+			}
+		}
+		return jsPatternExpression
+	case self.is("{"):
+		self.object()
+		return jsPatternExpression
+	}
+	self.unexpected()
+	return jsOtherExpression
+}
+
+func (self *javaScriptParser) object() {
+	self.expect("{")
+	for !self.accept("}") {
+		if self.accept("...") {
+			self.assignment()
+		} else {
+			if (self.is("get") || self.is("set") || self.is("async")) && !self.isPropertyEnd(1) {
+				self.next()
+			}
+			generator := self.accept("*")
+			shorthand := self.isIdentifier()
+			self.propertyName()
+			switch {
+			case self.is("(") || generator:
+				self.parameters()
+				self.block()
+			case self.accept(":"):
+				self.assignment()
+			case !shorthand:
+				self.unexpected()
+			case self.accept("="):
+				// default value of destructuring assignment
+				self.assignment()
+			}
+		}
+		if !self.is("}") {
+			self.expect(",")
+		}
+	}
+}
+
+/*
+	The token at n is the end of a property name like ":" or "(".
+*/
+func (self *javaScriptParser) isPropertyEnd(n int) bool {
+	token := self.peekAt(n)
+	if token.kind != punctToken {
+		return false
+	}
+	switch token.text {
+	case ":", "(", ",", "}", "=":
+		return true
+	}
+	return false
+}
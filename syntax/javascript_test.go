@@ -0,0 +1,63 @@
+package syntax_test
+
+import (
+	"github.com/shibukawa/curl_as_dsl/syntax"
+	. "gopkg.in/check.v1"
+)
+
+type JavaScriptTest struct{}
+
+var _ = Suite(&JavaScriptTest{})
+
+func (s *JavaScriptTest) Test_Valid(c *C) {
+	checkValid(c, syntax.CheckJavaScript, []string{
+		"",
+		"#!/usr/bin/env node\nvar http = require('http');",
+		"import fs from 'fs';\nimport { readFile as read } from \"fs/promises\";\nimport * as path from 'path';",
+		"export default async function main() {}\nexport const a = 1, b = [1, 2,];",
+		"const { a, b: [c, d = 2], ...rest } = obj;\nlet [x, , y] = list;",
+		"var re = /a[/]b\\//gi.test(s) ? 1 / 2 : a / b / c;",
+		"var s = `a ${b + `nested ${c}`} d ${ {e: 1}.e }`;\nconsole.log`tag`;",
+		"const f = async (a, {b}, ...c) => { await a; };\nconst g = x => y => x + y;\nconst h = async x => x;",
+		"for (const [k, v] of Object.entries(o)) {}\nfor (var k in o) continue;\nfor (let i = 0; i < 10; i++) {}\nfor await (const x of xs) {}",
+		"a = b\n++c\nreturn1()",
+		"function f() {\n  return\n}\nlabel: for (;;) { break label; }",
+		"try { a() } catch { b() } finally { c() }\ntry {} catch (e) {}",
+		"switch (a) { case 1: case 2: b(); break; default: c() }",
+		"class A extends B.C { static x = 1; #p = 2; get y() { return this.#p } static async *gen() {} constructor() { super(); } }",
+		"var o = { get: 1, set(v) {}, async: true, 'q': 2, [k]: 3, m() {}, ...p, get a() { return 1 }, async b() {} };",
+		"a?.b?.[c]?.(d) ?? e;\nx ||= y;\nx **= 2;\nvar n = 0x1F + 1e3 + .5 + 10n;",
+		"new Foo;\nnew Foo.Bar(1)\nnew new A()();\nnew.target;\nimport('x').then(m => m);",
+		"if (a) b(); else if (c) d(); else { e() }\ndo x++; while (x < 10)\nwhile (true) {}",
+		"(function () {})();\n(() => {})();\n!function () {}();",
+		"({ a, b } = obj);\n[a, b] = [b, a];",
+		"req.on('response', function(res) { res.on('data', (chunk) => { body += chunk; }); });",
+	})
+}
+
+func (s *JavaScriptTest) Test_Invalid(c *C) {
+	checkInvalid(c, syntax.CheckJavaScript, []invalidCode{
+		{"var a = \"abc;", 1, 9},
+		{"var a = 'abc\ndef';", 1, 9},
+		{"var a = `abc ${b}", 1, 17},
+		{"var a = {\n  \"b\": \"c\",\n, \"d\": 1 };", 3, 1},
+		{"var a = {\"b\": \"c\" \"d\": 1};", 1, 19},
+		{"foo(1, 2;", 1, 9},
+		{"if (a {}", 1, 7},
+		{"var = 1;", 1, 5},
+		{"var class = 1;", 1, 5},
+		{"a + b = c;", 1, 1},
+		{"1++;", 1, 1},
+		{"a b;", 1, 3},
+		{"function f( {}", 1, 15},
+		{"try {}", 1, 7},
+		{"throw\nerror;", 2, 1},
+		{"var a = 1 /* comment", 1, 11},
+		{"var a = 1\n}", 2, 1},
+		{"var a = 5px;", 1, 9},
+		{"var x = /abc;", 1, 9},
+		{"const { a };", 1, 12},
+		{"if (a) const b = 1;", 1, 8},
+		{"var a = \"x\" \\\"y\";", 1, 13},
+	})
+}
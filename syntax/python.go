@@ -0,0 +1,1071 @@
+package syntax
+
+import (
+	"strings"
+)
+
+var pythonPunctuators = []string{
+	"**=", "//=", ">>=", "<<=", "...",
+	"->", ":=", "**", "//", "<<", ">>", "<=", ">=", "==", "!=",
+	"+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=", "@=",
+	"+", "-", "*", "/", "%", "@", "&", "|", "^", "~", "<", ">",
+	"(", ")", "[", "]", "{", "}", ",", ":", ".", ";", "=",
+}
+
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true, "async": true,
+	"await": true, "break": true, "class": true, "continue": true, "def": true, "del": true, "elif": true,
+	"else": true, "except": true, "finally": true, "for": true, "from": true, "global": true, "if": true,
+	"import": true, "in": true, "is": true, "lambda": true, "nonlocal": true, "not": true, "or": true,
+	"pass": true, "raise": true, "return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+type pythonLexer struct {
+	*scanner
+	tokens   []token
+	indents  []int
+	brackets []byte
+}
+
+func lexPython(source string) []token {
+	lexer := &pythonLexer{scanner: newScanner(source), indents: []int{0}}
+	lexer.lex()
+	return lexer.tokens
+}
+
+func (self *pythonLexer) add(kind tokenKind, text string, line, column int) {
+	self.tokens = append(self.tokens, token{kind: kind, text: text, line: line, column: column})
+}
+
+func (self *pythonLexer) lex() {
+	atLineStart := true
+	for {
+		if atLineStart && len(self.brackets) == 0 {
+			if !self.indent() {
+				break
+			}
+			atLineStart = false
+		}
+		if self.eof() {
+			break
+		}
+		ch := self.peek(0)
+		line, column := self.line, self.column
+		switch {
+		case ch == '\n':
+			self.advance(1)
+			if len(self.brackets) == 0 {
+				self.add(newlineToken, "", line, column)
+				atLineStart = true
+			}
+		case ch == ' ' || ch == '\t' || ch == '\r' || ch == '\f':
+			self.advance(1)
+		case ch == '#':
+			for !self.eof() && self.peek(0) != '\n' {
+				self.advance(1)
+			}
+		case ch == '\\':
+			if self.peek(1) == '\n' {
+				self.advance(2)
+			} else if self.peek(1) == '\r' && self.peek(2) == '\n' {
+				self.advance(3)
+			} else {
+				self.errorf("unexpected character after line continuation character")
+			}
+		case isDigit(ch) || (ch == '.' && isDigit(self.peek(1))):
+			text := self.number()
+			if strings.HasSuffix(strings.ToLower(text), "l") {
+				panic(&Error{Line: line, Column: column, Message: "invalid number: " + text})
+			}
+			self.add(numberToken, text, line, column)
+		case isIdentStart(ch) && ch != '$':
+			if prefix := self.stringPrefix(); prefix != "" {
+				self.add(stringToken, self.string(len(prefix)), line, column)
+			} else {
+				self.add(identToken, self.ident(), line, column)
+			}
+		case ch == '"' || ch == '\'':
+			self.add(stringToken, self.string(0), line, column)
+		default:
+			punctuator := self.punctuator(pythonPunctuators)
+			if punctuator == "" {
+				self.errorf("invalid character '%c'", rune(ch))
+			}
+			self.bracket(punctuator)
+			self.advance(len(punctuator))
+			self.add(punctToken, punctuator, line, column)
+		}
+	}
+	if len(self.brackets) > 0 {
+		self.errorf("'%c' was never closed", self.brackets[len(self.brackets)-1])
+	}
+	if len(self.tokens) > 0 && self.tokens[len(self.tokens)-1].kind != newlineToken {
+		self.add(newlineToken, "", self.line, self.column)
+	}
+	for len(self.indents) > 1 {
+		self.indents = self.indents[:len(self.indents)-1]
+		self.add(dedentToken, "", self.line, self.column)
+	}
+	self.add(eofToken, "", self.line, self.column)
+}
+
+func (self *pythonLexer) bracket(punctuator string) {
+	switch punctuator {
+	case "(", "[", "{":
+		self.brackets = append(self.brackets, punctuator[0])
+	case ")", "]", "}":
+		open := map[string]byte{")": '(', "]": '[', "}": '{'}[punctuator]
+		if len(self.brackets) == 0 {
+			self.errorf("unmatched '%s'", punctuator)
+		}
+		if last := self.brackets[len(self.brackets)-1]; last != open {
+			self.errorf("closing parenthesis '%s' does not match opening parenthesis '%c'", punctuator, last)
+		}
+		self.brackets = self.brackets[:len(self.brackets)-1]
+	}
+}
+
+/*
+	Read indentation of a line and add indent and dedent tokens. Blank lines are skipped.
+	It returns false at the end of the file.
+*/
+func (self *pythonLexer) indent() bool {
+	for {
+		width := 0
+		for !self.eof() && (self.peek(0) == ' ' || self.peek(0) == '\t' || self.peek(0) == '\f') {
+			if self.peek(0) == '\t' {
+				width = (width/8 + 1) * 8
+			} else {
+				width++
+			}
+			self.advance(1)
+		}
+		if self.eof() {
+			return false
+		}
+		switch self.peek(0) {
+		case '#':
+			for !self.eof() && self.peek(0) != '\n' {
+				self.advance(1)
+			}
+			continue
+		case '\r':
+			self.advance(1)
+			continue
+		case '\n':
+			self.advance(1)
+			continue
+		}
+		current := self.indents[len(self.indents)-1]
+		if width > current {
+			self.indents = append(self.indents, width)
+			self.add(indentToken, "", self.line, self.column)
+		}
+		for width < self.indents[len(self.indents)-1] {
+			self.indents = self.indents[:len(self.indents)-1]
+			if width > self.indents[len(self.indents)-1] {
+				self.errorf("unindent does not match any outer indentation level")
+			}
+			self.add(dedentToken, "", self.line, self.column)
+		}
+		return true
+	}
+}
+
+/*
+	Prefix of a string literal like "r" or "rb" at the current position.
+*/
+func (self *pythonLexer) stringPrefix() string {
+	for length := 1; length <= 2; length++ {
+		ch := self.peek(length)
+		if ch != '"' && ch != '\'' {
+			continue
+		}
+		prefix := strings.ToLower(self.source[self.offset : self.offset+length])
+		switch prefix {
+		case "r", "u", "b", "f", "br", "rb", "fr", "rf":
+			return self.source[self.offset : self.offset+length]
+		}
+		return ""
+	}
+	return ""
+}
+
+func (self *pythonLexer) string(prefixLength int) string {
+	start := self.offset
+	line, column := self.line, self.column
+	prefix := strings.ToLower(self.source[self.offset : self.offset+prefixLength])
+	self.advance(prefixLength)
+	quote := self.source[self.offset : self.offset+1]
+	if self.hasPrefix(strings.Repeat(quote, 3)) {
+		quote = strings.Repeat(quote, 3)
+	}
+	self.advance(len(quote))
+	contentStart := self.offset
+	for {
+		if self.eof() || (len(quote) == 1 && self.peek(0) == '\n') {
+			panic(&Error{Line: line, Column: column, Message: "unterminated string literal"})
+		}
+		if self.hasPrefix(quote) {
+			break
+		}
+		if self.peek(0) == '\\' {
+			self.advance(1)
+		}
+		self.advance(1)
+	}
+	content := self.source[contentStart:self.offset]
+	self.advance(len(quote))
+	if strings.Contains(prefix, "f") {
+		checkFormatString(content, line, column+prefixLength+len(quote))
+	}
+	return self.source[start:self.offset]
+}
+
+/*
+	Check replacement fields like {name!r:>10} of f-string. Expressions in fields are parsed.
+*/
+func checkFormatString(content string, line, column int) {
+	position := func(offset int) (int, int) {
+		before := content[:offset]
+		if index := strings.LastIndexByte(before, '\n'); index != -1 {
+			return line + strings.Count(before, "\n"), offset - index
+		}
+		return line, column + offset
+	}
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '{':
+			if i+1 < len(content) && content[i+1] == '{' {
+				i++
+				continue
+			}
+			depth := 0
+			end := -1
+			for j := i + 1; j < len(content) && end == -1; j++ {
+				switch content[j] {
+				case '{', '[', '(':
+					depth++
+				case ')', ']':
+					depth--
+				case '}':
+					if depth == 0 {
+						end = j
+					}
+					depth--
+				case '!', ':':
+					if depth == 0 && !(content[j] == '!' && j+1 < len(content) && content[j+1] == '=') {
+						end = j
+					}
+				}
+			}
+			if end == -1 {
+				l, c := position(i)
+				panic(&Error{Line: l, Column: c, Message: "f-string: expecting '}'"})
+			}
+			expression := content[i+1 : end]
+			if strings.TrimSpace(expression) == "" {
+				l, c := position(i)
+				panic(&Error{Line: l, Column: c, Message: "f-string: empty expression not allowed"})
+			}
+			if err := check(func() {
+				parser := &pythonParser{parser: parser{tokens: lexPython("(" + expression + ")")}}
+				parser.expression()
+				parser.expectNewline()
+			}); err != nil {
+				l, c := position(i)
+				panic(&Error{Line: l, Column: c, Message: "f-string: " + err.(*Error).Message})
+			}
+			// skip conversion and format spec
+			depth = 0
+			for i = end; i < len(content); i++ {
+				if content[i] == '{' {
+					depth++
+				} else if content[i] == '}' {
+					if depth == 0 {
+						break
+					}
+					depth--
+				}
+			}
+		case '}':
+			if i+1 < len(content) && content[i+1] == '}' {
+				i++
+				continue
+			}
+			l, c := position(i)
+			panic(&Error{Line: l, Column: c, Message: "f-string: single '}' is not allowed"})
+		}
+	}
+}
+
+type pythonParser struct {
+	parser
+}
+
+/*
+	Check syntax of Python 3 code.
+*/
+func CheckPython(source string) error {
+	return check(func() {
+		parser := &pythonParser{parser: parser{tokens: lexPython(source)}}
+		parser.file()
+	})
+}
+
+func (self *pythonParser) file() {
+	for self.peek().kind != eofToken {
+		if self.peek().kind == newlineToken {
+			self.next()
+			continue
+		}
+		self.statement()
+	}
+}
+
+func (self *pythonParser) expectNewline() {
+	if self.peek().kind != newlineToken {
+		self.errorf("invalid syntax: unexpected %s", self.peek())
+	}
+	self.next()
+}
+
+func (self *pythonParser) name() token {
+	token := self.peek()
+	if token.kind != identToken || pythonKeywords[token.text] {
+		self.errorf("expected name but found %s", token)
+	}
+	return self.next()
+}
+
+func (self *pythonParser) isName() bool {
+	token := self.peek()
+	return token.kind == identToken && !pythonKeywords[token.text]
+}
+
+func (self *pythonParser) statement() {
+	token := self.peek()
+	if token.kind == indentToken {
+		self.errorf("unexpected indent")
+	}
+	if token.kind == dedentToken {
+		self.errorf("unexpected dedent")
+	}
+	switch {
+	case self.is("if"):
+		self.next()
+		self.namedExpression()
+		self.block()
+		for self.accept("elif") {
+			self.namedExpression()
+			self.block()
+		}
+		if self.accept("else") {
+			self.block()
+		}
+	case self.is("while"):
+		self.next()
+		self.namedExpression()
+		self.block()
+		if self.accept("else") {
+			self.block()
+		}
+	case self.is("for"):
+		self.forStatement()
+	case self.is("try"):
+		self.tryStatement()
+	case self.is("with"):
+		self.withStatement()
+	case self.is("def"):
+		self.function()
+	case self.is("class"):
+		self.next()
+		self.name()
+		if self.accept("(") {
+			self.arguments(")")
+		}
+		self.block()
+	case self.is("async"):
+		self.next()
+		switch {
+		case self.is("def"):
+			self.function()
+		case self.is("for"):
+			self.forStatement()
+		case self.is("with"):
+			self.withStatement()
+		default:
+			self.errorf("expected 'def', 'for' or 'with' after 'async'")
+		}
+	case self.is("@"):
+		self.next()
+		self.namedExpression()
+		self.expectNewline()
+		if !self.is("@") && !self.is("def") && !self.is("class") && !self.is("async") {
+			self.errorf("expected function or class after decorator")
+		}
+		self.statement()
+	default:
+		self.simpleStatements()
+	}
+}
+
+func (self *pythonParser) block() {
+	self.expect(":")
+	if self.peek().kind != newlineToken {
+		self.simpleStatements()
+		return
+	}
+	self.next()
+	if self.peek().kind != indentToken {
+		self.errorf("expected an indented block")
+	}
+	self.next()
+	for self.peek().kind != dedentToken && self.peek().kind != eofToken {
+		self.statement()
+	}
+	self.next()
+}
+
+func (self *pythonParser) forStatement() {
+	self.expect("for")
+	self.targets()
+	self.expect("in")
+	self.expressions()
+	self.block()
+	if self.accept("else") {
+		self.block()
+	}
+}
+
+func (self *pythonParser) tryStatement() {
+	self.expect("try")
+	self.block()
+	handlers := 0
+	for self.accept("except") {
+		handlers++
+		self.accept("*")
+		if !self.is(":") {
+			self.expression()
+			if self.accept("as") {
+				self.name()
+			}
+		}
+		self.block()
+	}
+	if handlers > 0 && self.accept("else") {
+		self.block()
+	}
+	if self.accept("finally") {
+		self.block()
+	} else if handlers == 0 {
+		self.errorf("expected 'except' or 'finally' block")
+	}
+}
+
+func (self *pythonParser) withStatement() {
+	self.expect("with")
+	for {
+		self.expression()
+		if self.accept("as") {
+			self.target()
+		}
+		if !self.accept(",") {
+			break
+		}
+	}
+	self.block()
+}
+
+func (self *pythonParser) function() {
+	self.expect("def")
+	self.name()
+	self.expect("(")
+	self.parameters(")", true)
+	self.expect(")")
+	if self.accept("->") {
+		self.expression()
+	}
+	self.block()
+}
+
+/*
+	Parameters of def and lambda. Annotations are allowed only in def.
+*/
+func (self *pythonParser) parameters(end string, annotation bool) {
+	defaults := false
+	for !self.is(end) {
+		switch {
+		case self.accept("**"):
+			self.name()
+			if annotation && self.accept(":") {
+				self.expression()
+			}
+		case self.accept("*"):
+			if self.isName() {
+				self.name()
+				if annotation && self.accept(":") {
+					self.expression()
+				}
+			}
+		case self.accept("/"):
+		default:
+			name := self.name()
+			if annotation && self.accept(":") {
+				self.expression()
+			}
+			if self.accept("=") {
+				self.expression()
+				defaults = true
+			} else if defaults {
+				self.errorAt(name, "non-default argument follows default argument")
+			}
+		}
+		if !self.accept(",") {
+			break
+		}
+	}
+}
+
+func (self *pythonParser) simpleStatements() {
+	for {
+		self.smallStatement()
+		if !self.accept(";") || self.peek().kind == newlineToken {
+			break
+		}
+	}
+	self.expectNewline()
+}
+
+func (self *pythonParser) smallStatement() {
+	switch {
+	case self.accept("pass"), self.accept("break"), self.accept("continue"):
+	case self.accept("return"):
+		if self.peek().kind != newlineToken && !self.is(";") {
+			self.starExpressions()
+		}
+	case self.accept("raise"):
+		if self.peek().kind != newlineToken && !self.is(";") {
+			self.expression()
+			if self.accept("from") {
+				self.expression()
+			}
+		}
+	case self.accept("global"), self.accept("nonlocal"):
+		self.name()
+		for self.accept(",") {
+			self.name()
+		}
+	case self.accept("del"):
+		self.targets()
+	case self.accept("assert"):
+		self.expression()
+		if self.accept(",") {
+			self.expression()
+		}
+	case self.accept("import"):
+		for {
+			self.dottedName()
+			if self.accept("as") {
+				self.name()
+			}
+			if !self.accept(",") {
+				break
+			}
+		}
+	case self.accept("from"):
+		dots := false
+		for self.is(".") || self.is("...") {
+			self.next()
+			dots = true
+		}
+		if !dots || !self.is("import") {
+			self.dottedName()
+		}
+		self.expect("import")
+		if self.accept("*") {
+			break
+		}
+		parenthesized := self.accept("(")
+		for {
+			self.name()
+			if self.accept("as") {
+				self.name()
+			}
+			if !self.accept(",") || (parenthesized && self.is(")")) {
+				break
+			}
+		}
+		if parenthesized {
+			self.expect(")")
+		}
+	default:
+		self.expressionStatement()
+	}
+}
+
+func (self *pythonParser) dottedName() {
+	self.name()
+	for self.accept(".") {
+		self.name()
+	}
+}
+
+var pythonAugmentedAssignments = map[string]bool{
+	"+=": true, "-=": true, "*=": true, "/=": true, "//=": true, "%=": true, "**=": true,
+	">>=": true, "<<=": true, "&=": true, "|=": true, "^=": true, "@=": true,
+}
+
+func (self *pythonParser) expressionStatement() {
+	start := self.peek()
+	assignable := self.starExpressions()
+	switch {
+	case self.is(":"):
+		// annotated assignment
+		if !assignable {
+			self.errorAt(start, "illegal target for annotation")
+		}
+		self.next()
+		self.expression()
+		if self.accept("=") {
+			self.assignedValue()
+		}
+	case self.peek().kind == punctToken && pythonAugmentedAssignments[self.peek().text]:
+		if !assignable {
+			self.errorAt(start, "illegal expression for augmented assignment")
+		}
+		self.next()
+		self.assignedValue()
+	default:
+		for self.is("=") {
+			if !assignable {
+				self.errorAt(start, "cannot assign to expression")
+			}
+			self.next()
+			start = self.peek()
+			assignable = self.assignedValue()
+		}
+	}
+}
+
+func (self *pythonParser) assignedValue() bool {
+	if self.accept("yield") {
+		self.yieldValue()
+		return false
+	}
+	return self.starExpressions()
+}
+
+func (self *pythonParser) yieldValue() {
+	if self.accept("from") {
+		self.expression()
+	} else if self.peek().kind != newlineToken && !self.is(")") && !self.is(";") {
+		self.starExpressions()
+	}
+}
+
+/*
+	Targets of for and del. They are expressions until "in".
+*/
+func (self *pythonParser) targets() {
+	for {
+		self.target()
+		if !self.accept(",") || self.is("in") || self.is("=") || self.peek().kind == newlineToken {
+			break
+		}
+	}
+}
+
+func (self *pythonParser) target() {
+	start := self.peek()
+	self.accept("*")
+	if !self.bitwiseOr() {
+		self.errorAt(start, "cannot assign to expression")
+	}
+}
+
+/*
+	Expressions separated by commas. It returns whether they are assignment targets.
+*/
+func (self *pythonParser) starExpressions() bool {
+	assignable := self.starExpression()
+	if !self.is(",") {
+		return assignable
+	}
+	for self.accept(",") {
+		if self.endOfExpressions() {
+			break
+		}
+		assignable = self.starExpression() && assignable
+	}
+	return assignable
+}
+
+func (self *pythonParser) endOfExpressions() bool {
+	token := self.peek()
+	if token.kind == newlineToken || token.kind == eofToken {
+		return true
+	}
+	switch token.text {
+	case "=", ")", "]", "}", ":", ";", "in":
+		return token.kind == punctToken || token.text == "in"
+	}
+	return token.kind == punctToken && pythonAugmentedAssignments[token.text]
+}
+
+func (self *pythonParser) starExpression() bool {
+	if self.accept("*") {
+		return self.bitwiseOr()
+	}
+	return self.expression()
+}
+
+func (self *pythonParser) expressions() {
+	self.starExpressions()
+}
+
+func (self *pythonParser) namedExpression() bool {
+	if self.isName() && self.peekAt(1).kind == punctToken && self.peekAt(1).text == ":=" {
+		self.next()
+		self.next()
+		self.expression()
+		return false
+	}
+	return self.expression()
+}
+
+/*
+	Expression. It returns whether the expression can be an assignment target.
+*/
+func (self *pythonParser) expression() bool {
+	if self.accept("lambda") {
+		self.parameters(":", false)
+		self.expect(":")
+		self.expression()
+		return false
+	}
+	assignable := self.disjunction()
+	if self.accept("if") {
+		self.disjunction()
+		self.expect("else")
+		self.expression()
+		return false
+	}
+	return assignable
+}
+
+func (self *pythonParser) disjunction() bool {
+	assignable := self.conjunction()
+	for self.accept("or") {
+		self.conjunction()
+		assignable = false
+	}
+	return assignable
+}
+
+func (self *pythonParser) conjunction() bool {
+	assignable := self.inversion()
+	for self.accept("and") {
+		self.inversion()
+		assignable = false
+	}
+	return assignable
+}
+
+func (self *pythonParser) inversion() bool {
+	if self.accept("not") {
+		self.inversion()
+		return false
+	}
+	return self.comparison()
+}
+
+var pythonComparisons = map[string]bool{"<": true, ">": true, "==": true, ">=": true, "<=": true, "!=": true}
+
+func (self *pythonParser) comparison() bool {
+	assignable := self.bitwiseOr()
+	for {
+		token := self.peek()
+		switch {
+		case token.kind == punctToken && pythonComparisons[token.text], self.is("in"):
+			self.next()
+		case self.is("not") && self.peekAt(1).text == "in":
+			self.next()
+			self.next()
+		case self.is("is"):
+			self.next()
+			self.accept("not")
+		default:
+			return assignable
+		}
+		self.bitwiseOr()
+		assignable = false
+	}
+}
+
+var pythonBinaryOperators = [][]string{
+	{"|"}, {"^"}, {"&"}, {"<<", ">>"}, {"+", "-"}, {"*", "/", "//", "%", "@"},
+}
+
+func (self *pythonParser) bitwiseOr() bool {
+	return self.binary(0)
+}
+
+func (self *pythonParser) binary(level int) bool {
+	if level == len(pythonBinaryOperators) {
+		return self.factor()
+	}
+	assignable := self.binary(level + 1)
+	for {
+		token := self.peek()
+		matched := false
+		if token.kind == punctToken {
+			for _, operator := range pythonBinaryOperators[level] {
+				if token.text == operator {
+					matched = true
+				}
+			}
+		}
+		if !matched {
+			return assignable
+		}
+		self.next()
+		self.binary(level + 1)
+		assignable = false
+	}
+}
+
+func (self *pythonParser) factor() bool {
+	if self.accept("+") || self.accept("-") || self.accept("~") {
+		self.factor()
+		return false
+	}
+	return self.power()
+}
+
+func (self *pythonParser) power() bool {
+	assignable := self.primary()
+	if self.accept("**") {
+		self.factor()
+		return false
+	}
+	return assignable
+}
+
+func (self *pythonParser) primary() bool {
+	awaited := self.accept("await")
+	assignable := self.atom()
+	for {
+		switch {
+		case self.accept("."):
+			self.name()
+			assignable = true
+		case self.accept("("):
+			self.arguments(")")
+			assignable = false
+		case self.accept("["):
+			self.subscripts()
+			assignable = true
+		default:
+			return assignable && !awaited
+		}
+	}
+}
+
+func (self *pythonParser) atom() bool {
+	token := self.peek()
+	switch token.kind {
+	case numberToken:
+		self.next()
+		return false
+	case stringToken:
+		bytes := strings.Contains(strings.ToLower(self.stringPrefixOf(token)), "b")
+		for self.peek().kind == stringToken {
+			if strings.Contains(strings.ToLower(self.stringPrefixOf(self.peek())), "b") != bytes {
+				self.errorf("cannot mix bytes and nonbytes literals")
+			}
+			self.next()
+		}
+		return false
+	case identToken:
+		switch token.text {
+		case "None", "True", "False":
+			self.next()
+			return false
+		}
+		self.name()
+		return true
+	}
+	switch {
+	case self.accept("..."):
+		return false
+	case self.accept("("):
+		if self.accept(")") {
+			return false
+		}
+		if self.accept("yield") {
+			self.yieldValue()
+			self.expect(")")
+			return false
+		}
+		assignable := self.starredOrNamed()
+		if self.is("for") || self.is("async") {
+			self.comprehension()
+			self.expect(")")
+			return false
+		}
+		for self.accept(",") {
+			if self.is(")") {
+				break
+			}
+			assignable = self.starredOrNamed() && assignable
+		}
+		self.expect(")")
+		return assignable
+	case self.accept("["):
+		if self.accept("]") {
+			return true
+		}
+		assignable := self.starredOrNamed()
+		if self.is("for") || self.is("async") {
+			self.comprehension()
+			self.expect("]")
+			return false
+		}
+		for self.accept(",") {
+			if self.is("]") {
+				break
+			}
+			assignable = self.starredOrNamed() && assignable
+		}
+		self.expect("]")
+		return assignable
+	case self.accept("{"):
+		self.dictionaryOrSet()
+		return false
+	}
+	self.errorf("invalid syntax: unexpected %s", token)
+	return false
+}
+
+func (self *pythonParser) stringPrefixOf(token token) string {
+	index := strings.IndexAny(token.text, `"'`)
+	return token.text[:index]
+}
+
+func (self *pythonParser) starredOrNamed() bool {
+	if self.accept("*") {
+		return self.bitwiseOr()
+	}
+	return self.namedExpression()
+}
+
+func (self *pythonParser) dictionaryOrSet() {
+	if self.accept("}") {
+		return
+	}
+	dictionary := false
+	if self.accept("**") {
+		self.bitwiseOr()
+		dictionary = true
+	} else {
+		self.starredOrNamed()
+		if self.accept(":") {
+			self.expression()
+			dictionary = true
+		}
+	}
+	if self.is("for") || self.is("async") {
+		self.comprehension()
+		self.expect("}")
+		return
+	}
+	for self.accept(",") {
+		if self.is("}") {
+			break
+		}
+		if dictionary {
+			if self.accept("**") {
+				self.bitwiseOr()
+				continue
+			}
+			self.expression()
+			self.expect(":")
+			self.expression()
+		} else {
+			self.starredOrNamed()
+		}
+	}
+	self.expect("}")
+}
+
+func (self *pythonParser) comprehension() {
+	for self.is("for") || self.is("async") {
+		self.accept("async")
+		self.expect("for")
+		self.targets()
+		self.expect("in")
+		self.disjunction()
+		for self.accept("if") {
+			self.disjunction()
+		}
+	}
+}
+
+/*
+	Arguments of calls and base classes.
+*/
+func (self *pythonParser) arguments(end string) {
+	keywords := false
+	count := 0
+	for !self.is(end) {
+		switch {
+		case self.accept("**"):
+			self.expression()
+			keywords = true
+		case self.accept("*"):
+			self.expression()
+		case self.isName() && self.peekAt(1).kind == punctToken && self.peekAt(1).text == "=":
+			self.next()
+			self.next()
+			self.expression()
+			keywords = true
+		default:
+			if keywords {
+				self.errorf("positional argument follows keyword argument")
+			}
+			self.namedExpression()
+			if self.is("for") || self.is("async") {
+				if count > 0 || self.peekAt(0).text == "," {
+					self.errorf("generator expression must be parenthesized")
+				}
+				self.comprehension()
+			}
+		}
+		count++
+		if !self.accept(",") {
+			break
+		}
+	}
+	self.expect(end)
+}
+
+func (self *pythonParser) subscripts() {
+	for {
+		if !self.is(":") {
+			self.starredOrNamed()
+		}
+		if self.accept(":") {
+			if !self.is(":") && !self.is(",") && !self.is("]") {
+				self.expression()
+			}
+			if self.accept(":") && !self.is(",") && !self.is("]") {
+				self.expression()
+			}
+		}
+		if !self.accept(",") || self.is("]") {
+			break
+		}
+	}
+	self.expect("]")
+}
@@ -0,0 +1,61 @@
+package syntax_test
+
+import (
+	"github.com/shibukawa/curl_as_dsl/syntax"
+	. "gopkg.in/check.v1"
+)
+
+type PythonTest struct{}
+
+var _ = Suite(&PythonTest{})
+
+func (s *PythonTest) Test_Valid(c *C) {
+	checkValid(c, syntax.CheckPython, []string{
+		"",
+		"# comment only\n",
+		"import http.client, urllib.parse as parse\nfrom os import path\nfrom . import a\nfrom ..b import (c, d as e,)\n",
+		"def main(a, b=1, *args, c: int = 2, **kwargs) -> None:\n    pass\n\nif __name__ == '__main__':\n    main()\n",
+		"async def main():\n    async with httpx.AsyncClient() as client:\n        r = await client.get(url)\n\nasyncio.run(main())",
+		"headers = {\n        \"A\": \"b\",\n        'C': r'd\\e',\n    }\nvalues = urllib.parse.urlencode({\n        \"q\": \"a b\",\n    })\n",
+		"body = '''multi\nline'''\ns = \"a\" 'b' r\"c\"\nb = b'x' rb'y'\n",
+		"x = f'{a!r:>10} {b[\"k\"]} {{literal}} {c + d}'\n",
+		"x = [i * 2 for i in range(10) if i % 2]\ny = {k: v for k, v in items}\nz = (a for a in b)\nprint(*args, sep='', **kw)\n",
+		"try:\n    a()\nexcept (ValueError, KeyError) as e:\n    raise RuntimeError() from e\nelse:\n    pass\nfinally:\n    b()\n",
+		"with open('a') as f, open('b') as g:\n    data = f.read()\n",
+		"class A(B, metaclass=M):\n    x: int = 1\n\n    @property\n    def y(self):\n        return self.x\n",
+		"for i, (a, b) in enumerate(pairs):\n    continue\nelse:\n    pass\nwhile x:\n    break\n",
+		"a, *b = c\na[1:2], a[::2], a[:, 1] = 1, 2, 3\nx += 1\nf = lambda a, b=2: a + b\ny = a if b else c\n",
+		"if (n := len(a)) > 10: print(n)\nz = not a and b or c is not None and d not in e\n",
+		"x = 1 + \\\n    2\ny = (1 +\n     2)\n",
+		"def f():\n\tif a:\n\t\treturn\n\treturn 1\n",
+		"print(response.status, response.reason); print(body.decode('utf-8'))\n",
+	})
+}
+
+func (s *PythonTest) Test_Invalid(c *C) {
+	checkInvalid(c, syntax.CheckPython, []invalidCode{
+		{"a = 'abc\n", 1, 5},
+		{"a = '''abc\n", 1, 5},
+		{"values = urllib.parse.urlencode({\n        \"a\": \"b\",\n, \"        \"q\": \"a b\",\n    })\n", 3, 20},
+		{"headers = {\n    \"X\": \"he said \"hi\"\",\n}\n", 2, 20},
+		{"if a\n    b()\n", 1, 5},
+		{"def f():\nreturn 1\n", 2, 1},
+		{"def f():\n    a = 1\n      b = 2\n", 3, 7},
+		{"def f():\n        a = 1\n    b = 2\n", 3, 5},
+		{"a = (1, 2\n", 2, 1},
+		{"a = 1)\n", 1, 6},
+		{"a = [1, 2)\n", 1, 10},
+		{"f() = 1\n", 1, 1},
+		{"a + 1 += 2\n", 1, 1},
+		{"f(a=1, 2)\n", 1, 8},
+		{"def f(a=1, b):\n    pass\n", 1, 12},
+		{"try:\n    pass\n", 3, 1},
+		{"x = f'{a'\n", 1, 7},
+		{"x = f'{}'\n", 1, 7},
+		{"x = 'a' b'c'\n", 1, 9},
+		{"x = 1 \\ 2\n", 1, 7},
+		{"print 'hello'\n", 1, 7},
+		{"x = 10L\n", 1, 5},
+		{"x = $a\n", 1, 5},
+	})
+}
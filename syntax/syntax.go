@@ -0,0 +1,325 @@
+/*
+	Syntax checkers of generated code. They parse the code and report the first syntax error.
+	They don't check names, types or imports. They are not full parsers of the languages and
+	accept only syntax that generated code may use.
+*/
+package syntax
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+/*
+	Syntax error at the line and the column (both start from 1).
+*/
+type Error struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (self *Error) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", self.Line, self.Column, self.Message)
+}
+
+/*
+	The line of the error and a caret under the column. It is shown with the error.
+*/
+func (self *Error) Excerpt(source string) string {
+	lines := strings.Split(source, "\n")
+	if self.Line < 1 || self.Line > len(lines) {
+		return ""
+	}
+	line := strings.Replace(lines[self.Line-1], "\t", " ", -1)
+	column := self.Column - 1
+	if column > len(line) {
+		column = len(line)
+	}
+	return line + "\n" + strings.Repeat(" ", column) + "^"
+}
+
+type tokenKind int
+
+const (
+	eofToken tokenKind = iota
+	identToken
+	numberToken
+	stringToken
+	punctToken
+	// JavaScript
+	regexpToken
+	templateToken     // `text` without substitutions
+	templateHeadToken // `text${
+	templateMiddleToken
+	templateTailToken
+	// Java
+	charToken
+	// Python
+	newlineToken
+	indentToken
+	dedentToken
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	line   int
+	column int
+	// JavaScript: a line terminator is between the token and the previous one
+	newline bool
+	// Java: no space between the token and the previous one
+	adjacent bool
+}
+
+func (self token) String() string {
+	switch self.kind {
+	case eofToken:
+		return "end of file"
+	case newlineToken:
+		return "end of line"
+	case indentToken:
+		return "indent"
+	case dedentToken:
+		return "dedent"
+	}
+	if len(self.text) > 20 {
+		return "'" + self.text[:20] + "...'"
+	}
+	return "'" + self.text + "'"
+}
+
+/*
+	Scanner of source code. Lexers of the languages use it.
+*/
+type scanner struct {
+	source string
+	offset int
+	line   int
+	column int
+}
+
+func newScanner(source string) *scanner {
+	return &scanner{source: source, line: 1, column: 1}
+}
+
+func (self *scanner) eof() bool {
+	return self.offset >= len(self.source)
+}
+
+func (self *scanner) peek(n int) byte {
+	if self.offset+n >= len(self.source) {
+		return 0
+	}
+	return self.source[self.offset+n]
+}
+
+func (self *scanner) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(self.source[self.offset:], prefix)
+}
+
+func (self *scanner) advance(n int) {
+	for i := 0; i < n && self.offset < len(self.source); i++ {
+		if self.source[self.offset] == '\n' {
+			self.line++
+			self.column = 1
+		} else if self.source[self.offset]&0xC0 != 0x80 {
+			// count characters, not bytes of UTF-8
+			self.column++
+		}
+		self.offset++
+	}
+	// column of the first byte was counted already
+	for self.offset < len(self.source) && self.source[self.offset]&0xC0 == 0x80 {
+		self.offset++
+	}
+}
+
+func (self *scanner) errorf(format string, args ...interface{}) {
+	panic(&Error{Line: self.line, Column: self.column, Message: fmt.Sprintf(format, args...)})
+}
+
+/*
+	Longest punctuator at the current position.
+*/
+func (self *scanner) punctuator(punctuators []string) string {
+	for _, punctuator := range punctuators {
+		if self.hasPrefix(punctuator) {
+			return punctuator
+		}
+	}
+	return ""
+}
+
+func isIdentStart(ch byte) bool {
+	return ch == '_' || ch == '$' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || ch >= 0x80
+}
+
+func isIdentPart(ch byte) bool {
+	return isIdentStart(ch) || (ch >= '0' && ch <= '9')
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+var numberPattern = regexp.MustCompile(`^(?:0[xX][0-9a-fA-F_]+|0[oO][0-7_]+|0[bB][01_]+|(?:[0-9][0-9_]*(?:\.[0-9_]*)?|\.[0-9][0-9_]*)(?:[eE][+-]?[0-9_]+)?)[a-zA-Z]*`)
+
+/*
+	Number literal. Suffixes like "L", "n" or "j" are checked by lexers.
+*/
+func (self *scanner) number() string {
+	text := numberPattern.FindString(self.source[self.offset:])
+	if text == "" {
+		self.errorf("invalid number")
+	}
+	self.advance(len(text))
+	return text
+}
+
+func (self *scanner) ident() string {
+	start := self.offset
+	for !self.eof() && isIdentPart(self.source[self.offset]) {
+		self.advance(1)
+	}
+	return self.source[start:self.offset]
+}
+
+/*
+	Parser base. Parsers of the languages embed it.
+*/
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (self *parser) peek() token {
+	return self.tokens[self.pos]
+}
+
+func (self *parser) peekAt(n int) token {
+	if self.pos+n >= len(self.tokens) {
+		return self.tokens[len(self.tokens)-1]
+	}
+	return self.tokens[self.pos+n]
+}
+
+func (self *parser) next() token {
+	result := self.tokens[self.pos]
+	if result.kind != eofToken {
+		self.pos++
+	}
+	return result
+}
+
+/*
+	The next token is the punctuator or the keyword.
+*/
+func (self *parser) is(text string) bool {
+	token := self.tokens[self.pos]
+	return (token.kind == punctToken || token.kind == identToken) && token.text == text
+}
+
+func (self *parser) accept(text string) bool {
+	if self.is(text) {
+		self.pos++
+		return true
+	}
+	return false
+}
+
+func (self *parser) expect(text string) token {
+	if !self.is(text) {
+		self.errorf("expected '%s' but found %s", text, self.peek())
+	}
+	return self.next()
+}
+
+func (self *parser) errorf(format string, args ...interface{}) {
+	self.errorAt(self.peek(), format, args...)
+}
+
+func (self *parser) errorAt(token token, format string, args ...interface{}) {
+	panic(&Error{Line: token.line, Column: token.column, Message: fmt.Sprintf(format, args...)})
+}
+
+/*
+	Run parse and return the first syntax error.
+*/
+func check(parse func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if syntaxError, ok := r.(*Error); ok {
+				err = syntaxError
+				return
+			}
+			panic(r)
+		}
+	}()
+	parse()
+	return nil
+}
+
+/*
+	Try parse and restore the position when it fails. It is used where the grammar needs lookahead
+	(e.g. casts and declarations of Java).
+*/
+func (self *parser) try(parse func()) (ok bool) {
+	pos := self.pos
+	defer func() {
+		if r := recover(); r != nil {
+			if _, isError := r.(*Error); !isError {
+				panic(r)
+			}
+			self.pos = pos
+			ok = false
+		}
+	}()
+	parse()
+	return true
+}
+
+/*
+	Position of the token that closes the bracket at the current position, or -1.
+*/
+func (self *parser) matchingBracket() int {
+	depth := 0
+	for i := self.pos; i < len(self.tokens); i++ {
+		token := self.tokens[i]
+		if token.kind != punctToken {
+			continue
+		}
+		switch token.text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+var scriptPattern = regexp.MustCompile(`(?is)<script[^>]*>(.*?)</script>`)
+
+/*
+	Check JavaScript in <script> elements of HTML. Lines of errors are lines of the HTML.
+*/
+func CheckHTMLScripts(source string) error {
+	for _, match := range scriptPattern.FindAllStringSubmatchIndex(source, -1) {
+		script := source[match[2]:match[3]]
+		if err := CheckJavaScript(script); err != nil {
+			syntaxError := err.(*Error)
+			prefix := source[:match[2]]
+			if syntaxError.Line == 1 {
+				syntaxError.Column += len(prefix) - strings.LastIndexByte(prefix, '\n') - 1
+			}
+			syntaxError.Line += strings.Count(prefix, "\n")
+			return syntaxError
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package syntax_test
+
+import (
+	"github.com/shibukawa/curl_as_dsl/syntax"
+	. "gopkg.in/check.v1"
+)
+
+type SyntaxTest struct{}
+
+var _ = Suite(&SyntaxTest{})
+
+/*
+	Invalid code and the position of the error.
+*/
+type invalidCode struct {
+	source string
+	line   int
+	column int
+}
+
+func checkInvalid(c *C, check func(string) error, codes []invalidCode) {
+	for _, code := range codes {
+		err := check(code.source)
+		if !c.Check(err, NotNil, Commentf("%s", code.source)) {
+			continue
+		}
+		syntaxError := err.(*syntax.Error)
+		c.Check([]int{syntaxError.Line, syntaxError.Column}, DeepEquals, []int{code.line, code.column}, Commentf("%s: %s", code.source, err))
+	}
+}
+
+func checkValid(c *C, check func(string) error, codes []string) {
+	for _, code := range codes {
+		c.Check(check(code), IsNil, Commentf("%s", code))
+	}
+}
+
+func (s *SyntaxTest) Test_Excerpt(c *C) {
+	err := &syntax.Error{Line: 2, Column: 5, Message: "unexpected token"}
+	c.Check(err.Error(), Equals, "line 2, column 5: unexpected token")
+	c.Check(err.Excerpt("first\n\tabc def\nthird"), Equals, " abc def\n    ^")
+	c.Check(err.Excerpt("one line"), Equals, "")
+}
+
+func (s *SyntaxTest) Test_CheckHTMLScripts(c *C) {
+	checkValid(c, syntax.CheckHTMLScripts, []string{
+		"<html><body>no script</body></html>",
+		"<html>\n<script src=\"a.js\"></script>\n<script>\nvar a = 1;\n</script>\n</html>",
+	})
+	checkInvalid(c, syntax.CheckHTMLScripts, []invalidCode{
+		{"<html>\n<script>\nvar a = \"1;\n</script>\n</html>", 3, 9},
+		{"<html>\n  <script>var = 1;</script>\n</html>", 2, 15},
+	})
+}
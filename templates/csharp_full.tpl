@@ -0,0 +1,8 @@
+{{ .Usings }}
+{{ .Prepare }}{{ .Handler }}{{ .Client }}
+var request = new HttpRequestMessage({{ .Method }}, {{ .Url }});
+{{ .ModifyRequest }}
+using var response = await client.SendAsync(request);
+{{ .SaveCookies }}Console.WriteLine($"Response: {(int)response.StatusCode} {response.ReasonPhrase}");
+Console.WriteLine(await response.Content.ReadAsStringAsync());
+{{ .AdditionalDeclaration }}
\ No newline at end of file
@@ -0,0 +1,25 @@
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>fetch Test</title>
+</head>
+<body>{{ range $_, $input := .FileInputs }}
+<p><label>{{ $input.FileName }}: <input type="file" id="{{ $input.Id }}"></label></p>{{end}}
+<button id="send">Send</button>
+<pre id="result"></pre>
+<script type="module">
+{{ .Declaration }}async function request() {
+    {{ .Prepare }}const response = await fetch({{ .Url }}{{ .Init }});
+    const body = await response.text();{{ .TearDown }}
+    document.getElementById("result").textContent = `Response: ${response.status} ${response.statusText}\n${body}`;
+}
+
+document.getElementById("send").addEventListener("click", () => {
+    request().catch((error) => {
+        document.getElementById("result").textContent = String(error);
+    });
+});
+</script>
+</body>
+</html>
\ No newline at end of file
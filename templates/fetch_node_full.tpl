@@ -0,0 +1,3 @@
+{{ .Dependencies }}{{ .Imports }}{{ .Declaration }}{{ .Prepare }}{{ .Let }} response = await fetch({{ .Url }}{{ .Init }});
+{{ .Authenticate }}{{ .SaveCookies }}console.log(`Response: ${response.status} ${response.statusText}`);
+console.log(await response.text());{{ .TearDown }}
\ No newline at end of file
@@ -0,0 +1,17 @@
+{{ .Dependencies }}{{ .Imports }}{{ .Declaration }}{{ range $i, $_ := .Functions }}{{ if $i }}
+{{ end }}// {{ .Title }}
+export async function {{ .Name }}({{ .Arguments }}){{ .ReturnType }} {
+    {{ .Prepare }}{{ .Let }} response = await fetch({{ .Url }}{{ .Init }});
+    {{ .Authenticate }}{{ .SaveCookies }}return response;
+}
+{{ end }}{{ if .HasMain }}
+if (import.meta.url === pathToFileURL(process.argv[1]).href) {
+    for (const send of [
+{{ range .Functions }}        {{ .Sender }},
+{{ end }}    ]) {
+        const response = await send();
+        console.log(`Response: ${response.status} ${response.statusText}`);
+        console.log(await response.text());
+    }
+}
+{{ end }}
\ No newline at end of file
@@ -8,16 +8,16 @@ func main() {
     {{ .PrepareClient }}
     client := &http.Client{{ .ClientBody }}
     {{ .Data }}
-    request, err := http.NewRequest("{{ .Method }}", {{ .Url }}, {{ .DataVariable }})
+    request, err := http.NewRequest({{ .Method }}, {{ .Url }}, {{ .DataVariable }})
     {{ .ModifyRequest }}
     resp, err := client.Do(request)
     if err != nil {
         log.Fatal(err)
     }
-    defer resp.Body.Close()
+    {{ .Authenticate }}defer resp.Body.Close()
     body, err := ioutil.ReadAll(resp.Body)
     if err != nil {
         log.Fatal(err)
     }
-    log.Print(string(body))
+    log.Print(string(body)){{ .SaveCookies }}
 }
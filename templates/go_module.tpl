@@ -0,0 +1,46 @@
+package {{ .Package }}
+
+import (
+{{ range $key, $_ := .Modules }}    "{{ $key }}"
+{{end}})
+{{ .AdditionalDeclaration }}
+// NewClient creates the client shared by all requests.
+func NewClient() *http.Client {
+    {{ .Client.PrepareClient }}
+    return &http.Client{{ .Client.ClientBody }}
+}
+{{ range .Functions }}
+{{ .Comment }}
+func {{ .Name }}(ctx context.Context, client *http.Client{{ .Arguments }}) (*http.Response, error) {
+    {{ .Data }}
+    request, err := http.NewRequestWithContext(ctx, {{ .Method }}, {{ .Url }}, {{ .DataVariable }})
+    if err != nil {
+        return nil, err
+    }
+    {{ .ModifyRequest }}
+    resp, err := client.Do(request)
+    if err != nil {
+        return nil, err
+    }
+    {{ .Authenticate }}{{ .SaveCookies }}
+    return resp, nil
+}
+{{ end }}{{ if .HasMain }}
+func main() {
+    client := NewClient()
+    for _, send := range []func(context.Context, *http.Client) (*http.Response, error){
+{{ range .Functions }}        {{ .Sender }},
+{{ end }}    } {
+        resp, err := send(context.Background(), client)
+        if err != nil {
+            log.Fatal(err)
+        }
+        body, err := ioutil.ReadAll(resp.Body)
+        resp.Body.Close()
+        if err != nil {
+            log.Fatal(err)
+        }
+        log.Print(string(body))
+    }
+}
+{{ end }}
\ No newline at end of file
@@ -8,11 +8,11 @@ import (
 )
 
 func main() {
-    file, err := os.Open("{{ .FilePath }}")
+    file, err := os.Open({{ .FilePath }})
     if err != nil {
         log.Fatal(err)
     }
-    resp, err := http.Post({{ .Url }}, "{{ .ContentType }}", file)
+    resp, err := http.Post({{ .Url }}, {{ .ContentType }}, file)
     if err != nil {
         log.Fatal(err)
     }
@@ -7,7 +7,7 @@ public class Main { {{ .AdditionalDeclaration }}
             {{ .CommonInitialize }}{{ .PrepareBody }}URL url = new URL({{ .Url }});
 
             {{ .ConnectionClass }} conn = ({{ .ConnectionClass }})url.openConnection({{ .Proxy }});
-{{ .PrepareConnection }}
+{{ .PrepareConnection }}{{ .Authenticate }}
             System.out.printf("Response: %d %s\n", conn.getResponseCode(), conn.getResponseMessage());
             BufferedReader br = new BufferedReader(new InputStreamReader(conn.getInputStream()));
             String input;
@@ -15,7 +15,7 @@ public class Main { {{ .AdditionalDeclaration }}
             while ((input = br.readLine()) != null) {
                 System.out.println(input);
             }
-            br.close();
+            br.close();{{ .SaveCookies }}
         } catch (MalformedURLException e) {
             e.printStackTrace();
         } catch (IOException e) {
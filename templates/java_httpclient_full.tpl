@@ -0,0 +1,13 @@
+{{ range $key, $_ := .Modules }}import {{ $key }};
+{{end}}
+
+public class Main { {{ .AdditionalDeclaration }}
+    public static void main(String[] args) throws Exception {
+        {{ .Prepare }}HttpClient client = HttpClient.newBuilder(){{ .ClientBuilder }}
+                .build();
+        HttpRequest.Builder request = HttpRequest.newBuilder(URI.create({{ .Url }})){{ .RequestBuilder }};
+        HttpResponse<String> response = client.send(request.build(), HttpResponse.BodyHandlers.ofString());
+{{ .Authenticate }}        System.out.printf("Response: %d\n", response.statusCode());
+        System.out.println(response.body());{{ .SaveCookies }}
+    }
+}
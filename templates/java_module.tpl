@@ -0,0 +1,30 @@
+{{ if .Package }}package {{ .Package }};
+
+{{ end }}{{ range $key, $_ := .Modules }}import {{ $key }};
+{{end}}
+
+public class Main { {{ .AdditionalDeclaration }}{{ range .Functions }}
+    // {{ .Title }}
+    public static HttpURLConnection {{ .Name }}({{ .Arguments }}) throws IOException {
+        {{ .CommonInitialize }}{{ .PrepareBody }}URL url = new URL({{ .Url }});
+
+        {{ .ConnectionClass }} conn = ({{ .ConnectionClass }})url.openConnection({{ .Proxy }});
+{{ .PrepareConnection }}{{ .Authenticate }}{{ .SaveCookies }}
+        return conn;
+    }
+{{ end }}{{ if .HasMain }}
+    public static void main(String[] args) throws IOException {
+{{ range .Functions }}        print({{ .Sender }});
+{{ end }}    }
+
+    static void print(HttpURLConnection conn) throws IOException {
+        System.out.printf("Response: %d %s\n", conn.getResponseCode(), conn.getResponseMessage());
+        BufferedReader br = new BufferedReader(new InputStreamReader(conn.getInputStream()));
+        String input;
+
+        while ((input = br.readLine()) != null) {
+            System.out.println(input);
+        }
+        br.close();
+    }
+{{ end }}}
@@ -0,0 +1,15 @@
+// build.gradle.kts
+// dependencies {
+{{ .Dependencies }}// }
+{{ range $key, $_ := .Modules }}import {{ $key }}
+{{end}}{{ .AdditionalDeclaration }}
+fun main() {
+    {{ .Prepare }}val client = {{ .Client }}
+    val request = Request.Builder()
+        .url({{ .Url }}){{ .RequestBuilder }}
+        .build()
+    client.newCall(request).execute().use { response ->
+        println("Response: ${response.code} ${response.message}")
+        println(response.body!!.string())
+    }{{ .SaveCookies }}
+}
@@ -3,18 +3,18 @@ var fs = require("fs");
 {{end}}{{ .AdditionalDeclaration }}
 Promise.all([
 {{ range $i, $externalFile := .ExternalFiles }}    new Promise(function (success, reject) {
-        fs.readFile("{{$externalFile.FileName}}"{{if $externalFile.TextType }}, {encoding: "utf8"}{{end}}, function (err, data) {
+        fs.readFile({{$externalFile.FileName}}{{if $externalFile.TextType }}, {encoding: "utf8"}{{end}}, function (err, data) {
             if (err) { reject(err); } else { success(data); }
         });
     }),
 {{end}}]).then(function (fileContents) {
     {{ .PrepareBody }}var req = {{ .ClientModule }}.request({
-        host: "{{ .Host }}",
+        host: {{ .Host }},
         path: {{ .Path }},{{if ne .Port 0}}
         port: {{ .Port }},{{end}}
-        method: "{{ .Method }}",{{ .PrepareOptions }}
-    }, function(res) {
-        console.log("Got response: " + res.statusCode + " " + res.statusMessage);
+        method: {{ .Method }},{{ .PrepareOptions }}
+    }, function{{ .ResponseHandlerName }}(res) {
+        {{ .Authenticate }}{{ .StoreCookies }}console.log("Got response: " + res.statusCode + " " + res.statusMessage);
         res.on('data', function (chunk) {
             console.log('BODY: ' + chunk);
         });{{ .TearDown }}
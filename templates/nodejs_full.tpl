@@ -1,12 +1,12 @@
 {{ range $key, $_ := .Modules }}var {{ $key }} = require("{{ $key }}");
 {{end}}{{ .AdditionalDeclaration }}
 {{ .PrepareBody }}var req = {{ .ClientModule }}.request({
-    host: "{{ .Host }}",
+    host: {{ .Host }},
     path: {{ .Path }},{{if ne .Port 0}}
     port: {{ .Port }},{{end}}
-    method: "{{ .Method }}",{{ .PrepareOptions }}
-}, function(res) {
-    console.log("Got response: " + res.statusCode + " " + res.statusMessage);
+    method: {{ .Method }},{{ .PrepareOptions }}
+}, function{{ .ResponseHandlerName }}(res) {
+    {{ .Authenticate }}{{ .StoreCookies }}console.log("Got response: " + res.statusCode + " " + res.statusMessage);
     res.on('data', function (chunk) {
         console.log('BODY: ' + chunk);
     });{{ .TearDown }}
@@ -21,7 +21,16 @@ BOOL shouldKeepRunning = YES;
     }
 }
 
-- (void)connection:(NSURLConnection *)connection didReceiveData:(NSData *)data
+{{if .Options.UseDigestAuth }}- (void)connection:(NSURLConnection *)connection willSendRequestForAuthenticationChallenge:(NSURLAuthenticationChallenge *)challenge
+{
+    if ([challenge.protectionSpace.authenticationMethod isEqualToString:NSURLAuthenticationMethodHTTPDigest] && [challenge previousFailureCount] == 0) {
+        [[challenge sender] useCredential:{{ .Credential }} forAuthenticationChallenge:challenge];
+    } else {
+        [[challenge sender] performDefaultHandlingForAuthenticationChallenge:challenge];
+    }
+}
+
+{{end}}- (void)connection:(NSURLConnection *)connection didReceiveData:(NSData *)data
 {
     [contents appendData:data];
 }
@@ -45,6 +54,6 @@ int main(int argc, char *argv[]) {
         if (!connection) NSLog(@"failed to create connection");
         
         NSRunLoop *theRL = [NSRunLoop currentRunLoop];
-        while (shouldKeepRunning && [theRL runMode:NSDefaultRunLoopMode beforeDate:[NSDate distantFuture]]);
+        while (shouldKeepRunning && [theRL runMode:NSDefaultRunLoopMode beforeDate:[NSDate distantFuture]]);{{ .SaveCookies }}
     }
 }
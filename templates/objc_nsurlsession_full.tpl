@@ -1,13 +1,30 @@
 {{ range $key, $_ := .Modules }}#import <{{ $key }}>
 {{end}}
 BOOL shouldKeepRunning = YES;
-{{ .AdditionalDeclaration }}
+{{if .Options.UseDigestAuth }}
+@interface DigestAuthDelegate : NSObject<NSURLSessionTaskDelegate>
+@end
+
+@implementation DigestAuthDelegate
+
+- (void)URLSession:(NSURLSession *)session task:(NSURLSessionTask *)task didReceiveChallenge:(NSURLAuthenticationChallenge *)challenge completionHandler:(void (^)(NSURLSessionAuthChallengeDisposition, NSURLCredential *))completionHandler
+{
+    if ([challenge.protectionSpace.authenticationMethod isEqualToString:NSURLAuthenticationMethodHTTPDigest] && [challenge previousFailureCount] == 0) {
+        completionHandler(NSURLSessionAuthChallengeUseCredential, {{ .Credential }});
+    } else {
+        completionHandler(NSURLSessionAuthChallengePerformDefaultHandling, nil);
+    }
+}
+
+@end
+{{end}}{{ .AdditionalDeclaration }}
 int main(int argc, char *argv[]) {
     @autoreleasepool {
         {{ .CommonInitialize }}{{ .PrepareBody }}NSMutableURLRequest *request = [NSMutableURLRequest requestWithURL:[NSURL URLWithString:{{ .Url }}]];
 {{ .ModifyRequest }}
-        NSURLSession *session = [NSURLSession sharedSession];
-        NSURLSessionDataTask* task = [session dataTaskWithRequest:request completionHandler:^(NSData *data, NSURLResponse *response, NSError *error) {
+{{if .Options.UseDigestAuth }}        NSURLSession *session = [NSURLSession sessionWithConfiguration:[NSURLSessionConfiguration defaultSessionConfiguration] delegate:[[DigestAuthDelegate alloc] init] delegateQueue:nil];
+{{else}}        NSURLSession *session = [NSURLSession sharedSession];
+{{end}}        NSURLSessionDataTask* task = [session dataTaskWithRequest:request completionHandler:^(NSData *data, NSURLResponse *response, NSError *error) {
             NSHTTPURLResponse *httpResponse = (NSHTTPURLResponse *)response;
             NSLog(@"Status: %ld", httpResponse.statusCode);
             NSDictionary *headers = httpResponse.allHeaderFields;
@@ -23,6 +40,6 @@ int main(int argc, char *argv[]) {
         [task resume];
 
         NSRunLoop *theRL = [NSRunLoop currentRunLoop];
-        while (shouldKeepRunning && [theRL runMode:NSDefaultRunLoopMode beforeDate:[NSDate distantFuture]]);
+        while (shouldKeepRunning && [theRL runMode:NSDefaultRunLoopMode beforeDate:[NSDate distantFuture]]);{{ .SaveCookies }}
     }
 }
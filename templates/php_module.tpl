@@ -0,0 +1,24 @@
+<?php{{ .AdditionalDeclaration }}{{ range .Functions }}
+// {{ .Title }}
+function {{ .Name }}({{ .Arguments }}) {
+  {{ .Prepare }}$ctx = stream_context_create([
+    "http" => [
+      "method" => {{ .Method }}{{ .ContextOptions }}
+    ]
+  ]);
+  $fp = fopen({{ .Url }}, "r", false, $ctx);
+  {{ .Authenticate }}{{ .SaveCookies }}return $fp;
+}
+{{ end }}{{ if .HasMain }}
+if (realpath($_SERVER["SCRIPT_FILENAME"]) == __FILE__) {
+  foreach ([
+{{ range .Functions }}    {{ .Sender }},
+{{ end }}  ] as $send) {
+    $fp = $send();
+    if ($fp === false)
+      continue;
+    var_dump(stream_get_meta_data($fp));
+    var_dump(stream_get_contents($fp));
+  }
+}
+{{ end }}
\ No newline at end of file
@@ -1,11 +1,11 @@
 {{ range $key, $_ := .Modules }}import {{ $key }}
 {{end}}{{ .AdditionalDeclaration }}
 def main():
-    conn = http.client.{{ .ConnectionClass }}("{{ .Host }}")
+    conn = http.client.{{ .ConnectionClass }}({{ .Host }})
     {{ .Proxy }}{{ .PrepareBody }}{{ .PrepareHeader }}
-    conn.request("{{ .Method }}", {{ .Path }}{{if .HasBody}}, body={{ .Body }}{{end}}{{if .HasHeader}}, headers={{ .Header }}{{end}})
+    {{ .PrepareCookie }}{{ .Request }}
     res = conn.getresponse()
-    print(res.status, res.reason)
+    {{ .Authenticate }}{{ .SaveCookie }}print(res.status, res.reason)
     print(res.read())
     conn.close()
 
@@ -0,0 +1,11 @@
+import asyncio
+{{ range $key, $_ := .Modules }}import {{ $key }}
+{{end}}{{ .AdditionalDeclaration }}
+async def main():
+    {{ .Prepare }}async with httpx.AsyncClient({{ .ClientArguments }}) as client:
+        {{ .PrepareClient }}res = await {{ .Request }}
+    {{ .SaveCookie }}print(res.status_code, res.reason_phrase)
+    print(res.text)
+
+if __name__ == "__main__":
+    asyncio.run(main())
@@ -0,0 +1,10 @@
+{{ range $key, $_ := .Modules }}import {{ $key }}
+{{end}}{{ .AdditionalDeclaration }}
+def main():
+    {{ .Prepare }}with httpx.Client({{ .ClientArguments }}) as client:
+        {{ .PrepareClient }}res = {{ .Request }}
+    {{ .SaveCookie }}print(res.status_code, res.reason_phrase)
+    print(res.text)
+
+if __name__ == "__main__":
+    main()
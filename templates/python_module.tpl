@@ -0,0 +1,24 @@
+{{ range $key, $_ := .Modules }}import {{ $key }}
+{{end}}{{ .AdditionalDeclaration }}{{ range .Functions }}
+
+# {{ .Title }}
+def {{ .Name }}({{ .Arguments }}):
+    {{ .PrepareBody }}{{ .PrepareHeader }}
+    {{ .PrepareCookie }}{{ .Request }}
+    res = conn.getresponse()
+    {{ .Authenticate }}{{ .SaveCookie }}return res
+{{ end }}{{ if .HasMain }}
+
+def main():
+{{ range .Connections }}    {{ .Name }} = http.client.{{ .Class }}({{ .Host }})
+{{ if .Tunnel }}    {{ .Name }}.set_tunnel({{ .Tunnel }})
+{{ end }}{{ end }}    for request in [
+{{ range .Functions }}        {{ .Sender }},
+{{ end }}    ]:
+        res = request()
+        print(res.status, res.reason)
+        print(res.read())
+{{ range .Connections }}    {{ .Name }}.close()
+{{ end }}
+if __name__ == "__main__":
+    main(){{ end }}
\ No newline at end of file
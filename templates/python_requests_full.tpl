@@ -0,0 +1,9 @@
+{{ range $key, $_ := .Modules }}import {{ $key }}
+{{end}}{{ .AdditionalDeclaration }}
+def main():
+    {{ .Prepare }}res = {{ .Request }}
+    {{ .SaveCookie }}print(res.status_code, res.reason)
+    print(res.text)
+
+if __name__ == "__main__":
+    main()
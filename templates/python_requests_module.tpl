@@ -0,0 +1,20 @@
+{{ range $key, $_ := .Modules }}import {{ $key }}
+{{end}}{{ range .Functions }}
+
+# {{ .Title }}
+def {{ .Name }}({{ .Arguments }}):
+    {{ .Prepare }}res = {{ .Request }}
+    {{ .SaveCookie }}return res
+{{ end }}{{ if .HasMain }}
+
+def main():
+    session = requests.Session()
+    for request in [
+{{ range .Functions }}        {{ .Sender }},
+{{ end }}    ]:
+        res = request(session)
+        print(res.status_code, res.reason)
+        print(res.text)
+
+if __name__ == "__main__":
+    main(){{ end }}
\ No newline at end of file
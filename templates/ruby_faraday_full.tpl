@@ -0,0 +1,5 @@
+{{ .Requires }}{{ .AdditionalDeclaration }}
+{{ .Prepare }}conn = Faraday.new({{ .ConnectionArguments }}){{ .Middleware }}
+{{ .PrepareRequest }}response = {{ .Request }}
+{{ .Authenticate }}{{ .SaveCookies }}puts "#{response.status} #{response.reason_phrase}"
+puts response.body
\ No newline at end of file
@@ -0,0 +1,9 @@
+{{ .Requires }}{{ .AdditionalDeclaration }}
+uri = URI({{ .Url }})
+{{ .Prepare }}request = {{ .NewRequest }}
+{{ .ModifyRequest }}
+Net::HTTP.start({{ .StartArguments }}) do |http|
+  response = http.request(request)
+  {{ .Authenticate }}{{ .SaveCookies }}puts "#{response.code} #{response.message}"
+  puts response.body
+end
\ No newline at end of file
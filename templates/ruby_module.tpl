@@ -0,0 +1,21 @@
+{{ .Requires }}{{ .AdditionalDeclaration }}{{ range .Functions }}
+# {{ .Title }}
+def {{ .Name }}{{ .Arguments }}
+  uri = URI({{ .Url }})
+  {{ .Prepare }}request = {{ .NewRequest }}
+  {{ .ModifyRequest }}Net::HTTP.start({{ .StartArguments }}) do |http|
+    response = http.request(request)
+    {{ .Authenticate }}{{ .SaveCookies }}response
+  end
+end
+{{ end }}{{ if .HasMain }}
+if __FILE__ == $PROGRAM_NAME
+  [
+{{ range .Functions }}    {{ .Sender }},
+{{ end }}  ].each do |sender|
+    response = sender.call
+    puts "#{response.code} #{response.message}"
+    puts response.body
+  end
+end
+{{ end }}
\ No newline at end of file
@@ -0,0 +1,14 @@
+// Cargo.toml
+// [dependencies]
+{{ .Dependencies }}
+use std::error::Error;
+{{ .AdditionalDeclaration }}
+#[tokio::main]
+async fn main() -> Result<(), Box<dyn Error>> {
+    {{ .Prepare }}let client = reqwest::Client::builder(){{ .ClientBuilder }}
+        .build()?;
+    let {{ .Mut }}res = {{ .Request }}
+    {{ .Authenticate }}{{ .SaveCookie }}println!("{}", res.status());
+    println!("{}", res.text().await?);
+    Ok(())
+}
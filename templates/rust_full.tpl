@@ -0,0 +1,13 @@
+// Cargo.toml
+// [dependencies]
+{{ .Dependencies }}
+use std::error::Error;
+{{ .AdditionalDeclaration }}
+fn main() -> Result<(), Box<dyn Error>> {
+    {{ .Prepare }}let client = reqwest::blocking::Client::builder(){{ .ClientBuilder }}
+        .build()?;
+    let {{ .Mut }}res = {{ .Request }}
+    {{ .Authenticate }}{{ .SaveCookie }}println!("{}", res.status());
+    println!("{}", res.text()?);
+    Ok(())
+}
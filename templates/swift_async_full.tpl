@@ -0,0 +1,16 @@
+// main.swift
+import Foundation
+#if canImport(FoundationNetworking)
+import FoundationNetworking
+#endif
+{{ .AdditionalDeclaration }}{{ .Delegate }}
+{{ .Prepare }}{{ .Var }} request = URLRequest(url: {{ .Url }})
+{{ .ModifyRequest }}
+{{ .PrepareSession }}let (data, response) = try await session.data(for: request)
+let httpResponse = response as! HTTPURLResponse
+print("Status: \(httpResponse.statusCode)")
+for (key, value) in httpResponse.allHeaderFields {
+    print("\(key): \(value)")
+}
+print(String(decoding: data, as: UTF8.self))
+{{ .SaveCookies }}
\ No newline at end of file
@@ -0,0 +1,27 @@
+// main.swift
+import Foundation
+#if canImport(FoundationNetworking)
+import FoundationNetworking
+#endif
+{{ .AdditionalDeclaration }}{{ .Delegate }}
+{{ .Prepare }}{{ .Var }} request = URLRequest(url: {{ .Url }})
+{{ .ModifyRequest }}
+{{ .PrepareSession }}let semaphore = DispatchSemaphore(value: 0)
+let task = session.dataTask(with: request) { data, response, error in
+    defer { semaphore.signal() }
+    if let error = error {
+        print(error)
+        return
+    }
+    let httpResponse = response as! HTTPURLResponse
+    print("Status: \(httpResponse.statusCode)")
+    for (key, value) in httpResponse.allHeaderFields {
+        print("\(key): \(value)")
+    }
+    if let data = data, let text = String(data: data, encoding: .utf8) {
+        print(text)
+    }
+}
+task.resume()
+semaphore.wait()
+{{ .SaveCookies }}
\ No newline at end of file
@@ -17,7 +17,7 @@
 
 function request(file) {
     var xhr = new XMLHttpRequest();{{ .PrepareBody }}
-    xhr.open("{{ .Method }}", {{ .Url }}, true);
+    xhr.open({{ .Method }}, {{ .Url }}, true{{ .Credentials }});
     {{ .PrepareOptions }}
     xhr.onreadystatechange = function(e) {
         if (this.readyState == 4) {
@@ -8,7 +8,7 @@
 <script>
 function request() {
     var xhr = new XMLHttpRequest();{{ .PrepareBody }}
-    xhr.open("{{ .Method }}", {{ .Url }}, true);
+    xhr.open({{ .Method }}, {{ .Url }}, true{{ .Credentials }});
     {{ .PrepareOptions }}
     xhr.onreadystatechange = function(e) {
         if (this.readyState == 4) {
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/shibukawa/curl_as_dsl/common"
+	"github.com/shibukawa/curl_as_dsl/generator"
+	"github.com/shibukawa/curl_as_dsl/testserver/recorder"
+	. "gopkg.in/check.v1"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+/*
+	Language that the differential test runs. Generated code is written into file and command runs it.
+
+	Rust, C#, Kotlin, Swift and Objective-C are not here because their code doesn't run as a single file.
+	Rust needs a cargo project with reqwest, C# needs a dotnet project and Kotlin needs OkHttp from gradle.
+	It takes minutes to build a project for each command. Swift and Objective-C need Foundation of macOS.
+	run_test_*.sh scripts build them. Run testserver with -record option to write the requests they send,
+	and compare them with the requests of curl.
+*/
+type language struct {
+	target  string
+	file    string
+	command []string
+	// command that checks libraries of the target
+	check []string
+}
+
+var languages = []language{
+	{target: "go", file: "main.go", command: []string{"go", "run", "main.go"}},
+	{target: "python", file: "main.py", command: []string{"python3", "main.py"}},
+	{target: "python.requests", file: "main.py", command: []string{"python3", "main.py"}, check: []string{"python3", "-c", "import requests"}},
+	{target: "python.httpx", file: "main.py", command: []string{"python3", "main.py"}, check: []string{"python3", "-c", "import httpx"}},
+	{target: "python.httpx.async", file: "main.py", command: []string{"python3", "main.py"}, check: []string{"python3", "-c", "import httpx"}},
+	{target: "js.node", file: "main.js", command: []string{"node", "main.js"}},
+	{target: "js.fetch.node", file: "main.mjs", command: []string{"node", "main.mjs"}},
+	{target: "java", file: "Main.java", command: []string{"java", "Main.java"}},
+	{target: "java.httpclient", file: "Main.java", command: []string{"java", "Main.java"}},
+	{target: "php", file: "main.php", command: []string{"php", "main.php"}},
+	{target: "ruby", file: "main.rb", command: []string{"ruby", "main.rb"}},
+	{target: "ruby.faraday", file: "main.rb", command: []string{"ruby", "main.rb"}, check: []string{"ruby", "-e", "require 'faraday'"}},
+	{target: "curl", file: "main.sh", command: []string{"bash", "main.sh"}, check: []string{"curl", "--version"}},
+	{target: "httpie", file: "main.sh", command: []string{"bash", "main.sh"}, check: []string{"http", "--version"}},
+	{target: "wget", file: "main.sh", command: []string{"bash", "main.sh"}, check: []string{"wget", "--version"}},
+	{target: "powershell", file: "main.ps1", command: []string{"pwsh", "main.ps1"}},
+}
+
+/*
+	Commands of the differential test. URL is replaced with the URL of the recording server.
+	Commands run in the directory that has test.txt.
+*/
+var corpus = []string{
+	`curl URL`,
+	`curl -d test URL`,
+	`curl -d test -d hello URL`,
+	`curl --data-urlencode 'test% =' URL`,
+	`curl -G -d hello URL`,
+	`curl -G -d hello=world URL`,
+	`curl -G -d hello=world -d foo=bar URL`,
+	`curl -X POST -G -d hello=world URL`,
+	`curl -X POST URL`,
+	`curl -T test.txt URL/upload`,
+	`curl -T test.txt URL/upload/`,
+	`curl -X POST -T test.txt URL/upload`,
+	`curl -F hello=world URL`,
+	`curl -F hello=world -F good=morning URL`,
+	`curl --data-ascii @test.txt URL`,
+	`curl --data-raw @test.txt URL`,
+	`curl --data-binary @test.txt URL`,
+	`curl --data-urlencode @test.txt URL`,
+	`curl --data-urlencode name@test.txt --data-urlencode "it's=a (b)*" URL`,
+	`curl -F file=@test.txt URL`,
+	`curl -F 'file=@test.txt;filename=nameinpost;type=text/plain' URL`,
+	`curl -F 'file=<test.txt' URL`,
+	`curl -H 'Content-Type: application/json' -d '{"name": "bob"}' URL/users`,
+	`curl -H 'X-Quote: he said "hi" \ $HOME #{x}' -d '{"name": "bob \"jr\"", "path": "C:\\temp"}' URL/users`,
+	`curl -u 'us"er:pa\s$s' URL`,
+	`curl -H 'Accept: text/html' -G -d hello=world URL`,
+	`curl -A 'Netscape 4.7' -e http://example.com -d test URL`,
+	`curl -u USER:PASS URL`,
+	`curl --digest -u user:pass URL/auth`,
+	`curl -b a=b -c cookiejar.txt URL/cookie`,
+}
+
+type DiffTest struct {
+	recorder *recorder.Recorder
+	server   *httptest.Server
+}
+
+var _ = Suite(&DiffTest{})
+
+func (s *DiffTest) SetUpSuite(c *C) {
+	if testing.Short() {
+		c.Skip("differential test runs programs of all languages")
+	}
+	if _, err := exec.LookPath("curl"); err != nil {
+		c.Skip("curl is not installed")
+	}
+	s.recorder = recorder.New(recorder.NewServeMux(), nil)
+	s.server = httptest.NewServer(s.recorder)
+}
+
+func (s *DiffTest) TearDownSuite(c *C) {
+	if s.server != nil {
+		s.server.Close()
+	}
+}
+
+/*
+	Run the command in a new directory and return requests that the server received.
+*/
+func (s *DiffTest) record(c *C, fileName, source string, command ...string) ([]recorder.Request, string, error) {
+	dir := c.MkDir()
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "test.txt"), []byte("hello world\n"), 0644), IsNil)
+	if fileName != "" {
+		c.Assert(ioutil.WriteFile(filepath.Join(dir, fileName), []byte(source), 0644), IsNil)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Dir = dir
+	// generated Go code is not in a module
+	cmd.Env = append(os.Environ(), "GOFLAGS=")
+	s.recorder.Reset()
+	output, err := cmd.CombinedOutput()
+	return s.recorder.Requests(), string(output), err
+}
+
+/*
+	Parts of the request that all clients should send in the same way. Default headers like
+	User-Agent and Accept are different in each client, so only headers that the command specifies are compared.
+*/
+func normalize(options *common.CurlOptions, requests []recorder.Request) string {
+	names := map[string]bool{"Host": true}
+	for _, header := range options.Header {
+		names[http.CanonicalHeaderKey(strings.TrimSpace(strings.SplitN(header, ":", 2)[0]))] = true
+	}
+	if options.User != "" {
+		names["Authorization"] = true
+	}
+	if len(options.Cookie) > 0 {
+		names["Cookie"] = true
+	}
+	var result []recorder.Request
+	for _, request := range requests {
+		header := make(map[string][]string)
+		for name, values := range request.Header {
+			if names[name] {
+				header[name] = values
+			}
+		}
+		if request.BodySize > 0 || request.Parts != nil {
+			contentType := request.Header["Content-Type"]
+			if len(contentType) > 0 {
+				// boundary and charset
+				mediaType, _, _ := mime.ParseMediaType(contentType[0])
+				header["Content-Type"] = []string{mediaType}
+			}
+		}
+		if values := header["Authorization"]; len(values) > 0 && strings.HasPrefix(values[0], "Digest ") {
+			// cnonce and response are different in each request
+			params := recorder.DigestParams(values[0])
+			header["Authorization"] = []string{"Digest username=" + params["username"] + " realm=" + params["realm"] + " uri=" + params["uri"]}
+		}
+		if values := header["Cookie"]; len(values) > 0 {
+			cookies := strings.Split(strings.Join(values, "; "), "; ")
+			sort.Strings(cookies)
+			header["Cookie"] = []string{strings.Join(cookies, "; ")}
+		}
+		request.Header = header
+		if request.Parts != nil {
+			request.BodySize = 0
+		}
+		// query is compared after parsing because clients escape it differently
+		request.RawQuery = ""
+		result = append(result, request)
+	}
+	content, _ := json.MarshalIndent(result, "", "  ")
+	return string(content)
+}
+
+func (s *DiffTest) Test_Languages(c *C) {
+	var available []language
+	for _, language := range languages {
+		check := language.check
+		if check == nil {
+			check = language.command[:1]
+		}
+		if _, err := exec.LookPath(check[0]); err != nil {
+			c.Logf("%s is skipped: %s is not installed", language.target, check[0])
+			continue
+		}
+		if len(check) > 1 {
+			if err := exec.Command(check[0], check[1:]...).Run(); err != nil {
+				c.Logf("%s is skipped: %s failed", language.target, strings.Join(check, " "))
+				continue
+			}
+		}
+		available = append(available, language)
+	}
+
+	for _, template := range corpus {
+		command := strings.Replace(template, "URL", s.server.URL, -1)
+		options, err := common.ParseCurlCommand(command)
+		c.Assert(err, IsNil)
+		requests, output, err := s.record(c, "", "", "sh", "-c", command)
+		c.Assert(err, IsNil, Commentf("%s\n%s", command, output))
+		expected := normalize(options, requests)
+
+		for _, language := range available {
+			options, err := common.ParseCurlCommand(command)
+			c.Assert(err, IsNil)
+			result, err := generator.Generate(context.Background(), language.target, options)
+			if !c.Check(err, IsNil, Commentf("%s: %s", language.target, command)) {
+				continue
+			}
+			if len(result.Warnings) > 0 {
+				// the target can't send the same request and the generator tells it to users
+				c.Logf("%s: %s is skipped: %s", language.target, command, strings.Join(result.Warnings, " "))
+				continue
+			}
+			requests, output, err := s.record(c, language.file, result.SourceCode, language.command...)
+			if !c.Check(err, IsNil, Commentf("%s: %s\n%s\n%s", language.target, command, result.SourceCode, output)) {
+				continue
+			}
+			actual := normalize(options, requests)
+			c.Check(actual, Equals, expected, Commentf("%s: %s\n%s", language.target, command, result.SourceCode))
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package recorder
+
+import (
+	"crypto/md5"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	DigestRealm    = "testrealm@host.com"
+	DigestNonce    = "dcd98b7102dd2f0e8b11d0f600bfb0c093"
+	DigestOpaque   = "5ccc069c403ebaf9f0171e9517f40e41"
+	DigestUser     = "user"
+	DigestPassword = "pass"
+)
+
+var digestParamPattern = regexp.MustCompile(`(\w+)=(?:"([^"]*)"|([^,\s]*))`)
+
+func md5Hex(text string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(text)))
+}
+
+/*
+	Parameters of Digest authorization header like username and uri.
+*/
+func DigestParams(authorization string) map[string]string {
+	params := make(map[string]string)
+	for _, match := range digestParamPattern.FindAllStringSubmatch(authorization, -1) {
+		params[match[1]] = match[2] + match[3]
+	}
+	return params
+}
+
+/*
+	Check Digest authorization header sent by generated code (curl --digest -u user:pass)
+*/
+func verifyDigest(r *http.Request, authorization string) bool {
+	params := DigestParams(authorization)
+	if params["username"] != DigestUser || params["realm"] != DigestRealm || params["nonce"] != DigestNonce || params["opaque"] != DigestOpaque {
+		return false
+	}
+	ha1 := md5Hex(strings.Join([]string{DigestUser, DigestRealm, DigestPassword}, ":"))
+	ha2 := md5Hex(r.Method + ":" + params["uri"])
+	var expected string
+	if params["qop"] != "" {
+		expected = md5Hex(strings.Join([]string{ha1, DigestNonce, params["nc"], params["cnonce"], params["qop"], ha2}, ":"))
+	} else {
+		expected = md5Hex(strings.Join([]string{ha1, DigestNonce, ha2}, ":"))
+	}
+	return params["response"] == expected
+}
+
+func helloHandler(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "hello\n")
+}
+
+func authHandler(w http.ResponseWriter, r *http.Request) {
+	authorization := r.Header.Get("Authorization")
+	if authorization == "" || (strings.HasPrefix(authorization, "Digest ") && !verifyDigest(r, authorization)) {
+		w.Header().Add("WWW-Authenticate", fmt.Sprintf(`Digest realm="%s", qop="auth", nonce="%s", opaque="%s"`, DigestRealm, DigestNonce, DigestOpaque))
+		w.WriteHeader(401)
+	} else {
+		fmt.Fprintf(w, "hello\n")
+	}
+}
+
+func cookieHandler(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{Name: "session", Value: "testsession", Path: "/"})
+	fmt.Fprintf(w, "hello\n")
+}
+
+/*
+	Handlers of test server:
+
+	/auth     requires Digest authentication of user:pass (or any other Authorization header)
+	/cookie   sets "session" cookie
+	/         returns "hello"
+*/
+func NewServeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth", authHandler)
+	mux.HandleFunc("/cookie", cookieHandler)
+	mux.HandleFunc("/", helloHandler)
+	return mux
+}
@@ -0,0 +1,188 @@
+package recorder
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sync"
+	"unicode/utf8"
+)
+
+// bodies longer than it are recorded only by their hashes
+const maxBodyText = 4096
+
+/*
+	Request that the server received. Multipart bodies are recorded for each part
+	because boundaries are different in each client.
+*/
+type Request struct {
+	Method   string              `json:"method"`
+	Path     string              `json:"path"`
+	RawQuery string              `json:"raw_query,omitempty"`
+	Query    url.Values          `json:"query,omitempty"`
+	Header   map[string][]string `json:"header"`
+	Parts    []Part              `json:"parts,omitempty"`
+	BodyHash string              `json:"body_hash,omitempty"`
+	BodySize int                 `json:"body_size"`
+	Body     string              `json:"body,omitempty"`
+}
+
+type Part struct {
+	Name        string `json:"name"`
+	FileName    string `json:"file_name,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Hash        string `json:"hash"`
+	Size        int    `json:"size"`
+	Body        string `json:"body,omitempty"`
+}
+
+func hash(content []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(content))
+}
+
+func text(content []byte) string {
+	if len(content) > maxBodyText || !utf8.Valid(content) {
+		return ""
+	}
+	return string(content)
+}
+
+/*
+	Record the request and its body. The body is read already because it was consumed by the handler.
+*/
+func NewRequest(r *http.Request, body []byte) (*Request, error) {
+	header := make(map[string][]string)
+	for key, values := range r.Header {
+		header[key] = values
+	}
+	header["Host"] = []string{r.Host}
+	request := &Request{
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		RawQuery: r.URL.RawQuery,
+		Header:   header,
+	}
+	if r.URL.RawQuery != "" {
+		query, err := url.ParseQuery(r.URL.RawQuery)
+		if err != nil {
+			return nil, err
+		}
+		request.Query = query
+	}
+	if len(body) > 0 {
+		request.BodyHash = hash(body)
+		request.BodySize = len(body)
+		request.Body = text(body)
+	}
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return request, nil
+	}
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	request.Parts = []Part{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		request.Parts = append(request.Parts, Part{
+			Name:        part.FormName(),
+			FileName:    part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+			Hash:        hash(content),
+			Size:        len(content),
+			Body:        text(content),
+		})
+	}
+	// boundary is different in each client
+	request.Body = ""
+	request.BodyHash = ""
+	return request, nil
+}
+
+/*
+	http.Handler that records requests and passes them to the handler.
+	GET /_requests returns recorded requests as JSON and DELETE /_requests clears them.
+*/
+type Recorder struct {
+	handler  http.Handler
+	output   io.Writer
+	lock     sync.Mutex
+	requests []Request
+}
+
+/*
+	Create a recorder. Recorded requests are written to output as JSON lines if it is not nil.
+*/
+func New(handler http.Handler, output io.Writer) *Recorder {
+	return &Recorder{handler: handler, output: output}
+}
+
+func (self *Recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/_requests" {
+		self.serveRequests(w, r)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	request, err := NewRequest(r, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	self.lock.Lock()
+	self.requests = append(self.requests, *request)
+	if self.output != nil {
+		line, _ := json.Marshal(request)
+		self.output.Write(append(line, '\n'))
+	}
+	self.lock.Unlock()
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	self.handler.ServeHTTP(w, r)
+}
+
+func (self *Recorder) serveRequests(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(self.Requests())
+	case "DELETE":
+		self.Reset()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+/*
+	Requests received after the last Reset().
+*/
+func (self *Recorder) Requests() []Request {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	return append([]Request{}, self.requests...)
+}
+
+func (self *Recorder) Reset() {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.requests = nil
+}
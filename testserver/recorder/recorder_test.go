@@ -0,0 +1,87 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	. "gopkg.in/check.v1"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+type RecorderTest struct{}
+
+var _ = Suite(&RecorderTest{})
+
+func (s *RecorderTest) Test_Record(c *C) {
+	var output bytes.Buffer
+	recorder := New(NewServeMux(), &output)
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/path?a=1&b=x+y", "text/plain", strings.NewReader("hello"))
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	requests := recorder.Requests()
+	c.Assert(requests, HasLen, 1)
+	c.Check(requests[0].Method, Equals, "POST")
+	c.Check(requests[0].Path, Equals, "/path")
+	c.Check(requests[0].Query["b"], DeepEquals, []string{"x y"})
+	c.Check(requests[0].Header["Content-Type"], DeepEquals, []string{"text/plain"})
+	c.Check(requests[0].Header["Host"], DeepEquals, []string{strings.TrimPrefix(server.URL, "http://")})
+	c.Check(requests[0].Body, Equals, "hello")
+	c.Check(requests[0].BodyHash, Equals, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")
+
+	var recorded Request
+	c.Assert(json.Unmarshal(output.Bytes(), &recorded), IsNil)
+	c.Check(recorded.Path, Equals, "/path")
+}
+
+func (s *RecorderTest) Test_Record_Multipart(c *C) {
+	recorder := New(NewServeMux(), nil)
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("name", "bob")
+	part, _ := writer.CreateFormFile("file", "test.txt")
+	part.Write([]byte("content"))
+	writer.Close()
+	resp, err := http.Post(server.URL, writer.FormDataContentType(), &body)
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	requests := recorder.Requests()
+	c.Assert(requests, HasLen, 1)
+	c.Check(requests[0].BodyHash, Equals, "")
+	c.Assert(requests[0].Parts, HasLen, 2)
+	c.Check(requests[0].Parts[0], DeepEquals, Part{Name: "name", Hash: hash([]byte("bob")), Size: 3, Body: "bob"})
+	c.Check(requests[0].Parts[1].FileName, Equals, "test.txt")
+	c.Check(requests[0].Parts[1].ContentType, Equals, "application/octet-stream")
+	c.Check(requests[0].Parts[1].Body, Equals, "content")
+}
+
+func (s *RecorderTest) Test_Requests(c *C) {
+	recorder := New(NewServeMux(), nil)
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/cookie")
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	resp, err = http.Get(server.URL + "/_requests")
+	c.Assert(err, IsNil)
+	var requests []Request
+	c.Assert(json.NewDecoder(resp.Body).Decode(&requests), IsNil)
+	resp.Body.Close()
+	c.Assert(requests, HasLen, 1)
+	c.Check(requests[0].Path, Equals, "/cookie")
+
+	request, _ := http.NewRequest("DELETE", server.URL+"/_requests", nil)
+	resp, err = http.DefaultClient.Do(request)
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	c.Check(resp.StatusCode, Equals, http.StatusNoContent)
+	c.Check(recorder.Requests(), HasLen, 0)
+}
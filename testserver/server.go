@@ -1,28 +1,17 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"github.com/shibukawa/curl_as_dsl/testserver/recorder"
 	"golang.org/x/net/http2"
 	"io"
-	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
 	"sync"
 )
 
-func handler(w http.ResponseWriter, r *http.Request) {
-	log.Println(r.URL.String(), r.Method)
-	log.Println("Method:", r.Proto)
-	log.Println("Header", r.Header)
-	fmt.Println("--body--")
-	defer r.Body.Close()
-	byte, _ := ioutil.ReadAll(r.Body)
-	log.Println(string(byte))
-
-	fmt.Fprintf(w, "hello\n")
-}
-
 func jsHandler(w http.ResponseWriter, r *http.Request) {
 	log.Println(r.URL.String(), r.Method)
 	log.Println("Method:", r.Proto)
@@ -48,32 +37,28 @@ func jsHandler(w http.ResponseWriter, r *http.Request) {
 	io.Copy(w, in)
 }
 
-func authHandler(w http.ResponseWriter, r *http.Request) {
-	log.Println(r.URL.String(), r.Method)
-	log.Println("Method:", r.Proto)
-	log.Println("Header", r.Header)
-	fmt.Println("--body--")
-	defer r.Body.Close()
-	byte, _ := ioutil.ReadAll(r.Body)
-	log.Println(string(byte))
-
-	if r.Header.Get("Authorization") == "" {
-		w.Header().Add("WWW-Authenticate", `Digest realm="testrealm@host.com", nonce="dcd98b7102dd2f0e8b11d0f600bfb0c093", opaque="5ccc069c403ebaf9f0171e9517f40e41"`)
-		w.WriteHeader(401)
-	} else {
-		fmt.Fprintf(w, "hello\n")
+func main() {
+	// received requests are printed as JSON lines, and they are written to the file too
+	record := flag.String("record", "", "Append received requests to the file as JSON lines")
+	flag.Parse()
+	var output io.Writer = os.Stdout
+	if *record != "" {
+		file, err := os.OpenFile(*record, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		defer file.Close()
+		output = io.MultiWriter(os.Stdout, file)
 	}
-}
 
-func main() {
 	var httpServer http.Server
 	var httpsServer http.Server
 	http2.VerboseLogs = true
 	http2.ConfigureServer(&httpsServer, nil)
 
-	http.HandleFunc("/auth", authHandler)
-	http.HandleFunc("/", handler)
-	http.HandleFunc("/js", jsHandler)
+	mux := recorder.NewServeMux()
+	mux.HandleFunc("/js", jsHandler)
+	http.Handle("/", recorder.New(mux, output))
 
 	var wg sync.WaitGroup
 	wg.Add(2)
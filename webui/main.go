@@ -1,56 +1,39 @@
 package main
 
 import (
+	"context"
 	"github.com/gopherjs/gopherjs/js"
-	"github.com/jessevdk/go-flags"
 	"github.com/shibukawa/curl_as_dsl/common"
 	"github.com/shibukawa/curl_as_dsl/generator"
-	"github.com/shibukawa/shell"
 	"honnef.co/go/js/console"
 	"html"
 	"strings"
 )
 
-type GlobalOptions struct{}
-
-func GenerateCode(target, options string) (string, string) {
-	var globalOptions GlobalOptions
-	var curlOptions common.CurlOptions
-	curlOptions.Init()
-
-	parser := flags.NewParser(&globalOptions, flags.Default)
-	curlCommand, err := parser.AddCommand("curl",
-		"Generate code from curl options",
-		"This command has almost same options of curl and generate code",
-		&curlOptions)
-
-	if !strings.HasPrefix(options, "curl ") {
-		options = "curl " + options
-	}
-	args := shell.Parse(options)
-
-	urls, err := parser.ParseArgs(args)
+/*
+	redact reads credentials from environment variables instead of writing them in the code.
+*/
+func GenerateCode(target, options string, redact bool) (string, string) {
+	curlOptions, err := common.ParseCurlCommand(options)
 	if err != nil {
 		console.Log(err)
 		return "", err.Error()
 	}
-	if len(urls) > 1 {
-		return "", "It accept only one url. Remained urls are ignored:" + strings.Join(urls, ", ")
+	if len(curlOptions.RemainingUrls) > 0 {
+		return "", "It accept only one url. Remained urls are ignored:" + strings.Join(curlOptions.RemainingUrls, ", ")
 	}
-	if parser.Active == curlCommand {
-		// --url option has higher priority than params.
-		if curlOptions.Url == "" {
-			if len(urls) > 0 {
-				curlOptions.Url = urls[0]
-			} else {
-				console.Error("Both --url option and url parameters are missing")
-				return "", "Both --url option and url parameters are missing"
-			}
-		}
-		sourceCode, _, _, _ := generator.GenerateCode(target, &curlOptions)
-		return html.EscapeString(sourceCode), ""
+	if curlOptions.Url == "" {
+		console.Error("Both --url option and url parameters are missing")
+		return "", "Both --url option and url parameters are missing"
+	}
+	if redact {
+		curlOptions.Redact()
+	}
+	result, err := generator.Generate(context.Background(), target, curlOptions)
+	if err != nil {
+		return "", err.Error()
 	}
-	return "", ""
+	return html.EscapeString(result.SourceCode), ""
 }
 
 func main() {